@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"github.com/PizzaHomicide/hisame/internal/config"
 	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/proxy"
 	"github.com/PizzaHomicide/hisame/internal/ui/tui"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
 	"github.com/PizzaHomicide/hisame/internal/version"
 	"os"
 )
@@ -35,6 +37,16 @@ func main() {
 
 	log.Info("Starting up Hisame", "version", version.GetVersion(), "build_time", version.GetBuildTime())
 
+	if err := proxy.Configure(cfg.Network.Proxy); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "failed to configure proxy: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := keybindings.Apply(cfg.Keybindings); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "invalid keybindings in config: %v\n", err)
+		os.Exit(1)
+	}
+
 	if err := tui.Run(cfg); err != nil {
 		log.Error("Unhandled error while running TUI", "error", err)
 		os.Exit(1)
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"net/url"
 	"os/exec"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/PizzaHomicide/hisame/internal/log"
@@ -72,8 +74,9 @@ func (auth *Auth) StartCallbackServer() error {
 	return nil
 }
 
-// DoAuth performs the entire authentication flow and returns the result
-func (auth *Auth) DoAuth() Result {
+// DoAuth performs the entire authentication flow and returns the result.  Cancelling ctx gives up on the login and
+// shuts down the callback server.
+func (auth *Auth) DoAuth(ctx context.Context) Result {
 	// Start the callback server
 	if err := auth.StartCallbackServer(); err != nil {
 		return Result{Error: err}
@@ -87,7 +90,7 @@ func (auth *Auth) DoAuth() Result {
 	}
 
 	// Create a context with timeout for token waiting
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
 	defer cancel()
 
 	// Wait for the token
@@ -145,6 +148,83 @@ func generateAuthURL() *url.URL {
 	return loginURL
 }
 
+// ManualLoginInstructions explains how to log in by pasting a token, when the browser can't reach Hisame's callback
+// server, e.g. because Hisame is running over SSH
+const ManualLoginInstructions = "Open the URL in any browser and log in.  AniList then redirects to a localhost page " +
+	"that won't load.  Copy that page's full address, or just the access_token in it, and paste it here."
+
+// ParseManualToken extracts the access token from text pasted during a manual login.  The text can be the address of
+// the callback page AniList redirected to, which has the token in its fragment, or the token itself.
+func ParseManualToken(input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", errors.New("no token entered")
+	}
+
+	if strings.Contains(input, "access_token=") {
+		fragment := input
+		if i := strings.IndexAny(input, "#?"); i >= 0 {
+			fragment = input[i+1:]
+		}
+		values, err := url.ParseQuery(fragment)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the pasted address: %w", err)
+		}
+		input = values.Get("access_token")
+		if input == "" {
+			return "", errors.New("the pasted address has no access token")
+		}
+	}
+
+	if strings.ContainsAny(input, " \t/:") {
+		return "", errors.New("that doesn't look like an access token or the address of the page AniList redirected to")
+	}
+	return input, nil
+}
+
+// TokenExpiry reads when an AniList token expires.  AniList tokens are JWTs, which carry their expiry time in the exp
+// claim.  ok is false if the token isn't a JWT or doesn't say when it expires.
+func TokenExpiry(token string) (expiry time.Time, ok bool) {
+	claims, ok := tokenClaims(token)
+	if !ok || claims.Exp <= 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(claims.Exp), 0), true
+}
+
+// TokenIssuedAt reads when an AniList token was issued, from its iat claim.  ok is false if the token isn't a JWT or
+// doesn't say when it was issued.
+func TokenIssuedAt(token string) (issued time.Time, ok bool) {
+	claims, ok := tokenClaims(token)
+	if !ok || claims.Iat <= 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(claims.Iat), 0), true
+}
+
+// jwtClaims are the claims of an AniList token Hisame makes use of.  AniList writes the times as floats.
+type jwtClaims struct {
+	Exp float64 `json:"exp"`
+	Iat float64 `json:"iat"`
+}
+
+// tokenClaims decodes the payload of a JWT, without checking its signature
+func tokenClaims(token string) (jwtClaims, bool) {
+	var claims jwtClaims
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return claims, false
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return claims, false
+	}
+	return claims, true
+}
+
 // handleToken creates a handler for the token endpoint
 func (auth *Auth) handleToken() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,452 @@
+// Package cast sends media to Chromecasts and other Cast devices on the local network.  Devices are found over mDNS
+// and controlled with the Cast V2 protocol, playing streams in the Default Media Receiver app every device has.
+package cast
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+const (
+	// defaultMediaReceiver is the app ID of the receiver built in to Cast devices, which plays media from a URL
+	defaultMediaReceiver = "CC1AD845"
+
+	senderID   = "sender-0"
+	receiverID = "receiver-0"
+
+	namespaceConnection = "urn:x-cast:com.google.cast.tp.connection"
+	namespaceHeartbeat  = "urn:x-cast:com.google.cast.tp.heartbeat"
+	namespaceReceiver   = "urn:x-cast:com.google.cast.receiver"
+	namespaceMedia      = "urn:x-cast:com.google.cast.media"
+
+	// heartbeatInterval is how often the device is pinged, so it keeps the connection open
+	heartbeatInterval = 5 * time.Second
+	// readTimeout is how long the device can go without sending anything, pongs included, before the connection is
+	// taken to be lost
+	readTimeout = 3 * heartbeatInterval
+	// requestTimeout is how long the device has to answer a request, including launching the receiver and loading media
+	requestTimeout = 20 * time.Second
+)
+
+// Media player states reported in MediaStatus
+const (
+	StateIdle      = "IDLE"
+	StatePlaying   = "PLAYING"
+	StatePaused    = "PAUSED"
+	StateBuffering = "BUFFERING"
+)
+
+// Media is what to play on the device
+type Media struct {
+	URL         string
+	ContentType string  // e.g. "video/mp4" or "application/x-mpegurl"
+	Title       string  // Shown on the TV while the media plays
+	StartTime   float64 // Seconds into the media to start at
+}
+
+// MediaStatus is the state of the media playing on the device
+type MediaStatus struct {
+	PlayerState string  // One of the State constants, empty if nothing is loaded
+	IdleReason  string  // Why the player went idle, e.g. "FINISHED", "CANCELLED" or "ERROR"
+	CurrentTime float64 // Seconds into the media
+	Duration    float64 // Length of the media in seconds, 0 until it is known
+}
+
+// Client is a connection to a Cast device
+type Client struct {
+	conn net.Conn
+
+	writeLock sync.Mutex
+
+	lock           sync.Mutex
+	nextRequestID  int
+	pending        map[int]chan json.RawMessage // Requests waiting for an answer, by request ID
+	transportID    string                       // Destination of the media receiver, once launched
+	sessionID      string                       // Session of the media receiver, once launched
+	mediaSessionID int                          // Media loaded in the receiver, once loaded
+	receiverClosed bool                         // Whether the media receiver has been closed, e.g. from the TV's remote
+
+	done    chan struct{}
+	doneErr error
+}
+
+// Dial connects to the device
+func Dial(ctx context.Context, device Device) (*Client, error) {
+	dialer := &tls.Dialer{
+		// Cast devices present certificates signed by Google's device CA rather than one for their address
+		Config: &tls.Config{InsecureSkipVerify: true},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", device.Address())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", device.Name, err)
+	}
+	log.Info("Connected to Cast device", "name", device.Name, "address", device.Address())
+
+	client, err := newClient(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to connect to %s: %w", device.Name, err)
+	}
+	return client, nil
+}
+
+// newClient opens a virtual connection to the device's receiver over conn, and starts reading from it
+func newClient(conn net.Conn) (*Client, error) {
+	c := &Client{
+		conn:    conn,
+		pending: make(map[int]chan json.RawMessage),
+		done:    make(chan struct{}),
+	}
+	if err := c.send(receiverID, namespaceConnection, map[string]any{"type": "CONNECT"}); err != nil {
+		return nil, err
+	}
+	go c.readLoop()
+	go c.heartbeat()
+	return c, nil
+}
+
+// Load launches the media receiver on the device, if it isn't already running, and plays the media in it
+func (c *Client) Load(ctx context.Context, media Media) error {
+	if err := c.launch(ctx); err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	transportID := c.transportID
+	c.lock.Unlock()
+
+	reply, err := c.request(ctx, transportID, namespaceMedia, map[string]any{
+		"type":        "LOAD",
+		"autoplay":    true,
+		"currentTime": media.StartTime,
+		"media": map[string]any{
+			"contentId":   media.URL,
+			"contentType": media.ContentType,
+			"streamType":  "BUFFERED",
+			"metadata": map[string]any{
+				"metadataType": 0,
+				"title":        media.Title,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load media: %w", err)
+	}
+
+	var status mediaStatusPayload
+	if err := json.Unmarshal(reply, &status); err != nil {
+		return fmt.Errorf("failed to decode load answer: %w", err)
+	}
+	if status.Type != "MEDIA_STATUS" {
+		return fmt.Errorf("device could not load the media: %s", status.Type)
+	}
+	c.updateMediaSession(status)
+	return nil
+}
+
+// launch starts the media receiver and opens a virtual connection to it
+func (c *Client) launch(ctx context.Context) error {
+	reply, err := c.request(ctx, receiverID, namespaceReceiver, map[string]any{
+		"type":  "LAUNCH",
+		"appId": defaultMediaReceiver,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to launch the media receiver: %w", err)
+	}
+
+	var status struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+		Status struct {
+			Applications []struct {
+				AppID       string `json:"appId"`
+				SessionID   string `json:"sessionId"`
+				TransportID string `json:"transportId"`
+			} `json:"applications"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(reply, &status); err != nil {
+		return fmt.Errorf("failed to decode launch answer: %w", err)
+	}
+	if status.Type == "LAUNCH_ERROR" {
+		return fmt.Errorf("device could not launch the media receiver: %s", status.Reason)
+	}
+
+	for _, app := range status.Status.Applications {
+		if app.AppID != defaultMediaReceiver {
+			continue
+		}
+		c.lock.Lock()
+		c.transportID = app.TransportID
+		c.sessionID = app.SessionID
+		c.lock.Unlock()
+		return c.send(app.TransportID, namespaceConnection, map[string]any{"type": "CONNECT"})
+	}
+	return errors.New("device did not start the media receiver")
+}
+
+// Status asks the device for the state of the media playing on it.  If the media receiver has been closed, the status
+// is idle.
+func (c *Client) Status(ctx context.Context) (MediaStatus, error) {
+	c.lock.Lock()
+	transportID, receiverClosed := c.transportID, c.receiverClosed
+	c.lock.Unlock()
+	if transportID == "" {
+		return MediaStatus{}, errors.New("no media has been loaded")
+	}
+	if receiverClosed {
+		return MediaStatus{PlayerState: StateIdle}, nil
+	}
+
+	reply, err := c.request(ctx, transportID, namespaceMedia, map[string]any{"type": "GET_STATUS"})
+	if err != nil {
+		return MediaStatus{}, err
+	}
+	var status mediaStatusPayload
+	if err := json.Unmarshal(reply, &status); err != nil {
+		return MediaStatus{}, fmt.Errorf("failed to decode media status: %w", err)
+	}
+	c.updateMediaSession(status)
+
+	if len(status.Status) == 0 {
+		return MediaStatus{PlayerState: StateIdle}, nil
+	}
+	current := status.Status[0]
+	return MediaStatus{
+		PlayerState: current.PlayerState,
+		IdleReason:  current.IdleReason,
+		CurrentTime: current.CurrentTime,
+		Duration:    current.Media.Duration,
+	}, nil
+}
+
+// Pause pauses the media
+func (c *Client) Pause() error {
+	return c.mediaCommand("PAUSE")
+}
+
+// Play resumes the paused media
+func (c *Client) Play() error {
+	return c.mediaCommand("PLAY")
+}
+
+// Stop closes the media receiver, which returns the device to its idle screen
+func (c *Client) Stop() error {
+	c.lock.Lock()
+	sessionID := c.sessionID
+	c.lock.Unlock()
+	if sessionID == "" {
+		return nil
+	}
+	return c.send(receiverID, namespaceReceiver, map[string]any{
+		"type":      "STOP",
+		"sessionId": sessionID,
+		"requestId": c.newRequestID(),
+	})
+}
+
+// Done is closed once the connection to the device has been lost or closed
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Close closes the connection to the device.  The media keeps playing.
+func (c *Client) Close() error {
+	c.lock.Lock()
+	transportID := c.transportID
+	c.lock.Unlock()
+	if transportID != "" {
+		// Best effort, as the device cleans up the virtual connection when the connection closes anyway
+		_ = c.send(transportID, namespaceConnection, map[string]any{"type": "CLOSE"})
+	}
+	return c.conn.Close()
+}
+
+// mediaCommand sends a command that acts on the loaded media
+func (c *Client) mediaCommand(commandType string) error {
+	c.lock.Lock()
+	transportID, mediaSessionID := c.transportID, c.mediaSessionID
+	c.lock.Unlock()
+	if transportID == "" {
+		return errors.New("no media has been loaded")
+	}
+	return c.send(transportID, namespaceMedia, map[string]any{
+		"type":           commandType,
+		"mediaSessionId": mediaSessionID,
+		"requestId":      c.newRequestID(),
+	})
+}
+
+// mediaStatusPayload is the answer to media requests.  Its type is MEDIA_STATUS on success, or names the error.
+type mediaStatusPayload struct {
+	Type   string `json:"type"`
+	Status []struct {
+		MediaSessionID int     `json:"mediaSessionId"`
+		PlayerState    string  `json:"playerState"`
+		IdleReason     string  `json:"idleReason"`
+		CurrentTime    float64 `json:"currentTime"`
+		Media          struct {
+			Duration float64 `json:"duration"`
+		} `json:"media"`
+	} `json:"status"`
+}
+
+// updateMediaSession remembers the session of the loaded media, which the media commands act on
+func (c *Client) updateMediaSession(status mediaStatusPayload) {
+	if len(status.Status) == 0 || status.Status[0].MediaSessionID == 0 {
+		return
+	}
+	c.lock.Lock()
+	c.mediaSessionID = status.Status[0].MediaSessionID
+	c.lock.Unlock()
+}
+
+// request sends a request and waits for the device to answer it
+func (c *Client) request(ctx context.Context, destination, namespace string, payload map[string]any) (json.RawMessage, error) {
+	requestID := c.newRequestID()
+	payload["requestId"] = requestID
+
+	reply := make(chan json.RawMessage, 1)
+	c.lock.Lock()
+	c.pending[requestID] = reply
+	c.lock.Unlock()
+	defer func() {
+		c.lock.Lock()
+		delete(c.pending, requestID)
+		c.lock.Unlock()
+	}()
+
+	if err := c.send(destination, namespace, payload); err != nil {
+		return nil, err
+	}
+
+	timeout := time.NewTimer(requestTimeout)
+	defer timeout.Stop()
+	select {
+	case answer := <-reply:
+		return answer, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-timeout.C:
+		return nil, errors.New("device did not answer")
+	case <-c.done:
+		return nil, c.err()
+	}
+}
+
+// send sends a JSON payload to the destination on the device
+func (c *Client) send(destination, namespace string, payload map[string]any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	return writeMessage(c.conn, message{
+		SourceID:      senderID,
+		DestinationID: destination,
+		Namespace:     namespace,
+		Payload:       string(data),
+	})
+}
+
+// newRequestID returns the ID for the next request, which the device echoes in its answer
+func (c *Client) newRequestID() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.nextRequestID++
+	return c.nextRequestID
+}
+
+// readLoop reads messages from the device until the connection is lost, answering pings and handing answers to the
+// requests waiting on them
+func (c *Client) readLoop() {
+	var err error
+	defer func() {
+		c.lock.Lock()
+		c.doneErr = err
+		c.lock.Unlock()
+		close(c.done)
+	}()
+
+	for {
+		c.conn.SetReadDeadline(time.Now().Add(readTimeout))
+		var msg message
+		msg, err = readMessage(c.conn)
+		if err != nil {
+			log.Debug("Connection to Cast device ended", "error", err)
+			return
+		}
+
+		var header struct {
+			Type      string `json:"type"`
+			RequestID int    `json:"requestId"`
+		}
+		if err := json.Unmarshal([]byte(msg.Payload), &header); err != nil {
+			log.Debug("Ignoring malformed Cast message", "namespace", msg.Namespace, "error", err)
+			continue
+		}
+		log.Trace("Cast message received", "namespace", msg.Namespace, "type", header.Type,
+			"request_id", header.RequestID)
+
+		switch {
+		case msg.Namespace == namespaceHeartbeat && header.Type == "PING":
+			if err := c.send(msg.SourceID, namespaceHeartbeat, map[string]any{"type": "PONG"}); err != nil {
+				log.Debug("Failed to answer Cast device ping", "error", err)
+			}
+		case msg.Namespace == namespaceConnection && header.Type == "CLOSE":
+			log.Debug("Cast device closed the virtual connection", "source", msg.SourceID)
+			c.lock.Lock()
+			if msg.SourceID == c.transportID {
+				c.receiverClosed = true
+			}
+			c.lock.Unlock()
+		case header.RequestID != 0:
+			c.lock.Lock()
+			reply, ok := c.pending[header.RequestID]
+			c.lock.Unlock()
+			if ok {
+				// Only the first answer is waited on
+				select {
+				case reply <- json.RawMessage(msg.Payload):
+				default:
+				}
+			}
+		}
+	}
+}
+
+// heartbeat pings the device until the connection ends, as the device closes connections that go quiet
+func (c *Client) heartbeat() {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			if err := c.send(receiverID, namespaceHeartbeat, map[string]any{"type": "PING"}); err != nil {
+				log.Debug("Failed to ping Cast device", "error", err)
+			}
+		}
+	}
+}
+
+// err returns why the connection ended
+func (c *Client) err() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.doneErr == nil {
+		return errors.New("connection to the device was closed")
+	}
+	return fmt.Errorf("connection to the device was lost: %w", c.doneErr)
+}
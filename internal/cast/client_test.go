@@ -0,0 +1,151 @@
+package cast
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDevice answers a client as a Cast device running the media receiver would
+func fakeDevice(t *testing.T, conn net.Conn, playerState string) {
+	t.Helper()
+	reply := func(to message, payload string) {
+		require.NoError(t, writeMessage(conn, message{
+			SourceID:      to.DestinationID,
+			DestinationID: to.SourceID,
+			Namespace:     to.Namespace,
+			Payload:       payload,
+		}))
+	}
+
+	for {
+		msg, err := readMessage(conn)
+		if err != nil {
+			return
+		}
+		var request struct {
+			Type      string `json:"type"`
+			RequestID int    `json:"requestId"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(msg.Payload), &request))
+
+		switch request.Type {
+		case "LAUNCH":
+			reply(msg, `{"type":"RECEIVER_STATUS","requestId":`+jsonInt(request.RequestID)+`,"status":{"applications":[`+
+				`{"appId":"CC1AD845","sessionId":"session-1","transportId":"transport-1"}]}}`)
+		case "LOAD", "GET_STATUS":
+			assert.Equal(t, "transport-1", msg.DestinationID)
+			reply(msg, `{"type":"MEDIA_STATUS","requestId":`+jsonInt(request.RequestID)+`,"status":[`+
+				`{"mediaSessionId":7,"playerState":"`+playerState+`","currentTime":120.5,"media":{"duration":1440}}]}`)
+		}
+	}
+}
+
+func jsonInt(n int) string {
+	b, _ := json.Marshal(n)
+	return string(b)
+}
+
+func TestClientLoadAndStatus(t *testing.T) {
+	clientConn, deviceConn := net.Pipe()
+	defer deviceConn.Close()
+	go fakeDevice(t, deviceConn, StatePlaying)
+
+	client, err := newClient(clientConn)
+	require.NoError(t, err)
+	defer client.Close()
+
+	err = client.Load(context.Background(), Media{URL: "https://example.com/ep.mp4", ContentType: "video/mp4"})
+	require.NoError(t, err)
+	assert.Equal(t, 7, client.mediaSessionID)
+
+	status, err := client.Status(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, MediaStatus{PlayerState: StatePlaying, CurrentTime: 120.5, Duration: 1440}, status)
+}
+
+func TestClientLoadFailed(t *testing.T) {
+	clientConn, deviceConn := net.Pipe()
+	defer deviceConn.Close()
+	go func() {
+		for {
+			msg, err := readMessage(deviceConn)
+			if err != nil {
+				return
+			}
+			var request struct {
+				Type      string `json:"type"`
+				RequestID int    `json:"requestId"`
+			}
+			_ = json.Unmarshal([]byte(msg.Payload), &request)
+			answer := `{"type":"LOAD_FAILED","requestId":` + jsonInt(request.RequestID) + `}`
+			if request.Type == "LAUNCH" {
+				answer = `{"type":"RECEIVER_STATUS","requestId":` + jsonInt(request.RequestID) + `,"status":{"applications":[` +
+					`{"appId":"CC1AD845","sessionId":"session-1","transportId":"transport-1"}]}}`
+			}
+			if request.Type != "CONNECT" {
+				_ = writeMessage(deviceConn, message{SourceID: msg.DestinationID, DestinationID: msg.SourceID,
+					Namespace: msg.Namespace, Payload: answer})
+			}
+		}
+	}()
+
+	client, err := newClient(clientConn)
+	require.NoError(t, err)
+	defer client.Close()
+
+	err = client.Load(context.Background(), Media{URL: "https://example.com/ep.mp4", ContentType: "video/mp4"})
+	assert.ErrorContains(t, err, "LOAD_FAILED")
+}
+
+func TestClientAnswersPings(t *testing.T) {
+	clientConn, deviceConn := net.Pipe()
+	defer deviceConn.Close()
+
+	// The pipe is unbuffered, so the connect message has to be read as it is sent
+	connected := make(chan message, 1)
+	go func() {
+		connect, _ := readMessage(deviceConn)
+		connected <- connect
+	}()
+
+	client, err := newClient(clientConn)
+	require.NoError(t, err)
+	defer client.Close()
+	assert.Equal(t, namespaceConnection, (<-connected).Namespace)
+
+	require.NoError(t, writeMessage(deviceConn, message{
+		SourceID:      receiverID,
+		DestinationID: senderID,
+		Namespace:     namespaceHeartbeat,
+		Payload:       `{"type":"PING"}`,
+	}))
+	pong, err := readMessage(deviceConn)
+	require.NoError(t, err)
+	assert.Equal(t, namespaceHeartbeat, pong.Namespace)
+	assert.Equal(t, receiverID, pong.DestinationID)
+	assert.JSONEq(t, `{"type":"PONG"}`, pong.Payload)
+}
+
+func TestClientStatusAfterReceiverClosed(t *testing.T) {
+	clientConn, deviceConn := net.Pipe()
+	defer deviceConn.Close()
+	go fakeDevice(t, deviceConn, StatePlaying)
+
+	client, err := newClient(clientConn)
+	require.NoError(t, err)
+	defer client.Close()
+	require.NoError(t, client.Load(context.Background(), Media{URL: "https://example.com/ep.mp4"}))
+
+	client.lock.Lock()
+	client.receiverClosed = true
+	client.lock.Unlock()
+
+	status, err := client.Status(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, StateIdle, status.PlayerState)
+}
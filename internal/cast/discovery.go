@@ -0,0 +1,291 @@
+package cast
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+const (
+	// castService is the DNS-SD service Cast devices advertise themselves under
+	castService = "_googlecast._tcp.local."
+	// mdnsAddress is the multicast group mDNS queries are sent to
+	mdnsAddress = "224.0.0.251:5353"
+	// defaultCastPort is the port Cast devices listen on, used if a device's SRV record is missed
+	defaultCastPort = 8009
+)
+
+// DNS record types read from mDNS responses
+const (
+	typeA   = 1
+	typePTR = 12
+	typeTXT = 16
+	typeSRV = 33
+)
+
+// ErrDeviceNotFound is returned when no Cast device answered discovery, or none with the name asked for
+var ErrDeviceNotFound = errors.New("no Chromecast found on the network")
+
+// Device is a Cast device found on the local network
+type Device struct {
+	Name  string // Friendly name set up for the device, e.g. "Living Room TV"
+	Model string // e.g. "Chromecast Ultra"
+	Host  string
+	Port  int
+}
+
+// Address returns the host and port the device's Cast protocol is served on
+func (d Device) Address() string {
+	return net.JoinHostPort(d.Host, fmt.Sprint(d.Port))
+}
+
+// Discover finds the Cast devices on the local network, waiting until ctx is done for them to answer.  Callers should
+// give ctx a deadline, as devices don't say when they have all answered.
+func Discover(ctx context.Context) ([]Device, error) {
+	conn, err := net.ListenUDP("udp4", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open socket for discovery: %w", err)
+	}
+	defer conn.Close()
+
+	group, err := net.ResolveUDPAddr("udp4", mdnsAddress)
+	if err != nil {
+		return nil, err
+	}
+	// Sent from a port other than 5353, devices answer the query directly instead of to the multicast group
+	if _, err := conn.WriteToUDP(mdnsQuery(castService), group); err != nil {
+		return nil, fmt.Errorf("failed to send discovery query: %w", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		conn.SetReadDeadline(time.Now())
+	}()
+
+	found := make(map[string]Device)
+	buf := make([]byte, 9000)
+	for {
+		n, from, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return nil, fmt.Errorf("failed to read discovery answers: %w", err)
+		}
+
+		devices, err := parseMDNSResponse(buf[:n], from.IP)
+		if err != nil {
+			log.Debug("Ignoring malformed mDNS response", "from", from, "error", err)
+			continue
+		}
+		for _, device := range devices {
+			found[device.Name] = device
+		}
+	}
+
+	devices := make([]Device, 0, len(found))
+	for _, device := range found {
+		devices = append(devices, device)
+	}
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].Name < devices[j].Name
+	})
+	log.Debug("Discovered Cast devices", "count", len(devices))
+	return devices, nil
+}
+
+// Find discovers the Cast devices on the local network and returns the one with the given name, ignoring case.  An
+// empty name returns the first device found.
+func Find(ctx context.Context, name string) (Device, error) {
+	devices, err := Discover(ctx)
+	if err != nil {
+		return Device{}, err
+	}
+	for _, device := range devices {
+		if name == "" || strings.EqualFold(device.Name, name) {
+			return device, nil
+		}
+	}
+	if name != "" {
+		return Device{}, fmt.Errorf("%w named %q", ErrDeviceNotFound, name)
+	}
+	return Device{}, ErrDeviceNotFound
+}
+
+// mdnsQuery builds an mDNS query for the PTR records of the service
+func mdnsQuery(service string) []byte {
+	header := make([]byte, 12)
+	binary.BigEndian.PutUint16(header[4:], 1) // One question
+	query := appendName(header, service)
+	query = binary.BigEndian.AppendUint16(query, typePTR)
+	return binary.BigEndian.AppendUint16(query, 1) // IN class
+}
+
+// appendName appends a domain name in DNS wire format, without compression
+func appendName(b []byte, name string) []byte {
+	for _, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		b = append(b, byte(len(label)))
+		b = append(b, label...)
+	}
+	return append(b, 0)
+}
+
+// parseMDNSResponse reads the Cast devices described by an mDNS response.  Devices send their PTR, SRV, TXT and A
+// records together, but if the A record is missing, the address the response came from is used.
+func parseMDNSResponse(msg []byte, from net.IP) ([]Device, error) {
+	if len(msg) < 12 {
+		return nil, errors.New("response is shorter than a DNS header")
+	}
+	questions := int(binary.BigEndian.Uint16(msg[4:]))
+	records := int(binary.BigEndian.Uint16(msg[6:])) + int(binary.BigEndian.Uint16(msg[8:])) +
+		int(binary.BigEndian.Uint16(msg[10:]))
+
+	offset := 12
+	for range questions {
+		_, next, err := readName(msg, offset)
+		if err != nil {
+			return nil, err
+		}
+		offset = next + 4 // Type and class
+	}
+
+	type service struct {
+		target string
+		port   int
+	}
+	var instances []string
+	services := make(map[string]service)
+	txt := make(map[string]map[string]string)
+	addresses := make(map[string]net.IP)
+
+	for range records {
+		name, next, err := readName(msg, offset)
+		if err != nil {
+			return nil, err
+		}
+		if next+10 > len(msg) {
+			return nil, errors.New("truncated record")
+		}
+		recordType := binary.BigEndian.Uint16(msg[next:])
+		length := int(binary.BigEndian.Uint16(msg[next+8:]))
+		dataStart := next + 10
+		if dataStart+length > len(msg) {
+			return nil, errors.New("truncated record data")
+		}
+		data := msg[dataStart : dataStart+length]
+		name = strings.ToLower(name)
+
+		switch recordType {
+		case typePTR:
+			if name == castService {
+				instance, _, err := readName(msg, dataStart)
+				if err != nil {
+					return nil, err
+				}
+				instances = append(instances, strings.ToLower(instance))
+			}
+		case typeSRV:
+			if len(data) < 6 {
+				return nil, errors.New("truncated SRV record")
+			}
+			target, _, err := readName(msg, dataStart+6)
+			if err != nil {
+				return nil, err
+			}
+			services[name] = service{target: strings.ToLower(target), port: int(binary.BigEndian.Uint16(data[4:]))}
+		case typeTXT:
+			txt[name] = parseTXT(data)
+		case typeA:
+			if len(data) == net.IPv4len {
+				addresses[name] = net.IP(data)
+			}
+		}
+		offset = dataStart + length
+	}
+
+	devices := make([]Device, 0, len(instances))
+	for _, instance := range instances {
+		device := Device{Port: defaultCastPort}
+		if from != nil {
+			device.Host = from.String()
+		}
+		if srv, ok := services[instance]; ok {
+			device.Port = srv.port
+			if address, ok := addresses[srv.target]; ok {
+				device.Host = address.String()
+			}
+		}
+
+		attributes := txt[instance]
+		device.Name = attributes["fn"]
+		device.Model = attributes["md"]
+		if device.Name == "" {
+			// The instance name is the device's model and ID, e.g. "Chromecast-1a2b3c._googlecast._tcp.local."
+			device.Name = strings.TrimSuffix(instance, "."+castService)
+		}
+		if device.Host == "" {
+			continue
+		}
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+// parseTXT reads the key=value strings of a TXT record
+func parseTXT(data []byte) map[string]string {
+	attributes := make(map[string]string)
+	for len(data) > 0 {
+		length := int(data[0])
+		if 1+length > len(data) {
+			break
+		}
+		key, value, _ := strings.Cut(string(data[1:1+length]), "=")
+		attributes[strings.ToLower(key)] = value
+		data = data[1+length:]
+	}
+	return attributes
+}
+
+// readName reads the domain name at offset, following compression pointers.  Returns the name, with a trailing dot,
+// and the offset just past it.
+func readName(msg []byte, offset int) (string, int, error) {
+	var labels []string
+	next := -1
+	for jumps := 0; ; {
+		if offset >= len(msg) {
+			return "", 0, errors.New("truncated name")
+		}
+		length := int(msg[offset])
+		switch {
+		case length == 0:
+			if next < 0 {
+				next = offset + 1
+			}
+			return strings.Join(labels, ".") + ".", next, nil
+		case length&0xC0 == 0xC0:
+			if offset+1 >= len(msg) {
+				return "", 0, errors.New("truncated name pointer")
+			}
+			if jumps++; jumps > 32 {
+				return "", 0, errors.New("name pointers loop")
+			}
+			if next < 0 {
+				next = offset + 2
+			}
+			offset = int(binary.BigEndian.Uint16(msg[offset:]) & 0x3FFF)
+		default:
+			if offset+1+length > len(msg) {
+				return "", 0, errors.New("truncated label")
+			}
+			labels = append(labels, string(msg[offset+1:offset+1+length]))
+			offset += 1 + length
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package cast
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// appendRecord appends a resource record with the given owner name, which may be a compression pointer
+func appendRecord(b []byte, owner []byte, recordType uint16, data []byte) []byte {
+	b = append(b, owner...)
+	b = binary.BigEndian.AppendUint16(b, recordType)
+	b = binary.BigEndian.AppendUint16(b, 0x8001) // Cache flush, IN class
+	b = binary.BigEndian.AppendUint32(b, 120)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(data)))
+	return append(b, data...)
+}
+
+func TestParseMDNSResponse(t *testing.T) {
+	msg := make([]byte, 12)
+	binary.BigEndian.PutUint16(msg[2:], 0x8400)
+	binary.BigEndian.PutUint16(msg[6:], 1)  // PTR answer
+	binary.BigEndian.PutUint16(msg[10:], 3) // SRV, TXT and A additionals
+
+	instance := appendName(nil, "Chromecast-Ultra-1a2b._googlecast._tcp.local.")
+	msg = appendRecord(msg, appendName(nil, castService), typePTR, instance)
+	instanceOffset := len(msg) - len(instance)
+	pointer := func(offset int) []byte {
+		return []byte{0xC0 | byte(offset>>8), byte(offset)}
+	}
+
+	target := appendName(nil, "1a2b.local.")
+	srv := append([]byte{0, 0, 0, 0, 0x1f, 0x49}, target...)
+	msg = appendRecord(msg, pointer(instanceOffset), typeSRV, srv)
+	targetOffset := len(msg) - len(target)
+
+	var txt []byte
+	for _, attribute := range []string{"id=1a2b", "md=Chromecast Ultra", "fn=Living Room TV"} {
+		txt = append(txt, byte(len(attribute)))
+		txt = append(txt, attribute...)
+	}
+	msg = appendRecord(msg, pointer(instanceOffset), typeTXT, txt)
+	msg = appendRecord(msg, pointer(targetOffset), typeA, []byte{192, 168, 1, 40})
+
+	devices, err := parseMDNSResponse(msg, net.ParseIP("192.168.1.99"))
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	assert.Equal(t, Device{Name: "Living Room TV", Model: "Chromecast Ultra", Host: "192.168.1.40", Port: 8009}, devices[0])
+	assert.Equal(t, "192.168.1.40:8009", devices[0].Address())
+}
+
+func TestParseMDNSResponseWithoutAdditionals(t *testing.T) {
+	msg := make([]byte, 12)
+	binary.BigEndian.PutUint16(msg[6:], 1)
+	msg = appendRecord(msg, appendName(nil, castService), typePTR,
+		appendName(nil, "Chromecast-1a2b._googlecast._tcp.local."))
+
+	devices, err := parseMDNSResponse(msg, net.ParseIP("192.168.1.41"))
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	assert.Equal(t, Device{Name: "chromecast-1a2b", Host: "192.168.1.41", Port: defaultCastPort}, devices[0],
+		"the sender's address and the instance name should be used when the other records are missing")
+}
+
+func TestParseMDNSResponseIgnoresOtherServices(t *testing.T) {
+	msg := make([]byte, 12)
+	binary.BigEndian.PutUint16(msg[6:], 1)
+	msg = appendRecord(msg, appendName(nil, "_airplay._tcp.local."), typePTR,
+		appendName(nil, "Speaker._airplay._tcp.local."))
+
+	devices, err := parseMDNSResponse(msg, net.ParseIP("192.168.1.42"))
+	require.NoError(t, err)
+	assert.Empty(t, devices)
+}
+
+func TestReadNameRejectsPointerLoops(t *testing.T) {
+	_, _, err := readName([]byte{0xC0, 0x00}, 0)
+	assert.ErrorContains(t, err, "loop")
+}
@@ -0,0 +1,142 @@
+package cast
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// maxMessageSize is the largest message a Cast device sends, per the Cast V2 protocol
+const maxMessageSize = 64 * 1024
+
+// message is the CastMessage protobuf everything sent to and from a device is wrapped in.  Only UTF-8 payloads are
+// used by the namespaces Hisame speaks, so binary payloads are not supported.
+type message struct {
+	SourceID      string
+	DestinationID string
+	Namespace     string
+	Payload       string
+}
+
+// CastMessage protobuf field numbers
+const (
+	fieldProtocolVersion = 1
+	fieldSourceID        = 2
+	fieldDestinationID   = 3
+	fieldNamespace       = 4
+	fieldPayloadType     = 5
+	fieldPayloadUTF8     = 6
+)
+
+// protobuf wire types
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// marshal encodes the message as a CastMessage protobuf.  The protocol version and payload type are both required,
+// and both always 0, for CASTV2_1_0 and a string payload.
+func (m message) marshal() []byte {
+	var b []byte
+	b = appendVarintField(b, fieldProtocolVersion, 0)
+	b = appendBytesField(b, fieldSourceID, m.SourceID)
+	b = appendBytesField(b, fieldDestinationID, m.DestinationID)
+	b = appendBytesField(b, fieldNamespace, m.Namespace)
+	b = appendVarintField(b, fieldPayloadType, 0)
+	b = appendBytesField(b, fieldPayloadUTF8, m.Payload)
+	return b
+}
+
+// unmarshalMessage decodes a CastMessage protobuf, skipping fields Hisame doesn't use
+func unmarshalMessage(b []byte) (message, error) {
+	var m message
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			return message{}, errors.New("malformed field tag")
+		}
+		b = b[n:]
+
+		field, wireType := tag>>3, tag&7
+		switch wireType {
+		case wireVarint:
+			_, n := binary.Uvarint(b)
+			if n <= 0 {
+				return message{}, fmt.Errorf("malformed varint in field %d", field)
+			}
+			b = b[n:]
+		case wireFixed64:
+			if len(b) < 8 {
+				return message{}, fmt.Errorf("truncated field %d", field)
+			}
+			b = b[8:]
+		case wireFixed32:
+			if len(b) < 4 {
+				return message{}, fmt.Errorf("truncated field %d", field)
+			}
+			b = b[4:]
+		case wireBytes:
+			length, n := binary.Uvarint(b)
+			if n <= 0 || length > uint64(len(b)-n) {
+				return message{}, fmt.Errorf("truncated field %d", field)
+			}
+			value := string(b[n : n+int(length)])
+			b = b[n+int(length):]
+
+			switch field {
+			case fieldSourceID:
+				m.SourceID = value
+			case fieldDestinationID:
+				m.DestinationID = value
+			case fieldNamespace:
+				m.Namespace = value
+			case fieldPayloadUTF8:
+				m.Payload = value
+			}
+		default:
+			return message{}, fmt.Errorf("unsupported wire type %d in field %d", wireType, field)
+		}
+	}
+	return m, nil
+}
+
+// writeMessage writes the message to w, prefixed with its length as the Cast V2 protocol frames it
+func writeMessage(w io.Writer, m message) error {
+	body := m.marshal()
+	frame := make([]byte, 4, 4+len(body))
+	binary.BigEndian.PutUint32(frame, uint32(len(body)))
+	_, err := w.Write(append(frame, body...))
+	return err
+}
+
+// readMessage reads the next length prefixed message from r
+func readMessage(r io.Reader) (message, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return message{}, err
+	}
+	length := binary.BigEndian.Uint32(header[:])
+	if length > maxMessageSize {
+		return message{}, fmt.Errorf("message of %d bytes is larger than the protocol allows", length)
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return message{}, err
+	}
+	return unmarshalMessage(body)
+}
+
+func appendVarintField(b []byte, field int, value uint64) []byte {
+	b = binary.AppendUvarint(b, uint64(field)<<3|wireVarint)
+	return binary.AppendUvarint(b, value)
+}
+
+func appendBytesField(b []byte, field int, value string) []byte {
+	b = binary.AppendUvarint(b, uint64(field)<<3|wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(value)))
+	return append(b, value...)
+}
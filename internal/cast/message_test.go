@@ -0,0 +1,44 @@
+package cast
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageRoundTrip(t *testing.T) {
+	sent := message{
+		SourceID:      senderID,
+		DestinationID: receiverID,
+		Namespace:     namespaceReceiver,
+		Payload:       `{"type":"LAUNCH","appId":"CC1AD845","requestId":1}`,
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeMessage(&buf, sent))
+	assert.Equal(t, []byte{0, 0}, buf.Bytes()[:2], "the frame should start with the big endian length")
+
+	received, err := readMessage(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, sent, received)
+}
+
+func TestUnmarshalMessageSkipsUnknownFields(t *testing.T) {
+	b := appendBytesField(nil, fieldNamespace, namespaceMedia)
+	b = appendVarintField(b, 9, 300)
+	b = append(b, 10<<3|wireFixed32, 1, 2, 3, 4)
+	b = appendBytesField(b, 7, "binary payload")
+	b = appendBytesField(b, fieldPayloadUTF8, `{"type":"PING"}`)
+
+	m, err := unmarshalMessage(b)
+	require.NoError(t, err)
+	assert.Equal(t, namespaceMedia, m.Namespace)
+	assert.Equal(t, `{"type":"PING"}`, m.Payload)
+}
+
+func TestReadMessageRejectsOversizedFrames(t *testing.T) {
+	_, err := readMessage(bytes.NewReader([]byte{0, 2, 0, 0}))
+	assert.ErrorContains(t, err, "larger than the protocol allows")
+}
@@ -12,28 +12,162 @@ import (
 
 // Config represents the application configuration
 type Config struct {
-	Auth    AuthConfig    `yaml:"auth,omitempty"`
-	Player  PlayerConfig  `yaml:"player,omitempty"`
-	UI      UIConfig      `yaml:"ui,omitempty"`
-	Logging LoggingConfig `yaml:"logging,omitempty"`
+	Auth     AuthConfig     `yaml:"auth,omitempty"`
+	AniList  AniListConfig  `yaml:"anilist,omitempty"`
+	AllAnime AllAnimeConfig `yaml:"allanime,omitempty"`
+	Player   PlayerConfig   `yaml:"player,omitempty"`
+	Torrent  TorrentConfig  `yaml:"torrent,omitempty"`
+	UI       UIConfig       `yaml:"ui,omitempty"`
+	Network  NetworkConfig  `yaml:"network,omitempty"`
+	Export   ExportConfig   `yaml:"export,omitempty"`
+	Simkl    SimklConfig    `yaml:"simkl,omitempty"`
+	Syncplay SyncplayConfig `yaml:"syncplay,omitempty"`
+	Hooks    HooksConfig    `yaml:"hooks,omitempty"`
+	Logging  LoggingConfig  `yaml:"logging,omitempty"`
+	// Keybindings overrides the keys of actions, as context name to action name to comma separated keys
+	Keybindings map[string]map[string]string `yaml:"keybindings,omitempty"`
 }
 
 // AuthConfig contains authentication settings
 type AuthConfig struct {
 	Token string `yaml:"token,omitempty,omitempty"`
+
+	// LocalOnly keeps the list in a local file instead of an AniList account.  AniList is still used, without logging
+	// in, to look up and search anime.
+	LocalOnly bool `yaml:"local_only,omitempty"`
+}
+
+// AniListConfig contains settings for optional AniList integrations
+type AniListConfig struct {
+	CompletionActivity string `yaml:"completion_activity,omitempty"` // "never", "ask", "always"
+}
+
+// AllAnimeConfig contains settings for the AllAnime episode source.  The query hashes are sha256 persisted query
+// hashes, used when AllAnime stops accepting the raw GraphQL queries.  Empty hashes send the full query.
+type AllAnimeConfig struct {
+	ShowsQueryHash   string `yaml:"shows_query_hash,omitempty"`   // Persisted query hash for show searches
+	EpisodeQueryHash string `yaml:"episode_query_hash,omitempty"` // Persisted query hash for episode sources
 }
 
 // PlayerConfig contains media player settings
 type PlayerConfig struct {
-	Type            string `yaml:"type,omitempty"` // "mpv", "custom"
+	Type            string `yaml:"type,omitempty"`    // "mpv", "vlc", "custom", "chromecast"
 	Command         string `yaml:"command,omitempty"` // Full command with any prefix (e.g., "flatpak run io.mpv.Mpv")
-	Path            string `yaml:"path,omitempty"` // Deprecated:  use Command instead
+	Path            string `yaml:"path,omitempty"`    // Deprecated:  use Command instead
 	Args            string `yaml:"args,omitempty"`
 	TranslationType string `yaml:"translation_type,omitempty"` // "sub", "dub"
+	WatchLaterSync  bool   `yaml:"watch_later_sync,omitempty"` // Sync resume positions MPV saves to its watch_later dir
+	WatchLaterDir   string `yaml:"watch_later_dir,omitempty"`  // MPV's watch_later dir.  Empty uses MPV's default
+	// Fraction of the episode that must be played for progress to be updated automatically when playback ends
+	WatchedFraction float64 `yaml:"watched_fraction,omitempty"`
+	// Whether to ask to mark the episode watched when less than WatchedFraction of it was played: "on" or "off"
+	ConfirmUnfinished string `yaml:"confirm_unfinished,omitempty"`
+	// Fraction of the episode a player without IPC must run for before Hisame offers to mark the episode watched
+	ExitWatchedFraction float64 `yaml:"exit_watched_fraction,omitempty"`
+	// Minutes a player without IPC must run for to assume the episode was finished, updating progress without asking.
+	// 0 always asks
+	AssumeFinishedMinutes int `yaml:"assume_finished_minutes,omitempty"`
+	// Subtitle and audio languages to pick tracks by, in order of preference, e.g. "en,eng".  Empty leaves it to the player
+	SubtitleLanguages string `yaml:"subtitle_languages,omitempty"`
+	AudioLanguages    string `yaml:"audio_languages,omitempty"`
+	// Folders of downloaded episodes.  An episode found in one is played from the file instead of being streamed
+	LocalDirs []string `yaml:"local_dirs,omitempty"`
+	// Whether what is playing is shown in desktop media controls over MPRIS on Linux: "on" or "off"
+	MPRIS string `yaml:"mpris,omitempty"`
+	// Whether filler episodes are found from MyAnimeList: "off", "mark" to flag them in the episode selector, or "skip"
+	// to also skip them when playing the next episode
+	Filler string `yaml:"filler,omitempty"`
+	// Named MPV preset applied on top of Args, e.g. "low-power".  Can be overridden for each anime
+	Preset string `yaml:"preset,omitempty"`
+	// Extra presets, as preset name to MPV args.  Replaces a built in preset of the same name
+	Presets map[string]string `yaml:"presets,omitempty"`
+	// MPV args added for anime of an AniList format, as format to args, e.g. MOVIE: "--fs --profile=movie".  Applied
+	// before the preset, so a preset can override them
+	FormatArgs map[string]string `yaml:"format_args,omitempty"`
+	// How many sources are resolved and checked at the same time when playing an episode, playing the fastest one that
+	// works.  1 tries them one at a time
+	ProbeSources int `yaml:"probe_sources,omitempty"`
+	// Name of the Chromecast on the local network to cast episodes to instead of playing them in the player, or through
+	// Syncplay.  Empty plays them locally, as are streams the device can't fetch itself
+	CastDevice string `yaml:"cast_device,omitempty"`
+	// Whether the system is kept from sleeping, and the screen from turning off, while an episode plays: "on" or "off"
+	InhibitSleep string `yaml:"inhibit_sleep,omitempty"`
+	// How long each step of starting playback may take before it is given up on
+	Timeouts TimeoutsConfig `yaml:"timeouts,omitempty"`
+}
+
+// TimeoutsConfig contains how long each step of starting playback may take, as durations such as "30s"
+type TimeoutsConfig struct {
+	Connect       string `yaml:"connect,omitempty"`        // MPV opening its IPC socket for Hisame to connect to
+	PlaybackStart string `yaml:"playback_start,omitempty"` // The player starting to play once it has been launched
+	Overall       string `yaml:"overall,omitempty"`        // Finding a working stream and starting playback, altogether
+}
+
+// TorrentConfig contains settings for finding episodes on Nyaa, for when AllAnime has no good source
+type TorrentConfig struct {
+	Command    string `yaml:"command,omitempty"`    // Command that streams a magnet link, e.g. "webtorrent {url} --mpv".  Empty hands the magnet to the system's torrent client
+	Resolution string `yaml:"resolution,omitempty"` // Resolution ranked first, e.g. "1080p"
 }
 
 // UIConfig contains UI display preferences
 type UIConfig struct {
+	AiringTimeFormat string `yaml:"airing_time_format,omitempty"` // "countdown", "absolute"
+	Timezone         string `yaml:"timezone,omitempty"`           // IANA timezone name used for absolute air times.  Empty uses the system timezone
+	StartupAgenda    string `yaml:"startup_agenda,omitempty"`     // "panel", "off".  Shows episodes airing in the next 24 hours on startup
+	TaskbarProgress  string `yaml:"taskbar_progress,omitempty"`   // "auto", "on", "off".  Reports progress to the terminal via OSC 9;4
+	SpoilerSafe      bool   `yaml:"spoiler_safe,omitempty"`       // Hides episode titles, which can give away plot points
+	Color            string `yaml:"color,omitempty"`              // "auto", "light", "dark", "none".  Which background colors suit, or plain text
+	// "panel", "off".  Shows the anime with episodes left to watch, most recently watched first, on startup
+	StartupContinueWatching string `yaml:"startup_continue_watching,omitempty"`
+	// Columns shown in the anime list, in order: title, progress, format, score, my_score, status, next and airing
+	Columns []string `yaml:"columns,omitempty"`
+	// "compact", "detailed".  Detailed adds a line under each anime with its genres, next episode and notes
+	ListRows string `yaml:"list_rows,omitempty"`
+}
+
+// NetworkConfig contains settings for how Hisame talks to remote services
+type NetworkConfig struct {
+	LowBandwidth        bool        `yaml:"low_bandwidth,omitempty"`         // Skip heavy fields (synonyms, tags, cover images) when fetching the list
+	AutoRefreshInterval string      `yaml:"auto_refresh_interval,omitempty"` // How often the list is refreshed in the background, e.g. "15m".  "off" disables it
+	HealthCheckInterval string      `yaml:"health_check_interval,omitempty"` // How often episode providers are checked for outages, e.g. "5m".  "off" disables it
+	Proxy               string      `yaml:"proxy,omitempty"`                 // Proxy for all requests and players, e.g. "http://proxy:3128" or "socks5://127.0.0.1:1080".  Empty follows HTTP_PROXY/HTTPS_PROXY
+	Retry               RetryConfig `yaml:"retry,omitempty"`
+}
+
+// RetryConfig controls how requests that fail with timeouts or server errors are retried
+type RetryConfig struct {
+	MaxRetries int    `yaml:"max_retries,omitempty"` // Retries after the first attempt.  Negative disables retrying
+	BaseDelay  string `yaml:"base_delay,omitempty"`  // Delay before the first retry, doubled for each retry after it, e.g. "500ms"
+	MaxDelay   string `yaml:"max_delay,omitempty"`   // Upper bound on the delay between retries, e.g. "8s"
+}
+
+// ExportConfig contains settings for exporting the list to files
+type ExportConfig struct {
+	Dir string `yaml:"dir,omitempty"` // Directory exports are written to unless another path is entered.  Empty uses the home directory
+}
+
+// SimklConfig contains settings for scrobbling watched episodes to Simkl.  Scrobbling is off unless a token is set.
+type SimklConfig struct {
+	ClientID string `yaml:"client_id,omitempty"` // Client ID of the Simkl app the token was issued to
+	Token    string `yaml:"token,omitempty"`     // Simkl access token
+}
+
+// SyncplayConfig contains settings for watching episodes in sync with friends through Syncplay, which runs MPV itself
+type SyncplayConfig struct {
+	Enabled  bool   `yaml:"enabled,omitempty"`  // Play episodes through Syncplay instead of the player, unless casting
+	Command  string `yaml:"command,omitempty"`  // Command that runs Syncplay, e.g. "flatpak run pl.syncplay.Syncplay"
+	Server   string `yaml:"server,omitempty"`   // Syncplay server as host:port.  Empty uses Syncplay's saved server
+	Room     string `yaml:"room,omitempty"`     // Room to join.  Empty uses Syncplay's saved room
+	Name     string `yaml:"name,omitempty"`     // Name shown to the rest of the room.  Empty uses Syncplay's saved name
+	Password string `yaml:"password,omitempty"` // Password of the server, if it has one
+}
+
+// HooksConfig contains commands run when things happen in Hisame.  They run through the shell, with what happened in
+// HISAME_* environment variables.  Empty commands aren't run.
+type HooksConfig struct {
+	BeforePlayback     string `yaml:"before_playback,omitempty"`      // Run before the player launches, exiting non-zero stops playback
+	OnPlaybackComplete string `yaml:"on_playback_complete,omitempty"` // Run when playback of an episode ends
+	OnProgressUpdate   string `yaml:"on_progress_update,omitempty"`   // Run when a progress change has been saved
 }
 
 // LoggingConfig contains log related settings
@@ -157,13 +291,51 @@ func getConfigPath() (string, error) {
 func createBaseDefaultConfig() *Config {
 	return &Config{
 		Auth: AuthConfig{},
+		AniList: AniListConfig{
+			CompletionActivity: "never",
+		},
 		Player: PlayerConfig{
-			Type:            "mpv",
-			Command:         "mpv",
-			Path:            "mpv",
-			TranslationType: "sub",
+			Type:                "mpv",
+			Command:             "mpv",
+			Path:                "mpv",
+			TranslationType:     "sub",
+			WatchedFraction:     0.75,
+			ConfirmUnfinished:   "off",
+			ExitWatchedFraction: 0.75,
+			MPRIS:               "on",
+			Filler:              "mark",
+			ProbeSources:        3,
+			InhibitSleep:        "on",
+			Timeouts: TimeoutsConfig{
+				Connect:       "10s",
+				PlaybackStart: "30s",
+				Overall:       "2m",
+			},
+		},
+		Torrent: TorrentConfig{
+			Resolution: "1080p",
+		},
+		Syncplay: SyncplayConfig{
+			Command: "syncplay",
+		},
+		UI: UIConfig{
+			AiringTimeFormat:        "countdown",
+			StartupAgenda:           "panel",
+			TaskbarProgress:         "auto",
+			StartupContinueWatching: "off",
+			Color:                   "auto",
+			Columns:                 []string{"title", "progress", "format", "score", "status", "next", "airing"},
+			ListRows:                "compact",
+		},
+		Network: NetworkConfig{
+			AutoRefreshInterval: "15m",
+			HealthCheckInterval: "5m",
+			Retry: RetryConfig{
+				MaxRetries: 3,
+				BaseDelay:  "500ms",
+				MaxDelay:   "8s",
+			},
 		},
-		UI: UIConfig{},
 		Logging: LoggingConfig{
 			Level: "info",
 		},
@@ -206,3 +378,40 @@ func defaultLogFilePath() string {
 	}
 	return filepath.Join(basePath, "hisame.log")
 }
+
+// DataDir returns the directory Hisame stores local metadata in, creating it if needed.  Uses the HISAME_DATA_DIR
+// environment variable override if present, else tries to use OS data location defaults.
+func DataDir() (string, error) {
+	basePath := os.Getenv("HISAME_DATA_DIR")
+	if basePath == "" {
+		homedir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to determine home directory: %w", err)
+		}
+
+		switch runtime.GOOS {
+		case "windows":
+			// Windows:  %LOCALAPPDATA%\hisame\data
+			if appData := os.Getenv("LOCALAPPDATA"); appData != "" {
+				basePath = filepath.Join(appData, "hisame", "data")
+			} else {
+				basePath = filepath.Join(homedir, "AppData", "local", "hisame", "data")
+			}
+		case "darwin":
+			// macOS:  ~/Library/Application Support/hisame
+			basePath = filepath.Join(homedir, "Library", "Application Support", "hisame")
+		default:
+			// Linux/BSD:  XDG_DATA_HOME
+			if xdgData := os.Getenv("XDG_DATA_HOME"); xdgData != "" {
+				basePath = filepath.Join(xdgData, "hisame")
+			} else {
+				basePath = filepath.Join(homedir, ".local", "share", "hisame")
+			}
+		}
+	}
+
+	if err := os.MkdirAll(basePath, 0700); err != nil {
+		return "", fmt.Errorf("failed to create data directory: %w", err)
+	}
+	return basePath, nil
+}
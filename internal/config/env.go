@@ -2,6 +2,9 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 )
 
 type envVar struct {
@@ -23,12 +26,128 @@ var supportedEnvVars = []envVar{
 		desc:  "Set the AniList authentication token.  Default: None",
 		apply: func(c *Config, s string) { c.Auth.Token = s },
 	},
+	{
+		name:  "HISAME_CONFIG_AUTH_LOCAL_ONLY",
+		desc:  "Keeps the anime list in a local file instead of an AniList account.  Default: false",
+		apply: func(c *Config, s string) { c.Auth.LocalOnly = parseBool(s) },
+	},
+	{
+		name:  "HISAME_CONFIG_ANILIST_COMPLETION_ACTIVITY",
+		desc:  "Sets whether to post an AniList activity when completing an anime.  One of: never, ask, always.  Default: never",
+		apply: func(c *Config, s string) { c.AniList.CompletionActivity = s },
+	},
+	{
+		name: "HISAME_CONFIG_PLAYER_WATCHED_FRACTION",
+		desc: "Sets the fraction of an episode (0-1) that must be played for progress to be updated automatically.  Default: 0.75",
+		apply: func(c *Config, s string) {
+			if f, ok := parseFloat(s); ok {
+				c.Player.WatchedFraction = f
+			}
+		},
+	},
+	{
+		name:  "HISAME_CONFIG_PLAYER_CONFIRM_UNFINISHED",
+		desc:  "Sets whether to ask to mark an episode watched when playback ends before the watched fraction (on or off).  Default: off",
+		apply: func(c *Config, s string) { c.Player.ConfirmUnfinished = s },
+	},
+	{
+		name: "HISAME_CONFIG_PLAYER_EXIT_WATCHED_FRACTION",
+		desc: "Sets the fraction of an episode (0-1) a player without IPC must run for before Hisame offers to mark it watched.  Default: 0.75",
+		apply: func(c *Config, s string) {
+			if f, ok := parseFloat(s); ok {
+				c.Player.ExitWatchedFraction = f
+			}
+		},
+	},
+	{
+		name: "HISAME_CONFIG_PLAYER_ASSUME_FINISHED_MINUTES",
+		desc: "Sets how many minutes a player without IPC must run for to assume the episode was finished, updating progress without asking.  Default: 0 (always ask)",
+		apply: func(c *Config, s string) {
+			if minutes, err := strconv.Atoi(s); err == nil {
+				c.Player.AssumeFinishedMinutes = minutes
+			}
+		},
+	},
+	{
+		name:  "HISAME_CONFIG_PLAYER_LOCAL_DIRS",
+		desc:  "Sets the folders of downloaded episodes to play from instead of streaming, separated like PATH.  Default: None",
+		apply: func(c *Config, s string) { c.Player.LocalDirs = filepath.SplitList(s) },
+	},
+	{
+		name:  "HISAME_CONFIG_PLAYER_SUBTITLE_LANGUAGES",
+		desc:  "Sets the subtitle languages to pick a track by, in order of preference, e.g. en,eng.  Default: None",
+		apply: func(c *Config, s string) { c.Player.SubtitleLanguages = s },
+	},
+	{
+		name:  "HISAME_CONFIG_PLAYER_AUDIO_LANGUAGES",
+		desc:  "Sets the audio languages to pick a track by, in order of preference, e.g. ja,jpn.  Default: None",
+		apply: func(c *Config, s string) { c.Player.AudioLanguages = s },
+	},
+	{
+		name:  "HISAME_CONFIG_PLAYER_MPRIS",
+		desc:  "Sets whether what is playing is shown in desktop media controls over MPRIS on Linux.  One of: on, off.  Default: on",
+		apply: func(c *Config, s string) { c.Player.MPRIS = s },
+	},
+	{
+		name:  "HISAME_CONFIG_PLAYER_INHIBIT_SLEEP",
+		desc:  "Sets whether the system and screen are kept from sleeping while an episode plays.  One of: on, off.  Default: on",
+		apply: func(c *Config, s string) { c.Player.InhibitSleep = s },
+	},
+	{
+		name:  "HISAME_CONFIG_PLAYER_FILLER",
+		desc:  "Sets whether filler episodes are flagged in the episode selector or skipped when playing the next episode.  One of: off, mark, skip.  Default: mark",
+		apply: func(c *Config, s string) { c.Player.Filler = s },
+	},
+	{
+		name:  "HISAME_CONFIG_PLAYER_TIMEOUTS_CONNECT",
+		desc:  "Sets how long MPV has to open its IPC socket for Hisame to connect to.  Default: 10s",
+		apply: func(c *Config, s string) { c.Player.Timeouts.Connect = s },
+	},
+	{
+		name:  "HISAME_CONFIG_PLAYER_TIMEOUTS_PLAYBACK_START",
+		desc:  "Sets how long the player has to start playing once it has been launched.  Default: 30s",
+		apply: func(c *Config, s string) { c.Player.Timeouts.PlaybackStart = s },
+	},
+	{
+		name:  "HISAME_CONFIG_PLAYER_TIMEOUTS_OVERALL",
+		desc:  "Sets how long finding a working stream and starting playback may take altogether.  Default: 2m",
+		apply: func(c *Config, s string) { c.Player.Timeouts.Overall = s },
+	},
+	{
+		name:  "HISAME_CONFIG_PLAYER_PRESET",
+		desc:  "Sets the named MPV preset applied on top of the player args, e.g. low-power or high-quality.  Default: None",
+		apply: func(c *Config, s string) { c.Player.Preset = s },
+	},
+	{
+		name: "HISAME_CONFIG_PLAYER_PROBE_SOURCES",
+		desc: "Sets how many sources are checked at the same time when playing an episode, playing the fastest that works.  Default: 3",
+		apply: func(c *Config, s string) {
+			if n, err := strconv.Atoi(s); err == nil {
+				c.Player.ProbeSources = n
+			}
+		},
+	},
+	{
+		name:  "HISAME_CONFIG_PLAYER_CAST_DEVICE",
+		desc:  "Sets the name of the Chromecast to cast episodes to instead of playing them locally.  Default: None (play locally)",
+		apply: func(c *Config, s string) { c.Player.CastDevice = s },
+	},
+	{
+		name:  "HISAME_CONFIG_ALLANIME_SHOWS_QUERY_HASH",
+		desc:  "Sets the AllAnime persisted query hash used to search shows.  Default: None (send the full query)",
+		apply: func(c *Config, s string) { c.AllAnime.ShowsQueryHash = s },
+	},
+	{
+		name:  "HISAME_CONFIG_ALLANIME_EPISODE_QUERY_HASH",
+		desc:  "Sets the AllAnime persisted query hash used to fetch episode sources.  Default: None (send the full query)",
+		apply: func(c *Config, s string) { c.AllAnime.EpisodeQueryHash = s },
+	},
 	{
 		name:  "HISAME_CONFIG_PLAYER_TYPE",
-		desc:  "Sets the video player type.  Should be one of `mpv` or `custom`.  Default: mpv",
+		desc:  "Sets the video player type.  Should be one of `mpv`, `vlc` or `custom`.  Default: mpv",
 		apply: func(c *Config, s string) { c.Player.Type = s },
 	},
-		{
+	{
 		name:  "HISAME_CONFIG_PLAYER_PATH",
 		desc:  "Sets the path to a video player binary. Deprecated: use HISAME_CONFIG_PLAYER_COMMAND instead. Default: mpv",
 		apply: func(c *Config, s string) { c.Player.Path = s },
@@ -48,6 +167,160 @@ var supportedEnvVars = []envVar{
 		desc:  "Sets the translation type to search for.  Default: sub",
 		apply: func(c *Config, s string) { c.Player.TranslationType = s },
 	},
+	{
+		name:  "HISAME_CONFIG_PLAYER_WATCH_LATER_SYNC",
+		desc:  "Syncs resume positions from MPV's watch_later files, so episodes resumed directly in MPV are picked up.  Default: false",
+		apply: func(c *Config, s string) { c.Player.WatchLaterSync = parseBool(s) },
+	},
+	{
+		name:  "HISAME_CONFIG_PLAYER_WATCH_LATER_DIR",
+		desc:  "Sets the MPV watch_later directory to sync from.  Default: MPV's default location",
+		apply: func(c *Config, s string) { c.Player.WatchLaterDir = s },
+	},
+	{
+		name:  "HISAME_CONFIG_UI_AIRING_TIME_FORMAT",
+		desc:  "Sets how upcoming air times are shown.  One of: countdown, absolute.  Default: countdown",
+		apply: func(c *Config, s string) { c.UI.AiringTimeFormat = s },
+	},
+	{
+		name:  "HISAME_CONFIG_UI_TIMEZONE",
+		desc:  "Sets the IANA timezone (e.g. Asia/Tokyo) used for absolute air times.  Default: system timezone",
+		apply: func(c *Config, s string) { c.UI.Timezone = s },
+	},
+	{
+		name:  "HISAME_CONFIG_UI_STARTUP_AGENDA",
+		desc:  "Sets whether episodes airing in the next 24 hours are shown on startup.  One of: panel, off.  Default: panel",
+		apply: func(c *Config, s string) { c.UI.StartupAgenda = s },
+	},
+	{
+		name:  "HISAME_CONFIG_UI_STARTUP_CONTINUE_WATCHING",
+		desc:  "Sets whether the anime to continue watching are shown on startup.  One of: panel, off.  Default: off",
+		apply: func(c *Config, s string) { c.UI.StartupContinueWatching = s },
+	},
+	{
+		name:  "HISAME_CONFIG_UI_TASKBAR_PROGRESS",
+		desc:  "Sets whether loading and playback progress is reported to the terminal (OSC 9;4).  One of: auto, on, off.  Default: auto",
+		apply: func(c *Config, s string) { c.UI.TaskbarProgress = s },
+	},
+	{
+		name:  "HISAME_CONFIG_UI_COLOR",
+		desc:  "Sets the terminal background colors are picked for, or none for plain text.  One of: auto, light, dark, none.  Default: auto",
+		apply: func(c *Config, s string) { c.UI.Color = s },
+	},
+	{
+		name:  "HISAME_CONFIG_UI_COLUMNS",
+		desc:  "Sets the columns shown in the anime list, in order, separated by commas.  Default: title,progress,format,score,status,next,airing",
+		apply: func(c *Config, s string) { c.UI.Columns = strings.Split(s, ",") },
+	},
+	{
+		name:  "HISAME_CONFIG_UI_LIST_ROWS",
+		desc:  "Sets whether anime list rows show a second line of genres, next episode and notes.  One of: compact, detailed.  Default: compact",
+		apply: func(c *Config, s string) { c.UI.ListRows = s },
+	},
+	{
+		name:  "HISAME_CONFIG_UI_SPOILER_SAFE",
+		desc:  "Hides episode titles in the episode selector, as they can give away plot points.  Default: false",
+		apply: func(c *Config, s string) { c.UI.SpoilerSafe = parseBool(s) },
+	},
+	{
+		name:  "HISAME_CONFIG_NETWORK_LOW_BANDWIDTH",
+		desc:  "Skips heavy fields such as synonyms, tags and cover images when fetching the anime list.  Default: false",
+		apply: func(c *Config, s string) { c.Network.LowBandwidth = parseBool(s) },
+	},
+	{
+		name:  "HISAME_CONFIG_NETWORK_AUTO_REFRESH_INTERVAL",
+		desc:  "Sets how often the anime list is refreshed in the background, e.g. 15m.  Use off to disable.  Default: 15m",
+		apply: func(c *Config, s string) { c.Network.AutoRefreshInterval = s },
+	},
+	{
+		name:  "HISAME_CONFIG_NETWORK_PROXY",
+		desc:  "Sets the proxy all requests and media players go through, e.g. socks5://127.0.0.1:1080.  Default: HTTP_PROXY/HTTPS_PROXY",
+		apply: func(c *Config, s string) { c.Network.Proxy = s },
+	},
+	{
+		name:  "HISAME_CONFIG_NETWORK_HEALTH_CHECK_INTERVAL",
+		desc:  "Sets how often episode providers such as AllAnime are checked for outages, e.g. 5m.  Use off to disable.  Default: 5m",
+		apply: func(c *Config, s string) { c.Network.HealthCheckInterval = s },
+	},
+	{
+		name: "HISAME_CONFIG_NETWORK_RETRY_MAX_RETRIES",
+		desc: "Sets how many times a request that times out or hits a server error is retried.  Negative disables retrying.  Default: 3",
+		apply: func(c *Config, s string) {
+			if n, err := strconv.Atoi(s); err == nil {
+				c.Network.Retry.MaxRetries = n
+			}
+		},
+	},
+	{
+		name:  "HISAME_CONFIG_NETWORK_RETRY_BASE_DELAY",
+		desc:  "Sets the delay before the first retry, doubled for each retry after it.  Default: 500ms",
+		apply: func(c *Config, s string) { c.Network.Retry.BaseDelay = s },
+	},
+	{
+		name:  "HISAME_CONFIG_NETWORK_RETRY_MAX_DELAY",
+		desc:  "Sets the longest delay between retries.  Default: 8s",
+		apply: func(c *Config, s string) { c.Network.Retry.MaxDelay = s },
+	},
+	{
+		name:  "HISAME_CONFIG_TORRENT_COMMAND",
+		desc:  "Sets the command that streams a torrent's magnet link, e.g. webtorrent {url} --mpv.  Default: None (hand the magnet to the system's torrent client)",
+		apply: func(c *Config, s string) { c.Torrent.Command = s },
+	},
+	{
+		name:  "HISAME_CONFIG_TORRENT_RESOLUTION",
+		desc:  "Sets the resolution of the torrents ranked first.  Default: 1080p",
+		apply: func(c *Config, s string) { c.Torrent.Resolution = s },
+	},
+	{
+		name:  "HISAME_CONFIG_EXPORT_DIR",
+		desc:  "Sets the directory list exports are written to unless another path is entered.  Default: home directory",
+		apply: func(c *Config, s string) { c.Export.Dir = s },
+	},
+	{
+		name:  "HISAME_CONFIG_SIMKL_CLIENT_ID",
+		desc:  "Sets the client ID of the Simkl app used for scrobbling",
+		apply: func(c *Config, s string) { c.Simkl.ClientID = s },
+	},
+	{
+		name:  "HISAME_CONFIG_SIMKL_TOKEN",
+		desc:  "Sets the Simkl access token.  Watched episodes are scrobbled to Simkl when set",
+		apply: func(c *Config, s string) { c.Simkl.Token = s },
+	},
+	{
+		name:  "HISAME_CONFIG_SYNCPLAY_ENABLED",
+		desc:  "Sets whether episodes are played through Syncplay to watch in sync with friends.  Default: false",
+		apply: func(c *Config, s string) { c.Syncplay.Enabled = parseBool(s) },
+	},
+	{
+		name:  "HISAME_CONFIG_SYNCPLAY_SERVER",
+		desc:  "Sets the Syncplay server to join, as host:port.  Default: None (Syncplay's saved server)",
+		apply: func(c *Config, s string) { c.Syncplay.Server = s },
+	},
+	{
+		name:  "HISAME_CONFIG_SYNCPLAY_ROOM",
+		desc:  "Sets the Syncplay room to join.  Default: None (Syncplay's saved room)",
+		apply: func(c *Config, s string) { c.Syncplay.Room = s },
+	},
+	{
+		name:  "HISAME_CONFIG_SYNCPLAY_NAME",
+		desc:  "Sets the name shown to the rest of the Syncplay room.  Default: None (Syncplay's saved name)",
+		apply: func(c *Config, s string) { c.Syncplay.Name = s },
+	},
+	{
+		name:  "HISAME_CONFIG_HOOKS_BEFORE_PLAYBACK",
+		desc:  "Sets the command run before the player launches, which stops playback if it exits non-zero.  Default: None",
+		apply: func(c *Config, s string) { c.Hooks.BeforePlayback = s },
+	},
+	{
+		name:  "HISAME_CONFIG_HOOKS_ON_PLAYBACK_COMPLETE",
+		desc:  "Sets the command run when playback of an episode ends.  Default: None",
+		apply: func(c *Config, s string) { c.Hooks.OnPlaybackComplete = s },
+	},
+	{
+		name:  "HISAME_CONFIG_HOOKS_ON_PROGRESS_UPDATE",
+		desc:  "Sets the command run when a progress change has been saved.  Default: None",
+		apply: func(c *Config, s string) { c.Hooks.OnProgressUpdate = s },
+	},
 	{
 		name:  "HISAME_CONFIG_LOGGING_LEVEL",
 		desc:  "Sets the logging level.  One of: debug, info, warn, error.  Default: info",
@@ -60,6 +333,33 @@ var supportedEnvVars = []envVar{
 	},
 }
 
+// parseBool interprets an environment variable value as a boolean, treating anything unparseable as false
+func parseBool(s string) bool {
+	b, err := strconv.ParseBool(s)
+	return err == nil && b
+}
+
+// parseFloat interprets an environment variable value as a number, reporting whether it could be parsed
+func parseFloat(s string) (float64, bool) {
+	f, err := strconv.ParseFloat(s, 64)
+	return f, err == nil
+}
+
+// TokenSource describes where the AniList token was loaded from, for diagnosing login problems.  A token the
+// HISAME_CONFIG_AUTH_TOKEN environment variable supplies overrides the one in the config file.
+func TokenSource(token string) string {
+	if token == "" {
+		return "none"
+	}
+	if env := os.Getenv("HISAME_CONFIG_AUTH_TOKEN"); env != "" && env == token {
+		return "HISAME_CONFIG_AUTH_TOKEN environment variable"
+	}
+	if path, err := getConfigPath(); err == nil {
+		return "config file (" + path + ")"
+	}
+	return "config file"
+}
+
 func applyEnvVarOverrides(c *Config) {
 	for _, envVar := range supportedEnvVars {
 		if value := os.Getenv(envVar.name); value != "" {
@@ -0,0 +1,99 @@
+// Package diagnostics keeps an in-memory record of what Hisame has been doing behind the scenes, so it can be surfaced
+// to the user when things are slow or failing.
+package diagnostics
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxAPICalls is the number of recent API calls kept for the usage inspector
+const maxAPICalls = 200
+
+// API names used when recording calls
+const (
+	APIAniList  = "AniList"
+	APIAllAnime = "AllAnime"
+	APISimkl    = "Simkl"
+	APINyaa     = "Nyaa"
+	APIJikan    = "Jikan"
+)
+
+// APICall describes a single call made to an external API
+type APICall struct {
+	API       string        // Which API was called, e.g. AniList
+	Operation string        // The GraphQL operation or endpoint that was called
+	StartedAt time.Time     // When the call was made
+	Duration  time.Duration // How long the call took
+	Error     error         // The error returned by the call, if any
+	Cached    bool          // Whether the result was served from a cache rather than the network
+}
+
+// Status returns a short description of the outcome of the call
+func (c APICall) Status() string {
+	if c.Error != nil {
+		return "error"
+	}
+	return "ok"
+}
+
+var (
+	mu    sync.Mutex
+	calls []APICall
+)
+
+// RecordAPICall records a completed API call, discarding the oldest call once the buffer is full
+func RecordAPICall(call APICall) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	calls = append(calls, call)
+	if len(calls) > maxAPICalls {
+		calls = calls[len(calls)-maxAPICalls:]
+	}
+}
+
+// TrackAPICall records a call to the given API that started at `start`, for use with defer
+func TrackAPICall(api, operation string, start time.Time, err error) {
+	RecordAPICall(APICall{
+		API:       api,
+		Operation: operation,
+		StartedAt: start,
+		Duration:  time.Since(start),
+		Error:     err,
+	})
+}
+
+// RecentAPICalls returns the recorded API calls, most recent first
+func RecentAPICalls() []APICall {
+	mu.Lock()
+	defer mu.Unlock()
+
+	result := make([]APICall, len(calls))
+	for i, call := range calls {
+		result[len(calls)-1-i] = call
+	}
+	return result
+}
+
+// OperationName extracts a readable operation name from a GraphQL query.  This is the name of the first field selected,
+// e.g. "MediaListCollection" or "SaveMediaListEntry", ignoring any alias it is given.
+func OperationName(query string) string {
+	start := strings.Index(query, "{")
+	if start == -1 {
+		return "unknown"
+	}
+
+	name := strings.TrimSpace(query[start+1:])
+	if colon, end := strings.Index(name, ":"), strings.IndexAny(name, "({"); colon != -1 && (end == -1 || colon < end) {
+		name = strings.TrimSpace(name[colon+1:])
+	}
+	if end := strings.IndexAny(name, "({ \t\n"); end != -1 {
+		name = name[:end]
+	}
+	if name == "" {
+		return "unknown"
+	}
+	return name
+}
@@ -0,0 +1,40 @@
+package diagnostics
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationName(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"query { Viewer { id } }", "Viewer"},
+		{"query ($userId: Int) {\n  MediaListCollection(userId: $userId) { lists { name } } }", "MediaListCollection"},
+		{"mutation ($id: Int) { SaveMediaListEntry(mediaId: $id) { id } }", "SaveMediaListEntry"},
+		{"mutation ($id0: Int) {\n  entry0: SaveMediaListEntry(mediaId: $id0) { id }\n}", "SaveMediaListEntry"},
+		{"not graphql", "unknown"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, OperationName(tt.query), tt.query)
+	}
+}
+
+func TestRecentAPICalls(t *testing.T) {
+	for i := 0; i < maxAPICalls+5; i++ {
+		RecordAPICall(APICall{API: APIAniList, Operation: fmt.Sprintf("op%d", i), StartedAt: time.Now()})
+	}
+	RecordAPICall(APICall{API: APIAllAnime, Operation: "last", Error: errors.New("boom")})
+
+	recent := RecentAPICalls()
+	assert.Len(t, recent, maxAPICalls)
+	assert.Equal(t, "last", recent[0].Operation)
+	assert.Equal(t, "error", recent[0].Status())
+	assert.Equal(t, "ok", recent[1].Status())
+}
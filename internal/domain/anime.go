@@ -1,5 +1,11 @@
 package domain
 
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
 // MediaStatus represents which list the anime is in
 type MediaStatus string
 
@@ -15,19 +21,60 @@ const (
 // Anime represents the core anime information
 type Anime struct {
 	ID           int
+	IDMal        int // The anime's MyAnimeList ID, 0 if AniList doesn't know it
 	Title        AnimeTitle
 	CoverImage   string
 	Episodes     int
+	Duration     int // Typical episode length in minutes, 0 if unknown
 	NextAiringEp *AiringSchedule
 	Status       string
 	Format       string
 	Season       string
 	SeasonYear   string
 	AverageScore float64
+	IsAdult      bool
+	Country      string // Country of origin as an ISO 3166-1 alpha-2 code, e.g. "JP"
 	Synonyms     []string
+	Genres       []string
+	Tags         []AnimeTag
+	Relations    []AnimeRelation
 	UserData     *UserAnimeData
 }
 
+// Media formats used by AniList for anime
+const (
+	FormatTV      = "TV"
+	FormatTVShort = "TV_SHORT"
+	FormatMovie   = "MOVIE"
+	FormatOVA     = "OVA"
+	FormatONA     = "ONA"
+	FormatSpecial = "SPECIAL"
+)
+
+// RelationType describes how one media relates to another on AniList
+type RelationType string
+
+const (
+	RelationPrequel   RelationType = "PREQUEL"
+	RelationSequel    RelationType = "SEQUEL"
+	RelationSideStory RelationType = "SIDE_STORY"
+	RelationParent    RelationType = "PARENT"
+)
+
+// AnimeRelation is a link from one media to another related media
+type AnimeRelation struct {
+	Type      RelationType
+	AnimeID   int
+	MediaType string // ANIME or MANGA
+}
+
+// AnimeTag is an AniList tag describing the anime's content in more detail than its genres
+type AnimeTag struct {
+	Name      string
+	Rank      int  // How relevant the tag is to the anime, as a percentage
+	IsSpoiler bool // Whether the tag gives away a plot point
+}
+
 // AnimeTitle contains various versions of the anime title
 type AnimeTitle struct {
 	Romaji    string
@@ -46,11 +93,18 @@ type AiringSchedule struct {
 // UserAnimeData represents user-specific data for an anime
 type UserAnimeData struct {
 	Status    MediaStatus
-	Score     float64
+	Score     float64 // In the user's score format
+	Score10   int     // The score as a whole number out of 10, whatever the user's score format
 	Progress  int
 	StartDate string
 	EndDate   string
 	Notes     string
+	UpdatedAt int64 // Unix timestamp of the last change to the list entry
+
+	// HiddenFromStatusLists is set for entries the user keeps out of AniList's status lists, so they only appear in
+	// custom lists
+	HiddenFromStatusLists bool
+	Priority              int // The user's priority for the entry, higher first.  0 means none
 }
 
 // getFirstNonEmpty returns the first non-empty string from the provided arguments
@@ -76,6 +130,10 @@ func (a *Anime) HasUnwatchedEpisodes() bool {
 // Returns 0 if it cannot be determined
 func (a *Anime) GetLatestAiredEpisode() int {
 	if a.NextAiringEp != nil {
+		// The list may have been loaded before the next episode aired, so check whether it has aired since
+		if a.NextAiringEp.AiringAt > 0 && a.NextAiringEp.AiringAt <= time.Now().Unix() {
+			return a.NextAiringEp.Episode
+		}
 		// If we know the next episode that will air, assume all previous episodes have aired
 		return a.NextAiringEp.Episode - 1
 	} else if a.Status == "FINISHED" && a.Episodes > 0 {
@@ -89,3 +147,36 @@ func (a *Anime) GetLatestAiredEpisode() int {
 	// We don't have enough information to determine the latest aired episode
 	return 0
 }
+
+// HasGenre reports whether the anime has the given genre.  Matching ignores case, spaces and punctuation so
+// "scifi" matches "Sci-Fi" and "slice_of_life" matches "Slice of Life".
+func (a *Anime) HasGenre(genre string) bool {
+	want := normaliseLabel(genre)
+	for _, g := range a.Genres {
+		if normaliseLabel(g) == want {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTag reports whether the anime has the given tag, matching the same way as HasGenre
+func (a *Anime) HasTag(tag string) bool {
+	want := normaliseLabel(tag)
+	for _, t := range a.Tags {
+		if normaliseLabel(t.Name) == want {
+			return true
+		}
+	}
+	return false
+}
+
+// normaliseLabel lowercases a genre or tag name and strips everything but letters and digits
+func normaliseLabel(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
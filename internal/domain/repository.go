@@ -1,6 +1,11 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // AnimeRepository defines the interface for anime data access
 type AnimeRepository interface {
@@ -10,8 +15,40 @@ type AnimeRepository interface {
 	// UpdateUserAnimeData syncs the user-specified data about an anime with AniList
 	UpdateUserAnimeData(ctx context.Context, id int, data *UserAnimeData) error
 
+	// GetAnimeByID retrieves a single media by its AniList ID, including its relations
+	GetAnimeByID(ctx context.Context, id int) (*Anime, error)
+
+	// SearchAnime searches all of AniList for anime matching the query, including those not on the user's list
+	SearchAnime(ctx context.Context, search string) ([]*Anime, error)
+
+	// GetAnimeByMalIDs retrieves the anime with the given MyAnimeList IDs, including the user's list entries.  IDs
+	// AniList has no anime for are left out.
+	GetAnimeByMalIDs(ctx context.Context, malIDs []int) ([]*Anime, error)
+
+	// GetAnimeByIDs retrieves the anime with the given AniList IDs, including the user's list entries.  IDs with no
+	// anime are left out.
+	GetAnimeByIDs(ctx context.Context, ids []int) ([]*Anime, error)
+
 	// UpdateAnime provides a structured way to update specific fields of an anime list entry
 	UpdateAnime(ctx context.Context, params *AnimeUpdateParams) (*AnimeUpdateResult, error)
+
+	// UpdateAnimeBatch updates several list entries in a single request, returning results in the same order
+	UpdateAnimeBatch(ctx context.Context, params []*AnimeUpdateParams) ([]*AnimeUpdateResult, error)
+
+	// PostTextActivity posts a text status activity to the user's AniList feed
+	PostTextActivity(ctx context.Context, text string) error
+
+	// GetStreamingEpisodes retrieves the episode titles AniList knows of from official streaming sites
+	GetStreamingEpisodes(ctx context.Context, id int) ([]StreamingEpisode, error)
+
+	// GetAiringSchedule retrieves the episodes of an anime that have yet to air
+	GetAiringSchedule(ctx context.Context, id int) ([]AiringSchedule, error)
+}
+
+// StreamingEpisode is an episode listed on an official streaming site, as reported by AniList
+type StreamingEpisode struct {
+	Title string // Usually in the form "Episode 3 - Title"
+	Site  string
 }
 
 // FuzzyDate represents a date that might be incomplete (missing day or month)
@@ -21,15 +58,53 @@ type FuzzyDate struct {
 	Day   int `json:"day"`
 }
 
+// String formats the date as far as it is known, in the same form as list entry dates, e.g. "2024-05" if the day is
+// missing, or "" if nothing is known
+func (d FuzzyDate) String() string {
+	if d.Year == 0 {
+		return ""
+	}
+	if d.Month == 0 {
+		return strconv.Itoa(d.Year)
+	}
+	if d.Day == 0 {
+		return fmt.Sprintf("%d-%02d", d.Year, d.Month)
+	}
+	return fmt.Sprintf("%d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// clearedFuzzyDate is the FuzzyDateInput that removes a date from a list entry
+var clearedFuzzyDate = map[string]interface{}{"year": nil, "month": nil, "day": nil}
+
+// ParseFuzzyDate parses a date in the "2006", "2006-01" or "2006-01-02" forms list entries use.  An empty or
+// unparseable date gives a FuzzyDate with no components.
+func ParseFuzzyDate(s string) FuzzyDate {
+	var date FuzzyDate
+	parts := strings.Split(s, "-")
+	fields := []*int{&date.Year, &date.Month, &date.Day}
+	for i := 0; i < len(parts) && i < len(fields); i++ {
+		value, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return FuzzyDate{}
+		}
+		*fields[i] = value
+	}
+	return date
+}
+
 // AnimeUpdateParams defines the parameters that can be updated for an anime list entry
 type AnimeUpdateParams struct {
 	MediaID     int        `json:"mediaId"` // Required - The ID of the anime to update
 	Status      string     `json:"status,omitempty"`
 	Progress    *int       `json:"progress,omitempty"`
-	Score       *float64   `json:"score,omitempty"`
+	Score       *float64   `json:"score,omitempty"`    // In the user's score format
+	ScoreRaw    *int       `json:"scoreRaw,omitempty"` // Out of 100, which AniList converts to the user's score format
 	Notes       *string    `json:"notes,omitempty"`
 	StartedAt   *FuzzyDate `json:"startedAt,omitempty"`
 	CompletedAt *FuzzyDate `json:"completedAt,omitempty"`
+
+	HiddenFromStatusLists *bool `json:"hiddenFromStatusLists,omitempty"`
+	Priority              *int  `json:"priority,omitempty"`
 }
 
 // AnimeUpdateResult contains information about the result of an anime update operation
@@ -39,10 +114,14 @@ type AnimeUpdateResult struct {
 	Status         MediaStatus // The status after the update
 	Progress       int         // The progress after the update
 	Score          float64     // The score after the update
+	Score10        int         // The score after the update, as a whole number out of 10
 	Notes          string      // The notes after the update
 	UpdatedAt      int         // The timestamp when the update occurred
 	StartDate      string      // The start date after the update
 	CompletionDate string      // The completion date after the update
+
+	HiddenFromStatusLists bool // Whether the entry is hidden from status lists after the update
+	Priority              int  // The priority after the update
 }
 
 // ToAnimeUpdateVariables converts the update params to a variables map for GraphQL
@@ -63,10 +142,22 @@ func (p *AnimeUpdateParams) ToAnimeUpdateVariables() map[string]interface{} {
 		variables["score"] = *p.Score
 	}
 
+	if p.ScoreRaw != nil {
+		variables["scoreRaw"] = *p.ScoreRaw
+	}
+
 	if p.Notes != nil {
 		variables["notes"] = *p.Notes
 	}
 
+	if p.HiddenFromStatusLists != nil {
+		variables["hiddenFromStatusLists"] = *p.HiddenFromStatusLists
+	}
+
+	if p.Priority != nil {
+		variables["priority"] = *p.Priority
+	}
+
 	if p.StartedAt != nil {
 		// Only include non-zero date components
 		startedAtMap := map[string]int{}
@@ -80,9 +171,11 @@ func (p *AnimeUpdateParams) ToAnimeUpdateVariables() map[string]interface{} {
 			startedAtMap["day"] = p.StartedAt.Day
 		}
 
-		// Only add if we have at least one date component
+		// A date with no components clears the date
 		if len(startedAtMap) > 0 {
 			variables["startedAt"] = startedAtMap
+		} else {
+			variables["startedAt"] = clearedFuzzyDate
 		}
 	}
 
@@ -99,9 +192,11 @@ func (p *AnimeUpdateParams) ToAnimeUpdateVariables() map[string]interface{} {
 			completedAtMap["day"] = p.CompletedAt.Day
 		}
 
-		// Only add if we have at least one date component
+		// A date with no components clears the date
 		if len(completedAtMap) > 0 {
 			variables["completedAt"] = completedAtMap
+		} else {
+			variables["completedAt"] = clearedFuzzyDate
 		}
 	}
 
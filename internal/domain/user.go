@@ -1,11 +1,16 @@
 package domain
 
+import "math"
+
 type User struct {
 	ID         int
 	Name       string
 	Avatar     string
 	SiteURL    string
 	Statistics UserStatistics
+
+	DisplayAdultContent bool        // Whether the user has chosen to see adult content in their AniList settings
+	ScoreFormat         ScoreFormat // The scale the user scores anime on in their AniList settings
 }
 
 type UserStatistics struct {
@@ -14,3 +19,50 @@ type UserStatistics struct {
 	EpisodesWatched int
 	ChaptersRead    int
 }
+
+// ScoreFormat is one of AniList's scales for scoring anime
+type ScoreFormat string
+
+const (
+	ScoreFormat100       ScoreFormat = "POINT_100"
+	ScoreFormat10Decimal ScoreFormat = "POINT_10_DECIMAL"
+	ScoreFormat10        ScoreFormat = "POINT_10"
+	ScoreFormat5         ScoreFormat = "POINT_5"
+	ScoreFormat3         ScoreFormat = "POINT_3"
+)
+
+// MaxScore returns the highest score in the format.  Scores are taken to be out of 100 if the format isn't known.
+func (f ScoreFormat) MaxScore() float64 {
+	switch f {
+	case ScoreFormat10Decimal, ScoreFormat10:
+		return 10
+	case ScoreFormat5:
+		return 5
+	case ScoreFormat3:
+		return 3
+	default:
+		return 100
+	}
+}
+
+// Decimal reports whether scores in the format can have a decimal place.  An unknown format allows them, so no score
+// is turned away that might be valid.
+func (f ScoreFormat) Decimal() bool {
+	switch f {
+	case ScoreFormat100, ScoreFormat10, ScoreFormat5, ScoreFormat3:
+		return false
+	default:
+		return true
+	}
+}
+
+// ValidScore reports whether the score can be given in the format.  0 is always valid, and means unscored.
+func (f ScoreFormat) ValidScore(score float64) bool {
+	if score < 0 || score > f.MaxScore() {
+		return false
+	}
+	if f.Decimal() {
+		score *= 10
+	}
+	return math.Abs(score-math.Round(score)) < 1e-9
+}
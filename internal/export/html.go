@@ -0,0 +1,122 @@
+// Package export writes the user's anime list out to files that can be shared or imported elsewhere, and reads lists
+// exported from MyAnimeList back in
+package export
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+)
+
+// statusSections is the order list statuses are shown in, along with their heading
+var statusSections = []struct {
+	Status domain.MediaStatus
+	Label  string
+}{
+	{domain.StatusCurrent, "Watching"},
+	{domain.StatusRepeating, "Rewatching"},
+	{domain.StatusCompleted, "Completed"},
+	{domain.StatusPaused, "Paused"},
+	{domain.StatusDropped, "Dropped"},
+	{domain.StatusPlanning, "Planning"},
+}
+
+// htmlEntry is a single anime as shown on the exported page
+type htmlEntry struct {
+	Title    string
+	Cover    string
+	Format   string
+	Score    string
+	Progress string
+}
+
+// htmlSection is a heading and the entries with that list status
+type htmlSection struct {
+	Label   string
+	Entries []htmlEntry
+}
+
+var htmlTemplate = template.Must(template.New("list").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; background: #1a1a2e; color: #e0e0e0; margin: 0 auto; max-width: 960px; padding: 1em; }
+h1 { color: #b39ddb; }
+h2 { color: #7d56f4; border-bottom: 1px solid #7d56f4; padding-bottom: 0.2em; }
+table { border-collapse: collapse; width: 100%; }
+td { padding: 0.4em; vertical-align: middle; }
+tr:nth-child(even) { background: #22223a; }
+img { width: 46px; height: 66px; object-fit: cover; border-radius: 3px; }
+.num { text-align: right; white-space: nowrap; }
+footer { color: #888; font-size: 0.8em; margin-top: 2em; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{range .Sections}}<h2>{{.Label}} ({{len .Entries}})</h2>
+<table>
+{{range .Entries}}<tr><td>{{if .Cover}}<img src="{{.Cover}}" alt="" loading="lazy">{{end}}</td><td>{{.Title}}</td><td>{{.Format}}</td><td class="num">{{.Score}}</td><td class="num">{{.Progress}}</td></tr>
+{{end}}</table>
+{{end}}<footer>Generated by Hisame on {{.Generated}}</footer>
+</body>
+</html>
+`))
+
+// WriteHTML writes the list as a self-contained static HTML page, grouped by list status.  Only what is needed to
+// show the list is included, so the page can be shared without linking back to the user's AniList profile.
+func WriteHTML(w io.Writer, userName string, list []*domain.Anime, generated time.Time) error {
+	title := "Anime List"
+	if userName != "" {
+		title = userName + "'s Anime List"
+	}
+
+	sections := make([]htmlSection, 0, len(statusSections))
+	for _, section := range statusSections {
+		var entries []htmlEntry
+		for _, anime := range list {
+			if anime.UserData == nil || anime.UserData.Status != section.Status {
+				continue
+			}
+			entries = append(entries, toHTMLEntry(anime))
+		}
+		if len(entries) > 0 {
+			sections = append(sections, htmlSection{Label: section.Label, Entries: entries})
+		}
+	}
+
+	return htmlTemplate.Execute(w, struct {
+		Title     string
+		Sections  []htmlSection
+		Generated string
+	}{
+		Title:     title,
+		Sections:  sections,
+		Generated: generated.Format("2006-01-02"),
+	})
+}
+
+func toHTMLEntry(anime *domain.Anime) htmlEntry {
+	score := "-"
+	if anime.UserData.Score > 0 {
+		score = fmt.Sprintf("%g", anime.UserData.Score)
+	}
+
+	episodes := "?"
+	if anime.Episodes > 0 {
+		episodes = fmt.Sprintf("%d", anime.Episodes)
+	}
+
+	return htmlEntry{
+		Title:    anime.Title.Preferred,
+		Cover:    anime.CoverImage,
+		Format:   anime.Format,
+		Score:    score,
+		Progress: fmt.Sprintf("%d/%s", anime.UserData.Progress, episodes),
+	}
+}
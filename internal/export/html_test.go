@@ -0,0 +1,42 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteHTML(t *testing.T) {
+	list := []*domain.Anime{
+		{
+			ID:         1,
+			Title:      domain.AnimeTitle{Preferred: "Frieren <Beyond Journey's End>"},
+			CoverImage: "https://example.com/frieren.jpg",
+			Episodes:   28,
+			Format:     domain.FormatTV,
+			UserData:   &domain.UserAnimeData{Status: domain.StatusCompleted, Score: 9.5, Progress: 28},
+		},
+		{
+			ID:       2,
+			Title:    domain.AnimeTitle{Preferred: "Dandadan"},
+			UserData: &domain.UserAnimeData{Status: domain.StatusCurrent, Progress: 3},
+		},
+	}
+
+	var b strings.Builder
+	require.NoError(t, WriteHTML(&b, "pizza", list, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)))
+	page := b.String()
+
+	assert.Contains(t, page, "pizza&#39;s Anime List")
+	assert.Contains(t, page, "Frieren &lt;Beyond Journey&#39;s End&gt;", "titles should be escaped")
+	assert.Contains(t, page, `src="https://example.com/frieren.jpg"`)
+	assert.Contains(t, page, "9.5")
+	assert.Contains(t, page, "3/?")
+	assert.Contains(t, page, "2024-05-01")
+	assert.NotContains(t, page, "Planning", "empty statuses should be left out")
+	assert.Less(t, strings.Index(page, "Watching"), strings.Index(page, "Completed"), "watching should be listed first")
+}
@@ -0,0 +1,211 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+)
+
+// malStatuses are the names MyAnimeList uses for each list status
+var malStatuses = map[domain.MediaStatus]string{
+	domain.StatusCurrent:   "Watching",
+	domain.StatusRepeating: "Watching",
+	domain.StatusCompleted: "Completed",
+	domain.StatusPaused:    "On-Hold",
+	domain.StatusDropped:   "Dropped",
+	domain.StatusPlanning:  "Plan to Watch",
+}
+
+// malTypes are the names MyAnimeList uses for each media format.  MyAnimeList has no TV short format.
+var malTypes = map[string]string{
+	domain.FormatTV:      "TV",
+	domain.FormatTVShort: "TV",
+	domain.FormatMovie:   "Movie",
+	domain.FormatOVA:     "OVA",
+	domain.FormatONA:     "ONA",
+	domain.FormatSpecial: "Special",
+	"MUSIC":              "Music",
+}
+
+// malImportStatuses are the list statuses for each of MyAnimeList's, by name and by the number older exports use
+var malImportStatuses = map[string]domain.MediaStatus{
+	"watching":      domain.StatusCurrent,
+	"completed":     domain.StatusCompleted,
+	"on-hold":       domain.StatusPaused,
+	"dropped":       domain.StatusDropped,
+	"plan to watch": domain.StatusPlanning,
+	"1":             domain.StatusCurrent,
+	"2":             domain.StatusCompleted,
+	"3":             domain.StatusPaused,
+	"4":             domain.StatusDropped,
+	"6":             domain.StatusPlanning,
+}
+
+// cdata is text written as a CDATA section, as MyAnimeList does for titles and comments
+type cdata struct {
+	Text string `xml:",cdata"`
+}
+
+// malList is the root of a MyAnimeList export
+type malList struct {
+	XMLName xml.Name   `xml:"myanimelist"`
+	Info    malInfo    `xml:"myinfo"`
+	Anime   []malAnime `xml:"anime"`
+}
+
+// malInfo describes the exported list and whose it is
+type malInfo struct {
+	ExportType  int    `xml:"user_export_type"` // 1 is an anime list
+	UserName    string `xml:"user_name"`
+	Total       int    `xml:"user_total_anime"`
+	Watching    int    `xml:"user_total_watching"`
+	Completed   int    `xml:"user_total_completed"`
+	OnHold      int    `xml:"user_total_onhold"`
+	Dropped     int    `xml:"user_total_dropped"`
+	PlanToWatch int    `xml:"user_total_plantowatch"`
+}
+
+// malAnime is a single list entry in a MyAnimeList export
+type malAnime struct {
+	ID             int    `xml:"series_animedb_id"`
+	Title          cdata  `xml:"series_title"`
+	Type           string `xml:"series_type"`
+	Episodes       int    `xml:"series_episodes"`
+	MyID           int    `xml:"my_id"`
+	Watched        int    `xml:"my_watched_episodes"`
+	StartDate      string `xml:"my_start_date"`
+	FinishDate     string `xml:"my_finish_date"`
+	Score          int    `xml:"my_score"`
+	Status         string `xml:"my_status"`
+	Comments       cdata  `xml:"my_comments"`
+	TimesWatched   int    `xml:"my_times_watched"`
+	Rewatching     int    `xml:"my_rewatching"`
+	UpdateOnImport int    `xml:"update_on_import"`
+}
+
+// WriteMALXML writes the list in the XML format MyAnimeList exports and imports, so it can be imported into
+// MyAnimeList or any site that accepts its exports.  MyAnimeList identifies anime by its own IDs, so entries AniList
+// has no MyAnimeList ID for are left out.  Returns how many entries were written.
+func WriteMALXML(w io.Writer, userName string, list []*domain.Anime) (int, error) {
+	export := malList{Info: malInfo{ExportType: 1, UserName: userName}}
+
+	for _, anime := range list {
+		data := anime.UserData
+		if anime.IDMal == 0 || data == nil {
+			continue
+		}
+		status, ok := malStatuses[data.Status]
+		if !ok {
+			continue
+		}
+
+		entry := malAnime{
+			ID:             anime.IDMal,
+			Title:          cdata{anime.Title.Preferred},
+			Type:           malTypes[anime.Format],
+			Episodes:       anime.Episodes,
+			Watched:        data.Progress,
+			StartDate:      malDate(data.StartDate),
+			FinishDate:     malDate(data.EndDate),
+			Score:          data.Score10,
+			Status:         status,
+			Comments:       cdata{data.Notes},
+			UpdateOnImport: 1,
+		}
+		if data.Status == domain.StatusRepeating {
+			entry.Rewatching = 1
+		}
+		export.Anime = append(export.Anime, entry)
+
+		switch status {
+		case "Watching":
+			export.Info.Watching++
+		case "Completed":
+			export.Info.Completed++
+		case "On-Hold":
+			export.Info.OnHold++
+		case "Dropped":
+			export.Info.Dropped++
+		case "Plan to Watch":
+			export.Info.PlanToWatch++
+		}
+	}
+	export.Info.Total = len(export.Anime)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return 0, err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "\t")
+	if err := encoder.Encode(export); err != nil {
+		return 0, fmt.Errorf("failed to write MyAnimeList XML: %w", err)
+	}
+	return len(export.Anime), nil
+}
+
+// malDate formats a list entry date the way MyAnimeList does, with unknown parts as zeros, e.g. "2024-05-00"
+func malDate(date string) string {
+	d := domain.ParseFuzzyDate(date)
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// MALEntry is a list entry read from a MyAnimeList export
+type MALEntry struct {
+	MalID     int
+	Title     string
+	Status    domain.MediaStatus
+	Progress  int
+	Score     int // Out of 10, 0 if unscored
+	StartDate string
+	EndDate   string
+	Notes     string
+}
+
+// ReadMALXML reads the entries of a MyAnimeList XML export.  Entries with a status Hisame doesn't know are left out.
+// Dates are returned as far as they are known, e.g. "2024-05" if the day is missing, as in the rest of Hisame.
+func ReadMALXML(r io.Reader) ([]MALEntry, error) {
+	var list malList
+	if err := xml.NewDecoder(r).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to read MyAnimeList XML: %w", err)
+	}
+
+	entries := make([]MALEntry, 0, len(list.Anime))
+	for _, anime := range list.Anime {
+		status, ok := malImportStatuses[strings.ToLower(strings.TrimSpace(anime.Status))]
+		if anime.ID == 0 || !ok {
+			continue
+		}
+		if status == domain.StatusCurrent && anime.Rewatching == 1 {
+			status = domain.StatusRepeating
+		}
+		entries = append(entries, MALEntry{
+			MalID:     anime.ID,
+			Title:     strings.TrimSpace(anime.Title.Text),
+			Status:    status,
+			Progress:  anime.Watched,
+			Score:     anime.Score,
+			StartDate: fromMALDate(anime.StartDate),
+			EndDate:   fromMALDate(anime.FinishDate),
+			Notes:     strings.TrimSpace(anime.Comments.Text),
+		})
+	}
+	return entries, nil
+}
+
+// fromMALDate converts a MyAnimeList date, with unknown parts as zeros, to a date as far as it is known, e.g.
+// "2024-05-00" to "2024-05"
+func fromMALDate(date string) string {
+	parts := strings.Split(date, "-")
+	var known []string
+	for _, part := range parts {
+		if n, err := strconv.Atoi(part); err != nil || n == 0 {
+			break
+		}
+		known = append(known, part)
+	}
+	return strings.Join(known, "-")
+}
@@ -0,0 +1,146 @@
+package export
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteMALXML(t *testing.T) {
+	list := []*domain.Anime{
+		{
+			ID:       154587,
+			IDMal:    52991,
+			Title:    domain.AnimeTitle{Preferred: "Sousou no Frieren"},
+			Episodes: 28,
+			Format:   domain.FormatTV,
+			UserData: &domain.UserAnimeData{
+				Status:    domain.StatusCompleted,
+				Score:     9.5,
+				Score10:   10,
+				Progress:  28,
+				StartDate: "2023-09",
+				EndDate:   "2024-03-22",
+				Notes:     "A <masterpiece> & more",
+			},
+		},
+		{
+			ID:    2,
+			IDMal: 3,
+			Title: domain.AnimeTitle{Preferred: "Rewatch"},
+			UserData: &domain.UserAnimeData{
+				Status:   domain.StatusRepeating,
+				Score:    85,
+				Score10:  9,
+				Progress: 3,
+			},
+		},
+		{
+			ID:       4,
+			Title:    domain.AnimeTitle{Preferred: "Not on MAL"},
+			UserData: &domain.UserAnimeData{Status: domain.StatusPlanning},
+		},
+	}
+
+	var b strings.Builder
+	count, err := WriteMALXML(&b, "pizza", list)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count, "entries without a MAL ID should be left out")
+
+	var decoded malList
+	require.NoError(t, xml.Unmarshal([]byte(b.String()), &decoded))
+	assert.Equal(t, "pizza", decoded.Info.UserName)
+	assert.Equal(t, 2, decoded.Info.Total)
+	assert.Equal(t, 1, decoded.Info.Watching)
+	assert.Equal(t, 1, decoded.Info.Completed)
+
+	frieren := decoded.Anime[0]
+	assert.Equal(t, 52991, frieren.ID)
+	assert.Equal(t, "TV", frieren.Type)
+	assert.Equal(t, 10, frieren.Score)
+	assert.Equal(t, "2023-09-00", frieren.StartDate)
+	assert.Equal(t, "2024-03-22", frieren.FinishDate)
+	assert.Equal(t, "Completed", frieren.Status)
+	assert.Equal(t, "A <masterpiece> & more", frieren.Comments.Text)
+	assert.Contains(t, b.String(), "<![CDATA[A <masterpiece> & more]]>")
+
+	rewatch := decoded.Anime[1]
+	assert.Equal(t, "Watching", rewatch.Status)
+	assert.Equal(t, 1, rewatch.Rewatching)
+	assert.Equal(t, 9, rewatch.Score, "scores should be exported out of 10")
+	assert.Equal(t, "0000-00-00", rewatch.StartDate)
+}
+
+func TestReadMALXML(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8" ?>
+<myanimelist>
+	<myinfo><user_export_type>1</user_export_type></myinfo>
+	<anime>
+		<series_animedb_id>52991</series_animedb_id>
+		<series_title><![CDATA[Sousou no Frieren]]></series_title>
+		<my_watched_episodes>28</my_watched_episodes>
+		<my_start_date>2023-09-00</my_start_date>
+		<my_finish_date>0000-00-00</my_finish_date>
+		<my_score>9</my_score>
+		<my_status>Completed</my_status>
+		<my_comments><![CDATA[Great]]></my_comments>
+	</anime>
+	<anime>
+		<series_animedb_id>3</series_animedb_id>
+		<my_status>Watching</my_status>
+		<my_rewatching>1</my_rewatching>
+	</anime>
+	<anime>
+		<series_animedb_id>4</series_animedb_id>
+		<my_status>6</my_status>
+	</anime>
+	<anime>
+		<series_animedb_id>5</series_animedb_id>
+		<my_status>Unknown</my_status>
+	</anime>
+</myanimelist>`
+
+	entries, err := ReadMALXML(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, MALEntry{
+		MalID:     52991,
+		Title:     "Sousou no Frieren",
+		Status:    domain.StatusCompleted,
+		Progress:  28,
+		Score:     9,
+		StartDate: "2023-09",
+		Notes:     "Great",
+	}, entries[0])
+	assert.Equal(t, domain.StatusRepeating, entries[1].Status)
+	assert.Equal(t, domain.StatusPlanning, entries[2].Status)
+}
+
+func TestMALXMLRoundTrip(t *testing.T) {
+	list := []*domain.Anime{{
+		ID:    1,
+		IDMal: 2,
+		Title: domain.AnimeTitle{Preferred: "Show"},
+		UserData: &domain.UserAnimeData{
+			Status:   domain.StatusPaused,
+			Score:    7,
+			Score10:  7,
+			Progress: 4,
+			EndDate:  "2024-03-22",
+		},
+	}}
+	var sb strings.Builder
+	_, err := WriteMALXML(&sb, "", list)
+	require.NoError(t, err)
+
+	entries, err := ReadMALXML(strings.NewReader(sb.String()))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, MALEntry{MalID: 2, Title: "Show", Status: domain.StatusPaused, Progress: 4, Score: 7,
+		EndDate: "2024-03-22"}, entries[0])
+}
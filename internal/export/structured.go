@@ -0,0 +1,164 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+)
+
+// listEntry is a single anime and the user's list entry for it, as written to JSON and CSV exports.  Fields are
+// named after AniList's, so the export is easy to match back up with the API.
+type listEntry struct {
+	ID           int      `json:"id"`
+	IDMal        int      `json:"idMal,omitempty"`
+	TitleRomaji  string   `json:"titleRomaji"`
+	TitleEnglish string   `json:"titleEnglish,omitempty"`
+	TitleNative  string   `json:"titleNative,omitempty"`
+	Format       string   `json:"format"`
+	Episodes     int      `json:"episodes"`
+	Duration     int      `json:"duration"`
+	MediaStatus  string   `json:"mediaStatus"`
+	Season       string   `json:"season,omitempty"`
+	SeasonYear   string   `json:"seasonYear,omitempty"`
+	Genres       []string `json:"genres,omitempty"`
+	AverageScore float64  `json:"averageScore"`
+
+	Status      string  `json:"status"`
+	Score       float64 `json:"score"`
+	Progress    int     `json:"progress"`
+	StartedAt   string  `json:"startedAt,omitempty"`
+	CompletedAt string  `json:"completedAt,omitempty"`
+	Notes       string  `json:"notes,omitempty"`
+	Priority    int     `json:"priority,omitempty"`
+	UpdatedAt   int64   `json:"updatedAt"`
+
+	HiddenFromStatusLists bool `json:"hiddenFromStatusLists,omitempty"`
+}
+
+// jsonExport is the root of a JSON export
+type jsonExport struct {
+	UserName  string      `json:"userName,omitempty"`
+	Generated string      `json:"generated"`
+	Entries   []listEntry `json:"entries"`
+}
+
+// csvHeader names the columns of a CSV export, in the order csvRow writes them
+var csvHeader = []string{
+	"id", "id_mal", "title_romaji", "title_english", "title_native", "format", "episodes", "duration",
+	"media_status", "season", "season_year", "genres", "average_score", "status", "score", "progress",
+	"started_at", "completed_at", "notes", "priority", "updated_at", "hidden_from_status_lists",
+}
+
+// toListEntries converts the anime on the user's list to export entries, leaving out any not on it
+func toListEntries(list []*domain.Anime) []listEntry {
+	entries := make([]listEntry, 0, len(list))
+	for _, anime := range list {
+		data := anime.UserData
+		if data == nil {
+			continue
+		}
+		seasonYear := anime.SeasonYear
+		if seasonYear == "0" {
+			seasonYear = ""
+		}
+		entries = append(entries, listEntry{
+			ID:           anime.ID,
+			IDMal:        anime.IDMal,
+			TitleRomaji:  anime.Title.Romaji,
+			TitleEnglish: anime.Title.English,
+			TitleNative:  anime.Title.Native,
+			Format:       anime.Format,
+			Episodes:     anime.Episodes,
+			Duration:     anime.Duration,
+			MediaStatus:  anime.Status,
+			Season:       anime.Season,
+			SeasonYear:   seasonYear,
+			Genres:       anime.Genres,
+			AverageScore: anime.AverageScore,
+
+			Status:      string(data.Status),
+			Score:       data.Score,
+			Progress:    data.Progress,
+			StartedAt:   data.StartDate,
+			CompletedAt: data.EndDate,
+			Notes:       data.Notes,
+			Priority:    data.Priority,
+			UpdatedAt:   data.UpdatedAt,
+
+			HiddenFromStatusLists: data.HiddenFromStatusLists,
+		})
+	}
+	return entries
+}
+
+// WriteJSON writes the full list, including everything the user has recorded for each entry, as indented JSON for
+// custom tooling.  Returns how many entries were written.
+func WriteJSON(w io.Writer, userName string, list []*domain.Anime, generated time.Time) (int, error) {
+	export := jsonExport{
+		UserName:  userName,
+		Generated: generated.UTC().Format(time.RFC3339),
+		Entries:   toListEntries(list),
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(export); err != nil {
+		return 0, fmt.Errorf("failed to write JSON: %w", err)
+	}
+	return len(export.Entries), nil
+}
+
+// WriteCSV writes the full list as CSV with a header row, one entry per row, for spreadsheets.  Genres are joined
+// with semicolons.  Returns how many entries were written.
+func WriteCSV(w io.Writer, list []*domain.Anime) (int, error) {
+	entries := toListEntries(list)
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return 0, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	for _, entry := range entries {
+		if err := writer.Write(csvRow(entry)); err != nil {
+			return 0, fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return 0, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return len(entries), nil
+}
+
+// csvRow formats an entry's columns in csvHeader's order
+func csvRow(e listEntry) []string {
+	return []string{
+		strconv.Itoa(e.ID),
+		strconv.Itoa(e.IDMal),
+		e.TitleRomaji,
+		e.TitleEnglish,
+		e.TitleNative,
+		e.Format,
+		strconv.Itoa(e.Episodes),
+		strconv.Itoa(e.Duration),
+		e.MediaStatus,
+		e.Season,
+		e.SeasonYear,
+		strings.Join(e.Genres, ";"),
+		strconv.FormatFloat(e.AverageScore, 'f', -1, 64),
+		e.Status,
+		strconv.FormatFloat(e.Score, 'f', -1, 64),
+		strconv.Itoa(e.Progress),
+		e.StartedAt,
+		e.CompletedAt,
+		e.Notes,
+		strconv.Itoa(e.Priority),
+		strconv.FormatInt(e.UpdatedAt, 10),
+		strconv.FormatBool(e.HiddenFromStatusLists),
+	}
+}
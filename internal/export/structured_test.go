@@ -0,0 +1,81 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func structuredTestList() []*domain.Anime {
+	return []*domain.Anime{
+		{
+			ID:         154587,
+			IDMal:      52991,
+			Title:      domain.AnimeTitle{Romaji: "Sousou no Frieren", English: "Frieren: Beyond Journey's End"},
+			Episodes:   28,
+			Format:     domain.FormatTV,
+			SeasonYear: "2023",
+			Genres:     []string{"Adventure", "Drama"},
+			UserData: &domain.UserAnimeData{
+				Status:    domain.StatusCompleted,
+				Score:     9.5,
+				Progress:  28,
+				StartDate: "2023-09",
+				Notes:     "Rewatch, with \"friends\"",
+				UpdatedAt: 1711065600,
+			},
+		},
+		{
+			ID:    2,
+			Title: domain.AnimeTitle{Romaji: "Not on the list"},
+		},
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var sb strings.Builder
+	count, err := WriteJSON(&sb, "Tester", structuredTestList(), time.Date(2024, 3, 22, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	var export jsonExport
+	require.NoError(t, json.Unmarshal([]byte(sb.String()), &export))
+	assert.Equal(t, "Tester", export.UserName)
+	assert.Equal(t, "2024-03-22T12:00:00Z", export.Generated)
+	require.Len(t, export.Entries, 1)
+
+	entry := export.Entries[0]
+	assert.Equal(t, 52991, entry.IDMal)
+	assert.Equal(t, "COMPLETED", entry.Status)
+	assert.Equal(t, 9.5, entry.Score)
+	assert.Equal(t, "2023-09", entry.StartedAt)
+	assert.Equal(t, []string{"Adventure", "Drama"}, entry.Genres)
+}
+
+func TestWriteCSV(t *testing.T) {
+	var sb strings.Builder
+	count, err := WriteCSV(&sb, structuredTestList())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	rows, err := csv.NewReader(strings.NewReader(sb.String())).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, csvHeader, rows[0])
+
+	row := map[string]string{}
+	for i, column := range csvHeader {
+		row[column] = rows[1][i]
+	}
+	assert.Equal(t, "Frieren: Beyond Journey's End", row["title_english"])
+	assert.Equal(t, "Adventure;Drama", row["genres"])
+	assert.Equal(t, "9.5", row["score"])
+	assert.Equal(t, "Rewatch, with \"friends\"", row["notes"])
+	assert.Equal(t, "", row["completed_at"])
+}
@@ -0,0 +1,153 @@
+// Package filler looks up which episodes of an anime are filler or recaps, from MyAnimeList's episode data as served
+// by the Jikan API
+package filler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/diagnostics"
+	"github.com/PizzaHomicide/hisame/internal/proxy"
+	"github.com/PizzaHomicide/hisame/internal/retry"
+)
+
+const (
+	// jikanAPIURL is the base URL of the Jikan API
+	jikanAPIURL = "https://api.jikan.moe/v4"
+	// maxPages limits how many pages of 100 episodes are fetched for one anime, enough for the longest running shows
+	maxPages = 15
+	// cacheTTL is how long an anime's episodes are kept before they are fetched again.  Filler lists rarely change.
+	cacheTTL = 24 * time.Hour
+)
+
+// Kind is what an episode is, as far as skipping it goes
+type Kind string
+
+const (
+	Canon  Kind = ""       // Part of the story
+	Filler Kind = "filler" // Not adapted from the source material
+	Recap  Kind = "recap"  // Mostly made of earlier episodes
+)
+
+// Episodes are the kinds of an anime's episodes by episode number.  Canon episodes are left out.
+type Episodes map[int]Kind
+
+// Kind returns what the episode is, Canon if it isn't known to be anything else
+func (e Episodes) Kind(episode int) Kind {
+	return e[episode]
+}
+
+// Client looks up filler episodes on Jikan
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	retry      retry.Policy
+
+	mu    sync.Mutex
+	cache map[int]cachedEpisodes // MyAnimeList ID to the episodes fetched for it
+}
+
+type cachedEpisodes struct {
+	episodes  Episodes
+	fetchedAt time.Time
+}
+
+// NewClient creates a Jikan client
+func NewClient(retryConfig config.RetryConfig) *Client {
+	return &Client{
+		httpClient: &http.Client{
+			Timeout:   15 * time.Second,
+			Transport: retry.NewTransport(proxy.Transport()),
+		},
+		baseURL: jikanAPIURL,
+		retry:   retry.FromConfig(retryConfig),
+		cache:   make(map[int]cachedEpisodes),
+	}
+}
+
+// episodesResponse is a page of an anime/{id}/episodes response
+type episodesResponse struct {
+	Data []struct {
+		Number int  `json:"mal_id"`
+		Filler bool `json:"filler"`
+		Recap  bool `json:"recap"`
+	} `json:"data"`
+	Pagination struct {
+		HasNextPage bool `json:"has_next_page"`
+	} `json:"pagination"`
+}
+
+// Episodes returns the filler and recap episodes of the anime with the given MyAnimeList ID.  Results are cached for a
+// day.
+func (c *Client) Episodes(ctx context.Context, malID int) (Episodes, error) {
+	c.mu.Lock()
+	cached, ok := c.cache[malID]
+	c.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < cacheTTL {
+		diagnostics.RecordAPICall(diagnostics.APICall{API: diagnostics.APIJikan, Operation: "episodes",
+			StartedAt: time.Now(), Cached: true})
+		return cached.episodes, nil
+	}
+
+	episodes := make(Episodes)
+	for page := 1; page <= maxPages; page++ {
+		var response episodesResponse
+		path := fmt.Sprintf("/anime/%d/episodes?page=%d", malID, page)
+		err := c.retry.Do(ctx, "jikan episodes", func() error {
+			return c.get(ctx, path, &response)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, episode := range response.Data {
+			switch {
+			case episode.Filler:
+				episodes[episode.Number] = Filler
+			case episode.Recap:
+				episodes[episode.Number] = Recap
+			}
+		}
+		if !response.Pagination.HasNextPage {
+			break
+		}
+	}
+
+	c.mu.Lock()
+	c.cache[malID] = cachedEpisodes{episodes: episodes, fetchedAt: time.Now()}
+	c.mu.Unlock()
+	return episodes, nil
+}
+
+// get sends a request to the Jikan API, decoding the response into result
+func (c *Client) get(ctx context.Context, path string, result interface{}) error {
+	start := time.Now()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	callErr := err
+	if err == nil && resp.StatusCode != http.StatusOK {
+		callErr = fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	diagnostics.TrackAPICall(diagnostics.APIJikan, "episodes", start, callErr)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("jikan returned HTTP %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
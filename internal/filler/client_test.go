@@ -0,0 +1,57 @@
+package filler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEpisodes(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/anime/20/episodes", r.URL.Path)
+		switch r.URL.Query().Get("page") {
+		case "1":
+			_, _ = w.Write([]byte(`{"data":[{"mal_id":1},{"mal_id":2,"filler":true},{"mal_id":3,"recap":true}],
+				"pagination":{"has_next_page":true}}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"data":[{"mal_id":4,"filler":true,"recap":true}],"pagination":{"has_next_page":false}}`))
+		default:
+			t.Errorf("unexpected page %s", r.URL.Query().Get("page"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(config.RetryConfig{})
+	client.baseURL = server.URL
+
+	episodes, err := client.Episodes(context.Background(), 20)
+	require.NoError(t, err)
+	assert.Equal(t, Episodes{2: Filler, 3: Recap, 4: Filler}, episodes)
+	assert.Equal(t, Canon, episodes.Kind(1))
+	assert.Equal(t, 2, requests)
+
+	_, err = client.Episodes(context.Background(), 20)
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests, "episodes should be served from the cache")
+}
+
+func TestEpisodesError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(config.RetryConfig{})
+	client.baseURL = server.URL
+
+	_, err := client.Episodes(context.Background(), 1)
+	assert.ErrorContains(t, err, fmt.Sprintf("HTTP %d", http.StatusNotFound))
+}
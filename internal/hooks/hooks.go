@@ -0,0 +1,126 @@
+// Package hooks runs the commands users configure to run when things happen in Hisame, such as finishing an episode,
+// so they can automate notifications, media server refreshes and the like.  Hooks run through the shell, with what
+// happened described in HISAME_* environment variables.
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// hookTimeout is how long a hook can run before it is killed
+const hookTimeout = time.Minute
+
+// Names of the hooks, passed to them as HISAME_HOOK
+const (
+	BeforePlayback   = "before_playback"
+	PlaybackComplete = "on_playback_complete"
+	ProgressUpdate   = "on_progress_update"
+)
+
+// ErrAborted is returned by RunBefore when the hook stopped what it was run before
+var ErrAborted = errors.New("stopped by hook")
+
+// Event describes what a hook is being run for
+type Event struct {
+	Hook            string // Name of the hook, one of the constants above
+	AnimeID         int    // AniList ID of the anime
+	Title           string
+	Episode         int     // Episode played, or for progress updates the episode progress is now at
+	Progress        int     // Episodes of the anime watched
+	TotalEpisodes   int     // 0 if not known
+	PlaybackPercent float64 // How far through the episode playback got, for playback hooks
+	StreamURL       string  // URL or file the episode is played from, for before_playback
+}
+
+// environ returns the event as the environment variables a hook is run with
+func (e Event) environ() []string {
+	env := []string{
+		"HISAME_HOOK=" + e.Hook,
+		"HISAME_ANIME_ID=" + strconv.Itoa(e.AnimeID),
+		"HISAME_ANIME_TITLE=" + e.Title,
+		"HISAME_EPISODE=" + strconv.Itoa(e.Episode),
+		"HISAME_PROGRESS=" + strconv.Itoa(e.Progress),
+		"HISAME_TOTAL_EPISODES=" + strconv.Itoa(e.TotalEpisodes),
+	}
+	if e.Hook == PlaybackComplete {
+		env = append(env, "HISAME_PLAYBACK_PERCENT="+strconv.FormatFloat(e.PlaybackPercent, 'f', 0, 64))
+	}
+	if e.StreamURL != "" {
+		env = append(env, "HISAME_STREAM_URL="+e.StreamURL)
+	}
+	return env
+}
+
+// Run runs the hook command in the background.  An empty command does nothing.  Hooks failing is logged, but otherwise
+// doesn't affect Hisame.
+func Run(command string, event Event) {
+	if strings.TrimSpace(command) == "" {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+		defer cancel()
+		if output, err := run(ctx, command, event); err != nil {
+			log.Warn("Hook failed", "hook", event.Hook, "error", err, "output", output)
+		}
+	}()
+}
+
+// RunBefore runs the hook command and waits for it to finish, for hooks that can stop what they are run before.  If
+// the hook exits non-zero, or can't be run at all, ErrAborted is returned with the hook's output as the reason.  An
+// empty command does nothing.
+func RunBefore(ctx context.Context, command string, event Event) error {
+	if strings.TrimSpace(command) == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, hookTimeout)
+	defer cancel()
+
+	output, err := run(ctx, command, event)
+	if err == nil {
+		return nil
+	}
+	log.Info("Hook stopped what it was run before", "hook", event.Hook, "error", err, "output", output)
+
+	reason := err.Error()
+	if output != "" {
+		// The last line is the most likely to say what went wrong
+		lines := strings.Split(output, "\n")
+		reason = strings.TrimSpace(lines[len(lines)-1])
+	}
+	return fmt.Errorf("%w: %s", ErrAborted, reason)
+}
+
+// run runs the hook command and waits for it to finish, returning what it printed
+func run(ctx context.Context, command string, event Event) (string, error) {
+	log.Info("Running hook", "hook", event.Hook, "command", command, "anime_id", event.AnimeID, "episode", event.Episode)
+
+	cmd := shellCommand(ctx, command)
+	cmd.Env = append(os.Environ(), event.environ()...)
+	out, err := cmd.CombinedOutput()
+	output := strings.TrimSpace(string(out))
+	if err != nil {
+		return output, err
+	}
+	log.Debug("Hook finished", "hook", event.Hook, "output", output)
+	return output, nil
+}
+
+// shellCommand runs the command through the platform's shell, so hooks can use pipes and expand the HISAME_*
+// variables
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
@@ -0,0 +1,72 @@
+package hooks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventEnviron(t *testing.T) {
+	event := Event{
+		Hook:            PlaybackComplete,
+		AnimeID:         154587,
+		Title:           "Frieren",
+		Episode:         5,
+		Progress:        4,
+		TotalEpisodes:   28,
+		PlaybackPercent: 91.6,
+	}
+	assert.Equal(t, []string{
+		"HISAME_HOOK=on_playback_complete",
+		"HISAME_ANIME_ID=154587",
+		"HISAME_ANIME_TITLE=Frieren",
+		"HISAME_EPISODE=5",
+		"HISAME_PROGRESS=4",
+		"HISAME_TOTAL_EPISODES=28",
+		"HISAME_PLAYBACK_PERCENT=92",
+	}, event.environ())
+
+	event.Hook = ProgressUpdate
+	assert.NotContains(t, event.environ(), "HISAME_PLAYBACK_PERCENT=92", "only playback hooks have a playback percent")
+}
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks run through sh in this test")
+	}
+
+	out := filepath.Join(t.TempDir(), "out")
+	_, err := run(context.Background(), `echo "$HISAME_ANIME_TITLE $HISAME_EPISODE" > "`+out+`"`,
+		Event{Hook: ProgressUpdate, Title: "Frieren", Episode: 5})
+	require.NoError(t, err)
+	written, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "Frieren 5\n", string(written))
+
+	output, err := run(context.Background(), "echo broken >&2; exit 3", Event{Hook: ProgressUpdate})
+	assert.Error(t, err)
+	assert.Equal(t, "broken", output)
+}
+
+func TestRunBefore(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks run through sh in this test")
+	}
+	event := Event{Hook: BeforePlayback, StreamURL: "https://example.com/ep.m3u8"}
+
+	require.NoError(t, RunBefore(context.Background(), "", event), "no hook should let playback go ahead")
+	require.NoError(t, RunBefore(context.Background(), `test "$HISAME_STREAM_URL" = https://example.com/ep.m3u8`, event))
+
+	err := RunBefore(context.Background(), "echo checking VPN; echo VPN is down; exit 1", event)
+	assert.ErrorIs(t, err, ErrAborted)
+	assert.EqualError(t, err, "stopped by hook: VPN is down", "the last line printed should be the reason")
+
+	err = RunBefore(context.Background(), "exit 2", event)
+	assert.ErrorIs(t, err, ErrAborted)
+	assert.ErrorContains(t, err, "exit status 2")
+}
@@ -0,0 +1,48 @@
+// Package inhibit keeps the system from going to sleep, and the screen from turning off, while an episode plays.  It
+// uses logind and the desktop's screensaver over D-Bus on Linux, as systemd-inhibit does, caffeinate on macOS and
+// SetThreadExecutionState on Windows.  On other platforms inhibiting does nothing.
+package inhibit
+
+import (
+	"sync"
+
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+var (
+	mu      sync.Mutex
+	release func() // Releases the inhibitor held, nil if none is
+
+	// start takes an inhibitor for the platform, returning the function that releases it
+	start = inhibit
+)
+
+// Acquire keeps the system and screen awake until Release is called.  Acquiring while already holding an inhibitor
+// does nothing.  Failing to inhibit, such as on a system without logind, is logged and otherwise ignored.
+func Acquire(reason string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if release != nil {
+		return
+	}
+	r, err := start(reason)
+	if err != nil {
+		log.Info("Unable to keep the system awake during playback", "reason", err)
+		return
+	}
+	release = r
+	log.Debug("Inhibiting sleep", "reason", reason)
+}
+
+// Release lets the system and screen sleep again
+func Release() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if release != nil {
+		release()
+		release = nil
+		log.Debug("Released sleep inhibitor")
+	}
+}
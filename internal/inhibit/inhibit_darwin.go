@@ -0,0 +1,23 @@
+//go:build darwin
+
+package inhibit
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+// inhibit runs caffeinate to keep the display and system awake.  caffeinate is told to watch Hisame's process, so it
+// exits with Hisame rather than being left behind.
+func inhibit(reason string) (func(), error) {
+	cmd := exec.Command("caffeinate", "-dis", "-w", strconv.Itoa(os.Getpid()))
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start caffeinate: %w", err)
+	}
+	return func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}, nil
+}
@@ -0,0 +1,61 @@
+//go:build linux
+
+package inhibit
+
+import (
+	"fmt"
+	"syscall"
+
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/godbus/dbus/v5"
+)
+
+// inhibit takes a logind inhibitor lock against sleeping and idling, which lasts until the file descriptor it hands
+// back is closed, so it is released even if Hisame crashes.  Desktops that blank the screen without asking logind are
+// also asked to hold off through the screensaver interface, which is best effort.
+func inhibit(reason string) (func(), error) {
+	system, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the system bus: %w", err)
+	}
+
+	var fd dbus.UnixFD
+	err = system.Object("org.freedesktop.login1", "/org/freedesktop/login1").
+		Call("org.freedesktop.login1.Manager.Inhibit", 0, "sleep:idle", "Hisame", reason, "block").
+		Store(&fd)
+	if err != nil {
+		_ = system.Close()
+		return nil, fmt.Errorf("logind refused the inhibitor lock: %w", err)
+	}
+
+	releaseScreen := inhibitScreensaver(reason)
+	return func() {
+		releaseScreen()
+		if err := syscall.Close(int(fd)); err != nil {
+			log.Warn("Failed to release logind inhibitor lock", "error", err)
+		}
+		_ = system.Close()
+	}, nil
+}
+
+// inhibitScreensaver asks the desktop's screensaver not to blank the screen.  The session bus connection is kept open
+// until it is released, as screensavers drop inhibitors of clients that disconnect.
+func inhibitScreensaver(reason string) func() {
+	session, err := dbus.ConnectSessionBus()
+	if err != nil {
+		log.Debug("No session bus to inhibit the screensaver over", "error", err)
+		return func() {}
+	}
+
+	screensaver := session.Object("org.freedesktop.ScreenSaver", "/org/freedesktop/ScreenSaver")
+	var cookie uint32
+	if err := screensaver.Call("org.freedesktop.ScreenSaver.Inhibit", 0, "Hisame", reason).Store(&cookie); err != nil {
+		log.Debug("Screensaver can't be inhibited", "error", err)
+		_ = session.Close()
+		return func() {}
+	}
+	return func() {
+		_ = screensaver.Call("org.freedesktop.ScreenSaver.UnInhibit", 0, cookie).Err
+		_ = session.Close()
+	}
+}
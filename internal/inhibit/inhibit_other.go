@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !windows
+
+package inhibit
+
+import "errors"
+
+// inhibit fails, as there is no known way to keep other platforms awake
+func inhibit(reason string) (func(), error) {
+	return nil, errors.New("inhibiting sleep is not supported on this platform")
+}
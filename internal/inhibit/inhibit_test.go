@@ -0,0 +1,49 @@
+package inhibit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeInhibit swaps the platform inhibitor for one that counts how often it is taken and released
+func fakeInhibit(t *testing.T, err error) (acquired, released *int) {
+	acquired, released = new(int), new(int)
+	original := start
+	start = func(reason string) (func(), error) {
+		if err != nil {
+			return nil, err
+		}
+		*acquired++
+		return func() { *released++ }, nil
+	}
+	t.Cleanup(func() {
+		Release()
+		start = original
+	})
+	return acquired, released
+}
+
+func TestAcquireRelease(t *testing.T) {
+	acquired, released := fakeInhibit(t, nil)
+
+	Acquire("Playing an episode")
+	Acquire("Playing another episode")
+	assert.Equal(t, 1, *acquired, "an inhibitor already held should be kept")
+
+	Release()
+	Release()
+	assert.Equal(t, 1, *released, "releasing with nothing held should do nothing")
+
+	Acquire("Playing an episode")
+	assert.Equal(t, 2, *acquired, "a new inhibitor should be taken once the last was released")
+}
+
+func TestAcquireFailure(t *testing.T) {
+	_, released := fakeInhibit(t, errors.New("no logind"))
+
+	Acquire("Playing an episode")
+	Release()
+	assert.Zero(t, *released)
+}
@@ -0,0 +1,49 @@
+//go:build windows
+
+package inhibit
+
+import (
+	"errors"
+	"runtime"
+	"syscall"
+)
+
+// SetThreadExecutionState flags
+const (
+	esContinuous      = 0x80000000
+	esSystemRequired  = 0x00000001
+	esDisplayRequired = 0x00000002
+)
+
+var setThreadExecutionState = syscall.NewLazyDLL("kernel32.dll").NewProc("SetThreadExecutionState")
+
+// inhibit keeps the system and display awake with SetThreadExecutionState.  The state belongs to the thread that set
+// it, so a goroutine locked to its thread holds it until it is released.
+func inhibit(reason string) (func(), error) {
+	if err := setThreadExecutionState.Find(); err != nil {
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	started := make(chan error, 1)
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		if previous, _, _ := setThreadExecutionState.Call(esContinuous | esSystemRequired | esDisplayRequired); previous == 0 {
+			started <- errors.New("SetThreadExecutionState failed")
+			return
+		}
+		started <- nil
+
+		<-done
+		setThreadExecutionState.Call(esContinuous)
+	}()
+
+	if err := <-started; err != nil {
+		return nil, err
+	}
+	return func() {
+		close(done)
+	}, nil
+}
@@ -0,0 +1,91 @@
+// Package localfiles finds anime episodes among files on disk, such as a folder of previously downloaded episodes
+package localfiles
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Episode is a single episode file found on disk
+type Episode struct {
+	Path         string
+	Title        string // The show's title as written in the filename or its folder
+	Season       int    // The season the filename or its folder gives, 0 if neither does
+	Number       int    // The episode number
+	Version      int    // Version of the release, 0 if not given
+	ReleaseGroup string
+	Resolution   string
+}
+
+// bracketedPattern matches release group, resolution and checksum tags such as "[Group]", "(1080p)" or "{CRC}"
+var bracketedPattern = regexp.MustCompile(`\[[^\]]*\]|\([^)]*\)|\{[^}]*\}`)
+
+// ParseFilename works out the show and episode number of an episode file from its name, e.g. "[Group] Show Name -
+// 05 (1080p).mkv" is episode 5 of "Show Name".  Reports false if no episode number can be found.  The title may be
+// empty if the name is only an episode number, in which case the caller should fall back to the folder name.
+func ParseFilename(name string) (title string, number int, ok bool) {
+	e := Parse(name)
+	return e.Title, e.Episode, e.Episode > 0
+}
+
+// ParseFolderName works out a show's title and season from the name of the folder its episodes are in, e.g.
+// "[Group] Show Name Season 2 (1080p)" is season 2 of "Show Name".  The season is 0 if the name doesn't give one.
+func ParseFolderName(name string) (title string, season int) {
+	name = bracketedPattern.ReplaceAllString(name, " ")
+	return extractSeason(splitWords(name))
+}
+
+// cleanTitle trims separators left around a title once the episode number is cut off
+func cleanTitle(title string) string {
+	return strings.Trim(title, " -_.")
+}
+
+// NormalizeTitle reduces a title to lowercase words of letters and digits, so titles written with different
+// punctuation and spacing can be compared
+func NormalizeTitle(title string) string {
+	title = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, title)
+	return strings.Join(strings.Fields(title), " ")
+}
+
+// TitleKeys returns the normalized titles a show's season could be listed under, so "Show Name" season 2 can be
+// matched to "Show Name Season 2" or "Show Name 2nd Season".  Titles without a season, or the first season, are
+// only listed under the title itself.
+func TitleKeys(title string, season int) []string {
+	base := NormalizeTitle(title)
+	if base == "" {
+		return nil
+	}
+	if season <= 1 {
+		return []string{base}
+	}
+	n := strconv.Itoa(season)
+	return []string{
+		base + " season " + n,
+		base + " " + ordinal(season) + " season",
+		base + " " + n,
+		base + " s" + n,
+	}
+}
+
+// ordinal writes a number as an ordinal, e.g. "2nd"
+func ordinal(n int) string {
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
@@ -0,0 +1,86 @@
+package localfiles
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilename(t *testing.T) {
+	tests := []struct {
+		name   string
+		title  string
+		number int
+	}{
+		{"[Group] Show Name - 05 (1080p).mkv", "Show Name", 5},
+		{"[Group] Show Name - 05v2 [ABCD1234].mkv", "Show Name", 5},
+		{"Show Name S02E11 1080p WEB.mkv", "Show Name", 11},
+		{"Show.Name.E07.mp4", "Show Name", 7},
+		{"Show_Name_-_12.mkv", "Show Name", 12},
+		{"Show Name Episode 3.mp4", "Show Name", 3},
+		{"Show Name 24.mkv", "Show Name", 24},
+		{"Mob Psycho 100 - 02.mkv", "Mob Psycho 100", 2},
+		{"05.mkv", "", 5},
+	}
+
+	for _, tt := range tests {
+		title, number, ok := ParseFilename(tt.name)
+		assert.True(t, ok, tt.name)
+		assert.Equal(t, tt.title, title, tt.name)
+		assert.Equal(t, tt.number, number, tt.name)
+	}
+
+	_, _, ok := ParseFilename("Show Name Movie.mkv")
+	assert.False(t, ok)
+}
+
+func TestScan(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "[Group] Other Show"), 0o755))
+	for _, name := range []string{
+		"Show Name - 01.mkv",
+		"Show Name - 02.mkv",
+		"notes.txt",
+		filepath.Join("[Group] Other Show", "03.mkv"),
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+	}
+
+	episodes, err := Scan(dir)
+	require.NoError(t, err)
+	require.Len(t, episodes, 3)
+	assert.Equal(t, Episode{Path: filepath.Join(dir, "Show Name - 01.mkv"), Title: "Show Name", Number: 1}, episodes[0])
+	assert.Equal(t, "Other Show", episodes[2].Title, "the title should come from the folder")
+	assert.Equal(t, 3, episodes[2].Number)
+}
+
+func TestFindEpisode(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "Show Name Season 2"), 0o755))
+	for _, name := range []string{
+		"[Group] Show Name - 03.mkv",
+		"[Group] Show Name - 03v2.mkv",
+		"[Group] Other Show - 03.mkv",
+		filepath.Join("Show Name Season 2", "03.mkv"),
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+	}
+
+	episode, ok := FindEpisode([]string{dir, filepath.Join(dir, "missing")}, []string{"Show Name", "Another Title"}, 3)
+	require.True(t, ok)
+	assert.Equal(t, filepath.Join(dir, "[Group] Show Name - 03v2.mkv"), episode.Path, "the latest version should be preferred")
+
+	episode, ok = FindEpisode([]string{dir}, []string{"Show Name 2nd Season"}, 3)
+	require.True(t, ok)
+	assert.Equal(t, filepath.Join(dir, "Show Name Season 2", "03.mkv"), episode.Path)
+
+	_, ok = FindEpisode([]string{dir}, []string{"Show Name"}, 4)
+	assert.False(t, ok)
+}
+
+func TestNormalizeTitle(t *testing.T) {
+	assert.Equal(t, "show name 2nd season", NormalizeTitle("Show  Name: 2nd Season!"))
+}
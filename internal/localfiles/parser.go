@@ -0,0 +1,335 @@
+package localfiles
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Elements are the parts of a release's filename, e.g. "[Group] Show Name S2 - 05v2 (1080p) [ABCD1234].mkv"
+type Elements struct {
+	Title        string // The show's title, empty if the name has none
+	ReleaseGroup string
+	Season       int    // 0 if not given
+	Episode      int    // 0 if no episode number was found
+	EpisodeEnd   int    // Last episode of a batch, e.g. 12 for "01-12".  0 for a single episode
+	Version      int    // Version of the release, e.g. 2 for "05v2".  0 if not given
+	Resolution   string // e.g. "1080p"
+	Source       string // e.g. "WEB" or "BD"
+	Checksum     string // CRC32 of the file, e.g. "ABCD1234"
+	Year         int
+	Extension    string // Lowercase, without the dot
+}
+
+// token is a run of text from a filename, either inside a pair of brackets or between them
+type token struct {
+	text     string
+	enclosed bool
+}
+
+// brackets pairs each opening bracket with its closing one
+var brackets = map[rune]rune{'[': ']', '(': ')', '{': '}', '【': '】'}
+
+var (
+	resolutionPattern = regexp.MustCompile(`(?i)^(?:\d{3,4}[pi]|\d{3,4}x\d{3,4}|4k)$`)
+	checksumPattern   = regexp.MustCompile(`^[0-9A-Fa-f]{8}$`)
+	yearPattern       = regexp.MustCompile(`^(?:19[5-9]\d|20\d\d)$`)
+	// seasonEpisodePattern matches "S01E05", "S01E05v2" and "S01E01-E12"
+	seasonEpisodePattern = regexp.MustCompile(`(?i)^S(\d{1,2})E(\d{1,4})(?:v(\d))?(?:-E?(\d{1,4}))?$`)
+	// episodeNumberPattern matches "05", "E05", "EP05", "05v2" and "01-12"
+	episodeNumberPattern = regexp.MustCompile(`(?i)^(?:ep?\.?)?(\d{1,4})(?:v(\d))?(?:-(\d{1,4})(?:v\d)?)?$`)
+	// trailingEpisodePattern matches an episode number ending a name, which is kept to three digits so a year isn't
+	// taken for one
+	trailingEpisodePattern = regexp.MustCompile(`(?i)^\d{1,3}(?:v\d)?(?:-\d{1,3})?$`)
+	seasonPattern          = regexp.MustCompile(`(?i)^(?:S|Season)(\d{1,2})$`)
+	ordinalPattern         = regexp.MustCompile(`(?i)^(\d{1,2})(?:st|nd|rd|th)$`)
+)
+
+// sources are the keywords naming where a release was ripped from, and how Elements.Source writes them
+var sources = map[string]string{
+	"bd": "BD", "bdrip": "BD", "bdremux": "BD", "bluray": "BD", "blu-ray": "BD",
+	"dvd": "DVD", "dvdrip": "DVD",
+	"web": "WEB", "web-dl": "WEB", "webdl": "WEB", "webrip": "WEB",
+	"hdtv": "HDTV", "hdrip": "HDTV",
+}
+
+// keywords are the words describing a release rather than the show, such as codecs and subtitle languages.  The
+// title ends at the first one.
+var keywords = map[string]bool{
+	// Video
+	"x264": true, "x265": true, "h264": true, "h.264": true, "h265": true, "h.265": true, "hevc": true, "avc": true,
+	"av1": true, "10bit": true, "10-bit": true, "8bit": true, "8-bit": true, "hi10": true, "hi10p": true, "hdr": true,
+	// Audio
+	"aac": true, "aac2.0": true, "flac": true, "ac3": true, "eac3": true, "opus": true, "mp3": true, "ddp": true,
+	"ddp2.0": true, "dual": true, "dual-audio": true, "multi-audio": true,
+	// Subtitles
+	"eng": true, "jpn": true, "multi": true, "multi-sub": true, "multi-subs": true, "multisub": true, "multisubs": true,
+	"subbed": true, "dubbed": true, "raw": true, "vostfr": true,
+	// Release
+	"batch": true, "complete": true, "end": true, "final": true, "uncensored": true, "uncut": true, "remastered": true,
+	"proper": true, "repack": true,
+}
+
+// videoFileExtensions are the extensions Parse removes from the end of a name
+var videoFileExtensions = map[string]bool{
+	".mkv": true, ".mp4": true, ".avi": true, ".webm": true, ".m4v": true, ".mov": true, ".wmv": true, ".ts": true,
+	".flv": true, ".ogm": true, ".rmvb": true,
+}
+
+// Parse splits a release's filename into its elements, in the manner of anitomy.  The name is split into bracketed
+// tags and the text between them.  Tags are sorted into the release group, checksum, year and release details, and
+// the episode number is found in the remaining text, which the title is taken from.
+func Parse(filename string) Elements {
+	var e Elements
+	name := filename
+	if ext := filepath.Ext(name); videoFileExtensions[strings.ToLower(ext)] {
+		e.Extension = strings.ToLower(ext[1:])
+		name = strings.TrimSuffix(name, ext)
+	}
+
+	var free, unknown []string
+	for i, tok := range tokenize(name) {
+		if !tok.enclosed {
+			free = append(free, tok.text)
+			continue
+		}
+		content := strings.TrimSpace(tok.text)
+		switch {
+		case content == "":
+		case checksumPattern.MatchString(content) && !isNumber(content):
+			e.Checksum = strings.ToUpper(content)
+		case yearPattern.MatchString(content):
+			e.Year, _ = strconv.Atoi(content)
+		case e.identifyKeywords(strings.Fields(strings.NewReplacer(",", " ", "_", " ").Replace(content))):
+		case i == 0:
+			e.ReleaseGroup = content
+		default:
+			unknown = append(unknown, content)
+		}
+	}
+
+	text := strings.Join(free, " ")
+	if strings.TrimSpace(text) == "" {
+		// Names like "[Group][Show Name][05]" put everything in brackets
+		text = strings.Join(unknown, " ")
+		unknown = nil
+	}
+	words := splitWords(text)
+
+	// Everything from the first keyword on describes the release
+	for i, word := range words {
+		if isKeyword(word) {
+			e.identifyKeywords(words[i:])
+			words = words[:i]
+			break
+		}
+	}
+
+	titleEnd := e.findEpisode(words)
+	if e.Episode == 0 {
+		// Some names bracket the episode number, e.g. "Show Name [05]"
+		for _, content := range unknown {
+			if e.setEpisode(content) {
+				break
+			}
+		}
+	}
+
+	var season int
+	e.Title, season = extractSeason(words[:titleEnd])
+	if e.Season == 0 {
+		e.Season = season
+	}
+	return e
+}
+
+// tokenize splits a name into bracketed tokens and the text between them.  Text with nothing but spaces is dropped.
+func tokenize(name string) []token {
+	var tokens []token
+	add := func(text string, enclosed bool) {
+		if enclosed || strings.TrimSpace(text) != "" {
+			tokens = append(tokens, token{text: text, enclosed: enclosed})
+		}
+	}
+
+	runes := []rune(name)
+	start := 0
+	for i := 0; i < len(runes); i++ {
+		closing, ok := brackets[runes[i]]
+		if !ok {
+			continue
+		}
+		end := -1
+		for j := i + 1; j < len(runes); j++ {
+			if runes[j] == closing {
+				end = j
+				break
+			}
+		}
+		if end == -1 {
+			// An unclosed bracket is left as text
+			continue
+		}
+		add(string(runes[start:i]), false)
+		add(string(runes[i+1:end]), true)
+		start = end + 1
+		i = end
+	}
+	add(string(runes[start:]), false)
+	return tokens
+}
+
+// splitWords splits the text outside brackets into words.  Underscores always separate words, and dots do too when a
+// name has no spaces, e.g. "Show.Name.05".
+func splitWords(text string) []string {
+	if !strings.Contains(strings.TrimSpace(text), " ") {
+		text = strings.ReplaceAll(text, ".", " ")
+	}
+	return strings.Fields(strings.ReplaceAll(text, "_", " "))
+}
+
+// identifyKeywords records the resolution and source among words describing a release.  Scene style names can end
+// with the release group after the last keyword, e.g. "x264-Group".  Reports whether any word was a keyword.
+func (e *Elements) identifyKeywords(words []string) bool {
+	found := false
+	for _, word := range words {
+		lower := strings.ToLower(word)
+		switch {
+		case resolutionPattern.MatchString(word):
+			e.Resolution = lower
+			found = true
+		case sources[lower] != "":
+			e.Source = sources[lower]
+			found = true
+		case keywords[lower]:
+			found = true
+		default:
+			if prefix, group, ok := strings.Cut(word, "-"); ok && isKeyword(prefix) && group != "" {
+				e.identifyKeywords([]string{prefix})
+				if e.ReleaseGroup == "" {
+					e.ReleaseGroup = group
+				}
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+// findEpisode looks for the episode number among the words of a name, most specific form first, and returns where the
+// title ends.  If no episode number is found the whole name is the title.
+func (e *Elements) findEpisode(words []string) int {
+	// "Show Name S01E05"
+	for i, word := range words {
+		if match := seasonEpisodePattern.FindStringSubmatch(word); match != nil {
+			if number, _ := strconv.Atoi(match[2]); number > 0 {
+				e.Season, _ = strconv.Atoi(match[1])
+				e.Episode = number
+				e.Version, _ = strconv.Atoi(match[3])
+				e.EpisodeEnd, _ = strconv.Atoi(match[4])
+				return i
+			}
+		}
+	}
+
+	// "Show Name - 05", "Show Name - Ep 5" and "Show Name -05"
+	for i, word := range words {
+		next := ""
+		switch {
+		case word == "-" && i+1 < len(words):
+			next = words[i+1]
+			if isEpisodeWord(next) && i+2 < len(words) {
+				next = words[i+2]
+			}
+		case strings.HasPrefix(word, "-"):
+			next = word[1:]
+		}
+		if next != "" && e.setEpisode(next) {
+			return i
+		}
+	}
+
+	// "Show Name Episode 5", "Show Name EP05" and "Show Name #5"
+	for i, word := range words {
+		if isEpisodeWord(word) && i+1 < len(words) && e.setEpisode(words[i+1]) {
+			return i
+		}
+		if len(word) > 1 && (word[0] == 'e' || word[0] == 'E' || word[0] == '#') && e.setEpisode(strings.TrimPrefix(word, "#")) {
+			return i
+		}
+	}
+
+	// "Show Name 05", so long as it isn't "Show Name Season 2"
+	if last := len(words) - 1; last >= 0 && trailingEpisodePattern.MatchString(words[last]) {
+		if (last == 0 || !strings.EqualFold(words[last-1], "season")) && e.setEpisode(words[last]) {
+			return last
+		}
+	}
+	return len(words)
+}
+
+// setEpisode sets the episode number, batch end and version from a word such as "05", "E05", "05v2" or "01-12".
+// Reports false if the word isn't an episode number.  Episode 0 is taken as not an episode, as progress starts at 1.
+func (e *Elements) setEpisode(word string) bool {
+	match := episodeNumberPattern.FindStringSubmatch(word)
+	if match == nil {
+		return false
+	}
+	number, _ := strconv.Atoi(match[1])
+	if number == 0 {
+		return false
+	}
+	e.Episode = number
+	e.Version, _ = strconv.Atoi(match[2])
+	e.EpisodeEnd, _ = strconv.Atoi(match[3])
+	return true
+}
+
+// extractSeason removes a season from the words of a title, as in "Show Name S2", "Show Name Season 2" or "Show Name
+// 2nd Season", returning the title that's left and the season.  The season is 0 if the title doesn't give one.
+func extractSeason(words []string) (string, int) {
+	season := 0
+	title := make([]string, 0, len(words))
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if match := seasonPattern.FindStringSubmatch(word); match != nil && i > 0 {
+			season, _ = strconv.Atoi(match[1])
+			continue
+		}
+		if strings.EqualFold(word, "season") && i > 0 && i+1 < len(words) && isNumber(words[i+1]) {
+			season, _ = strconv.Atoi(words[i+1])
+			i++
+			continue
+		}
+		if match := ordinalPattern.FindStringSubmatch(word); match != nil && i+1 < len(words) &&
+			strings.EqualFold(words[i+1], "season") {
+			season, _ = strconv.Atoi(match[1])
+			i++
+			continue
+		}
+		title = append(title, word)
+	}
+	return cleanTitle(strings.Join(title, " ")), season
+}
+
+// isKeyword reports whether a word describes a release rather than the show
+func isKeyword(word string) bool {
+	lower := strings.ToLower(word)
+	return keywords[lower] || sources[lower] != "" || resolutionPattern.MatchString(word)
+}
+
+// isEpisodeWord reports whether a word introduces an episode number, as "Episode" does in "Episode 5"
+func isEpisodeWord(word string) bool {
+	switch strings.ToLower(word) {
+	case "episode", "ep", "ep.", "e":
+		return true
+	}
+	return false
+}
+
+// isNumber reports whether a word is made only of digits
+func isNumber(word string) bool {
+	_, err := strconv.Atoi(word)
+	return err == nil
+}
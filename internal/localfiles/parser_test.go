@@ -0,0 +1,83 @@
+package localfiles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected Elements
+	}{
+		{
+			"[SubsPlease] Sousou no Frieren - 05 (1080p) [ABCD1234].mkv",
+			Elements{Title: "Sousou no Frieren", ReleaseGroup: "SubsPlease", Episode: 5, Resolution: "1080p",
+				Checksum: "ABCD1234", Extension: "mkv"},
+		},
+		{
+			"[Group] Vinland Saga S2 - 03v2 [1080p HEVC 10bit][Multi-Subs].mkv",
+			Elements{Title: "Vinland Saga", ReleaseGroup: "Group", Season: 2, Episode: 3, Version: 2,
+				Resolution: "1080p", Extension: "mkv"},
+		},
+		{
+			"Vinland.Saga.S02E11.1080p.WEB-DL.x264-Group.mkv",
+			Elements{Title: "Vinland Saga", ReleaseGroup: "Group", Season: 2, Episode: 11, Resolution: "1080p",
+				Source: "WEB", Extension: "mkv"},
+		},
+		{
+			"[Group] Show Name 2nd Season - 01-12 [BD 1080p] [Batch]",
+			Elements{Title: "Show Name", ReleaseGroup: "Group", Season: 2, Episode: 1, EpisodeEnd: 12,
+				Resolution: "1080p", Source: "BD"},
+		},
+		{
+			"[Group][Show Name][07][720p].mp4",
+			Elements{Title: "Show Name", ReleaseGroup: "Group", Episode: 7, Resolution: "720p", Extension: "mp4"},
+		},
+		{
+			"Show Name (2019) - Ep 4 [Dual Audio].mkv",
+			Elements{Title: "Show Name", Episode: 4, Year: 2019, Extension: "mkv"},
+		},
+		{
+			"Show Name - The Subtitle - 08.mkv",
+			Elements{Title: "Show Name - The Subtitle", Episode: 8, Extension: "mkv"},
+		},
+		{
+			"Show Name Season 3 #2.mkv",
+			Elements{Title: "Show Name", Season: 3, Episode: 2, Extension: "mkv"},
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, Parse(tt.name), tt.name)
+	}
+}
+
+func TestParseWithoutEpisode(t *testing.T) {
+	e := Parse("Show Name Season 2.mkv")
+	assert.Zero(t, e.Episode, "the season number shouldn't be taken for an episode")
+	assert.Equal(t, "Show Name", e.Title)
+	assert.Equal(t, 2, e.Season)
+
+	e = Parse("[Group] Show Name 2019 [1080p].mkv")
+	assert.Zero(t, e.Episode, "a year shouldn't be taken for an episode")
+}
+
+func TestParseFolderName(t *testing.T) {
+	title, season := ParseFolderName("[Group] Show Name Season 2 (1080p)")
+	assert.Equal(t, "Show Name", title)
+	assert.Equal(t, 2, season)
+
+	title, season = ParseFolderName("Mob Psycho 100")
+	assert.Equal(t, "Mob Psycho 100", title)
+	assert.Zero(t, season)
+}
+
+func TestTitleKeys(t *testing.T) {
+	assert.Equal(t, []string{"show name"}, TitleKeys("Show Name", 1))
+	assert.Contains(t, TitleKeys("Show Name", 2), "show name 2nd season")
+	assert.Contains(t, TitleKeys("Show Name", 2), "show name season 2")
+	assert.Contains(t, TitleKeys("Show Name", 12), "show name 12th season")
+	assert.Nil(t, TitleKeys("", 2))
+}
@@ -0,0 +1,105 @@
+package localfiles
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// videoExtensions are the file extensions treated as episodes
+var videoExtensions = map[string]bool{
+	".mkv": true, ".mp4": true, ".avi": true, ".webm": true, ".m4v": true, ".mov": true, ".wmv": true,
+}
+
+// Scan walks the folder and its subfolders for video files and parses each one's show and episode number.  Files
+// whose names don't give an episode number are skipped.  A file named only by its episode number, e.g.
+// "Show Name/05.mkv", takes its title from the folder it is in, as does its season if the name doesn't give one.
+func Scan(dir string) ([]Episode, error) {
+	var episodes []Episode
+	skipped := 0
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// Keep going past folders that can't be read
+			log.Debug("Skipping unreadable path", "path", path, "error", err)
+			if entry != nil && entry.IsDir() && path != dir {
+				return fs.SkipDir
+			}
+			return err
+		}
+		if entry.IsDir() || !videoExtensions[strings.ToLower(filepath.Ext(path))] {
+			return nil
+		}
+
+		elements := Parse(entry.Name())
+		if elements.Episode == 0 {
+			skipped++
+			return nil
+		}
+		title, season := elements.Title, elements.Season
+		if title == "" || season == 0 {
+			folderTitle, folderSeason := ParseFolderName(filepath.Base(filepath.Dir(path)))
+			if title == "" {
+				title = folderTitle
+			}
+			if season == 0 {
+				season = folderSeason
+			}
+		}
+		if title == "" {
+			skipped++
+			return nil
+		}
+		episodes = append(episodes, Episode{
+			Path:         path,
+			Title:        title,
+			Season:       season,
+			Number:       elements.Episode,
+			Version:      elements.Version,
+			ReleaseGroup: elements.ReleaseGroup,
+			Resolution:   elements.Resolution,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Scanned folder for episodes", "dir", dir, "episodes", len(episodes), "skipped", skipped)
+	return episodes, nil
+}
+
+// FindEpisode looks through the folders for a file of the episode of a show known by any of the given names,
+// preferring the latest version of a release.  Folders that can't be scanned are skipped.
+func FindEpisode(dirs []string, names []string, number int) (Episode, bool) {
+	wanted := make(map[string]bool)
+	for _, name := range names {
+		if key := NormalizeTitle(name); key != "" {
+			wanted[key] = true
+		}
+	}
+
+	var best Episode
+	found := false
+	for _, dir := range dirs {
+		episodes, err := Scan(dir)
+		if err != nil {
+			log.Warn("Failed to scan folder for episodes", "dir", dir, "error", err)
+			continue
+		}
+		for _, episode := range episodes {
+			if episode.Number != number || (found && episode.Version <= best.Version) {
+				continue
+			}
+			for _, key := range TitleKeys(episode.Title, episode.Season) {
+				if wanted[key] {
+					best, found = episode, true
+					break
+				}
+			}
+		}
+	}
+	return best, found
+}
@@ -0,0 +1,109 @@
+// Package mpris publishes what Hisame is playing over MPRIS, the D-Bus interface Linux desktops use for media widgets
+// and tools such as playerctl.  On other platforms publishing does nothing.
+package mpris
+
+import (
+	"sync"
+
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// Track describes the episode being played
+type Track struct {
+	AnimeID       int
+	AnimeTitle    string
+	EpisodeNumber int
+	ArtURL        string // Cover image of the anime.  Empty if there isn't one
+	Length        int    // Length of the episode in seconds, 0 if unknown
+}
+
+// Controls are the playback controls media widgets can use.  The player service implements them.
+type Controls interface {
+	// TogglePause pauses or resumes playback
+	TogglePause() error
+	// StopPlayback stops the player
+	StopPlayback()
+}
+
+// publisher is what publishes the playback state, implemented over D-Bus on Linux
+type publisher interface {
+	publish(track Track, controls Controls)
+	setPaused(paused bool)
+	setPosition(seconds float64)
+	clear()
+	close()
+}
+
+var (
+	mu      sync.Mutex
+	current publisher
+)
+
+// Start connects to the session bus so playback can be published.  Failing to connect, such as on a desktop without
+// D-Bus, is logged and leaves publishing turned off.
+func Start() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if current != nil {
+		return
+	}
+	p, err := newPublisher()
+	if err != nil {
+		log.Info("MPRIS unavailable, playback won't be shown in desktop media controls", "reason", err)
+		return
+	}
+	current = p
+	log.Info("Publishing playback over MPRIS")
+}
+
+// Close stops publishing playback
+func Close() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if current != nil {
+		current.close()
+		current = nil
+	}
+}
+
+// Publish shows the track as playing
+func Publish(track Track, controls Controls) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if current != nil {
+		current.publish(track, controls)
+	}
+}
+
+// SetPaused updates whether the published track is paused
+func SetPaused(paused bool) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if current != nil {
+		current.setPaused(paused)
+	}
+}
+
+// SetPosition updates how many seconds into the published track playback is
+func SetPosition(seconds float64) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if current != nil {
+		current.setPosition(seconds)
+	}
+}
+
+// Clear shows that nothing is playing
+func Clear() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if current != nil {
+		current.clear()
+	}
+}
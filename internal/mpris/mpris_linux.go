@@ -0,0 +1,270 @@
+//go:build linux
+
+package mpris
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/introspect"
+	"github.com/godbus/dbus/v5/prop"
+)
+
+const (
+	busName         = "org.mpris.MediaPlayer2.hisame"
+	objectPath      = dbus.ObjectPath("/org/mpris/MediaPlayer2")
+	rootInterface   = "org.mpris.MediaPlayer2"
+	playerInterface = "org.mpris.MediaPlayer2.Player"
+
+	statusPlaying = "Playing"
+	statusPaused  = "Paused"
+	statusStopped = "Stopped"
+)
+
+// dbusPublisher publishes playback on the session bus as an MPRIS media player
+type dbusPublisher struct {
+	conn  *dbus.Conn
+	props *prop.Properties
+
+	mu       sync.Mutex
+	controls Controls // Controls for the track playing, nil when nothing is
+}
+
+// newPublisher connects to the session bus and registers Hisame as a media player
+func newPublisher() (publisher, error) {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the session bus: %w", err)
+	}
+
+	p := &dbusPublisher{conn: conn}
+	if err := p.export(); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	reply, err := conn.RequestName(busName, dbus.NameFlagDoNotQueue)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to request bus name: %w", err)
+	}
+	if reply != dbus.RequestNameReplyPrimaryOwner {
+		_ = conn.Close()
+		return nil, errors.New("another Hisame is already publishing playback")
+	}
+	return p, nil
+}
+
+// export registers the MPRIS interfaces and their properties
+func (p *dbusPublisher) export() error {
+	root := mediaPlayerRoot{}
+	player := &mediaPlayerControls{publisher: p}
+	if err := p.conn.Export(root, objectPath, rootInterface); err != nil {
+		return fmt.Errorf("failed to export %s: %w", rootInterface, err)
+	}
+	// Seek is exported from SeekBy, as go vet expects a method named Seek to be an io.Seeker
+	if err := p.conn.ExportWithMap(player, map[string]string{"SeekBy": "Seek"}, objectPath, playerInterface); err != nil {
+		return fmt.Errorf("failed to export %s: %w", playerInterface, err)
+	}
+
+	props, err := prop.Export(p.conn, objectPath, prop.Map{
+		rootInterface: {
+			"CanQuit":             {Value: false, Emit: prop.EmitTrue},
+			"CanRaise":            {Value: false, Emit: prop.EmitTrue},
+			"HasTrackList":        {Value: false, Emit: prop.EmitTrue},
+			"Identity":            {Value: "Hisame", Emit: prop.EmitTrue},
+			"SupportedUriSchemes": {Value: []string{}, Emit: prop.EmitTrue},
+			"SupportedMimeTypes":  {Value: []string{}, Emit: prop.EmitTrue},
+		},
+		playerInterface: {
+			"PlaybackStatus": {Value: statusStopped, Emit: prop.EmitTrue},
+			"Rate":           {Value: 1.0, Emit: prop.EmitTrue},
+			"MinimumRate":    {Value: 1.0, Emit: prop.EmitTrue},
+			"MaximumRate":    {Value: 1.0, Emit: prop.EmitTrue},
+			"Volume":         {Value: 1.0, Emit: prop.EmitTrue},
+			"Metadata":       {Value: map[string]dbus.Variant{}, Emit: prop.EmitTrue},
+			// The spec has position changes left out of PropertiesChanged, as clients work it out from the rate
+			"Position":      {Value: int64(0), Emit: prop.EmitFalse},
+			"CanGoNext":     {Value: false, Emit: prop.EmitTrue},
+			"CanGoPrevious": {Value: false, Emit: prop.EmitTrue},
+			"CanPlay":       {Value: false, Emit: prop.EmitTrue},
+			"CanPause":      {Value: false, Emit: prop.EmitTrue},
+			"CanSeek":       {Value: false, Emit: prop.EmitTrue},
+			"CanControl":    {Value: true, Emit: prop.EmitFalse},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export properties: %w", err)
+	}
+	p.props = props
+
+	playerMethods := introspect.Methods(player)
+	for i := range playerMethods {
+		if playerMethods[i].Name == "SeekBy" {
+			playerMethods[i].Name = "Seek"
+		}
+	}
+	node := &introspect.Node{
+		Name: string(objectPath),
+		Interfaces: []introspect.Interface{
+			introspect.IntrospectData,
+			prop.IntrospectData,
+			{
+				Name:       rootInterface,
+				Methods:    introspect.Methods(root),
+				Properties: props.Introspection(rootInterface),
+			},
+			{
+				Name:       playerInterface,
+				Methods:    playerMethods,
+				Properties: props.Introspection(playerInterface),
+			},
+		},
+	}
+	if err := p.conn.Export(introspect.NewIntrospectable(node), objectPath, "org.freedesktop.DBus.Introspectable"); err != nil {
+		return fmt.Errorf("failed to export introspection data: %w", err)
+	}
+	return nil
+}
+
+func (p *dbusPublisher) publish(track Track, controls Controls) {
+	p.mu.Lock()
+	p.controls = controls
+	p.mu.Unlock()
+
+	p.set(playerInterface, "Metadata", metadata(track))
+	p.set(playerInterface, "Position", int64(0))
+	p.set(playerInterface, "CanPlay", true)
+	p.set(playerInterface, "CanPause", true)
+	p.set(playerInterface, "PlaybackStatus", statusPlaying)
+}
+
+func (p *dbusPublisher) setPaused(paused bool) {
+	if paused {
+		p.set(playerInterface, "PlaybackStatus", statusPaused)
+	} else {
+		p.set(playerInterface, "PlaybackStatus", statusPlaying)
+	}
+}
+
+func (p *dbusPublisher) setPosition(seconds float64) {
+	p.set(playerInterface, "Position", int64(seconds*1e6))
+}
+
+func (p *dbusPublisher) clear() {
+	p.mu.Lock()
+	p.controls = nil
+	p.mu.Unlock()
+
+	p.set(playerInterface, "PlaybackStatus", statusStopped)
+	p.set(playerInterface, "Metadata", map[string]dbus.Variant{})
+	p.set(playerInterface, "Position", int64(0))
+	p.set(playerInterface, "CanPlay", false)
+	p.set(playerInterface, "CanPause", false)
+}
+
+func (p *dbusPublisher) close() {
+	if _, err := p.conn.ReleaseName(busName); err != nil {
+		log.Debug("Failed to release MPRIS bus name", "error", err)
+	}
+	_ = p.conn.Close()
+}
+
+// set updates a property, emitting PropertiesChanged for it.  The properties are read only over D-Bus, so SetMust is
+// used rather than Set.  It panics if the change can't be emitted, such as when the bus connection drops, which
+// shouldn't take playback down with it.
+func (p *dbusPublisher) set(iface, name string, value interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Debug("Failed to update MPRIS property", "property", name, "error", r)
+		}
+	}()
+	p.props.SetMust(iface, name, value)
+}
+
+// playbackControls returns the controls for the playing track, and whether it is paused
+func (p *dbusPublisher) playbackControls() (Controls, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	paused := p.props.GetMust(playerInterface, "PlaybackStatus") == statusPaused
+	return p.controls, paused
+}
+
+// metadata describes the track in MPRIS's metadata format
+func metadata(track Track) map[string]dbus.Variant {
+	title := fmt.Sprintf("Episode %d", track.EpisodeNumber)
+	m := map[string]dbus.Variant{
+		"mpris:trackid":     dbus.MakeVariant(dbus.ObjectPath(fmt.Sprintf("/org/hisame/track/%d_%d", track.AnimeID, track.EpisodeNumber))),
+		"xesam:title":       dbus.MakeVariant(title),
+		"xesam:album":       dbus.MakeVariant(track.AnimeTitle),
+		"xesam:artist":      dbus.MakeVariant([]string{track.AnimeTitle}),
+		"xesam:trackNumber": dbus.MakeVariant(int32(track.EpisodeNumber)),
+	}
+	if track.ArtURL != "" {
+		m["mpris:artUrl"] = dbus.MakeVariant(track.ArtURL)
+	}
+	if track.Length > 0 {
+		m["mpris:length"] = dbus.MakeVariant(int64(track.Length) * 1e6)
+	}
+	if track.AnimeID != 0 {
+		m["xesam:url"] = dbus.MakeVariant(fmt.Sprintf("https://anilist.co/anime/%d", track.AnimeID))
+	}
+	return m
+}
+
+// mediaPlayerRoot implements the org.mpris.MediaPlayer2 methods.  Hisame can't be raised or quit from outside, as
+// CanRaise and CanQuit say.
+type mediaPlayerRoot struct{}
+
+func (mediaPlayerRoot) Raise() *dbus.Error { return nil }
+func (mediaPlayerRoot) Quit() *dbus.Error  { return nil }
+
+// mediaPlayerControls implements the org.mpris.MediaPlayer2.Player methods, passing play, pause and stop on to the
+// player.  Hisame plays one episode at a time, so there is nothing to skip to or seek within.
+type mediaPlayerControls struct {
+	publisher *dbusPublisher
+}
+
+func (c *mediaPlayerControls) Next() *dbus.Error     { return nil }
+func (c *mediaPlayerControls) Previous() *dbus.Error { return nil }
+
+func (c *mediaPlayerControls) Pause() *dbus.Error {
+	if _, paused := c.publisher.playbackControls(); paused {
+		return nil
+	}
+	return c.PlayPause()
+}
+
+func (c *mediaPlayerControls) Play() *dbus.Error {
+	if _, paused := c.publisher.playbackControls(); !paused {
+		return nil
+	}
+	return c.PlayPause()
+}
+
+func (c *mediaPlayerControls) PlayPause() *dbus.Error {
+	controls, _ := c.publisher.playbackControls()
+	if controls == nil {
+		return nil
+	}
+	if err := controls.TogglePause(); err != nil {
+		log.Warn("Failed to pause or resume playback from MPRIS", "error", err)
+		return dbus.MakeFailedError(err)
+	}
+	return nil
+}
+
+func (c *mediaPlayerControls) Stop() *dbus.Error {
+	if controls, _ := c.publisher.playbackControls(); controls != nil {
+		controls.StopPlayback()
+	}
+	return nil
+}
+
+func (c *mediaPlayerControls) SeekBy(int64) *dbus.Error                       { return nil }
+func (c *mediaPlayerControls) SetPosition(dbus.ObjectPath, int64) *dbus.Error { return nil }
+func (c *mediaPlayerControls) OpenUri(string) *dbus.Error                     { return nil }
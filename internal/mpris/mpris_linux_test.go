@@ -0,0 +1,45 @@
+//go:build linux
+
+package mpris
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadata(t *testing.T) {
+	m := metadata(Track{
+		AnimeID:       154587,
+		AnimeTitle:    "Frieren",
+		EpisodeNumber: 3,
+		ArtURL:        "https://example.com/cover.jpg",
+		Length:        1440,
+	})
+
+	assert.Equal(t, dbus.ObjectPath("/org/hisame/track/154587_3"), m["mpris:trackid"].Value())
+	assert.Equal(t, "Episode 3", m["xesam:title"].Value())
+	assert.Equal(t, "Frieren", m["xesam:album"].Value())
+	assert.Equal(t, int32(3), m["xesam:trackNumber"].Value())
+	assert.Equal(t, "https://example.com/cover.jpg", m["mpris:artUrl"].Value())
+	assert.Equal(t, int64(1440_000_000), m["mpris:length"].Value(), "the length should be in microseconds")
+	assert.Equal(t, "https://anilist.co/anime/154587", m["xesam:url"].Value())
+}
+
+func TestMetadataUnknownDetails(t *testing.T) {
+	m := metadata(Track{AnimeTitle: "Frieren", EpisodeNumber: 1})
+
+	assert.NotContains(t, m, "mpris:artUrl")
+	assert.NotContains(t, m, "mpris:length")
+	assert.NotContains(t, m, "xesam:url")
+}
+
+func TestPublishWithoutBus(t *testing.T) {
+	// Nothing is published until Start connects, so these must not panic
+	Publish(Track{AnimeTitle: "Frieren"}, nil)
+	SetPaused(true)
+	SetPosition(30)
+	Clear()
+	Close()
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package mpris
+
+import "errors"
+
+// newPublisher fails, as MPRIS is only found on Linux desktops
+func newPublisher() (publisher, error) {
+	return nil, errors.New("MPRIS is only supported on Linux")
+}
@@ -8,9 +8,15 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/diagnostics"
 	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/proxy"
+	"github.com/PizzaHomicide/hisame/internal/retry"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -20,26 +26,236 @@ import (
 const (
 	allAnimeGraphQLURL = "https://api.allanime.day/api"
 	allAnimeUserAgent  = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36"
+	allAnimeReferer    = "https://allmanga.to"
+
+	// Operation names, used to look up persisted query hashes and to label API calls in diagnostics
+	allAnimeOpShows   = "shows"
+	allAnimeOpShow    = "show"
+	allAnimeOpInfos   = "episodeInfos"
+	allAnimeOpEpisode = "episode"
+	allAnimeOpPing    = "ping"
 )
 
+// showsQuery searches AllAnime for shows
+const showsQuery = `
+		query ($search: SearchInput, $limit: Int, $page: Int, $translationType: VaildTranslationTypeEnumType, $countryOrigin: VaildCountryOriginEnumType) {
+			shows(
+				search: $search
+				limit: $limit
+				page: $page
+				translationType: $translationType
+				countryOrigin: $countryOrigin
+			) {
+				edges {
+					_id
+					name
+					englishName
+					nativeName
+					trustedAltNames
+					availableEpisodesDetail
+					season
+					airedStart
+					airedEnd
+					aniListId
+					malId
+				}
+			}
+		}
+	`
+
+// showQuery fetches a single show by its AllAnime ID
+const showQuery = `
+		query ($showId: String!) {
+			show(_id: $showId) {
+				_id
+				name
+				englishName
+				nativeName
+				trustedAltNames
+				availableEpisodesDetail
+				season
+				airedStart
+				airedEnd
+				aniListId
+				malId
+			}
+		}
+	`
+
+// episodeInfosQuery fetches what AllAnime knows about a range of a show's episodes, such as their titles
+const episodeInfosQuery = `
+		query ($showId: String!, $episodeNumStart: Float!, $episodeNumEnd: Float!) {
+			episodeInfos(
+				showId: $showId
+				episodeNumStart: $episodeNumStart
+				episodeNumEnd: $episodeNumEnd
+			) {
+				episodeIdNum
+				notes
+				thumbnails
+				uploadDates
+			}
+		}
+	`
+
+// pingQuery is the smallest query AllAnime will answer, used to check it is up
+const pingQuery = `query { __typename }`
+
+// episodeQuery fetches the streaming sources for an episode
+const episodeQuery = `
+		query ($showId: String!, $translationType: VaildTranslationTypeEnumType!, $episodeString: String!) {
+			episode(
+				showId: $showId
+				translationType: $translationType
+				episodeString: $episodeString
+			) {
+				episodeString
+				sourceUrls
+			}
+		}
+	`
+
+// errPersistedQueryNotFound is returned when AllAnime doesn't recognise a persisted query hash
+var errPersistedQueryNotFound = errors.New("persisted query not found")
+
 // AllAnimeClient is responsible for communicating with the AllAnime API
 type AllAnimeClient struct {
-	client *graphql.Client
+	client           *graphql.Client
+	httpClient       *http.Client
+	endpoint         string
+	persistedQueries map[string]string // Operation name to sha256 persisted query hash
+	retry            retry.Policy
+	allowAdult       bool // Whether adult shows are included in searches
 }
 
 // NewAllAnimeClient creates a new AllAnime client
-func NewAllAnimeClient() *AllAnimeClient {
+func NewAllAnimeClient(cfg config.AllAnimeConfig, retryConfig config.RetryConfig) *AllAnimeClient {
 	// Create a custom HTTP client with a timeout
 	httpClient := &http.Client{
-		Timeout: 30 * time.Second,
+		Timeout:   30 * time.Second,
+		Transport: retry.NewTransport(proxy.Transport()),
 	}
 
 	// Create a new GraphQL client with the custom HTTP client
 	client := graphql.NewClient(allAnimeGraphQLURL, graphql.WithHTTPClient(httpClient))
 
 	return &AllAnimeClient{
-		client: client,
+		client:     client,
+		httpClient: httpClient,
+		endpoint:   allAnimeGraphQLURL,
+		persistedQueries: map[string]string{
+			allAnimeOpShows:   cfg.ShowsQueryHash,
+			allAnimeOpEpisode: cfg.EpisodeQueryHash,
+		},
+		retry:      retry.FromConfig(retryConfig),
+		allowAdult: true,
+	}
+}
+
+// ProviderName implements HealthPinger
+func (c *AllAnimeClient) ProviderName() string {
+	return "AllAnime"
+}
+
+// Ping implements HealthPinger.  It isn't retried, as the point is to find out whether AllAnime is answering right now.
+func (c *AllAnimeClient) Ping(ctx context.Context) error {
+	var result struct {
+		Typename string `json:"__typename"`
+	}
+	return c.runOnce(ctx, allAnimeOpPing, pingQuery, nil, &result)
+}
+
+// run executes a query against AllAnime, retrying timeouts and server errors following the retry policy
+func (c *AllAnimeClient) run(ctx context.Context, operation, query string, variables map[string]interface{}, result interface{}) error {
+	return c.retry.Do(ctx, operation, func() error {
+		return c.runOnce(ctx, operation, query, variables, result)
+	})
+}
+
+// runOnce executes a query against AllAnime.  If a persisted query hash is configured for the operation it is sent in
+// place of the query, falling back to the full query if AllAnime doesn't recognise the hash.
+func (c *AllAnimeClient) runOnce(ctx context.Context, operation, query string, variables map[string]interface{}, result interface{}) error {
+	start := time.Now()
+
+	if hash := c.persistedQueries[operation]; hash != "" {
+		err := c.runPersisted(ctx, hash, variables, result)
+		if !errors.Is(err, errPersistedQueryNotFound) {
+			diagnostics.TrackAPICall(diagnostics.APIAllAnime, operation, start, err)
+			return err
+		}
+		log.Warn("AllAnime did not recognise the persisted query hash, sending the full query", "operation", operation)
+	}
+
+	req := graphql.NewRequest(query)
+	for key, value := range variables {
+		req.Var(key, value)
+	}
+	req.Header.Set("User-Agent", allAnimeUserAgent)
+
+	err := c.client.Run(ctx, req, result)
+	diagnostics.TrackAPICall(diagnostics.APIAllAnime, operation, start, err)
+	return err
+}
+
+// runPersisted executes a query using the GraphQL persisted query extension, sending only the query hash and
+// variables as a GET request
+func (c *AllAnimeClient) runPersisted(ctx context.Context, hash string, variables map[string]interface{}, result interface{}) error {
+	vars, err := json.Marshal(variables)
+	if err != nil {
+		return fmt.Errorf("failed to encode variables: %w", err)
+	}
+	extensions, err := json.Marshal(map[string]interface{}{
+		"persistedQuery": map[string]interface{}{
+			"version":    1,
+			"sha256Hash": hash,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode extensions: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("variables", string(vars))
+	params.Set("extensions", string(extensions))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", allAnimeUserAgent)
+	req.Header.Set("Referer", allAnimeReferer)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message    string `json:"message"`
+			Extensions struct {
+				Code string `json:"code"`
+			} `json:"extensions"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("failed to decode response (HTTP %d): %w", resp.StatusCode, err)
 	}
+
+	if len(body.Errors) > 0 {
+		first := body.Errors[0]
+		if first.Extensions.Code == "PERSISTED_QUERY_NOT_FOUND" || first.Message == "PersistedQueryNotFound" {
+			return errPersistedQueryNotFound
+		}
+		return fmt.Errorf("graphql: %s", first.Message)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	return json.Unmarshal(body.Data, result)
 }
 
 // AiredDate represents a date in the AllAnime API
@@ -70,6 +286,7 @@ type AllAnimeShow struct {
 	NativeName              string    `json:"nativeName"`
 	TrustedAltNames         []string  `json:"trustedAltNames"`
 	AniListID               string    `json:"aniListId"`
+	MalID                   string    `json:"malId"`
 	Season                  Season    `json:"season"`
 	AiredStart              AiredDate `json:"airedStart"`
 	AiredEnd                AiredDate `json:"airedEnd"`
@@ -93,6 +310,20 @@ func (s AllAnimeShow) GetAniListID() int {
 	return id
 }
 
+// GetMalID returns the MalID as an integer, or 0 if AllAnime doesn't know it.  Shows fetched with a persisted query
+// that doesn't select malId never have one.
+func (s AllAnimeShow) GetMalID() int {
+	if s.MalID == "" || s.MalID == "null" {
+		return 0
+	}
+	id, err := strconv.Atoi(s.MalID)
+	if err != nil {
+		log.Warn("Failed to convert MalID to int", "id", s.MalID, "allanime_id", s.ID, "title", s.EnglishName)
+		return 0
+	}
+	return id
+}
+
 // GetAvailableEpisodes returns the available episodes for the given translation type
 func (s AllAnimeShow) GetAvailableEpisodes(translationType string) []string {
 	switch translationType {
@@ -112,61 +343,117 @@ type ShowSearchResponse struct {
 	} `json:"shows"`
 }
 
-// SearchShows searches for shows matching the given query
-func (c *AllAnimeClient) SearchShows(ctx context.Context, query string, translationType string) ([]AllAnimeShow, error) {
-	// Create the GraphQL request
-	req := graphql.NewRequest(`
-		query ($search: SearchInput, $limit: Int, $page: Int, $translationType: VaildTranslationTypeEnumType, $countryOrigin: VaildCountryOriginEnumType) {
-			shows(
-				search: $search
-				limit: $limit
-				page: $page
-				translationType: $translationType
-				countryOrigin: $countryOrigin
-			) {
-				edges {
-					_id
-					name
-					englishName
-					nativeName
-					trustedAltNames
-					availableEpisodesDetail
-					season
-					airedStart
-					airedEnd
-					aniListId
-				}
+const (
+	// showsPageSize is how many shows are asked for in each page of search results
+	showsPageSize = 20
+	// maxShowsPages limits how many pages of search results are fetched.  Long running franchises can have more
+	// entries than fit in a page, but vague searches can go on for many pages of unrelated shows.
+	maxShowsPages = 5
+)
+
+// SearchShows searches for shows matching the given query, from the given country of origin or "ALL".  Results are
+// fetched a page at a time until a page comes back short or maxShowsPages have been fetched.
+func (c *AllAnimeClient) SearchShows(ctx context.Context, query, translationType, countryOrigin string) ([]AllAnimeShow, error) {
+	var shows []AllAnimeShow
+	seen := make(map[string]bool)
+	for page := 1; page <= maxShowsPages; page++ {
+		edges, err := c.searchShowsPage(ctx, query, translationType, countryOrigin, page)
+		if err != nil {
+			if page == 1 {
+				return nil, err
 			}
+			// The shows already found are still worth matching against
+			log.Warn("Failed to fetch a page of search results", "query", query, "page", page, "error", err)
+			break
 		}
-	`)
 
-	// Set the variables
-	req.Var("search", map[string]interface{}{
-		"allowAdult":   true,
-		"allowUnknown": false,
-		"query":        query,
-	})
-	req.Var("limit", 20)
-	// TODO:  Paging support.  But 20 is probably safe for the specific queries we're running.  Will support paging if I ever find a case where things don't work.
-	req.Var("page", 1)
-	req.Var("translationType", translationType)
-	req.Var("countryOrigin", "ALL")
+		for _, show := range edges {
+			if !seen[show.ID] {
+				seen[show.ID] = true
+				shows = append(shows, show)
+			}
+		}
+		if len(edges) < showsPageSize {
+			break
+		}
+	}
 
-	// Set the user agent header
-	req.Header.Set("User-Agent", allAnimeUserAgent)
+	log.Debug("Search shows", "query", query, "count", len(shows))
+	return shows, nil
+}
+
+// searchShowsPage fetches a single page of search results
+func (c *AllAnimeClient) searchShowsPage(ctx context.Context, query, translationType, countryOrigin string, page int) ([]AllAnimeShow, error) {
+	// Set the variables
+	variables := map[string]interface{}{
+		"search": map[string]interface{}{
+			"allowAdult":   c.allowAdult,
+			"allowUnknown": false,
+			"query":        query,
+		},
+		"limit":           showsPageSize,
+		"page":            page,
+		"translationType": translationType,
+		"countryOrigin":   countryOrigin,
+	}
 
 	// Execute the request
 	var response ShowSearchResponse
-	if err := c.client.Run(ctx, req, &response); err != nil {
+	if err := c.run(ctx, allAnimeOpShows, showsQuery, variables, &response); err != nil {
 		log.Debug("Error executing request", "err", err)
 		return nil, fmt.Errorf("error searching shows: %w", err)
 	}
 
-	log.Debug("Search shows", "response", response, "query", query)
+	log.Debug("Search shows page", "response", response, "query", query, "page", page)
 
 	return response.Shows.Edges, nil
 }
 
+// ShowResponse represents the response to showQuery
+type ShowResponse struct {
+	Show *AllAnimeShow `json:"show"`
+}
+
+// GetShow fetches a single show by its AllAnime ID
+func (c *AllAnimeClient) GetShow(ctx context.Context, showID string) (AllAnimeShow, error) {
+	var response ShowResponse
+	if err := c.run(ctx, allAnimeOpShow, showQuery, map[string]interface{}{"showId": showID}, &response); err != nil {
+		return AllAnimeShow{}, fmt.Errorf("error fetching show: %w", err)
+	}
+	if response.Show == nil {
+		return AllAnimeShow{}, fmt.Errorf("show %s not found", showID)
+	}
+	return *response.Show, nil
+}
+
+// EpisodeMetadata is what AllAnime knows about a single episode of a show
+type EpisodeMetadata struct {
+	Number      float64                `json:"episodeIdNum"`
+	Title       string                 `json:"notes"`       // Episode title, empty if AllAnime doesn't know it
+	Thumbnails  []string               `json:"thumbnails"`  // Absolute URLs or paths on AllAnime's image host
+	UploadDates map[string]interface{} `json:"uploadDates"` // Translation type, e.g. "sub", to when it was uploaded
+}
+
+// EpisodeInfosResponse represents the response to episodeInfosQuery
+type EpisodeInfosResponse struct {
+	EpisodeInfos []EpisodeMetadata `json:"episodeInfos"`
+}
+
+// GetEpisodeInfos fetches what AllAnime knows about the show's episodes numbered from start to end, inclusive
+func (c *AllAnimeClient) GetEpisodeInfos(ctx context.Context, showID string, start, end int) ([]EpisodeMetadata, error) {
+	variables := map[string]interface{}{
+		"showId":          showID,
+		"episodeNumStart": start,
+		"episodeNumEnd":   end,
+	}
+
+	var response EpisodeInfosResponse
+	if err := c.run(ctx, allAnimeOpInfos, episodeInfosQuery, variables, &response); err != nil {
+		return nil, fmt.Errorf("error fetching episode infos: %w", err)
+	}
+	return response.EpisodeInfos, nil
+}
+
 // EpisodeSource represents a single streaming source for an episode
 type EpisodeSource struct {
 	SourceURL  string  `json:"sourceUrl"`
@@ -192,33 +479,18 @@ type EpisodeSourceResponse struct {
 
 // GetEpisodeSources fetches the available streaming sources for a specific episode
 func (c *AllAnimeClient) GetEpisodeSources(ctx context.Context, showID string, episodeNum string, translationType string) ([]EpisodeSource, error) {
-	// Create the GraphQL request
-	req := graphql.NewRequest(`
-		query ($showId: String!, $translationType: VaildTranslationTypeEnumType!, $episodeString: String!) {
-			episode(
-				showId: $showId
-				translationType: $translationType
-				episodeString: $episodeString
-			) {
-				episodeString
-				sourceUrls
-			}
-		}
-	`)
-
 	// Set the variables
-	req.Var("showId", showID)
-	req.Var("translationType", translationType)
-	req.Var("episodeString", episodeNum)
-
-	// Set the user agent header
-	req.Header.Set("User-Agent", allAnimeUserAgent)
+	variables := map[string]interface{}{
+		"showId":          showID,
+		"translationType": translationType,
+		"episodeString":   episodeNum,
+	}
 
 	log.Debug("Fetching episode sources", "showId", showID, "episodeNum", episodeNum, "translationType", translationType)
 
 	// Execute the request
 	var response map[string]interface{}
-	if err := c.client.Run(ctx, req, &response); err != nil {
+	if err := c.run(ctx, allAnimeOpEpisode, episodeQuery, variables, &response); err != nil {
 		log.Error("Error fetching episode sources", "error", err)
 		return nil, fmt.Errorf("error fetching episode sources: %w", err)
 	}
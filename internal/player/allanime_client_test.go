@@ -1,15 +1,25 @@
 package player
 
 import (
+	"context"
 	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/machinebox/graphql"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestDecryptTobeparsed tests the AES-256-CTR decryption function
 func TestDecryptTobeparsed(t *testing.T) {
-	client := NewAllAnimeClient()
+	client := NewAllAnimeClient(config.AllAnimeConfig{}, config.RetryConfig{})
 
 	// This is a test case based on the ani-cli implementation
 	// Encrypted value of: {"episodeString":"1","sourceUrls":[{"sourceUrl":"--test","sourceName":"Test","priority":1,"type":"iframe","className":"test","streamerId":"test"}]}
@@ -57,3 +67,74 @@ func TestMapToStruct(t *testing.T) {
 	assert.Len(t, output.Episode.SourceUrls, 1)
 	assert.Equal(t, "--test", output.Episode.SourceUrls[0].SourceURL)
 }
+
+// TestRunPersistedQuery tests that a configured hash is sent via the persisted query extension
+func TestRunPersistedQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Contains(t, r.URL.Query().Get("extensions"), `"sha256Hash":"abc123"`)
+		assert.Contains(t, r.URL.Query().Get("variables"), `"showId":"show1"`)
+		_, _ = w.Write([]byte(`{"data":{"episode":{"episodeString":"1"}}}`))
+	}))
+	defer server.Close()
+
+	client := NewAllAnimeClient(config.AllAnimeConfig{EpisodeQueryHash: "abc123"}, config.RetryConfig{})
+	client.endpoint = server.URL
+
+	var response EpisodeSourceResponse
+	err := client.run(context.Background(), allAnimeOpEpisode, episodeQuery, map[string]interface{}{"showId": "show1"}, &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", response.Episode.EpisodeString)
+}
+
+// TestRunPersistedQueryNotFound tests that an unrecognised hash is reported so the full query can be sent instead
+func TestRunPersistedQueryNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"errors":[{"message":"PersistedQueryNotFound","extensions":{"code":"PERSISTED_QUERY_NOT_FOUND"}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAllAnimeClient(config.AllAnimeConfig{}, config.RetryConfig{})
+	client.endpoint = server.URL
+
+	var response EpisodeSourceResponse
+	err := client.runPersisted(context.Background(), "stale", map[string]interface{}{}, &response)
+	assert.ErrorIs(t, err, errPersistedQueryNotFound)
+}
+
+// TestSearchShowsPages tests that search results are fetched page by page until a page comes back short
+func TestSearchShowsPages(t *testing.T) {
+	var pages []float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		page := body.Variables["page"].(float64)
+		pages = append(pages, page)
+
+		// Two full pages, the second repeating a show from the first, then a short third page
+		count := showsPageSize
+		if page == 3 {
+			count = 1
+		}
+		var edges []string
+		for i := 0; i < count; i++ {
+			id := int(page)*100 + i
+			if page == 2 && i == 0 {
+				id = 100
+			}
+			edges = append(edges, fmt.Sprintf(`{"_id":"show%d"}`, id))
+		}
+		_, _ = w.Write([]byte(`{"data":{"shows":{"edges":[` + strings.Join(edges, ",") + `]}}}`))
+	}))
+	defer server.Close()
+
+	client := NewAllAnimeClient(config.AllAnimeConfig{}, config.RetryConfig{})
+	client.client = graphql.NewClient(server.URL)
+
+	shows, err := client.SearchShows(context.Background(), "frieren", "sub", "ALL")
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1, 2, 3}, pages)
+	assert.Len(t, shows, 2*showsPageSize, "the repeated show should only be included once")
+}
@@ -0,0 +1,252 @@
+package player
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/cast"
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+const (
+	// castDiscoveryTimeout is how long Cast devices have to answer discovery
+	castDiscoveryTimeout = 3 * time.Second
+	// castPollInterval is how often the Cast device is asked for the playback status
+	castPollInterval = time.Second
+)
+
+// ChromecastPlayer implements the VideoPlayer interface by casting the stream to a Chromecast on the local network.
+// The device fetches the stream itself, so local files and streams that need a Referer can't be cast.
+type ChromecastPlayer struct {
+	config   *config.Config
+	startPos float64 // Seconds to start playback at, 0 to start from the beginning
+
+	lock   sync.Mutex
+	client *cast.Client // Connection to the device, set by Play
+	paused bool
+}
+
+// NewChromecastPlayer creates a new Chromecast player instance
+func NewChromecastPlayer(cfg *config.Config) *ChromecastPlayer {
+	return &ChromecastPlayer{config: cfg}
+}
+
+// canCast reports whether a cast device can fetch the stream itself, which it can only do for URLs needing no Referer
+func canCast(streamURL, referer string) bool {
+	return referer == "" && (strings.HasPrefix(streamURL, "http://") || strings.HasPrefix(streamURL, "https://"))
+}
+
+// DiscoverCastDevices finds the Chromecasts on the local network
+func DiscoverCastDevices(ctx context.Context) ([]cast.Device, error) {
+	ctx, cancel := context.WithTimeout(ctx, castDiscoveryTimeout)
+	defer cancel()
+	return cast.Discover(ctx)
+}
+
+// Play casts the URL to the configured device, and monitors the device for playback starting and the position
+func (p *ChromecastPlayer) Play(ctx context.Context, url string, title string) (<-chan PlaybackEvent, error) {
+	deviceName := p.config.Player.CastDevice
+	log.Info("Starting Chromecast playback", "url", url, "title", title, "device", deviceName)
+
+	events := make(chan PlaybackEvent, 10)
+
+	findCtx, cancel := context.WithTimeout(ctx, castDiscoveryTimeout)
+	device, err := cast.Find(findCtx, deviceName)
+	cancel()
+	if err != nil {
+		close(events)
+		return events, err
+	}
+
+	client, err := cast.Dial(ctx, device)
+	if err != nil {
+		close(events)
+		return events, err
+	}
+
+	err = client.Load(ctx, cast.Media{
+		URL:         url,
+		ContentType: castContentType(url),
+		Title:       title,
+		StartTime:   p.startPos,
+	})
+	if err != nil {
+		client.Close()
+		close(events)
+		return events, fmt.Errorf("failed to cast to %s: %w", device.Name, err)
+	}
+
+	p.lock.Lock()
+	p.client = client
+	p.lock.Unlock()
+
+	go p.monitor(ctx, client, events)
+
+	return events, nil
+}
+
+// monitor waits for playback to start, then reports progress until the device stops playing or is disconnected
+func (p *ChromecastPlayer) monitor(ctx context.Context, client *cast.Client, events chan<- PlaybackEvent) {
+	defer close(events)
+	defer client.Close()
+
+	ticker := time.NewTicker(castPollInterval)
+	defer ticker.Stop()
+
+	startDeadline := time.After(TimeoutsFromConfig(p.config.Player.Timeouts).PlaybackStart)
+	started := false
+	var last cast.MediaStatus
+	lastReportedProgress := -1
+
+	ended := func() {
+		log.Info("Chromecast playback ended", "position", last.CurrentTime, "length", last.Duration)
+		events <- PlaybackEvent{
+			Type:     PlaybackEnded,
+			Progress: castProgress(last),
+			Position: last.CurrentTime,
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Debug("Context cancelled, stopping Chromecast monitoring")
+			return
+		case <-client.Done():
+			if !started {
+				err := errors.New("lost the connection to the Chromecast before playback started")
+				log.Error("Chromecast disconnected before playback started")
+				events <- PlaybackEvent{Type: PlaybackError, Error: err}
+				return
+			}
+			ended()
+			return
+		case <-startDeadline:
+			if !started {
+				err := errors.New("timed out waiting for the Chromecast to start playback")
+				log.Error("Failed to detect Chromecast playback start", "error", err)
+				events <- PlaybackEvent{Type: PlaybackError, Error: err}
+				return
+			}
+		case <-ticker.C:
+			statusCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+			status, err := client.Status(statusCtx)
+			cancel()
+			if err != nil {
+				log.Trace("Unable to get Chromecast status", "error", err)
+				continue
+			}
+
+			if !started {
+				if status.PlayerState == cast.StateIdle && status.IdleReason == "ERROR" {
+					err := errors.New("the Chromecast could not play the stream")
+					log.Error("Chromecast failed to start playback")
+					events <- PlaybackEvent{Type: PlaybackError, Error: err}
+					return
+				}
+				if status.PlayerState != cast.StatePlaying {
+					continue
+				}
+				started = true
+				events <- PlaybackEvent{Type: PlaybackStarted}
+			}
+
+			if status.PlayerState == cast.StateIdle {
+				ended()
+				return
+			}
+			if (status.PlayerState == cast.StatePaused) != (last.PlayerState == cast.StatePaused) && last.PlayerState != "" {
+				eventType := PlaybackResumed
+				if status.PlayerState == cast.StatePaused {
+					eventType = PlaybackPaused
+				}
+				// Like progress, this is informational only
+				select {
+				case events <- PlaybackEvent{Type: eventType, Position: status.CurrentTime}:
+				default:
+				}
+			}
+			p.lock.Lock()
+			p.paused = status.PlayerState == cast.StatePaused
+			p.lock.Unlock()
+			last = status
+
+			progress := int(castProgress(status))
+			if progress != lastReportedProgress {
+				lastReportedProgress = progress
+				// Progress is informational only, so never block the monitor if nobody is keeping up
+				select {
+				case events <- PlaybackEvent{Type: PlaybackProgress, Progress: float64(progress), Position: status.CurrentTime}:
+				default:
+				}
+				if progress%5 == 0 {
+					log.Info("Playback progress", "percent", progress)
+				}
+			}
+		}
+	}
+}
+
+// castProgress returns the percentage of the media played
+func castProgress(status cast.MediaStatus) float64 {
+	if status.Duration <= 0 {
+		return 0
+	}
+	return min(status.CurrentTime/status.Duration*100, 100)
+}
+
+// castContentType guesses the content type of the stream from its URL, as the device needs one to pick how to play it
+func castContentType(url string) string {
+	path, _, _ := strings.Cut(url, "?")
+	switch {
+	case strings.HasSuffix(strings.ToLower(path), ".m3u8"):
+		return "application/x-mpegurl"
+	case strings.HasSuffix(strings.ToLower(path), ".webm"):
+		return "video/webm"
+	default:
+		return "video/mp4"
+	}
+}
+
+// TogglePause pauses playback, or resumes it if it is paused
+func (p *ChromecastPlayer) TogglePause() error {
+	p.lock.Lock()
+	client, paused := p.client, p.paused
+	p.paused = !paused
+	p.lock.Unlock()
+	if client == nil {
+		return errors.New("nothing is being cast")
+	}
+
+	if paused {
+		return client.Play()
+	}
+	return client.Pause()
+}
+
+// SetStartPosition sets how many seconds into the media the next playback starts at
+func (p *ChromecastPlayer) SetStartPosition(seconds float64) {
+	p.startPos = seconds
+}
+
+// Stop stops playback, returning the device to its idle screen
+func (p *ChromecastPlayer) Stop() error {
+	p.lock.Lock()
+	client := p.client
+	p.lock.Unlock()
+	if client == nil {
+		return nil
+	}
+	log.Info("Stopping Chromecast playback")
+	return client.Stop()
+}
+
+// Cleanup performs any necessary cleanup
+func (p *ChromecastPlayer) Cleanup() {
+	p.Stop()
+}
@@ -0,0 +1,48 @@
+package player
+
+import (
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/cast"
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCastContentType(t *testing.T) {
+	assert.Equal(t, "application/x-mpegurl", castContentType("https://example.com/hls/master.M3U8?token=abc"))
+	assert.Equal(t, "video/webm", castContentType("https://example.com/ep.webm"))
+	assert.Equal(t, "video/mp4", castContentType("https://example.com/ep.mp4?file=list.m3u8"),
+		"only the path should be used to guess the type")
+}
+
+func TestCastProgress(t *testing.T) {
+	assert.InDelta(t, 50.0, castProgress(cast.MediaStatus{CurrentTime: 720, Duration: 1440}), 0.001)
+	assert.Zero(t, castProgress(cast.MediaStatus{CurrentTime: 30}), "progress is unknown until the length is")
+}
+
+func TestCreateVideoPlayerCastDevice(t *testing.T) {
+	cfg := &config.Config{Player: config.PlayerConfig{Type: "mpv", CastDevice: "Living Room TV"},
+		Syncplay: config.SyncplayConfig{Enabled: true}}
+
+	videoPlayer, err := CreateVideoPlayer(cfg, "https://example.com/ep.m3u8", "")
+	require.NoError(t, err)
+	assert.IsType(t, &ChromecastPlayer{}, videoPlayer, "a cast device should be cast to whatever the player type")
+}
+
+func TestCreateVideoPlayerCastFallsBack(t *testing.T) {
+	cfg := &config.Config{Player: config.PlayerConfig{Type: "mpv", CastDevice: "Living Room TV"}}
+
+	videoPlayer, err := CreateVideoPlayer(cfg, "/home/pizza/Anime/Frieren - 01.mkv", "")
+	require.NoError(t, err)
+	assert.IsType(t, &MPVPlayer{}, videoPlayer, "a local file can't be fetched by the cast device")
+
+	videoPlayer, err = CreateVideoPlayer(cfg, "https://example.com/ep.m3u8", "https://allanime.day")
+	require.NoError(t, err)
+	assert.IsType(t, &MPVPlayer{}, videoPlayer, "a stream that needs a Referer can't be fetched by the cast device")
+
+	cfg.Player = config.PlayerConfig{Type: "chromecast"}
+	videoPlayer, err = CreateVideoPlayer(cfg, "/home/pizza/Anime/Frieren - 01.mkv", "")
+	require.NoError(t, err)
+	assert.IsType(t, &MPVPlayer{}, videoPlayer)
+}
@@ -1,23 +1,43 @@
 package player
 
 import (
-	"fmt"
-
 	"github.com/PizzaHomicide/hisame/internal/config"
 	"github.com/PizzaHomicide/hisame/internal/log"
 )
 
-// CreateVideoPlayer creates a new video player based on the configuration
-func CreateVideoPlayer(cfg *config.Config) (VideoPlayer, error) {
+// CreateVideoPlayer creates a new video player for the stream based on the configuration.  Casting takes precedence
+// over Syncplay, but a stream the cast device can't fetch itself, such as a local file or one whose host needs the
+// given Referer, is played locally instead.
+func CreateVideoPlayer(cfg *config.Config, streamURL, referer string) (VideoPlayer, error) {
 	playerType := cfg.Player.Type
 	log.Info("Creating video player", "type", playerType)
 
+	if cfg.Player.CastDevice != "" || playerType == "chromecast" {
+		if canCast(streamURL, referer) {
+			log.Info("Casting to Chromecast instead of the player", "device", cfg.Player.CastDevice)
+			if cfg.Syncplay.Enabled {
+				log.Warn("Syncplay isn't used while casting")
+			}
+			return NewChromecastPlayer(cfg), nil
+		}
+		log.Warn("The cast device can't fetch this stream, playing it locally instead", "url", streamURL,
+			"needs_referer", referer != "")
+		if playerType == "chromecast" {
+			playerType = "mpv"
+		}
+	}
+	if cfg.Syncplay.Enabled {
+		log.Info("Playing through Syncplay", "server", cfg.Syncplay.Server, "room", cfg.Syncplay.Room)
+		return NewSyncplayPlayer(cfg), nil
+	}
+
 	switch playerType {
 	case "mpv":
 		return NewMPVPlayer(cfg), nil
+	case "vlc":
+		return NewVLCPlayer(cfg), nil
 	case "custom":
-		// Custom player implementation (future extension)
-		return nil, fmt.Errorf("custom player not yet implemented")
+		return NewProcessPlayer(cfg), nil
 	default:
 		log.Warn("Unknown player type, falling back to MPV", "type", playerType)
 		return NewMPVPlayer(cfg), nil
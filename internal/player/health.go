@@ -0,0 +1,88 @@
+package player
+
+import (
+	"context"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+const (
+	// healthCheckTimeout is how long a provider has to answer a health check before it is considered down
+	healthCheckTimeout = 10 * time.Second
+	// slowResponseThreshold is the response time above which a provider is reported as slow
+	slowResponseThreshold = 3 * time.Second
+)
+
+// HealthStatus describes whether a provider is answering requests
+type HealthStatus int
+
+const (
+	HealthUnknown HealthStatus = iota // Not checked yet
+	HealthUp                          // Answering normally
+	HealthSlow                        // Answering, but slowly enough that searches may time out
+	HealthDown                        // Not answering, or answering with errors
+)
+
+func (s HealthStatus) String() string {
+	switch s {
+	case HealthUp:
+		return "up"
+	case HealthSlow:
+		return "slow"
+	case HealthDown:
+		return "down"
+	default:
+		return "unknown"
+	}
+}
+
+// ProviderHealth is the result of checking a single provider
+type ProviderHealth struct {
+	Name      string
+	Status    HealthStatus
+	Latency   time.Duration
+	Error     error // Why the provider is down, if it is
+	CheckedAt time.Time
+}
+
+// HealthPinger is implemented by providers that can be health checked
+type HealthPinger interface {
+	// ProviderName returns the name shown to users for the provider
+	ProviderName() string
+	// Ping makes the cheapest request the provider supports, returning an error if it fails
+	Ping(ctx context.Context) error
+}
+
+// CheckHealth pings the provider and classifies the result by whether it succeeded and how long it took
+func CheckHealth(ctx context.Context, pinger HealthPinger) ProviderHealth {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := pinger.Ping(ctx)
+	health := ProviderHealth{
+		Name:      pinger.ProviderName(),
+		Latency:   time.Since(start),
+		Error:     err,
+		CheckedAt: time.Now(),
+	}
+
+	switch {
+	case err != nil:
+		health.Status = HealthDown
+		log.Warn("Provider health check failed", "provider", health.Name, "latency", health.Latency, "error", err)
+	case health.Latency > slowResponseThreshold:
+		health.Status = HealthSlow
+		log.Info("Provider is responding slowly", "provider", health.Name, "latency", health.Latency)
+	default:
+		health.Status = HealthUp
+		log.Debug("Provider is healthy", "provider", health.Name, "latency", health.Latency)
+	}
+	return health
+}
+
+// CheckProviderHealth checks each of the providers episodes are sourced from
+func (s *PlayerService) CheckProviderHealth(ctx context.Context) []ProviderHealth {
+	return []ProviderHealth{CheckHealth(ctx, s.animeClient)}
+}
@@ -0,0 +1,35 @@
+package player
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePinger is a HealthPinger that returns a fixed error
+type fakePinger struct {
+	err error
+}
+
+func (p fakePinger) ProviderName() string {
+	return "Fake"
+}
+
+func (p fakePinger) Ping(context.Context) error {
+	return p.err
+}
+
+func TestCheckHealth(t *testing.T) {
+	health := CheckHealth(context.Background(), fakePinger{})
+	assert.Equal(t, "Fake", health.Name)
+	assert.Equal(t, HealthUp, health.Status)
+	assert.NoError(t, health.Error)
+	assert.False(t, health.CheckedAt.IsZero())
+
+	health = CheckHealth(context.Background(), fakePinger{err: errors.New("503 Service Unavailable")})
+	assert.Equal(t, HealthDown, health.Status)
+	assert.Error(t, health.Error)
+	assert.Equal(t, "down", health.Status.String())
+}
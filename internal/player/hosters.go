@@ -0,0 +1,174 @@
+package player
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/diagnostics"
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/proxy"
+)
+
+// hosterResolver turns the sources of one kind of hoster into a stream the player can open
+type hosterResolver struct {
+	name     string // Used in logs
+	supports func(s *PlayerService, source EpisodeSource) bool
+	resolve  func(ctx context.Context, s *PlayerService, source EpisodeSource) (StreamInfo, error)
+}
+
+// hosterResolvers are the hosters Hisame can play from, most specific first.  A source is resolved by the first
+// resolver that supports it, and sources no resolver supports are dropped.
+var hosterResolvers = []hosterResolver{
+	{
+		// Encoded links to AllAnime's own API, which answers with the stream or points straight at it
+		name:     "allanime",
+		supports: func(_ *PlayerService, source EpisodeSource) bool { return canDecodeSourceURL(source.SourceURL) },
+		resolve:  resolveAllAnimeSource,
+	},
+	{
+		// Embedded mp4upload players, whose page has the video's address in it
+		name:     "mp4upload",
+		supports: func(_ *PlayerService, source EpisodeSource) bool { return sourceHost(source) == "mp4upload.com" },
+		resolve:  resolveMp4Upload,
+	},
+	{
+		// Links straight to a video file or HLS playlist
+		name: "direct",
+		supports: func(_ *PlayerService, source EpisodeSource) bool {
+			path := strings.ToLower(sourcePath(source))
+			return strings.HasSuffix(path, ".mp4") || strings.HasSuffix(path, ".m3u8")
+		},
+		resolve: func(_ context.Context, _ *PlayerService, source EpisodeSource) (StreamInfo, error) {
+			return StreamInfo{
+				URL: source.SourceURL,
+				HLS: strings.HasSuffix(strings.ToLower(sourcePath(source)), ".m3u8"),
+			}, nil
+		},
+	},
+	{
+		// Embedded players yt-dlp can extract the video from.  MPV runs yt-dlp itself for links like these, so they
+		// are only kept for MPV.
+		name: "ytdl",
+		supports: func(s *PlayerService, source EpisodeSource) bool {
+			return s.config.Player.Type == string(PlayerTypeMPV) && ytdlHosts[sourceHost(source)]
+		},
+		resolve: func(_ context.Context, _ *PlayerService, source EpisodeSource) (StreamInfo, error) {
+			return StreamInfo{URL: source.SourceURL, YTDL: true}, nil
+		},
+	},
+}
+
+// ytdlHosts are the embedded players MPV can play through yt-dlp
+var ytdlHosts = map[string]bool{
+	"ok.ru":           true,
+	"dailymotion.com": true,
+	"vk.com":          true,
+}
+
+// resolverFor returns the resolver for a source, or nil if no hoster Hisame knows of serves it
+func (s *PlayerService) resolverFor(source EpisodeSource) *hosterResolver {
+	for i := range hosterResolvers {
+		if hosterResolvers[i].supports(s, source) {
+			return &hosterResolvers[i]
+		}
+	}
+	return nil
+}
+
+// resolveAllAnimeSource decodes an AllAnime source.  Most decode to a path on AllAnime's API that answers with the
+// stream, but some decode to the stream itself, which is served only to requests from AllAnime's site.
+func resolveAllAnimeSource(ctx context.Context, s *PlayerService, source EpisodeSource) (StreamInfo, error) {
+	decoded, err := s.decodeSourceURL(source.SourceURL)
+	if err != nil {
+		return StreamInfo{}, fmt.Errorf("failed to decode source URL: %w", err)
+	}
+
+	if strings.HasPrefix(decoded, "http") {
+		log.Debug("Decoded direct stream URL", "url", decoded)
+		return StreamInfo{
+			URL:     decoded,
+			HLS:     strings.Contains(decoded, ".m3u8"),
+			Referer: allAnimeReferer,
+		}, nil
+	}
+
+	apiURL := "https://allanime.day" + decoded
+	log.Debug("Decoded API URL", "url", apiURL)
+	stream, err := s.fetchStream(ctx, apiURL)
+	if err != nil {
+		return StreamInfo{}, fmt.Errorf("failed to fetch stream URL: %w", err)
+	}
+	return stream, nil
+}
+
+// mp4UploadPattern finds the video's address in an mp4upload player page
+var mp4UploadPattern = regexp.MustCompile(`src:\s*"(https?://[^"]+\.mp4)"`)
+
+// resolveMp4Upload fetches an mp4upload player page for the video's address.  mp4upload only serves the video to
+// requests from its own pages.
+func resolveMp4Upload(ctx context.Context, _ *PlayerService, source EpisodeSource) (StreamInfo, error) {
+	page, err := fetchPage(ctx, source.SourceURL, "")
+	if err != nil {
+		return StreamInfo{}, err
+	}
+	match := mp4UploadPattern.FindSubmatch(page)
+	if match == nil {
+		return StreamInfo{}, fmt.Errorf("no video found in the mp4upload page")
+	}
+	return StreamInfo{URL: string(match[1]), Referer: "https://www.mp4upload.com/"}, nil
+}
+
+// fetchPage fetches an embedded player's page, as a browser would
+func fetchPage(ctx context.Context, pageURL, referer string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", allAnimeUserAgent)
+	if referer != "" {
+		req.Header.Set("Referer", referer)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second, Transport: proxy.Transport()}
+	start := time.Now()
+	resp, err := client.Do(req)
+	callErr := err
+	if err == nil && resp.StatusCode != http.StatusOK {
+		callErr = fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	diagnostics.TrackAPICall(diagnostics.APIAllAnime, "hoster "+req.URL.Host, start, callErr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+	if callErr != nil {
+		return nil, callErr
+	}
+
+	// Player pages are small, so anything much bigger isn't one
+	return io.ReadAll(io.LimitReader(resp.Body, 2<<20))
+}
+
+// sourceHost returns the host of a source's URL without any "www." prefix, or "" if it isn't a URL
+func sourceHost(source EpisodeSource) string {
+	parsed, err := url.Parse(source.SourceURL)
+	if err != nil || parsed.Host == "" {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
+
+// sourcePath returns the path of a source's URL, or "" if it isn't a URL
+func sourcePath(source EpisodeSource) string {
+	parsed, err := url.Parse(source.SourceURL)
+	if err != nil || parsed.Host == "" {
+		return ""
+	}
+	return parsed.Path
+}
@@ -0,0 +1,82 @@
+package player
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolverFor(t *testing.T) {
+	s := &PlayerService{config: &config.Config{Player: config.PlayerConfig{Type: string(PlayerTypeMPV)}}}
+
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"--175948514e4c4f57175b54575b5307", "allanime"},
+		{"https://www.mp4upload.com/embed-abc123.html", "mp4upload"},
+		{"https://cdn.example.com/videos/ep1.MP4", "direct"},
+		{"https://cdn.example.com/hls/master.m3u8?token=1", "direct"},
+		{"https://ok.ru/videoembed/123", "ytdl"},
+		{"https://streamwish.to/e/abc", ""},
+		{"not a url", ""},
+	}
+	for _, tt := range tests {
+		resolver := s.resolverFor(EpisodeSource{SourceURL: tt.url})
+		if tt.expected == "" {
+			assert.Nil(t, resolver, tt.url)
+			continue
+		}
+		require.NotNil(t, resolver, tt.url)
+		assert.Equal(t, tt.expected, resolver.name, tt.url)
+	}
+
+	s.config.Player.Type = string(PlayerTypeVLC)
+	assert.Nil(t, s.resolverFor(EpisodeSource{SourceURL: "https://ok.ru/videoembed/123"}),
+		"yt-dlp hosters should only be kept for MPV")
+}
+
+func TestResolveDirect(t *testing.T) {
+	s := &PlayerService{config: &config.Config{}}
+	source := EpisodeSource{SourceURL: "https://cdn.example.com/hls/master.m3u8"}
+
+	stream, err := s.resolverFor(source).resolve(context.Background(), s, source)
+	require.NoError(t, err)
+	assert.Equal(t, source.SourceURL, stream.URL)
+	assert.True(t, stream.HLS)
+	assert.Empty(t, stream.Referer)
+}
+
+func TestResolveMp4Upload(t *testing.T) {
+	page := `<script>player.src({type: "video/mp4", src: "https://a4.mp4upload.com:183/d/abc/video.mp4"});</script>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/embed-abc.html" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	stream, err := resolveMp4Upload(context.Background(), nil, EpisodeSource{SourceURL: server.URL + "/embed-abc.html"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://a4.mp4upload.com:183/d/abc/video.mp4", stream.URL)
+	assert.Equal(t, "https://www.mp4upload.com/", stream.Referer)
+
+	_, err = resolveMp4Upload(context.Background(), nil, EpisodeSource{SourceURL: server.URL + "/missing.html"})
+	assert.ErrorContains(t, err, "HTTP 404")
+}
+
+func TestRefererIsRemembered(t *testing.T) {
+	s := &PlayerService{}
+	s.rememberReferer(StreamInfo{URL: "https://a.example/video.mp4", Referer: "https://a.example/"})
+	s.rememberReferer(StreamInfo{URL: "https://b.example/video.mp4"})
+
+	assert.Equal(t, "https://a.example/", s.refererFor("https://a.example/video.mp4"))
+	assert.Empty(t, s.refererFor("https://b.example/video.mp4"))
+}
@@ -2,6 +2,7 @@ package player
 
 import (
 	"context"
+	"time"
 )
 
 // PlaybackEventType represents the type of playback event
@@ -10,6 +11,12 @@ type PlaybackEventType string
 const (
 	// PlaybackStarted indicates that playback has successfully started
 	PlaybackStarted PlaybackEventType = "started"
+	// PlaybackProgress reports how far through the episode playback is
+	PlaybackProgress PlaybackEventType = "progress"
+	// PlaybackPaused indicates that playback was paused
+	PlaybackPaused PlaybackEventType = "paused"
+	// PlaybackResumed indicates that paused playback was resumed
+	PlaybackResumed PlaybackEventType = "resumed"
 	// PlaybackEnded indicates that playback has completed
 	PlaybackEnded PlaybackEventType = "ended"
 	// PlaybackError indicates an error during playback
@@ -19,9 +26,12 @@ const (
 // PlaybackEvent represents an event from the video player
 type PlaybackEvent struct {
 	Type     PlaybackEventType
-	Progress float64     // Percentage of progress (0-100)
-	Error    error       // Error if Type is PlaybackError
-	Data     interface{} // Additional data related to the event
+	Progress float64       // Percentage of progress (0-100)
+	Position float64       // Seconds into the media when the event was sent.  Set for PlaybackProgress and PlaybackEnded
+	Elapsed  time.Duration // How long the player ran for.  Set for PlaybackEnded by players without IPC, unless they assume the episode was finished
+	Error    error         // Error if Type is PlaybackError
+	Missed   bool          // The player exited while Hisame was closed, so this is replayed from the playback journal
+	Data     interface{}   // Additional data related to the event
 }
 
 // VideoPlayer defines the interface for media player implementations
@@ -35,3 +45,34 @@ type VideoPlayer interface {
 	// Cleanup performs any necessary cleanup
 	Cleanup()
 }
+
+// SubtitleCycler is implemented by players Hisame can switch subtitle tracks in while they play
+type SubtitleCycler interface {
+	// CycleSubtitles switches to the next subtitle track
+	CycleSubtitles() error
+}
+
+// Pauser is implemented by players Hisame can pause and resume while they play
+type Pauser interface {
+	// TogglePause pauses playback, or resumes it if it is paused
+	TogglePause() error
+}
+
+// StartPositionSetter is implemented by players that can start playback part way through, for resuming episodes
+type StartPositionSetter interface {
+	// SetStartPosition sets the number of seconds into the media the next Play call should start at
+	SetStartPosition(seconds float64)
+}
+
+// RefererSetter is implemented by players that can send a Referer with their stream requests, which some hosters
+// need before they will serve the stream
+type RefererSetter interface {
+	// SetReferer sets the Referer the next Play call sends
+	SetReferer(referer string)
+}
+
+// Reattacher is implemented by players Hisame can reattach to if it is restarted while they are still playing
+type Reattacher interface {
+	// Session returns the IPC socket and process ID of the running player
+	Session() (socketPath string, pid int)
+}
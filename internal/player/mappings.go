@@ -0,0 +1,92 @@
+package player
+
+import (
+	"slices"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/store"
+)
+
+// showMappingsFileName is the name of the file AniList to AllAnime mappings are persisted to within the data dir
+const showMappingsFileName = "show_mappings.json"
+
+// MappedShow is an AllAnime show an anime was matched to
+type MappedShow struct {
+	ID        string `json:"id"`
+	MatchType string `json:"match_type"` // How the show was first matched, see MatchTypeAniList
+}
+
+// ShowMapping is the AllAnime shows an anime was matched to, in the order their episodes are numbered, and the shows
+// the user has said are not the anime
+type ShowMapping struct {
+	Shows       []MappedShow `json:"shows"`
+	Blacklisted []string     `json:"blacklisted,omitempty"` // IDs of shows never to match
+	Updated     time.Time    `json:"updated"`
+}
+
+// ShowMappings remembers which AllAnime shows each anime was matched to, so later plays can fetch them directly rather
+// than searching AllAnime and matching the results again
+type ShowMappings struct {
+	mappings *store.Map[int, ShowMapping] // AniList ID to the shows it was matched to
+}
+
+// NewShowMappings creates a mapping store backed by the given file.  An empty path keeps mappings in memory only.
+func NewShowMappings(path string) *ShowMappings {
+	mappings, err := store.LoadMap[int, ShowMapping](path)
+	if err != nil {
+		log.Warn("Failed to load AllAnime show mappings, shows will be searched for", "path", path, "error", err)
+	}
+	return &ShowMappings{mappings: mappings}
+}
+
+// newDefaultShowMappings creates a mapping store in the Hisame data dir
+func newDefaultShowMappings() *ShowMappings {
+	return NewShowMappings(store.DataPath(showMappingsFileName, "AllAnime show mappings"))
+}
+
+// Get returns the shows the anime was matched to, if it has been
+func (m *ShowMappings) Get(animeID int) (ShowMapping, bool) {
+	mapping, _ := m.mappings.Get(animeID)
+	return mapping, len(mapping.Shows) > 0
+}
+
+// Set remembers the shows the anime was matched to
+func (m *ShowMappings) Set(animeID int, shows []MappedShow) error {
+	return m.mappings.Update(animeID, func(mapping ShowMapping, _ bool) (ShowMapping, bool) {
+		mapping.Shows = shows
+		mapping.Updated = time.Now()
+		return mapping, true
+	})
+}
+
+// Delete forgets the shows the anime was matched to, so it is searched for again.  Blacklisted shows stay blacklisted.
+func (m *ShowMappings) Delete(animeID int) error {
+	if _, ok := m.mappings.Get(animeID); !ok {
+		return nil
+	}
+	return m.mappings.Update(animeID, func(mapping ShowMapping, _ bool) (ShowMapping, bool) {
+		mapping.Shows = nil
+		mapping.Updated = time.Now()
+		return mapping, len(mapping.Blacklisted) > 0
+	})
+}
+
+// IsBlacklisted reports whether the user has said the show is not the anime
+func (m *ShowMappings) IsBlacklisted(animeID int, showID string) bool {
+	mapping, _ := m.mappings.Get(animeID)
+	return slices.Contains(mapping.Blacklisted, showID)
+}
+
+// Blacklist records that the show is not the anime, so it is never matched to it again.  The shows the anime was
+// matched to are forgotten, so it is matched again without the show.
+func (m *ShowMappings) Blacklist(animeID int, showID string) error {
+	return m.mappings.Update(animeID, func(mapping ShowMapping, _ bool) (ShowMapping, bool) {
+		if !slices.Contains(mapping.Blacklisted, showID) {
+			mapping.Blacklisted = append(slices.Clone(mapping.Blacklisted), showID)
+		}
+		mapping.Shows = nil
+		mapping.Updated = time.Now()
+		return mapping, true
+	})
+}
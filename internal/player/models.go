@@ -3,6 +3,8 @@ package player
 import (
 	"context"
 	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
 )
 
 // PlayerType defines the type of media player to use
@@ -11,6 +13,8 @@ type PlayerType string
 const (
 	// PlayerTypeMPV represents the MPV player
 	PlayerTypeMPV PlayerType = "mpv"
+	// PlayerTypeVLC represents the VLC player
+	PlayerTypeVLC PlayerType = "vlc"
 	// PlayerTypeCustom represents a custom player executable
 	PlayerTypeCustom PlayerType = "custom"
 )
@@ -33,11 +37,21 @@ type AllAnimeEpisodeInfo struct {
 	AirDate time.Time
 	// The AniList ID if available
 	AniListID int
+	// The AniList format of the anime, e.g. "TV" or "MOVIE", if known
+	Format string
 	// The season information
 	Season string
 	Year   int
-	// Whether this was matched by AniList ID or by synonyms
+	// Whether this was matched by AniList ID, MAL ID or by synonyms
 	MatchType string
+	// The translation type the episode was found in, "sub" or "dub"
+	TranslationType string
+	// Whether the episode was only found in TranslationType because there were none in the preferred type
+	TranslationFallback bool
+	// The episode's title, thumbnail and when it was uploaded, where AllAnime knows them
+	Title      string
+	Thumbnail  string
+	UploadDate time.Time
 }
 
 // FindEpisodesResult contains the complete result of finding episodes
@@ -51,5 +65,5 @@ type FindEpisodesResult struct {
 // Service defines the interface for the player service
 type Service interface {
 	// FindEpisodes finds all available episodes for an anime
-	FindEpisodes(ctx context.Context, animeID int, title string, synonyms []string) (*FindEpisodesResult, error)
+	FindEpisodes(ctx context.Context, anime *domain.Anime) (*FindEpisodesResult, error)
 }
@@ -3,13 +3,16 @@ package player
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"runtime"
 	"time"
 
 	"github.com/PizzaHomicide/hisame/internal/config"
 	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/proxy"
 )
 
 // MPVPlayer implements the VideoPlayer interface for MPV
@@ -17,7 +20,12 @@ type MPVPlayer struct {
 	config     *config.Config
 	ipcClient  *MPVIPCClient
 	cmd        *exec.Cmd
+	exited     chan struct{} // Closed once the MPV started by Play exits, nil for a reattached MPV
+	pid        int           // Kept separately, as a reattached MPV has no cmd
 	socketPath string
+	startPos   float64  // Seconds to start playback at, 0 to start from the beginning
+	presetArgs []string // Arguments from the player preset, added after the configured args
+	referer    string   // Referer the stream's host needs, empty if none
 }
 
 // NewMPVPlayer creates a new MPV player instance
@@ -30,6 +38,57 @@ func NewMPVPlayer(cfg *config.Config) *MPVPlayer {
 	}
 }
 
+// AttachMPVPlayer creates a player for an MPV that is already running, such as one left playing when Hisame was
+// restarted
+func AttachMPVPlayer(cfg *config.Config, socketPath string, pid int) *MPVPlayer {
+	return &MPVPlayer{
+		config:     cfg,
+		pid:        pid,
+		socketPath: socketPath,
+		ipcClient:  NewMPVIPCClient(socketPath),
+	}
+}
+
+// Attach connects to the already running MPV and monitors the rest of its playback.  Like Play, the first event is
+// PlaybackStarted
+func (p *MPVPlayer) Attach(ctx context.Context) (<-chan PlaybackEvent, error) {
+	if !p.alive() {
+		return nil, errMPVExited
+	}
+
+	connCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := p.ipcClient.Connect(connCtx); err != nil {
+		return nil, err
+	}
+	p.ipcClient.ObserveProgress()
+	log.Info("Reattached to MPV", "socket_path", p.socketPath, "pid", p.pid)
+
+	events := make(chan PlaybackEvent, 10)
+	events <- PlaybackEvent{Type: PlaybackStarted}
+	go func() {
+		defer close(events)
+		defer p.removeSocket()
+		p.monitor(ctx, events)
+	}()
+	return events, nil
+}
+
+// removeSocket removes MPV's socket once MPV has exited, in case it couldn't remove it itself
+func (p *MPVPlayer) removeSocket() {
+	if runtime.GOOS == "windows" || p.alive() {
+		return
+	}
+	if err := os.Remove(p.socketPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Warn("Failed to remove MPV socket file", "path", p.socketPath, "error", err)
+	}
+}
+
+// Session returns the IPC socket and process ID of the MPV playing, so it can be reattached to after a restart
+func (p *MPVPlayer) Session() (string, int) {
+	return p.socketPath, p.pid
+}
+
 // Play starts playback of the given URL, monitors for playback start, and returns a notification channel
 func (p *MPVPlayer) Play(ctx context.Context, url string, title string) (<-chan PlaybackEvent, error) {
 	log.Info("Starting MPV playback", "url", url, "title", title)
@@ -52,17 +111,41 @@ func (p *MPVPlayer) Play(ctx context.Context, url string, title string) (<-chan
 		args = append(args, "--title="+title)
 	}
 
+	if p.startPos > 0 {
+		args = append(args, fmt.Sprintf("--start=%.0f", p.startPos))
+	}
+
+	if p.referer != "" {
+		args = append(args, "--referrer="+p.referer)
+	}
+
+	// Have MPV save the position on quit, so episodes resumed outside Hisame can be synced back
+	if p.config.Player.WatchLaterSync {
+		args = append(args, "--save-position-on-quit")
+	}
+
+	if p.config.Player.SubtitleLanguages != "" {
+		args = append(args, "--slang="+p.config.Player.SubtitleLanguages)
+	}
+	if p.config.Player.AudioLanguages != "" {
+		args = append(args, "--alang="+p.config.Player.AudioLanguages)
+	}
+
 	// Add any additional configured arguments
 	if p.config.Player.Args != "" {
 		customArgs := ParseArgs(p.config.Player.Args)
 		args = append(args, customArgs...)
 	}
 
+	// The preset goes last so its options take precedence
+	args = append(args, p.presetArgs...)
+
 	// Add the stream URL as the final argument
 	args = append(args, url)
 
 	// Create command
 	cmd := exec.Command(executable, args...)
+	cmd.Env = proxy.Environ()
 
 	// Platform-specific process setup
 	setupPlayerProcess(cmd)
@@ -72,26 +155,25 @@ func (p *MPVPlayer) Play(ctx context.Context, url string, title string) (<-chan
 		close(events)
 		return events, fmt.Errorf("failed to start MPV: %w", err)
 	}
-	p.cmd = cmd
-
-	// Release the process (platform-specific)
-	if err := releasePlayerProcess(cmd); err != nil {
-		log.Warn("Failed to release MPV process", "error", err)
-	}
+	p.watch(cmd)
 
 	// Start a goroutine to monitor playback
 	go func() {
 		defer close(events)
+		defer p.removeSocket()
 
 		// Allow time for MPV to create the socket
 		time.Sleep(300 * time.Millisecond)
 
+		timeouts := TimeoutsFromConfig(p.config.Player.Timeouts)
+
 		// Wait for MPV to create its socket and establish connection
-		connCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		connCtx, cancel := context.WithTimeout(ctx, timeouts.Connect)
 		defer cancel()
 
-		// Try to connect to MPV with retries
-		err := p.ipcClient.WaitForConnection(connCtx, 20, 500*time.Millisecond)
+		// Try to connect to MPV with retries, for as long as the timeout allows
+		retryDelay := 500 * time.Millisecond
+		err := p.ipcClient.WaitForConnection(connCtx, max(int(timeouts.Connect/retryDelay), 1), retryDelay)
 		if err != nil {
 			log.Error("Failed to connect to MPV", "error", err)
 			events <- PlaybackEvent{
@@ -102,10 +184,10 @@ func (p *MPVPlayer) Play(ctx context.Context, url string, title string) (<-chan
 		}
 
 		// Wait for playback to actually start
-		playbackCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		playbackCtx, cancel := context.WithTimeout(ctx, timeouts.PlaybackStart)
 		defer cancel()
 
-		err = p.ipcClient.WaitForPlaybackStart(playbackCtx, 30*time.Second)
+		err = p.ipcClient.WaitForPlaybackStart(playbackCtx, timeouts.PlaybackStart)
 		if err != nil {
 			log.Error("Failed to detect MPV playback start", "error", err)
 			events <- PlaybackEvent{
@@ -120,59 +202,100 @@ func (p *MPVPlayer) Play(ctx context.Context, url string, title string) (<-chan
 			Type: PlaybackStarted,
 		}
 
-		var playbackTime, duration float64
-		// Used for logging.  We want to log out progress updates infrequently and will be casting a float to an int,
-		// so will get many events for the same percentage number - therefore we need to track the last logged number
-		// so we don't spam logs of that one number
-		var lastLoggedProgress int = -1
-
-		// Keep processing events until MPV exits or context is cancelled
-		mpvEventCh := p.ipcClient.Events()
-		for {
-			select {
-			case <-ctx.Done():
-				log.Debug("Context cancelled, stopping MPV monitoring")
-				return
-			case event, ok := <-mpvEventCh:
-				if !ok {
-					log.Debug("MPV event channel closed")
-					events <- PlaybackEvent{
-						Type:     PlaybackEnded,
-						Progress: p.calculateProgressPercentage(playbackTime, duration),
-					}
-					return
+		p.monitor(ctx, events)
+	}()
+
+	return events, nil
+}
+
+// monitor reports MPV's progress as events until playback ends or ctx is cancelled, reconnecting if the connection
+// drops while MPV is still running
+func (p *MPVPlayer) monitor(ctx context.Context, events chan<- PlaybackEvent) {
+	var playbackTime, duration float64
+	// Used for logging.  We want to log out progress updates infrequently and will be casting a float to an int,
+	// so will get many events for the same percentage number - therefore we need to track the last logged number
+	// so we don't spam logs of that one number
+	var lastLoggedProgress int = -1
+	// Progress events are only sent when the whole percentage changes
+	lastReportedProgress := -1
+
+	// Keep processing events until MPV exits or context is cancelled
+	mpvEventCh := p.ipcClient.Events()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Debug("Context cancelled, stopping MPV monitoring")
+			return
+		case event, ok := <-mpvEventCh:
+			if !ok {
+				log.Debug("MPV event channel closed")
+				// The connection can drop during a long pause or while the system sleeps, with MPV still
+				// running.  Reconnect rather than losing track of the rest of the episode.
+				err := p.ipcClient.Reconnect(ctx, ipcReconnectMaxAttempts, p.alive)
+				if err == nil {
+					p.ipcClient.ObserveProgress()
+					mpvEventCh = p.ipcClient.Events()
+					continue
+				}
+				log.Debug("Not reconnecting to MPV", "reason", err)
+				events <- PlaybackEvent{
+					Type:     PlaybackEnded,
+					Progress: p.calculateProgressPercentage(playbackTime, duration),
+					Position: playbackTime,
 				}
+				return
+			}
 
-				// Process events - in the future, we could handle property changes to track progress
-				if event.Event == "end-file" {
-					log.Info("MPV playback ended")
-					events <- PlaybackEvent{
-						Type:     PlaybackEnded,
-						Progress: p.calculateProgressPercentage(playbackTime, duration),
-					}
-					return
+			// Process events - in the future, we could handle property changes to track progress
+			if event.Event == "end-file" {
+				log.Info("MPV playback ended")
+				events <- PlaybackEvent{
+					Type:     PlaybackEnded,
+					Progress: p.calculateProgressPercentage(playbackTime, duration),
+					Position: playbackTime,
 				}
-				if event.Event == "property-change" {
-					if durationValue, err := p.extractEventDataFloat(event, "duration"); err == nil {
-						log.Trace("Setting video duration", "duration", durationValue)
-						duration = durationValue
+				return
+			}
+			if event.Event == "property-change" && event.Name == "pause" {
+				var paused bool
+				if err := json.Unmarshal(event.Data, &paused); err == nil {
+					eventType := PlaybackResumed
+					if paused {
+						eventType = PlaybackPaused
+					}
+					// Like progress, this is informational only
+					select {
+					case events <- PlaybackEvent{Type: eventType, Position: playbackTime}:
+					default:
 					}
-					if playbackValue, err := p.extractEventDataFloat(event, "playback-time"); err == nil {
-						log.Trace("Setting playback time", "playback-time", playbackValue)
-						playbackTime = playbackValue
-
-						progress := int(p.calculateProgressPercentage(playbackTime, duration))
-						if progress != lastLoggedProgress && (progress%5 == 0 || absInt(lastLoggedProgress-progress) >= 5) {
-							log.Info("Playback progress", "percent", progress)
-							lastLoggedProgress = progress
+				}
+			}
+			if event.Event == "property-change" {
+				if durationValue, err := p.extractEventDataFloat(event, "duration"); err == nil {
+					log.Trace("Setting video duration", "duration", durationValue)
+					duration = durationValue
+				}
+				if playbackValue, err := p.extractEventDataFloat(event, "playback-time"); err == nil {
+					log.Trace("Setting playback time", "playback-time", playbackValue)
+					playbackTime = playbackValue
+
+					progress := int(p.calculateProgressPercentage(playbackTime, duration))
+					if progress != lastReportedProgress {
+						lastReportedProgress = progress
+						// Progress is informational only, so never block the monitor if nobody is keeping up
+						select {
+						case events <- PlaybackEvent{Type: PlaybackProgress, Progress: float64(progress), Position: playbackTime}:
+						default:
 						}
 					}
+					if progress != lastLoggedProgress && (progress%5 == 0 || absInt(lastLoggedProgress-progress) >= 5) {
+						log.Info("Playback progress", "percent", progress)
+						lastLoggedProgress = progress
+					}
 				}
 			}
 		}
-	}()
-
-	return events, nil
+	}
 }
 
 func absInt(x int) int {
@@ -205,8 +328,71 @@ func (p *MPVPlayer) calculateProgressPercentage(playbackTime, duration float64)
 	return (playbackTime / duration) * 100
 }
 
+// watch keeps track of the MPV process started by Play, waiting on it so it's reaped once it exits.  Until it is
+// reaped, an exited MPV still looks like it's running to processAlive.
+func (p *MPVPlayer) watch(cmd *exec.Cmd) {
+	p.cmd = cmd
+	p.pid = cmd.Process.Pid
+	exited := make(chan struct{})
+	p.exited = exited
+	go func() {
+		err := cmd.Wait()
+		log.Debug("MPV exited", "pid", p.pid, "error", err)
+		close(exited)
+	}()
+}
+
+// alive reports whether MPV is still running
+func (p *MPVPlayer) alive() bool {
+	if p.exited == nil {
+		return processAlive(p.pid)
+	}
+	select {
+	case <-p.exited:
+		return false
+	default:
+		return true
+	}
+}
+
+// SetPresetArgs sets the preset arguments the next playback adds after the configured args
+func (p *MPVPlayer) SetPresetArgs(args []string) {
+	p.presetArgs = args
+}
+
+// CycleSubtitles switches to the next subtitle track, showing which is selected on MPV's OSD
+func (p *MPVPlayer) CycleSubtitles() error {
+	if err := p.ipcClient.SendCommand([]interface{}{"cycle", "sub"}); err != nil {
+		return err
+	}
+	return p.ipcClient.SendCommand([]interface{}{"show-text", "Subtitles: ${sub-title:${sid}}"})
+}
+
+// TogglePause pauses playback, or resumes it if it is paused
+func (p *MPVPlayer) TogglePause() error {
+	return p.ipcClient.SendCommand([]interface{}{"cycle", "pause"})
+}
+
+// SetReferer sets the Referer the next playback sends with its stream requests
+func (p *MPVPlayer) SetReferer(referer string) {
+	p.referer = referer
+}
+
+// SetStartPosition sets how many seconds into the media the next playback starts at
+func (p *MPVPlayer) SetStartPosition(seconds float64) {
+	p.startPos = seconds
+}
+
 // Stop stops playback if it's active
 func (p *MPVPlayer) Stop() error {
+	// An MPV reattached to wasn't started by this Hisame, so it can only be asked to quit
+	if p.cmd == nil && p.ipcClient != nil {
+		log.Info("Stopping reattached MPV playback")
+		err := p.ipcClient.SendCommand([]interface{}{"quit"})
+		p.ipcClient.Close()
+		return err
+	}
+
 	// Close IPC connection if it exists
 	if p.ipcClient != nil {
 		p.ipcClient.Close()
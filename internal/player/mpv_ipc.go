@@ -4,16 +4,30 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sync/atomic"
 	"time"
 
 	"github.com/PizzaHomicide/hisame/internal/log"
 )
 
+// ipcReconnectInitialDelay and ipcReconnectMaxDelay bound the exponential backoff between attempts to reconnect to MPV
+// after the connection drops, and ipcReconnectMaxAttempts how many attempts are made before giving up on an MPV that
+// is still running but no longer answering
+const (
+	ipcReconnectInitialDelay = 500 * time.Millisecond
+	ipcReconnectMaxDelay     = 30 * time.Second
+	ipcReconnectMaxAttempts  = 10
+)
+
+// errMPVExited is returned when reconnecting is given up because the MPV process has exited
+var errMPVExited = errors.New("MPV has exited")
+
 // MPVIPCClient provides communication with a running MPV instance
 type MPVIPCClient struct {
 	socketPath string
@@ -39,43 +53,66 @@ func NewMPVIPCClient(socketPath string) *MPVIPCClient {
 	}
 }
 
-// GetMPVSocketPath returns the socket path for MPV IPC communication
-func GetMPVSocketPath() string {
-	var socketPath string
+// mpvSocketPrefix starts the name of every socket Hisame has MPV create, so stale ones can be found and removed
+const mpvSocketPrefix = "hisame-mpv-"
 
+// mpvSocketSeq numbers the players launched by this Hisame, so each gets a socket of its own
+var mpvSocketSeq atomic.Int64
+
+// GetMPVSocketPath returns a new socket path for MPV IPC communication.  Each playback gets its own, named after this
+// Hisame's process ID and the playback, so two instances of Hisame or two players never connect to each other's MPV.
+// MPV_IPC_SOCKET overrides it with a fixed path.
+func GetMPVSocketPath() string {
 	// Use environment variable if set
 	if path := os.Getenv("MPV_IPC_SOCKET"); path != "" {
 		return path
 	}
 
-	// Otherwise use default location based on OS
-	switch runtime.GOOS {
-	case "windows":
+	name := fmt.Sprintf("%s%d-%d", mpvSocketPrefix, os.Getpid(), mpvSocketSeq.Add(1))
+	if runtime.GOOS == "windows" {
 		// Windows uses named pipes instead of unix sockets
-		return `\\.\pipe\mpv-pipe`
-	case "darwin":
-		// macOS
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			log.Error("Failed to get user home directory", "error", err)
-			return "/tmp/mpv-socket"
-		}
-		socketPath = filepath.Join(homeDir, ".config", "mpv", "socket")
-	default:
-		// Linux and others
-		runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
-		if runtimeDir != "" {
-			socketPath = filepath.Join(runtimeDir, "mpv-socket")
-		} else {
-			socketPath = "/tmp/mpv-socket"
-		}
+		return `\\.\pipe\` + name
 	}
-	
-	log.Info("Determined IPC socket path", "socket_path", socketPath)
 
+	socketPath := filepath.Join(mpvSocketDir(), name+".sock")
+	log.Debug("Determined IPC socket path", "socket_path", socketPath)
 	return socketPath
 }
 
+// mpvSocketDir returns the directory MPV sockets are created in on Unix systems
+func mpvSocketDir() string {
+	// Linux keeps per-user runtime files here
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		return runtimeDir
+	}
+	// macOS gives each user their own temp dir
+	return os.TempDir()
+}
+
+// removeStaleMPVSockets removes sockets left behind by MPVs that have since exited without cleaning up, such as after
+// a crash.  Sockets MPV is still listening on are kept, as their player may be reattached to.
+func removeStaleMPVSockets() {
+	if runtime.GOOS == "windows" {
+		return // Named pipes go away with the process that created them
+	}
+
+	sockets, err := filepath.Glob(filepath.Join(mpvSocketDir(), mpvSocketPrefix+"*.sock"))
+	if err != nil {
+		log.Warn("Failed to look for stale MPV sockets", "error", err)
+		return
+	}
+	for _, socket := range sockets {
+		if socketInUse(socket) {
+			continue
+		}
+		if err := os.Remove(socket); err != nil {
+			log.Warn("Failed to remove stale MPV socket", "path", socket, "error", err)
+			continue
+		}
+		log.Debug("Removed stale MPV socket", "path", socket)
+	}
+}
+
 // WaitForConnection attempts to connect to MPV with retries
 // Platform-specific Connect() functions are implemented in
 // platform-specific files (mpv_windows.go, mpv_unix.go)
@@ -116,6 +153,53 @@ func (c *MPVIPCClient) WaitForConnection(ctx context.Context, maxAttempts int, r
 	return fmt.Errorf("failed to connect to MPV after %d attempts", maxAttempts)
 }
 
+// Reconnect re-establishes a dropped connection to MPV, such as after a long pause or the system sleeping.  Attempts
+// back off exponentially, and stop after maxAttempts or as soon as alive reports the MPV process has exited.  Observed
+// properties belong to a connection, so the caller needs to observe them again once reconnected.
+func (c *MPVIPCClient) Reconnect(ctx context.Context, maxAttempts int, alive func() bool) error {
+	c.Close()
+
+	delay := ipcReconnectInitialDelay
+	for attempt := 1; ; attempt++ {
+		if !alive() {
+			return errMPVExited
+		}
+
+		err := c.Connect(ctx)
+		if err == nil {
+			log.Info("Reconnected to MPV", "attempt", attempt)
+			return nil
+		}
+		if attempt >= maxAttempts {
+			return fmt.Errorf("failed to reconnect to MPV after %d attempts: %w", attempt, err)
+		}
+		log.Debug("Failed to reconnect to MPV", "attempt", attempt, "retry_in", delay, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, ipcReconnectMaxDelay)
+	}
+}
+
+// ObserveProgress asks MPV to report playback-time and duration as they change.  MPV reports each property's current
+// value straight away, so this also brings the progress up to date after reconnecting.
+func (c *MPVIPCClient) ObserveProgress() {
+	if err := c.SendCommand([]interface{}{"observe_property", 1, "playback-time"}); err != nil {
+		log.Warn("Failed to observe playback-time property", "error", err)
+	}
+
+	if err := c.SendCommand([]interface{}{"observe_property", 2, "duration"}); err != nil {
+		log.Warn("Failed to observe duration property", "error", err)
+	}
+
+	if err := c.SendCommand([]interface{}{"observe_property", 3, "pause"}); err != nil {
+		log.Warn("Failed to observe pause property", "error", err)
+	}
+}
+
 // Close closes the connection to MPV
 func (c *MPVIPCClient) Close() error {
 	if c.conn != nil {
@@ -124,9 +208,17 @@ func (c *MPVIPCClient) Close() error {
 	return nil
 }
 
-// readEvents continuously reads events from MPV
-func (c *MPVIPCClient) readEvents() {
-	scanner := bufio.NewScanner(c.conn)
+// startReading uses a newly opened connection, reading its events into a fresh channel.  Each connection gets its own
+// channel, which is closed when the connection drops.
+func (c *MPVIPCClient) startReading(conn net.Conn) {
+	c.conn = conn
+	c.events = make(chan MPVEvent, 100)
+	go c.readEvents(conn, c.events)
+}
+
+// readEvents continuously reads events from an MPV connection until it drops
+func (c *MPVIPCClient) readEvents(conn net.Conn, events chan<- MPVEvent) {
+	scanner := bufio.NewScanner(conn)
 	for scanner.Scan() {
 		line := scanner.Text()
 
@@ -139,7 +231,7 @@ func (c *MPVIPCClient) readEvents() {
 			continue
 		}
 
-		c.events <- event
+		events <- event
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -147,10 +239,11 @@ func (c *MPVIPCClient) readEvents() {
 	}
 
 	log.Debug("MPV event reader stopped")
-	close(c.events)
+	close(events)
 }
 
-// Events returns the channel for MPV events
+// Events returns the channel for MPV events on the current connection.  It is closed if the connection drops, after
+// which Events must be called again once reconnected.
 func (c *MPVIPCClient) Events() <-chan MPVEvent {
 	return c.events
 }
@@ -190,13 +283,7 @@ func (c *MPVIPCClient) WaitForPlaybackStart(ctx context.Context, timeout time.Du
 	}
 
 	// Also observe playback-time ongoing to figure out when playback starts, and to track progress
-	if err := c.SendCommand([]interface{}{"observe_property", 1, "playback-time"}); err != nil {
-		log.Warn("Failed to observe playback-time property", "error", err)
-	}
-
-	if err := c.SendCommand([]interface{}{"observe_property", 2, "duration"}); err != nil {
-		log.Warn("Failed to observe duration property", "error", err)
-	}
+	c.ObserveProgress()
 
 	// Wait for either an idle-active=false response or a playback-time property change
 	for {
@@ -0,0 +1,173 @@
+//go:build !windows
+
+package player
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMPVIPCClientReconnect(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "mpv.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	accepted := make(chan net.Conn, 2)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- conn
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := NewMPVIPCClient(socketPath)
+	require.NoError(t, client.Connect(ctx))
+	first := <-accepted
+
+	// Dropping the connection closes the events channel
+	first.Close()
+	_, ok := <-client.Events()
+	assert.False(t, ok)
+
+	require.NoError(t, client.Reconnect(ctx, ipcReconnectMaxAttempts, func() bool { return true }))
+	second := <-accepted
+	defer second.Close()
+
+	// The new connection delivers events again
+	_, err = second.Write([]byte(`{"event":"property-change","name":"playback-time","data":42.5}` + "\n"))
+	require.NoError(t, err)
+	event := <-client.Events()
+	assert.Equal(t, "playback-time", event.Name)
+
+	// Observing progress again is sent down the new connection
+	client.ObserveProgress()
+	line, err := bufio.NewReader(second).ReadString('\n')
+	require.NoError(t, err)
+	assert.Contains(t, line, "playback-time")
+	client.Close()
+}
+
+func TestMPVIPCClientReconnectGivesUpWhenExited(t *testing.T) {
+	client := NewMPVIPCClient(filepath.Join(t.TempDir(), "missing.sock"))
+	err := client.Reconnect(context.Background(), ipcReconnectMaxAttempts, func() bool { return false })
+	assert.ErrorIs(t, err, errMPVExited)
+}
+
+func TestMPVIPCClientReconnectGivesUpAfterMaxAttempts(t *testing.T) {
+	client := NewMPVIPCClient(filepath.Join(t.TempDir(), "missing.sock"))
+	err := client.Reconnect(context.Background(), 1, func() bool { return true })
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, errMPVExited)
+}
+
+func TestMPVPlayerNoticesExit(t *testing.T) {
+	// Stands in for an MPV that exits while Hisame is trying to reconnect to it
+	cmd := exec.Command("sh", "-c", "exit 0")
+	require.NoError(t, cmd.Start())
+
+	mpv := NewMPVPlayer(&config.Config{})
+	mpv.watch(cmd)
+	require.Eventually(t, func() bool { return !mpv.alive() }, 5*time.Second, 10*time.Millisecond)
+
+	// The exited process has been reaped rather than left as a zombie
+	assert.False(t, processAlive(cmd.Process.Pid))
+
+	err := mpv.ipcClient.Reconnect(context.Background(), ipcReconnectMaxAttempts, mpv.alive)
+	assert.ErrorIs(t, err, errMPVExited)
+}
+
+func TestMPVPlayerAttach(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "mpv.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			accepted <- conn
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Stands in for an MPV left playing by a previous Hisame, which is running as long as this test is
+	mpv := AttachMPVPlayer(&config.Config{}, socketPath, os.Getpid())
+	events, err := mpv.Attach(ctx)
+	require.NoError(t, err)
+	conn := <-accepted
+	defer conn.Close()
+
+	assert.Equal(t, PlaybackStarted, (<-events).Type)
+
+	// Progress is observed again on the new connection, and reported from there on
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+	assert.Contains(t, line, "observe_property")
+	_, err = conn.Write([]byte(`{"event":"property-change","name":"duration","data":100}` + "\n" +
+		`{"event":"property-change","name":"playback-time","data":50}` + "\n" +
+		`{"event":"end-file"}` + "\n"))
+	require.NoError(t, err)
+
+	var last PlaybackEvent
+	for event := range events {
+		last = event
+	}
+	assert.Equal(t, PlaybackEnded, last.Type)
+	assert.InDelta(t, 50, last.Progress, 0.01)
+}
+
+func TestGetMPVSocketPathIsUniquePerPlayback(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_RUNTIME_DIR", dir)
+	t.Setenv("MPV_IPC_SOCKET", "")
+
+	first, second := GetMPVSocketPath(), GetMPVSocketPath()
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, dir, filepath.Dir(first))
+	assert.Contains(t, filepath.Base(first), mpvSocketPrefix)
+
+	t.Setenv("MPV_IPC_SOCKET", "/tmp/my-mpv-socket")
+	assert.Equal(t, "/tmp/my-mpv-socket", GetMPVSocketPath())
+}
+
+func TestRemoveStaleMPVSockets(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_RUNTIME_DIR", dir)
+
+	// A socket whose MPV exited without removing it
+	stalePath := filepath.Join(dir, mpvSocketPrefix+"1-1.sock")
+	stale, err := net.Listen("unix", stalePath)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	// A socket an MPV is still playing on
+	livePath := filepath.Join(dir, mpvSocketPrefix+"1-2.sock")
+	live, err := net.Listen("unix", livePath)
+	require.NoError(t, err)
+	defer live.Close()
+
+	removeStaleMPVSockets()
+	assert.NoFileExists(t, stalePath)
+	assert.FileExists(t, livePath)
+}
@@ -4,11 +4,13 @@ package player
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/PizzaHomicide/hisame/internal/log"
 	"net"
 	"os/exec"
 	"syscall"
+	"time"
 )
 
 // setupPlayerProcess configures the process for detached execution
@@ -18,12 +20,13 @@ func setupPlayerProcess(cmd *exec.Cmd) {
 	}
 }
 
-// releasePlayerProcess handles post-start process management
-func releasePlayerProcess(cmd *exec.Cmd) error {
-	if cmd.Process != nil {
-		return cmd.Process.Release()
+// processAlive reports whether the process with the given ID is still running
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
 	}
-	return nil
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
 }
 
 // Connect establishes a connection with MPV for Unix systems
@@ -36,7 +39,17 @@ func (c *MPVIPCClient) Connect(ctx context.Context) error {
 		return fmt.Errorf("failed to connect to MPV socket: %w", err)
 	}
 
-	c.conn = conn
-	go c.readEvents()
+	c.startReading(conn)
 	return nil
 }
+
+// socketInUse reports whether something is still listening on the Unix socket.  A socket nothing answers on is left
+// over from an MPV that exited without removing it.
+func socketInUse(path string) bool {
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err != nil {
+		return !errors.Is(err, syscall.ECONNREFUSED)
+	}
+	conn.Close()
+	return true
+}
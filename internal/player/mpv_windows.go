@@ -18,10 +18,24 @@ func setupPlayerProcess(cmd *exec.Cmd) {
 	}
 }
 
-// releasePlayerProcess handles post-start process management
-func releasePlayerProcess(cmd *exec.Cmd) error {
-	// Windows doesn't need explicit process release
-	return nil
+// processAlive reports whether the process with the given ID is still running
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	const processQueryLimitedInformation = 0x1000
+	const stillActive = 259
+	handle, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(pid))
+	if err != nil {
+		return false
+	}
+	defer syscall.CloseHandle(handle)
+
+	var exitCode uint32
+	if err := syscall.GetExitCodeProcess(handle, &exitCode); err != nil {
+		return false
+	}
+	return exitCode == stillActive
 }
 
 // Connect establishes a connection with MPV for Windows
@@ -34,7 +48,12 @@ func (c *MPVIPCClient) Connect(ctx context.Context) error {
 		return fmt.Errorf("failed to connect to MPV pipe: %w", err)
 	}
 
-	c.conn = conn
-	go c.readEvents()
+	c.startReading(conn)
 	return nil
 }
+
+// socketInUse reports whether something is still listening on the socket.  Named pipes go away with the process that
+// created them, so any that exist are in use.
+func socketInUse(path string) bool {
+	return true
+}
@@ -0,0 +1,147 @@
+package player
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/diagnostics"
+	"github.com/PizzaHomicide/hisame/internal/proxy"
+	"github.com/PizzaHomicide/hisame/internal/retry"
+)
+
+const (
+	nyaaURL = "https://nyaa.si/"
+	// nyaaAnimeCategory is Nyaa's category for English translated anime
+	nyaaAnimeCategory = "1_2"
+)
+
+// nyaaTrackers are announced in magnet links, so a torrent client can find peers before it has the torrent file
+var nyaaTrackers = []string{
+	"http://nyaa.tracker.wf:7777/announce",
+	"udp://open.stealth.si:80/announce",
+	"udp://tracker.opentrackr.org:1337/announce",
+	"udp://exodus.desync.com:6969/announce",
+}
+
+// Torrent is a release found on Nyaa
+type Torrent struct {
+	Title     string
+	InfoHash  string
+	Seeders   int
+	Leechers  int
+	Size      string // As Nyaa writes it, e.g. "1.4 GiB"
+	Trusted   bool   // Whether the uploader is trusted by Nyaa
+	ViewURL   string // The torrent's page on Nyaa
+	Published time.Time
+}
+
+// Magnet returns a magnet link for the torrent
+func (t Torrent) Magnet() string {
+	params := url.Values{}
+	params.Set("dn", t.Title)
+	for _, tracker := range nyaaTrackers {
+		params.Add("tr", tracker)
+	}
+	return "magnet:?xt=urn:btih:" + t.InfoHash + "&" + params.Encode()
+}
+
+// nyaaFeed is Nyaa's RSS search results
+type nyaaFeed struct {
+	Items []struct {
+		Title    string `xml:"title"`
+		GUID     string `xml:"guid"`
+		PubDate  string `xml:"pubDate"`
+		Seeders  int    `xml:"seeders"`
+		Leechers int    `xml:"leechers"`
+		InfoHash string `xml:"infoHash"`
+		Size     string `xml:"size"`
+		Trusted  string `xml:"trusted"`
+	} `xml:"channel>item"`
+}
+
+// NyaaClient searches Nyaa's RSS feed for torrents
+type NyaaClient struct {
+	httpClient *http.Client
+	endpoint   string
+	retry      retry.Policy
+}
+
+// NewNyaaClient creates a new Nyaa client
+func NewNyaaClient(retryConfig config.RetryConfig) *NyaaClient {
+	return &NyaaClient{
+		httpClient: &http.Client{
+			Timeout:   20 * time.Second,
+			Transport: retry.NewTransport(proxy.Transport()),
+		},
+		endpoint: nyaaURL,
+		retry:    retry.FromConfig(retryConfig),
+	}
+}
+
+// Search returns the anime torrents matching the query, newest first.  Timeouts and server errors are retried
+// following the retry policy.
+func (c *NyaaClient) Search(ctx context.Context, query string) ([]Torrent, error) {
+	var torrents []Torrent
+	err := c.retry.Do(ctx, "nyaa search", func() error {
+		start := time.Now()
+		var err error
+		torrents, err = c.search(ctx, query)
+		diagnostics.TrackAPICall(diagnostics.APINyaa, "search", start, err)
+		return err
+	})
+	return torrents, err
+}
+
+// search makes a single search request
+func (c *NyaaClient) search(ctx context.Context, query string) ([]Torrent, error) {
+	params := url.Values{}
+	params.Set("page", "rss")
+	params.Set("q", query)
+	params.Set("c", nyaaAnimeCategory)
+	params.Set("f", "0")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Nyaa returned HTTP %d", resp.StatusCode)
+	}
+
+	var feed nyaaFeed
+	if err := xml.NewDecoder(resp.Body).Decode(&feed); err != nil {
+		return nil, fmt.Errorf("failed to decode Nyaa results: %w", err)
+	}
+
+	torrents := make([]Torrent, 0, len(feed.Items))
+	for _, item := range feed.Items {
+		if item.InfoHash == "" {
+			continue
+		}
+		published, _ := time.Parse(time.RFC1123Z, item.PubDate)
+		torrents = append(torrents, Torrent{
+			Title:     strings.TrimSpace(item.Title),
+			InfoHash:  strings.ToLower(item.InfoHash),
+			Seeders:   item.Seeders,
+			Leechers:  item.Leechers,
+			Size:      item.Size,
+			Trusted:   strings.EqualFold(item.Trusted, "yes"),
+			ViewURL:   item.GUID,
+			Published: published,
+		})
+	}
+	return torrents, nil
+}
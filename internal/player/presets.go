@@ -0,0 +1,115 @@
+package player
+
+import (
+	"sort"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/store"
+)
+
+// animePresetsFileName is the name of the file per-anime preset choices are persisted to within the data dir
+const animePresetsFileName = "anime_presets.json"
+
+// Preset is a named set of MPV arguments suited to a kind of hardware, applied on top of the configured args
+type Preset struct {
+	Name        string
+	Description string
+	Args        []string
+}
+
+// builtinPresets ship with Hisame.  Presets of the same name in the config replace them.
+var builtinPresets = []Preset{
+	{
+		Name:        "low-power",
+		Description: "Low-power laptop: hardware decoding and MPV's fast profile",
+		Args:        []string{"--hwdec=auto", "--profile=fast"},
+	},
+	{
+		Name:        "balanced",
+		Description: "Hardware decoding where it is known to be safe, with MPV's default scaling",
+		Args:        []string{"--hwdec=auto-safe"},
+	},
+	{
+		Name:        "high-quality",
+		Description: "High quality scaling, debanding and frame interpolation for capable GPUs",
+		Args: []string{
+			"--scale=ewa_lanczossharp",
+			"--cscale=ewa_lanczossharp",
+			"--dscale=mitchell",
+			"--deband",
+			"--video-sync=display-resample",
+			"--interpolation",
+			"--tscale=oversample",
+		},
+	},
+}
+
+// Presets returns the built in presets merged with any defined in the config, sorted by name
+func Presets(cfg *config.Config) []Preset {
+	byName := make(map[string]Preset, len(builtinPresets)+len(cfg.Player.Presets))
+	for _, preset := range builtinPresets {
+		byName[preset.Name] = preset
+	}
+	for name, args := range cfg.Player.Presets {
+		byName[name] = Preset{Name: name, Description: args, Args: ParseArgs(args)}
+	}
+
+	presets := make([]Preset, 0, len(byName))
+	for _, preset := range byName {
+		presets = append(presets, preset)
+	}
+	sort.Slice(presets, func(i, j int) bool {
+		return presets[i].Name < presets[j].Name
+	})
+	return presets
+}
+
+// FindPreset returns the named preset, reporting whether it exists
+func FindPreset(cfg *config.Config, name string) (Preset, bool) {
+	for _, preset := range Presets(cfg) {
+		if preset.Name == name {
+			return preset, true
+		}
+	}
+	return Preset{}, false
+}
+
+// PresetArgsSetter is implemented by players that accept preset arguments
+type PresetArgsSetter interface {
+	// SetPresetArgs sets arguments the next Play call adds after the configured args
+	SetPresetArgs(args []string)
+}
+
+// AnimePresets remembers the preset chosen for individual anime, overriding the preset in the config
+type AnimePresets struct {
+	presets *store.Map[int, string] // AniList ID to preset name
+}
+
+// NewAnimePresets creates a per-anime preset store backed by the given file.  An empty path keeps choices in memory only.
+func NewAnimePresets(path string) *AnimePresets {
+	presets, err := store.LoadMap[int, string](path)
+	if err != nil {
+		log.Warn("Failed to load per-anime presets, using the configured preset", "path", path, "error", err)
+	}
+	return &AnimePresets{presets: presets}
+}
+
+// newDefaultAnimePresets creates a per-anime preset store in the Hisame data dir
+func newDefaultAnimePresets() *AnimePresets {
+	return NewAnimePresets(store.DataPath(animePresetsFileName, "per-anime presets"))
+}
+
+// Get returns the preset chosen for the anime, or an empty string if it uses the configured preset
+func (a *AnimePresets) Get(animeID int) string {
+	name, _ := a.presets.Get(animeID)
+	return name
+}
+
+// Set chooses the preset for the anime.  An empty name goes back to the configured preset.
+func (a *AnimePresets) Set(animeID int, name string) error {
+	if name == "" {
+		return a.presets.Delete(animeID)
+	}
+	return a.presets.Set(animeID, name)
+}
@@ -0,0 +1,60 @@
+package player
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresetsMergesConfig(t *testing.T) {
+	cfg := &config.Config{Player: config.PlayerConfig{Presets: map[string]string{
+		"low-power": "--hwdec=vaapi",
+		"anime4k":   `--glsl-shaders="~~/shaders/Anime4K.glsl"`,
+	}}}
+
+	preset, ok := FindPreset(cfg, "low-power")
+	require.True(t, ok)
+	assert.Equal(t, []string{"--hwdec=vaapi"}, preset.Args, "config presets should replace built in ones")
+
+	preset, ok = FindPreset(cfg, "anime4k")
+	require.True(t, ok)
+	assert.Equal(t, []string{"--glsl-shaders=~~/shaders/Anime4K.glsl"}, preset.Args)
+
+	_, ok = FindPreset(cfg, "high-quality")
+	assert.True(t, ok, "built in presets should still be available")
+}
+
+func TestPresetArgsPrefersAnimePreset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), animePresetsFileName)
+	s := &PlayerService{
+		config:  &config.Config{Player: config.PlayerConfig{Preset: "low-power"}},
+		presets: NewAnimePresets(path),
+	}
+
+	assert.Equal(t, []string{"--hwdec=auto", "--profile=fast"}, s.presetArgs(1))
+
+	require.NoError(t, s.SetAnimePreset(1, "balanced"))
+	assert.Equal(t, []string{"--hwdec=auto-safe"}, s.presetArgs(1))
+	assert.Equal(t, "balanced", NewAnimePresets(path).Get(1), "the choice should be persisted")
+
+	require.NoError(t, s.SetAnimePreset(1, ""))
+	assert.Equal(t, []string{"--hwdec=auto", "--profile=fast"}, s.presetArgs(1))
+
+	s.config.Player.Preset = "missing"
+	assert.Nil(t, s.presetArgs(1), "unknown presets should be ignored")
+}
+
+func TestFormatArgs(t *testing.T) {
+	s := &PlayerService{config: &config.Config{Player: config.PlayerConfig{FormatArgs: map[string]string{
+		"movie":    "--fs --profile=movie",
+		"TV_SHORT": "--speed=1.0",
+	}}}}
+
+	assert.Equal(t, []string{"--fs", "--profile=movie"}, s.formatArgs("MOVIE"), "formats should match ignoring case")
+	assert.Equal(t, []string{"--speed=1.0"}, s.formatArgs("TV_SHORT"))
+	assert.Nil(t, s.formatArgs("TV"))
+	assert.Nil(t, s.formatArgs(""), "episodes of an unknown format get no format args")
+}
@@ -0,0 +1,146 @@
+package player
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/proxy"
+)
+
+const (
+	// probeTimeout is how long a resolved stream has to answer the check that it can be played
+	probeTimeout = 5 * time.Second
+	// validateStreamBytes is how much of a stream is fetched to check it is a video
+	validateStreamBytes = 512
+)
+
+// ProbedStream is a source whose stream has been resolved and answered a check that it can be played
+type ProbedStream struct {
+	Source EpisodeSource
+	Stream StreamInfo
+}
+
+// FastestStream resolves the sources and validates their streams, probe_sources at a time in the order given, and
+// returns the first one that works.  The next sources are only tried once every source being probed has failed.
+func (s *PlayerService) FastestStream(ctx context.Context, sources []EpisodeSource) (ProbedStream, error) {
+	batchSize := max(s.config.Player.ProbeSources, 1)
+
+	var errs []error
+	for start := 0; start < len(sources); start += batchSize {
+		probed, err := s.probeSources(ctx, sources[start:min(start+batchSize, len(sources))])
+		if err == nil {
+			return probed, nil
+		}
+		errs = append(errs, err)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return ProbedStream{}, fmt.Errorf("failed to get playable URL from any source: %w", errors.Join(errs...))
+}
+
+// probeSources probes each of the sources at the same time, returning the first to work.  The others are cancelled once
+// one has worked
+func (s *PlayerService) probeSources(ctx context.Context, sources []EpisodeSource) (ProbedStream, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type probeResult struct {
+		probed ProbedStream
+		err    error
+	}
+	// Buffered so the probes that lose the race can still finish once nobody is waiting for them
+	results := make(chan probeResult, len(sources))
+	for _, source := range sources {
+		go func() {
+			start := time.Now()
+			stream, err := s.GetStream(ctx, source)
+			if err == nil {
+				err = s.validateStream(ctx, stream)
+				// The source only counts as working once its stream has passed validation.  Don't penalise it if we
+				// gave up on it ourselves, such as when another source won.
+				if err == nil {
+					s.reliability.RecordSuccess(source.SourceName)
+				} else if ctx.Err() == nil {
+					s.reliability.RecordFailure(source.SourceName)
+				}
+			}
+			if err != nil {
+				err = fmt.Errorf("%s: %w", source.SourceName, err)
+			} else {
+				log.Debug("Source probed", "source_name", source.SourceName, "latency", time.Since(start))
+			}
+			results <- probeResult{probed: ProbedStream{Source: source, Stream: stream}, err: err}
+		}()
+	}
+
+	var errs []error
+	for range sources {
+		result := <-results
+		if result.err == nil {
+			return result.probed, nil
+		}
+		log.Warn("Failed to get a playable stream from source", "error", result.err)
+		errs = append(errs, result.err)
+	}
+	return ProbedStream{}, errors.Join(errs...)
+}
+
+// validateStream fetches the start of the stream, to catch hosts that hand out links which won't play before the player
+// is launched with them.  The stream has to answer with a success, something other than an error page, and the start
+// of a video or HLS playlist.  Streams that aren't fetched over HTTP are left for the player to deal with, and pages
+// yt-dlp extracts the video from only have to answer with a success.
+func (s *PlayerService) validateStream(ctx context.Context, stream StreamInfo) error {
+	if !strings.HasPrefix(stream.URL, "http://") && !strings.HasPrefix(stream.URL, "https://") {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, stream.URL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", allAnimeUserAgent)
+	req.Header.Set("Range", fmt.Sprintf("bytes=0-%d", validateStreamBytes-1))
+	if stream.Referer != "" {
+		req.Header.Set("Referer", stream.Referer)
+	}
+
+	client := &http.Client{Timeout: probeTimeout, Transport: proxy.Transport()}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("stream did not answer: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("stream answered with HTTP %d", resp.StatusCode)
+	}
+	if stream.YTDL {
+		return nil
+	}
+	// Hosters tend to answer dead links with a page or an error rather than a status code
+	contentType := strings.ToLower(resp.Header.Get("Content-Type"))
+	if strings.HasPrefix(contentType, "text/html") || strings.HasPrefix(contentType, "application/json") {
+		return fmt.Errorf("stream answered with %s instead of a video", contentType)
+	}
+
+	start := make([]byte, validateStreamBytes)
+	n, err := io.ReadFull(resp.Body, start)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("stream is empty")
+		}
+		return fmt.Errorf("failed to read stream: %w", err)
+	}
+	if stream.HLS && !bytes.HasPrefix(bytes.TrimSpace(start[:n]), []byte("#EXTM3U")) {
+		return fmt.Errorf("stream is not an HLS playlist")
+	}
+	return nil
+}
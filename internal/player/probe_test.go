@@ -0,0 +1,149 @@
+package player
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFastestStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "bytes=0-511", r.Header.Get("Range"))
+		switch r.URL.Path {
+		case "/slow.mp4":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(2 * time.Second):
+			}
+		case "/broken.mp4":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "video/mp4")
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = w.Write([]byte("\x00\x00\x00\x20ftypisom"))
+	}))
+	defer server.Close()
+
+	source := func(name string) EpisodeSource {
+		return EpisodeSource{SourceName: name, SourceURL: server.URL + "/" + name + ".mp4"}
+	}
+	s := &PlayerService{
+		config:      &config.Config{Player: config.PlayerConfig{ProbeSources: 3}},
+		reliability: NewSourceReliability(""),
+	}
+
+	// The fastest working source wins, even though it was listed last
+	probed, err := s.FastestStream(context.Background(), []EpisodeSource{source("slow"), source("broken"), source("fast")})
+	require.NoError(t, err)
+	assert.Equal(t, "fast", probed.Source.SourceName)
+	assert.Equal(t, server.URL+"/fast.mp4", probed.Stream.URL)
+
+	// Sources after the first batch are tried once it has all failed
+	s.config.Player.ProbeSources = 1
+	probed, err = s.FastestStream(context.Background(), []EpisodeSource{source("broken"), source("fast")})
+	require.NoError(t, err)
+	assert.Equal(t, "fast", probed.Source.SourceName)
+
+	_, err = s.FastestStream(context.Background(), []EpisodeSource{source("broken")})
+	assert.ErrorContains(t, err, "HTTP 404")
+}
+
+func TestFastestStreamRecordsReliability(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken.mp4" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "video/mp4")
+		_, _ = w.Write([]byte("\x00\x00\x00\x20ftypisom"))
+	}))
+	defer server.Close()
+
+	s := &PlayerService{
+		config:      &config.Config{Player: config.PlayerConfig{ProbeSources: 1}},
+		reliability: NewSourceReliability(""),
+	}
+
+	_, err := s.FastestStream(context.Background(), []EpisodeSource{
+		{SourceName: "broken", SourceURL: server.URL + "/broken.mp4"},
+		{SourceName: "fast", SourceURL: server.URL + "/fast.mp4"},
+	})
+	require.NoError(t, err)
+	broken := s.reliability.Stats("broken")
+	assert.Zero(t, broken.Successes, "a source whose link resolves but won't play should not count as working")
+	assert.Equal(t, 1, broken.Failures)
+	assert.Equal(t, 1, s.reliability.Stats("fast").Successes)
+}
+
+func TestValidateStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/page.mp4":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte("<html>File not found</html>"))
+		case "/empty.mp4":
+		case "/playlist.m3u8":
+			_, _ = w.Write([]byte("#EXTM3U\n#EXT-X-VERSION:3\n"))
+		case "/fake.m3u8":
+			_, _ = w.Write([]byte("not a playlist"))
+		default:
+			w.Header().Set("Content-Type", "video/mp4")
+			_, _ = w.Write([]byte("\x00\x00\x00\x20ftypisom"))
+		}
+	}))
+	defer server.Close()
+
+	s := &PlayerService{}
+	tests := []struct {
+		path    string
+		hls     bool
+		wantErr string
+	}{
+		{"/video.mp4", false, ""},
+		{"/playlist.m3u8", true, ""},
+		{"/page.mp4", false, "text/html"},
+		{"/empty.mp4", false, "empty"},
+		{"/fake.m3u8", true, "not an HLS playlist"},
+	}
+	for _, tt := range tests {
+		err := s.validateStream(context.Background(), StreamInfo{URL: server.URL + tt.path, HLS: tt.hls})
+		if tt.wantErr == "" {
+			assert.NoError(t, err, tt.path)
+		} else {
+			assert.ErrorContains(t, err, tt.wantErr, tt.path)
+		}
+	}
+
+	assert.NoError(t, s.validateStream(context.Background(), StreamInfo{URL: "magnet:?xt=urn:btih:abc"}),
+		"streams that aren't fetched over HTTP are left to the player")
+	assert.NoError(t, s.validateStream(context.Background(), StreamInfo{URL: server.URL + "/page.mp4", YTDL: true}),
+		"yt-dlp extracts the video from the page")
+}
+
+func TestFastestStreamYTDL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte("<html><video></video></html>"))
+	}))
+	defer server.Close()
+
+	s := &PlayerService{
+		config:      &config.Config{Player: config.PlayerConfig{Type: "mpv", ProbeSources: 1}},
+		reliability: NewSourceReliability(""),
+	}
+	// Stand in for ok.ru by pointing a ytdl source at the test server
+	source := EpisodeSource{SourceName: "Ok", SourceURL: server.URL + "/videoembed/123"}
+	ytdlHosts[sourceHost(source)] = true
+	defer delete(ytdlHosts, sourceHost(source))
+
+	probed, err := s.FastestStream(context.Background(), []EpisodeSource{source})
+	require.NoError(t, err)
+	assert.True(t, probed.Stream.YTDL)
+}
@@ -0,0 +1,167 @@
+package player
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/proxy"
+)
+
+// defaultEpisodeMinutes is the episode length assumed when estimating progress for an anime with no known duration
+const defaultEpisodeMinutes = 24
+
+// ProcessPlayer implements the VideoPlayer interface for custom players Hisame has no IPC connection to.  It can only
+// see the player process start and exit, so it reports how long the player ran for rather than playback progress.
+//
+// The command and args can contain the placeholders {url}, {title} and {start}, which are replaced with the stream URL,
+// the episode title and the number of seconds to resume from.  The URL is added as the last argument if {url} isn't
+// used.
+type ProcessPlayer struct {
+	config   *config.Config
+	name     string // What the player is called in logs
+	cmd      *exec.Cmd
+	startPos float64 // Seconds to start playback at, 0 to start from the beginning
+}
+
+// NewProcessPlayer creates a new player that runs the configured command and watches for it to exit
+func NewProcessPlayer(cfg *config.Config) *ProcessPlayer {
+	return &ProcessPlayer{
+		config: cfg,
+		name:   "custom player",
+	}
+}
+
+// Play starts the player with the given URL.  PlaybackStarted is sent as soon as the process starts, and
+// PlaybackEnded with the time it ran for once it exits.
+func (p *ProcessPlayer) Play(ctx context.Context, url string, title string) (<-chan PlaybackEvent, error) {
+	log.Info("Starting custom player playback", "url", url, "title", title)
+
+	events := make(chan PlaybackEvent, 10)
+
+	commandStr := p.config.Player.Command
+	if commandStr == "" {
+		commandStr = p.config.Player.Path
+	}
+	commandParts := ParseArgs(commandStr)
+	if len(commandParts) == 0 {
+		close(events)
+		return events, fmt.Errorf("no player command configured")
+	}
+
+	args := commandParts[1:]
+	if p.config.Player.Args != "" {
+		args = append(args, ParseArgs(p.config.Player.Args)...)
+	}
+	args = expandArgTemplates(args, url, title, p.startPos)
+
+	return p.run(ctx, commandParts[0], args, events)
+}
+
+// run starts the player process, sending PlaybackStarted as soon as it starts and PlaybackEnded with the time it ran
+// for once it exits
+func (p *ProcessPlayer) run(ctx context.Context, executable string, args []string, events chan PlaybackEvent) (<-chan PlaybackEvent, error) {
+	cmd := exec.Command(executable, args...)
+	cmd.Env = proxy.Environ()
+	setupPlayerProcess(cmd)
+
+	if err := cmd.Start(); err != nil {
+		close(events)
+		return events, fmt.Errorf("failed to start %s: %w", p.name, err)
+	}
+	p.cmd = cmd
+	start := time.Now()
+
+	events <- PlaybackEvent{Type: PlaybackStarted}
+
+	go func() {
+		defer close(events)
+
+		exited := make(chan error, 1)
+		go func() {
+			exited <- cmd.Wait()
+		}()
+
+		select {
+		case <-ctx.Done():
+			log.Debug("Context cancelled, stopping player monitoring", "player", p.name)
+		case err := <-exited:
+			elapsed := time.Since(start)
+			if err != nil {
+				// Plenty of players exit non-zero when closed by the user, so this isn't treated as a playback error
+				log.Warn("Player exited with an error", "player", p.name, "error", err, "elapsed", elapsed)
+			}
+			log.Info("Player exited", "player", p.name, "elapsed", elapsed)
+			events <- p.endedEvent(elapsed)
+		}
+	}()
+
+	return events, nil
+}
+
+// endedEvent reports the player exiting after running for elapsed.  With assume_finished_minutes set, a player that
+// ran at least that long is taken to have played the whole episode, so progress is updated without asking.
+func (p *ProcessPlayer) endedEvent(elapsed time.Duration) PlaybackEvent {
+	if minutes := p.config.Player.AssumeFinishedMinutes; minutes > 0 && elapsed >= time.Duration(minutes)*time.Minute {
+		log.Info("Player ran long enough to assume the episode was finished", "player", p.name, "elapsed", elapsed,
+			"assume_finished_minutes", minutes)
+		return PlaybackEvent{Type: PlaybackEnded, Progress: 100}
+	}
+	return PlaybackEvent{Type: PlaybackEnded, Elapsed: elapsed}
+}
+
+// expandArgTemplates replaces the {url}, {title} and {start} placeholders in the player arguments.  The URL is added
+// as the last argument if no argument asks for it.
+func expandArgTemplates(args []string, url, title string, startPos float64) []string {
+	replacer := strings.NewReplacer(
+		"{url}", url,
+		"{title}", title,
+		"{start}", fmt.Sprintf("%.0f", startPos),
+	)
+
+	expanded := make([]string, 0, len(args)+1)
+	hasURL := false
+	for _, arg := range args {
+		if strings.Contains(arg, "{url}") {
+			hasURL = true
+		}
+		expanded = append(expanded, replacer.Replace(arg))
+	}
+	if !hasURL {
+		expanded = append(expanded, url)
+	}
+	return expanded
+}
+
+// SetStartPosition sets how many seconds into the media the next playback starts at, for the {start} placeholder
+func (p *ProcessPlayer) SetStartPosition(seconds float64) {
+	p.startPos = seconds
+}
+
+// Stop stops playback if it's active
+func (p *ProcessPlayer) Stop() error {
+	if p.cmd != nil && p.cmd.Process != nil {
+		log.Info("Stopping playback", "player", p.name)
+		return p.cmd.Process.Kill()
+	}
+	return nil
+}
+
+// Cleanup performs any necessary cleanup
+func (p *ProcessPlayer) Cleanup() {
+	p.Stop()
+}
+
+// EstimateProgress estimates the percentage of an episode watched from how long the player ran for.  A default
+// episode length is assumed when the duration isn't known.
+func EstimateProgress(elapsed time.Duration, episodeMinutes int) float64 {
+	if episodeMinutes <= 0 {
+		episodeMinutes = defaultEpisodeMinutes
+	}
+	progress := elapsed.Minutes() / float64(episodeMinutes) * 100
+	return min(progress, 100)
+}
@@ -0,0 +1,35 @@
+package player
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateProgress(t *testing.T) {
+	assert.InDelta(t, 50.0, EstimateProgress(12*time.Minute, 24), 0.001)
+	assert.InDelta(t, 25.0, EstimateProgress(6*time.Minute, 0), 0.001, "unknown durations should assume a default length")
+	assert.Equal(t, 100.0, EstimateProgress(2*time.Hour, 24), "progress should be capped at 100")
+}
+
+func TestExpandArgTemplates(t *testing.T) {
+	args := expandArgTemplates([]string{"-ss", "{start}", "-title", "{title}", "{url}", "-fs"}, "https://example.com/ep.m3u8", "Ep 3 - Frieren", 90)
+	assert.Equal(t, []string{"-ss", "90", "-title", "Ep 3 - Frieren", "https://example.com/ep.m3u8", "-fs"}, args)
+
+	args = expandArgTemplates([]string{"--play-and-exit"}, "https://example.com/ep.m3u8", "Ep 3", 0)
+	assert.Equal(t, []string{"--play-and-exit", "https://example.com/ep.m3u8"}, args, "the URL should be added last without {url}")
+}
+
+func TestProcessPlayerAssumeFinished(t *testing.T) {
+	p := NewProcessPlayer(&config.Config{Player: config.PlayerConfig{AssumeFinishedMinutes: 20}})
+
+	event := p.endedEvent(21 * time.Minute)
+	assert.Equal(t, 100.0, event.Progress)
+	assert.Zero(t, event.Elapsed, "the progress shouldn't be estimated again")
+
+	event = p.endedEvent(5 * time.Minute)
+	assert.Equal(t, 5*time.Minute, event.Elapsed)
+	assert.Zero(t, event.Progress)
+}
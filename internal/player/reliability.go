@@ -0,0 +1,96 @@
+package player
+
+import (
+	"sort"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/store"
+)
+
+// reliabilityFileName is the name of the file the learned source reliability is persisted to within the data dir
+const reliabilityFileName = "source_reliability.json"
+
+// SourceStats tracks how often a single AllAnime source has worked
+type SourceStats struct {
+	Successes   int       `json:"successes"`
+	Failures    int       `json:"failures"`
+	LastSuccess time.Time `json:"last_success,omitempty"`
+	LastFailure time.Time `json:"last_failure,omitempty"`
+}
+
+// Score returns the learned reliability of the source between 0 and 1.  Uses Laplace smoothing so sources with no
+// history score a neutral 0.5, and a single failure does not write a source off entirely.
+func (s SourceStats) Score() float64 {
+	return float64(s.Successes+1) / float64(s.Successes+s.Failures+2)
+}
+
+// SourceReliability keeps persistent success/failure counts for each AllAnime source name, so sources that work
+// well are tried first regardless of the static priority AllAnime gives them
+type SourceReliability struct {
+	stats *store.Map[string, SourceStats]
+}
+
+// NewSourceReliability creates a reliability tracker backed by the given file.  An empty path keeps stats in memory only.
+func NewSourceReliability(path string) *SourceReliability {
+	stats, err := store.LoadMap[string, SourceStats](path)
+	if err != nil {
+		log.Warn("Failed to load source reliability, starting fresh", "path", path, "error", err)
+	}
+	return &SourceReliability{stats: stats}
+}
+
+// newDefaultSourceReliability creates a reliability tracker stored in the Hisame data dir
+func newDefaultSourceReliability() *SourceReliability {
+	return NewSourceReliability(store.DataPath(reliabilityFileName, "source reliability"))
+}
+
+// RecordSuccess records that a stream URL was successfully resolved from the named source
+func (r *SourceReliability) RecordSuccess(sourceName string) {
+	r.record(sourceName, true)
+}
+
+// RecordFailure records that the named source failed to produce a stream URL
+func (r *SourceReliability) RecordFailure(sourceName string) {
+	r.record(sourceName, false)
+}
+
+// Stats returns the stats for the named source
+func (r *SourceReliability) Stats(sourceName string) SourceStats {
+	stats, _ := r.stats.Get(sourceName)
+	return stats
+}
+
+// Sort orders sources best first.  The static AllAnime priority is weighted by the learned reliability, scaled so that
+// a source with no history keeps its priority unchanged and a consistently failing source drops to half of it.
+func (r *SourceReliability) Sort(sources []EpisodeSource) {
+	weights := make(map[string]float64, len(sources))
+	for _, source := range sources {
+		weights[source.SourceName] = source.Priority * (0.5 + r.Stats(source.SourceName).Score())
+	}
+
+	sort.SliceStable(sources, func(i, j int) bool {
+		return weights[sources[i].SourceName] > weights[sources[j].SourceName]
+	})
+}
+
+func (r *SourceReliability) record(sourceName string, success bool) {
+	var score float64
+	err := r.stats.Update(sourceName, func(stats SourceStats, _ bool) (SourceStats, bool) {
+		if success {
+			stats.Successes++
+			stats.LastSuccess = time.Now()
+		} else {
+			stats.Failures++
+			stats.LastFailure = time.Now()
+		}
+		score = stats.Score()
+		return stats, true
+	})
+
+	log.Debug("Recorded source result", "source_name", sourceName, "success", success, "score", score)
+
+	if err != nil {
+		log.Warn("Failed to persist source reliability", "error", err)
+	}
+}
@@ -0,0 +1,47 @@
+package player
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceStatsScore(t *testing.T) {
+	assert.Equal(t, 0.5, SourceStats{}.Score())
+	assert.Greater(t, SourceStats{Successes: 5}.Score(), 0.8)
+	assert.Less(t, SourceStats{Failures: 5}.Score(), 0.2)
+}
+
+func TestSourceReliabilitySort(t *testing.T) {
+	r := NewSourceReliability("")
+	for i := 0; i < 10; i++ {
+		r.RecordFailure("S-mp4")
+		r.RecordSuccess("Luf-mp4")
+	}
+
+	sources := []EpisodeSource{
+		{SourceName: "S-mp4", Priority: 8},
+		{SourceName: "Luf-mp4", Priority: 7},
+		{SourceName: "Unknown", Priority: 7.5},
+	}
+	r.Sort(sources)
+
+	assert.Equal(t, "Luf-mp4", sources[0].SourceName)
+	assert.Equal(t, "Unknown", sources[1].SourceName)
+	assert.Equal(t, "S-mp4", sources[2].SourceName)
+}
+
+func TestSourceReliabilityPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), reliabilityFileName)
+
+	r := NewSourceReliability(path)
+	r.RecordSuccess("S-mp4")
+	r.RecordSuccess("S-mp4")
+	r.RecordFailure("S-mp4")
+
+	reloaded := NewSourceReliability(path)
+	stats := reloaded.Stats("S-mp4")
+	assert.Equal(t, 2, stats.Successes)
+	assert.Equal(t, 1, stats.Failures)
+}
@@ -0,0 +1,234 @@
+package player
+
+import (
+	"bufio"
+	"crypto/md5"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/store"
+)
+
+const (
+	// resumeFileName is the name of the file resume points are persisted to within the data dir
+	resumeFileName = "resume_points.json"
+	// resumeMinPosition is how far into an episode playback must get before it is worth resuming
+	resumeMinPosition = 30.0
+	// resumeMaxProgress is the percentage after which an episode is treated as watched and not resumed
+	resumeMaxProgress = 90.0
+)
+
+// ResumePoint is where playback of an episode last stopped
+type ResumePoint struct {
+	AnimeID   int       `json:"anime_id"`
+	Episode   int       `json:"episode"`
+	Position  float64   `json:"position"`             // Seconds into the episode
+	StreamURL string    `json:"stream_url,omitempty"` // The URL MPV was given, which MPV's watch_later file is named after
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ResumeStore keeps resume points for episodes, persisted to a JSON file.  When a watch_later directory is set,
+// positions MPV saved there are merged in, so resuming an episode directly in MPV is reflected in Hisame too.
+type ResumeStore struct {
+	mu            sync.Mutex
+	file          store.File
+	watchLaterDir string
+	points        map[string]*ResumePoint
+}
+
+// NewResumeStore creates a resume store backed by the given file.  An empty path keeps points in memory only, and an
+// empty watchLaterDir disables syncing from MPV.
+func NewResumeStore(path, watchLaterDir string) *ResumeStore {
+	r := &ResumeStore{
+		file:          store.NewFile(path),
+		watchLaterDir: watchLaterDir,
+		points:        make(map[string]*ResumePoint),
+	}
+	if err := r.load(); err != nil {
+		log.Warn("Failed to load resume points, starting fresh", "path", path, "error", err)
+	}
+	return r
+}
+
+// newDefaultResumeStore creates a resume store in the Hisame data dir, syncing from MPV's watch_later directory if
+// enabled in the config
+func newDefaultResumeStore(cfg *config.Config) *ResumeStore {
+	watchLaterDir := ""
+	if cfg.Player.WatchLaterSync {
+		watchLaterDir = cfg.Player.WatchLaterDir
+		if watchLaterDir == "" {
+			watchLaterDir = defaultWatchLaterDir()
+		}
+		log.Debug("Syncing resume points from MPV watch_later", "dir", watchLaterDir)
+	}
+
+	return NewResumeStore(store.DataPath(resumeFileName, "resume points"), watchLaterDir)
+}
+
+// Position returns the position to resume the episode from, or 0 if it should be played from the start
+func (r *ResumeStore) Position(animeID, episode int) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	point, ok := r.points[resumeKey(animeID, episode)]
+	if !ok {
+		return 0
+	}
+	r.syncWatchLater(point)
+	if point.Position < resumeMinPosition {
+		return 0
+	}
+	return point.Position
+}
+
+// RecordLaunch remembers the stream URL an episode was launched with, so MPV's watch_later file can be found later
+func (r *ResumeStore) RecordLaunch(animeID, episode int, streamURL string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	point := r.point(animeID, episode)
+	point.StreamURL = streamURL
+	point.UpdatedAt = time.Now()
+	if err := r.save(); err != nil {
+		log.Warn("Failed to persist resume points", "error", err)
+	}
+}
+
+// RecordStop stores where playback stopped.  Episodes watched to the end have their resume point cleared.
+func (r *ResumeStore) RecordStop(animeID, episode int, position, progress float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if progress >= resumeMaxProgress {
+		delete(r.points, resumeKey(animeID, episode))
+	} else {
+		point := r.point(animeID, episode)
+		point.Position = position
+		point.UpdatedAt = time.Now()
+	}
+	if err := r.save(); err != nil {
+		log.Warn("Failed to persist resume points", "error", err)
+	}
+}
+
+// point returns the resume point for an episode, creating it if needed.  Must be called with the lock held.
+func (r *ResumeStore) point(animeID, episode int) *ResumePoint {
+	key := resumeKey(animeID, episode)
+	point, ok := r.points[key]
+	if !ok {
+		point = &ResumePoint{AnimeID: animeID, Episode: episode}
+		r.points[key] = point
+	}
+	return point
+}
+
+// syncWatchLater updates the point from MPV's watch_later file if MPV saved a position after Hisame last did.
+// Must be called with the lock held.
+func (r *ResumeStore) syncWatchLater(point *ResumePoint) {
+	if r.watchLaterDir == "" || point.StreamURL == "" {
+		return
+	}
+
+	file := filepath.Join(r.watchLaterDir, watchLaterFileName(point.StreamURL))
+	info, err := os.Stat(file)
+	if err != nil || !info.ModTime().After(point.UpdatedAt) {
+		return
+	}
+
+	position, err := readWatchLaterStart(file)
+	if err != nil {
+		log.Warn("Failed to read MPV watch_later file", "file", file, "error", err)
+		return
+	}
+
+	log.Info("Synced resume position from MPV watch_later", "anime_id", point.AnimeID, "episode", point.Episode,
+		"position", position)
+	point.Position = position
+	point.UpdatedAt = info.ModTime()
+	if err := r.save(); err != nil {
+		log.Warn("Failed to persist resume points", "error", err)
+	}
+}
+
+// watchLaterFileName returns the name MPV gives the watch_later file for a path or URL:  its MD5 in upper case hex
+func watchLaterFileName(path string) string {
+	return fmt.Sprintf("%X", md5.Sum([]byte(path)))
+}
+
+// readWatchLaterStart reads the saved position from an MPV watch_later file
+func readWatchLaterStart(file string) (float64, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "start="); ok {
+			return strconv.ParseFloat(value, 64)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("no start position in watch_later file")
+}
+
+// defaultWatchLaterDir returns the directory MPV saves watch_later files to by default
+func defaultWatchLaterDir() string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("APPDATA"), "mpv", "watch_later")
+	}
+
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	// MPV 0.36 moved watch_later to the state dir.  Prefer it, but fall back to the old location if that is in use.
+	stateDir := os.Getenv("XDG_STATE_HOME")
+	if stateDir == "" {
+		stateDir = filepath.Join(homedir, ".local", "state")
+	}
+	dir := filepath.Join(stateDir, "mpv", "watch_later")
+	if _, err := os.Stat(dir); err != nil {
+		legacy := filepath.Join(homedir, ".config", "mpv", "watch_later")
+		if _, err := os.Stat(legacy); err == nil {
+			return legacy
+		}
+	}
+	return dir
+}
+
+func resumeKey(animeID, episode int) string {
+	return fmt.Sprintf("%d:%d", animeID, episode)
+}
+
+func (r *ResumeStore) load() error {
+	var points []*ResumePoint
+	if err := r.file.Load(&points); err != nil {
+		return err
+	}
+	for _, point := range points {
+		r.points[resumeKey(point.AnimeID, point.Episode)] = point
+	}
+	return nil
+}
+
+// save writes the resume points to disk.  Callers must hold the lock.
+func (r *ResumeStore) save() error {
+	points := make([]*ResumePoint, 0, len(r.points))
+	for _, point := range r.points {
+		points = append(points, point)
+	}
+	return r.file.Save(points)
+}
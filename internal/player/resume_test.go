@@ -0,0 +1,47 @@
+package player
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchLaterFileName(t *testing.T) {
+	// MD5 of "https://example.com/ep1.mp4", in the upper case hex MPV uses
+	assert.Equal(t, "EFB2F1531B94F2B202D760D9A6C8B393", watchLaterFileName("https://example.com/ep1.mp4"))
+}
+
+func TestResumeStorePosition(t *testing.T) {
+	r := NewResumeStore(filepath.Join(t.TempDir(), resumeFileName), "")
+
+	r.RecordLaunch(1, 3, "https://example.com/ep3.mp4")
+	r.RecordStop(1, 3, 10, 1)
+	assert.Zero(t, r.Position(1, 3), "positions near the start should not be resumed")
+
+	r.RecordStop(1, 3, 600, 40)
+	assert.Equal(t, 600.0, r.Position(1, 3))
+
+	r.RecordStop(1, 3, 1400, 95)
+	assert.Zero(t, r.Position(1, 3), "watched episodes should not be resumed")
+}
+
+func TestResumeStoreSyncsWatchLater(t *testing.T) {
+	watchLaterDir := t.TempDir()
+	url := "https://example.com/ep4.mp4"
+
+	r := NewResumeStore("", watchLaterDir)
+	r.RecordLaunch(2, 4, url)
+	r.RecordStop(2, 4, 300, 20)
+
+	// MPV saved a later position after the episode was resumed outside Hisame
+	file := filepath.Join(watchLaterDir, watchLaterFileName(url))
+	require.NoError(t, os.WriteFile(file, []byte("# "+url+"\nstart=842.125000\nvolume=80\n"), 0644))
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(file, later, later))
+
+	assert.Equal(t, 842.125, r.Position(2, 4))
+}
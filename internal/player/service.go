@@ -2,67 +2,210 @@ package player
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/diagnostics"
 	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/inhibit"
 	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/proxy"
+	"golang.org/x/sync/errgroup"
 	"io"
 	"net/http"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 const (
 	// MatchTypeAniList indicates the show was matched by AniList ID
 	MatchTypeAniList = "anilist"
+	// MatchTypeMAL indicates the show was matched by MyAnimeList ID
+	MatchTypeMAL = "mal"
 	// MatchTypeSynonym indicates the show was matched by synonym
 	MatchTypeSynonym = "synonym"
 )
 
+// allAnimeCountries are the countries of origin AllAnime can narrow a search to
+var allAnimeCountries = map[string]bool{"JP": true, "CN": true, "KR": true}
+
 // PlayerService implements the Service interface
 type PlayerService struct {
 	config      *config.Config
 	animeClient *AllAnimeClient
+	nyaaClient  *NyaaClient
+	reliability *SourceReliability
+	resume      *ResumeStore
+	presets     *AnimePresets
+	translation *AnimeTranslations
+	mappings    *ShowMappings
+	sessions    *PlaybackSessions
+
+	activeLock   sync.Mutex
+	active       map[VideoPlayer]struct{} // Players launched whose playback hasn't finished being monitored
+	inhibitSleep bool                     // Whether the system is kept awake while players are active
+
+	refererLock sync.Mutex
+	referers    map[string]string // Stream URL to the Referer its host needs, for streams resolved this session
 }
 
 // NewPlayerService creates a new player service
 func NewPlayerService(config *config.Config) *PlayerService {
+	// Each playback gets its own MPV socket, so clear out any left behind by players that crashed
+	go removeStaleMPVSockets()
+
 	return &PlayerService{
 		config:      config,
-		animeClient: NewAllAnimeClient(),
+		animeClient: NewAllAnimeClient(config.AllAnime, config.Network.Retry),
+		nyaaClient:  NewNyaaClient(config.Network.Retry),
+		reliability: newDefaultSourceReliability(),
+		resume:      newDefaultResumeStore(config),
+		presets:     newDefaultAnimePresets(),
+		translation: newDefaultAnimeTranslations(),
+		mappings:    newDefaultShowMappings(),
+		sessions:    newDefaultPlaybackSessions(),
+
+		inhibitSleep: config.Player.InhibitSleep != "off",
 	}
 }
 
-// FindEpisodes implements the Service FindEpisodes method
-func (s *PlayerService) FindEpisodes(ctx context.Context, animeID int, title *domain.AnimeTitle, synonyms []string) (*FindEpisodesResult, error) {
-	log.Debug("Finding episodes", "title", title.Preferred, "id", animeID, "synonyms", synonyms)
+// SetAllowAdult sets whether adult shows are searched for episodes, following the user's AniList setting
+func (s *PlayerService) SetAllowAdult(allow bool) {
+	s.animeClient.allowAdult = allow
+}
+
+// FindEpisodes finds the episodes of an anime on AllAnime, across every AllAnime show that matches it.  Episodes are
+// found in the anime's translation type, unless there are none in it, which is common for shows still being dubbed,
+// in which case they are found in the other type and marked as a fallback.
+func (s *PlayerService) FindEpisodes(ctx context.Context, anime *domain.Anime) (*FindEpisodesResult, error) {
+	translationType := s.translationType(anime.ID)
+	result, err := s.findEpisodes(ctx, anime, translationType)
+	if err == nil && len(result.Episodes) > 0 {
+		s.addEpisodeMetadata(ctx, result)
+		result.setFormat(anime.Format)
+		return result, nil
+	}
 
-	// Search for shows matching the anime title.  Cycles through each language looking for a match, as sometimes
-	// we find one for one language, but not another.
-	titles := []string{title.Native, title.English, title.Romaji}
-	var allShows []AllAnimeShow
+	other := otherTranslationType(translationType)
+	if other == "" || ctx.Err() != nil {
+		return result, err
+	}
+	log.Info("No episodes found in the preferred translation type, trying the other", "id", anime.ID,
+		"translation_type", translationType, "fallback", other, "error", err)
+	fallback, fallbackErr := s.findEpisodes(ctx, anime, other)
+	if fallbackErr != nil || len(fallback.Episodes) == 0 {
+		return result, err
+	}
+	for i := range fallback.Episodes {
+		fallback.Episodes[i].TranslationFallback = true
+	}
+	s.addEpisodeMetadata(ctx, fallback)
+	fallback.setFormat(anime.Format)
+	return fallback, nil
+}
+
+// setFormat sets the AniList format of the anime the episodes were found for
+func (r *FindEpisodesResult) setFormat(format string) {
+	for i := range r.Episodes {
+		r.Episodes[i].Format = format
+	}
+}
+
+// addEpisodeMetadata fills in the titles, thumbnails and upload dates AllAnime has for the episodes.  They are a nice
+// to have, so they are skipped in low bandwidth mode and failing to fetch them only leaves them out.
+func (s *PlayerService) addEpisodeMetadata(ctx context.Context, result *FindEpisodesResult) {
+	if s.config.Network.LowBandwidth {
+		return
+	}
 
-	// Try each title format
-	for _, title := range titles {
-		if title == "" {
-			continue // Skip empty titles
+	// Each show's episodes are fetched in one request, covering the range of their numbers on AllAnime
+	type episodeRange struct{ first, last int }
+	ranges := make(map[string]*episodeRange)
+	var showIDs []string
+	for _, episode := range result.Episodes {
+		number, err := strconv.Atoi(episode.AllAnimeEpisodeNumber)
+		if err != nil {
+			continue
 		}
+		r, ok := ranges[episode.AllAnimeID]
+		if !ok {
+			ranges[episode.AllAnimeID] = &episodeRange{first: number, last: number}
+			showIDs = append(showIDs, episode.AllAnimeID)
+			continue
+		}
+		r.first = min(r.first, number)
+		r.last = max(r.last, number)
+	}
 
-		shows, err := s.animeClient.SearchShows(ctx, title, s.config.Player.TranslationType)
+	metadata := make(map[string]EpisodeMetadata) // Keyed by show ID and episode number
+	for _, showID := range showIDs {
+		r := ranges[showID]
+		infos, err := s.animeClient.GetEpisodeInfos(ctx, showID, r.first, r.last)
 		if err != nil {
-			log.Warn("Error searching with title format", "title", title, "error", err)
-			continue // Try next format on error
+			log.Warn("Failed to fetch episode metadata", "allanime_id", showID, "error", err)
+			continue
+		}
+		for _, info := range infos {
+			metadata[fmt.Sprintf("%s/%g", showID, info.Number)] = info
 		}
+	}
 
-		// Add these shows to our collection
-		allShows = append(allShows, shows...)
+	for i := range result.Episodes {
+		episode := &result.Episodes[i]
+		info, ok := metadata[episode.AllAnimeID+"/"+episode.AllAnimeEpisodeNumber]
+		if !ok {
+			continue
+		}
+		episode.Title = strings.TrimSpace(info.Title)
+		for _, thumbnail := range info.Thumbnails {
+			if strings.HasPrefix(thumbnail, "https://") || strings.HasPrefix(thumbnail, "http://") {
+				episode.Thumbnail = thumbnail
+				break
+			}
+		}
+		if uploaded, ok := info.UploadDates[episode.TranslationType].(string); ok {
+			if t, err := time.Parse(time.RFC3339, uploaded); err == nil {
+				episode.UploadDate = t
+			}
+		}
+	}
+	log.Debug("Added episode metadata", "shows", len(showIDs), "episodes_with_metadata", len(metadata))
+}
+
+// otherTranslationType returns the translation type to fall back to when there are no episodes in the given one, or
+// "" if there is none to fall back to
+func otherTranslationType(translationType string) string {
+	switch translationType {
+	case "sub":
+		return "dub"
+	case "dub":
+		return "sub"
+	}
+	return ""
+}
+
+// findEpisodes finds the episodes of an anime in a single translation type.  The AllAnime shows the anime was last
+// matched to are used if it has been matched before, otherwise AllAnime is searched for them.
+func (s *PlayerService) findEpisodes(ctx context.Context, anime *domain.Anime, translationType string) (*FindEpisodesResult, error) {
+	title := &anime.Title
+	if result := s.findMappedEpisodes(ctx, anime, translationType); result != nil {
+		return result, nil
+	}
+
+	log.Debug("Finding episodes", "title", title.Preferred, "id", anime.ID, "mal_id", anime.IDMal,
+		"country", anime.Country, "synonyms", anime.Synonyms, "translation_type", translationType)
+
+	// Narrow the search to the anime's country of origin where AllAnime supports it, which keeps out similarly named
+	// shows from elsewhere.  AllAnime doesn't always agree on the country, so fall back to searching everywhere.
+	shows := s.searchCandidates(ctx, title, translationType, countryOrigin(anime.Country))
+	if len(shows) == 0 && countryOrigin(anime.Country) != "ALL" {
+		log.Debug("No candidate shows found in country of origin, searching all countries", "country", anime.Country)
+		shows = s.searchCandidates(ctx, title, translationType, "ALL")
 	}
-	// Deduplicate by AllAnime ID
-	shows := deduplicateShows(allShows)
 
 	if len(shows) == 0 {
 		return nil, errors.New("no candidate shows found")
@@ -70,20 +213,18 @@ func (s *PlayerService) FindEpisodes(ctx context.Context, animeID int, title *do
 
 	log.Debug("Found candidate shows on allanime", "count", len(shows))
 
-	// Find all matching shows (either by AniList ID or by synonyms)
+	// Find all matching shows, by AniList ID, MAL ID or title
 	var matchedShows []AllAnimeShow
+	matchTypes := make(map[string]string)
 
 	for _, show := range shows {
-		aniListID := show.GetAniListID()
-
-		if aniListID == animeID {
-			// Direct match by AniList ID
-			log.Debug("Found direct AniList ID match", "allanime_id", show.ID, "name", show.Name, "anilist_id", aniListID)
-			matchedShows = append(matchedShows, show)
-		} else if aniListID == 0 && s.matchesByTitleOrSynonyms(title, synonyms, show) {
-			// Match by title or synonyms for shows without AniList ID
-			log.Debug("Found match by title or synonym", "allanime_id", show.ID, "name", show.Name)
+		if s.mappings != nil && s.mappings.IsBlacklisted(anime.ID, show.ID) {
+			log.Debug("Skipping blacklisted show", "allanime_id", show.ID, "name", show.Name, "id", anime.ID)
+			continue
+		}
+		if matchType := s.matchShow(anime, show); matchType != "" {
 			matchedShows = append(matchedShows, show)
+			matchTypes[show.ID] = matchType
 		}
 	}
 
@@ -110,13 +251,148 @@ func (s *PlayerService) FindEpisodes(ctx context.Context, animeID int, title *do
 	})
 
 	// Build the episode list from matched shows
-	result := s.buildEpisodeList(matchedShows, animeID, title)
+	result := s.buildEpisodeList(matchedShows, matchTypes, title, translationType)
 
 	log.Debug("Built episode list", "matched_show_count", len(matchedShows), "episode_count", len(result.Episodes), "title", title)
 
+	if len(result.Episodes) > 0 {
+		s.rememberShows(anime.ID, matchedShows, matchTypes)
+	}
 	return result, nil
 }
 
+// findMappedEpisodes builds the episode list from the AllAnime shows the anime was last matched to.  Returns nil if
+// it hasn't been matched, or if the shows can't be fetched or have no episodes in the translation type, so they are
+// searched for again.
+func (s *PlayerService) findMappedEpisodes(ctx context.Context, anime *domain.Anime, translationType string) *FindEpisodesResult {
+	if s.mappings == nil {
+		return nil
+	}
+	mapping, ok := s.mappings.Get(anime.ID)
+	if !ok {
+		return nil
+	}
+
+	var shows []AllAnimeShow
+	matchTypes := make(map[string]string)
+	for _, mapped := range mapping.Shows {
+		show, err := s.animeClient.GetShow(ctx, mapped.ID)
+		if err != nil {
+			log.Warn("Failed to fetch mapped AllAnime show, searching instead", "id", anime.ID, "allanime_id", mapped.ID,
+				"error", err)
+			return nil
+		}
+		shows = append(shows, show)
+		matchTypes[show.ID] = mapped.MatchType
+	}
+
+	result := s.buildEpisodeList(shows, matchTypes, &anime.Title, translationType)
+	if len(result.Episodes) == 0 {
+		log.Debug("Mapped AllAnime shows have no episodes in the translation type, searching instead", "id", anime.ID,
+			"translation_type", translationType)
+		return nil
+	}
+	log.Debug("Built episode list from mapped shows", "id", anime.ID, "show_count", len(shows),
+		"episode_count", len(result.Episodes))
+	return result
+}
+
+// rememberShows persists the AllAnime shows an anime was matched to, in episode order
+func (s *PlayerService) rememberShows(animeID int, shows []AllAnimeShow, matchTypes map[string]string) {
+	if s.mappings == nil {
+		return
+	}
+	mapped := make([]MappedShow, 0, len(shows))
+	for _, show := range shows {
+		mapped = append(mapped, MappedShow{ID: show.ID, MatchType: matchTypes[show.ID]})
+	}
+	if err := s.mappings.Set(animeID, mapped); err != nil {
+		log.Warn("Failed to save AllAnime show mapping", "id", animeID, "error", err)
+	}
+}
+
+// BlacklistShow records that an AllAnime show is not the anime, so it is left out when the anime is matched from then on
+func (s *PlayerService) BlacklistShow(animeID int, showID string) error {
+	return s.mappings.Blacklist(animeID, showID)
+}
+
+// ForgetShowMapping forgets which AllAnime shows the anime was matched to, so they are searched for and matched again
+// the next time it is played
+func (s *PlayerService) ForgetShowMapping(animeID int) error {
+	return s.mappings.Delete(animeID)
+}
+
+// searchCandidates searches AllAnime for each of the anime's titles.  Searches for every language, as sometimes we find
+// a match for one language, but not another.  The searches run at the same time, and one failing doesn't stop the
+// others.
+func (s *PlayerService) searchCandidates(ctx context.Context, title *domain.AnimeTitle, translationType, country string) []AllAnimeShow {
+	var titles []string
+	for _, t := range []string{title.Native, title.English, title.Romaji} {
+		if t != "" && !slices.Contains(titles, t) {
+			titles = append(titles, t)
+		}
+	}
+
+	// Each search fills in its own slot, so the shows are combined in the same order whichever finishes first
+	results := make([][]AllAnimeShow, len(titles))
+	group, groupCtx := errgroup.WithContext(ctx)
+	for i, title := range titles {
+		group.Go(func() error {
+			shows, err := s.animeClient.SearchShows(groupCtx, title, translationType, country)
+			if err != nil {
+				log.Warn("Error searching with title format", "title", title, "error", err)
+				return nil // The other titles may still find the show
+			}
+			results[i] = shows
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	var allShows []AllAnimeShow
+	for _, shows := range results {
+		allShows = append(allShows, shows...)
+	}
+	// Deduplicate by AllAnime ID
+	return deduplicateShows(allShows)
+}
+
+// countryOrigin converts an AniList country of origin to the value AllAnime filters searches by
+func countryOrigin(country string) string {
+	if allAnimeCountries[country] {
+		return country
+	}
+	return "ALL"
+}
+
+// matchShow decides whether an AllAnime show is the given anime, returning how it matched or "" if it isn't.  IDs are
+// trusted over titles: a show with a different AniList or MAL ID is never matched by title, as similarly titled
+// seasons, remakes and spin-offs are the usual cause of false matches.
+func (s *PlayerService) matchShow(anime *domain.Anime, show AllAnimeShow) string {
+	aniListID := show.GetAniListID()
+	malID := show.GetMalID()
+
+	switch {
+	case aniListID != 0 && aniListID == anime.ID:
+		log.Debug("Found direct AniList ID match", "allanime_id", show.ID, "name", show.Name, "anilist_id", aniListID)
+		return MatchTypeAniList
+	case aniListID != 0:
+		return ""
+	case malID != 0 && malID == anime.IDMal:
+		log.Debug("Found MAL ID match", "allanime_id", show.ID, "name", show.Name, "mal_id", malID)
+		return MatchTypeMAL
+	case malID != 0 && anime.IDMal != 0:
+		log.Debug("Skipping show with a different MAL ID", "allanime_id", show.ID, "name", show.Name,
+			"mal_id", malID, "anime_mal_id", anime.IDMal)
+		return ""
+	case s.matchesByTitleOrSynonyms(&anime.Title, anime.Synonyms, show):
+		// Match by title or synonyms for shows without either ID
+		log.Debug("Found match by title or synonym", "allanime_id", show.ID, "name", show.Name)
+		return MatchTypeSynonym
+	}
+	return ""
+}
+
 func deduplicateShows(shows []AllAnimeShow) []AllAnimeShow {
 	seen := make(map[string]bool)
 	var result []AllAnimeShow
@@ -131,12 +407,13 @@ func deduplicateShows(shows []AllAnimeShow) []AllAnimeShow {
 	return result
 }
 
-// matchesByTitleOrSynonyms checks if a show matches the anime by title or synonyms
+// matchesByTitleOrSynonyms checks if a show matches the anime by title or synonyms, exactly or once normalized
 func (s *PlayerService) matchesByTitleOrSynonyms(title *domain.AnimeTitle, synonyms []string, show AllAnimeShow) bool {
-	// Check if the anime title matches any of the show's names
-	if strings.ToLower(show.Name) == strings.ToLower(title.Romaji) ||
-		strings.ToLower(show.EnglishName) == strings.ToLower(title.English) ||
-		strings.ToLower(show.NativeName) == strings.ToLower(title.Native) {
+	// Check if the anime title matches any of the show's names.  Missing names never match, otherwise an anime
+	// without a native title would match every show without one too.
+	if namesEqual(show.Name, title.Romaji) ||
+		namesEqual(show.EnglishName, title.English) ||
+		namesEqual(show.NativeName, title.Native) {
 		log.Debug("AllAnimeName match found", "title", title, "allanime_name", show.Name,
 			"allanime_englishname", show.EnglishName, "allanime_nativename", show.NativeName)
 		return true
@@ -155,18 +432,38 @@ func (s *PlayerService) matchesByTitleOrSynonyms(title *domain.AnimeTitle, synon
 		}
 	}
 
+	// Fall back to comparing normalized titles, which tolerates the two sites writing season markers differently.  See
+	// normalizeTitle.
+	animeNames := append([]string{title.Romaji, title.English, title.Native}, synonyms...)
+	showNames := append([]string{show.Name, show.EnglishName, show.NativeName}, show.TrustedAltNames...)
+	for _, animeName := range animeNames {
+		for _, showName := range showNames {
+			if titlesMatch(animeName, showName) {
+				log.Debug("Normalized title match found", "title", animeName, "allanime_name", showName,
+					"normalized", normalizeTitle(showName))
+				return true
+			}
+		}
+	}
+
 	// No matches found
 	return false
 }
 
-// buildEpisodeList builds a chronologically ordered list of episodes from the matched shows
-func (s *PlayerService) buildEpisodeList(shows []AllAnimeShow, animeID int, titles *domain.AnimeTitle) *FindEpisodesResult {
+// namesEqual compares two names case insensitively, never matching an empty name
+func namesEqual(a, b string) bool {
+	return a != "" && strings.EqualFold(a, b)
+}
+
+// buildEpisodeList builds a chronologically ordered list of episodes in the translation type from the matched shows,
+// given how each show was matched by AllAnime ID
+func (s *PlayerService) buildEpisodeList(shows []AllAnimeShow, matchTypes map[string]string, titles *domain.AnimeTitle, translationType string) *FindEpisodesResult {
 	var episodes []AllAnimeEpisodeInfo
 	episodeOffset := 0
 
 	// Process each show in chronological order
 	for _, show := range shows {
-		availableEps := show.GetAvailableEpisodes(s.config.Player.TranslationType)
+		availableEps := show.GetAvailableEpisodes(translationType)
 
 		// Skip shows with no available episodes
 		if len(availableEps) == 0 {
@@ -187,12 +484,6 @@ func (s *PlayerService) buildEpisodeList(shows []AllAnimeShow, animeID int, titl
 		}
 		sort.Ints(episodeNums)
 
-		// Determine match type
-		matchType := MatchTypeSynonym
-		if show.GetAniListID() == animeID && animeID != 0 {
-			matchType = MatchTypeAniList
-		}
-
 		// Create episode info for each episode
 		for _, epNum := range episodeNums {
 			epStr := episodeMap[epNum]
@@ -211,7 +502,8 @@ func (s *PlayerService) buildEpisodeList(shows []AllAnimeShow, animeID int, titl
 				AniListID:             show.GetAniListID(),
 				Season:                show.Season.Quarter,
 				Year:                  show.Season.Year,
-				MatchType:             matchType,
+				MatchType:             matchTypes[show.ID],
+				TranslationType:       translationType,
 			})
 		}
 
@@ -239,16 +531,20 @@ type EpisodeSourceInfo struct {
 
 // GetEpisodeSources fetches all available sources for a specific episode and filters to supported types
 func (s *PlayerService) GetEpisodeSources(ctx context.Context, animeInfo AllAnimeEpisodeInfo) (*EpisodeSourceInfo, error) {
+	translationType := animeInfo.TranslationType
+	if translationType == "" {
+		translationType = s.config.Player.TranslationType
+	}
 	log.Debug("Getting episode sources",
 		"allAnimeID", animeInfo.AllAnimeID,
 		"episodeNumber", animeInfo.AllAnimeEpisodeNumber,
-		"translationType", s.config.Player.TranslationType)
+		"translationType", translationType)
 
 	sources, err := s.animeClient.GetEpisodeSources(
 		ctx,
 		animeInfo.AllAnimeID,
 		animeInfo.AllAnimeEpisodeNumber,
-		s.config.Player.TranslationType,
+		translationType,
 	)
 
 	if err != nil {
@@ -260,11 +556,13 @@ func (s *PlayerService) GetEpisodeSources(ctx context.Context, animeInfo AllAnim
 		"title", animeInfo.AllAnimeName,
 		"episode", animeInfo.AllAnimeEpisodeNumber)
 
-	// Filter sources to only include supported types (S-mp4 and Luf-mp4)
+	// Filter sources to the hosters a resolver can play from
 	var filteredSources []EpisodeSource
 	for _, source := range sources {
-		if strings.Contains(source.SourceName, "S-mp4") || strings.Contains(source.SourceName, "Luf-mp4") {
+		if s.resolverFor(source) != nil {
 			filteredSources = append(filteredSources, source)
+		} else {
+			log.Debug("No resolver for source", "source_name", source.SourceName, "type", source.Type)
 		}
 	}
 
@@ -279,205 +577,146 @@ func (s *PlayerService) GetEpisodeSources(ctx context.Context, animeInfo AllAnim
 		return nil, fmt.Errorf("no supported sources found for episode %s", animeInfo.AllAnimeEpisodeNumber)
 	}
 
-	// Sort sources by priority (highest first)
+	// Sort sources by priority (highest first), adjusted by how reliable each source has been in the past
 	sort.Slice(filteredSources, func(i, j int) bool {
 		return filteredSources[i].Priority > filteredSources[j].Priority
 	})
+	s.reliability.Sort(filteredSources)
 
 	return &EpisodeSourceInfo{
 		AnimeName:       animeInfo.AllAnimeName,
 		EpisodeNumber:   animeInfo.AllAnimeEpisodeNumber,
 		AllAnimeID:      animeInfo.AllAnimeID,
 		Sources:         filteredSources,
-		TranslationType: s.config.Player.TranslationType,
+		TranslationType: translationType,
 	}, nil
 }
 
-// GetStreamURL decodes the source URL and fetches the actual streaming URL
-func (s *PlayerService) GetStreamURL(ctx context.Context, source EpisodeSource) (string, error) {
+// GetStream decodes the source URL and fetches the stream it points to, along with what the provider says about it.
+// A source that can't be resolved counts against its reliability, but one that can only counts for it once its stream
+// has been validated, see FastestStream.
+func (s *PlayerService) GetStream(ctx context.Context, source EpisodeSource) (StreamInfo, error) {
 	log.Debug("Getting stream URL for source", "sourceName", source.SourceName)
 
-	// Decode the source URL
-	decodedPath, err := s.decodeSourceURL(source.SourceURL)
+	resolver := s.resolverFor(source)
+	if resolver == nil {
+		return StreamInfo{}, fmt.Errorf("no resolver for source %s", source.SourceName)
+	}
+
+	stream, err := resolver.resolve(ctx, s, source)
 	if err != nil {
-		return "", fmt.Errorf("failed to decode source URL: %w", err)
+		// Don't penalise the source if we gave up on it ourselves
+		if ctx.Err() == nil {
+			s.reliability.RecordFailure(source.SourceName)
+		}
+		return StreamInfo{}, err
 	}
+	s.rememberReferer(stream)
 
-	// Build the full API URL
-	apiURL := "https://allanime.day" + decodedPath
-	log.Debug("Decoded API URL", "url", apiURL)
+	log.Info("Retrieved stream URL", "sourceName", source.SourceName, "resolver", resolver.name, "url", stream.URL,
+		"resolution", stream.Resolution, "subtitles", stream.Subtitles, "referer", stream.Referer)
+	return stream, nil
+}
 
-	// Fetch the stream URL from the API
-	streamURL, err := s.fetchStreamURL(ctx, apiURL)
-	if err != nil {
-		return "", fmt.Errorf("failed to fetch stream URL: %w", err)
-	}
-
-	log.Info("Retrieved stream URL", "sourceName", source.SourceName, "url", streamURL)
-	return streamURL, nil
-}
-
-// decodeSourceURL decodes an encoded source URL from allanime
-func (s *PlayerService) decodeSourceURL(encoded string) (string, error) {
-	// Check if the string starts with "--"
-	if len(encoded) < 2 || encoded[:2] != "--" {
-		return "", fmt.Errorf("encoded string does not start with '--': %s", encoded)
-	}
-
-	// Remove the "--" prefix
-	hexStr := encoded[2:]
-
-	var decodedBuilder strings.Builder
-
-	// Process each 2-character hex pair
-	for i := 0; i < len(hexStr); i += 2 {
-		if i+2 > len(hexStr) {
-			return "", fmt.Errorf("invalid hex pair at position %d", i)
-		}
-
-		pair := hexStr[i : i+2]
-		char := hexToChar(pair)
-
-		if char == 0 {
-			return "", fmt.Errorf("invalid hex pair: %s", pair)
-		}
-
-		decodedBuilder.WriteString(string(char))
-	}
-
-	decoded := decodedBuilder.String()
-
-	// Replace "/clock" with "/clock.json" if needed
-	decoded = strings.Replace(decoded, "/clock", "/clock.json", -1)
-
-	return decoded, nil
-}
-
-// hexToChar maps hex pairs to their character representation
-func hexToChar(pair string) rune {
-	switch pair {
-	case "01":
-		return '9'
-	case "08":
-		return '0'
-	case "05":
-		return '='
-	case "0a":
-		return '2'
-	case "0b":
-		return '3'
-	case "0c":
-		return '4'
-	case "07":
-		return '?'
-	case "00":
-		return '8'
-	case "5c":
-		return 'd'
-	case "0f":
-		return '7'
-	case "5e":
-		return 'f'
-	case "17":
-		return '/'
-	case "54":
-		return 'l'
-	case "09":
-		return '1'
-	case "48":
-		return 'p'
-	case "4f":
-		return 'w'
-	case "0e":
-		return '6'
-	case "5b":
-		return 'c'
-	case "5d":
-		return 'e'
-	case "0d":
-		return '5'
-	case "53":
-		return 'k'
-	case "1e":
-		return '&'
-	case "5a":
-		return 'b'
-	case "59":
-		return 'a'
-	case "4a":
-		return 'r'
-	case "4c":
-		return 't'
-	case "4e":
-		return 'v'
-	case "57":
-		return 'o'
-	case "51":
-		return 'i'
-	default:
-		return 0
-	}
-}
-
-// fetchStreamURL fetches the actual streaming URL from the decoded allanime URL
-func (s *PlayerService) fetchStreamURL(ctx context.Context, url string) (string, error) {
+// fetchStream fetches the actual stream from the decoded allanime URL
+func (s *PlayerService) fetchStream(ctx context.Context, url string) (StreamInfo, error) {
 	// Create an HTTP request
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return StreamInfo{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set user agent to mimic a browser
 	req.Header.Set("User-Agent", allAnimeUserAgent)
 
 	// Execute the request
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := &http.Client{Timeout: 10 * time.Second, Transport: proxy.Transport()}
+	start := time.Now()
 	resp, err := client.Do(req)
+	callErr := err
+	if err == nil && resp.StatusCode != http.StatusOK {
+		callErr = fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	diagnostics.TrackAPICall(diagnostics.APIAllAnime, "clock", start, callErr)
 	if err != nil {
-		return "", fmt.Errorf("failed to execute request: %w", err)
+		return StreamInfo{}, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Read and parse the response
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+		return StreamInfo{}, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	// Parse the JSON response
-	var response struct {
-		Links []struct {
-			Link string `json:"link"`
-			HLS  bool   `json:"hls"`
-		} `json:"links"`
-	}
+	return parseStreamResponse(body)
+}
 
-	if err := json.Unmarshal(body, &response); err != nil {
-		return "", fmt.Errorf("failed to parse JSON response: %w", err)
+// rememberReferer keeps the Referer a stream needs, so it can be sent when the stream is played
+func (s *PlayerService) rememberReferer(stream StreamInfo) {
+	if stream.Referer == "" {
+		return
 	}
-
-	// Check if we have any links
-	if len(response.Links) == 0 {
-		return "", fmt.Errorf("no streaming links found in response")
+	s.refererLock.Lock()
+	defer s.refererLock.Unlock()
+	if s.referers == nil {
+		s.referers = make(map[string]string)
 	}
+	s.referers[stream.URL] = stream.Referer
+}
 
-	// Return the first link (typically the best quality)
-	return response.Links[0].Link, nil
+// refererFor returns the Referer a resolved stream needs, or "" if it needs none
+func (s *PlayerService) refererFor(streamURL string) string {
+	s.refererLock.Lock()
+	defer s.refererLock.Unlock()
+	return s.referers[streamURL]
 }
 
-// LaunchPlayer starts playback with the given stream URL and returns a channel for playback events
-func (s *PlayerService) LaunchPlayer(ctx context.Context, streamURL string, episode AllAnimeEpisodeInfo) (<-chan PlaybackEvent, error) {
+// LaunchPlayer starts playback with the given stream URL and returns a channel for playback events.  tracked is whether
+// finishing the episode updates the list, which is remembered in case the player is reattached to after a restart.
+func (s *PlayerService) LaunchPlayer(ctx context.Context, streamURL string, episode AllAnimeEpisodeInfo,
+	tracked bool) (<-chan PlaybackEvent, error) {
 	log.Info("Launching media player",
 		"player_type", s.config.Player.Type,
 		"player_path", s.config.Player.Path)
 
-	// Create the appropriate video player based on config
-	videoPlayer, err := CreateVideoPlayer(s.config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create video player: %w", err)
+	// Create the appropriate video player based on config, or the torrent command for a torrent found on Nyaa
+	var videoPlayer VideoPlayer
+	if isMagnet(streamURL) {
+		videoPlayer = s.newTorrentPlayer()
+	} else {
+		var err error
+		videoPlayer, err = CreateVideoPlayer(s.config, streamURL, s.refererFor(streamURL))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create video player: %w", err)
+		}
 	}
 
 	title := fmt.Sprintf("Ep %d - %s", episode.OverallEpisodeNumber, episode.PreferredTitle)
+	if episode.TranslationFallback {
+		title += fmt.Sprintf(" (%s)", episode.TranslationType)
+	}
+
+	// Pick up where the episode was left off, if the player supports it
+	if setter, ok := videoPlayer.(StartPositionSetter); ok && episode.AniListID != 0 {
+		if position := s.resume.Position(episode.AniListID, episode.OverallEpisodeNumber); position > 0 {
+			log.Info("Resuming episode", "anime_id", episode.AniListID, "episode", episode.OverallEpisodeNumber,
+				"position", position)
+			setter.SetStartPosition(position)
+		}
+	}
+
+	if referer := s.refererFor(streamURL); referer != "" {
+		if setter, ok := videoPlayer.(RefererSetter); ok {
+			setter.SetReferer(referer)
+		} else {
+			log.Warn("The player can't send the Referer this stream needs, so it may not play", "referer", referer)
+		}
+	}
+
+	if setter, ok := videoPlayer.(PresetArgsSetter); ok {
+		setter.SetPresetArgs(append(s.formatArgs(episode.Format), s.presetArgs(episode.AniListID)...))
+	}
 
 	// Start playback and get the events channel
 	events, err := videoPlayer.Play(ctx, streamURL, title)
@@ -485,9 +724,176 @@ func (s *PlayerService) LaunchPlayer(ctx context.Context, streamURL string, epis
 		return nil, fmt.Errorf("failed to start player: %w", err)
 	}
 
+	if episode.AniListID != 0 {
+		s.resume.RecordLaunch(episode.AniListID, episode.OverallEpisodeNumber, streamURL)
+	}
+
+	s.trackPlayer(ctx, videoPlayer)
+	s.rememberSession(ctx, videoPlayer, episode, tracked)
 	return events, nil
 }
 
+// trackPlayer counts the player as active until ctx is cancelled, which callers do once they have finished handling
+// the end of playback
+func (s *PlayerService) trackPlayer(ctx context.Context, videoPlayer VideoPlayer) {
+	s.activeLock.Lock()
+	defer s.activeLock.Unlock()
+	if s.active == nil {
+		s.active = make(map[VideoPlayer]struct{})
+	}
+	s.active[videoPlayer] = struct{}{}
+	if s.inhibitSleep {
+		inhibit.Acquire("Playing an episode")
+	}
+
+	go func() {
+		<-ctx.Done()
+		s.activeLock.Lock()
+		defer s.activeLock.Unlock()
+		delete(s.active, videoPlayer)
+		if len(s.active) == 0 && s.inhibitSleep {
+			inhibit.Release()
+		}
+	}()
+}
+
+// IsPlaying reports whether a player launched by Hisame is still playing, or its result is still being handled
+func (s *PlayerService) IsPlaying() bool {
+	s.activeLock.Lock()
+	defer s.activeLock.Unlock()
+	return len(s.active) > 0
+}
+
+// StopPlayback stops every player launched by Hisame that is still playing
+func (s *PlayerService) StopPlayback() {
+	s.activeLock.Lock()
+	defer s.activeLock.Unlock()
+	for videoPlayer := range s.active {
+		if err := videoPlayer.Stop(); err != nil {
+			log.Warn("Failed to stop player", "error", err)
+		}
+	}
+}
+
+// CycleSubtitles switches to the next subtitle track in the players launched by Hisame that are still playing
+func (s *PlayerService) CycleSubtitles() error {
+	s.activeLock.Lock()
+	defer s.activeLock.Unlock()
+
+	cycled := false
+	for videoPlayer := range s.active {
+		cycler, ok := videoPlayer.(SubtitleCycler)
+		if !ok {
+			continue
+		}
+		if err := cycler.CycleSubtitles(); err != nil {
+			return fmt.Errorf("failed to switch subtitles: %w", err)
+		}
+		cycled = true
+	}
+	if !cycled {
+		return errors.New("no episode is playing in a player Hisame can switch subtitles in")
+	}
+	return nil
+}
+
+// TogglePause pauses, or resumes, the players launched by Hisame that are still playing
+func (s *PlayerService) TogglePause() error {
+	s.activeLock.Lock()
+	defer s.activeLock.Unlock()
+
+	toggled := false
+	for videoPlayer := range s.active {
+		pauser, ok := videoPlayer.(Pauser)
+		if !ok {
+			continue
+		}
+		if err := pauser.TogglePause(); err != nil {
+			return fmt.Errorf("failed to pause: %w", err)
+		}
+		toggled = true
+	}
+	if !toggled {
+		return errors.New("no episode is playing in a player Hisame can pause")
+	}
+	return nil
+}
+
+// AnimePreset returns the preset chosen for the anime, or an empty string if it uses the configured preset
+func (s *PlayerService) AnimePreset(animeID int) string {
+	return s.presets.Get(animeID)
+}
+
+// SetAnimePreset chooses the preset used when playing the anime.  An empty name goes back to the configured preset.
+func (s *PlayerService) SetAnimePreset(animeID int, name string) error {
+	return s.presets.Set(animeID, name)
+}
+
+// AnimeTranslationType returns the translation type chosen for the anime, or an empty string if it uses the configured
+// type
+func (s *PlayerService) AnimeTranslationType(animeID int) string {
+	return s.translation.Get(animeID)
+}
+
+// SetAnimeTranslationType chooses whether the anime is watched subbed or dubbed.  An empty type goes back to the
+// configured type.
+func (s *PlayerService) SetAnimeTranslationType(animeID int, translationType string) error {
+	return s.translation.Set(animeID, translationType)
+}
+
+// translationType returns the translation type the anime's episodes are found in: its own type if one was chosen,
+// otherwise the configured type
+func (s *PlayerService) translationType(animeID int) string {
+	if s.translation != nil {
+		if translationType := s.translation.Get(animeID); translationType != "" {
+			return translationType
+		}
+	}
+	return s.config.Player.TranslationType
+}
+
+// presetArgs returns the arguments for the preset that applies to the anime: its own preset if one was chosen,
+// otherwise the configured preset
+func (s *PlayerService) presetArgs(animeID int) []string {
+	name := s.config.Player.Preset
+	if animePreset := s.presets.Get(animeID); animePreset != "" {
+		name = animePreset
+	}
+	if name == "" {
+		return nil
+	}
+
+	preset, ok := FindPreset(s.config, name)
+	if !ok {
+		log.Warn("Unknown player preset, playing without one", "preset", name)
+		return nil
+	}
+	log.Debug("Applying player preset", "preset", name, "args", preset.Args)
+	return preset.Args
+}
+
+// formatArgs returns the arguments configured for the AniList format of the anime, e.g. "MOVIE", matched ignoring case
+func (s *PlayerService) formatArgs(format string) []string {
+	if format == "" {
+		return nil
+	}
+	for name, args := range s.config.Player.FormatArgs {
+		if strings.EqualFold(name, format) {
+			log.Debug("Applying format args", "format", format, "args", args)
+			return ParseArgs(args)
+		}
+	}
+	return nil
+}
+
+// RecordPlaybackStop stores where playback of an episode stopped, so it can be resumed from there next time
+func (s *PlayerService) RecordPlaybackStop(episode AllAnimeEpisodeInfo, position, progress float64) {
+	if episode.AniListID == 0 {
+		return
+	}
+	s.resume.RecordStop(episode.AniListID, episode.OverallEpisodeNumber, position, progress)
+}
+
 // parseArgs splits a string of command-line arguments, respecting quotes
 func parseArgs(argsString string) []string {
 	var args []string
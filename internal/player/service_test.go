@@ -0,0 +1,290 @@
+package player
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/machinebox/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchShow(t *testing.T) {
+	s := &PlayerService{}
+	anime := &domain.Anime{
+		ID:    16498,
+		IDMal: 16498,
+		Title: domain.AnimeTitle{Romaji: "Shingeki no Kyojin", English: "Attack on Titan"},
+	}
+
+	tests := []struct {
+		name string
+		show AllAnimeShow
+		want string
+	}{
+		{"anilist id", AllAnimeShow{AniListID: "16498", Name: "Something Else"}, MatchTypeAniList},
+		{"other anilist id", AllAnimeShow{AniListID: "20958", Name: "Shingeki no Kyojin"}, ""},
+		{"mal id", AllAnimeShow{MalID: "16498", Name: "Something Else"}, MatchTypeMAL},
+		{"other mal id", AllAnimeShow{MalID: "25777", Name: "Shingeki no Kyojin"}, ""},
+		{"title without ids", AllAnimeShow{Name: "Shingeki no Kyojin"}, MatchTypeSynonym},
+		{"no match", AllAnimeShow{Name: "Something Else"}, ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, s.matchShow(anime, tt.show), tt.name)
+	}
+
+	// Without a MAL ID for the anime, a show's MAL ID can't rule it out
+	anime.IDMal = 0
+	assert.Equal(t, MatchTypeSynonym, s.matchShow(anime, AllAnimeShow{MalID: "25777", Name: "Shingeki no Kyojin"}))
+}
+
+func TestCountryOrigin(t *testing.T) {
+	assert.Equal(t, "JP", countryOrigin("JP"))
+	assert.Equal(t, "CN", countryOrigin("CN"))
+	assert.Equal(t, "ALL", countryOrigin("TW"))
+	assert.Equal(t, "ALL", countryOrigin(""))
+}
+
+// fakeSubtitlePlayer is a playing player that counts subtitle switches
+type fakeSubtitlePlayer struct {
+	VideoPlayer
+	cycled int
+}
+
+func (p *fakeSubtitlePlayer) CycleSubtitles() error {
+	p.cycled++
+	return nil
+}
+
+func TestCycleSubtitles(t *testing.T) {
+	s := &PlayerService{}
+	assert.Error(t, s.CycleSubtitles(), "nothing is playing")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.trackPlayer(ctx, &ProcessPlayer{})
+	assert.Error(t, s.CycleSubtitles(), "custom players can't switch subtitles")
+
+	fake := &fakeSubtitlePlayer{}
+	s.trackPlayer(ctx, fake)
+	require.NoError(t, s.CycleSubtitles())
+	assert.Equal(t, 1, fake.cycled)
+}
+
+func TestFindEpisodesFallsBackToOtherTranslationType(t *testing.T) {
+	// AllAnime only has the show dubbed
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		edges := "[]"
+		if body.Variables["translationType"] == "dub" {
+			edges = `[{"_id":"show1","name":"Show","aniListId":"1","availableEpisodesDetail":{"dub":["1","2"]}}]`
+		}
+		_, _ = w.Write([]byte(`{"data":{"shows":{"edges":` + edges + `}}}`))
+	}))
+	defer server.Close()
+
+	s := &PlayerService{
+		config:      &config.Config{Player: config.PlayerConfig{TranslationType: "sub"}},
+		animeClient: &AllAnimeClient{client: graphql.NewClient(server.URL)},
+		translation: NewAnimeTranslations(""),
+	}
+	anime := &domain.Anime{ID: 1, Title: domain.AnimeTitle{Romaji: "Show", Preferred: "Show"}}
+
+	result, err := s.FindEpisodes(context.Background(), anime)
+	require.NoError(t, err)
+	require.Len(t, result.Episodes, 2)
+	assert.Equal(t, "dub", result.Episodes[0].TranslationType)
+	assert.True(t, result.Episodes[0].TranslationFallback)
+
+	// Choosing dub for the anime finds the same episodes without it being a fallback
+	require.NoError(t, s.SetAnimeTranslationType(1, "dub"))
+	result, err = s.FindEpisodes(context.Background(), anime)
+	require.NoError(t, err)
+	require.Len(t, result.Episodes, 2)
+	assert.False(t, result.Episodes[0].TranslationFallback)
+}
+
+func TestFindEpisodesUsesShowMapping(t *testing.T) {
+	var searches, fetches int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query string `json:"query"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		show := `{"_id":"show1","name":"Show","aniListId":"1","availableEpisodesDetail":{"sub":["1","2","3"]}}`
+		if strings.Contains(body.Query, "shows(") {
+			searches++
+			_, _ = w.Write([]byte(`{"data":{"shows":{"edges":[` + show + `]}}}`))
+			return
+		}
+		if strings.Contains(body.Query, "show(") {
+			fetches++
+		}
+		_, _ = w.Write([]byte(`{"data":{"show":` + show + `}}`))
+	}))
+	defer server.Close()
+
+	s := &PlayerService{
+		config:      &config.Config{Player: config.PlayerConfig{TranslationType: "sub"}},
+		animeClient: &AllAnimeClient{client: graphql.NewClient(server.URL)},
+		mappings:    NewShowMappings(filepath.Join(t.TempDir(), showMappingsFileName)),
+	}
+	anime := &domain.Anime{ID: 1, Title: domain.AnimeTitle{Romaji: "Show", Preferred: "Show"}}
+
+	result, err := s.FindEpisodes(context.Background(), anime)
+	require.NoError(t, err)
+	assert.Len(t, result.Episodes, 3)
+	assert.Equal(t, 1, searches)
+
+	mapping, ok := s.mappings.Get(1)
+	require.True(t, ok, "the match should be remembered")
+	assert.Equal(t, []MappedShow{{ID: "show1", MatchType: MatchTypeAniList}}, mapping.Shows)
+
+	result, err = s.FindEpisodes(context.Background(), anime)
+	require.NoError(t, err)
+	assert.Len(t, result.Episodes, 3)
+	assert.Equal(t, 1, searches, "the mapped show should be fetched without searching")
+	assert.Equal(t, 1, fetches)
+	assert.Equal(t, MatchTypeAniList, result.Episodes[0].MatchType)
+
+	require.NoError(t, s.ForgetShowMapping(1))
+	_, err = s.FindEpisodes(context.Background(), anime)
+	require.NoError(t, err)
+	assert.Equal(t, 2, searches, "forgetting the mapping should search again")
+}
+
+func TestFindEpisodesSkipsBlacklistedShows(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"shows":{"edges":[
+			{"_id":"right","name":"Show","availableEpisodesDetail":{"sub":["1","2"]}},
+			{"_id":"wrong","name":"Show","availableEpisodesDetail":{"sub":["1"]}}
+		]}}}`))
+	}))
+	defer server.Close()
+
+	s := &PlayerService{
+		config:      &config.Config{Player: config.PlayerConfig{TranslationType: "sub"}},
+		animeClient: &AllAnimeClient{client: graphql.NewClient(server.URL)},
+		mappings:    NewShowMappings(""),
+	}
+	anime := &domain.Anime{ID: 1, Title: domain.AnimeTitle{Romaji: "Show", Preferred: "Show"}}
+
+	result, err := s.FindEpisodes(context.Background(), anime)
+	require.NoError(t, err)
+	assert.Len(t, result.Episodes, 3, "both shows match by title")
+
+	require.NoError(t, s.BlacklistShow(1, "wrong"))
+	_, ok := s.mappings.Get(1)
+	assert.False(t, ok, "blacklisting should forget the mapping so the anime is matched again")
+
+	result, err = s.FindEpisodes(context.Background(), anime)
+	require.NoError(t, err)
+	require.Len(t, result.RawShows, 1)
+	assert.Equal(t, "right", result.RawShows[0].ID)
+
+	require.NoError(t, s.ForgetShowMapping(1))
+	assert.True(t, s.mappings.IsBlacklisted(1, "wrong"), "forgetting the mapping should keep the blacklist")
+}
+
+func TestFindEpisodesAddsMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if strings.Contains(body.Query, "episodeInfos(") {
+			assert.Equal(t, float64(1), body.Variables["episodeNumStart"])
+			assert.Equal(t, float64(2), body.Variables["episodeNumEnd"])
+			_, _ = w.Write([]byte(`{"data":{"episodeInfos":[
+				{"episodeIdNum":1,"notes":" The Journey's End ","thumbnails":["/relative.jpg","https://img.example/1.jpg"],
+					"uploadDates":{"sub":"2023-09-29T15:00:00.000Z"}},
+				{"episodeIdNum":2,"notes":null,"thumbnails":[]}
+			]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"shows":{"edges":[
+			{"_id":"show1","name":"Show","aniListId":"1","availableEpisodesDetail":{"sub":["1","2"]}}
+		]}}}`))
+	}))
+	defer server.Close()
+
+	s := &PlayerService{
+		config:      &config.Config{Player: config.PlayerConfig{TranslationType: "sub"}},
+		animeClient: &AllAnimeClient{client: graphql.NewClient(server.URL)},
+	}
+	anime := &domain.Anime{ID: 1, Title: domain.AnimeTitle{Romaji: "Show", Preferred: "Show"}}
+
+	result, err := s.FindEpisodes(context.Background(), anime)
+	require.NoError(t, err)
+	require.Len(t, result.Episodes, 2)
+	assert.Equal(t, "The Journey's End", result.Episodes[0].Title)
+	assert.Equal(t, "https://img.example/1.jpg", result.Episodes[0].Thumbnail)
+	assert.Equal(t, time.Date(2023, 9, 29, 15, 0, 0, 0, time.UTC), result.Episodes[0].UploadDate)
+	assert.Empty(t, result.Episodes[1].Title)
+	assert.True(t, result.Episodes[1].UploadDate.IsZero())
+}
+
+func TestFindEpisodesSearchesTitlesConcurrently(t *testing.T) {
+	// Every search waits for the others to arrive, so this only finishes if they run at the same time
+	var arrived sync.WaitGroup
+	arrived.Add(3)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables struct {
+				Search struct {
+					Query string `json:"query"`
+				} `json:"search"`
+			} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		arrived.Done()
+		allArrived := make(chan struct{})
+		go func() {
+			arrived.Wait()
+			close(allArrived)
+		}()
+		select {
+		case <-allArrived:
+		case <-time.After(2 * time.Second):
+			t.Error("title searches did not run concurrently")
+		}
+
+		switch body.Variables.Search.Query {
+		case "ショー":
+			http.Error(w, "broken", http.StatusBadRequest)
+		case "Show":
+			_, _ = w.Write([]byte(`{"data":{"shows":{"edges":[
+				{"_id":"show1","name":"Show","aniListId":"1","availableEpisodesDetail":{"sub":["1","2"]}}
+			]}}}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":{"shows":{"edges":[]}}}`))
+		}
+	}))
+	defer server.Close()
+
+	s := &PlayerService{
+		config:      &config.Config{Player: config.PlayerConfig{TranslationType: "sub"}},
+		animeClient: &AllAnimeClient{client: graphql.NewClient(server.URL)},
+	}
+	anime := &domain.Anime{ID: 1, Title: domain.AnimeTitle{Native: "ショー", English: "The Show", Romaji: "Show", Preferred: "Show"}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := s.FindEpisodes(ctx, anime)
+	require.NoError(t, err)
+	require.Len(t, result.Episodes, 2)
+}
@@ -0,0 +1,199 @@
+package player
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/store"
+)
+
+// playbackSessionFileName is the name of the file the playing episode is journaled to within the data dir
+const playbackSessionFileName = "playback_session.json"
+
+// ErrNoPlaybackSession is returned when there is no player left running from before Hisame was restarted
+var ErrNoPlaybackSession = errors.New("no player to reattach to")
+
+// PlaybackSession is an episode playing in a player Hisame can reattach to if it is restarted.  If the player has
+// exited by then, how far it last got is used to apply the progress update Hisame missed.
+type PlaybackSession struct {
+	Episode    AllAnimeEpisodeInfo `json:"episode"`
+	SocketPath string              `json:"socket_path"`
+	PID        int                 `json:"pid"`
+	StartedAt  time.Time           `json:"started_at"`
+	Position   float64             `json:"position,omitempty"` // Seconds into the episode playback last got to
+	Progress   float64             `json:"progress,omitempty"` // Percentage of the episode playback last got to
+
+	// Tracked is whether finishing the episode updates the list.  Episodes played without tracking progress are
+	// reattached to, but their missed playback isn't replayed.
+	Tracked bool `json:"tracked,omitempty"`
+}
+
+// PlaybackSessions journals the episode playing to a JSON file, so it outlives Hisame
+type PlaybackSessions struct {
+	mu      sync.Mutex
+	file    store.File
+	session *PlaybackSession
+}
+
+// NewPlaybackSessions creates a playback session store backed by the given file.  An empty path keeps the session in
+// memory only.
+func NewPlaybackSessions(path string) *PlaybackSessions {
+	p := &PlaybackSessions{file: store.NewFile(path)}
+	if err := p.file.Load(&p.session); err != nil {
+		log.Warn("Failed to load the playback session, nothing will be reattached to", "path", path, "error", err)
+		p.session = nil
+	}
+	return p
+}
+
+// newDefaultPlaybackSessions creates a playback session store in the Hisame data dir
+func newDefaultPlaybackSessions() *PlaybackSessions {
+	return NewPlaybackSessions(store.DataPath(playbackSessionFileName, "players to reattach to after a restart"))
+}
+
+// Get returns the episode playing, if any
+func (p *PlaybackSessions) Get() (PlaybackSession, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.session == nil {
+		return PlaybackSession{}, false
+	}
+	return *p.session, true
+}
+
+// Set records the episode playing, replacing any before it
+func (p *PlaybackSessions) Set(session PlaybackSession) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.session = &session
+	return p.save()
+}
+
+// RecordProgress updates how far playback of the episode has got, if it is the episode playing
+func (p *PlaybackSessions) RecordProgress(animeID, episode int, position, progress float64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.session == nil || p.session.Episode.AniListID != animeID || p.session.Episode.OverallEpisodeNumber != episode {
+		return nil
+	}
+	p.session.Position = position
+	p.session.Progress = progress
+	return p.save()
+}
+
+// Clear forgets the session of the player with the given process ID once it has finished.  A session started by
+// another player since is kept.
+func (p *PlaybackSessions) Clear(pid int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.session == nil || p.session.PID != pid {
+		return nil
+	}
+	p.session = nil
+	return p.save()
+}
+
+// save writes the session to disk, removing the file when there is none.  Callers must hold the lock.
+func (p *PlaybackSessions) save() error {
+	if p.session == nil {
+		return p.file.Remove()
+	}
+	return p.file.Save(p.session)
+}
+
+// rememberSession records the player as playing the episode, if it can be reattached to, until ctx is cancelled
+func (s *PlayerService) rememberSession(ctx context.Context, videoPlayer VideoPlayer, episode AllAnimeEpisodeInfo,
+	tracked bool) {
+	reattacher, ok := videoPlayer.(Reattacher)
+	if !ok || episode.AniListID == 0 {
+		return
+	}
+
+	socketPath, pid := reattacher.Session()
+	err := s.sessions.Set(PlaybackSession{
+		Episode:    episode,
+		SocketPath: socketPath,
+		PID:        pid,
+		StartedAt:  time.Now(),
+		Tracked:    tracked,
+	})
+	if err != nil {
+		log.Warn("Failed to persist the playback session", "error", err)
+		return
+	}
+	s.clearSessionWhenDone(ctx, pid)
+}
+
+// clearSessionWhenDone forgets the player's session once ctx is cancelled, which callers do once they have finished
+// handling the end of playback
+func (s *PlayerService) clearSessionWhenDone(ctx context.Context, pid int) {
+	go func() {
+		<-ctx.Done()
+		if err := s.sessions.Clear(pid); err != nil {
+			log.Warn("Failed to clear the playback session", "error", err)
+		}
+	}()
+}
+
+// RecordPlaybackProgress journals how far playback of the episode has got, so the progress update can still be
+// applied if Hisame is closed before the player
+func (s *PlayerService) RecordPlaybackProgress(episode AllAnimeEpisodeInfo, position, progress float64) {
+	if episode.AniListID == 0 {
+		return
+	}
+	if err := s.sessions.RecordProgress(episode.AniListID, episode.OverallEpisodeNumber, position, progress); err != nil {
+		log.Warn("Failed to journal playback progress", "error", err)
+	}
+}
+
+// ReattachPlayback reattaches to a player left playing when Hisame was last closed, returning its session and its
+// playback events as LaunchPlayer does.  If the player has exited since, the events are its journaled start and end,
+// marked as missed, provided the episode's progress was being tracked.  Returns ErrNoPlaybackSession if no player was
+// left playing, or an untracked one has exited.
+func (s *PlayerService) ReattachPlayback(ctx context.Context) (PlaybackSession, <-chan PlaybackEvent, error) {
+	session, ok := s.sessions.Get()
+	if !ok {
+		return PlaybackSession{}, nil, ErrNoPlaybackSession
+	}
+
+	mpv := AttachMPVPlayer(s.config, session.SocketPath, session.PID)
+	events, err := mpv.Attach(ctx)
+	if err != nil {
+		log.Info("The player from the last session has exited, applying its journaled playback", "pid", session.PID,
+			"reason", err)
+		if err := s.sessions.Clear(session.PID); err != nil {
+			log.Warn("Failed to clear the playback session", "error", err)
+		}
+		if !session.Tracked {
+			return PlaybackSession{}, nil, ErrNoPlaybackSession
+		}
+		return session, s.missedPlaybackEvents(session), nil
+	}
+
+	s.trackPlayer(ctx, mpv)
+	s.clearSessionWhenDone(ctx, session.PID)
+	return session, events, nil
+}
+
+// missedPlaybackEvents replays the journaled playback of a player that exited while Hisame was closed.  MPV may have
+// saved a later position to its watch_later dir when it quit, which is used in place of the journal's if so.
+func (s *PlayerService) missedPlaybackEvents(session PlaybackSession) <-chan PlaybackEvent {
+	position, progress := session.Position, session.Progress
+	resumed := s.resume.Position(session.Episode.AniListID, session.Episode.OverallEpisodeNumber)
+	if resumed > position && position > 0 {
+		// The journal gives the episode's length, to work out how far the later position is through it
+		progress = min(progress*resumed/position, 100)
+		position = resumed
+	}
+	log.Info("Missed the end of playback", "anime_id", session.Episode.AniListID,
+		"episode", session.Episode.OverallEpisodeNumber, "position", position, "progress", progress)
+
+	events := make(chan PlaybackEvent, 2)
+	events <- PlaybackEvent{Type: PlaybackStarted, Missed: true}
+	events <- PlaybackEvent{Type: PlaybackEnded, Missed: true, Position: position, Progress: progress}
+	close(events)
+	return events
+}
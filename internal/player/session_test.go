@@ -0,0 +1,86 @@
+package player
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaybackSessionsPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), playbackSessionFileName)
+	sessions := NewPlaybackSessions(path)
+	episode := AllAnimeEpisodeInfo{AniListID: 1, OverallEpisodeNumber: 3, PreferredTitle: "Show"}
+	require.NoError(t, sessions.Set(PlaybackSession{Episode: episode, SocketPath: "/tmp/mpv-socket", PID: 42,
+		Tracked: true}))
+
+	reloaded := NewPlaybackSessions(path)
+	session, ok := reloaded.Get()
+	require.True(t, ok)
+	assert.Equal(t, episode, session.Episode)
+	assert.Equal(t, 42, session.PID)
+	assert.True(t, session.Tracked)
+
+	// Another player finishing leaves the session alone
+	require.NoError(t, reloaded.Clear(7))
+	_, ok = NewPlaybackSessions(path).Get()
+	assert.True(t, ok)
+
+	require.NoError(t, reloaded.Clear(42))
+	_, ok = NewPlaybackSessions(path).Get()
+	assert.False(t, ok)
+	assert.NoFileExists(t, path)
+}
+
+func TestReattachPlaybackReplaysExitedPlayer(t *testing.T) {
+	s := &PlayerService{
+		config:   &config.Config{},
+		sessions: NewPlaybackSessions(""),
+		resume:   NewResumeStore("", ""),
+	}
+
+	_, _, err := s.ReattachPlayback(context.Background())
+	assert.ErrorIs(t, err, ErrNoPlaybackSession)
+
+	// The player has exited since, so how far it got is replayed from the journal, once
+	episode := AllAnimeEpisodeInfo{AniListID: 1, OverallEpisodeNumber: 3}
+	require.NoError(t, s.sessions.Set(PlaybackSession{Episode: episode, PID: -1, Tracked: true}))
+	s.RecordPlaybackProgress(episode, 1200, 80)
+	s.RecordPlaybackProgress(AllAnimeEpisodeInfo{AniListID: 2, OverallEpisodeNumber: 1}, 60, 4)
+
+	reattached, events, err := s.ReattachPlayback(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, episode, reattached.Episode)
+	assert.True(t, reattached.Tracked)
+	var received []PlaybackEvent
+	for event := range events {
+		received = append(received, event)
+	}
+	require.Len(t, received, 2)
+	assert.Equal(t, PlaybackEvent{Type: PlaybackStarted, Missed: true}, received[0])
+	assert.Equal(t, PlaybackEvent{Type: PlaybackEnded, Missed: true, Position: 1200, Progress: 80}, received[1])
+
+	_, _, err = s.ReattachPlayback(context.Background())
+	assert.ErrorIs(t, err, ErrNoPlaybackSession)
+}
+
+func TestReattachPlaybackSkipsUntrackedExitedPlayer(t *testing.T) {
+	s := &PlayerService{
+		config:   &config.Config{},
+		sessions: NewPlaybackSessions(""),
+		resume:   NewResumeStore("", ""),
+	}
+
+	episode := AllAnimeEpisodeInfo{AniListID: 1, OverallEpisodeNumber: 3}
+	require.NoError(t, s.sessions.Set(PlaybackSession{Episode: episode, PID: -1}))
+	s.RecordPlaybackProgress(episode, 1200, 80)
+
+	_, events, err := s.ReattachPlayback(context.Background())
+	assert.ErrorIs(t, err, ErrNoPlaybackSession, "an episode played without tracking shouldn't update the list")
+	assert.Nil(t, events)
+	_, ok := s.sessions.Get()
+	assert.False(t, ok, "the session should still be cleared")
+}
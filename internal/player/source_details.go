@@ -0,0 +1,144 @@
+package player
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// resolutionPattern matches the resolutions AllAnime gives its links, e.g. "1080p".  Other values it uses, like "Mp4"
+// or "Hls", say nothing about the resolution.
+var resolutionPattern = regexp.MustCompile(`^\d{3,4}p$`)
+
+// StreamInfo is a stream a source points to, along with what the provider says about it
+type StreamInfo struct {
+	URL        string
+	Resolution string   // e.g. "1080p", empty if the provider doesn't say
+	HLS        bool     // Whether the stream is an HLS playlist, which usually adapts its resolution
+	Subtitles  []string // Languages of the subtitle tracks served alongside the video, rather than burned into it
+	Referer    string   // Referer the host only serves the stream with, empty if it doesn't need one
+	YTDL       bool     // Whether the URL is an embedded player's page, which MPV extracts the video from with yt-dlp
+}
+
+// parseStreamResponse reads the stream from a clock.json response, using the first link as it is typically the best
+// quality
+func parseStreamResponse(body []byte) (StreamInfo, error) {
+	var response struct {
+		Links []struct {
+			Link          string `json:"link"`
+			HLS           bool   `json:"hls"`
+			ResolutionStr string `json:"resolutionStr"`
+			Subtitles     []struct {
+				Lang  string `json:"lang"`
+				Label string `json:"label"`
+			} `json:"subtitles"`
+			Headers struct {
+				Referer string `json:"Referer"`
+			} `json:"headers"`
+		} `json:"links"`
+	}
+
+	if err := json.Unmarshal(body, &response); err != nil {
+		return StreamInfo{}, fmt.Errorf("failed to parse JSON response: %w", err)
+	}
+
+	// Check if we have any links
+	if len(response.Links) == 0 {
+		return StreamInfo{}, fmt.Errorf("no streaming links found in response")
+	}
+
+	link := response.Links[0]
+	stream := StreamInfo{URL: link.Link, HLS: link.HLS, Referer: link.Headers.Referer}
+	if resolutionPattern.MatchString(strings.ToLower(link.ResolutionStr)) {
+		stream.Resolution = strings.ToLower(link.ResolutionStr)
+	}
+	for _, subtitle := range link.Subtitles {
+		language := subtitle.Label
+		if language == "" {
+			language = subtitle.Lang
+		}
+		if language != "" {
+			stream.Subtitles = append(stream.Subtitles, language)
+		}
+	}
+	return stream, nil
+}
+
+// SubtitleKind is how a source's subtitles are delivered
+type SubtitleKind string
+
+const (
+	SubtitlesNone SubtitleKind = ""     // Dubs and raws, or sources that couldn't be checked
+	SubtitlesHard SubtitleKind = "hard" // Burned into the video, so they can't be turned off or restyled
+	SubtitlesSoft SubtitleKind = "soft" // Separate tracks the player can switch between
+)
+
+// SourceDetails describes what playing a source gives, as far as the provider says, so one can be chosen by more
+// than its name
+type SourceDetails struct {
+	Source          EpisodeSource
+	TranslationType string // "sub", "dub" or "raw"
+	Stream          StreamInfo
+	Subtitles       SubtitleKind
+	Error           error // Why the stream couldn't be fetched, leaving only the translation type known
+}
+
+// describeSource works out the details of a source from its stream.  AllAnime subs are burned in unless the stream
+// comes with subtitle tracks.
+func describeSource(source EpisodeSource, translationType string, stream StreamInfo, err error) SourceDetails {
+	details := SourceDetails{
+		Source:          source,
+		TranslationType: translationType,
+		Stream:          stream,
+		Error:           err,
+	}
+	switch {
+	case err != nil:
+	case len(stream.Subtitles) > 0:
+		details.Subtitles = SubtitlesSoft
+	case translationType == "sub":
+		details.Subtitles = SubtitlesHard
+	}
+	return details
+}
+
+// Summary describes the source in a few words, e.g. "1080p • soft subs (English, Spanish)"
+func (d SourceDetails) Summary() string {
+	if d.Error != nil {
+		return strings.TrimSpace(d.TranslationType + " • unavailable")
+	}
+
+	var parts []string
+	switch {
+	case d.Stream.Resolution != "":
+		parts = append(parts, d.Stream.Resolution)
+	case d.Stream.HLS:
+		parts = append(parts, "adaptive")
+	default:
+		parts = append(parts, "resolution unknown")
+	}
+
+	switch d.Subtitles {
+	case SubtitlesSoft:
+		parts = append(parts, fmt.Sprintf("soft subs (%s)", strings.Join(d.Stream.Subtitles, ", ")))
+	case SubtitlesHard:
+		parts = append(parts, "hard subs")
+	}
+	if d.TranslationType == "dub" || d.TranslationType == "raw" {
+		parts = append(parts, d.TranslationType)
+	}
+	return strings.Join(parts, " • ")
+}
+
+// DescribeSources fetches the stream of each source to find out what it plays.  Sources whose stream can't be fetched
+// are still returned, with the error, so the user can see they were tried.
+func (s *PlayerService) DescribeSources(ctx context.Context, info *EpisodeSourceInfo) []SourceDetails {
+	details := make([]SourceDetails, 0, len(info.Sources))
+	for _, source := range info.Sources {
+		stream, err := s.GetStream(ctx, source)
+		details = append(details, describeSource(source, info.TranslationType, stream, err))
+	}
+	return details
+}
@@ -0,0 +1,77 @@
+package player
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStreamResponse(t *testing.T) {
+	body := `{"links":[{"link":"https://example.com/ep1.m3u8","hls":true,"resolutionStr":"1080P",
+		"subtitles":[{"lang":"en","label":"English","src":"a.vtt"},{"lang":"es","src":"b.vtt"}]},
+		{"link":"https://example.com/ep1-720.mp4","resolutionStr":"720p"}]}`
+
+	stream, err := parseStreamResponse([]byte(body))
+	require.NoError(t, err)
+	assert.Equal(t, StreamInfo{
+		URL:        "https://example.com/ep1.m3u8",
+		Resolution: "1080p",
+		HLS:        true,
+		Subtitles:  []string{"English", "es"},
+	}, stream)
+}
+
+func TestParseStreamResponseIgnoresNonResolutions(t *testing.T) {
+	stream, err := parseStreamResponse([]byte(`{"links":[{"link":"https://example.com/ep1.mp4","resolutionStr":"Mp4"}]}`))
+	require.NoError(t, err)
+	assert.Empty(t, stream.Resolution)
+}
+
+func TestParseStreamResponseNoLinks(t *testing.T) {
+	_, err := parseStreamResponse([]byte(`{"links":[]}`))
+	assert.Error(t, err)
+}
+
+func TestSourceDetailsSummary(t *testing.T) {
+	tests := []struct {
+		name            string
+		translationType string
+		stream          StreamInfo
+		err             error
+		want            string
+	}{
+		{
+			name:            "hard subs",
+			translationType: "sub",
+			stream:          StreamInfo{Resolution: "1080p"},
+			want:            "1080p • hard subs",
+		},
+		{
+			name:            "soft subs",
+			translationType: "sub",
+			stream:          StreamInfo{HLS: true, Subtitles: []string{"English", "Spanish"}},
+			want:            "adaptive • soft subs (English, Spanish)",
+		},
+		{
+			name:            "dub",
+			translationType: "dub",
+			stream:          StreamInfo{},
+			want:            "resolution unknown • dub",
+		},
+		{
+			name:            "unavailable",
+			translationType: "sub",
+			err:             errors.New("HTTP 500"),
+			want:            "sub • unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			details := describeSource(EpisodeSource{SourceName: "S-mp4"}, tt.translationType, tt.stream, tt.err)
+			assert.Equal(t, tt.want, details.Summary())
+		})
+	}
+}
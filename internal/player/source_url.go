@@ -0,0 +1,157 @@
+package player
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// sourceURLKey is the byte AllAnime XORs each character of a source URL with before hex encoding it
+const sourceURLKey = 0x38
+
+// sourceDecoder decodes one scheme AllAnime obfuscates source URLs with
+type sourceDecoder struct {
+	name   string // Used in logs
+	detect func(encoded string) bool
+	decode func(encoded string) (string, error)
+}
+
+// sourceDecoders are the schemes source URLs are decoded with, tried in order.  A source URL is decoded by the first
+// decoder that detects it and decodes it to something that looks like a URL, so when AllAnime changes its scheme a
+// decoder for the new one can be added here without changing how sources are played.
+var sourceDecoders = []sourceDecoder{
+	{
+		// Each character XORed with sourceURLKey and hex encoded, after a "--" prefix
+		name:   "xor",
+		detect: isHexSourceURL,
+		decode: decodeXOR,
+	},
+	{
+		// The same scheme with a different key, found by trying every key
+		name:   "xor-hex",
+		detect: isHexSourceURL,
+		decode: decodeXORAnyKey,
+	},
+	{
+		// Paths on AllAnime's API that aren't obfuscated at all
+		name:   "plain",
+		detect: func(encoded string) bool { return strings.HasPrefix(encoded, "/") },
+		decode: func(encoded string) (string, error) { return encoded, nil },
+	},
+}
+
+// decodeSourceURL decodes an encoded source URL from allanime with the first decoder in sourceDecoders that can
+func (s *PlayerService) decodeSourceURL(encoded string) (string, error) {
+	var lastErr error
+	for _, decoder := range sourceDecoders {
+		if !decoder.detect(encoded) {
+			continue
+		}
+		decoded, err := decoder.decode(encoded)
+		if err == nil && !looksLikeSourceURL(decoded) {
+			err = fmt.Errorf("decoded to %q, which isn't a URL", decoded)
+		}
+		if err != nil {
+			log.Debug("Source URL decoder failed", "decoder", decoder.name, "error", err)
+			lastErr = err
+			continue
+		}
+
+		// Replace "/clock" with "/clock.json" if needed
+		return strings.Replace(decoded, "/clock", "/clock.json", -1), nil
+	}
+	if lastErr != nil {
+		return "", lastErr
+	}
+	return "", fmt.Errorf("no decoder recognises source URL: %s", encoded)
+}
+
+// canDecodeSourceURL reports whether any decoder recognises a source URL's scheme
+func canDecodeSourceURL(encoded string) bool {
+	for _, decoder := range sourceDecoders {
+		if decoder.detect(encoded) {
+			return true
+		}
+	}
+	return false
+}
+
+// looksLikeSourceURL reports whether a decoded source URL is a path on AllAnime's API or a link elsewhere, which is
+// how a decoder that used the wrong scheme is told apart from one that worked
+func looksLikeSourceURL(decoded string) bool {
+	return strings.HasPrefix(decoded, "/") || strings.HasPrefix(decoded, "http://") ||
+		strings.HasPrefix(decoded, "https://")
+}
+
+// isHexSourceURL reports whether a source URL is "--" followed by hex pairs
+func isHexSourceURL(encoded string) bool {
+	return strings.HasPrefix(encoded, "--") && len(encoded) > 2
+}
+
+// decodeXOR decodes a source URL whose characters were XORed with sourceURLKey
+func decodeXOR(encoded string) (string, error) {
+	hexStr := encoded[2:]
+
+	var decodedBuilder strings.Builder
+
+	// Process each 2-character hex pair
+	for i := 0; i < len(hexStr); i += 2 {
+		if i+2 > len(hexStr) {
+			return "", fmt.Errorf("invalid hex pair at position %d", i)
+		}
+
+		pair := hexStr[i : i+2]
+		char := decodeHexPair(pair)
+
+		if char == 0 {
+			return "", fmt.Errorf("invalid hex pair: %s", pair)
+		}
+
+		decodedBuilder.WriteRune(char)
+	}
+
+	return decodedBuilder.String(), nil
+}
+
+// decodeXORAnyKey decodes a source URL whose characters were XORed with a single byte key, working out the key by
+// trying each one until the URL decodes to printable characters that look like a URL
+func decodeXORAnyKey(encoded string) (string, error) {
+	data, err := hex.DecodeString(encoded[2:])
+	if err != nil {
+		return "", fmt.Errorf("source URL is not hex: %w", err)
+	}
+
+	decoded := make([]byte, len(data))
+	for key := 0; key < 256; key++ {
+		printable := true
+		for i, b := range data {
+			decoded[i] = b ^ byte(key)
+			if decoded[i] <= ' ' || decoded[i] > '~' {
+				printable = false
+				break
+			}
+		}
+		if printable && looksLikeSourceURL(string(decoded)) {
+			if key != sourceURLKey {
+				log.Info("Source URL decoded with a new key", "key", fmt.Sprintf("%#02x", key))
+			}
+			return string(decoded), nil
+		}
+	}
+	return "", fmt.Errorf("no single byte key decodes the source URL")
+}
+
+// decodeHexPair decodes a single character of an encoded source URL.  Returns 0 if the pair isn't hex or doesn't XOR
+// to a printable character.
+func decodeHexPair(pair string) rune {
+	b, err := hex.DecodeString(pair)
+	if err != nil {
+		return 0
+	}
+	if char := rune(b[0] ^ sourceURLKey); char > ' ' && char <= '~' {
+		return char
+	}
+	return 0
+}
@@ -0,0 +1,81 @@
+package player
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeSourceURL(t *testing.T) {
+	s := &PlayerService{}
+
+	tests := []struct {
+		name    string
+		encoded string
+		want    string
+	}{
+		{
+			name:    "clock API path",
+			encoded: "--175948514e4c4f57175b54575b5307515c050f5c0a0c0f0b0f0c0e590a0c0b5b0a0c0a010f0b0f5b0e5a0e5c0f590d5c085e",
+			want:    "/apivtwo/clock.json?id=7d2473746a243c2429737c6b6d7a5d0f",
+		},
+		{
+			name:    "upper case and escaped characters",
+			encoded: "--175948514e4c4f57175b54575b5307515c05604d01501e494d5954514c4105707c1d0a08",
+			want:    "/apivtwo/clock.json?id=Xu9h&quality=HD%20",
+		},
+		{
+			name:    "link to another hoster",
+			encoded: "--504c4c484b0217174c5757544b165e594b4c0c4b485d5d5c164a4b4e4817174e515c5d574b1750544b17507f4b685f567a420a556c176b0a6f6f40014a6f55490b4a7e00547b17",
+			want:    "https://tools.fast4speed.rsvp//videos/hls/hGsPgnBz2mT/S2WWx9rWmq3rF8lC/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := s.decodeSourceURL(tt.encoded)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, decoded)
+		})
+	}
+}
+
+func TestDecodeSourceURLInvalid(t *testing.T) {
+	s := &PlayerService{}
+
+	_, err := s.decodeSourceURL("175948")
+	assert.Error(t, err, "missing prefix")
+
+	_, err = s.decodeSourceURL("--17594")
+	assert.Error(t, err, "odd length")
+
+	_, err = s.decodeSourceURL("--17zz")
+	assert.Error(t, err, "not hex")
+}
+
+func TestDecodeSourceURLNewKey(t *testing.T) {
+	s := &PlayerService{}
+
+	// "/apivtwo/clock?id=abc" XORed with 0x5a instead of sourceURLKey
+	plain := "/apivtwo/clock?id=abc"
+	encoded := "--"
+	for _, c := range []byte(plain) {
+		encoded += fmt.Sprintf("%02x", c^0x5a)
+	}
+
+	decoded, err := s.decodeSourceURL(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, "/apivtwo/clock.json?id=abc", decoded)
+}
+
+func TestDecodeSourceURLPlain(t *testing.T) {
+	s := &PlayerService{}
+
+	decoded, err := s.decodeSourceURL("/apivtwo/clock?id=abc")
+	require.NoError(t, err)
+	assert.Equal(t, "/apivtwo/clock.json?id=abc", decoded)
+	assert.True(t, canDecodeSourceURL("/apivtwo/clock?id=abc"))
+	assert.False(t, canDecodeSourceURL("https://ok.ru/videoembed/1"), "links to other hosters aren't AllAnime's")
+}
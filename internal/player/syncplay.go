@@ -0,0 +1,132 @@
+package player
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// SyncplayPlayer implements the VideoPlayer interface by playing through Syncplay, which runs MPV and keeps it in sync
+// with everyone else in the room.  Syncplay keeps MPV's IPC socket to itself, so like a custom player, Hisame can only
+// see how long it ran for.
+type SyncplayPlayer struct {
+	*ProcessPlayer
+	referer    string   // Referer the stream's host needs, empty if none
+	presetArgs []string // MPV args of the preset, added after the configured args
+}
+
+// NewSyncplayPlayer creates a new player that runs MPV through Syncplay
+func NewSyncplayPlayer(cfg *config.Config) *SyncplayPlayer {
+	return &SyncplayPlayer{
+		ProcessPlayer: &ProcessPlayer{config: cfg, name: "Syncplay"},
+	}
+}
+
+// Play joins the configured Syncplay room and plays the URL in MPV.  PlaybackStarted is sent as soon as Syncplay
+// starts, and PlaybackEnded with the time it ran for once it exits.
+func (p *SyncplayPlayer) Play(ctx context.Context, url string, title string) (<-chan PlaybackEvent, error) {
+	log.Info("Starting Syncplay playback", "url", url, "title", title, "server", p.config.Syncplay.Server,
+		"room", p.config.Syncplay.Room)
+
+	events := make(chan PlaybackEvent, 10)
+
+	executable, args, err := p.buildCommand(url, title)
+	if err != nil {
+		close(events)
+		return events, err
+	}
+	log.Debug("Player command", "executable", executable, "args", redactPassword(args))
+
+	return p.run(ctx, executable, args, events)
+}
+
+// buildCommand returns the executable and arguments to start Syncplay with.  Arguments after "--" are passed on to MPV.
+func (p *SyncplayPlayer) buildCommand(url, title string) (string, []string, error) {
+	syncplay := p.config.Syncplay
+	commandStr := syncplay.Command
+	if commandStr == "" {
+		commandStr = "syncplay"
+	}
+	commandParts := ParseArgs(commandStr)
+	if len(commandParts) == 0 {
+		return "", nil, fmt.Errorf("no Syncplay command configured")
+	}
+
+	// Syncplay is given the MPV executable itself, so any prefix in the player command can't be used
+	mpvPath := "mpv"
+	if p.config.Player.Type == "mpv" {
+		if playerParts := ParseArgs(p.config.Player.Command); len(playerParts) > 0 {
+			mpvPath = playerParts[0]
+		}
+	}
+
+	args := append([]string{}, commandParts[1:]...)
+	// Don't show Syncplay's window, or save the room given here over the one the user set up in Syncplay
+	args = append(args, "--no-gui", "--no-store")
+	if syncplay.Server != "" {
+		args = append(args, "--host", syncplay.Server)
+	}
+	if syncplay.Room != "" {
+		args = append(args, "--room", syncplay.Room)
+	}
+	if syncplay.Name != "" {
+		args = append(args, "--name", syncplay.Name)
+	}
+	if syncplay.Password != "" {
+		args = append(args, "--password", syncplay.Password)
+	}
+	args = append(args, "--player-path", mpvPath, url, "--")
+
+	if title != "" {
+		args = append(args, "--title="+title)
+	}
+	if p.startPos > 0 {
+		args = append(args, fmt.Sprintf("--start=%.0f", p.startPos))
+	}
+	if p.referer != "" {
+		args = append(args, "--referrer="+p.referer)
+	}
+	if p.config.Player.SubtitleLanguages != "" {
+		args = append(args, "--slang="+p.config.Player.SubtitleLanguages)
+	}
+	if p.config.Player.AudioLanguages != "" {
+		args = append(args, "--alang="+p.config.Player.AudioLanguages)
+	}
+	if p.config.Player.Args != "" {
+		args = append(args, ParseArgs(p.config.Player.Args)...)
+	}
+	args = append(args, p.presetArgs...)
+
+	return commandParts[0], args, nil
+}
+
+// redactPassword returns a copy of a player's arguments with any password hidden, so they can be logged.  It covers
+// Syncplay's room password, given as "--password <password>", and VLC's HTTP interface password, given as
+// "--http-password=<password>".
+func redactPassword(args []string) []string {
+	redacted := append([]string{}, args...)
+	for i := 0; i < len(redacted); i++ {
+		switch {
+		case redacted[i] == "--":
+			return redacted
+		case redacted[i] == "--password" && i+1 < len(redacted):
+			redacted[i+1] = "[redacted]"
+		case strings.HasPrefix(redacted[i], "--http-password="):
+			redacted[i] = "--http-password=[redacted]"
+		}
+	}
+	return redacted
+}
+
+// SetReferer sets the Referer the next playback sends with its stream requests
+func (p *SyncplayPlayer) SetReferer(referer string) {
+	p.referer = referer
+}
+
+// SetPresetArgs sets the MPV args of the preset the next playback uses
+func (p *SyncplayPlayer) SetPresetArgs(args []string) {
+	p.presetArgs = args
+}
@@ -0,0 +1,79 @@
+package player
+
+import (
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncplayBuildCommand(t *testing.T) {
+	cfg := &config.Config{
+		Player: config.PlayerConfig{Type: "mpv", Command: "/usr/bin/mpv", Args: "--fullscreen", SubtitleLanguages: "en"},
+		Syncplay: config.SyncplayConfig{
+			Enabled: true,
+			Command: "flatpak run pl.syncplay.Syncplay",
+			Server:  "syncplay.pl:8999",
+			Room:    "anime-night",
+			Name:    "pizza",
+		},
+	}
+	p := NewSyncplayPlayer(cfg)
+	p.SetStartPosition(90)
+	p.SetReferer("https://allanime.day")
+	p.SetPresetArgs([]string{"--profile=fast"})
+
+	executable, args, err := p.buildCommand("https://example.com/ep.m3u8", "Ep 1 - Frieren")
+	require.NoError(t, err)
+	assert.Equal(t, "flatpak", executable)
+	assert.Equal(t, []string{
+		"run", "pl.syncplay.Syncplay",
+		"--no-gui", "--no-store",
+		"--host", "syncplay.pl:8999",
+		"--room", "anime-night",
+		"--name", "pizza",
+		"--player-path", "/usr/bin/mpv",
+		"https://example.com/ep.m3u8",
+		"--",
+		"--title=Ep 1 - Frieren",
+		"--start=90",
+		"--referrer=https://allanime.day",
+		"--slang=en",
+		"--fullscreen",
+		"--profile=fast",
+	}, args)
+}
+
+func TestSyncplayRedactPassword(t *testing.T) {
+	p := NewSyncplayPlayer(&config.Config{
+		Player:   config.PlayerConfig{Type: "mpv", Command: "mpv"},
+		Syncplay: config.SyncplayConfig{Enabled: true, Room: "anime-night", Password: "hunter2"},
+	})
+
+	_, args, err := p.buildCommand("https://example.com/ep.m3u8", "")
+	require.NoError(t, err)
+	assert.Contains(t, args, "hunter2")
+
+	redacted := redactPassword(args)
+	assert.NotContains(t, redacted, "hunter2")
+	assert.Contains(t, redacted, "[redacted]")
+	assert.Contains(t, args, "hunter2", "the args Syncplay is started with should be left untouched")
+}
+
+func TestSyncplayBuildCommandDefaults(t *testing.T) {
+	p := NewSyncplayPlayer(&config.Config{Player: config.PlayerConfig{Type: "vlc", Command: "vlc"}})
+
+	executable, args, err := p.buildCommand("https://example.com/ep.mp4", "")
+	require.NoError(t, err)
+	assert.Equal(t, "syncplay", executable)
+	assert.Equal(t, []string{"--no-gui", "--no-store", "--player-path", "mpv", "https://example.com/ep.mp4", "--"}, args,
+		"the room Syncplay has saved should be used, and MPV even if another player is configured")
+}
+
+func TestCreateVideoPlayerSyncplay(t *testing.T) {
+	videoPlayer, err := CreateVideoPlayer(&config.Config{Player: config.PlayerConfig{Type: "mpv"},
+		Syncplay: config.SyncplayConfig{Enabled: true}}, "https://example.com/ep.mp4", "")
+	require.NoError(t, err)
+	assert.IsType(t, &SyncplayPlayer{}, videoPlayer)
+}
@@ -0,0 +1,43 @@
+package player
+
+import (
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+const (
+	defaultConnectTimeout       = 10 * time.Second
+	defaultPlaybackStartTimeout = 30 * time.Second
+	defaultOverallTimeout       = 2 * time.Minute
+)
+
+// Timeouts are how long each step of starting playback may take before it is given up on
+type Timeouts struct {
+	Connect       time.Duration // MPV opening its IPC socket and accepting a connection
+	PlaybackStart time.Duration // The player starting to play once it has connected
+	Overall       time.Duration // Finding a working stream and starting playback, altogether
+}
+
+// TimeoutsFromConfig reads the timeouts from the config, using the defaults for any that are missing or can't be parsed
+func TimeoutsFromConfig(cfg config.TimeoutsConfig) Timeouts {
+	return Timeouts{
+		Connect:       parseTimeout("connect", cfg.Connect, defaultConnectTimeout),
+		PlaybackStart: parseTimeout("playback_start", cfg.PlaybackStart, defaultPlaybackStartTimeout),
+		Overall:       parseTimeout("overall", cfg.Overall, defaultOverallTimeout),
+	}
+}
+
+func parseTimeout(name, value string, fallback time.Duration) time.Duration {
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Warn("Invalid playback timeout in config, using the default", "setting", name, "value", value,
+			"default", fallback)
+		return fallback
+	}
+	return d
+}
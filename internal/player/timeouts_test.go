@@ -0,0 +1,21 @@
+package player
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeoutsFromConfig(t *testing.T) {
+	timeouts := TimeoutsFromConfig(config.TimeoutsConfig{Connect: "20s", PlaybackStart: "1m30s", Overall: "5m"})
+	assert.Equal(t, Timeouts{Connect: 20 * time.Second, PlaybackStart: 90 * time.Second, Overall: 5 * time.Minute}, timeouts)
+
+	timeouts = TimeoutsFromConfig(config.TimeoutsConfig{Connect: "soon", PlaybackStart: "-5s"})
+	assert.Equal(t, Timeouts{
+		Connect:       defaultConnectTimeout,
+		PlaybackStart: defaultPlaybackStartTimeout,
+		Overall:       defaultOverallTimeout,
+	}, timeouts, "missing and invalid timeouts should use the defaults")
+}
@@ -0,0 +1,116 @@
+package player
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// AniList and AllAnime often disagree on how a later season is written, e.g. "Show 2nd Season", "Show Season 2",
+// "Show Part 2" and "Show II".  normalizeTitle reduces these to a common form so titles can be compared.
+
+// nativeSeasonPattern matches Japanese season markers such as 第2期, which are reduced to the bare number
+var nativeSeasonPattern = regexp.MustCompile(`第?(\d+)期`)
+
+// ordinalPattern matches numeric ordinals such as "2nd"
+var ordinalPattern = regexp.MustCompile(`^(\d+)(?:st|nd|rd|th)$`)
+
+// shortSeasonPattern matches abbreviated season markers such as "s2"
+var shortSeasonPattern = regexp.MustCompile(`^s(\d+)$`)
+
+// ordinalWords are the spelled out ordinals that appear in season markers, e.g. "Second Season"
+var ordinalWords = map[string]int{
+	"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5,
+	"sixth": 6, "seventh": 7, "eighth": 8, "ninth": 9, "tenth": 10,
+}
+
+// romanNumerals are the numerals that appear in titles of later seasons.  "i" is left out as it's far more likely
+// to be the word.
+var romanNumerals = map[string]int{
+	"ii": 2, "iii": 3, "iv": 4, "v": 5, "vi": 6, "vii": 7, "viii": 8, "ix": 9, "x": 10,
+}
+
+// seasonMarkers are the words that introduce a season number
+var seasonMarkers = map[string]bool{"season": true, "part": true, "cour": true}
+
+// normalizeTitle lowercases a title, drops punctuation and reduces season, part and cour markers to their number, so
+// "Show Name: 2nd Season", "Show Name Season 2", "Show Name Part 2" and "Show Name II" all become "show name 2".  A
+// first season marker is dropped entirely, as the first season is usually titled without one.
+func normalizeTitle(title string) string {
+	title = nativeSeasonPattern.ReplaceAllString(title, " $1 ")
+	title = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, title)
+
+	words := strings.Fields(title)
+	result := make([]string, 0, len(words))
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		next := ""
+		if i+1 < len(words) {
+			next = words[i+1]
+		}
+
+		switch {
+		case seasonMarkers[word] && parseSeasonNumber(next) > 0:
+			// "season 2", "part ii"
+			result = appendSeasonNumber(result, word, parseSeasonNumber(next))
+			i++
+		case seasonMarkers[next] && parseOrdinal(word) > 0:
+			// "2nd season", "second cour"
+			result = appendSeasonNumber(result, next, parseOrdinal(word))
+			i++
+		case shortSeasonPattern.MatchString(word):
+			// "s2"
+			n, _ := strconv.Atoi(shortSeasonPattern.FindStringSubmatch(word)[1])
+			result = appendSeasonNumber(result, "season", n)
+		case i > 0 && i == len(words)-1 && romanNumerals[word] > 0:
+			// A trailing "ii"
+			result = append(result, strconv.Itoa(romanNumerals[word]))
+		default:
+			result = append(result, word)
+		}
+	}
+
+	return strings.Join(result, " ")
+}
+
+// appendSeasonNumber adds a marker's number to the normalized words, leaving out a first season
+func appendSeasonNumber(words []string, marker string, n int) []string {
+	if marker == "season" && n == 1 {
+		return words
+	}
+	return append(words, strconv.Itoa(n))
+}
+
+// parseSeasonNumber parses the number following a season marker, as digits or a roman numeral
+func parseSeasonNumber(word string) int {
+	if n, err := strconv.Atoi(word); err == nil {
+		return n
+	}
+	if word == "i" {
+		return 1
+	}
+	return romanNumerals[word]
+}
+
+// parseOrdinal parses an ordinal preceding a season marker, e.g. "2nd" or "second"
+func parseOrdinal(word string) int {
+	if match := ordinalPattern.FindStringSubmatch(word); match != nil {
+		n, _ := strconv.Atoi(match[1])
+		return n
+	}
+	return ordinalWords[word]
+}
+
+// titlesMatch reports whether two titles are the same once normalized
+func titlesMatch(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	return normalizeTitle(a) == normalizeTitle(b)
+}
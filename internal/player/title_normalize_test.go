@@ -0,0 +1,42 @@
+package player
+
+import (
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Show Name 2nd Season", "show name 2"},
+		{"Show Name Season 2", "show name 2"},
+		{"Show Name: Part 2", "show name 2"},
+		{"Show Name Second Season", "show name 2"},
+		{"Show Name II", "show name 2"},
+		{"Show Name Season II", "show name 2"},
+		{"Show Name S2", "show name 2"},
+		{"Show Name 2nd Cour", "show name 2"},
+		{"Show Name Season 1", "show name"},
+		{"Show Name Season 3 Part 2", "show name 3 2"},
+		{"ショー 第2期", "ショー 2"},
+		{"I Want to Eat Your Pancreas", "i want to eat your pancreas"},
+		{"Hunter x Hunter (2011)", "hunter x hunter 2011"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, normalizeTitle(tt.title), tt.title)
+	}
+}
+
+func TestMatchesByNormalizedTitle(t *testing.T) {
+	s := &PlayerService{}
+	title := &domain.AnimeTitle{Romaji: "Shingeki no Kyojin 2nd Season", English: "Attack on Titan Season 2"}
+
+	assert.True(t, s.matchesByTitleOrSynonyms(title, nil, AllAnimeShow{Name: "Shingeki no Kyojin Season 2"}))
+	assert.True(t, s.matchesByTitleOrSynonyms(title, nil, AllAnimeShow{EnglishName: "Attack on Titan Part 2"}))
+	assert.False(t, s.matchesByTitleOrSynonyms(title, nil, AllAnimeShow{Name: "Shingeki no Kyojin Season 3"}))
+}
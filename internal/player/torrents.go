@@ -0,0 +1,141 @@
+package player
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/localfiles"
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// FindTorrents searches Nyaa for releases of a single episode of the anime, best first.  Releases are searched for by
+// each of the anime's titles and kept if their name parses to one of them and to the episode, so batches and other
+// shows with similar names are left out.
+func (s *PlayerService) FindTorrents(ctx context.Context, anime *domain.Anime, episode int) ([]Torrent, error) {
+	names := append([]string{anime.Title.Romaji, anime.Title.English}, anime.Synonyms...)
+	wanted := make(map[string]bool)
+	for _, name := range names {
+		if key := localfiles.NormalizeTitle(name); key != "" {
+			wanted[key] = true
+		}
+	}
+
+	// Releases often write the season differently from AniList, so the title is searched for without it
+	var queries []string
+	searched := make(map[string]bool)
+	for _, name := range []string{anime.Title.Romaji, anime.Title.English} {
+		title, _ := localfiles.ParseFolderName(name)
+		if key := localfiles.NormalizeTitle(title); key != "" && !searched[key] {
+			searched[key] = true
+			queries = append(queries, fmt.Sprintf("%s %02d", title, episode))
+		}
+	}
+
+	var torrents []Torrent
+	seen := make(map[string]bool)
+	var lastErr error
+	for _, query := range queries {
+		results, err := s.nyaaClient.Search(ctx, query)
+		if err != nil {
+			log.Warn("Failed to search Nyaa", "query", query, "error", err)
+			lastErr = err
+			continue
+		}
+		for _, torrent := range results {
+			if !seen[torrent.InfoHash] && torrentMatches(torrent, wanted, episode) {
+				seen[torrent.InfoHash] = true
+				torrents = append(torrents, torrent)
+			}
+		}
+	}
+	if len(torrents) == 0 && lastErr != nil {
+		return nil, fmt.Errorf("failed to search Nyaa: %w", lastErr)
+	}
+
+	rankTorrents(torrents, s.config.Torrent.Resolution)
+	log.Info("Found torrents on Nyaa", "anime_id", anime.ID, "episode", episode, "queries", len(queries),
+		"torrents", len(torrents))
+	return torrents, nil
+}
+
+// torrentMatches reports whether a torrent is the single episode of a show known by one of the wanted titles
+func torrentMatches(torrent Torrent, wanted map[string]bool, episode int) bool {
+	elements := localfiles.Parse(torrent.Title)
+	if elements.Episode != episode || elements.EpisodeEnd != 0 {
+		return false
+	}
+	for _, key := range localfiles.TitleKeys(elements.Title, elements.Season) {
+		if wanted[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// rankTorrents orders torrents best first.  Torrents nobody is seeding go last, and the rest are ordered by seeders,
+// with the preferred resolution and trusted uploaders counting for more.
+func rankTorrents(torrents []Torrent, resolution string) {
+	score := func(torrent Torrent) float64 {
+		score := float64(torrent.Seeders)
+		if resolution != "" && strings.EqualFold(localfiles.Parse(torrent.Title).Resolution, resolution) {
+			score *= 2
+		}
+		if torrent.Trusted {
+			score *= 1.5
+		}
+		return score
+	}
+	sort.SliceStable(torrents, func(i, j int) bool {
+		if (torrents[i].Seeders > 0) != (torrents[j].Seeders > 0) {
+			return torrents[i].Seeders > 0
+		}
+		return score(torrents[i]) > score(torrents[j])
+	})
+}
+
+// CanStreamTorrents reports whether a command is configured to stream torrents.  Without one, torrents are handed to
+// the system's torrent client with OpenTorrent.
+func (s *PlayerService) CanStreamTorrents() bool {
+	return s.config.Torrent.Command != ""
+}
+
+// OpenTorrent hands the torrent's magnet link to whichever torrent client the system opens magnet links with
+func (s *PlayerService) OpenTorrent(torrent Torrent) error {
+	magnet := torrent.Magnet()
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", magnet)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", magnet)
+	default:
+		cmd = exec.Command("xdg-open", magnet)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open the magnet link: %w", err)
+	}
+	go func() { _ = cmd.Wait() }()
+
+	log.Info("Handed torrent to the torrent client", "title", torrent.Title, "info_hash", torrent.InfoHash)
+	return nil
+}
+
+// newTorrentPlayer creates a player that streams magnet links with the configured torrent command.  It can only see
+// the command start and exit, like a custom player.
+func (s *PlayerService) newTorrentPlayer() VideoPlayer {
+	cfg := *s.config
+	cfg.Player.Command = s.config.Torrent.Command
+	cfg.Player.Path = ""
+	cfg.Player.Args = ""
+	return NewProcessPlayer(&cfg)
+}
+
+// isMagnet reports whether a stream URL is a torrent's magnet link
+func isMagnet(streamURL string) bool {
+	return strings.HasPrefix(streamURL, "magnet:")
+}
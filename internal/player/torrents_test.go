@@ -0,0 +1,86 @@
+package player
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nyaaFeedItem writes a search result as Nyaa's RSS feed does
+func nyaaFeedItem(title, hash, seeders, trusted string) string {
+	return `<item>
+		<title>` + title + `</title>
+		<guid isPermaLink="true">https://nyaa.si/view/1</guid>
+		<pubDate>Fri, 06 Oct 2023 17:01:02 -0000</pubDate>
+		<nyaa:seeders>` + seeders + `</nyaa:seeders>
+		<nyaa:leechers>3</nyaa:leechers>
+		<nyaa:infoHash>` + hash + `</nyaa:infoHash>
+		<nyaa:size>1.4 GiB</nyaa:size>
+		<nyaa:trusted>` + trusted + `</nyaa:trusted>
+	</item>`
+}
+
+func TestFindTorrents(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("q"))
+		assert.Equal(t, "rss", r.URL.Query().Get("page"))
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="utf-8"?>
+<rss xmlns:nyaa="https://nyaa.si/xmlns/nyaa" version="2.0"><channel>` +
+			nyaaFeedItem("[SubsPlease] Sousou no Frieren - 05 (720p) [AAAA1111].mkv", "AAAA", "900", "No") +
+			nyaaFeedItem("[SubsPlease] Sousou no Frieren - 05 (1080p) [BBBB2222].mkv", "BBBB", "800", "No") +
+			nyaaFeedItem("[Group] Sousou no Frieren - 05 [1080p]", "CCCC", "0", "Yes") +
+			nyaaFeedItem("[Group] Sousou no Frieren - 15 (1080p)", "DDDD", "500", "No") +
+			nyaaFeedItem("[Group] Sousou no Frieren - 01-10 (1080p) [Batch]", "EEEE", "700", "Yes") +
+			nyaaFeedItem("[Group] Frieren Spin-off - 05 (1080p)", "FFFF", "700", "Yes") +
+			`</channel></rss>`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{Torrent: config.TorrentConfig{Resolution: "1080p"}}
+	s := &PlayerService{config: cfg, nyaaClient: NewNyaaClient(config.RetryConfig{MaxRetries: -1})}
+	s.nyaaClient.endpoint = server.URL + "/"
+
+	anime := &domain.Anime{ID: 1, Title: domain.AnimeTitle{Romaji: "Sousou no Frieren", English: "Frieren: Beyond Journey's End"}}
+	torrents, err := s.FindTorrents(context.Background(), anime, 5)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Sousou no Frieren 05", "Frieren: Beyond Journey's End 05"}, queries)
+	hashes := make([]string, 0, len(torrents))
+	for _, torrent := range torrents {
+		hashes = append(hashes, torrent.InfoHash)
+	}
+	// The preferred resolution outranks more seeders, and nobody seeding ranks last
+	assert.Equal(t, []string{"bbbb", "aaaa", "cccc"}, hashes)
+	assert.True(t, torrents[2].Trusted)
+	assert.Equal(t, "1.4 GiB", torrents[0].Size)
+}
+
+func TestTorrentMagnet(t *testing.T) {
+	magnet := Torrent{Title: "Show - 05", InfoHash: "abcd"}.Magnet()
+	assert.True(t, strings.HasPrefix(magnet, "magnet:?xt=urn:btih:abcd&"))
+	assert.Contains(t, magnet, "dn=Show+-+05")
+	assert.Contains(t, magnet, "tr=")
+}
+
+func TestTorrentPlayerUsesTorrentCommand(t *testing.T) {
+	cfg := &config.Config{
+		Player:  config.PlayerConfig{Type: "mpv", Command: "mpv", Path: "mpv", Args: "--fs"},
+		Torrent: config.TorrentConfig{Command: "webtorrent {url} --mpv"},
+	}
+	s := &PlayerService{config: cfg}
+
+	p, ok := s.newTorrentPlayer().(*ProcessPlayer)
+	require.True(t, ok)
+	assert.Equal(t, "webtorrent {url} --mpv", p.config.Player.Command)
+	assert.Empty(t, p.config.Player.Args, "the player's args are for the player, not the torrent command")
+	assert.Equal(t, "mpv", cfg.Player.Command, "the player config should be left alone")
+	assert.True(t, isMagnet("magnet:?xt=urn:btih:abcd"))
+}
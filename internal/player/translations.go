@@ -0,0 +1,46 @@
+package player
+
+import (
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/store"
+)
+
+// animeTranslationsFileName is the name of the file per-anime translation types are persisted to within the data dir
+const animeTranslationsFileName = "anime_translations.json"
+
+// TranslationTypes are the translation types AllAnime has episodes in
+var TranslationTypes = []string{"sub", "dub"}
+
+// AnimeTranslations remembers the translation type chosen for individual anime, overriding player.translation_type
+type AnimeTranslations struct {
+	types *store.Map[int, string] // AniList ID to "sub" or "dub"
+}
+
+// NewAnimeTranslations creates a per-anime translation type store backed by the given file.  An empty path keeps
+// choices in memory only.
+func NewAnimeTranslations(path string) *AnimeTranslations {
+	types, err := store.LoadMap[int, string](path)
+	if err != nil {
+		log.Warn("Failed to load per-anime translation types, using the configured type", "path", path, "error", err)
+	}
+	return &AnimeTranslations{types: types}
+}
+
+// newDefaultAnimeTranslations creates a per-anime translation type store in the Hisame data dir
+func newDefaultAnimeTranslations() *AnimeTranslations {
+	return NewAnimeTranslations(store.DataPath(animeTranslationsFileName, "per-anime translation types"))
+}
+
+// Get returns the translation type chosen for the anime, or an empty string if it uses the configured type
+func (a *AnimeTranslations) Get(animeID int) string {
+	translationType, _ := a.types.Get(animeID)
+	return translationType
+}
+
+// Set chooses the translation type for the anime.  An empty type goes back to the configured type.
+func (a *AnimeTranslations) Set(animeID int, translationType string) error {
+	if translationType == "" {
+		return a.types.Delete(animeID)
+	}
+	return a.types.Set(animeID, translationType)
+}
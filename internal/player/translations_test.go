@@ -0,0 +1,29 @@
+package player
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslationTypePrefersAnimeChoice(t *testing.T) {
+	path := filepath.Join(t.TempDir(), animeTranslationsFileName)
+	s := &PlayerService{
+		config:      &config.Config{Player: config.PlayerConfig{TranslationType: "sub"}},
+		translation: NewAnimeTranslations(path),
+	}
+
+	assert.Equal(t, "sub", s.translationType(1))
+
+	require.NoError(t, s.SetAnimeTranslationType(1, "dub"))
+	assert.Equal(t, "dub", s.translationType(1))
+	assert.Equal(t, "sub", s.translationType(2), "other anime should keep the configured type")
+	assert.Equal(t, "dub", NewAnimeTranslations(path).Get(1), "the choice should be persisted")
+
+	require.NoError(t, s.SetAnimeTranslationType(1, ""))
+	assert.Equal(t, "sub", s.translationType(1))
+	assert.Empty(t, NewAnimeTranslations(path).Get(1))
+}
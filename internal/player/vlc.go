@@ -0,0 +1,343 @@
+package player
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/proxy"
+)
+
+// vlcPollInterval is how often VLC's HTTP interface is asked for the playback status
+const vlcPollInterval = time.Second
+
+// vlcStatus is the part of VLC's status.json Hisame makes use of
+type vlcStatus struct {
+	State    string  `json:"state"`    // "playing", "paused" or "stopped"
+	Time     float64 `json:"time"`     // Whole seconds into the media
+	Length   float64 `json:"length"`   // Length of the media in whole seconds, 0 until it is known
+	Position float64 `json:"position"` // Fraction of the media played, from 0 to 1
+}
+
+// seconds returns how far into the media playback is.  position is more precise than time, which VLC rounds down.
+func (s vlcStatus) seconds() float64 {
+	if s.Length > 0 && s.Position > 0 {
+		return s.Position * s.Length
+	}
+	return s.Time
+}
+
+// VLCPlayer implements the VideoPlayer interface for VLC.  VLC is started with its HTTP interface listening on
+// localhost, which is polled for the playback position.
+type VLCPlayer struct {
+	config    *config.Config
+	cmd       *exec.Cmd
+	client    *http.Client
+	statusURL string  // Address of the HTTP interface's status.json, set by Play
+	password  string  // Password for the HTTP interface, generated for each playback
+	startPos  float64 // Seconds to start playback at, 0 to start from the beginning
+	referer   string  // Referer the stream's host needs, empty if none
+}
+
+// NewVLCPlayer creates a new VLC player instance
+func NewVLCPlayer(cfg *config.Config) *VLCPlayer {
+	return &VLCPlayer{
+		config: cfg,
+		client: &http.Client{Timeout: 2 * time.Second},
+	}
+}
+
+// Play starts VLC with the given URL, and monitors its HTTP interface for playback starting and the position
+func (p *VLCPlayer) Play(ctx context.Context, url string, title string) (<-chan PlaybackEvent, error) {
+	log.Info("Starting VLC playback", "url", url, "title", title)
+
+	events := make(chan PlaybackEvent, 10)
+
+	port, err := freeLocalPort()
+	if err != nil {
+		close(events)
+		return events, fmt.Errorf("failed to find a port for VLC's HTTP interface: %w", err)
+	}
+	password, err := randomPassword()
+	if err != nil {
+		close(events)
+		return events, fmt.Errorf("failed to generate a password for VLC's HTTP interface: %w", err)
+	}
+	p.password = password
+	p.statusURL = fmt.Sprintf("http://127.0.0.1:%d/requests/status.json", port)
+
+	executable, args := p.buildCommand(url, title, port)
+	log.Debug("Player command", "executable", executable, "args", redactPassword(args))
+
+	cmd := exec.Command(executable, args...)
+	cmd.Env = proxy.Environ()
+	setupPlayerProcess(cmd)
+
+	if err := cmd.Start(); err != nil {
+		close(events)
+		return events, fmt.Errorf("failed to start VLC: %w", err)
+	}
+	p.cmd = cmd
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	go p.monitor(ctx, events, exited)
+
+	return events, nil
+}
+
+// monitor waits for playback to start, then reports progress until VLC exits or stops playing
+func (p *VLCPlayer) monitor(ctx context.Context, events chan<- PlaybackEvent, exited <-chan error) {
+	defer close(events)
+
+	ticker := time.NewTicker(vlcPollInterval)
+	defer ticker.Stop()
+
+	startDeadline := time.After(TimeoutsFromConfig(p.config.Player.Timeouts).PlaybackStart)
+	started := false
+	var last vlcStatus
+	lastReportedProgress := -1
+
+	ended := func() {
+		log.Info("VLC playback ended", "position", last.seconds(), "length", last.Length)
+		events <- PlaybackEvent{
+			Type:     PlaybackEnded,
+			Progress: vlcProgress(last),
+			Position: last.seconds(),
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Debug("Context cancelled, stopping VLC monitoring")
+			return
+		case err := <-exited:
+			if !started {
+				if err == nil {
+					err = errors.New("VLC exited before playback started")
+				}
+				log.Error("VLC exited before playback started", "error", err)
+				events <- PlaybackEvent{Type: PlaybackError, Error: err}
+				return
+			}
+			ended()
+			return
+		case <-startDeadline:
+			if !started {
+				err := errors.New("timed out waiting for VLC to start playback")
+				log.Error("Failed to detect VLC playback start", "error", err)
+				events <- PlaybackEvent{Type: PlaybackError, Error: err}
+				return
+			}
+		case <-ticker.C:
+			status, err := p.status(ctx)
+			if err != nil {
+				// VLC takes a moment to open its HTTP interface, and is briefly unresponsive while seeking
+				log.Trace("Unable to get VLC status", "error", err)
+				continue
+			}
+
+			if !started {
+				if status.State != "playing" {
+					continue
+				}
+				started = true
+				events <- PlaybackEvent{Type: PlaybackStarted}
+			}
+
+			if status.State == "stopped" {
+				ended()
+				return
+			}
+			if (status.State == "paused") != (last.State == "paused") && last.State != "" {
+				eventType := PlaybackResumed
+				if status.State == "paused" {
+					eventType = PlaybackPaused
+				}
+				// Like progress, this is informational only
+				select {
+				case events <- PlaybackEvent{Type: eventType, Position: status.seconds()}:
+				default:
+				}
+			}
+			last = status
+
+			progress := int(vlcProgress(status))
+			if progress != lastReportedProgress {
+				lastReportedProgress = progress
+				// Progress is informational only, so never block the monitor if nobody is keeping up
+				select {
+				case events <- PlaybackEvent{Type: PlaybackProgress, Progress: float64(progress), Position: status.seconds()}:
+				default:
+				}
+				if progress%5 == 0 {
+					log.Info("Playback progress", "percent", progress)
+				}
+			}
+		}
+	}
+}
+
+// CycleSubtitles switches to the next subtitle track, as VLC's subtitle hotkey does
+func (p *VLCPlayer) CycleSubtitles() error {
+	if p.statusURL == "" {
+		return errors.New("VLC isn't playing")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	_, err := p.request(ctx, "?command=key&val=subtitle-track")
+	return err
+}
+
+// TogglePause pauses playback, or resumes it if it is paused
+func (p *VLCPlayer) TogglePause() error {
+	if p.statusURL == "" {
+		return errors.New("VLC isn't playing")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	_, err := p.request(ctx, "?command=pl_pause")
+	return err
+}
+
+// status asks VLC's HTTP interface for the playback status
+func (p *VLCPlayer) status(ctx context.Context) (vlcStatus, error) {
+	return p.request(ctx, "")
+}
+
+// request sends a request to VLC's status.json with the given query, which can carry a command, returning the
+// playback status
+func (p *VLCPlayer) request(ctx context.Context, query string) (vlcStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.statusURL+query, nil)
+	if err != nil {
+		return vlcStatus{}, err
+	}
+	// VLC's HTTP interface takes a password with no user name
+	req.SetBasicAuth("", p.password)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return vlcStatus{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return vlcStatus{}, fmt.Errorf("VLC status request failed with HTTP %d", resp.StatusCode)
+	}
+
+	var status vlcStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return vlcStatus{}, fmt.Errorf("failed to decode VLC status: %w", err)
+	}
+	return status, nil
+}
+
+// vlcProgress returns the percentage of the media played
+func vlcProgress(status vlcStatus) float64 {
+	if status.Length <= 0 {
+		return 0
+	}
+	return min(status.seconds()/status.Length*100, 100)
+}
+
+// buildCommand returns the executable and arguments to start VLC with
+func (p *VLCPlayer) buildCommand(url, title string, port int) (string, []string) {
+	// The default command is MPV's, so anything else is taken to be how VLC is run on this system
+	commandStr := p.config.Player.Command
+	if commandStr == "" || commandStr == "mpv" {
+		commandStr = "vlc"
+	}
+	commandParts := ParseArgs(commandStr)
+	if len(commandParts) == 0 {
+		commandParts = []string{"vlc"}
+	}
+
+	args := append([]string{}, commandParts[1:]...) // e.g., ["run", "org.videolan.VLC"] for flatpak
+	args = append(args,
+		"--extraintf=http",
+		"--http-host=127.0.0.1",
+		"--http-port="+strconv.Itoa(port),
+		"--http-password="+p.password,
+		"--play-and-exit",
+	)
+	if p.config.Player.SubtitleLanguages != "" {
+		args = append(args, "--sub-language="+p.config.Player.SubtitleLanguages)
+	}
+	if p.config.Player.AudioLanguages != "" {
+		args = append(args, "--audio-language="+p.config.Player.AudioLanguages)
+	}
+	if title != "" {
+		args = append(args, "--meta-title="+title)
+	}
+	if p.startPos > 0 {
+		args = append(args, fmt.Sprintf("--start-time=%.0f", p.startPos))
+	}
+	if p.referer != "" {
+		args = append(args, "--http-referrer="+p.referer)
+	}
+	if p.config.Player.Args != "" {
+		args = append(args, ParseArgs(p.config.Player.Args)...)
+	}
+	args = append(args, url)
+
+	return commandParts[0], args
+}
+
+// SetStartPosition sets how many seconds into the media the next playback starts at
+func (p *VLCPlayer) SetStartPosition(seconds float64) {
+	p.startPos = seconds
+}
+
+// SetReferer sets the Referer the next playback sends with its stream requests
+func (p *VLCPlayer) SetReferer(referer string) {
+	p.referer = referer
+}
+
+// Stop stops playback if it's active
+func (p *VLCPlayer) Stop() error {
+	if p.cmd != nil && p.cmd.Process != nil {
+		log.Info("Stopping VLC playback")
+		return p.cmd.Process.Kill()
+	}
+	return nil
+}
+
+// Cleanup performs any necessary cleanup
+func (p *VLCPlayer) Cleanup() {
+	p.Stop()
+}
+
+// freeLocalPort finds a port on localhost nothing is listening on
+func freeLocalPort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// randomPassword generates a password, so other local programs can't control the player
+func randomPassword() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
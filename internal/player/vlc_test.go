@@ -0,0 +1,74 @@
+package player
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVLCStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, password, ok := r.BasicAuth()
+		if !ok || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "/requests/status.json", r.URL.Path)
+		_, _ = w.Write([]byte(`{"state":"playing","time":719,"length":1440,"position":0.5,"volume":256}`))
+	}))
+	defer server.Close()
+
+	p := NewVLCPlayer(&config.Config{})
+	p.statusURL = server.URL + "/requests/status.json"
+	p.password = "secret"
+
+	status, err := p.status(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "playing", status.State)
+	assert.InDelta(t, 720.0, status.seconds(), 0.001, "the position should be used over the rounded down time")
+	assert.InDelta(t, 50.0, vlcProgress(status), 0.001)
+
+	p.password = "wrong"
+	_, err = p.status(context.Background())
+	assert.ErrorContains(t, err, "HTTP 401")
+}
+
+func TestVLCProgressUnknownLength(t *testing.T) {
+	assert.Zero(t, vlcProgress(vlcStatus{State: "playing", Time: 30}))
+}
+
+func TestVLCBuildCommand(t *testing.T) {
+	cfg := &config.Config{Player: config.PlayerConfig{Command: "mpv", Args: "--fullscreen", SubtitleLanguages: "en,eng"}}
+	p := NewVLCPlayer(cfg)
+	p.password = "secret"
+	p.SetStartPosition(90)
+
+	executable, args := p.buildCommand("https://example.com/ep.m3u8", "Ep 1 - Frieren", 8123)
+	assert.Equal(t, "vlc", executable, "the default MPV command should be swapped for VLC")
+	assert.Equal(t, []string{
+		"--extraintf=http",
+		"--http-host=127.0.0.1",
+		"--http-port=8123",
+		"--http-password=secret",
+		"--play-and-exit",
+		"--sub-language=en,eng",
+		"--meta-title=Ep 1 - Frieren",
+		"--start-time=90",
+		"--fullscreen",
+		"https://example.com/ep.m3u8",
+	}, args)
+
+	redacted := redactPassword(args)
+	assert.Contains(t, redacted, "--http-password=[redacted]")
+	assert.NotContains(t, redacted, "--http-password=secret")
+
+	cfg.Player.Command = "flatpak run org.videolan.VLC"
+	executable, args = p.buildCommand("url", "", 8123)
+	assert.Equal(t, "flatpak", executable)
+	assert.Equal(t, []string{"run", "org.videolan.VLC"}, args[:2])
+}
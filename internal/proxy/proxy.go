@@ -0,0 +1,85 @@
+// Package proxy routes Hisame's HTTP requests, and the media players it starts, through a proxy.  Without a configured
+// proxy, the standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are followed.
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+var (
+	mu         sync.Mutex
+	configured *url.URL          // The proxy from the config, nil to follow the environment
+	transport  http.RoundTripper = http.DefaultTransport
+)
+
+// Parse checks a proxy URL from the config.  http, https and socks5 proxies are supported, e.g.
+// "http://proxy.example.com:3128" or "socks5://127.0.0.1:1080".
+func Parse(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL %q: %w", rawURL, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q, expected http, https or socks5", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy URL %q has no host", rawURL)
+	}
+	return u, nil
+}
+
+// Configure sets the proxy requests are sent through.  An empty URL follows the environment variables.
+func Configure(rawURL string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if rawURL == "" {
+		configured = nil
+		transport = http.DefaultTransport
+		return nil
+	}
+
+	u, err := Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.Proxy = http.ProxyURL(u)
+	configured = u
+	transport = base
+
+	log.Info("Sending requests through proxy", "proxy", u.Redacted())
+	return nil
+}
+
+// Transport returns the transport HTTP clients should be built on, which sends requests through the proxy
+func Transport() http.RoundTripper {
+	mu.Lock()
+	defer mu.Unlock()
+	return transport
+}
+
+// Environ returns the environment media players should be started with, so they stream through the proxy too.  It is
+// nil when no proxy is configured, leaving players with Hisame's own environment.
+func Environ() []string {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if configured == nil {
+		return nil
+	}
+	value := configured.String()
+	return append(os.Environ(),
+		"http_proxy="+value, "HTTP_PROXY="+value,
+		"https_proxy="+value, "HTTPS_PROXY="+value,
+		"all_proxy="+value, "ALL_PROXY="+value,
+	)
+}
@@ -3,322 +3,189 @@ package anilist
 import (
 	"context"
 	"fmt"
+	"github.com/PizzaHomicide/hisame/internal/config"
 	"github.com/PizzaHomicide/hisame/internal/domain"
 	"github.com/PizzaHomicide/hisame/internal/log"
+	"strings"
 )
 
 type AnimeRepository struct {
 	client *Client
+	config *config.Config
 }
 
-func NewAnimeRepository(client *Client) domain.AnimeRepository {
+func NewAnimeRepository(client *Client, cfg *config.Config) domain.AnimeRepository {
 	return &AnimeRepository{
 		client: client,
+		config: cfg,
 	}
 }
 
-func (r *AnimeRepository) GetAllAnimeList(ctx context.Context) ([]*domain.Anime, error) {
-	query := `
-        query ($userId: Int) {
-            MediaListCollection(userId: $userId, type: ANIME) {
-                lists {
-                    entries {
-                        media {
-                            id
-                            title {
-                                romaji
-                                english
-                                native
-								userPreferred
-                            }
-                            coverImage {
-                                large
-                            }
-                            episodes
-                            nextAiringEpisode {
-                                episode
-                                airingAt
-                                timeUntilAiring
-                            }
-                            status
-                            format
-                            season
-                            seasonYear
-                            averageScore
-							synonyms
-                        }
-                        status
-                        score
-                        progress
-                        startedAt { year month day }
-                        completedAt { year month day }
-                        notes
-                    }
-                }
-            }
-        }
-    `
-
-	variables := map[string]interface{}{
-		"userId": r.client.user.ID,
-	}
-
-	var response struct {
-		MediaListCollection struct {
-			Lists []struct {
-				Entries []struct {
-					Media struct {
-						ID    int
-						Title struct {
-							Romaji        string
-							English       string
-							Native        string
-							UserPreferred string
-						}
-						CoverImage struct {
-							Large string
-						}
-						Episodes          int
-						NextAiringEpisode *struct {
-							Episode         int
-							AiringAt        int64
-							TimeUntilAiring int64
-						}
-						Status       string
-						Format       string
-						Season       string
-						SeasonYear   int
-						AverageScore float64
-						Synonyms     []string
-					}
-					Status    string
-					Score     float64
-					Progress  int
-					StartedAt struct {
-						Year  int
-						Month int
-						Day   int
-					}
-					CompletedAt struct {
-						Year  int
-						Month int
-						Day   int
-					}
-					Notes string
-				}
-			}
-		}
-	}
+// lowBandwidth returns true if the larger media fields should be skipped, to trim the payload on metered or slow
+// connections
+func (r *AnimeRepository) lowBandwidth() bool {
+	return r.config != nil && r.config.Network.LowBandwidth
+}
 
-	if err := r.client.Query(ctx, query, variables, &response); err != nil {
+func (r *AnimeRepository) GetAllAnimeList(ctx context.Context) ([]*domain.Anime, error) {
+	response, err := getAnimeList(ctx, r.client, r.client.user.ID, r.lowBandwidth())
+	if err != nil {
 		return nil, fmt.Errorf("failed to fetch anime list: %w", err)
 	}
 
 	var animeList []*domain.Anime
 
+	// An entry in a custom list appears in that list as well as its status list, or only in custom lists if it is
+	// hidden from status lists, so entries are deduplicated by media
+	seen := make(map[int]bool)
 	for _, list := range response.MediaListCollection.Lists {
 		for _, entry := range list.Entries {
-			anime := &domain.Anime{
-				ID: entry.Media.ID,
-				Title: domain.AnimeTitle{
-					Romaji:    entry.Media.Title.Romaji,
-					English:   entry.Media.Title.English,
-					Native:    entry.Media.Title.Native,
-					Preferred: entry.Media.Title.UserPreferred,
-				},
-				CoverImage:   entry.Media.CoverImage.Large,
-				Episodes:     entry.Media.Episodes,
-				Status:       entry.Media.Status,
-				Format:       entry.Media.Format,
-				Season:       entry.Media.Season,
-				SeasonYear:   fmt.Sprintf("%d", entry.Media.SeasonYear),
-				AverageScore: entry.Media.AverageScore,
-				Synonyms:     entry.Media.Synonyms,
-				UserData: &domain.UserAnimeData{
-					Status:    domain.MediaStatus(entry.Status),
-					Score:     entry.Score,
-					Progress:  entry.Progress,
-					StartDate: formatDate(entry.StartedAt.Year, entry.StartedAt.Month, entry.StartedAt.Day),
-					EndDate:   formatDate(entry.CompletedAt.Year, entry.CompletedAt.Month, entry.CompletedAt.Day),
-					Notes:     entry.Notes,
-				},
-			}
-
-			if entry.Media.NextAiringEpisode != nil {
-				anime.NextAiringEp = &domain.AiringSchedule{
-					Episode:      entry.Media.NextAiringEpisode.Episode,
-					AiringAt:     entry.Media.NextAiringEpisode.AiringAt,
-					TimeUntilAir: entry.Media.NextAiringEpisode.TimeUntilAiring,
-				}
+			if seen[entry.Media.Id] {
+				continue
 			}
+			seen[entry.Media.Id] = true
 
+			anime := entry.Media.toDomain()
+			anime.UserData = entry.listEntry.toDomain()
 			animeList = append(animeList, anime)
 		}
 	}
 
-	log.Info("Fetched complete anime list", "count", len(animeList))
+	log.Info("Fetched complete anime list", "count", len(animeList), "low_bandwidth", r.lowBandwidth())
 	return animeList, nil
 }
 
-func (r *AnimeRepository) UpdateUserAnimeData(ctx context.Context, id int, data *domain.UserAnimeData) error {
-	mutation := `
-		mutation ($mediaId: Int, $status: MediaListStatus, $score: Float, $progress: Int, $notes: String) {
-			SaveMediaListEntry(
-				mediaId: $mediaId, 
-				status: $status, 
-				score: $score, 
-				progress: $progress,
-				notes: $notes
-			) {
-				id
-				status
-				score
-				progress
-				notes
-			}
+// GetAnimeByID fetches a single media by ID, along with its relations and the user's list entry if there is one
+func (r *AnimeRepository) GetAnimeByID(ctx context.Context, id int) (*domain.Anime, error) {
+	response, err := getAnime(ctx, r.client, id, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch anime %d: %w", id, err)
+	}
+
+	anime := response.Media.toDomain()
+	log.Debug("Fetched anime by ID", "id", anime.ID, "relations", len(anime.Relations))
+	return anime, nil
+}
+
+// SearchAnime searches all of AniList for anime matching the query, best match first.  Each result includes the
+// user's list entry if they have one.  Adult anime are left out unless the user has chosen to see adult content.
+func (r *AnimeRepository) SearchAnime(ctx context.Context, search string) ([]*domain.Anime, error) {
+	var isAdult *bool
+	if !r.client.user.DisplayAdultContent {
+		isAdult = new(bool)
+	}
+
+	response, err := searchAnime(ctx, r.client, search, isAdult, r.lowBandwidth())
+	if err != nil {
+		return nil, fmt.Errorf("failed to search AniList for %q: %w", search, err)
+	}
+
+	results := make([]*domain.Anime, 0, len(response.Page.Media))
+	for _, m := range response.Page.Media {
+		results = append(results, m.toDomain())
+	}
+
+	log.Debug("Searched AniList", "search", search, "results", len(results))
+	return results, nil
+}
+
+// malIDPageSize is how many MyAnimeList IDs are looked up per request, the most AniList returns in a page
+const malIDPageSize = 50
+
+// GetAnimeByMalIDs fetches the anime with the given MyAnimeList IDs, along with the user's list entries.  IDs AniList
+// has no anime for are left out.
+func (r *AnimeRepository) GetAnimeByMalIDs(ctx context.Context, malIDs []int) ([]*domain.Anime, error) {
+	var results []*domain.Anime
+	for start := 0; start < len(malIDs); start += malIDPageSize {
+		ids := malIDs[start:min(start+malIDPageSize, len(malIDs))]
+
+		response, err := getAnimeByMalIDs(ctx, r.client, ids, r.lowBandwidth())
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up anime by MyAnimeList ID: %w", err)
+		}
+		for _, m := range response.Page.Media {
+			results = append(results, m.toDomain())
+		}
+	}
+
+	log.Debug("Looked up anime by MyAnimeList ID", "requested", len(malIDs), "found", len(results))
+	return results, nil
+}
+
+// idPageSize is how many AniList IDs are looked up per request, the most AniList returns in a page
+const idPageSize = 50
+
+// GetAnimeByIDs fetches the anime with the given AniList IDs, along with the user's list entries
+func (r *AnimeRepository) GetAnimeByIDs(ctx context.Context, ids []int) ([]*domain.Anime, error) {
+	var results []*domain.Anime
+	for start := 0; start < len(ids); start += idPageSize {
+		page := ids[start:min(start+idPageSize, len(ids))]
+
+		response, err := getAnimeByIDs(ctx, r.client, page, r.lowBandwidth())
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up anime by ID: %w", err)
+		}
+		for _, m := range response.Page.Media {
+			results = append(results, m.toDomain())
 		}
-	`
-
-	// Convert domain.MediaStatus to string for the GraphQL API
-	variables := map[string]interface{}{
-		"mediaId":  id,
-		"status":   string(data.Status),
-		"score":    data.Score,
-		"progress": data.Progress,
-		"notes":    data.Notes,
 	}
 
-	// For date fields, we would need to parse and convert the format
-	// This can be added if needed for start/end dates
+	log.Debug("Looked up anime by ID", "requested", len(ids), "found", len(results))
+	return results, nil
+}
 
+func (r *AnimeRepository) UpdateUserAnimeData(ctx context.Context, id int, data *domain.UserAnimeData) error {
 	log.Debug("Updating anime data",
 		"mediaId", id,
 		"status", data.Status,
 		"score", data.Score,
 		"progress", data.Progress)
 
-	var response struct {
-		SaveMediaListEntry struct {
-			ID       int     `json:"id"`
-			Status   string  `json:"status"`
-			Score    float64 `json:"score"`
-			Progress int     `json:"progress"`
-			Notes    string  `json:"notes"`
-		}
-	}
-
-	if err := r.client.Query(ctx, mutation, variables, &response); err != nil {
+	status := MediaListStatus(data.Status)
+	response, err := saveListEntry(ctx, r.client, &id, &status, &data.Score, nil, &data.Progress, &data.Notes, nil, nil,
+		nil, nil)
+	if err != nil {
 		log.Error("Failed to update anime data", "error", err, "mediaId", id)
 		return fmt.Errorf("failed to update anime data: %w", err)
 	}
+	if response.SaveMediaListEntry == nil {
+		return fmt.Errorf("failed to update anime data: no entry returned for anime %d", id)
+	}
 
 	log.Info("Successfully updated anime data",
 		"mediaId", id,
-		"listEntryId", response.SaveMediaListEntry.ID,
+		"listEntryId", response.SaveMediaListEntry.Id,
 		"status", response.SaveMediaListEntry.Status,
 		"progress", response.SaveMediaListEntry.Progress)
 
 	return nil
 }
 
-// UpdateAnime provides a structured way to update specific fields of an anime list entry
+// UpdateAnime provides a structured way to update specific fields of an anime list entry.  Only the fields set in
+// params are sent, so the others keep their values.
 func (r *AnimeRepository) UpdateAnime(ctx context.Context, params *domain.AnimeUpdateParams) (*domain.AnimeUpdateResult, error) {
-	mutation := `
-		mutation (
-			$mediaId: Int, 
-			$status: MediaListStatus, 
-			$score: Float, 
-			$progress: Int, 
-			$notes: String,
-			$startedAt: FuzzyDateInput,
-			$completedAt: FuzzyDateInput
-		) {
-			SaveMediaListEntry(
-				mediaId: $mediaId, 
-				status: $status, 
-				score: $score, 
-				progress: $progress,
-				notes: $notes,
-				startedAt: $startedAt,
-				completedAt: $completedAt
-			) {
-				id
-				mediaId
-				status
-				score
-				progress
-				notes
-				updatedAt
-				startedAt {
-					year
-					month
-					day
-				}
-				completedAt {
-					year
-					month
-					day
-				}
-			}
-		}
-	`
-
-	// Convert params to variables map
-	variables := params.ToAnimeUpdateVariables()
-
 	log.Debug("Updating anime data",
 		"mediaId", params.MediaID,
-		"variables", variables)
-
-	var response struct {
-		SaveMediaListEntry struct {
-			ID        int     `json:"id"`
-			MediaID   int     `json:"mediaId"`
-			Status    string  `json:"status"`
-			Score     float64 `json:"score"`
-			Progress  int     `json:"progress"`
-			Notes     string  `json:"notes"`
-			UpdatedAt int     `json:"updatedAt"`
-			StartedAt struct {
-				Year  int `json:"year"`
-				Month int `json:"month"`
-				Day   int `json:"day"`
-			} `json:"startedAt"`
-			CompletedAt struct {
-				Year  int `json:"year"`
-				Month int `json:"month"`
-				Day   int `json:"day"`
-			} `json:"completedAt"`
-		}
-	}
+		"variables", params.ToAnimeUpdateVariables())
 
-	if err := r.client.Query(ctx, mutation, variables, &response); err != nil {
+	var status *MediaListStatus
+	if params.Status != "" {
+		s := MediaListStatus(params.Status)
+		status = &s
+	}
+	response, err := saveListEntry(ctx, r.client, &params.MediaID, status, params.Score, params.ScoreRaw, params.Progress,
+		params.Notes, toFuzzyDateInput(params.StartedAt), toFuzzyDateInput(params.CompletedAt),
+		params.HiddenFromStatusLists, params.Priority)
+	if err != nil {
 		log.Error("Failed to update anime data", "error", err, "mediaId", params.MediaID)
 		return nil, fmt.Errorf("failed to update anime data: %w", err)
 	}
-
-	// Create the result
-	result := &domain.AnimeUpdateResult{
-		EntryID:   response.SaveMediaListEntry.ID,
-		MediaID:   response.SaveMediaListEntry.MediaID,
-		Status:    domain.MediaStatus(response.SaveMediaListEntry.Status),
-		Progress:  response.SaveMediaListEntry.Progress,
-		Score:     response.SaveMediaListEntry.Score,
-		Notes:     response.SaveMediaListEntry.Notes,
-		UpdatedAt: response.SaveMediaListEntry.UpdatedAt,
-	}
-
-	// Check for start date
-	startedAt := response.SaveMediaListEntry.StartedAt
-	if startedAt.Year > 0 {
-		result.StartDate = formatDate(startedAt.Year, startedAt.Month, startedAt.Day)
+	if response.SaveMediaListEntry == nil {
+		return nil, fmt.Errorf("failed to update anime data: no entry returned for anime %d", params.MediaID)
 	}
 
-	// Check for completion date
-	completedAt := response.SaveMediaListEntry.CompletedAt
-	if completedAt.Year > 0 {
-		result.CompletionDate = formatDate(completedAt.Year, completedAt.Month, completedAt.Day)
-	}
+	// Create the result
+	result := response.SaveMediaListEntry.toResult()
 
 	log.Info("Successfully updated anime data",
 		"mediaId", result.MediaID,
@@ -330,18 +197,123 @@ func (r *AnimeRepository) UpdateAnime(ctx context.Context, params *domain.AnimeU
 	return result, nil
 }
 
-func formatDate(year, month, day int) string {
-	if year == 0 {
-		return ""
+// UpdateAnimeBatch saves several list entries in a single request, sending one aliased SaveMediaListEntry per entry.
+// The results are in the same order as params.  If the request fails, none of the results are returned, though AniList
+// may have saved some of the entries.
+func (r *AnimeRepository) UpdateAnimeBatch(ctx context.Context, params []*domain.AnimeUpdateParams) ([]*domain.AnimeUpdateResult, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+
+	mutation, variables := batchUpdateMutation(params)
+	log.Debug("Updating anime data in a batch", "count", len(params))
+
+	var response map[string]savedListEntry
+	if err := r.client.Query(ctx, mutation, variables, &response); err != nil {
+		log.Error("Failed to update anime data in a batch", "error", err, "count", len(params))
+		return nil, fmt.Errorf("failed to update %d entries: %w", len(params), err)
+	}
+
+	results := make([]*domain.AnimeUpdateResult, len(params))
+	for i, p := range params {
+		entry, ok := response[batchEntryAlias(i)]
+		if !ok {
+			return nil, fmt.Errorf("no result returned for anime %d", p.MediaID)
+		}
+		results[i] = entry.toResult()
+	}
+
+	log.Info("Successfully updated anime data in a batch", "count", len(results))
+	return results, nil
+}
+
+// savedListEntryFragment is the definition of the savedListEntry fragment, taken from the generated saveListEntry
+// operation.  A batch has a SaveMediaListEntry per entry, so can't be generated itself, but selecting through the
+// fragment keeps its results decoding into the generated savedListEntry.
+var savedListEntryFragment = saveListEntry_Operation[strings.Index(saveListEntry_Operation, "fragment savedListEntry"):]
+
+// batchEntryAlias is the alias the i'th SaveMediaListEntry in a batch is given, so its result can be found
+func batchEntryAlias(i int) string {
+	return fmt.Sprintf("entry%d", i)
+}
+
+// batchUpdateMutation builds a mutation saving each of the entries, with each entry's variables suffixed by its
+// position in the batch
+func batchUpdateMutation(params []*domain.AnimeUpdateParams) (string, map[string]interface{}) {
+	var declarations, fields []string
+	variables := make(map[string]interface{})
+
+	for i, p := range params {
+		declarations = append(declarations, fmt.Sprintf(
+			"$mediaId%[1]d: Int, $status%[1]d: MediaListStatus, $score%[1]d: Float, $scoreRaw%[1]d: Int, "+
+				"$progress%[1]d: Int, $notes%[1]d: String, $startedAt%[1]d: FuzzyDateInput, $completedAt%[1]d: FuzzyDateInput, "+
+				"$hiddenFromStatusLists%[1]d: Boolean, $priority%[1]d: Int", i))
+		fields = append(fields, fmt.Sprintf(`
+			%[2]s: SaveMediaListEntry(
+				mediaId: $mediaId%[1]d,
+				status: $status%[1]d,
+				score: $score%[1]d,
+				scoreRaw: $scoreRaw%[1]d,
+				progress: $progress%[1]d,
+				notes: $notes%[1]d,
+				startedAt: $startedAt%[1]d,
+				completedAt: $completedAt%[1]d,
+				hiddenFromStatusLists: $hiddenFromStatusLists%[1]d,
+				priority: $priority%[1]d
+			) {
+				...savedListEntry
+			}`, i, batchEntryAlias(i)))
+
+		for name, value := range p.ToAnimeUpdateVariables() {
+			variables[fmt.Sprintf("%s%d", name, i)] = value
+		}
+	}
+
+	mutation := fmt.Sprintf("mutation (%s) {%s\n}\n%s", strings.Join(declarations, ", "), strings.Join(fields, ""),
+		savedListEntryFragment)
+	return mutation, variables
+}
+
+// PostTextActivity posts a text activity to the authenticated user's feed
+func (r *AnimeRepository) PostTextActivity(ctx context.Context, text string) error {
+	response, err := saveTextActivity(ctx, r.client, text)
+	if err != nil {
+		log.Error("Failed to post text activity", "error", err)
+		return fmt.Errorf("failed to post text activity: %w", err)
 	}
 
-	if month == 0 {
-		return fmt.Sprintf("%d", year)
+	log.Info("Posted text activity", "activityId", response.SaveTextActivity.Id)
+	return nil
+}
+
+// GetStreamingEpisodes fetches the episodes AniList lists from official streaming sites
+func (r *AnimeRepository) GetStreamingEpisodes(ctx context.Context, id int) ([]domain.StreamingEpisode, error) {
+	response, err := getStreamingEpisodes(ctx, r.client, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch streaming episodes: %w", err)
+	}
+
+	episodes := make([]domain.StreamingEpisode, 0, len(response.Media.StreamingEpisodes))
+	for _, ep := range response.Media.StreamingEpisodes {
+		episodes = append(episodes, domain.StreamingEpisode{Title: ep.Title, Site: ep.Site})
+	}
+
+	log.Debug("Fetched streaming episodes", "id", id, "count", len(episodes))
+	return episodes, nil
+}
+
+// GetAiringSchedule fetches the episodes of an anime that have yet to air, in broadcast order
+func (r *AnimeRepository) GetAiringSchedule(ctx context.Context, id int) ([]domain.AiringSchedule, error) {
+	response, err := getAiringSchedule(ctx, r.client, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch airing schedule: %w", err)
 	}
 
-	if day == 0 {
-		return fmt.Sprintf("%d-%02d", year, month)
+	schedule := make([]domain.AiringSchedule, 0, len(response.Media.AiringSchedule.Nodes))
+	for _, node := range response.Media.AiringSchedule.Nodes {
+		schedule = append(schedule, node.toDomain())
 	}
 
-	return fmt.Sprintf("%d-%02d-%02d", year, month, day)
+	log.Debug("Fetched airing schedule", "id", id, "count", len(schedule))
+	return schedule, nil
 }
@@ -0,0 +1,114 @@
+package anilist
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/diagnostics"
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/machinebox/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchUpdateMutation(t *testing.T) {
+	progress, notes := 5, "rewatch"
+	mutation, variables := batchUpdateMutation([]*domain.AnimeUpdateParams{
+		{MediaID: 1, Progress: &progress},
+		{MediaID: 2, Status: "COMPLETED", Notes: &notes},
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"mediaId0":  1,
+		"progress0": 5,
+		"mediaId1":  2,
+		"status1":   "COMPLETED",
+		"notes1":    "rewatch",
+	}, variables)
+
+	assert.True(t, strings.HasPrefix(mutation, "mutation ($mediaId0: Int,"))
+	assert.Contains(t, mutation, "entry0: SaveMediaListEntry(")
+	assert.Contains(t, mutation, "entry1: SaveMediaListEntry(")
+	assert.Contains(t, mutation, "progress: $progress1,")
+	assert.Contains(t, mutation, "fragment savedListEntry on MediaList", "results should decode into the generated type")
+	assert.Equal(t, "SaveMediaListEntry", diagnostics.OperationName(mutation))
+}
+
+// testRepository returns a repository whose client sends its requests to handle
+func testRepository(t *testing.T, handle func(query string, variables map[string]interface{}) string) *AnimeRepository {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		_, _ = w.Write([]byte(handle(body.Query, body.Variables)))
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewAnonymousClient(config.RetryConfig{})
+	c.client = graphql.NewClient(server.URL)
+	return &AnimeRepository{client: c, config: &config.Config{}}
+}
+
+func TestGetAllAnimeList(t *testing.T) {
+	repo := testRepository(t, func(query string, variables map[string]interface{}) string {
+		assert.Contains(t, query, "query getAnimeList")
+		assert.Equal(t, false, variables["lowBandwidth"])
+		return `{"data": {"MediaListCollection": {"lists": [
+			{"entries": [{
+				"status": "CURRENT", "score": 8.5, "score10": 9, "progress": 3,
+				"startedAt": {"year": 2024, "month": 4, "day": null},
+				"completedAt": {"year": null, "month": null, "day": null}, "updatedAt": 1714560000,
+				"media": {
+					"id": 154587, "idMal": 52991, "title": {"userPreferred": "Sousou no Frieren"}, "episodes": 28,
+					"nextAiringEpisode": {"episode": 4, "airingAt": 1714600000, "timeUntilAiring": 40000},
+					"status": "RELEASING", "format": "TV", "seasonYear": 2023, "averageScore": 91,
+					"tags": [{"name": "Elf", "rank": 95, "isMediaSpoiler": false}]
+				}
+			}]},
+			{"entries": [{"status": "CURRENT", "media": {"id": 154587}}]}
+		]}}}`
+	})
+
+	list, err := repo.GetAllAnimeList(context.Background())
+	require.NoError(t, err)
+	require.Len(t, list, 1, "entries in custom lists as well as status lists should only appear once")
+
+	anime := list[0]
+	assert.Equal(t, 154587, anime.ID)
+	assert.Equal(t, "Sousou no Frieren", anime.Title.Preferred)
+	assert.Equal(t, "RELEASING", anime.Status)
+	assert.Equal(t, float64(91), anime.AverageScore)
+	assert.Equal(t, &domain.AiringSchedule{Episode: 4, AiringAt: 1714600000, TimeUntilAir: 40000}, anime.NextAiringEp)
+	assert.Equal(t, []domain.AnimeTag{{Name: "Elf", Rank: 95}}, anime.Tags)
+	assert.Equal(t, &domain.UserAnimeData{
+		Status: domain.StatusCurrent, Score: 8.5, Score10: 9, Progress: 3, StartDate: "2024-04", UpdatedAt: 1714560000,
+	}, anime.UserData)
+}
+
+func TestUpdateAnimeOnlySendsSetFields(t *testing.T) {
+	repo := testRepository(t, func(query string, variables map[string]interface{}) string {
+		assert.Contains(t, query, "mutation saveListEntry")
+		assert.Equal(t, map[string]interface{}{
+			"mediaId":     float64(1),
+			"progress":    float64(4),
+			"completedAt": map[string]interface{}{"year": nil, "month": nil, "day": nil},
+		}, variables, "fields that aren't being changed shouldn't be sent, and an empty date should clear the date")
+		return `{"data": {"SaveMediaListEntry": {"id": 10, "mediaId": 1, "status": "CURRENT", "progress": 4}}}`
+	})
+
+	progress := 4
+	result, err := repo.UpdateAnime(context.Background(), &domain.AnimeUpdateParams{
+		MediaID:     1,
+		Progress:    &progress,
+		CompletedAt: &domain.FuzzyDate{},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &domain.AnimeUpdateResult{EntryID: 10, MediaID: 1, Status: domain.StatusCurrent, Progress: 4}, result)
+}
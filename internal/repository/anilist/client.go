@@ -2,37 +2,57 @@ package anilist
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	genqlient "github.com/Khan/genqlient/graphql"
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/diagnostics"
 	"github.com/PizzaHomicide/hisame/internal/domain"
 	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/proxy"
+	"github.com/PizzaHomicide/hisame/internal/retry"
 	"github.com/machinebox/graphql"
+	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
 // Client is the generic AniList client for making queries to the AniList graphql API
 type Client struct {
-	client    *graphql.Client
-	authToken string
-	user      domain.User
+	client     *graphql.Client
+	rateLimits *rateLimitTransport
+	retry      retry.Policy
+	user       domain.User
+
+	tokenMu      sync.RWMutex
+	authToken    string
+	unauthorized chan struct{} // Receives when AniList rejects the token, e.g. because it has expired
 }
 
+// ErrUnauthorized is returned when AniList rejects the login token, usually because it has expired or been revoked
+var ErrUnauthorized = errors.New("AniList rejected the login token")
+
 func (c *Client) GetUser() domain.User {
 	return c.user
 }
 
-func NewClient(authToken string) (*Client, error) {
+func NewClient(authToken string, retryConfig config.RetryConfig) (*Client, error) {
 	if authToken == "" {
 		log.Error("AniList Client authToken is empty.")
 		return nil, fmt.Errorf("AniList Client authToken is empty")
 	}
 
-	client := graphql.NewClient("https://graphql.anilist.co")
+	rateLimits := newRateLimitTransport(retry.NewTransport(proxy.Transport()))
+	client := graphql.NewClient("https://graphql.anilist.co", graphql.WithHTTPClient(&http.Client{Transport: rateLimits}))
 	c := &Client{
-		client:    client,
-		authToken: authToken,
+		client:       client,
+		rateLimits:   rateLimits,
+		retry:        retry.FromConfig(retryConfig),
+		authToken:    authToken,
+		unauthorized: make(chan struct{}, 1),
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
@@ -47,18 +67,110 @@ func NewClient(authToken string) (*Client, error) {
 	return c, nil
 }
 
+// NewAnonymousClient creates a client that isn't logged in to any account.  It can look up and search anime, but has no
+// user or list of its own.
+func NewAnonymousClient(retryConfig config.RetryConfig) *Client {
+	rateLimits := newRateLimitTransport(retry.NewTransport(proxy.Transport()))
+	client := graphql.NewClient("https://graphql.anilist.co", graphql.WithHTTPClient(&http.Client{Transport: rateLimits}))
+	return &Client{
+		client:       client,
+		rateLimits:   rateLimits,
+		retry:        retry.FromConfig(retryConfig),
+		unauthorized: make(chan struct{}, 1),
+	}
+}
+
+// Unauthorized returns a channel that receives whenever AniList rejects the client's token, so the user can be asked
+// to log in again
+func (c *Client) Unauthorized() <-chan struct{} {
+	return c.unauthorized
+}
+
+// SetToken replaces the token requests are sent with, after the user has logged in again
+func (c *Client) SetToken(authToken string) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	c.authToken = authToken
+}
+
+// token returns the token requests are sent with
+func (c *Client) token() string {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.authToken
+}
+
+// isUnauthorized reports whether err is AniList rejecting the token.  AniList reports this in the GraphQL errors
+// rather than only the status code, which the graphql client doesn't expose.
+func isUnauthorized(err error) bool {
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "invalid token") || strings.Contains(message, "unauthorized")
+}
+
+// RateLimited returns a channel that receives how long requests are being held back for whenever AniList's rate
+// limit delays them
+func (c *Client) RateLimited() <-chan time.Duration {
+	return c.rateLimits.RateLimited()
+}
+
+// RateLimitStatus returns the state of AniList's rate limit as of the last response
+func (c *Client) RateLimitStatus() RateLimitStatus {
+	return c.rateLimits.Status(time.Now())
+}
+
+// Query runs a query or mutation against AniList.  Queries that fail with a timeout or server error are retried
+// following the retry policy.  Mutations are sent once, as some (such as posting an activity) aren't safe to repeat.
 func (c *Client) Query(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
 	req := graphql.NewRequest(query)
 
-	if c.authToken != "" {
-		req.Header.Set("Authorization", "Bearer "+c.authToken)
+	token := c.token()
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
 	}
 
 	for key, value := range variables {
 		req.Var(key, value)
 	}
 
-	return c.client.Run(ctx, req, result)
+	operation := diagnostics.OperationName(query)
+	run := func() error {
+		start := time.Now()
+		err := c.client.Run(ctx, req, result)
+		diagnostics.TrackAPICall(diagnostics.APIAniList, operation, start, err)
+		return err
+	}
+
+	var err error
+	if strings.HasPrefix(strings.TrimSpace(query), "mutation") {
+		err = run()
+	} else {
+		err = c.retry.Do(ctx, operation, run)
+	}
+	if err != nil && token != "" && isUnauthorized(err) {
+		log.Warn("AniList rejected the login token", "operation", operation, "error", err)
+		select {
+		case c.unauthorized <- struct{}{}:
+		default:
+		}
+		return fmt.Errorf("%w: %v", ErrUnauthorized, err)
+	}
+	return err
+}
+
+// MakeRequest runs a request made by the generated client in generated.go through Query, so generated operations are
+// retried, rate limited and report a rejected token in the same way.  It implements genqlient's graphql.Client.
+func (c *Client) MakeRequest(ctx context.Context, req *genqlient.Request, resp *genqlient.Response) error {
+	var variables map[string]interface{}
+	if req.Variables != nil {
+		encoded, err := json.Marshal(req.Variables)
+		if err != nil {
+			return fmt.Errorf("failed to encode variables for %s: %w", req.OpName, err)
+		}
+		if err := json.Unmarshal(encoded, &variables); err != nil {
+			return fmt.Errorf("failed to encode variables for %s: %w", req.OpName, err)
+		}
+	}
+	return c.Query(ctx, req.Query, variables, resp.Data)
 }
 
 type NetworkError struct {
@@ -75,53 +187,8 @@ func (e NetworkError) Unwrap() error {
 
 // Update the fetchUserProfile method to detect network errors
 func (c *Client) fetchUserProfile(ctx context.Context) (*domain.User, error) {
-	query := `
-        query {
-            Viewer {
-                id
-                name
-                avatar {
-                    medium
-                }
-                siteUrl
-                statistics {
-                    anime {
-                        count
-                        episodesWatched
-                    }
-                    manga {
-                        count
-                        chaptersRead
-                    }
-                }
-                options {
-                    titleLanguage
-                    displayAdultContent
-                }
-            }
-        }
-    `
-
-	var response struct {
-		Viewer struct {
-			ID         int
-			Name       string
-			Avatar     struct{ Medium string }
-			SiteUrl    string
-			Statistics struct {
-				Anime struct {
-					Count           int
-					EpisodesWatched int `json:"episodesWatched"`
-				}
-				Manga struct {
-					Count        int
-					ChaptersRead int `json:"chaptersRead"`
-				}
-			}
-		}
-	}
-
-	if err := c.Query(ctx, query, nil, &response); err != nil {
+	response, err := getViewer(ctx, c)
+	if err != nil {
 		// Check if this is a network error
 		var netErr *url.Error
 		if errors.As(err, &netErr) && (netErr.Timeout() || netErr.Temporary() ||
@@ -133,22 +200,11 @@ func (c *Client) fetchUserProfile(ctx context.Context) (*domain.User, error) {
 		return nil, fmt.Errorf("failed to fetch user profile: %w", err)
 	}
 
-	if response.Viewer.ID == 0 {
+	if response.Viewer.Id == 0 {
 		return nil, fmt.Errorf("invalid or unauthorized token")
 	}
 
-	log.Info("Fetched user profile", "id", response.Viewer.ID)
-
-	return &domain.User{
-		ID:      response.Viewer.ID,
-		Name:    response.Viewer.Name,
-		Avatar:  response.Viewer.Avatar.Medium,
-		SiteURL: response.Viewer.SiteUrl,
-		Statistics: domain.UserStatistics{
-			AnimeCount:      response.Viewer.Statistics.Anime.Count,
-			MangaCount:      response.Viewer.Statistics.Manga.Count,
-			EpisodesWatched: response.Viewer.Statistics.Anime.EpisodesWatched,
-			ChaptersRead:    response.Viewer.Statistics.Manga.ChaptersRead,
-		},
-	}, nil
+	log.Info("Fetched user profile", "id", response.Viewer.Id)
+
+	return response.Viewer.toDomain(), nil
 }
@@ -0,0 +1,51 @@
+package anilist
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/machinebox/graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryReportsRejectedToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer expired", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"data":null,"errors":[{"message":"Invalid token","status":400}]}`))
+	}))
+	defer server.Close()
+
+	c := NewAnonymousClient(config.RetryConfig{})
+	c.client = graphql.NewClient(server.URL)
+	c.SetToken("expired")
+
+	var result struct{}
+	err := c.Query(context.Background(), "query { Viewer { id } }", nil, &result)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	select {
+	case <-c.Unauthorized():
+	default:
+		t.Error("expected the rejected token to be reported")
+	}
+}
+
+func TestQueryWithoutTokenIsNotReportedAsRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"data":null,"errors":[{"message":"Unauthorized.","status":401}]}`))
+	}))
+	defer server.Close()
+
+	c := NewAnonymousClient(config.RetryConfig{})
+	c.client = graphql.NewClient(server.URL)
+
+	var result struct{}
+	err := c.Query(context.Background(), "mutation { SaveTextActivity(text: \"hi\") { id } }", nil, &result)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrUnauthorized)
+	assert.Empty(t, c.Unauthorized())
+}
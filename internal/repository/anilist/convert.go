@@ -0,0 +1,173 @@
+package anilist
+
+// convert.go converts the types genqlient generates from queries.graphql into domain types.  After changing a query,
+// regenerate generated.go with `go generate ./internal/repository/anilist`.
+
+//go:generate go run github.com/Khan/genqlient
+
+import (
+	"fmt"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+)
+
+// toDomain converts the media to a domain anime.  The user's list entry, if selected, is added by the caller.
+func (m *mediaFields) toDomain() *domain.Anime {
+	anime := &domain.Anime{
+		ID:    m.Id,
+		IDMal: m.IdMal,
+		Title: domain.AnimeTitle{
+			Romaji:    m.Title.Romaji,
+			English:   m.Title.English,
+			Native:    m.Title.Native,
+			Preferred: m.Title.UserPreferred,
+		},
+		CoverImage:   m.CoverImage.Large,
+		Episodes:     m.Episodes,
+		Duration:     m.Duration,
+		Status:       string(m.Status),
+		Format:       string(m.Format),
+		Season:       string(m.Season),
+		SeasonYear:   fmt.Sprintf("%d", m.SeasonYear),
+		AverageScore: float64(m.AverageScore),
+		IsAdult:      m.IsAdult,
+		Country:      m.CountryOfOrigin,
+		Synonyms:     m.Synonyms,
+		Genres:       m.Genres,
+		Tags:         toDomainTags(m.Tags),
+	}
+
+	if m.NextAiringEpisode != nil {
+		schedule := m.NextAiringEpisode.toDomain()
+		anime.NextAiringEp = &schedule
+	}
+	return anime
+}
+
+// toDomain converts the media to a domain anime, including the user's list entry if they have one
+func (m *mediaWithEntry) toDomain() *domain.Anime {
+	anime := m.mediaFields.toDomain()
+	if m.MediaListEntry != nil {
+		anime.UserData = m.MediaListEntry.toDomain()
+	}
+	return anime
+}
+
+// toDomain converts the media to a domain anime, including its relations and the user's list entry if they have one
+func (m *getAnimeMedia) toDomain() *domain.Anime {
+	anime := m.mediaWithEntry.toDomain()
+	for _, edge := range m.Relations.Edges {
+		anime.Relations = append(anime.Relations, domain.AnimeRelation{
+			Type:      domain.RelationType(edge.RelationType),
+			AnimeID:   edge.Node.Id,
+			MediaType: string(edge.Node.Type),
+		})
+	}
+	return anime
+}
+
+// toDomain converts the list entry to the user's data for an anime
+func (e *listEntry) toDomain() *domain.UserAnimeData {
+	return &domain.UserAnimeData{
+		Status:    domain.MediaStatus(e.Status),
+		Score:     e.Score,
+		Score10:   int(e.Score10),
+		Progress:  e.Progress,
+		StartDate: formatDate(e.StartedAt.Year, e.StartedAt.Month, e.StartedAt.Day),
+		EndDate:   formatDate(e.CompletedAt.Year, e.CompletedAt.Month, e.CompletedAt.Day),
+		Notes:     e.Notes,
+		UpdatedAt: int64(e.UpdatedAt),
+
+		HiddenFromStatusLists: e.HiddenFromStatusLists,
+		Priority:              e.Priority,
+	}
+}
+
+// toResult converts the saved entry to the domain update result
+func (e *savedListEntry) toResult() *domain.AnimeUpdateResult {
+	return &domain.AnimeUpdateResult{
+		EntryID:        e.Id,
+		MediaID:        e.MediaId,
+		Status:         domain.MediaStatus(e.Status),
+		Progress:       e.Progress,
+		Score:          e.Score,
+		Score10:        int(e.Score10),
+		Notes:          e.Notes,
+		UpdatedAt:      e.UpdatedAt,
+		StartDate:      formatDate(e.StartedAt.Year, e.StartedAt.Month, e.StartedAt.Day),
+		CompletionDate: formatDate(e.CompletedAt.Year, e.CompletedAt.Month, e.CompletedAt.Day),
+
+		HiddenFromStatusLists: e.HiddenFromStatusLists,
+		Priority:              e.Priority,
+	}
+}
+
+// toDomain converts the viewer to the domain user
+func (v *viewer) toDomain() *domain.User {
+	return &domain.User{
+		ID:      v.Id,
+		Name:    v.Name,
+		Avatar:  v.Avatar.Medium,
+		SiteURL: v.SiteUrl,
+		Statistics: domain.UserStatistics{
+			AnimeCount:      v.Statistics.Anime.Count,
+			MangaCount:      v.Statistics.Manga.Count,
+			EpisodesWatched: v.Statistics.Anime.EpisodesWatched,
+			ChaptersRead:    v.Statistics.Manga.ChaptersRead,
+		},
+		DisplayAdultContent: v.Options.DisplayAdultContent,
+		ScoreFormat:         domain.ScoreFormat(v.MediaListOptions.ScoreFormat),
+	}
+}
+
+// toDomain converts the airing time to the domain airing schedule
+func (s *airingSchedule) toDomain() domain.AiringSchedule {
+	return domain.AiringSchedule{
+		Episode:      s.Episode,
+		AiringAt:     int64(s.AiringAt),
+		TimeUntilAir: int64(s.TimeUntilAiring),
+	}
+}
+
+// toFuzzyDateInput converts a date to be saved to a list entry.  Missing parts are sent as null, so a date with no
+// parts clears the entry's date.
+func toFuzzyDateInput(date *domain.FuzzyDate) *FuzzyDateInput {
+	if date == nil {
+		return nil
+	}
+	part := func(value int) *int {
+		if value <= 0 {
+			return nil
+		}
+		return &value
+	}
+	return &FuzzyDateInput{Year: part(date.Year), Month: part(date.Month), Day: part(date.Day)}
+}
+
+func toDomainTags(tags []mediaFieldsTagsMediaTag) []domain.AnimeTag {
+	result := make([]domain.AnimeTag, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, domain.AnimeTag{
+			Name:      tag.Name,
+			Rank:      tag.Rank,
+			IsSpoiler: tag.IsMediaSpoiler,
+		})
+	}
+	return result
+}
+
+func formatDate(year, month, day int) string {
+	if year == 0 {
+		return ""
+	}
+
+	if month == 0 {
+		return fmt.Sprintf("%d", year)
+	}
+
+	if day == 0 {
+		return fmt.Sprintf("%d-%02d", year, month)
+	}
+
+	return fmt.Sprintf("%d-%02d-%02d", year, month, day)
+}
@@ -0,0 +1,2165 @@
+// Code generated by github.com/Khan/genqlient, DO NOT EDIT.
+
+package anilist
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Khan/genqlient/graphql"
+)
+
+type FuzzyDateInput struct {
+	Year  *int `json:"year"`
+	Month *int `json:"month"`
+	Day   *int `json:"day"`
+}
+
+// GetYear returns FuzzyDateInput.Year, and is useful for accessing the field via an interface.
+func (v *FuzzyDateInput) GetYear() *int { return v.Year }
+
+// GetMonth returns FuzzyDateInput.Month, and is useful for accessing the field via an interface.
+func (v *FuzzyDateInput) GetMonth() *int { return v.Month }
+
+// GetDay returns FuzzyDateInput.Day, and is useful for accessing the field via an interface.
+func (v *FuzzyDateInput) GetDay() *int { return v.Day }
+
+type MediaFormat string
+
+const (
+	MediaFormatTv      MediaFormat = "TV"
+	MediaFormatTvShort MediaFormat = "TV_SHORT"
+	MediaFormatMovie   MediaFormat = "MOVIE"
+	MediaFormatSpecial MediaFormat = "SPECIAL"
+	MediaFormatOva     MediaFormat = "OVA"
+	MediaFormatOna     MediaFormat = "ONA"
+	MediaFormatMusic   MediaFormat = "MUSIC"
+	MediaFormatManga   MediaFormat = "MANGA"
+	MediaFormatNovel   MediaFormat = "NOVEL"
+	MediaFormatOneShot MediaFormat = "ONE_SHOT"
+)
+
+var AllMediaFormat = []MediaFormat{
+	MediaFormatTv,
+	MediaFormatTvShort,
+	MediaFormatMovie,
+	MediaFormatSpecial,
+	MediaFormatOva,
+	MediaFormatOna,
+	MediaFormatMusic,
+	MediaFormatManga,
+	MediaFormatNovel,
+	MediaFormatOneShot,
+}
+
+type MediaListStatus string
+
+const (
+	MediaListStatusCurrent   MediaListStatus = "CURRENT"
+	MediaListStatusPlanning  MediaListStatus = "PLANNING"
+	MediaListStatusCompleted MediaListStatus = "COMPLETED"
+	MediaListStatusDropped   MediaListStatus = "DROPPED"
+	MediaListStatusPaused    MediaListStatus = "PAUSED"
+	MediaListStatusRepeating MediaListStatus = "REPEATING"
+)
+
+var AllMediaListStatus = []MediaListStatus{
+	MediaListStatusCurrent,
+	MediaListStatusPlanning,
+	MediaListStatusCompleted,
+	MediaListStatusDropped,
+	MediaListStatusPaused,
+	MediaListStatusRepeating,
+}
+
+type MediaRelation string
+
+const (
+	MediaRelationAdaptation  MediaRelation = "ADAPTATION"
+	MediaRelationPrequel     MediaRelation = "PREQUEL"
+	MediaRelationSequel      MediaRelation = "SEQUEL"
+	MediaRelationParent      MediaRelation = "PARENT"
+	MediaRelationSideStory   MediaRelation = "SIDE_STORY"
+	MediaRelationCharacter   MediaRelation = "CHARACTER"
+	MediaRelationSummary     MediaRelation = "SUMMARY"
+	MediaRelationAlternative MediaRelation = "ALTERNATIVE"
+	MediaRelationSpinOff     MediaRelation = "SPIN_OFF"
+	MediaRelationOther       MediaRelation = "OTHER"
+	MediaRelationSource      MediaRelation = "SOURCE"
+	MediaRelationCompilation MediaRelation = "COMPILATION"
+	MediaRelationContains    MediaRelation = "CONTAINS"
+)
+
+var AllMediaRelation = []MediaRelation{
+	MediaRelationAdaptation,
+	MediaRelationPrequel,
+	MediaRelationSequel,
+	MediaRelationParent,
+	MediaRelationSideStory,
+	MediaRelationCharacter,
+	MediaRelationSummary,
+	MediaRelationAlternative,
+	MediaRelationSpinOff,
+	MediaRelationOther,
+	MediaRelationSource,
+	MediaRelationCompilation,
+	MediaRelationContains,
+}
+
+type MediaSeason string
+
+const (
+	MediaSeasonWinter MediaSeason = "WINTER"
+	MediaSeasonSpring MediaSeason = "SPRING"
+	MediaSeasonSummer MediaSeason = "SUMMER"
+	MediaSeasonFall   MediaSeason = "FALL"
+)
+
+var AllMediaSeason = []MediaSeason{
+	MediaSeasonWinter,
+	MediaSeasonSpring,
+	MediaSeasonSummer,
+	MediaSeasonFall,
+}
+
+type MediaStatus string
+
+const (
+	MediaStatusFinished       MediaStatus = "FINISHED"
+	MediaStatusReleasing      MediaStatus = "RELEASING"
+	MediaStatusNotYetReleased MediaStatus = "NOT_YET_RELEASED"
+	MediaStatusCancelled      MediaStatus = "CANCELLED"
+	MediaStatusHiatus         MediaStatus = "HIATUS"
+)
+
+var AllMediaStatus = []MediaStatus{
+	MediaStatusFinished,
+	MediaStatusReleasing,
+	MediaStatusNotYetReleased,
+	MediaStatusCancelled,
+	MediaStatusHiatus,
+}
+
+type MediaType string
+
+const (
+	MediaTypeAnime MediaType = "ANIME"
+	MediaTypeManga MediaType = "MANGA"
+)
+
+var AllMediaType = []MediaType{
+	MediaTypeAnime,
+	MediaTypeManga,
+}
+
+type ScoreFormat string
+
+const (
+	ScoreFormatPoint100       ScoreFormat = "POINT_100"
+	ScoreFormatPoint10Decimal ScoreFormat = "POINT_10_DECIMAL"
+	ScoreFormatPoint10        ScoreFormat = "POINT_10"
+	ScoreFormatPoint5         ScoreFormat = "POINT_5"
+	ScoreFormatPoint3         ScoreFormat = "POINT_3"
+)
+
+var AllScoreFormat = []ScoreFormat{
+	ScoreFormatPoint100,
+	ScoreFormatPoint10Decimal,
+	ScoreFormatPoint10,
+	ScoreFormatPoint5,
+	ScoreFormatPoint3,
+}
+
+type UserTitleLanguage string
+
+const (
+	UserTitleLanguageRomaji          UserTitleLanguage = "ROMAJI"
+	UserTitleLanguageEnglish         UserTitleLanguage = "ENGLISH"
+	UserTitleLanguageNative          UserTitleLanguage = "NATIVE"
+	UserTitleLanguageRomajiStylised  UserTitleLanguage = "ROMAJI_STYLISED"
+	UserTitleLanguageEnglishStylised UserTitleLanguage = "ENGLISH_STYLISED"
+	UserTitleLanguageNativeStylised  UserTitleLanguage = "NATIVE_STYLISED"
+)
+
+var AllUserTitleLanguage = []UserTitleLanguage{
+	UserTitleLanguageRomaji,
+	UserTitleLanguageEnglish,
+	UserTitleLanguageNative,
+	UserTitleLanguageRomajiStylised,
+	UserTitleLanguageEnglishStylised,
+	UserTitleLanguageNativeStylised,
+}
+
+// __getAiringScheduleInput is used internally by genqlient
+type __getAiringScheduleInput struct {
+	Id int `json:"id"`
+}
+
+// GetId returns __getAiringScheduleInput.Id, and is useful for accessing the field via an interface.
+func (v *__getAiringScheduleInput) GetId() int { return v.Id }
+
+// __getAnimeByIDsInput is used internally by genqlient
+type __getAnimeByIDsInput struct {
+	Ids          []int `json:"ids"`
+	LowBandwidth bool  `json:"lowBandwidth"`
+}
+
+// GetIds returns __getAnimeByIDsInput.Ids, and is useful for accessing the field via an interface.
+func (v *__getAnimeByIDsInput) GetIds() []int { return v.Ids }
+
+// GetLowBandwidth returns __getAnimeByIDsInput.LowBandwidth, and is useful for accessing the field via an interface.
+func (v *__getAnimeByIDsInput) GetLowBandwidth() bool { return v.LowBandwidth }
+
+// __getAnimeByMalIDsInput is used internally by genqlient
+type __getAnimeByMalIDsInput struct {
+	Ids          []int `json:"ids"`
+	LowBandwidth bool  `json:"lowBandwidth"`
+}
+
+// GetIds returns __getAnimeByMalIDsInput.Ids, and is useful for accessing the field via an interface.
+func (v *__getAnimeByMalIDsInput) GetIds() []int { return v.Ids }
+
+// GetLowBandwidth returns __getAnimeByMalIDsInput.LowBandwidth, and is useful for accessing the field via an interface.
+func (v *__getAnimeByMalIDsInput) GetLowBandwidth() bool { return v.LowBandwidth }
+
+// __getAnimeInput is used internally by genqlient
+type __getAnimeInput struct {
+	Id           int  `json:"id"`
+	LowBandwidth bool `json:"lowBandwidth"`
+}
+
+// GetId returns __getAnimeInput.Id, and is useful for accessing the field via an interface.
+func (v *__getAnimeInput) GetId() int { return v.Id }
+
+// GetLowBandwidth returns __getAnimeInput.LowBandwidth, and is useful for accessing the field via an interface.
+func (v *__getAnimeInput) GetLowBandwidth() bool { return v.LowBandwidth }
+
+// __getAnimeListInput is used internally by genqlient
+type __getAnimeListInput struct {
+	UserId       int  `json:"userId"`
+	LowBandwidth bool `json:"lowBandwidth"`
+}
+
+// GetUserId returns __getAnimeListInput.UserId, and is useful for accessing the field via an interface.
+func (v *__getAnimeListInput) GetUserId() int { return v.UserId }
+
+// GetLowBandwidth returns __getAnimeListInput.LowBandwidth, and is useful for accessing the field via an interface.
+func (v *__getAnimeListInput) GetLowBandwidth() bool { return v.LowBandwidth }
+
+// __getStreamingEpisodesInput is used internally by genqlient
+type __getStreamingEpisodesInput struct {
+	Id int `json:"id"`
+}
+
+// GetId returns __getStreamingEpisodesInput.Id, and is useful for accessing the field via an interface.
+func (v *__getStreamingEpisodesInput) GetId() int { return v.Id }
+
+// __saveListEntryInput is used internally by genqlient
+type __saveListEntryInput struct {
+	MediaId               *int             `json:"mediaId,omitempty"`
+	Status                *MediaListStatus `json:"status,omitempty"`
+	Score                 *float64         `json:"score,omitempty"`
+	ScoreRaw              *int             `json:"scoreRaw,omitempty"`
+	Progress              *int             `json:"progress,omitempty"`
+	Notes                 *string          `json:"notes,omitempty"`
+	StartedAt             *FuzzyDateInput  `json:"startedAt,omitempty"`
+	CompletedAt           *FuzzyDateInput  `json:"completedAt,omitempty"`
+	HiddenFromStatusLists *bool            `json:"hiddenFromStatusLists,omitempty"`
+	Priority              *int             `json:"priority,omitempty"`
+}
+
+// GetMediaId returns __saveListEntryInput.MediaId, and is useful for accessing the field via an interface.
+func (v *__saveListEntryInput) GetMediaId() *int { return v.MediaId }
+
+// GetStatus returns __saveListEntryInput.Status, and is useful for accessing the field via an interface.
+func (v *__saveListEntryInput) GetStatus() *MediaListStatus { return v.Status }
+
+// GetScore returns __saveListEntryInput.Score, and is useful for accessing the field via an interface.
+func (v *__saveListEntryInput) GetScore() *float64 { return v.Score }
+
+// GetScoreRaw returns __saveListEntryInput.ScoreRaw, and is useful for accessing the field via an interface.
+func (v *__saveListEntryInput) GetScoreRaw() *int { return v.ScoreRaw }
+
+// GetProgress returns __saveListEntryInput.Progress, and is useful for accessing the field via an interface.
+func (v *__saveListEntryInput) GetProgress() *int { return v.Progress }
+
+// GetNotes returns __saveListEntryInput.Notes, and is useful for accessing the field via an interface.
+func (v *__saveListEntryInput) GetNotes() *string { return v.Notes }
+
+// GetStartedAt returns __saveListEntryInput.StartedAt, and is useful for accessing the field via an interface.
+func (v *__saveListEntryInput) GetStartedAt() *FuzzyDateInput { return v.StartedAt }
+
+// GetCompletedAt returns __saveListEntryInput.CompletedAt, and is useful for accessing the field via an interface.
+func (v *__saveListEntryInput) GetCompletedAt() *FuzzyDateInput { return v.CompletedAt }
+
+// GetHiddenFromStatusLists returns __saveListEntryInput.HiddenFromStatusLists, and is useful for accessing the field via an interface.
+func (v *__saveListEntryInput) GetHiddenFromStatusLists() *bool { return v.HiddenFromStatusLists }
+
+// GetPriority returns __saveListEntryInput.Priority, and is useful for accessing the field via an interface.
+func (v *__saveListEntryInput) GetPriority() *int { return v.Priority }
+
+// __saveTextActivityInput is used internally by genqlient
+type __saveTextActivityInput struct {
+	Text string `json:"text"`
+}
+
+// GetText returns __saveTextActivityInput.Text, and is useful for accessing the field via an interface.
+func (v *__saveTextActivityInput) GetText() string { return v.Text }
+
+// __searchAnimeInput is used internally by genqlient
+type __searchAnimeInput struct {
+	Search       string `json:"search"`
+	IsAdult      *bool  `json:"isAdult,omitempty"`
+	LowBandwidth bool   `json:"lowBandwidth"`
+}
+
+// GetSearch returns __searchAnimeInput.Search, and is useful for accessing the field via an interface.
+func (v *__searchAnimeInput) GetSearch() string { return v.Search }
+
+// GetIsAdult returns __searchAnimeInput.IsAdult, and is useful for accessing the field via an interface.
+func (v *__searchAnimeInput) GetIsAdult() *bool { return v.IsAdult }
+
+// GetLowBandwidth returns __searchAnimeInput.LowBandwidth, and is useful for accessing the field via an interface.
+func (v *__searchAnimeInput) GetLowBandwidth() bool { return v.LowBandwidth }
+
+// airingSchedule is an episode's broadcast time
+type airingSchedule struct {
+	Episode         int `json:"episode"`
+	AiringAt        int `json:"airingAt"`
+	TimeUntilAiring int `json:"timeUntilAiring"`
+}
+
+// GetEpisode returns airingSchedule.Episode, and is useful for accessing the field via an interface.
+func (v *airingSchedule) GetEpisode() int { return v.Episode }
+
+// GetAiringAt returns airingSchedule.AiringAt, and is useful for accessing the field via an interface.
+func (v *airingSchedule) GetAiringAt() int { return v.AiringAt }
+
+// GetTimeUntilAiring returns airingSchedule.TimeUntilAiring, and is useful for accessing the field via an interface.
+func (v *airingSchedule) GetTimeUntilAiring() int { return v.TimeUntilAiring }
+
+// getAiringScheduleMedia includes the requested fields of the GraphQL type Media.
+type getAiringScheduleMedia struct {
+	AiringSchedule getAiringScheduleMediaAiringScheduleAiringScheduleConnection `json:"airingSchedule"`
+}
+
+// GetAiringSchedule returns getAiringScheduleMedia.AiringSchedule, and is useful for accessing the field via an interface.
+func (v *getAiringScheduleMedia) GetAiringSchedule() getAiringScheduleMediaAiringScheduleAiringScheduleConnection {
+	return v.AiringSchedule
+}
+
+// getAiringScheduleMediaAiringScheduleAiringScheduleConnection includes the requested fields of the GraphQL type AiringScheduleConnection.
+type getAiringScheduleMediaAiringScheduleAiringScheduleConnection struct {
+	Nodes []airingSchedule `json:"nodes"`
+}
+
+// GetNodes returns getAiringScheduleMediaAiringScheduleAiringScheduleConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *getAiringScheduleMediaAiringScheduleAiringScheduleConnection) GetNodes() []airingSchedule {
+	return v.Nodes
+}
+
+// getAiringScheduleResponse is returned by getAiringSchedule on success.
+type getAiringScheduleResponse struct {
+	Media getAiringScheduleMedia `json:"Media"`
+}
+
+// GetMedia returns getAiringScheduleResponse.Media, and is useful for accessing the field via an interface.
+func (v *getAiringScheduleResponse) GetMedia() getAiringScheduleMedia { return v.Media }
+
+// getAnimeByIDsPage includes the requested fields of the GraphQL type Page.
+type getAnimeByIDsPage struct {
+	Media []mediaWithEntry `json:"media"`
+}
+
+// GetMedia returns getAnimeByIDsPage.Media, and is useful for accessing the field via an interface.
+func (v *getAnimeByIDsPage) GetMedia() []mediaWithEntry { return v.Media }
+
+// getAnimeByIDsResponse is returned by getAnimeByIDs on success.
+type getAnimeByIDsResponse struct {
+	Page getAnimeByIDsPage `json:"Page"`
+}
+
+// GetPage returns getAnimeByIDsResponse.Page, and is useful for accessing the field via an interface.
+func (v *getAnimeByIDsResponse) GetPage() getAnimeByIDsPage { return v.Page }
+
+// getAnimeByMalIDsPage includes the requested fields of the GraphQL type Page.
+type getAnimeByMalIDsPage struct {
+	Media []mediaWithEntry `json:"media"`
+}
+
+// GetMedia returns getAnimeByMalIDsPage.Media, and is useful for accessing the field via an interface.
+func (v *getAnimeByMalIDsPage) GetMedia() []mediaWithEntry { return v.Media }
+
+// getAnimeByMalIDsResponse is returned by getAnimeByMalIDs on success.
+type getAnimeByMalIDsResponse struct {
+	Page getAnimeByMalIDsPage `json:"Page"`
+}
+
+// GetPage returns getAnimeByMalIDsResponse.Page, and is useful for accessing the field via an interface.
+func (v *getAnimeByMalIDsResponse) GetPage() getAnimeByMalIDsPage { return v.Page }
+
+// getAnimeListMediaListCollection includes the requested fields of the GraphQL type MediaListCollection.
+type getAnimeListMediaListCollection struct {
+	Lists []getAnimeListMediaListCollectionListsMediaListGroup `json:"lists"`
+}
+
+// GetLists returns getAnimeListMediaListCollection.Lists, and is useful for accessing the field via an interface.
+func (v *getAnimeListMediaListCollection) GetLists() []getAnimeListMediaListCollectionListsMediaListGroup {
+	return v.Lists
+}
+
+// getAnimeListMediaListCollectionListsMediaListGroup includes the requested fields of the GraphQL type MediaListGroup.
+type getAnimeListMediaListCollectionListsMediaListGroup struct {
+	Entries []getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList `json:"entries"`
+}
+
+// GetEntries returns getAnimeListMediaListCollectionListsMediaListGroup.Entries, and is useful for accessing the field via an interface.
+func (v *getAnimeListMediaListCollectionListsMediaListGroup) GetEntries() []getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList {
+	return v.Entries
+}
+
+// getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList includes the requested fields of the GraphQL type MediaList.
+type getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList struct {
+	listEntry `json:"-"`
+	Media     mediaFields `json:"media"`
+}
+
+// GetMedia returns getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList.Media, and is useful for accessing the field via an interface.
+func (v *getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList) GetMedia() mediaFields {
+	return v.Media
+}
+
+// GetStatus returns getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList.Status, and is useful for accessing the field via an interface.
+func (v *getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList) GetStatus() MediaListStatus {
+	return v.listEntry.Status
+}
+
+// GetScore returns getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList.Score, and is useful for accessing the field via an interface.
+func (v *getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList) GetScore() float64 {
+	return v.listEntry.Score
+}
+
+// GetScore10 returns getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList.Score10, and is useful for accessing the field via an interface.
+func (v *getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList) GetScore10() float64 {
+	return v.listEntry.Score10
+}
+
+// GetProgress returns getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList.Progress, and is useful for accessing the field via an interface.
+func (v *getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList) GetProgress() int {
+	return v.listEntry.Progress
+}
+
+// GetStartedAt returns getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList.StartedAt, and is useful for accessing the field via an interface.
+func (v *getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList) GetStartedAt() listEntryStartedAtFuzzyDate {
+	return v.listEntry.StartedAt
+}
+
+// GetCompletedAt returns getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList.CompletedAt, and is useful for accessing the field via an interface.
+func (v *getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList) GetCompletedAt() listEntryCompletedAtFuzzyDate {
+	return v.listEntry.CompletedAt
+}
+
+// GetNotes returns getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList.Notes, and is useful for accessing the field via an interface.
+func (v *getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList) GetNotes() string {
+	return v.listEntry.Notes
+}
+
+// GetUpdatedAt returns getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList) GetUpdatedAt() int {
+	return v.listEntry.UpdatedAt
+}
+
+// GetHiddenFromStatusLists returns getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList.HiddenFromStatusLists, and is useful for accessing the field via an interface.
+func (v *getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList) GetHiddenFromStatusLists() bool {
+	return v.listEntry.HiddenFromStatusLists
+}
+
+// GetPriority returns getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList.Priority, and is useful for accessing the field via an interface.
+func (v *getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList) GetPriority() int {
+	return v.listEntry.Priority
+}
+
+func (v *getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.listEntry)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalgetAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList struct {
+	Media mediaFields `json:"media"`
+
+	Status MediaListStatus `json:"status"`
+
+	Score float64 `json:"score"`
+
+	Score10 float64 `json:"score10"`
+
+	Progress int `json:"progress"`
+
+	StartedAt listEntryStartedAtFuzzyDate `json:"startedAt"`
+
+	CompletedAt listEntryCompletedAtFuzzyDate `json:"completedAt"`
+
+	Notes string `json:"notes"`
+
+	UpdatedAt int `json:"updatedAt"`
+
+	HiddenFromStatusLists bool `json:"hiddenFromStatusLists"`
+
+	Priority int `json:"priority"`
+}
+
+func (v *getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *getAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList) __premarshalJSON() (*__premarshalgetAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList, error) {
+	var retval __premarshalgetAnimeListMediaListCollectionListsMediaListGroupEntriesMediaList
+
+	retval.Media = v.Media
+	retval.Status = v.listEntry.Status
+	retval.Score = v.listEntry.Score
+	retval.Score10 = v.listEntry.Score10
+	retval.Progress = v.listEntry.Progress
+	retval.StartedAt = v.listEntry.StartedAt
+	retval.CompletedAt = v.listEntry.CompletedAt
+	retval.Notes = v.listEntry.Notes
+	retval.UpdatedAt = v.listEntry.UpdatedAt
+	retval.HiddenFromStatusLists = v.listEntry.HiddenFromStatusLists
+	retval.Priority = v.listEntry.Priority
+	return &retval, nil
+}
+
+// getAnimeListResponse is returned by getAnimeList on success.
+type getAnimeListResponse struct {
+	MediaListCollection getAnimeListMediaListCollection `json:"MediaListCollection"`
+}
+
+// GetMediaListCollection returns getAnimeListResponse.MediaListCollection, and is useful for accessing the field via an interface.
+func (v *getAnimeListResponse) GetMediaListCollection() getAnimeListMediaListCollection {
+	return v.MediaListCollection
+}
+
+// getAnimeMedia includes the requested fields of the GraphQL type Media.
+type getAnimeMedia struct {
+	mediaWithEntry `json:"-"`
+	Relations      getAnimeMediaRelationsMediaConnection `json:"relations"`
+}
+
+// GetRelations returns getAnimeMedia.Relations, and is useful for accessing the field via an interface.
+func (v *getAnimeMedia) GetRelations() getAnimeMediaRelationsMediaConnection { return v.Relations }
+
+// GetMediaListEntry returns getAnimeMedia.MediaListEntry, and is useful for accessing the field via an interface.
+func (v *getAnimeMedia) GetMediaListEntry() *listEntry { return v.mediaWithEntry.MediaListEntry }
+
+// GetId returns getAnimeMedia.Id, and is useful for accessing the field via an interface.
+func (v *getAnimeMedia) GetId() int { return v.mediaWithEntry.mediaFields.Id }
+
+// GetIdMal returns getAnimeMedia.IdMal, and is useful for accessing the field via an interface.
+func (v *getAnimeMedia) GetIdMal() int { return v.mediaWithEntry.mediaFields.IdMal }
+
+// GetTitle returns getAnimeMedia.Title, and is useful for accessing the field via an interface.
+func (v *getAnimeMedia) GetTitle() mediaFieldsTitleMediaTitle {
+	return v.mediaWithEntry.mediaFields.Title
+}
+
+// GetEpisodes returns getAnimeMedia.Episodes, and is useful for accessing the field via an interface.
+func (v *getAnimeMedia) GetEpisodes() int { return v.mediaWithEntry.mediaFields.Episodes }
+
+// GetDuration returns getAnimeMedia.Duration, and is useful for accessing the field via an interface.
+func (v *getAnimeMedia) GetDuration() int { return v.mediaWithEntry.mediaFields.Duration }
+
+// GetNextAiringEpisode returns getAnimeMedia.NextAiringEpisode, and is useful for accessing the field via an interface.
+func (v *getAnimeMedia) GetNextAiringEpisode() *airingSchedule {
+	return v.mediaWithEntry.mediaFields.NextAiringEpisode
+}
+
+// GetStatus returns getAnimeMedia.Status, and is useful for accessing the field via an interface.
+func (v *getAnimeMedia) GetStatus() MediaStatus { return v.mediaWithEntry.mediaFields.Status }
+
+// GetFormat returns getAnimeMedia.Format, and is useful for accessing the field via an interface.
+func (v *getAnimeMedia) GetFormat() MediaFormat { return v.mediaWithEntry.mediaFields.Format }
+
+// GetSeason returns getAnimeMedia.Season, and is useful for accessing the field via an interface.
+func (v *getAnimeMedia) GetSeason() MediaSeason { return v.mediaWithEntry.mediaFields.Season }
+
+// GetSeasonYear returns getAnimeMedia.SeasonYear, and is useful for accessing the field via an interface.
+func (v *getAnimeMedia) GetSeasonYear() int { return v.mediaWithEntry.mediaFields.SeasonYear }
+
+// GetAverageScore returns getAnimeMedia.AverageScore, and is useful for accessing the field via an interface.
+func (v *getAnimeMedia) GetAverageScore() int { return v.mediaWithEntry.mediaFields.AverageScore }
+
+// GetIsAdult returns getAnimeMedia.IsAdult, and is useful for accessing the field via an interface.
+func (v *getAnimeMedia) GetIsAdult() bool { return v.mediaWithEntry.mediaFields.IsAdult }
+
+// GetCountryOfOrigin returns getAnimeMedia.CountryOfOrigin, and is useful for accessing the field via an interface.
+func (v *getAnimeMedia) GetCountryOfOrigin() string {
+	return v.mediaWithEntry.mediaFields.CountryOfOrigin
+}
+
+// GetGenres returns getAnimeMedia.Genres, and is useful for accessing the field via an interface.
+func (v *getAnimeMedia) GetGenres() []string { return v.mediaWithEntry.mediaFields.Genres }
+
+// GetCoverImage returns getAnimeMedia.CoverImage, and is useful for accessing the field via an interface.
+func (v *getAnimeMedia) GetCoverImage() mediaFieldsCoverImageMediaCoverImage {
+	return v.mediaWithEntry.mediaFields.CoverImage
+}
+
+// GetSynonyms returns getAnimeMedia.Synonyms, and is useful for accessing the field via an interface.
+func (v *getAnimeMedia) GetSynonyms() []string { return v.mediaWithEntry.mediaFields.Synonyms }
+
+// GetTags returns getAnimeMedia.Tags, and is useful for accessing the field via an interface.
+func (v *getAnimeMedia) GetTags() []mediaFieldsTagsMediaTag { return v.mediaWithEntry.mediaFields.Tags }
+
+func (v *getAnimeMedia) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*getAnimeMedia
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.getAnimeMedia = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.mediaWithEntry)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalgetAnimeMedia struct {
+	Relations getAnimeMediaRelationsMediaConnection `json:"relations"`
+
+	MediaListEntry *listEntry `json:"mediaListEntry"`
+
+	Id int `json:"id"`
+
+	IdMal int `json:"idMal"`
+
+	Title mediaFieldsTitleMediaTitle `json:"title"`
+
+	Episodes int `json:"episodes"`
+
+	Duration int `json:"duration"`
+
+	NextAiringEpisode *airingSchedule `json:"nextAiringEpisode"`
+
+	Status MediaStatus `json:"status"`
+
+	Format MediaFormat `json:"format"`
+
+	Season MediaSeason `json:"season"`
+
+	SeasonYear int `json:"seasonYear"`
+
+	AverageScore int `json:"averageScore"`
+
+	IsAdult bool `json:"isAdult"`
+
+	CountryOfOrigin string `json:"countryOfOrigin"`
+
+	Genres []string `json:"genres"`
+
+	CoverImage mediaFieldsCoverImageMediaCoverImage `json:"coverImage"`
+
+	Synonyms []string `json:"synonyms"`
+
+	Tags []mediaFieldsTagsMediaTag `json:"tags"`
+}
+
+func (v *getAnimeMedia) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *getAnimeMedia) __premarshalJSON() (*__premarshalgetAnimeMedia, error) {
+	var retval __premarshalgetAnimeMedia
+
+	retval.Relations = v.Relations
+	retval.MediaListEntry = v.mediaWithEntry.MediaListEntry
+	retval.Id = v.mediaWithEntry.mediaFields.Id
+	retval.IdMal = v.mediaWithEntry.mediaFields.IdMal
+	retval.Title = v.mediaWithEntry.mediaFields.Title
+	retval.Episodes = v.mediaWithEntry.mediaFields.Episodes
+	retval.Duration = v.mediaWithEntry.mediaFields.Duration
+	retval.NextAiringEpisode = v.mediaWithEntry.mediaFields.NextAiringEpisode
+	retval.Status = v.mediaWithEntry.mediaFields.Status
+	retval.Format = v.mediaWithEntry.mediaFields.Format
+	retval.Season = v.mediaWithEntry.mediaFields.Season
+	retval.SeasonYear = v.mediaWithEntry.mediaFields.SeasonYear
+	retval.AverageScore = v.mediaWithEntry.mediaFields.AverageScore
+	retval.IsAdult = v.mediaWithEntry.mediaFields.IsAdult
+	retval.CountryOfOrigin = v.mediaWithEntry.mediaFields.CountryOfOrigin
+	retval.Genres = v.mediaWithEntry.mediaFields.Genres
+	retval.CoverImage = v.mediaWithEntry.mediaFields.CoverImage
+	retval.Synonyms = v.mediaWithEntry.mediaFields.Synonyms
+	retval.Tags = v.mediaWithEntry.mediaFields.Tags
+	return &retval, nil
+}
+
+// getAnimeMediaRelationsMediaConnection includes the requested fields of the GraphQL type MediaConnection.
+type getAnimeMediaRelationsMediaConnection struct {
+	Edges []getAnimeMediaRelationsMediaConnectionEdgesMediaEdge `json:"edges"`
+}
+
+// GetEdges returns getAnimeMediaRelationsMediaConnection.Edges, and is useful for accessing the field via an interface.
+func (v *getAnimeMediaRelationsMediaConnection) GetEdges() []getAnimeMediaRelationsMediaConnectionEdgesMediaEdge {
+	return v.Edges
+}
+
+// getAnimeMediaRelationsMediaConnectionEdgesMediaEdge includes the requested fields of the GraphQL type MediaEdge.
+type getAnimeMediaRelationsMediaConnectionEdgesMediaEdge struct {
+	RelationType MediaRelation                                                `json:"relationType"`
+	Node         getAnimeMediaRelationsMediaConnectionEdgesMediaEdgeNodeMedia `json:"node"`
+}
+
+// GetRelationType returns getAnimeMediaRelationsMediaConnectionEdgesMediaEdge.RelationType, and is useful for accessing the field via an interface.
+func (v *getAnimeMediaRelationsMediaConnectionEdgesMediaEdge) GetRelationType() MediaRelation {
+	return v.RelationType
+}
+
+// GetNode returns getAnimeMediaRelationsMediaConnectionEdgesMediaEdge.Node, and is useful for accessing the field via an interface.
+func (v *getAnimeMediaRelationsMediaConnectionEdgesMediaEdge) GetNode() getAnimeMediaRelationsMediaConnectionEdgesMediaEdgeNodeMedia {
+	return v.Node
+}
+
+// getAnimeMediaRelationsMediaConnectionEdgesMediaEdgeNodeMedia includes the requested fields of the GraphQL type Media.
+type getAnimeMediaRelationsMediaConnectionEdgesMediaEdgeNodeMedia struct {
+	Id   int       `json:"id"`
+	Type MediaType `json:"type"`
+}
+
+// GetId returns getAnimeMediaRelationsMediaConnectionEdgesMediaEdgeNodeMedia.Id, and is useful for accessing the field via an interface.
+func (v *getAnimeMediaRelationsMediaConnectionEdgesMediaEdgeNodeMedia) GetId() int { return v.Id }
+
+// GetType returns getAnimeMediaRelationsMediaConnectionEdgesMediaEdgeNodeMedia.Type, and is useful for accessing the field via an interface.
+func (v *getAnimeMediaRelationsMediaConnectionEdgesMediaEdgeNodeMedia) GetType() MediaType {
+	return v.Type
+}
+
+// getAnimeResponse is returned by getAnime on success.
+type getAnimeResponse struct {
+	Media getAnimeMedia `json:"Media"`
+}
+
+// GetMedia returns getAnimeResponse.Media, and is useful for accessing the field via an interface.
+func (v *getAnimeResponse) GetMedia() getAnimeMedia { return v.Media }
+
+// getStreamingEpisodesMedia includes the requested fields of the GraphQL type Media.
+type getStreamingEpisodesMedia struct {
+	StreamingEpisodes []getStreamingEpisodesMediaStreamingEpisodesMediaStreamingEpisode `json:"streamingEpisodes"`
+}
+
+// GetStreamingEpisodes returns getStreamingEpisodesMedia.StreamingEpisodes, and is useful for accessing the field via an interface.
+func (v *getStreamingEpisodesMedia) GetStreamingEpisodes() []getStreamingEpisodesMediaStreamingEpisodesMediaStreamingEpisode {
+	return v.StreamingEpisodes
+}
+
+// getStreamingEpisodesMediaStreamingEpisodesMediaStreamingEpisode includes the requested fields of the GraphQL type MediaStreamingEpisode.
+type getStreamingEpisodesMediaStreamingEpisodesMediaStreamingEpisode struct {
+	Title string `json:"title"`
+	Site  string `json:"site"`
+}
+
+// GetTitle returns getStreamingEpisodesMediaStreamingEpisodesMediaStreamingEpisode.Title, and is useful for accessing the field via an interface.
+func (v *getStreamingEpisodesMediaStreamingEpisodesMediaStreamingEpisode) GetTitle() string {
+	return v.Title
+}
+
+// GetSite returns getStreamingEpisodesMediaStreamingEpisodesMediaStreamingEpisode.Site, and is useful for accessing the field via an interface.
+func (v *getStreamingEpisodesMediaStreamingEpisodesMediaStreamingEpisode) GetSite() string {
+	return v.Site
+}
+
+// getStreamingEpisodesResponse is returned by getStreamingEpisodes on success.
+type getStreamingEpisodesResponse struct {
+	Media getStreamingEpisodesMedia `json:"Media"`
+}
+
+// GetMedia returns getStreamingEpisodesResponse.Media, and is useful for accessing the field via an interface.
+func (v *getStreamingEpisodesResponse) GetMedia() getStreamingEpisodesMedia { return v.Media }
+
+// getViewerResponse is returned by getViewer on success.
+type getViewerResponse struct {
+	Viewer viewer `json:"Viewer"`
+}
+
+// GetViewer returns getViewerResponse.Viewer, and is useful for accessing the field via an interface.
+func (v *getViewerResponse) GetViewer() viewer { return v.Viewer }
+
+// listEntry is a user's list entry.  score is in the user's score format, and score10 is the same score as a whole
+// number out of 10.
+type listEntry struct {
+	Status                MediaListStatus               `json:"status"`
+	Score                 float64                       `json:"score"`
+	Score10               float64                       `json:"score10"`
+	Progress              int                           `json:"progress"`
+	StartedAt             listEntryStartedAtFuzzyDate   `json:"startedAt"`
+	CompletedAt           listEntryCompletedAtFuzzyDate `json:"completedAt"`
+	Notes                 string                        `json:"notes"`
+	UpdatedAt             int                           `json:"updatedAt"`
+	HiddenFromStatusLists bool                          `json:"hiddenFromStatusLists"`
+	Priority              int                           `json:"priority"`
+}
+
+// GetStatus returns listEntry.Status, and is useful for accessing the field via an interface.
+func (v *listEntry) GetStatus() MediaListStatus { return v.Status }
+
+// GetScore returns listEntry.Score, and is useful for accessing the field via an interface.
+func (v *listEntry) GetScore() float64 { return v.Score }
+
+// GetScore10 returns listEntry.Score10, and is useful for accessing the field via an interface.
+func (v *listEntry) GetScore10() float64 { return v.Score10 }
+
+// GetProgress returns listEntry.Progress, and is useful for accessing the field via an interface.
+func (v *listEntry) GetProgress() int { return v.Progress }
+
+// GetStartedAt returns listEntry.StartedAt, and is useful for accessing the field via an interface.
+func (v *listEntry) GetStartedAt() listEntryStartedAtFuzzyDate { return v.StartedAt }
+
+// GetCompletedAt returns listEntry.CompletedAt, and is useful for accessing the field via an interface.
+func (v *listEntry) GetCompletedAt() listEntryCompletedAtFuzzyDate { return v.CompletedAt }
+
+// GetNotes returns listEntry.Notes, and is useful for accessing the field via an interface.
+func (v *listEntry) GetNotes() string { return v.Notes }
+
+// GetUpdatedAt returns listEntry.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *listEntry) GetUpdatedAt() int { return v.UpdatedAt }
+
+// GetHiddenFromStatusLists returns listEntry.HiddenFromStatusLists, and is useful for accessing the field via an interface.
+func (v *listEntry) GetHiddenFromStatusLists() bool { return v.HiddenFromStatusLists }
+
+// GetPriority returns listEntry.Priority, and is useful for accessing the field via an interface.
+func (v *listEntry) GetPriority() int { return v.Priority }
+
+// listEntryCompletedAtFuzzyDate includes the requested fields of the GraphQL type FuzzyDate.
+type listEntryCompletedAtFuzzyDate struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+	Day   int `json:"day"`
+}
+
+// GetYear returns listEntryCompletedAtFuzzyDate.Year, and is useful for accessing the field via an interface.
+func (v *listEntryCompletedAtFuzzyDate) GetYear() int { return v.Year }
+
+// GetMonth returns listEntryCompletedAtFuzzyDate.Month, and is useful for accessing the field via an interface.
+func (v *listEntryCompletedAtFuzzyDate) GetMonth() int { return v.Month }
+
+// GetDay returns listEntryCompletedAtFuzzyDate.Day, and is useful for accessing the field via an interface.
+func (v *listEntryCompletedAtFuzzyDate) GetDay() int { return v.Day }
+
+// listEntryStartedAtFuzzyDate includes the requested fields of the GraphQL type FuzzyDate.
+type listEntryStartedAtFuzzyDate struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+	Day   int `json:"day"`
+}
+
+// GetYear returns listEntryStartedAtFuzzyDate.Year, and is useful for accessing the field via an interface.
+func (v *listEntryStartedAtFuzzyDate) GetYear() int { return v.Year }
+
+// GetMonth returns listEntryStartedAtFuzzyDate.Month, and is useful for accessing the field via an interface.
+func (v *listEntryStartedAtFuzzyDate) GetMonth() int { return v.Month }
+
+// GetDay returns listEntryStartedAtFuzzyDate.Day, and is useful for accessing the field via an interface.
+func (v *listEntryStartedAtFuzzyDate) GetDay() int { return v.Day }
+
+// mediaFields are the fields selected for every media.  The larger fields are skipped in low-bandwidth mode to trim the
+// payload on metered or slow connections.
+type mediaFields struct {
+	Id                int                                  `json:"id"`
+	IdMal             int                                  `json:"idMal"`
+	Title             mediaFieldsTitleMediaTitle           `json:"title"`
+	Episodes          int                                  `json:"episodes"`
+	Duration          int                                  `json:"duration"`
+	NextAiringEpisode *airingSchedule                      `json:"nextAiringEpisode"`
+	Status            MediaStatus                          `json:"status"`
+	Format            MediaFormat                          `json:"format"`
+	Season            MediaSeason                          `json:"season"`
+	SeasonYear        int                                  `json:"seasonYear"`
+	AverageScore      int                                  `json:"averageScore"`
+	IsAdult           bool                                 `json:"isAdult"`
+	CountryOfOrigin   string                               `json:"countryOfOrigin"`
+	Genres            []string                             `json:"genres"`
+	CoverImage        mediaFieldsCoverImageMediaCoverImage `json:"coverImage"`
+	Synonyms          []string                             `json:"synonyms"`
+	Tags              []mediaFieldsTagsMediaTag            `json:"tags"`
+}
+
+// GetId returns mediaFields.Id, and is useful for accessing the field via an interface.
+func (v *mediaFields) GetId() int { return v.Id }
+
+// GetIdMal returns mediaFields.IdMal, and is useful for accessing the field via an interface.
+func (v *mediaFields) GetIdMal() int { return v.IdMal }
+
+// GetTitle returns mediaFields.Title, and is useful for accessing the field via an interface.
+func (v *mediaFields) GetTitle() mediaFieldsTitleMediaTitle { return v.Title }
+
+// GetEpisodes returns mediaFields.Episodes, and is useful for accessing the field via an interface.
+func (v *mediaFields) GetEpisodes() int { return v.Episodes }
+
+// GetDuration returns mediaFields.Duration, and is useful for accessing the field via an interface.
+func (v *mediaFields) GetDuration() int { return v.Duration }
+
+// GetNextAiringEpisode returns mediaFields.NextAiringEpisode, and is useful for accessing the field via an interface.
+func (v *mediaFields) GetNextAiringEpisode() *airingSchedule { return v.NextAiringEpisode }
+
+// GetStatus returns mediaFields.Status, and is useful for accessing the field via an interface.
+func (v *mediaFields) GetStatus() MediaStatus { return v.Status }
+
+// GetFormat returns mediaFields.Format, and is useful for accessing the field via an interface.
+func (v *mediaFields) GetFormat() MediaFormat { return v.Format }
+
+// GetSeason returns mediaFields.Season, and is useful for accessing the field via an interface.
+func (v *mediaFields) GetSeason() MediaSeason { return v.Season }
+
+// GetSeasonYear returns mediaFields.SeasonYear, and is useful for accessing the field via an interface.
+func (v *mediaFields) GetSeasonYear() int { return v.SeasonYear }
+
+// GetAverageScore returns mediaFields.AverageScore, and is useful for accessing the field via an interface.
+func (v *mediaFields) GetAverageScore() int { return v.AverageScore }
+
+// GetIsAdult returns mediaFields.IsAdult, and is useful for accessing the field via an interface.
+func (v *mediaFields) GetIsAdult() bool { return v.IsAdult }
+
+// GetCountryOfOrigin returns mediaFields.CountryOfOrigin, and is useful for accessing the field via an interface.
+func (v *mediaFields) GetCountryOfOrigin() string { return v.CountryOfOrigin }
+
+// GetGenres returns mediaFields.Genres, and is useful for accessing the field via an interface.
+func (v *mediaFields) GetGenres() []string { return v.Genres }
+
+// GetCoverImage returns mediaFields.CoverImage, and is useful for accessing the field via an interface.
+func (v *mediaFields) GetCoverImage() mediaFieldsCoverImageMediaCoverImage { return v.CoverImage }
+
+// GetSynonyms returns mediaFields.Synonyms, and is useful for accessing the field via an interface.
+func (v *mediaFields) GetSynonyms() []string { return v.Synonyms }
+
+// GetTags returns mediaFields.Tags, and is useful for accessing the field via an interface.
+func (v *mediaFields) GetTags() []mediaFieldsTagsMediaTag { return v.Tags }
+
+// mediaFieldsCoverImageMediaCoverImage includes the requested fields of the GraphQL type MediaCoverImage.
+type mediaFieldsCoverImageMediaCoverImage struct {
+	Large string `json:"large"`
+}
+
+// GetLarge returns mediaFieldsCoverImageMediaCoverImage.Large, and is useful for accessing the field via an interface.
+func (v *mediaFieldsCoverImageMediaCoverImage) GetLarge() string { return v.Large }
+
+// mediaFieldsTagsMediaTag includes the requested fields of the GraphQL type MediaTag.
+type mediaFieldsTagsMediaTag struct {
+	Name           string `json:"name"`
+	Rank           int    `json:"rank"`
+	IsMediaSpoiler bool   `json:"isMediaSpoiler"`
+}
+
+// GetName returns mediaFieldsTagsMediaTag.Name, and is useful for accessing the field via an interface.
+func (v *mediaFieldsTagsMediaTag) GetName() string { return v.Name }
+
+// GetRank returns mediaFieldsTagsMediaTag.Rank, and is useful for accessing the field via an interface.
+func (v *mediaFieldsTagsMediaTag) GetRank() int { return v.Rank }
+
+// GetIsMediaSpoiler returns mediaFieldsTagsMediaTag.IsMediaSpoiler, and is useful for accessing the field via an interface.
+func (v *mediaFieldsTagsMediaTag) GetIsMediaSpoiler() bool { return v.IsMediaSpoiler }
+
+// mediaFieldsTitleMediaTitle includes the requested fields of the GraphQL type MediaTitle.
+type mediaFieldsTitleMediaTitle struct {
+	Romaji        string `json:"romaji"`
+	English       string `json:"english"`
+	Native        string `json:"native"`
+	UserPreferred string `json:"userPreferred"`
+}
+
+// GetRomaji returns mediaFieldsTitleMediaTitle.Romaji, and is useful for accessing the field via an interface.
+func (v *mediaFieldsTitleMediaTitle) GetRomaji() string { return v.Romaji }
+
+// GetEnglish returns mediaFieldsTitleMediaTitle.English, and is useful for accessing the field via an interface.
+func (v *mediaFieldsTitleMediaTitle) GetEnglish() string { return v.English }
+
+// GetNative returns mediaFieldsTitleMediaTitle.Native, and is useful for accessing the field via an interface.
+func (v *mediaFieldsTitleMediaTitle) GetNative() string { return v.Native }
+
+// GetUserPreferred returns mediaFieldsTitleMediaTitle.UserPreferred, and is useful for accessing the field via an interface.
+func (v *mediaFieldsTitleMediaTitle) GetUserPreferred() string { return v.UserPreferred }
+
+// mediaWithEntry is a media along with the user's list entry for it, if they have one
+type mediaWithEntry struct {
+	mediaFields    `json:"-"`
+	MediaListEntry *listEntry `json:"mediaListEntry"`
+}
+
+// GetMediaListEntry returns mediaWithEntry.MediaListEntry, and is useful for accessing the field via an interface.
+func (v *mediaWithEntry) GetMediaListEntry() *listEntry { return v.MediaListEntry }
+
+// GetId returns mediaWithEntry.Id, and is useful for accessing the field via an interface.
+func (v *mediaWithEntry) GetId() int { return v.mediaFields.Id }
+
+// GetIdMal returns mediaWithEntry.IdMal, and is useful for accessing the field via an interface.
+func (v *mediaWithEntry) GetIdMal() int { return v.mediaFields.IdMal }
+
+// GetTitle returns mediaWithEntry.Title, and is useful for accessing the field via an interface.
+func (v *mediaWithEntry) GetTitle() mediaFieldsTitleMediaTitle { return v.mediaFields.Title }
+
+// GetEpisodes returns mediaWithEntry.Episodes, and is useful for accessing the field via an interface.
+func (v *mediaWithEntry) GetEpisodes() int { return v.mediaFields.Episodes }
+
+// GetDuration returns mediaWithEntry.Duration, and is useful for accessing the field via an interface.
+func (v *mediaWithEntry) GetDuration() int { return v.mediaFields.Duration }
+
+// GetNextAiringEpisode returns mediaWithEntry.NextAiringEpisode, and is useful for accessing the field via an interface.
+func (v *mediaWithEntry) GetNextAiringEpisode() *airingSchedule {
+	return v.mediaFields.NextAiringEpisode
+}
+
+// GetStatus returns mediaWithEntry.Status, and is useful for accessing the field via an interface.
+func (v *mediaWithEntry) GetStatus() MediaStatus { return v.mediaFields.Status }
+
+// GetFormat returns mediaWithEntry.Format, and is useful for accessing the field via an interface.
+func (v *mediaWithEntry) GetFormat() MediaFormat { return v.mediaFields.Format }
+
+// GetSeason returns mediaWithEntry.Season, and is useful for accessing the field via an interface.
+func (v *mediaWithEntry) GetSeason() MediaSeason { return v.mediaFields.Season }
+
+// GetSeasonYear returns mediaWithEntry.SeasonYear, and is useful for accessing the field via an interface.
+func (v *mediaWithEntry) GetSeasonYear() int { return v.mediaFields.SeasonYear }
+
+// GetAverageScore returns mediaWithEntry.AverageScore, and is useful for accessing the field via an interface.
+func (v *mediaWithEntry) GetAverageScore() int { return v.mediaFields.AverageScore }
+
+// GetIsAdult returns mediaWithEntry.IsAdult, and is useful for accessing the field via an interface.
+func (v *mediaWithEntry) GetIsAdult() bool { return v.mediaFields.IsAdult }
+
+// GetCountryOfOrigin returns mediaWithEntry.CountryOfOrigin, and is useful for accessing the field via an interface.
+func (v *mediaWithEntry) GetCountryOfOrigin() string { return v.mediaFields.CountryOfOrigin }
+
+// GetGenres returns mediaWithEntry.Genres, and is useful for accessing the field via an interface.
+func (v *mediaWithEntry) GetGenres() []string { return v.mediaFields.Genres }
+
+// GetCoverImage returns mediaWithEntry.CoverImage, and is useful for accessing the field via an interface.
+func (v *mediaWithEntry) GetCoverImage() mediaFieldsCoverImageMediaCoverImage {
+	return v.mediaFields.CoverImage
+}
+
+// GetSynonyms returns mediaWithEntry.Synonyms, and is useful for accessing the field via an interface.
+func (v *mediaWithEntry) GetSynonyms() []string { return v.mediaFields.Synonyms }
+
+// GetTags returns mediaWithEntry.Tags, and is useful for accessing the field via an interface.
+func (v *mediaWithEntry) GetTags() []mediaFieldsTagsMediaTag { return v.mediaFields.Tags }
+
+func (v *mediaWithEntry) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*mediaWithEntry
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.mediaWithEntry = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.mediaFields)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalmediaWithEntry struct {
+	MediaListEntry *listEntry `json:"mediaListEntry"`
+
+	Id int `json:"id"`
+
+	IdMal int `json:"idMal"`
+
+	Title mediaFieldsTitleMediaTitle `json:"title"`
+
+	Episodes int `json:"episodes"`
+
+	Duration int `json:"duration"`
+
+	NextAiringEpisode *airingSchedule `json:"nextAiringEpisode"`
+
+	Status MediaStatus `json:"status"`
+
+	Format MediaFormat `json:"format"`
+
+	Season MediaSeason `json:"season"`
+
+	SeasonYear int `json:"seasonYear"`
+
+	AverageScore int `json:"averageScore"`
+
+	IsAdult bool `json:"isAdult"`
+
+	CountryOfOrigin string `json:"countryOfOrigin"`
+
+	Genres []string `json:"genres"`
+
+	CoverImage mediaFieldsCoverImageMediaCoverImage `json:"coverImage"`
+
+	Synonyms []string `json:"synonyms"`
+
+	Tags []mediaFieldsTagsMediaTag `json:"tags"`
+}
+
+func (v *mediaWithEntry) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *mediaWithEntry) __premarshalJSON() (*__premarshalmediaWithEntry, error) {
+	var retval __premarshalmediaWithEntry
+
+	retval.MediaListEntry = v.MediaListEntry
+	retval.Id = v.mediaFields.Id
+	retval.IdMal = v.mediaFields.IdMal
+	retval.Title = v.mediaFields.Title
+	retval.Episodes = v.mediaFields.Episodes
+	retval.Duration = v.mediaFields.Duration
+	retval.NextAiringEpisode = v.mediaFields.NextAiringEpisode
+	retval.Status = v.mediaFields.Status
+	retval.Format = v.mediaFields.Format
+	retval.Season = v.mediaFields.Season
+	retval.SeasonYear = v.mediaFields.SeasonYear
+	retval.AverageScore = v.mediaFields.AverageScore
+	retval.IsAdult = v.mediaFields.IsAdult
+	retval.CountryOfOrigin = v.mediaFields.CountryOfOrigin
+	retval.Genres = v.mediaFields.Genres
+	retval.CoverImage = v.mediaFields.CoverImage
+	retval.Synonyms = v.mediaFields.Synonyms
+	retval.Tags = v.mediaFields.Tags
+	return &retval, nil
+}
+
+// saveListEntryResponse is returned by saveListEntry on success.
+type saveListEntryResponse struct {
+	SaveMediaListEntry *savedListEntry `json:"SaveMediaListEntry"`
+}
+
+// GetSaveMediaListEntry returns saveListEntryResponse.SaveMediaListEntry, and is useful for accessing the field via an interface.
+func (v *saveListEntryResponse) GetSaveMediaListEntry() *savedListEntry { return v.SaveMediaListEntry }
+
+// saveTextActivityResponse is returned by saveTextActivity on success.
+type saveTextActivityResponse struct {
+	SaveTextActivity saveTextActivitySaveTextActivity `json:"SaveTextActivity"`
+}
+
+// GetSaveTextActivity returns saveTextActivityResponse.SaveTextActivity, and is useful for accessing the field via an interface.
+func (v *saveTextActivityResponse) GetSaveTextActivity() saveTextActivitySaveTextActivity {
+	return v.SaveTextActivity
+}
+
+// saveTextActivitySaveTextActivity includes the requested fields of the GraphQL type TextActivity.
+type saveTextActivitySaveTextActivity struct {
+	Id int `json:"id"`
+}
+
+// GetId returns saveTextActivitySaveTextActivity.Id, and is useful for accessing the field via an interface.
+func (v *saveTextActivitySaveTextActivity) GetId() int { return v.Id }
+
+// savedListEntry is a list entry as returned by SaveMediaListEntry
+type savedListEntry struct {
+	Id                    int                                `json:"id"`
+	MediaId               int                                `json:"mediaId"`
+	Status                MediaListStatus                    `json:"status"`
+	Score                 float64                            `json:"score"`
+	Score10               float64                            `json:"score10"`
+	Progress              int                                `json:"progress"`
+	Notes                 string                             `json:"notes"`
+	UpdatedAt             int                                `json:"updatedAt"`
+	HiddenFromStatusLists bool                               `json:"hiddenFromStatusLists"`
+	Priority              int                                `json:"priority"`
+	StartedAt             savedListEntryStartedAtFuzzyDate   `json:"startedAt"`
+	CompletedAt           savedListEntryCompletedAtFuzzyDate `json:"completedAt"`
+}
+
+// GetId returns savedListEntry.Id, and is useful for accessing the field via an interface.
+func (v *savedListEntry) GetId() int { return v.Id }
+
+// GetMediaId returns savedListEntry.MediaId, and is useful for accessing the field via an interface.
+func (v *savedListEntry) GetMediaId() int { return v.MediaId }
+
+// GetStatus returns savedListEntry.Status, and is useful for accessing the field via an interface.
+func (v *savedListEntry) GetStatus() MediaListStatus { return v.Status }
+
+// GetScore returns savedListEntry.Score, and is useful for accessing the field via an interface.
+func (v *savedListEntry) GetScore() float64 { return v.Score }
+
+// GetScore10 returns savedListEntry.Score10, and is useful for accessing the field via an interface.
+func (v *savedListEntry) GetScore10() float64 { return v.Score10 }
+
+// GetProgress returns savedListEntry.Progress, and is useful for accessing the field via an interface.
+func (v *savedListEntry) GetProgress() int { return v.Progress }
+
+// GetNotes returns savedListEntry.Notes, and is useful for accessing the field via an interface.
+func (v *savedListEntry) GetNotes() string { return v.Notes }
+
+// GetUpdatedAt returns savedListEntry.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *savedListEntry) GetUpdatedAt() int { return v.UpdatedAt }
+
+// GetHiddenFromStatusLists returns savedListEntry.HiddenFromStatusLists, and is useful for accessing the field via an interface.
+func (v *savedListEntry) GetHiddenFromStatusLists() bool { return v.HiddenFromStatusLists }
+
+// GetPriority returns savedListEntry.Priority, and is useful for accessing the field via an interface.
+func (v *savedListEntry) GetPriority() int { return v.Priority }
+
+// GetStartedAt returns savedListEntry.StartedAt, and is useful for accessing the field via an interface.
+func (v *savedListEntry) GetStartedAt() savedListEntryStartedAtFuzzyDate { return v.StartedAt }
+
+// GetCompletedAt returns savedListEntry.CompletedAt, and is useful for accessing the field via an interface.
+func (v *savedListEntry) GetCompletedAt() savedListEntryCompletedAtFuzzyDate { return v.CompletedAt }
+
+// savedListEntryCompletedAtFuzzyDate includes the requested fields of the GraphQL type FuzzyDate.
+type savedListEntryCompletedAtFuzzyDate struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+	Day   int `json:"day"`
+}
+
+// GetYear returns savedListEntryCompletedAtFuzzyDate.Year, and is useful for accessing the field via an interface.
+func (v *savedListEntryCompletedAtFuzzyDate) GetYear() int { return v.Year }
+
+// GetMonth returns savedListEntryCompletedAtFuzzyDate.Month, and is useful for accessing the field via an interface.
+func (v *savedListEntryCompletedAtFuzzyDate) GetMonth() int { return v.Month }
+
+// GetDay returns savedListEntryCompletedAtFuzzyDate.Day, and is useful for accessing the field via an interface.
+func (v *savedListEntryCompletedAtFuzzyDate) GetDay() int { return v.Day }
+
+// savedListEntryStartedAtFuzzyDate includes the requested fields of the GraphQL type FuzzyDate.
+type savedListEntryStartedAtFuzzyDate struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+	Day   int `json:"day"`
+}
+
+// GetYear returns savedListEntryStartedAtFuzzyDate.Year, and is useful for accessing the field via an interface.
+func (v *savedListEntryStartedAtFuzzyDate) GetYear() int { return v.Year }
+
+// GetMonth returns savedListEntryStartedAtFuzzyDate.Month, and is useful for accessing the field via an interface.
+func (v *savedListEntryStartedAtFuzzyDate) GetMonth() int { return v.Month }
+
+// GetDay returns savedListEntryStartedAtFuzzyDate.Day, and is useful for accessing the field via an interface.
+func (v *savedListEntryStartedAtFuzzyDate) GetDay() int { return v.Day }
+
+// searchAnimePage includes the requested fields of the GraphQL type Page.
+type searchAnimePage struct {
+	Media []mediaWithEntry `json:"media"`
+}
+
+// GetMedia returns searchAnimePage.Media, and is useful for accessing the field via an interface.
+func (v *searchAnimePage) GetMedia() []mediaWithEntry { return v.Media }
+
+// searchAnimeResponse is returned by searchAnime on success.
+type searchAnimeResponse struct {
+	Page searchAnimePage `json:"Page"`
+}
+
+// GetPage returns searchAnimeResponse.Page, and is useful for accessing the field via an interface.
+func (v *searchAnimeResponse) GetPage() searchAnimePage { return v.Page }
+
+// viewer is the logged in user
+type viewer struct {
+	Id               int                                `json:"id"`
+	Name             string                             `json:"name"`
+	Avatar           viewerAvatarUserAvatar             `json:"avatar"`
+	SiteUrl          string                             `json:"siteUrl"`
+	Statistics       viewerStatisticsUserStatisticTypes `json:"statistics"`
+	Options          viewerOptionsUserOptions           `json:"options"`
+	MediaListOptions viewerMediaListOptions             `json:"mediaListOptions"`
+}
+
+// GetId returns viewer.Id, and is useful for accessing the field via an interface.
+func (v *viewer) GetId() int { return v.Id }
+
+// GetName returns viewer.Name, and is useful for accessing the field via an interface.
+func (v *viewer) GetName() string { return v.Name }
+
+// GetAvatar returns viewer.Avatar, and is useful for accessing the field via an interface.
+func (v *viewer) GetAvatar() viewerAvatarUserAvatar { return v.Avatar }
+
+// GetSiteUrl returns viewer.SiteUrl, and is useful for accessing the field via an interface.
+func (v *viewer) GetSiteUrl() string { return v.SiteUrl }
+
+// GetStatistics returns viewer.Statistics, and is useful for accessing the field via an interface.
+func (v *viewer) GetStatistics() viewerStatisticsUserStatisticTypes { return v.Statistics }
+
+// GetOptions returns viewer.Options, and is useful for accessing the field via an interface.
+func (v *viewer) GetOptions() viewerOptionsUserOptions { return v.Options }
+
+// GetMediaListOptions returns viewer.MediaListOptions, and is useful for accessing the field via an interface.
+func (v *viewer) GetMediaListOptions() viewerMediaListOptions { return v.MediaListOptions }
+
+// viewerAvatarUserAvatar includes the requested fields of the GraphQL type UserAvatar.
+type viewerAvatarUserAvatar struct {
+	Medium string `json:"medium"`
+}
+
+// GetMedium returns viewerAvatarUserAvatar.Medium, and is useful for accessing the field via an interface.
+func (v *viewerAvatarUserAvatar) GetMedium() string { return v.Medium }
+
+// viewerMediaListOptions includes the requested fields of the GraphQL type MediaListOptions.
+type viewerMediaListOptions struct {
+	ScoreFormat ScoreFormat `json:"scoreFormat"`
+}
+
+// GetScoreFormat returns viewerMediaListOptions.ScoreFormat, and is useful for accessing the field via an interface.
+func (v *viewerMediaListOptions) GetScoreFormat() ScoreFormat { return v.ScoreFormat }
+
+// viewerOptionsUserOptions includes the requested fields of the GraphQL type UserOptions.
+type viewerOptionsUserOptions struct {
+	TitleLanguage       UserTitleLanguage `json:"titleLanguage"`
+	DisplayAdultContent bool              `json:"displayAdultContent"`
+}
+
+// GetTitleLanguage returns viewerOptionsUserOptions.TitleLanguage, and is useful for accessing the field via an interface.
+func (v *viewerOptionsUserOptions) GetTitleLanguage() UserTitleLanguage { return v.TitleLanguage }
+
+// GetDisplayAdultContent returns viewerOptionsUserOptions.DisplayAdultContent, and is useful for accessing the field via an interface.
+func (v *viewerOptionsUserOptions) GetDisplayAdultContent() bool { return v.DisplayAdultContent }
+
+// viewerStatisticsUserStatisticTypes includes the requested fields of the GraphQL type UserStatisticTypes.
+type viewerStatisticsUserStatisticTypes struct {
+	Anime viewerStatisticsUserStatisticTypesAnimeUserStatistics `json:"anime"`
+	Manga viewerStatisticsUserStatisticTypesMangaUserStatistics `json:"manga"`
+}
+
+// GetAnime returns viewerStatisticsUserStatisticTypes.Anime, and is useful for accessing the field via an interface.
+func (v *viewerStatisticsUserStatisticTypes) GetAnime() viewerStatisticsUserStatisticTypesAnimeUserStatistics {
+	return v.Anime
+}
+
+// GetManga returns viewerStatisticsUserStatisticTypes.Manga, and is useful for accessing the field via an interface.
+func (v *viewerStatisticsUserStatisticTypes) GetManga() viewerStatisticsUserStatisticTypesMangaUserStatistics {
+	return v.Manga
+}
+
+// viewerStatisticsUserStatisticTypesAnimeUserStatistics includes the requested fields of the GraphQL type UserStatistics.
+type viewerStatisticsUserStatisticTypesAnimeUserStatistics struct {
+	Count           int `json:"count"`
+	EpisodesWatched int `json:"episodesWatched"`
+}
+
+// GetCount returns viewerStatisticsUserStatisticTypesAnimeUserStatistics.Count, and is useful for accessing the field via an interface.
+func (v *viewerStatisticsUserStatisticTypesAnimeUserStatistics) GetCount() int { return v.Count }
+
+// GetEpisodesWatched returns viewerStatisticsUserStatisticTypesAnimeUserStatistics.EpisodesWatched, and is useful for accessing the field via an interface.
+func (v *viewerStatisticsUserStatisticTypesAnimeUserStatistics) GetEpisodesWatched() int {
+	return v.EpisodesWatched
+}
+
+// viewerStatisticsUserStatisticTypesMangaUserStatistics includes the requested fields of the GraphQL type UserStatistics.
+type viewerStatisticsUserStatisticTypesMangaUserStatistics struct {
+	Count        int `json:"count"`
+	ChaptersRead int `json:"chaptersRead"`
+}
+
+// GetCount returns viewerStatisticsUserStatisticTypesMangaUserStatistics.Count, and is useful for accessing the field via an interface.
+func (v *viewerStatisticsUserStatisticTypesMangaUserStatistics) GetCount() int { return v.Count }
+
+// GetChaptersRead returns viewerStatisticsUserStatisticTypesMangaUserStatistics.ChaptersRead, and is useful for accessing the field via an interface.
+func (v *viewerStatisticsUserStatisticTypesMangaUserStatistics) GetChaptersRead() int {
+	return v.ChaptersRead
+}
+
+// The query executed by getAiringSchedule.
+const getAiringSchedule_Operation = `
+query getAiringSchedule ($id: Int) {
+	Media(id: $id, type: ANIME) {
+		airingSchedule(notYetAired: true, perPage: 50) {
+			nodes {
+				... airingSchedule
+			}
+		}
+	}
+}
+fragment airingSchedule on AiringSchedule {
+	episode
+	airingAt
+	timeUntilAiring
+}
+`
+
+func getAiringSchedule(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id int,
+) (data_ *getAiringScheduleResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "getAiringSchedule",
+		Query:  getAiringSchedule_Operation,
+		Variables: &__getAiringScheduleInput{
+			Id: id,
+		},
+	}
+
+	data_ = &getAiringScheduleResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by getAnime.
+const getAnime_Operation = `
+query getAnime ($id: Int, $lowBandwidth: Boolean!) {
+	Media(id: $id, type: ANIME) {
+		... mediaWithEntry
+		relations {
+			edges {
+				relationType
+				node {
+					id
+					type
+				}
+			}
+		}
+	}
+}
+fragment mediaWithEntry on Media {
+	... mediaFields
+	mediaListEntry {
+		... listEntry
+	}
+}
+fragment mediaFields on Media {
+	id
+	idMal
+	title {
+		romaji
+		english
+		native
+		userPreferred
+	}
+	episodes
+	duration
+	nextAiringEpisode {
+		... airingSchedule
+	}
+	status
+	format
+	season
+	seasonYear
+	averageScore
+	isAdult
+	countryOfOrigin
+	genres
+	coverImage @skip(if: $lowBandwidth) {
+		large
+	}
+	synonyms @skip(if: $lowBandwidth)
+	tags @skip(if: $lowBandwidth) {
+		name
+		rank
+		isMediaSpoiler
+	}
+}
+fragment listEntry on MediaList {
+	status
+	score
+	score10: score(format: POINT_10)
+	progress
+	startedAt {
+		year
+		month
+		day
+	}
+	completedAt {
+		year
+		month
+		day
+	}
+	notes
+	updatedAt
+	hiddenFromStatusLists
+	priority
+}
+fragment airingSchedule on AiringSchedule {
+	episode
+	airingAt
+	timeUntilAiring
+}
+`
+
+func getAnime(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id int,
+	lowBandwidth bool,
+) (data_ *getAnimeResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "getAnime",
+		Query:  getAnime_Operation,
+		Variables: &__getAnimeInput{
+			Id:           id,
+			LowBandwidth: lowBandwidth,
+		},
+	}
+
+	data_ = &getAnimeResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by getAnimeByIDs.
+const getAnimeByIDs_Operation = `
+query getAnimeByIDs ($ids: [Int], $lowBandwidth: Boolean!) {
+	Page(perPage: 50) {
+		media(id_in: $ids, type: ANIME) {
+			... mediaWithEntry
+		}
+	}
+}
+fragment mediaWithEntry on Media {
+	... mediaFields
+	mediaListEntry {
+		... listEntry
+	}
+}
+fragment mediaFields on Media {
+	id
+	idMal
+	title {
+		romaji
+		english
+		native
+		userPreferred
+	}
+	episodes
+	duration
+	nextAiringEpisode {
+		... airingSchedule
+	}
+	status
+	format
+	season
+	seasonYear
+	averageScore
+	isAdult
+	countryOfOrigin
+	genres
+	coverImage @skip(if: $lowBandwidth) {
+		large
+	}
+	synonyms @skip(if: $lowBandwidth)
+	tags @skip(if: $lowBandwidth) {
+		name
+		rank
+		isMediaSpoiler
+	}
+}
+fragment listEntry on MediaList {
+	status
+	score
+	score10: score(format: POINT_10)
+	progress
+	startedAt {
+		year
+		month
+		day
+	}
+	completedAt {
+		year
+		month
+		day
+	}
+	notes
+	updatedAt
+	hiddenFromStatusLists
+	priority
+}
+fragment airingSchedule on AiringSchedule {
+	episode
+	airingAt
+	timeUntilAiring
+}
+`
+
+func getAnimeByIDs(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	ids []int,
+	lowBandwidth bool,
+) (data_ *getAnimeByIDsResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "getAnimeByIDs",
+		Query:  getAnimeByIDs_Operation,
+		Variables: &__getAnimeByIDsInput{
+			Ids:          ids,
+			LowBandwidth: lowBandwidth,
+		},
+	}
+
+	data_ = &getAnimeByIDsResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by getAnimeByMalIDs.
+const getAnimeByMalIDs_Operation = `
+query getAnimeByMalIDs ($ids: [Int], $lowBandwidth: Boolean!) {
+	Page(perPage: 50) {
+		media(idMal_in: $ids, type: ANIME) {
+			... mediaWithEntry
+		}
+	}
+}
+fragment mediaWithEntry on Media {
+	... mediaFields
+	mediaListEntry {
+		... listEntry
+	}
+}
+fragment mediaFields on Media {
+	id
+	idMal
+	title {
+		romaji
+		english
+		native
+		userPreferred
+	}
+	episodes
+	duration
+	nextAiringEpisode {
+		... airingSchedule
+	}
+	status
+	format
+	season
+	seasonYear
+	averageScore
+	isAdult
+	countryOfOrigin
+	genres
+	coverImage @skip(if: $lowBandwidth) {
+		large
+	}
+	synonyms @skip(if: $lowBandwidth)
+	tags @skip(if: $lowBandwidth) {
+		name
+		rank
+		isMediaSpoiler
+	}
+}
+fragment listEntry on MediaList {
+	status
+	score
+	score10: score(format: POINT_10)
+	progress
+	startedAt {
+		year
+		month
+		day
+	}
+	completedAt {
+		year
+		month
+		day
+	}
+	notes
+	updatedAt
+	hiddenFromStatusLists
+	priority
+}
+fragment airingSchedule on AiringSchedule {
+	episode
+	airingAt
+	timeUntilAiring
+}
+`
+
+func getAnimeByMalIDs(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	ids []int,
+	lowBandwidth bool,
+) (data_ *getAnimeByMalIDsResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "getAnimeByMalIDs",
+		Query:  getAnimeByMalIDs_Operation,
+		Variables: &__getAnimeByMalIDsInput{
+			Ids:          ids,
+			LowBandwidth: lowBandwidth,
+		},
+	}
+
+	data_ = &getAnimeByMalIDsResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by getAnimeList.
+const getAnimeList_Operation = `
+query getAnimeList ($userId: Int, $lowBandwidth: Boolean!) {
+	MediaListCollection(userId: $userId, type: ANIME) {
+		lists {
+			entries {
+				... listEntry
+				media {
+					... mediaFields
+				}
+			}
+		}
+	}
+}
+fragment listEntry on MediaList {
+	status
+	score
+	score10: score(format: POINT_10)
+	progress
+	startedAt {
+		year
+		month
+		day
+	}
+	completedAt {
+		year
+		month
+		day
+	}
+	notes
+	updatedAt
+	hiddenFromStatusLists
+	priority
+}
+fragment mediaFields on Media {
+	id
+	idMal
+	title {
+		romaji
+		english
+		native
+		userPreferred
+	}
+	episodes
+	duration
+	nextAiringEpisode {
+		... airingSchedule
+	}
+	status
+	format
+	season
+	seasonYear
+	averageScore
+	isAdult
+	countryOfOrigin
+	genres
+	coverImage @skip(if: $lowBandwidth) {
+		large
+	}
+	synonyms @skip(if: $lowBandwidth)
+	tags @skip(if: $lowBandwidth) {
+		name
+		rank
+		isMediaSpoiler
+	}
+}
+fragment airingSchedule on AiringSchedule {
+	episode
+	airingAt
+	timeUntilAiring
+}
+`
+
+func getAnimeList(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	userId int,
+	lowBandwidth bool,
+) (data_ *getAnimeListResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "getAnimeList",
+		Query:  getAnimeList_Operation,
+		Variables: &__getAnimeListInput{
+			UserId:       userId,
+			LowBandwidth: lowBandwidth,
+		},
+	}
+
+	data_ = &getAnimeListResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by getStreamingEpisodes.
+const getStreamingEpisodes_Operation = `
+query getStreamingEpisodes ($id: Int) {
+	Media(id: $id, type: ANIME) {
+		streamingEpisodes {
+			title
+			site
+		}
+	}
+}
+`
+
+func getStreamingEpisodes(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id int,
+) (data_ *getStreamingEpisodesResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "getStreamingEpisodes",
+		Query:  getStreamingEpisodes_Operation,
+		Variables: &__getStreamingEpisodesInput{
+			Id: id,
+		},
+	}
+
+	data_ = &getStreamingEpisodesResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by getViewer.
+const getViewer_Operation = `
+query getViewer {
+	Viewer {
+		... viewer
+	}
+}
+fragment viewer on User {
+	id
+	name
+	avatar {
+		medium
+	}
+	siteUrl
+	statistics {
+		anime {
+			count
+			episodesWatched
+		}
+		manga {
+			count
+			chaptersRead
+		}
+	}
+	options {
+		titleLanguage
+		displayAdultContent
+	}
+	mediaListOptions {
+		scoreFormat
+	}
+}
+`
+
+func getViewer(
+	ctx_ context.Context,
+	client_ graphql.Client,
+) (data_ *getViewerResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "getViewer",
+		Query:  getViewer_Operation,
+	}
+
+	data_ = &getViewerResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by saveListEntry.
+const saveListEntry_Operation = `
+mutation saveListEntry ($mediaId: Int, $status: MediaListStatus, $score: Float, $scoreRaw: Int, $progress: Int, $notes: String, $startedAt: FuzzyDateInput, $completedAt: FuzzyDateInput, $hiddenFromStatusLists: Boolean, $priority: Int) {
+	SaveMediaListEntry(mediaId: $mediaId, status: $status, score: $score, scoreRaw: $scoreRaw, progress: $progress, notes: $notes, startedAt: $startedAt, completedAt: $completedAt, hiddenFromStatusLists: $hiddenFromStatusLists, priority: $priority) {
+		... savedListEntry
+	}
+}
+fragment savedListEntry on MediaList {
+	id
+	mediaId
+	status
+	score
+	score10: score(format: POINT_10)
+	progress
+	notes
+	updatedAt
+	hiddenFromStatusLists
+	priority
+	startedAt {
+		year
+		month
+		day
+	}
+	completedAt {
+		year
+		month
+		day
+	}
+}
+`
+
+// saveListEntry only sends the variables that are set, so fields left out of an update keep their values.  A date with
+// none of its parts set clears the date.  score is in the user's score format, while scoreRaw is out of 100 whatever
+// their format.
+func saveListEntry(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	mediaId *int,
+	status *MediaListStatus,
+	score *float64,
+	scoreRaw *int,
+	progress *int,
+	notes *string,
+	startedAt *FuzzyDateInput,
+	completedAt *FuzzyDateInput,
+	hiddenFromStatusLists *bool,
+	priority *int,
+) (data_ *saveListEntryResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "saveListEntry",
+		Query:  saveListEntry_Operation,
+		Variables: &__saveListEntryInput{
+			MediaId:               mediaId,
+			Status:                status,
+			Score:                 score,
+			ScoreRaw:              scoreRaw,
+			Progress:              progress,
+			Notes:                 notes,
+			StartedAt:             startedAt,
+			CompletedAt:           completedAt,
+			HiddenFromStatusLists: hiddenFromStatusLists,
+			Priority:              priority,
+		},
+	}
+
+	data_ = &saveListEntryResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by saveTextActivity.
+const saveTextActivity_Operation = `
+mutation saveTextActivity ($text: String) {
+	SaveTextActivity(text: $text) {
+		id
+	}
+}
+`
+
+func saveTextActivity(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	text string,
+) (data_ *saveTextActivityResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "saveTextActivity",
+		Query:  saveTextActivity_Operation,
+		Variables: &__saveTextActivityInput{
+			Text: text,
+		},
+	}
+
+	data_ = &saveTextActivityResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by searchAnime.
+const searchAnime_Operation = `
+query searchAnime ($search: String, $isAdult: Boolean, $lowBandwidth: Boolean!) {
+	Page(perPage: 25) {
+		media(search: $search, type: ANIME, sort: SEARCH_MATCH, isAdult: $isAdult) {
+			... mediaWithEntry
+		}
+	}
+}
+fragment mediaWithEntry on Media {
+	... mediaFields
+	mediaListEntry {
+		... listEntry
+	}
+}
+fragment mediaFields on Media {
+	id
+	idMal
+	title {
+		romaji
+		english
+		native
+		userPreferred
+	}
+	episodes
+	duration
+	nextAiringEpisode {
+		... airingSchedule
+	}
+	status
+	format
+	season
+	seasonYear
+	averageScore
+	isAdult
+	countryOfOrigin
+	genres
+	coverImage @skip(if: $lowBandwidth) {
+		large
+	}
+	synonyms @skip(if: $lowBandwidth)
+	tags @skip(if: $lowBandwidth) {
+		name
+		rank
+		isMediaSpoiler
+	}
+}
+fragment listEntry on MediaList {
+	status
+	score
+	score10: score(format: POINT_10)
+	progress
+	startedAt {
+		year
+		month
+		day
+	}
+	completedAt {
+		year
+		month
+		day
+	}
+	notes
+	updatedAt
+	hiddenFromStatusLists
+	priority
+}
+fragment airingSchedule on AiringSchedule {
+	episode
+	airingAt
+	timeUntilAiring
+}
+`
+
+func searchAnime(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	search string,
+	isAdult *bool,
+	lowBandwidth bool,
+) (data_ *searchAnimeResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "searchAnime",
+		Query:  searchAnime_Operation,
+		Variables: &__searchAnimeInput{
+			Search:       search,
+			IsAdult:      isAdult,
+			LowBandwidth: lowBandwidth,
+		},
+	}
+
+	data_ = &searchAnimeResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
@@ -0,0 +1,179 @@
+package anilist
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+const (
+	// rateLimitLowWater is how many requests may be left in the window before further requests wait for it to reset
+	rateLimitLowWater = 2
+	// rateLimitMaxRetries is how many times a rate limited request is retried before the 429 is returned
+	rateLimitMaxRetries = 3
+	// rateLimitWindow is how long AniList's rate limit window lasts, used when it doesn't say when it resets
+	rateLimitWindow = time.Minute
+)
+
+// rateLimitTransport is an http.RoundTripper that follows AniList's rate limit headers.  Requests are delayed when
+// the window is close to running out, and requests that are rate limited anyway are retried once it resets.
+type rateLimitTransport struct {
+	next http.RoundTripper
+
+	mu        sync.Mutex
+	limit     int       // Requests allowed in each window, 0 if unknown
+	remaining int       // Requests left in the current window, -1 if unknown
+	resetAt   time.Time // When the current window resets
+
+	events chan time.Duration // Receives how long requests are being held back for whenever they are delayed
+}
+
+func newRateLimitTransport(next http.RoundTripper) *rateLimitTransport {
+	return &rateLimitTransport{
+		next:      next,
+		remaining: -1,
+		events:    make(chan time.Duration, 1),
+	}
+}
+
+// RoundTrip sends the request, waiting out the rate limit first if needed
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		if wait := t.waitBeforeRequest(time.Now()); wait > 0 {
+			log.Warn("Close to the AniList rate limit, delaying request", "wait", wait)
+			t.notify(wait)
+			if err := sleepContext(ctx, wait); err != nil {
+				return nil, err
+			}
+		}
+
+		attemptReq := req
+		if attempt > 0 {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		if err != nil {
+			return nil, err
+		}
+		t.update(resp.Header, time.Now())
+
+		// Only retry if the request can be sent again
+		if resp.StatusCode != http.StatusTooManyRequests || attempt >= rateLimitMaxRetries || req.GetBody == nil {
+			return resp, nil
+		}
+
+		wait := retryAfter(resp.Header, time.Now())
+		_ = resp.Body.Close()
+		log.Warn("Rate limited by AniList, retrying", "wait", wait, "attempt", attempt+1)
+		t.notify(wait)
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// RateLimited returns a channel that receives how long requests are being held back for each time they are delayed
+func (t *rateLimitTransport) RateLimited() <-chan time.Duration {
+	return t.events
+}
+
+// RateLimitStatus is the state of AniList's rate limit as of the last response
+type RateLimitStatus struct {
+	Limit     int       // Requests allowed in each window, 0 if AniList didn't say
+	Remaining int       // Requests left in the current window, -1 if no response has said yet
+	ResetAt   time.Time // When the current window resets
+}
+
+// Known reports whether any response has reported the rate limit yet
+func (s RateLimitStatus) Known() bool {
+	return s.Remaining >= 0
+}
+
+// Status returns the rate limit state as of the last response.  Once the window has reset, the full limit is
+// available again.
+func (t *rateLimitTransport) Status(now time.Time) RateLimitStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	status := RateLimitStatus{Limit: t.limit, Remaining: t.remaining, ResetAt: t.resetAt}
+	if status.Known() && !now.Before(t.resetAt) && t.limit > 0 {
+		status.Remaining = t.limit
+	}
+	return status
+}
+
+// waitBeforeRequest returns how long to wait before sending a request so as not to run out of the rate limit
+func (t *rateLimitTransport) waitBeforeRequest(now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.remaining < 0 || t.remaining > rateLimitLowWater || !now.Before(t.resetAt) {
+		return 0
+	}
+	return t.resetAt.Sub(now)
+}
+
+// update records the rate limit state from a response's headers
+func (t *rateLimitTransport) update(header http.Header, now time.Time) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.remaining = remaining
+	if limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit")); err == nil {
+		t.limit = limit
+	}
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		t.resetAt = time.Unix(reset, 0)
+	} else if !now.Before(t.resetAt) {
+		t.resetAt = now.Add(rateLimitWindow)
+	}
+}
+
+// notify reports a delay without blocking if nobody is listening
+func (t *rateLimitTransport) notify(wait time.Duration) {
+	select {
+	case t.events <- wait:
+	default:
+	}
+}
+
+// retryAfter returns how long a rate limited response asks to wait before retrying
+func retryAfter(header http.Header, now time.Time) time.Duration {
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		return max(0, time.Unix(reset, 0).Sub(now))
+	}
+	return rateLimitWindow
+}
+
+// sleepContext waits for the duration, returning early with the context's error if it is cancelled
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,94 @@
+package anilist
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitTransportRetries429(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("X-RateLimit-Remaining", "80")
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer server.Close()
+
+	transport := newRateLimitTransport(http.DefaultTransport)
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"query":"{}"}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, calls, "the rate limited request should be retried")
+	select {
+	case wait := <-transport.RateLimited():
+		assert.Zero(t, wait)
+	default:
+		t.Fatal("expected the delay to be reported")
+	}
+}
+
+func TestRateLimitTransportWaitsNearLimit(t *testing.T) {
+	transport := newRateLimitTransport(http.DefaultTransport)
+	now := time.Unix(1000, 0)
+
+	assert.Zero(t, transport.waitBeforeRequest(now), "no wait before the limit is known")
+
+	header := http.Header{}
+	header.Set("X-RateLimit-Remaining", "50")
+	transport.update(header, now)
+	assert.Zero(t, transport.waitBeforeRequest(now), "no wait with plenty of requests left")
+
+	header.Set("X-RateLimit-Remaining", "1")
+	header.Set("X-RateLimit-Reset", "1030")
+	transport.update(header, now)
+	assert.Equal(t, 30*time.Second, transport.waitBeforeRequest(now))
+	assert.Zero(t, transport.waitBeforeRequest(time.Unix(1030, 0)), "no wait once the window has reset")
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	header := http.Header{}
+	assert.Equal(t, rateLimitWindow, retryAfter(header, now))
+
+	header.Set("X-RateLimit-Reset", "1010")
+	assert.Equal(t, 10*time.Second, retryAfter(header, now))
+
+	header.Set("Retry-After", "5")
+	assert.Equal(t, 5*time.Second, retryAfter(header, now))
+}
+
+func TestRateLimitTransportStatus(t *testing.T) {
+	transport := newRateLimitTransport(http.DefaultTransport)
+	now := time.Unix(1000, 0)
+	assert.False(t, transport.Status(now).Known())
+
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "90")
+	header.Set("X-RateLimit-Remaining", "42")
+	header.Set("X-RateLimit-Reset", "1030")
+	transport.update(header, now)
+
+	status := transport.Status(now)
+	require.True(t, status.Known())
+	assert.Equal(t, 90, status.Limit)
+	assert.Equal(t, 42, status.Remaining)
+	assert.Equal(t, time.Unix(1030, 0), status.ResetAt)
+
+	assert.Equal(t, 90, transport.Status(now.Add(time.Minute)).Remaining, "the limit should be full once the window resets")
+}
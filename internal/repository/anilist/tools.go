@@ -0,0 +1,7 @@
+//go:build tools
+
+package anilist
+
+// Importing genqlient keeps it and the packages it needs in go.mod, so `go generate` can run it to regenerate
+// generated.go
+import _ "github.com/Khan/genqlient"
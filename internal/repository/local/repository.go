@@ -0,0 +1,369 @@
+// Package local keeps the anime list in a file on disk, for using Hisame without an AniList account.  Anime are still
+// looked up and searched on AniList, which needs no account.
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/store"
+)
+
+// listFileName is the name of the file the local list is kept in within the data dir
+const listFileName = "local_list.json"
+
+// ErrNoAccount is returned for features that need an AniList account, such as posting activities
+var ErrNoAccount = errors.New("not available without an AniList account")
+
+// storedList is the on-disk format of the local list
+type storedList struct {
+	Entries []*domain.Anime `json:"entries"`
+}
+
+// Repository is an AnimeRepository whose list entries are kept locally.  Everything that doesn't touch the list is
+// passed through to the catalog, with the local entries filled in.
+type Repository struct {
+	catalog domain.AnimeRepository
+
+	mu      sync.Mutex
+	file    store.File
+	entries map[int]*domain.Anime
+}
+
+// NewRepository creates a local repository backed by the given file, looking anime up in the catalog.  An empty path
+// keeps the list in memory only.
+//
+// The file is the only copy of the list, so if it can't be loaded it is moved aside before starting with an empty
+// list, rather than being overwritten by the next save.  If it can't be moved, the list is kept in memory only.
+func NewRepository(catalog domain.AnimeRepository, path string) *Repository {
+	r := &Repository{
+		catalog: catalog,
+		file:    store.NewFile(path),
+		entries: make(map[int]*domain.Anime),
+	}
+	if err := r.load(); err != nil {
+		backupPath := fmt.Sprintf("%s.%s.bak", path, time.Now().Format("20060102-150405"))
+		if renameErr := os.Rename(path, backupPath); renameErr != nil {
+			log.Error("Failed to load the local anime list, changes will not be saved so it isn't overwritten",
+				"path", path, "error", err, "backup_error", renameErr)
+			r.file = store.NewFile("")
+		} else {
+			log.Warn("Failed to load the local anime list, moved it aside and starting with an empty list",
+				"path", path, "backup", backupPath, "error", err)
+		}
+	}
+	return r
+}
+
+// NewDefaultRepository creates a local repository in the Hisame data dir
+func NewDefaultRepository(catalog domain.AnimeRepository) *Repository {
+	return NewRepository(catalog, store.DataPath(listFileName, "the local anime list"))
+}
+
+// GetAllAnimeList returns the local list.  The anime's details, such as their airing schedules, are refreshed from
+// AniList when it can be reached, otherwise the details from the last refresh are used.
+func (r *Repository) GetAllAnimeList(ctx context.Context) ([]*domain.Anime, error) {
+	r.mu.Lock()
+	ids := make([]int, 0, len(r.entries))
+	for id := range r.entries {
+		ids = append(ids, id)
+	}
+	r.mu.Unlock()
+
+	if len(ids) > 0 {
+		refreshed, err := r.catalog.GetAnimeByIDs(ctx, ids)
+		if err != nil {
+			log.Warn("Unable to refresh anime details, using the last known details", "error", err)
+		} else {
+			r.refreshMedia(refreshed)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list := make([]*domain.Anime, 0, len(r.entries))
+	for _, anime := range r.entries {
+		list = append(list, cloneAnime(anime))
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+
+	log.Info("Loaded local anime list", "count", len(list))
+	return list, nil
+}
+
+// refreshMedia replaces the stored details of the given anime, keeping their list entries
+func (r *Repository) refreshMedia(refreshed []*domain.Anime) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, anime := range refreshed {
+		entry, ok := r.entries[anime.ID]
+		if !ok {
+			continue
+		}
+		updated := cloneAnime(anime)
+		updated.UserData = entry.UserData
+		r.entries[anime.ID] = updated
+	}
+	if err := r.save(); err != nil {
+		log.Warn("Failed to save refreshed anime details", "error", err)
+	}
+}
+
+// UpdateUserAnimeData replaces the list entry of the anime with the given data
+func (r *Repository) UpdateUserAnimeData(ctx context.Context, id int, data *domain.UserAnimeData) error {
+	anime, err := r.entryFor(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	userData := *data
+	userData.Score10 = score10(userData.Score)
+	userData.UpdatedAt = time.Now().Unix()
+	anime.UserData = &userData
+	r.entries[id] = anime
+	return r.save()
+}
+
+// GetAnimeByID looks the anime up on AniList, with its local list entry
+func (r *Repository) GetAnimeByID(ctx context.Context, id int) (*domain.Anime, error) {
+	anime, err := r.catalog.GetAnimeByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.withEntries(anime)
+	return anime, nil
+}
+
+// SearchAnime searches AniList, with the local list entries of any results in the list
+func (r *Repository) SearchAnime(ctx context.Context, search string) ([]*domain.Anime, error) {
+	results, err := r.catalog.SearchAnime(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+	r.withEntries(results...)
+	return results, nil
+}
+
+// GetAnimeByMalIDs looks the anime up on AniList, with their local list entries
+func (r *Repository) GetAnimeByMalIDs(ctx context.Context, malIDs []int) ([]*domain.Anime, error) {
+	results, err := r.catalog.GetAnimeByMalIDs(ctx, malIDs)
+	if err != nil {
+		return nil, err
+	}
+	r.withEntries(results...)
+	return results, nil
+}
+
+// GetAnimeByIDs looks the anime up on AniList, with their local list entries
+func (r *Repository) GetAnimeByIDs(ctx context.Context, ids []int) ([]*domain.Anime, error) {
+	results, err := r.catalog.GetAnimeByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	r.withEntries(results...)
+	return results, nil
+}
+
+// UpdateAnime applies the update to the local list entry, adding the anime to the list if it isn't already
+func (r *Repository) UpdateAnime(ctx context.Context, params *domain.AnimeUpdateParams) (*domain.AnimeUpdateResult, error) {
+	anime, err := r.entryFor(ctx, params.MediaID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := r.apply(anime, params)
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+
+	log.Info("Updated local list entry", "mediaId", result.MediaID, "status", result.Status, "progress", result.Progress)
+	return result, nil
+}
+
+// UpdateAnimeBatch applies each update to the local list, saving them together.  The results are in the same order
+// as params.
+func (r *Repository) UpdateAnimeBatch(ctx context.Context, params []*domain.AnimeUpdateParams) ([]*domain.AnimeUpdateResult, error) {
+	entries := make([]*domain.Anime, len(params))
+	for i, p := range params {
+		anime, err := r.entryFor(ctx, p.MediaID)
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = anime
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results := make([]*domain.AnimeUpdateResult, len(params))
+	for i, p := range params {
+		results[i] = r.apply(entries[i], p)
+	}
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+
+	log.Info("Updated local list entries in a batch", "count", len(results))
+	return results, nil
+}
+
+// PostTextActivity always fails, as there is no account to post to
+func (r *Repository) PostTextActivity(context.Context, string) error {
+	return fmt.Errorf("failed to post text activity: %w", ErrNoAccount)
+}
+
+// GetStreamingEpisodes looks the episodes up on AniList
+func (r *Repository) GetStreamingEpisodes(ctx context.Context, id int) ([]domain.StreamingEpisode, error) {
+	return r.catalog.GetStreamingEpisodes(ctx, id)
+}
+
+// GetAiringSchedule looks the schedule up on AniList
+func (r *Repository) GetAiringSchedule(ctx context.Context, id int) ([]domain.AiringSchedule, error) {
+	return r.catalog.GetAiringSchedule(ctx, id)
+}
+
+// entryFor returns a copy of the anime's list entry to update.  Anime not yet in the list are looked up on AniList, and
+// start as Planning like a new AniList entry.
+func (r *Repository) entryFor(ctx context.Context, id int) (*domain.Anime, error) {
+	r.mu.Lock()
+	anime, ok := r.entries[id]
+	r.mu.Unlock()
+	if ok {
+		return cloneAnime(anime), nil
+	}
+
+	anime, err := r.catalog.GetAnimeByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up anime %d to add it to the list: %w", id, err)
+	}
+	anime.UserData = &domain.UserAnimeData{Status: domain.StatusPlanning}
+	return anime, nil
+}
+
+// apply updates the entry with the params, storing it in the list.  r.mu must be held.
+func (r *Repository) apply(anime *domain.Anime, params *domain.AnimeUpdateParams) *domain.AnimeUpdateResult {
+	data := anime.UserData
+	if params.Status != "" {
+		data.Status = domain.MediaStatus(params.Status)
+	}
+	if params.Progress != nil {
+		data.Progress = *params.Progress
+	}
+	if params.Score != nil {
+		data.Score = *params.Score
+		data.Score10 = score10(data.Score)
+	}
+	if params.ScoreRaw != nil {
+		data.Score = float64(*params.ScoreRaw)
+		data.Score10 = score10(data.Score)
+	}
+	if params.Notes != nil {
+		data.Notes = *params.Notes
+	}
+	if params.StartedAt != nil {
+		data.StartDate = params.StartedAt.String()
+	}
+	if params.CompletedAt != nil {
+		data.EndDate = params.CompletedAt.String()
+	}
+	if params.HiddenFromStatusLists != nil {
+		data.HiddenFromStatusLists = *params.HiddenFromStatusLists
+	}
+	if params.Priority != nil {
+		data.Priority = *params.Priority
+	}
+	data.UpdatedAt = time.Now().Unix()
+	r.entries[anime.ID] = anime
+
+	return &domain.AnimeUpdateResult{
+		EntryID:               anime.ID,
+		MediaID:               anime.ID,
+		Status:                data.Status,
+		Progress:              data.Progress,
+		Score:                 data.Score,
+		Score10:               data.Score10,
+		Notes:                 data.Notes,
+		UpdatedAt:             int(data.UpdatedAt),
+		StartDate:             data.StartDate,
+		CompletionDate:        data.EndDate,
+		HiddenFromStatusLists: data.HiddenFromStatusLists,
+		Priority:              data.Priority,
+	}
+}
+
+// score10 converts a score on the local list, which is out of 100, to a whole number out of 10
+func score10(score float64) int {
+	return int(math.Round(score / 10))
+}
+
+// withEntries fills in the local list entries of the anime, leaving anime not in the list without one
+func (r *Repository) withEntries(anime ...*domain.Anime) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, a := range anime {
+		a.UserData = nil
+		if entry, ok := r.entries[a.ID]; ok {
+			userData := *entry.UserData
+			a.UserData = &userData
+		}
+	}
+}
+
+// cloneAnime copies the anime and its list entry, so the service can change its copy without changing the stored list
+func cloneAnime(anime *domain.Anime) *domain.Anime {
+	clone := *anime
+	if anime.UserData != nil {
+		userData := *anime.UserData
+		clone.UserData = &userData
+	}
+	return &clone
+}
+
+func (r *Repository) load() error {
+	var stored storedList
+	if err := r.file.Load(&stored); err != nil {
+		return fmt.Errorf("failed to load local anime list: %w", err)
+	}
+	for _, anime := range stored.Entries {
+		if anime.UserData == nil {
+			continue
+		}
+		r.entries[anime.ID] = anime
+	}
+	return nil
+}
+
+// save persists the list.  r.mu must be held.
+func (r *Repository) save() error {
+	stored := storedList{Entries: make([]*domain.Anime, 0, len(r.entries))}
+	for _, anime := range r.entries {
+		stored.Entries = append(stored.Entries, anime)
+	}
+	sort.Slice(stored.Entries, func(i, j int) bool {
+		return stored.Entries[i].ID < stored.Entries[j].ID
+	})
+
+	if err := r.file.Save(stored); err != nil {
+		return fmt.Errorf("failed to save local anime list: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCatalog is an AniList catalog that knows of a fixed set of anime
+type fakeCatalog struct {
+	domain.AnimeRepository
+	anime   map[int]*domain.Anime
+	offline bool
+}
+
+func newFakeCatalog(anime ...*domain.Anime) *fakeCatalog {
+	c := &fakeCatalog{anime: make(map[int]*domain.Anime)}
+	for _, a := range anime {
+		c.anime[a.ID] = a
+	}
+	return c
+}
+
+func (c *fakeCatalog) GetAnimeByID(_ context.Context, id int) (*domain.Anime, error) {
+	if c.offline {
+		return nil, errors.New("offline")
+	}
+	anime, ok := c.anime[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	clone := *anime
+	return &clone, nil
+}
+
+func (c *fakeCatalog) GetAnimeByIDs(_ context.Context, ids []int) ([]*domain.Anime, error) {
+	if c.offline {
+		return nil, errors.New("offline")
+	}
+	var results []*domain.Anime
+	for _, id := range ids {
+		if anime, ok := c.anime[id]; ok {
+			clone := *anime
+			results = append(results, &clone)
+		}
+	}
+	return results, nil
+}
+
+func (c *fakeCatalog) SearchAnime(context.Context, string) ([]*domain.Anime, error) {
+	var results []*domain.Anime
+	for _, anime := range c.anime {
+		clone := *anime
+		results = append(results, &clone)
+	}
+	return results, nil
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestUpdateAnimeAddsAndPersistsEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), listFileName)
+	catalog := newFakeCatalog(&domain.Anime{ID: 1, Title: domain.AnimeTitle{Preferred: "Frieren"}, Episodes: 28})
+	repo := NewRepository(catalog, path)
+
+	result, err := repo.UpdateAnime(context.Background(), &domain.AnimeUpdateParams{
+		MediaID:   1,
+		Status:    string(domain.StatusCurrent),
+		Progress:  intPtr(3),
+		StartedAt: &domain.FuzzyDate{Year: 2026, Month: 10, Day: 1},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCurrent, result.Status)
+	assert.Equal(t, 3, result.Progress)
+	assert.Equal(t, "2026-10-01", result.StartDate)
+
+	catalog.offline = true
+	list, err := NewRepository(catalog, path).GetAllAnimeList(context.Background())
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "Frieren", list[0].Title.Preferred)
+	assert.Equal(t, 3, list[0].UserData.Progress)
+	assert.Equal(t, domain.StatusCurrent, list[0].UserData.Status)
+}
+
+func TestNewRepositoryMovesUnreadableListAside(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, listFileName)
+	require.NoError(t, os.WriteFile(path, []byte(`{"entries": [`), 0600))
+
+	catalog := newFakeCatalog(&domain.Anime{ID: 1, Episodes: 12})
+	repo := NewRepository(catalog, path)
+	_, err := repo.UpdateAnime(context.Background(), &domain.AnimeUpdateParams{MediaID: 1, Progress: intPtr(1)})
+	require.NoError(t, err)
+
+	backups, err := filepath.Glob(filepath.Join(dir, listFileName+".*.bak"))
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	data, err := os.ReadFile(backups[0])
+	require.NoError(t, err)
+	assert.Equal(t, `{"entries": [`, string(data), "the unreadable list should be kept as it was")
+
+	list, err := NewRepository(catalog, path).GetAllAnimeList(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+}
+
+func TestUpdateAnimeOfflineNewEntry(t *testing.T) {
+	catalog := newFakeCatalog()
+	catalog.offline = true
+	repo := NewRepository(catalog, "")
+
+	_, err := repo.UpdateAnime(context.Background(), &domain.AnimeUpdateParams{MediaID: 1, Progress: intPtr(1)})
+	assert.Error(t, err)
+}
+
+func TestGetAllAnimeListRefreshesDetails(t *testing.T) {
+	catalog := newFakeCatalog(&domain.Anime{ID: 1, Episodes: 0})
+	repo := NewRepository(catalog, "")
+	_, err := repo.UpdateAnime(context.Background(), &domain.AnimeUpdateParams{MediaID: 1, Progress: intPtr(2)})
+	require.NoError(t, err)
+
+	catalog.anime[1] = &domain.Anime{ID: 1, Episodes: 12}
+	list, err := repo.GetAllAnimeList(context.Background())
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, 12, list[0].Episodes)
+	assert.Equal(t, 2, list[0].UserData.Progress)
+	assert.Equal(t, domain.StatusPlanning, list[0].UserData.Status)
+}
+
+func TestGetAllAnimeListReturnsCopies(t *testing.T) {
+	repo := NewRepository(newFakeCatalog(&domain.Anime{ID: 1}), "")
+	_, err := repo.UpdateAnime(context.Background(), &domain.AnimeUpdateParams{MediaID: 1, Progress: intPtr(2)})
+	require.NoError(t, err)
+
+	list, err := repo.GetAllAnimeList(context.Background())
+	require.NoError(t, err)
+	list[0].UserData.Progress = 10
+
+	list, err = repo.GetAllAnimeList(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, list[0].UserData.Progress)
+}
+
+func TestSearchAnimeFillsInLocalEntries(t *testing.T) {
+	repo := NewRepository(newFakeCatalog(&domain.Anime{ID: 1}, &domain.Anime{ID: 2}), "")
+	_, err := repo.UpdateAnime(context.Background(), &domain.AnimeUpdateParams{MediaID: 2, Status: string(domain.StatusCompleted)})
+	require.NoError(t, err)
+
+	results, err := repo.SearchAnime(context.Background(), "anything")
+	require.NoError(t, err)
+	for _, anime := range results {
+		if anime.ID == 2 {
+			require.NotNil(t, anime.UserData)
+			assert.Equal(t, domain.StatusCompleted, anime.UserData.Status)
+		} else {
+			assert.Nil(t, anime.UserData)
+		}
+	}
+}
+
+func TestPostTextActivityNeedsAccount(t *testing.T) {
+	err := NewRepository(newFakeCatalog(), "").PostTextActivity(context.Background(), "Completed Frieren")
+	assert.ErrorIs(t, err, ErrNoAccount)
+}
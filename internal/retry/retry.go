@@ -0,0 +1,138 @@
+// Package retry retries requests that fail with transient network errors, backing off exponentially between attempts
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+const (
+	defaultBaseDelay = 500 * time.Millisecond
+	defaultMaxDelay  = 8 * time.Second
+)
+
+// Policy describes how many times, and how far apart, a failed request is retried
+type Policy struct {
+	MaxRetries int           // Retries after the first attempt.  Zero or less disables retrying
+	BaseDelay  time.Duration // Delay before the first retry, doubled for each retry after it
+	MaxDelay   time.Duration // Upper bound on the delay between attempts
+}
+
+// FromConfig builds a policy from the config, using the defaults for any delay that is missing or can't be parsed
+func FromConfig(cfg config.RetryConfig) Policy {
+	return Policy{
+		MaxRetries: cfg.MaxRetries,
+		BaseDelay:  parseDelay("base_delay", cfg.BaseDelay, defaultBaseDelay),
+		MaxDelay:   parseDelay("max_delay", cfg.MaxDelay, defaultMaxDelay),
+	}
+}
+
+func parseDelay(name, value string, fallback time.Duration) time.Duration {
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		log.Warn("Invalid retry delay in config, using the default", "setting", name, "value", value, "default", fallback)
+		return fallback
+	}
+	return d
+}
+
+// Do calls fn, calling it again after a backoff delay each time it fails with a transient error, until it succeeds,
+// fails permanently, runs out of retries or the context is done.  The last error is returned.
+func (p Policy) Do(ctx context.Context, operation string, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= p.MaxRetries || ctx.Err() != nil || !IsTransient(err) {
+			return err
+		}
+
+		delay := p.Backoff(attempt, rand.Float64())
+		log.Warn("Request failed with a transient error, retrying", "operation", operation, "attempt", attempt+1,
+			"delay", delay, "error", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// Backoff returns the delay before the given retry (0 for the first).  The delay doubles with each retry up to
+// MaxDelay, and jitter (0 to 1) spreads it across its upper half so clients that failed together don't retry together.
+func (p Policy) Backoff(attempt int, jitter float64) time.Duration {
+	delay := p.BaseDelay
+	for i := 0; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, p.MaxDelay)
+	return delay/2 + time.Duration(jitter*float64(delay/2))
+}
+
+// StatusError is returned in place of a response with a 5xx status, so server errors can be retried
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("server returned HTTP %d", e.StatusCode)
+}
+
+// IsTransient reports whether a request that failed with err is likely to succeed if tried again: timeouts, dropped
+// connections and server errors.
+func IsTransient(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusInternalServerError
+	}
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// serverErrorTransport turns 5xx responses into a StatusError, as GraphQL clients otherwise only report that the
+// response body couldn't be decoded
+type serverErrorTransport struct {
+	next http.RoundTripper
+}
+
+// NewTransport wraps an http.RoundTripper so that 5xx responses are returned as a *StatusError
+func NewTransport(next http.RoundTripper) http.RoundTripper {
+	return &serverErrorTransport{next: next}
+}
+
+func (t *serverErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		_ = resp.Body.Close()
+		return nil, &StatusError{StatusCode: resp.StatusCode}
+	}
+	return resp, nil
+}
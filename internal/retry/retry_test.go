@@ -0,0 +1,83 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoRetriesTransientErrors(t *testing.T) {
+	policy := Policy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+	calls := 0
+	err := policy.Do(context.Background(), "test", func() error {
+		calls++
+		if calls < 3 {
+			return &StatusError{StatusCode: http.StatusBadGateway}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	err = policy.Do(context.Background(), "test", func() error {
+		calls++
+		return errors.New("graphql: invalid query")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls, "permanent errors should not be retried")
+
+	calls = 0
+	err = policy.Do(context.Background(), "test", func() error {
+		calls++
+		return &StatusError{StatusCode: http.StatusServiceUnavailable}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 4, calls, "should stop after the last retry")
+}
+
+func TestBackoff(t *testing.T) {
+	policy := Policy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	assert.Equal(t, 500*time.Millisecond, policy.Backoff(0, 0))
+	assert.Equal(t, time.Second, policy.Backoff(0, 1))
+	assert.Equal(t, 2*time.Second, policy.Backoff(1, 1))
+	assert.Equal(t, 4*time.Second, policy.Backoff(2, 1))
+	assert.Equal(t, 5*time.Second, policy.Backoff(10, 1), "delay should be capped")
+}
+
+func TestIsTransient(t *testing.T) {
+	assert.True(t, IsTransient(fmt.Errorf("failed: %w", &StatusError{StatusCode: http.StatusInternalServerError})))
+	assert.False(t, IsTransient(&StatusError{StatusCode: http.StatusBadRequest}))
+	assert.True(t, IsTransient(context.DeadlineExceeded))
+	assert.False(t, IsTransient(context.Canceled))
+	assert.False(t, IsTransient(errors.New("graphql: not found")))
+}
+
+func TestTransportReturnsServerErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+	_, err := client.Get(server.URL)
+
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusServiceUnavailable, statusErr.StatusCode)
+}
+
+func TestFromConfig(t *testing.T) {
+	policy := FromConfig(config.RetryConfig{MaxRetries: 2, BaseDelay: "250ms", MaxDelay: "nonsense"})
+	assert.Equal(t, Policy{MaxRetries: 2, BaseDelay: 250 * time.Millisecond, MaxDelay: defaultMaxDelay}, policy)
+}
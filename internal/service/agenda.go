@@ -0,0 +1,38 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+)
+
+// GetAiringAgenda returns the anime being watched whose next episode airs within the given window, earliest first.
+// Episodes that have aired since the list was loaded are included too, so they can be offered for playback.  Snoozed
+// anime are left out, and nothing is returned while the whole agenda is snoozed.
+func (s *AnimeService) GetAiringAgenda(window time.Duration) []*domain.Anime {
+	now := time.Now()
+	if !s.snoozes.AllUntil(now).IsZero() {
+		return nil
+	}
+	earliest := now.Add(-window).Unix()
+	latest := now.Add(window).Unix()
+
+	var result []*domain.Anime
+	for _, anime := range s.animeList {
+		if anime.UserData == nil || anime.NextAiringEp == nil || s.IsHidden(anime.ID) || s.snoozes.IsSnoozed(anime.ID, now) {
+			continue
+		}
+		if anime.UserData.Status != domain.StatusCurrent && anime.UserData.Status != domain.StatusRepeating {
+			continue
+		}
+		if anime.NextAiringEp.AiringAt >= earliest && anime.NextAiringEp.AiringAt <= latest {
+			result = append(result, anime)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].NextAiringEp.AiringAt < result[j].NextAiringEp.AiringAt
+	})
+	return result
+}
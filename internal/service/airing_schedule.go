@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+)
+
+// GetAiringSchedule returns the episodes of an anime still to air, in broadcast order.  Anime that have finished or
+// have no scheduled episodes return an empty schedule.
+func (s *AnimeService) GetAiringSchedule(ctx context.Context, animeID int) ([]domain.AiringSchedule, error) {
+	schedule, err := s.repo.GetAiringSchedule(ctx, animeID)
+	if err != nil {
+		return nil, err
+	}
+	return upcomingEpisodes(schedule, time.Now()), nil
+}
+
+// upcomingEpisodes sorts the schedule by episode and drops episodes that aired before now.  The time until each
+// episode airs is worked out from now, as AniList's is only correct at the moment it answered.
+func upcomingEpisodes(schedule []domain.AiringSchedule, now time.Time) []domain.AiringSchedule {
+	upcoming := make([]domain.AiringSchedule, 0, len(schedule))
+	for _, ep := range schedule {
+		if ep.AiringAt <= now.Unix() {
+			continue
+		}
+		ep.TimeUntilAir = ep.AiringAt - now.Unix()
+		upcoming = append(upcoming, ep)
+	}
+	sort.Slice(upcoming, func(i, j int) bool {
+		return upcoming[i].Episode < upcoming[j].Episode
+	})
+	return upcoming
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpcomingEpisodes(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	week := int64(7 * 24 * 60 * 60)
+
+	upcoming := upcomingEpisodes([]domain.AiringSchedule{
+		{Episode: 9, AiringAt: now.Unix() + 2*week, TimeUntilAir: 1},
+		{Episode: 7, AiringAt: now.Unix() - 60},
+		{Episode: 8, AiringAt: now.Unix() + week},
+	}, now)
+
+	assert.Equal(t, []domain.AiringSchedule{
+		{Episode: 8, AiringAt: now.Unix() + week, TimeUntilAir: week},
+		{Episode: 9, AiringAt: now.Unix() + 2*week, TimeUntilAir: 2 * week},
+	}, upcoming)
+}
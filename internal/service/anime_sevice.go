@@ -6,6 +6,8 @@ import (
 	"github.com/PizzaHomicide/hisame/internal/domain"
 	"github.com/PizzaHomicide/hisame/internal/log"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type AnimeService struct {
@@ -13,11 +15,27 @@ type AnimeService struct {
 	// TODO consider a map for faster access when looking for a specific anime by ID
 	animeList  []*domain.Anime // Keeps a local copy of all the anime, only updating it on user request
 	updateLock sync.Mutex
+	hidden     *HiddenEntries // Entries the user has hidden from Hisame, kept locally rather than on AniList
+	lastLoad   RefreshSummary // What changed the last time the list was loaded
+	backups    *Backups       // Snapshots of entries taken before batch operations change them
+	listCache  *ListCache     // Last fetched list, shown on startup while it is refreshed.  Nil disables caching
+	cachedAt   time.Time      // When the list being shown was fetched, if it came from the cache
+	offline    *OfflineQueue  // Changes made while AniList couldn't be reached
+	snoozes    *Snoozes       // What the user has snoozed in the airing agenda
+
+	// Guards optimistic progress changes, separately from updateLock so they show while earlier updates are saving
+	pendingLock sync.Mutex
+	pending     pendingUpdates
+	saving      atomic.Int32 // Number of changes other than optimistic progress changes waiting on AniList
 }
 
 func NewAnimeService(repo domain.AnimeRepository) *AnimeService {
 	return &AnimeService{
-		repo: repo,
+		repo:    repo,
+		hidden:  newDefaultHiddenEntries(),
+		backups: newDefaultBackups(),
+		offline: newDefaultOfflineQueue(),
+		snoozes: newDefaultSnoozes(),
 	}
 }
 
@@ -32,10 +50,21 @@ func (s *AnimeService) LoadAnimeList(ctx context.Context) error {
 		return err
 	}
 
+	s.lastLoad = summariseRefresh(s.animeList, list, s.IsHidden)
+	if !s.lastLoad.IsEmpty() {
+		log.Info("Anime list changed since last load", "summary", s.lastLoad.String())
+	}
 	s.animeList = list
+	s.cachedAt = time.Time{}
+	s.saveListCache(list)
 	return nil
 }
 
+// LastRefreshSummary returns what changed in the list the last time it was loaded
+func (s *AnimeService) LastRefreshSummary() RefreshSummary {
+	return s.lastLoad
+}
+
 // GetAnimeListByStatus filters the cached anime list by status
 func (s *AnimeService) GetAnimeListByStatus(status domain.MediaStatus) []*domain.Anime {
 	var result []*domain.Anime
@@ -62,6 +91,20 @@ func (s *AnimeService) GetAnimeByID(id int) *domain.Anime {
 // IncrementProgress increases the progress for an anime by 1
 // Returns an error if progress is already at or above episode count
 func (s *AnimeService) IncrementProgress(ctx context.Context, animeID int) error {
+	return s.advanceProgress(ctx, animeID, 0)
+}
+
+// AdvanceProgress sets the progress for an anime to the episode just watched, for when the episodes before it were
+// skipped, such as filler.  Progress never goes backwards: an episode at or before the current progress increments it
+// by 1 as IncrementProgress does.
+func (s *AnimeService) AdvanceProgress(ctx context.Context, animeID, episode int) error {
+	return s.advanceProgress(ctx, animeID, episode)
+}
+
+// advanceProgress increases the progress for an anime to the episode, or by 1 if that would be less
+func (s *AnimeService) advanceProgress(ctx context.Context, animeID, episode int) error {
+	s.saving.Add(1)
+	defer s.saving.Add(-1)
 	s.updateLock.Lock()
 	defer s.updateLock.Unlock()
 
@@ -81,7 +124,10 @@ func (s *AnimeService) IncrementProgress(ctx context.Context, animeID int) error
 	}
 
 	// Calculate new progress
-	newProgress := currentProgress + 1
+	newProgress := max(currentProgress+1, episode)
+	if totalEpisodes > 0 {
+		newProgress = min(newProgress, totalEpisodes)
+	}
 
 	// Create update parameters
 	progressValue := newProgress // Using a variable because we need its address
@@ -90,13 +136,31 @@ func (s *AnimeService) IncrementProgress(ctx context.Context, animeID int) error
 		Progress: &progressValue,
 	}
 
+	// Don't overwrite the entry if it was changed on AniList since it was loaded
+	local := EntryState{Status: anime.UserData.Status, Progress: newProgress}
+	if err := s.checkForConflict(ctx, anime, *anime.UserData, local); err != nil {
+		return err
+	}
+
 	// Send update to repository
 	result, err := s.repo.UpdateAnime(ctx, params)
+	if err != nil && isOffline(err) {
+		base := entryState(*anime.UserData)
+		if queueErr := s.queueOffline(anime, local, base); queueErr != nil {
+			log.Warn("Failed to queue change", "animeID", animeID, "error", queueErr)
+			return fmt.Errorf("failed to update progress: %w", err)
+		}
+		anime.UserData.Progress = newProgress
+		return fmt.Errorf("%w: %v", ErrQueuedOffline, err)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to update progress: %w", err)
 	}
 
 	s.syncAnimeWithUpdateResult(anime, result)
+	if err := s.offline.Remove(animeID); err != nil {
+		log.Warn("Failed to remove superseded queued change", "animeID", animeID, "error", err)
+	}
 
 	// Log basic info about the update
 	log.Info("Incremented anime progress",
@@ -122,65 +186,22 @@ func (s *AnimeService) IncrementProgress(ctx context.Context, animeID int) error
 	return nil
 }
 
-// DecrementProgress decreases the progress for an anime by 1
-// Returns an error if progress is already 0
-func (s *AnimeService) DecrementProgress(ctx context.Context, animeID int) error {
-	s.updateLock.Lock()
-	defer s.updateLock.Unlock()
-
-	// Find the anime in our cached list
+// PostCompletionActivity posts a "Completed X" text activity to AniList for the given anime, including the user's
+// score if they have given one
+func (s *AnimeService) PostCompletionActivity(ctx context.Context, animeID int) error {
 	anime := s.GetAnimeByID(animeID)
 	if anime == nil {
 		return fmt.Errorf("anime not found with ID: %d", animeID)
 	}
 
-	// Get current values
-	currentProgress := anime.UserData.Progress
-	totalEpisodes := anime.Episodes
-
-	// Validate if we can decrement
-	if currentProgress <= 0 {
-		return fmt.Errorf("cannot decrement progress: already at 0 episodes")
+	text := fmt.Sprintf("Completed %s", anime.Title.Preferred)
+	if anime.UserData != nil && anime.UserData.Score > 0 {
+		text = fmt.Sprintf("%s — scored %g", text, anime.UserData.Score)
 	}
 
-	// Calculate new progress
-	newProgress := currentProgress - 1
-
-	// Create update parameters
-	progressValue := newProgress // Using a variable because we need its address
-	params := &domain.AnimeUpdateParams{
-		MediaID:  animeID,
-		Progress: &progressValue,
-	}
-
-	// Send update to repository
-	result, err := s.repo.UpdateAnime(ctx, params)
-	if err != nil {
-		return fmt.Errorf("failed to update progress: %w", err)
-	}
-
-	s.syncAnimeWithUpdateResult(anime, result)
-
-	// Log basic info about the update
-	log.Info("Decremented anime progress",
-		"animeID", animeID,
-		"title", anime.Title.Preferred,
-		"progress", fmt.Sprintf("%d/%d", result.Progress, totalEpisodes),
-		"status", result.Status)
-
-	// Special case - if anime was completed and is now un-completed
-	previouslyCompleted := anime.UserData.Status == domain.StatusCompleted &&
-		currentProgress == totalEpisodes &&
-		newProgress < totalEpisodes
-
-	if previouslyCompleted && result.Status != domain.StatusCompleted {
-		log.Info("Anime un-completed",
-			"title", anime.Title.Preferred,
-			"id", animeID,
-			"new_status", result.Status)
-	}
-
-	return nil
+	s.saving.Add(1)
+	defer s.saving.Add(-1)
+	return s.repo.PostTextActivity(ctx, text)
 }
 
 // syncAnimeWithUpdateResult updates the cached anime data with values from an update result
@@ -193,9 +214,13 @@ func (s *AnimeService) syncAnimeWithUpdateResult(anime *domain.Anime, result *do
 	anime.UserData.Status = result.Status
 	anime.UserData.Progress = result.Progress
 	anime.UserData.Score = result.Score
+	anime.UserData.Score10 = result.Score10
 	anime.UserData.Notes = result.Notes
 	anime.UserData.StartDate = result.StartDate
 	anime.UserData.EndDate = result.CompletionDate
+	anime.UserData.UpdatedAt = int64(result.UpdatedAt)
+	anime.UserData.HiddenFromStatusLists = result.HiddenFromStatusLists
+	anime.UserData.Priority = result.Priority
 
 	log.Debug("Synchronized local anime data with update result",
 		"animeID", anime.ID,
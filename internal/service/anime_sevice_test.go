@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvanceProgressSkipsToEpisodeWatched(t *testing.T) {
+	anime := testAnime(1, domain.StatusCurrent, 3)
+	anime.Episodes = 12
+	anime.UserData.UpdatedAt = 100
+	repo := &fakeRepo{remote: map[int]*domain.Anime{
+		1: remoteEntry(1, domain.UserAnimeData{Status: domain.StatusCurrent, Progress: 3, UpdatedAt: 100}),
+	}}
+	s := &AnimeService{repo: repo, animeList: []*domain.Anime{anime}, offline: NewOfflineQueue("")}
+
+	require.NoError(t, s.AdvanceProgress(context.Background(), 1, 7))
+	require.Len(t, repo.updates, 1)
+	assert.Equal(t, 7, *repo.updates[0].Progress, "the filler skipped before the episode should count as watched")
+
+	repo.remote[1] = remoteEntry(1, domain.UserAnimeData{
+		Status: domain.StatusCurrent, Progress: 7, UpdatedAt: anime.UserData.UpdatedAt,
+	})
+	require.NoError(t, s.AdvanceProgress(context.Background(), 1, 2))
+	assert.Equal(t, 8, *repo.updates[1].Progress, "an earlier episode should only increment progress")
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// staleWatchingThreshold is how long a finished show can sit in Watching with no list activity before it is flagged
+const staleWatchingThreshold = 90 * 24 * time.Hour
+
+// AuditFindingKind identifies the type of inconsistency found in a list entry
+type AuditFindingKind string
+
+const (
+	// AuditProgressOverTotal is an entry with more progress than the anime has episodes
+	AuditProgressOverTotal AuditFindingKind = "progress_over_total"
+	// AuditCompletedPartial is a completed entry that hasn't had every episode marked as watched
+	AuditCompletedPartial AuditFindingKind = "completed_partial"
+	// AuditStaleWatching is a finished show still in Watching with no recent activity
+	AuditStaleWatching AuditFindingKind = "stale_watching"
+)
+
+// AuditFinding is a single inconsistency found in the user's list, along with the fix that would be applied
+type AuditFinding struct {
+	Anime       *domain.Anime
+	Kind        AuditFindingKind
+	Description string // What is wrong with the entry
+	Fix         string // What applying the fix will do
+}
+
+// AuditList scans the cached anime list for inconsistent entries
+func (s *AnimeService) AuditList() []AuditFinding {
+	var findings []AuditFinding
+	now := time.Now()
+
+	for _, anime := range s.animeList {
+		data := anime.UserData
+		if data == nil || s.IsHidden(anime.ID) {
+			continue
+		}
+
+		switch {
+		case anime.Episodes > 0 && data.Progress > anime.Episodes:
+			findings = append(findings, AuditFinding{
+				Anime:       anime,
+				Kind:        AuditProgressOverTotal,
+				Description: fmt.Sprintf("Progress %d is more than the %d episodes", data.Progress, anime.Episodes),
+				Fix:         fmt.Sprintf("Set progress to %d", anime.Episodes),
+			})
+		case data.Status == domain.StatusCompleted && anime.Episodes > 0 && data.Progress < anime.Episodes:
+			findings = append(findings, AuditFinding{
+				Anime:       anime,
+				Kind:        AuditCompletedPartial,
+				Description: fmt.Sprintf("Completed with only %d/%d episodes watched", data.Progress, anime.Episodes),
+				Fix:         fmt.Sprintf("Set progress to %d", anime.Episodes),
+			})
+		case data.Status == domain.StatusCurrent && anime.Status == "FINISHED" &&
+			data.UpdatedAt > 0 && now.Sub(time.Unix(data.UpdatedAt, 0)) > staleWatchingThreshold:
+			findings = append(findings, AuditFinding{
+				Anime: anime,
+				Kind:  AuditStaleWatching,
+				Description: fmt.Sprintf("Finished airing, no activity since %s",
+					time.Unix(data.UpdatedAt, 0).Format("2006-01-02")),
+				Fix: "Move to Paused",
+			})
+		}
+	}
+
+	log.Info("Audited anime list", "entries", len(s.animeList), "findings", len(findings))
+	return findings
+}
+
+// ApplyAuditFix applies the suggested fix for a finding and updates the cached entry
+func (s *AnimeService) ApplyAuditFix(ctx context.Context, finding AuditFinding) error {
+	s.saving.Add(1)
+	defer s.saving.Add(-1)
+	s.updateLock.Lock()
+	defer s.updateLock.Unlock()
+
+	anime := finding.Anime
+	params := &domain.AnimeUpdateParams{MediaID: anime.ID}
+
+	switch finding.Kind {
+	case AuditProgressOverTotal, AuditCompletedPartial:
+		progress := anime.Episodes
+		params.Progress = &progress
+	case AuditStaleWatching:
+		params.Status = string(domain.StatusPaused)
+	default:
+		return fmt.Errorf("no fix available for finding: %s", finding.Kind)
+	}
+
+	result, err := s.repo.UpdateAnime(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to apply fix: %w", err)
+	}
+
+	s.syncAnimeWithUpdateResult(anime, result)
+	log.Info("Applied audit fix", "animeID", anime.ID, "title", anime.Title.Preferred, "kind", finding.Kind)
+	return nil
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// backupDirName is the directory within the data dir that list snapshots are written to
+const backupDirName = "backups"
+
+// BackupEntry is the state of a list entry at the time a backup was taken
+type BackupEntry struct {
+	AnimeID   int                `json:"anime_id"`
+	Title     string             `json:"title"`
+	Status    domain.MediaStatus `json:"status"`
+	Score     float64            `json:"score"`
+	Progress  int                `json:"progress"`
+	StartDate string             `json:"start_date,omitempty"`
+	EndDate   string             `json:"end_date,omitempty"`
+	Notes     string             `json:"notes,omitempty"`
+}
+
+// Backup is a snapshot of list entries taken before they were changed in bulk
+type Backup struct {
+	Path      string        `json:"-"`
+	Reason    string        `json:"reason"` // What was about to change the entries, e.g. "completion date backfill"
+	CreatedAt time.Time     `json:"created_at"`
+	Entries   []BackupEntry `json:"entries"`
+}
+
+// RestoreResult summarises the outcome of restoring a backup
+type RestoreResult struct {
+	Restored int
+	Failed   int
+}
+
+// Backups writes and reads list snapshots, one timestamped JSON file per backup
+type Backups struct {
+	dir string
+}
+
+// NewBackups creates a backup store writing to the given directory.  An empty dir disables backups.
+func NewBackups(dir string) *Backups {
+	return &Backups{dir: dir}
+}
+
+// newDefaultBackups creates a backup store in the Hisame data dir
+func newDefaultBackups() *Backups {
+	dataDir, err := config.DataDir()
+	if err != nil {
+		log.Warn("Unable to locate data dir, list backups will not be written", "error", err)
+		return NewBackups("")
+	}
+	return NewBackups(filepath.Join(dataDir, backupDirName))
+}
+
+// Snapshot writes the current state of the given entries to a new backup file
+func (b *Backups) Snapshot(reason string, list []*domain.Anime, now time.Time) (*Backup, error) {
+	if b.dir == "" {
+		return nil, fmt.Errorf("no backup directory available")
+	}
+
+	backup := &Backup{
+		Reason:    reason,
+		CreatedAt: now,
+	}
+	for _, anime := range list {
+		if anime.UserData == nil {
+			continue
+		}
+		backup.Entries = append(backup.Entries, BackupEntry{
+			AnimeID:   anime.ID,
+			Title:     anime.Title.Preferred,
+			Status:    anime.UserData.Status,
+			Score:     anime.UserData.Score,
+			Progress:  anime.UserData.Progress,
+			StartDate: anime.UserData.StartDate,
+			EndDate:   anime.UserData.EndDate,
+			Notes:     anime.UserData.Notes,
+		})
+	}
+
+	if err := os.MkdirAll(b.dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create backup dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal backup: %w", err)
+	}
+
+	slug := strings.ReplaceAll(strings.ToLower(reason), " ", "-")
+	backup.Path = filepath.Join(b.dir, fmt.Sprintf("%s-%s.json", now.UTC().Format("20060102-150405.000"), slug))
+	if err := os.WriteFile(backup.Path, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	log.Info("Wrote list backup", "path", backup.Path, "reason", reason, "entries", len(backup.Entries))
+	return backup, nil
+}
+
+// List returns every backup, newest first.  Files that can't be read are skipped.
+func (b *Backups) List() ([]Backup, error) {
+	if b.dir == "" {
+		return nil, nil
+	}
+
+	files, err := os.ReadDir(b.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []Backup
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		path := filepath.Join(b.dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Warn("Failed to read backup", "path", path, "error", err)
+			continue
+		}
+
+		var backup Backup
+		if err := json.Unmarshal(data, &backup); err != nil {
+			log.Warn("Failed to parse backup", "path", path, "error", err)
+			continue
+		}
+		backup.Path = path
+		backups = append(backups, backup)
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+	return backups, nil
+}
+
+// snapshotBeforeBatch backs up the given entries before a batch operation changes them.  A failed backup is logged
+// rather than stopping the operation.
+func (s *AnimeService) snapshotBeforeBatch(reason string, animeIDs []int) {
+	var list []*domain.Anime
+	for _, id := range animeIDs {
+		if anime := s.GetAnimeByID(id); anime != nil {
+			list = append(list, anime)
+		}
+	}
+	if len(list) == 0 {
+		return
+	}
+
+	if _, err := s.backups.Snapshot(reason, list, time.Now()); err != nil {
+		log.Warn("Failed to back up entries before batch update", "reason", reason, "error", err)
+	}
+}
+
+// GetBackups returns the list backups that can be restored, newest first
+func (s *AnimeService) GetBackups() ([]Backup, error) {
+	return s.backups.List()
+}
+
+// RestoreBackup replays the values in a backup to AniList.  The entries' current values are backed up first, so a
+// restore can itself be undone.  Entries are restored one by one so a single failure doesn't abort the rest.
+func (s *AnimeService) RestoreBackup(ctx context.Context, backup Backup) (RestoreResult, error) {
+	ids := make([]int, 0, len(backup.Entries))
+	for _, entry := range backup.Entries {
+		ids = append(ids, entry.AnimeID)
+	}
+
+	s.saving.Add(1)
+	defer s.saving.Add(-1)
+	s.updateLock.Lock()
+	defer s.updateLock.Unlock()
+
+	s.snapshotBeforeBatch("before restore", ids)
+
+	params := make([]*domain.AnimeUpdateParams, 0, len(backup.Entries))
+	for _, entry := range backup.Entries {
+		progress, score, notes := entry.Progress, entry.Score, entry.Notes
+		startedAt, completedAt := domain.ParseFuzzyDate(entry.StartDate), domain.ParseFuzzyDate(entry.EndDate)
+		params = append(params, &domain.AnimeUpdateParams{
+			MediaID:     entry.AnimeID,
+			Status:      string(entry.Status),
+			Progress:    &progress,
+			Score:       &score,
+			Notes:       &notes,
+			StartedAt:   &startedAt,
+			CompletedAt: &completedAt,
+		})
+	}
+
+	var result RestoreResult
+	updateResults, errs := s.updateBatch(ctx, params)
+	for i, entry := range backup.Entries {
+		if errs[i] != nil {
+			log.Warn("Failed to restore entry", "animeID", entry.AnimeID, "title", entry.Title, "error", errs[i])
+			result.Failed++
+			continue
+		}
+
+		s.syncAnimeWithUpdateResult(s.GetAnimeByID(entry.AnimeID), updateResults[i])
+		result.Restored++
+	}
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	log.Info("Restored list backup", "path", backup.Path, "restored", result.Restored, "failed", result.Failed)
+	return result, nil
+}
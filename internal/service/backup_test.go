@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupsSnapshotAndList(t *testing.T) {
+	backups := NewBackups(t.TempDir())
+
+	first := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	_, err := backups.Snapshot("completion date backfill", []*domain.Anime{testAnime(1, domain.StatusCompleted, 12)}, first)
+	require.NoError(t, err)
+	_, err = backups.Snapshot("before restore", []*domain.Anime{
+		testAnime(1, domain.StatusCompleted, 12, withEndDate("2024-05-01")),
+		{ID: 2}, // Not in the list, so has nothing to back up
+	}, first.Add(time.Hour))
+	require.NoError(t, err)
+
+	list, err := backups.List()
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "before restore", list[0].Reason, "newest backups should be first")
+	assert.Len(t, list[0].Entries, 1)
+	assert.Equal(t, "2024-05-01", list[0].Entries[0].EndDate)
+	assert.NotEmpty(t, list[1].Path)
+}
+
+func TestRestoreBackup(t *testing.T) {
+	repo := &fakeRepo{}
+	anime := testAnime(1, domain.StatusCompleted, 12, withEndDate("2024-05-01"))
+	s := &AnimeService{
+		repo:      repo,
+		animeList: []*domain.Anime{anime},
+		hidden:    NewHiddenEntries(""),
+		backups:   NewBackups(t.TempDir()),
+	}
+
+	result, err := s.RestoreBackup(context.Background(), Backup{
+		Entries: []BackupEntry{{AnimeID: 1, Status: domain.StatusCurrent, Progress: 10, StartDate: "2024-04"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, RestoreResult{Restored: 1}, result)
+
+	require.Len(t, repo.updates, 1)
+	params := repo.updates[0]
+	assert.Equal(t, domain.FuzzyDate{Year: 2024, Month: 4}, *params.StartedAt)
+	assert.Equal(t, domain.FuzzyDate{}, *params.CompletedAt, "dates missing from the backup should be cleared")
+	assert.Equal(t, 10, anime.UserData.Progress)
+
+	backups, err := s.GetBackups()
+	require.NoError(t, err)
+	require.Len(t, backups, 1, "the current values should be backed up before restoring")
+	assert.Equal(t, 12, backups[0].Entries[0].Progress)
+}
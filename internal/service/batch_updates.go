@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// maxBatchSize is how many entries are saved per request.  Kept small to stay well inside AniList's query complexity
+// limit.
+const maxBatchSize = 10
+
+// updateBatch saves the changes to AniList, sending several in each request to cut round trips and rate limit
+// pressure.  A batch that fails is retried one entry at a time, so one bad entry doesn't fail the rest of its batch.
+// This is safe as each change sets absolute values, so saving an entry AniList already saved changes nothing.
+//
+// Returns a result or an error for each change, in the same order.  Callers must hold updateLock.
+func (s *AnimeService) updateBatch(ctx context.Context, params []*domain.AnimeUpdateParams) ([]*domain.AnimeUpdateResult, []error) {
+	results := make([]*domain.AnimeUpdateResult, len(params))
+	errs := make([]error, len(params))
+
+	for start := 0; start < len(params); start += maxBatchSize {
+		end := min(start+maxBatchSize, len(params))
+		if err := ctx.Err(); err != nil {
+			for i := start; i < len(params); i++ {
+				errs[i] = err
+			}
+			break
+		}
+
+		batch := params[start:end]
+		batchResults, err := s.repo.UpdateAnimeBatch(ctx, batch)
+		if err == nil {
+			copy(results[start:end], batchResults)
+			continue
+		}
+		if len(batch) == 1 {
+			errs[start] = err
+			continue
+		}
+
+		log.Warn("Batch update failed, saving its entries one at a time", "count", len(batch), "error", err)
+		for i, p := range batch {
+			results[start+i], errs[start+i] = s.repo.UpdateAnime(ctx, p)
+		}
+	}
+	return results, errs
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func progressParams(count int) []*domain.AnimeUpdateParams {
+	params := make([]*domain.AnimeUpdateParams, count)
+	for i := range params {
+		progress := i
+		params[i] = &domain.AnimeUpdateParams{MediaID: i + 1, Progress: &progress}
+	}
+	return params
+}
+
+func TestUpdateBatchSplitsIntoRequests(t *testing.T) {
+	repo := &fakeRepo{}
+	s := &AnimeService{repo: repo}
+
+	results, errs := s.updateBatch(context.Background(), progressParams(maxBatchSize*2+5))
+	assert.Equal(t, 3, repo.batches)
+	assert.Len(t, repo.updates, maxBatchSize*2+5)
+	for i, result := range results {
+		assert.NoError(t, errs[i])
+		assert.Equal(t, i+1, result.MediaID, "results should be in the same order as the changes")
+	}
+}
+
+func TestUpdateBatchFallsBackToSingleUpdates(t *testing.T) {
+	repo := &fakeRepo{failBatch: true}
+	s := &AnimeService{repo: repo}
+
+	results, errs := s.updateBatch(context.Background(), progressParams(3))
+	assert.Equal(t, 1, repo.batches)
+	assert.Len(t, repo.updates, 3, "each change should be sent on its own after the batch failed")
+	for i := range results {
+		assert.NoError(t, errs[i])
+		assert.Equal(t, i+1, results[i].MediaID)
+	}
+}
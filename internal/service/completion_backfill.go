@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// CompletionDateSource determines which date is used when backfilling a missing completion date
+type CompletionDateSource string
+
+const (
+	// CompletionDateFromUpdatedAt uses the date the list entry was last updated
+	CompletionDateFromUpdatedAt CompletionDateSource = "updated_at"
+	// CompletionDateToday uses today's date
+	CompletionDateToday CompletionDateSource = "today"
+	// CompletionDateManual uses a date supplied by the user
+	CompletionDateManual CompletionDateSource = "manual"
+)
+
+// BackfillResult summarises the outcome of a completion date backfill
+type BackfillResult struct {
+	Updated int
+	Skipped int // Entries with no usable date, e.g. no updatedAt recorded
+	Failed  int
+}
+
+// GetMissingCompletionDates returns all completed entries in the cached list that have no completion date set
+func (s *AnimeService) GetMissingCompletionDates() []*domain.Anime {
+	var result []*domain.Anime
+	for _, anime := range s.animeList {
+		if anime.UserData != nil && anime.UserData.Status == domain.StatusCompleted && anime.UserData.EndDate == "" {
+			result = append(result, anime)
+		}
+	}
+	return result
+}
+
+// BackfillCompletionDates sets the completion date of each given anime using the chosen date source.  `manualDate` is
+// only used with CompletionDateManual.  Entries are updated one by one so a single failure doesn't abort the batch.
+func (s *AnimeService) BackfillCompletionDates(ctx context.Context, animeIDs []int, source CompletionDateSource, manualDate time.Time) (BackfillResult, error) {
+	s.saving.Add(1)
+	defer s.saving.Add(-1)
+	s.updateLock.Lock()
+	defer s.updateLock.Unlock()
+
+	s.snapshotBeforeBatch("completion date backfill", animeIDs)
+
+	var result BackfillResult
+	now := time.Now()
+
+	var toUpdate []*domain.Anime
+	var params []*domain.AnimeUpdateParams
+	for _, animeID := range animeIDs {
+		anime := s.GetAnimeByID(animeID)
+		if anime == nil || anime.UserData == nil {
+			result.Skipped++
+			continue
+		}
+
+		var date time.Time
+		switch source {
+		case CompletionDateFromUpdatedAt:
+			if anime.UserData.UpdatedAt == 0 {
+				log.Debug("Skipping completion date backfill, no updatedAt", "animeID", animeID)
+				result.Skipped++
+				continue
+			}
+			date = time.Unix(anime.UserData.UpdatedAt, 0)
+		case CompletionDateToday:
+			date = now
+		case CompletionDateManual:
+			date = manualDate
+		default:
+			return result, fmt.Errorf("unknown completion date source: %s", source)
+		}
+
+		toUpdate = append(toUpdate, anime)
+		params = append(params, &domain.AnimeUpdateParams{
+			MediaID: animeID,
+			CompletedAt: &domain.FuzzyDate{
+				Year:  date.Year(),
+				Month: int(date.Month()),
+				Day:   date.Day(),
+			},
+		})
+	}
+
+	updateResults, errs := s.updateBatch(ctx, params)
+	for i, anime := range toUpdate {
+		if errs[i] != nil {
+			log.Warn("Failed to backfill completion date", "animeID", anime.ID, "title", anime.Title.Preferred, "error", errs[i])
+			result.Failed++
+			continue
+		}
+
+		s.syncAnimeWithUpdateResult(anime, updateResults[i])
+		result.Updated++
+	}
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	log.Info("Backfilled completion dates", "source", source, "updated", result.Updated,
+		"skipped", result.Skipped, "failed", result.Failed)
+	return result, nil
+}
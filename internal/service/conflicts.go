@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// ErrRemoteConflict is returned when a change wasn't saved because the entry was changed on AniList since it was
+// loaded, e.g. from the website
+var ErrRemoteConflict = errors.New("the entry was changed on AniList since it was loaded")
+
+// ConflictError is returned instead of saving a change to an entry that was changed on AniList since it was loaded.
+// The change is queued, to be settled with ResolveOfflineChange like a change made offline.
+type ConflictError struct {
+	Conflict Reconciliation
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s was changed on AniList since it was loaded", e.Conflict.Change.Title)
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrRemoteConflict
+}
+
+// checkForConflict fetches the entry from AniList before a change is saved, and if it was changed there since known
+// was loaded, queues the change instead of overwriting AniList's.  The cached entry is updated to AniList's, and a
+// ConflictError is returned.  Further changes to an entry with a conflict waiting to be settled are queued too, so
+// they don't overwrite AniList's entry before the conflict is settled.
+//
+// The check is skipped if AniList can't be reached, leaving the save itself to fail and queue the change.
+func (s *AnimeService) checkForConflict(ctx context.Context, anime *domain.Anime, known domain.UserAnimeData, local EntryState) error {
+	if queued, ok := s.offline.Get(anime.ID); ok && queued.Remote {
+		return s.queueConflict(anime, queued.Base, local)
+	}
+	if known.UpdatedAt == 0 {
+		return nil
+	}
+
+	fresh, err := s.repo.GetAnimeByID(ctx, anime.ID)
+	if err != nil {
+		log.Debug("Unable to check entry for changes on AniList", "animeID", anime.ID, "error", err)
+		return nil
+	}
+	if fresh.UserData == nil || fresh.UserData.UpdatedAt <= known.UpdatedAt {
+		return nil
+	}
+
+	remote := entryState(*fresh.UserData)
+	if remote == entryState(known) {
+		// Only fields progress changes don't touch, such as notes, were changed, so there is nothing to settle
+		return nil
+	}
+
+	log.Info("Entry was changed on AniList since it was loaded", "animeID", anime.ID, "title", anime.Title.Preferred,
+		"remote_progress", remote.Progress, "remote_status", remote.Status, "local_progress", local.Progress)
+	s.pendingLock.Lock()
+	*anime.UserData = *fresh.UserData
+	s.pendingLock.Unlock()
+	return s.queueConflict(anime, entryState(known), local)
+}
+
+// queueConflict queues a change that conflicts with AniList's entry, returning the ConflictError to report it
+func (s *AnimeService) queueConflict(anime *domain.Anime, base, local EntryState) error {
+	if err := s.offline.Add(QueuedChange{
+		AnimeID:  anime.ID,
+		Title:    anime.Title.Preferred,
+		Local:    local,
+		Base:     base,
+		Remote:   true,
+		QueuedAt: time.Now(),
+	}); err != nil {
+		return fmt.Errorf("%w, and the change couldn't be kept: %v", ErrRemoteConflict, err)
+	}
+
+	change, _ := s.offline.Get(anime.ID)
+	return &ConflictError{Conflict: Reconciliation{Change: change, Remote: entryState(*anime.UserData)}}
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncrementProgressConflictsWithRemoteChange(t *testing.T) {
+	anime := testAnime(1, domain.StatusCurrent, 3)
+	anime.UserData.UpdatedAt = 100
+	repo := &fakeRepo{remote: map[int]*domain.Anime{
+		1: remoteEntry(1, domain.UserAnimeData{Status: domain.StatusCurrent, Progress: 7, UpdatedAt: 200}),
+	}}
+	s := &AnimeService{repo: repo, animeList: []*domain.Anime{anime}, offline: NewOfflineQueue("")}
+
+	err := s.IncrementProgress(context.Background(), 1)
+	var conflict *ConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.ErrorIs(t, err, ErrRemoteConflict)
+	assert.Empty(t, repo.updates, "the remote change should not be overwritten")
+	assert.Equal(t, 7, anime.UserData.Progress, "the cached entry should show AniList's version")
+	assert.Equal(t, EntryState{Status: domain.StatusCurrent, Progress: 4}, conflict.Conflict.Change.Local)
+	assert.Equal(t, EntryState{Status: domain.StatusCurrent, Progress: 7}, conflict.Conflict.Remote)
+
+	// Further changes wait for the conflict to be settled too
+	err = s.IncrementProgress(context.Background(), 1)
+	require.ErrorAs(t, err, &conflict)
+	assert.Empty(t, repo.updates)
+	assert.Equal(t, 8, conflict.Conflict.Change.Local.Progress)
+	assert.Equal(t, 3, conflict.Conflict.Change.Base.Progress)
+}
+
+func TestProgressUpdateConflictsWithRemoteChange(t *testing.T) {
+	anime := testAnime(1, domain.StatusCurrent, 3)
+	anime.UserData.UpdatedAt = 100
+	repo := &fakeRepo{remote: map[int]*domain.Anime{
+		1: remoteEntry(1, domain.UserAnimeData{Status: domain.StatusCompleted, Progress: 12, UpdatedAt: 200}),
+	}}
+	s := &AnimeService{repo: repo, animeList: []*domain.Anime{anime}, offline: NewOfflineQueue("")}
+
+	update, err := s.BeginProgressUpdate(1, 1)
+	require.NoError(t, err)
+	err = s.CommitProgressUpdate(context.Background(), update)
+	assert.ErrorIs(t, err, ErrRemoteConflict)
+	assert.Empty(t, repo.updates)
+	assert.False(t, s.IsUpdatePending(1))
+	assert.Equal(t, domain.StatusCompleted, anime.UserData.Status)
+	require.Len(t, s.QueuedChanges(), 1)
+}
+
+func TestProgressUpdateIgnoresUnrelatedRemoteChange(t *testing.T) {
+	anime := testAnime(1, domain.StatusCurrent, 3)
+	anime.UserData.UpdatedAt = 100
+	repo := &fakeRepo{remote: map[int]*domain.Anime{
+		1: remoteEntry(1, domain.UserAnimeData{
+			Status: domain.StatusCurrent, Progress: 3, Notes: "edited on the website", UpdatedAt: 200,
+		}),
+	}}
+	s := &AnimeService{repo: repo, animeList: []*domain.Anime{anime}, offline: NewOfflineQueue("")}
+
+	update, err := s.BeginProgressUpdate(1, 1)
+	require.NoError(t, err)
+	require.NoError(t, s.CommitProgressUpdate(context.Background(), update))
+	require.Len(t, repo.updates, 1)
+	assert.Empty(t, s.QueuedChanges())
+}
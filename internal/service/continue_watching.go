@@ -0,0 +1,30 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+)
+
+// GetContinueWatching returns the anime being watched that have an aired episode still to watch, the most recently
+// watched first, going by when each list entry was last updated.  At most limit anime are returned.
+func (s *AnimeService) GetContinueWatching(limit int) []*domain.Anime {
+	var result []*domain.Anime
+	for _, anime := range s.animeList {
+		if anime.UserData == nil || s.IsHidden(anime.ID) || !anime.HasUnwatchedEpisodes() {
+			continue
+		}
+		if anime.UserData.Status != domain.StatusCurrent && anime.UserData.Status != domain.StatusRepeating {
+			continue
+		}
+		result = append(result, anime)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].UserData.UpdatedAt > result[j].UserData.UpdatedAt
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetContinueWatching(t *testing.T) {
+	entry := func(id int, status domain.MediaStatus, progress, episodes int, updatedAt int64) *domain.Anime {
+		return &domain.Anime{
+			ID:       id,
+			Episodes: episodes,
+			UserData: &domain.UserAnimeData{Status: status, Progress: progress, UpdatedAt: updatedAt},
+		}
+	}
+	s := &AnimeService{
+		animeList: []*domain.Anime{
+			entry(1, domain.StatusCurrent, 3, 12, 100),
+			entry(2, domain.StatusCurrent, 12, 12, 500), // Caught up
+			entry(3, domain.StatusRepeating, 1, 12, 300),
+			entry(4, domain.StatusPlanning, 0, 12, 400),
+			entry(5, domain.StatusCurrent, 5, 12, 200),
+			entry(6, domain.StatusCurrent, 5, 12, 600),
+		},
+		hidden: NewHiddenEntries(""),
+	}
+	assert.NoError(t, s.hidden.Hide(6, "Hidden show"))
+
+	var ids []int
+	for _, anime := range s.GetContinueWatching(10) {
+		ids = append(ids, anime.ID)
+	}
+	assert.Equal(t, []int{3, 5, 1}, ids)
+
+	assert.Len(t, s.GetContinueWatching(2), 2)
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+)
+
+// streamingEpisodeTitle matches AniList streaming episode titles such as "Episode 3 - Killing Magic"
+var streamingEpisodeTitle = regexp.MustCompile(`(?i)^episode\s+(\d+)\s*[-–:]\s*(.+)$`)
+
+// GetEpisodeTitles returns the titles of an anime's episodes by episode number, where AniList lists them from
+// official streaming sites.  Anime without streaming episodes return an empty map.
+func (s *AnimeService) GetEpisodeTitles(ctx context.Context, animeID int) (map[int]string, error) {
+	episodes, err := s.repo.GetStreamingEpisodes(ctx, animeID)
+	if err != nil {
+		return nil, err
+	}
+	return parseEpisodeTitles(episodes), nil
+}
+
+// parseEpisodeTitles extracts episode numbers and titles from streaming episodes.  The same episode is often listed
+// by several sites, in which case the first title is kept.
+func parseEpisodeTitles(episodes []domain.StreamingEpisode) map[int]string {
+	titles := make(map[int]string)
+	for _, ep := range episodes {
+		match := streamingEpisodeTitle.FindStringSubmatch(strings.TrimSpace(ep.Title))
+		if match == nil {
+			continue
+		}
+		number, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		if _, ok := titles[number]; !ok {
+			titles[number] = strings.TrimSpace(match[2])
+		}
+	}
+	return titles
+}
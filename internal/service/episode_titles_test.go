@@ -0,0 +1,24 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEpisodeTitles(t *testing.T) {
+	titles := parseEpisodeTitles([]domain.StreamingEpisode{
+		{Title: "Episode 1 - The Journey's End", Site: "Crunchyroll"},
+		{Title: "Episode 2 - It Didn't Have to Be Magic...", Site: "Crunchyroll"},
+		{Title: "Episode 1 - A Different Translation", Site: "Hulu"},
+		{Title: "Episode 10: A Powerful Mage", Site: "Netflix"},
+		{Title: "Special Preview", Site: "Crunchyroll"},
+	})
+
+	assert.Equal(t, map[int]string{
+		1:  "The Journey's End",
+		2:  "It Didn't Have to Be Magic...",
+		10: "A Powerful Mage",
+	}, titles)
+}
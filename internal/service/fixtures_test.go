@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+)
+
+// fakeRepo is an in-memory AnimeRepository for the service tests.  It records the updates sent to it, and serves
+// the entries in remote as they are on AniList.
+type fakeRepo struct {
+	domain.AnimeRepository
+	list      []*domain.Anime       // The list returned by GetAllAnimeList
+	remote    map[int]*domain.Anime // Entries as they are on AniList, by AniList ID
+	updateErr error                 // Returned by updates instead of saving them
+	failBatch bool                  // Whether batch requests fail
+	updates   []*domain.AnimeUpdateParams
+	batches   int // Number of batch requests made
+}
+
+func (r *fakeRepo) GetAllAnimeList(context.Context) ([]*domain.Anime, error) {
+	return r.list, nil
+}
+
+// GetAnimeByID returns a copy of the remote entry, so that changes to it aren't made on "AniList" too
+func (r *fakeRepo) GetAnimeByID(_ context.Context, id int) (*domain.Anime, error) {
+	anime, ok := r.remote[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	remote := *anime
+	if anime.UserData != nil {
+		userData := *anime.UserData
+		remote.UserData = &userData
+	}
+	return &remote, nil
+}
+
+func (r *fakeRepo) GetAnimeByMalIDs(_ context.Context, malIDs []int) ([]*domain.Anime, error) {
+	var result []*domain.Anime
+	for _, malID := range malIDs {
+		for _, anime := range r.remote {
+			if anime.IDMal == malID {
+				result = append(result, anime)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeRepo) UpdateAnime(_ context.Context, params *domain.AnimeUpdateParams) (*domain.AnimeUpdateResult, error) {
+	if r.updateErr != nil {
+		return nil, r.updateErr
+	}
+	r.updates = append(r.updates, params)
+	result := &domain.AnimeUpdateResult{
+		MediaID: params.MediaID,
+		Status:  domain.MediaStatus(params.Status),
+	}
+	if params.Progress != nil {
+		result.Progress = *params.Progress
+	}
+	if params.HiddenFromStatusLists != nil {
+		result.HiddenFromStatusLists = *params.HiddenFromStatusLists
+	}
+	if params.Priority != nil {
+		result.Priority = *params.Priority
+	}
+	if params.Notes != nil {
+		result.Notes = *params.Notes
+	}
+	if params.Score != nil {
+		result.Score = *params.Score
+	}
+	if params.ScoreRaw != nil {
+		result.Score = float64(*params.ScoreRaw)
+	}
+	return result, nil
+}
+
+func (r *fakeRepo) UpdateAnimeBatch(ctx context.Context, params []*domain.AnimeUpdateParams) ([]*domain.AnimeUpdateResult, error) {
+	r.batches++
+	if r.failBatch {
+		return nil, errors.New("complexity limit exceeded")
+	}
+
+	results := make([]*domain.AnimeUpdateResult, 0, len(params))
+	for _, p := range params {
+		result, err := r.UpdateAnime(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// animeOption sets a field of an anime made by testAnime
+type animeOption func(*domain.Anime)
+
+func withEpisodes(episodes int) animeOption {
+	return func(anime *domain.Anime) { anime.Episodes = episodes }
+}
+
+func withEndDate(endDate string) animeOption {
+	return func(anime *domain.Anime) { anime.UserData.EndDate = endDate }
+}
+
+// testAnime returns an entry on the user's list for the service tests
+func testAnime(id int, status domain.MediaStatus, progress int, opts ...animeOption) *domain.Anime {
+	anime := &domain.Anime{
+		ID:       id,
+		Title:    domain.AnimeTitle{Preferred: "Show"},
+		UserData: &domain.UserAnimeData{Status: status, Progress: progress},
+	}
+	for _, opt := range opts {
+		opt(anime)
+	}
+	return anime
+}
+
+// remoteEntry returns an entry as it is on AniList, for fakeRepo.remote
+func remoteEntry(id int, userData domain.UserAnimeData) *domain.Anime {
+	return &domain.Anime{ID: id, UserData: &userData}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/store"
+)
+
+// hiddenFileName is the name of the file hidden entries are persisted to within the data dir
+const hiddenFileName = "hidden_entries.json"
+
+// HiddenEntry is a list entry the user has chosen to hide from Hisame.  The entry is left untouched on AniList.
+type HiddenEntry struct {
+	AnimeID  int       `json:"anime_id"`
+	Title    string    `json:"title"` // Title at the time it was hidden, so entries no longer in the list can still be shown
+	HiddenAt time.Time `json:"hidden_at"`
+}
+
+// HiddenEntries is a locally persisted set of anime IDs that should never be shown in the TUI
+type HiddenEntries struct {
+	mu      sync.Mutex
+	file    store.File
+	entries map[int]HiddenEntry
+}
+
+// NewHiddenEntries creates a hidden entry store backed by the given file.  An empty path keeps entries in memory only.
+func NewHiddenEntries(path string) *HiddenEntries {
+	h := &HiddenEntries{
+		file:    store.NewFile(path),
+		entries: make(map[int]HiddenEntry),
+	}
+	if err := h.load(); err != nil {
+		log.Warn("Failed to load hidden entries, nothing will be hidden", "path", path, "error", err)
+	}
+	return h
+}
+
+// newDefaultHiddenEntries creates a hidden entry store in the Hisame data dir
+func newDefaultHiddenEntries() *HiddenEntries {
+	return NewHiddenEntries(store.DataPath(hiddenFileName, "hidden entries"))
+}
+
+// IsHidden reports whether the anime with the given ID is hidden
+func (h *HiddenEntries) IsHidden(animeID int) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, ok := h.entries[animeID]
+	return ok
+}
+
+// Hide adds an anime to the hidden set and persists it
+func (h *HiddenEntries) Hide(animeID int, title string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.entries[animeID] = HiddenEntry{
+		AnimeID:  animeID,
+		Title:    title,
+		HiddenAt: time.Now(),
+	}
+	return h.save()
+}
+
+// Unhide removes an anime from the hidden set and persists the change
+func (h *HiddenEntries) Unhide(animeID int) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.entries[animeID]; !ok {
+		return nil
+	}
+	delete(h.entries, animeID)
+	return h.save()
+}
+
+// List returns all hidden entries, most recently hidden first
+func (h *HiddenEntries) List() []HiddenEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	result := make([]HiddenEntry, 0, len(h.entries))
+	for _, entry := range h.entries {
+		result = append(result, entry)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].HiddenAt.After(result[j].HiddenAt)
+	})
+	return result
+}
+
+func (h *HiddenEntries) load() error {
+	var entries []HiddenEntry
+	if err := h.file.Load(&entries); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		h.entries[entry.AnimeID] = entry
+	}
+	return nil
+}
+
+// save writes the hidden entries to disk.  Must be called with the lock held.
+func (h *HiddenEntries) save() error {
+	entries := make([]HiddenEntry, 0, len(h.entries))
+	for _, entry := range h.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].AnimeID < entries[j].AnimeID
+	})
+	return h.file.Save(entries)
+}
+
+// HideAnime hides an anime from Hisame without changing anything on AniList
+func (s *AnimeService) HideAnime(anime *domain.Anime) error {
+	log.Info("Hiding anime from Hisame", "anime_id", anime.ID, "title", anime.Title.Preferred)
+	return s.hidden.Hide(anime.ID, anime.Title.Preferred)
+}
+
+// UnhideAnime makes a previously hidden anime visible again
+func (s *AnimeService) UnhideAnime(animeID int) error {
+	log.Info("Unhiding anime", "anime_id", animeID)
+	return s.hidden.Unhide(animeID)
+}
+
+// IsHidden reports whether the user has hidden the anime from Hisame
+func (s *AnimeService) IsHidden(animeID int) bool {
+	return s.hidden.IsHidden(animeID)
+}
+
+// GetHiddenEntries returns all the entries the user has hidden, most recently hidden first
+func (s *AnimeService) GetHiddenEntries() []HiddenEntry {
+	return s.hidden.List()
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHiddenEntriesPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), hiddenFileName)
+
+	h := NewHiddenEntries(path)
+	require.NoError(t, h.Hide(1, "Show A"))
+	require.NoError(t, h.Hide(2, "Show B"))
+	require.NoError(t, h.Unhide(1))
+
+	reloaded := NewHiddenEntries(path)
+	assert.False(t, reloaded.IsHidden(1), "anime 1 should be visible after unhiding")
+	assert.True(t, reloaded.IsHidden(2), "anime 2 should still be hidden after reloading")
+	entries := reloaded.List()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "Show B", entries[0].Title)
+}
+
+func TestHiddenEntriesInMemory(t *testing.T) {
+	h := NewHiddenEntries("")
+	require.NoError(t, h.Hide(5, "Show"))
+	assert.True(t, h.IsHidden(5))
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/store"
+)
+
+// listCacheVersion is bumped whenever the cached anime fields change shape, so old caches are ignored rather than
+// shown with missing data
+const listCacheVersion = 5
+
+// cachedList is the on-disk format of the list cache
+type cachedList struct {
+	Version   int             `json:"version"`
+	FetchedAt time.Time       `json:"fetched_at"`
+	Anime     []*domain.Anime `json:"anime"`
+}
+
+// ListCache persists the last anime list fetched from AniList, so it can be shown straight away on the next startup
+type ListCache struct {
+	file store.File
+}
+
+// NewListCache creates a list cache backed by the given file
+func NewListCache(path string) *ListCache {
+	return &ListCache{file: store.NewFile(path)}
+}
+
+// newDefaultListCache creates a cache for the given user's list in the Hisame data dir.  Each user has their own
+// file, so switching accounts never shows the wrong list.
+func newDefaultListCache(userID int) *ListCache {
+	path := store.DataPath(filepath.Join("cache", fmt.Sprintf("anime_list_%d.json", userID)), "the anime list")
+	if path == "" {
+		return nil
+	}
+	return NewListCache(path)
+}
+
+// Load returns the cached list and when it was fetched.  A missing or outdated cache returns a nil list.
+func (c *ListCache) Load() ([]*domain.Anime, time.Time, error) {
+	var cached cachedList
+	if err := c.file.Load(&cached); err != nil {
+		return nil, time.Time{}, err
+	}
+	if cached.Version != listCacheVersion {
+		if cached.Version != 0 {
+			log.Info("Ignoring anime list cache from a different version", "version", cached.Version)
+		}
+		return nil, time.Time{}, nil
+	}
+	return cached.Anime, cached.FetchedAt, nil
+}
+
+// Save replaces the cache with the given list
+func (c *ListCache) Save(list []*domain.Anime, fetchedAt time.Time) error {
+	return c.file.Save(cachedList{
+		Version:   listCacheVersion,
+		FetchedAt: fetchedAt,
+		Anime:     list,
+	})
+}
+
+// UseListCache turns on caching of the given user's list, so the next startup can show it before AniList responds
+func (s *AnimeService) UseListCache(userID int) {
+	s.listCache = newDefaultListCache(userID)
+}
+
+// LoadCachedAnimeList loads the list saved by the last successful fetch, reporting whether there was one.  The list is
+// shown as stale, see CachedAt, until LoadAnimeList next succeeds.
+func (s *AnimeService) LoadCachedAnimeList() bool {
+	if s.listCache == nil {
+		return false
+	}
+
+	list, fetchedAt, err := s.listCache.Load()
+	if err != nil {
+		log.Warn("Failed to load the cached anime list", "error", err)
+		return false
+	}
+	if list == nil {
+		return false
+	}
+
+	log.Info("Loaded cached anime list", "count", len(list), "fetched_at", fetchedAt)
+	s.animeList = list
+	s.applyQueuedChanges()
+	s.cachedAt = fetchedAt
+	return true
+}
+
+// applyQueuedChanges shows changes still waiting for AniList in the cached list, which was saved before they were made
+func (s *AnimeService) applyQueuedChanges() {
+	for _, change := range s.offline.List() {
+		if anime := s.GetAnimeByID(change.AnimeID); anime != nil && anime.UserData != nil {
+			anime.UserData.Status = change.Local.Status
+			anime.UserData.Progress = change.Local.Progress
+		}
+	}
+}
+
+// CachedAt returns when the list being shown was fetched if it came from the cache, or the zero time once it has been
+// fetched from AniList
+func (s *AnimeService) CachedAt() time.Time {
+	return s.cachedAt
+}
+
+// saveListCache writes the freshly fetched list to the cache, if caching is on.  Failures are only logged, as the
+// cache is just a startup speed up.
+func (s *AnimeService) saveListCache(list []*domain.Anime) {
+	if s.listCache == nil {
+		return
+	}
+	if err := s.listCache.Save(list, time.Now()); err != nil {
+		log.Warn("Failed to cache the anime list", "error", err)
+	}
+}
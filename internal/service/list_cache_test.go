@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCacheRoundTrip(t *testing.T) {
+	cache := NewListCache(filepath.Join(t.TempDir(), "cache", "anime_list_1.json"))
+
+	list, _, err := cache.Load()
+	require.NoError(t, err)
+	assert.Nil(t, list, "a missing cache should load nothing")
+
+	fetchedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	anime := &domain.Anime{
+		ID:           1,
+		Title:        domain.AnimeTitle{Preferred: "Frieren"},
+		NextAiringEp: &domain.AiringSchedule{Episode: 5, AiringAt: 1714560000},
+		UserData:     &domain.UserAnimeData{Status: domain.StatusCurrent, Progress: 4},
+	}
+	require.NoError(t, cache.Save([]*domain.Anime{anime}, fetchedAt))
+
+	list, loadedAt, err := cache.Load()
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, anime, list[0])
+	assert.True(t, fetchedAt.Equal(loadedAt))
+}
+
+func TestListCacheIgnoresOtherVersions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "anime_list_1.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 0, "anime": [{"ID": 1}]}`), 0644))
+
+	list, _, err := NewListCache(path).Load()
+	require.NoError(t, err)
+	assert.Nil(t, list)
+}
+
+func TestLoadAnimeListClearsCachedState(t *testing.T) {
+	cache := NewListCache(filepath.Join(t.TempDir(), "anime_list_1.json"))
+	require.NoError(t, cache.Save([]*domain.Anime{{ID: 1}}, time.Now().Add(-time.Hour)))
+
+	s := &AnimeService{
+		repo:      &fakeRepo{list: []*domain.Anime{{ID: 1}, {ID: 2}}},
+		hidden:    NewHiddenEntries(""),
+		listCache: cache,
+	}
+	require.True(t, s.LoadCachedAnimeList())
+	assert.Len(t, s.GetAnimeList(), 1)
+	assert.False(t, s.CachedAt().IsZero())
+
+	require.NoError(t, s.LoadAnimeList(context.Background()))
+	assert.Len(t, s.GetAnimeList(), 2)
+	assert.True(t, s.CachedAt().IsZero(), "a fetched list is no longer stale")
+
+	list, _, err := cache.Load()
+	require.NoError(t, err)
+	assert.Len(t, list, 2, "the fetched list should replace the cache")
+}
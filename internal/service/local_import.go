@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/localfiles"
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// LocalProgress is a progress change suggested from episode files found on disk
+type LocalProgress struct {
+	Anime    *domain.Anime
+	Progress int // The progress the files support
+	Files    int // How many episode files were found for the anime
+}
+
+// LocalImportResult summarises the outcome of applying progress found in local files
+type LocalImportResult struct {
+	Updated int
+	Failed  int
+}
+
+// SuggestLocalProgress matches episode files found on disk to entries on the user's list and suggests progress for
+// each entry the files are ahead of.  The suggested progress is the highest episode reached without a gap after the
+// entry's current progress, so a missing episode is never marked as watched.  Completed and hidden entries are left
+// alone.  Also returns the titles of files that didn't match any entry.
+func (s *AnimeService) SuggestLocalProgress(files []localfiles.Episode) ([]LocalProgress, []string) {
+	byTitle := s.localTitleIndex()
+
+	found := make(map[int]map[int]bool) // AniList ID to the episode numbers found
+	counts := make(map[int]int)
+	var unmatched []string
+	unmatchedSeen := make(map[string]bool)
+	for _, file := range files {
+		var anime *domain.Anime
+		for _, key := range localfiles.TitleKeys(file.Title, file.Season) {
+			if anime = byTitle[key]; anime != nil {
+				break
+			}
+		}
+		if anime == nil {
+			if !unmatchedSeen[file.Title] {
+				unmatchedSeen[file.Title] = true
+				unmatched = append(unmatched, file.Title)
+			}
+			continue
+		}
+		if found[anime.ID] == nil {
+			found[anime.ID] = make(map[int]bool)
+		}
+		found[anime.ID][file.Number] = true
+		counts[anime.ID]++
+	}
+
+	var suggestions []LocalProgress
+	for animeID, episodes := range found {
+		anime := s.GetAnimeByID(animeID)
+		progress := anime.UserData.Progress
+		for episodes[progress+1] && (anime.Episodes == 0 || progress < anime.Episodes) {
+			progress++
+		}
+		if progress > anime.UserData.Progress {
+			suggestions = append(suggestions, LocalProgress{Anime: anime, Progress: progress, Files: counts[animeID]})
+		}
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		return suggestions[i].Anime.Title.Preferred < suggestions[j].Anime.Title.Preferred
+	})
+
+	log.Info("Matched local episode files to the list", "files", len(files), "suggestions", len(suggestions),
+		"unmatched_titles", len(unmatched))
+	return suggestions, unmatched
+}
+
+// localTitleIndex maps every normalized title and synonym of the entries local files can update to their anime.
+// Titles shared by more than one entry are left out, as there's no telling which one the files are for.
+func (s *AnimeService) localTitleIndex() map[string]*domain.Anime {
+	index := make(map[string]*domain.Anime)
+	ambiguous := make(map[string]bool)
+	for _, anime := range s.animeList {
+		if anime.UserData == nil || anime.UserData.Status == domain.StatusCompleted || s.IsHidden(anime.ID) {
+			continue
+		}
+		names := append([]string{anime.Title.Romaji, anime.Title.English, anime.Title.Native}, anime.Synonyms...)
+		for _, name := range names {
+			key := localfiles.NormalizeTitle(name)
+			if key == "" {
+				continue
+			}
+			if other, ok := index[key]; ok && other.ID != anime.ID {
+				ambiguous[key] = true
+			}
+			index[key] = anime
+		}
+	}
+	for key := range ambiguous {
+		delete(index, key)
+	}
+	return index
+}
+
+// ApplyLocalProgress saves the suggested progress for each entry, snapshotting the entries first so the change can
+// be undone from a backup
+func (s *AnimeService) ApplyLocalProgress(ctx context.Context, suggestions []LocalProgress) (LocalImportResult, error) {
+	s.saving.Add(1)
+	defer s.saving.Add(-1)
+	s.updateLock.Lock()
+	defer s.updateLock.Unlock()
+
+	ids := make([]int, 0, len(suggestions))
+	params := make([]*domain.AnimeUpdateParams, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		progress := suggestion.Progress
+		ids = append(ids, suggestion.Anime.ID)
+		params = append(params, &domain.AnimeUpdateParams{MediaID: suggestion.Anime.ID, Progress: &progress})
+	}
+	s.snapshotBeforeBatch("local file import", ids)
+
+	var result LocalImportResult
+	updateResults, errs := s.updateBatch(ctx, params)
+	for i, suggestion := range suggestions {
+		if errs[i] != nil {
+			log.Warn("Failed to import progress from local files", "animeID", suggestion.Anime.ID,
+				"title", suggestion.Anime.Title.Preferred, "error", errs[i])
+			result.Failed++
+			continue
+		}
+
+		s.syncAnimeWithUpdateResult(suggestion.Anime, updateResults[i])
+		result.Updated++
+	}
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	log.Info("Imported progress from local files", "updated", result.Updated, "failed", result.Failed)
+	return result, nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/localfiles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestLocalProgress(t *testing.T) {
+	watching := testAnime(1, domain.StatusCurrent, 1)
+	watching.Title = domain.AnimeTitle{Romaji: "Sousou no Frieren", English: "Frieren: Beyond Journey's End", Preferred: "Frieren"}
+	watching.Episodes = 28
+	planning := testAnime(2, domain.StatusPlanning, 0)
+	planning.Title = domain.AnimeTitle{Romaji: "Dungeon Meshi", Preferred: "Dungeon Meshi"}
+	completed := testAnime(3, domain.StatusCompleted, 12)
+	completed.Title = domain.AnimeTitle{Romaji: "Bocchi the Rock!", Preferred: "Bocchi the Rock!"}
+
+	s := &AnimeService{animeList: []*domain.Anime{watching, planning, completed}, hidden: NewHiddenEntries("")}
+	suggestions, unmatched := s.SuggestLocalProgress([]localfiles.Episode{
+		{Title: "Frieren Beyond Journey's End", Number: 1},
+		{Title: "Frieren Beyond Journey's End", Number: 2},
+		{Title: "Frieren Beyond Journey's End", Number: 3},
+		{Title: "Frieren Beyond Journey's End", Number: 5}, // After a gap, so not counted
+		{Title: "Dungeon Meshi", Number: 2},                // Episode 1 is missing
+		{Title: "Bocchi the Rock", Number: 12},             // Already completed
+		{Title: "Unknown Show", Number: 1},
+	})
+
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, 1, suggestions[0].Anime.ID)
+	assert.Equal(t, 3, suggestions[0].Progress)
+	assert.Equal(t, 4, suggestions[0].Files)
+	assert.Equal(t, []string{"Bocchi the Rock", "Unknown Show"}, unmatched)
+}
+
+func TestSuggestLocalProgressSeasons(t *testing.T) {
+	first := testAnime(1, domain.StatusCurrent, 3)
+	first.Title = domain.AnimeTitle{Romaji: "Vinland Saga", Preferred: "Vinland Saga"}
+	second := testAnime(2, domain.StatusCurrent, 0)
+	second.Title = domain.AnimeTitle{Romaji: "Vinland Saga Season 2", Preferred: "Vinland Saga Season 2"}
+
+	s := &AnimeService{animeList: []*domain.Anime{first, second}, hidden: NewHiddenEntries("")}
+	suggestions, unmatched := s.SuggestLocalProgress([]localfiles.Episode{
+		{Title: "Vinland Saga", Season: 2, Number: 1},
+		{Title: "Vinland Saga", Season: 2, Number: 2},
+	})
+
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, 2, suggestions[0].Anime.ID, "season 2 files should only match the season 2 entry")
+	assert.Equal(t, 2, suggestions[0].Progress)
+	assert.Empty(t, unmatched)
+}
+
+func TestApplyLocalProgress(t *testing.T) {
+	repo := &fakeRepo{}
+	anime := testAnime(1, domain.StatusCurrent, 1)
+	s := &AnimeService{repo: repo, animeList: []*domain.Anime{anime}, backups: NewBackups(t.TempDir())}
+
+	result, err := s.ApplyLocalProgress(context.Background(), []LocalProgress{{Anime: anime, Progress: 3, Files: 3}})
+	require.NoError(t, err)
+	assert.Equal(t, LocalImportResult{Updated: 1}, result)
+	require.Len(t, repo.updates, 1)
+	assert.Equal(t, 3, *repo.updates[0].Progress)
+	assert.Equal(t, 3, anime.UserData.Progress)
+}
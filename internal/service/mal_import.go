@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/export"
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// MALImportChange is what importing a MyAnimeList entry would change on AniList
+type MALImportChange struct {
+	Anime   *domain.Anime // The anime on AniList, with the user's entry if it is already on their list
+	Entry   export.MALEntry
+	New     bool     // Whether the anime is added to the list
+	Changes []string // Each field that changes, e.g. "progress 3 → 12"
+	params  *domain.AnimeUpdateParams
+}
+
+// MALImportPlan is what importing a MyAnimeList export would do, for previewing before anything is saved
+type MALImportPlan struct {
+	Changes   []MALImportChange
+	Unchanged int               // Entries AniList already matches
+	Unmatched []export.MALEntry // Entries with no anime on AniList for their MyAnimeList ID
+}
+
+// MALImportResult is the outcome of importing a MyAnimeList export
+type MALImportResult struct {
+	Added   int
+	Updated int
+	Failed  int
+}
+
+// PlanMALImport works out what importing the MyAnimeList entries would change without saving anything.  Entries are
+// matched by MyAnimeList ID, looking up any not on the cached list on AniList.  Only the fields MyAnimeList has a
+// value for are changed, so an unscored MyAnimeList entry doesn't clear a score set on AniList.
+func (s *AnimeService) PlanMALImport(ctx context.Context, entries []export.MALEntry) (MALImportPlan, error) {
+	byMalID := make(map[int]*domain.Anime)
+	for _, anime := range s.animeList {
+		if anime.IDMal != 0 && anime.UserData != nil {
+			byMalID[anime.IDMal] = anime
+		}
+	}
+
+	var missing []int
+	for _, entry := range entries {
+		if byMalID[entry.MalID] == nil {
+			missing = append(missing, entry.MalID)
+		}
+	}
+	if len(missing) > 0 {
+		found, err := s.repo.GetAnimeByMalIDs(ctx, missing)
+		if err != nil {
+			return MALImportPlan{}, err
+		}
+		for _, anime := range found {
+			byMalID[anime.IDMal] = anime
+		}
+	}
+
+	var plan MALImportPlan
+	for _, entry := range entries {
+		anime := byMalID[entry.MalID]
+		if anime == nil {
+			plan.Unmatched = append(plan.Unmatched, entry)
+			continue
+		}
+		if cached := s.GetAnimeByID(anime.ID); cached != nil {
+			anime = cached
+		}
+
+		change := planMALChange(anime, entry)
+		if len(change.Changes) == 0 {
+			plan.Unchanged++
+			continue
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+	return plan, nil
+}
+
+// planMALChange works out the changes importing the entry makes to the anime's list entry.  MyAnimeList scores out of
+// 10, so scores are compared out of 10 and saved out of 100 for AniList to convert to the user's score format.
+func planMALChange(anime *domain.Anime, entry export.MALEntry) MALImportChange {
+	current := domain.UserAnimeData{}
+	if anime.UserData != nil {
+		current = *anime.UserData
+	}
+	change := MALImportChange{
+		Anime:  anime,
+		Entry:  entry,
+		New:    anime.UserData == nil || anime.UserData.Status == "",
+		params: &domain.AnimeUpdateParams{MediaID: anime.ID},
+	}
+
+	if entry.Status != current.Status {
+		change.params.Status = string(entry.Status)
+		change.Changes = append(change.Changes, fmt.Sprintf("status %s → %s", orNone(string(current.Status)), entry.Status))
+	}
+	if entry.Progress != current.Progress {
+		progress := entry.Progress
+		change.params.Progress = &progress
+		change.Changes = append(change.Changes, fmt.Sprintf("progress %d → %d", current.Progress, entry.Progress))
+	}
+	if entry.Score > 0 && entry.Score != current.Score10 {
+		score := entry.Score * 10
+		change.params.ScoreRaw = &score
+		change.Changes = append(change.Changes, fmt.Sprintf("score %d/10 → %d/10", current.Score10, entry.Score))
+	}
+	if entry.StartDate != "" && entry.StartDate != current.StartDate {
+		date := domain.ParseFuzzyDate(entry.StartDate)
+		change.params.StartedAt = &date
+		change.Changes = append(change.Changes, fmt.Sprintf("started %s → %s", orNone(current.StartDate), entry.StartDate))
+	}
+	if entry.EndDate != "" && entry.EndDate != current.EndDate {
+		date := domain.ParseFuzzyDate(entry.EndDate)
+		change.params.CompletedAt = &date
+		change.Changes = append(change.Changes, fmt.Sprintf("completed %s → %s", orNone(current.EndDate), entry.EndDate))
+	}
+	if entry.Notes != "" && entry.Notes != current.Notes {
+		notes := entry.Notes
+		change.params.Notes = &notes
+		change.Changes = append(change.Changes, "notes")
+	}
+	return change
+}
+
+// orNone returns the value, or "none" if it is empty
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+// ApplyMALImport saves the chosen changes to AniList, adding anime that aren't on the list yet.  Entries already on
+// the list are snapshotted first so the import can be undone from a backup.
+func (s *AnimeService) ApplyMALImport(ctx context.Context, changes []MALImportChange) (MALImportResult, error) {
+	s.saving.Add(1)
+	defer s.saving.Add(-1)
+	s.updateLock.Lock()
+	defer s.updateLock.Unlock()
+
+	ids := make([]int, 0, len(changes))
+	params := make([]*domain.AnimeUpdateParams, 0, len(changes))
+	for _, change := range changes {
+		ids = append(ids, change.Anime.ID)
+		params = append(params, change.params)
+	}
+	s.snapshotBeforeBatch("MyAnimeList import", ids)
+
+	var result MALImportResult
+	updateResults, errs := s.updateBatch(ctx, params)
+	for i, change := range changes {
+		if errs[i] != nil {
+			log.Warn("Failed to import MyAnimeList entry", "animeID", change.Anime.ID,
+				"title", change.Anime.Title.Preferred, "error", errs[i])
+			result.Failed++
+			continue
+		}
+
+		if cached := s.GetAnimeByID(change.Anime.ID); cached != nil {
+			s.syncAnimeWithUpdateResult(cached, updateResults[i])
+			result.Updated++
+			continue
+		}
+		added := *change.Anime
+		added.UserData = &domain.UserAnimeData{}
+		s.syncAnimeWithUpdateResult(&added, updateResults[i])
+		s.animeList = append(slices.Clone(s.animeList), &added)
+		result.Added++
+	}
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	log.Info("Imported MyAnimeList export", "added", result.Added, "updated", result.Updated, "failed", result.Failed)
+	return result, nil
+}
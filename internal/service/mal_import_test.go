@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/export"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMALImport(t *testing.T) {
+	behind := testAnime(1, domain.StatusCurrent, 3)
+	behind.IDMal = 101
+	behind.UserData.Score = 80
+	behind.UserData.Score10 = 8
+	upToDate := testAnime(2, domain.StatusCompleted, 12)
+	upToDate.IDMal = 102
+	// Scored 4 out of 5, which AniList converts to 8 out of 10
+	upToDate.UserData.Score = 4
+	upToDate.UserData.Score10 = 8
+	notOnList := &domain.Anime{ID: 3, IDMal: 103, Title: domain.AnimeTitle{Preferred: "New"}}
+
+	repo := &fakeRepo{remote: map[int]*domain.Anime{3: notOnList}}
+	s := &AnimeService{
+		repo:      repo,
+		animeList: []*domain.Anime{behind, upToDate},
+		backups:   NewBackups(t.TempDir()),
+	}
+
+	plan, err := s.PlanMALImport(context.Background(), []export.MALEntry{
+		{MalID: 101, Status: domain.StatusCompleted, Progress: 12, Score: 9, EndDate: "2024-03-22"},
+		{MalID: 102, Status: domain.StatusCompleted, Progress: 12, Score: 8},
+		{MalID: 103, Status: domain.StatusPlanning},
+		{MalID: 104, Status: domain.StatusDropped, Title: "Not on AniList"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, plan.Unchanged)
+	require.Len(t, plan.Unmatched, 1)
+	assert.Equal(t, "Not on AniList", plan.Unmatched[0].Title)
+	require.Len(t, plan.Changes, 2)
+	assert.Equal(t, []string{"status CURRENT → COMPLETED", "progress 3 → 12", "score 8/10 → 9/10",
+		"completed none → 2024-03-22"}, plan.Changes[0].Changes)
+	assert.Equal(t, 90, *plan.Changes[0].params.ScoreRaw, "scores are saved out of 100 for AniList to convert")
+	assert.Nil(t, plan.Changes[0].params.Score)
+	assert.False(t, plan.Changes[0].New)
+	assert.True(t, plan.Changes[1].New)
+	assert.Empty(t, repo.updates, "planning shouldn't save anything")
+
+	result, err := s.ApplyMALImport(context.Background(), plan.Changes)
+	require.NoError(t, err)
+	assert.Equal(t, MALImportResult{Added: 1, Updated: 1}, result)
+	assert.Equal(t, 12, behind.UserData.Progress)
+	assert.Equal(t, float64(90), behind.UserData.Score)
+
+	added := s.GetAnimeByID(3)
+	require.NotNil(t, added)
+	assert.Equal(t, domain.StatusPlanning, added.UserData.Status)
+}
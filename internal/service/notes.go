@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// NotesEdit records an entry's notes as they were when the user started editing them, so changes made elsewhere in
+// the meantime can be detected before saving
+type NotesEdit struct {
+	AnimeID       int
+	Title         string
+	Base          string // The notes when editing started
+	BaseUpdatedAt int64  // When the entry was last updated when editing started
+}
+
+// NotesConflict is returned when saving notes that were also changed on AniList, e.g. from the website or mobile app,
+// since editing started.  The cached entry is updated with the remote notes.
+type NotesConflict struct {
+	Edit            NotesEdit
+	Local           string // The notes as edited in Hisame
+	Remote          string // The notes as they are on AniList now
+	RemoteUpdatedAt int64
+}
+
+func (c *NotesConflict) Error() string {
+	return fmt.Sprintf("the notes for %s were changed on AniList while being edited", c.Edit.Title)
+}
+
+// BeginNotesEdit starts editing an entry's notes
+func (s *AnimeService) BeginNotesEdit(animeID int) (NotesEdit, error) {
+	anime := s.GetAnimeByID(animeID)
+	if anime == nil || anime.UserData == nil {
+		return NotesEdit{}, fmt.Errorf("anime %d is not in the list", animeID)
+	}
+	return NotesEdit{
+		AnimeID:       animeID,
+		Title:         anime.Title.Preferred,
+		Base:          anime.UserData.Notes,
+		BaseUpdatedAt: anime.UserData.UpdatedAt,
+	}, nil
+}
+
+// SaveNotes saves edited notes to AniList.  Unless force is set, the entry is fetched first and a *NotesConflict is
+// returned instead of saving if its notes were changed on AniList since editing started, so notes written elsewhere
+// aren't overwritten.  Force saves the notes regardless, e.g. once the user has chosen to keep their own.
+func (s *AnimeService) SaveNotes(ctx context.Context, edit NotesEdit, notes string, force bool) error {
+	s.saving.Add(1)
+	defer s.saving.Add(-1)
+	s.updateLock.Lock()
+	defer s.updateLock.Unlock()
+
+	anime := s.GetAnimeByID(edit.AnimeID)
+	if anime == nil || anime.UserData == nil {
+		return fmt.Errorf("anime %d is not in the list", edit.AnimeID)
+	}
+
+	if !force {
+		fresh, err := s.repo.GetAnimeByID(ctx, edit.AnimeID)
+		if err != nil {
+			return fmt.Errorf("failed to check the notes of %s on AniList: %w", anime.Title.Preferred, err)
+		}
+		if fresh.UserData == nil {
+			return fmt.Errorf("%s is no longer on your AniList", anime.Title.Preferred)
+		}
+		remote := fresh.UserData
+		if remote.UpdatedAt > edit.BaseUpdatedAt && remote.Notes != edit.Base && remote.Notes != notes {
+			log.Info("Notes changed on AniList while being edited", "animeID", edit.AnimeID,
+				"title", anime.Title.Preferred)
+			anime.UserData.Notes = remote.Notes
+			anime.UserData.UpdatedAt = remote.UpdatedAt
+			return &NotesConflict{Edit: edit, Local: notes, Remote: remote.Notes, RemoteUpdatedAt: remote.UpdatedAt}
+		}
+	}
+
+	result, err := s.repo.UpdateAnime(ctx, &domain.AnimeUpdateParams{
+		MediaID: edit.AnimeID,
+		Notes:   &notes,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save the notes of %s: %w", anime.Title.Preferred, err)
+	}
+
+	s.syncAnimeWithUpdateResult(anime, result)
+	log.Info("Saved anime notes", "animeID", edit.AnimeID, "title", anime.Title.Preferred, "forced", force)
+	return nil
+}
+
+// MergeNotes combines notes changed both locally and remotely line by line.  The remote notes are kept as they are,
+// followed by any lines added locally that they don't already have, so nothing written on either side is lost.
+func MergeNotes(base, local, remote string) string {
+	switch {
+	case local == base:
+		return remote
+	case remote == base:
+		return local
+	}
+
+	existing := make(map[string]bool)
+	for _, line := range strings.Split(base, "\n") {
+		existing[line] = true
+	}
+	for _, line := range strings.Split(remote, "\n") {
+		existing[line] = true
+	}
+
+	var added []string
+	for _, line := range strings.Split(local, "\n") {
+		if !existing[line] {
+			added = append(added, line)
+			existing[line] = true
+		}
+	}
+	if len(added) == 0 {
+		return remote
+	}
+	if remote == "" {
+		return strings.Join(added, "\n")
+	}
+	return remote + "\n" + strings.Join(added, "\n")
+}
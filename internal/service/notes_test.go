@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func notesAnime(notes string, updatedAt int64) *domain.Anime {
+	anime := testAnime(1, domain.StatusCurrent, 3)
+	anime.UserData.Notes = notes
+	anime.UserData.UpdatedAt = updatedAt
+	return anime
+}
+
+func TestSaveNotes(t *testing.T) {
+	// Only the progress changed on AniList, so the notes are safe to save
+	repo := &fakeRepo{remote: map[int]*domain.Anime{1: notesAnime("Original", 200)}}
+	s := &AnimeService{repo: repo, animeList: []*domain.Anime{notesAnime("Original", 100)}}
+
+	edit, err := s.BeginNotesEdit(1)
+	require.NoError(t, err)
+	require.NoError(t, s.SaveNotes(context.Background(), edit, "Edited", false))
+	require.Len(t, repo.updates, 1)
+	assert.Equal(t, "Edited", *repo.updates[0].Notes)
+	assert.Equal(t, "Edited", s.GetAnimeByID(1).UserData.Notes)
+}
+
+func TestSaveNotesConflict(t *testing.T) {
+	repo := &fakeRepo{remote: map[int]*domain.Anime{1: notesAnime("Written on the website", 200)}}
+	s := &AnimeService{repo: repo, animeList: []*domain.Anime{notesAnime("Original", 100)}}
+
+	edit, err := s.BeginNotesEdit(1)
+	require.NoError(t, err)
+	err = s.SaveNotes(context.Background(), edit, "Edited", false)
+
+	var conflict *NotesConflict
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, "Original", conflict.Edit.Base)
+	assert.Equal(t, "Written on the website", conflict.Remote)
+	assert.Equal(t, int64(200), conflict.RemoteUpdatedAt)
+	assert.Empty(t, repo.updates, "nothing should be saved over the remote notes")
+	assert.Equal(t, "Written on the website", s.GetAnimeByID(1).UserData.Notes)
+
+	require.NoError(t, s.SaveNotes(context.Background(), edit, "Edited", true))
+	require.Len(t, repo.updates, 1)
+	assert.Equal(t, "Edited", *repo.updates[0].Notes)
+}
+
+func TestMergeNotes(t *testing.T) {
+	assert.Equal(t, "remote", MergeNotes("base", "base", "remote"))
+	assert.Equal(t, "local", MergeNotes("base", "local", "base"))
+	assert.Equal(t, "Watched with Sam\nRewatch ep 5\nGreat OST",
+		MergeNotes("Watched with Sam", "Watched with Sam\nGreat OST", "Watched with Sam\nRewatch ep 5"))
+	assert.Equal(t, "From the app", MergeNotes("", "From the app", "From the app"))
+}
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/retry"
+	"github.com/PizzaHomicide/hisame/internal/store"
+)
+
+// offlineQueueFileName is the name of the file queued changes are persisted to within the data dir
+const offlineQueueFileName = "offline_queue.json"
+
+// ErrQueuedOffline is returned when AniList couldn't be reached, so a change was kept locally and queued to be saved
+// once AniList is back
+var ErrQueuedOffline = errors.New("AniList couldn't be reached, the change was queued")
+
+// EntryState is the part of a list entry that offline changes can affect
+type EntryState struct {
+	Status   domain.MediaStatus `json:"status"`
+	Progress int                `json:"progress"`
+}
+
+func entryState(data domain.UserAnimeData) EntryState {
+	return EntryState{Status: data.Status, Progress: data.Progress}
+}
+
+// QueuedChange is a change made while AniList couldn't be reached
+type QueuedChange struct {
+	AnimeID  int        `json:"anime_id"`
+	Title    string     `json:"title"`
+	Local    EntryState `json:"local"` // The entry after the change
+	Base     EntryState `json:"base"`  // The entry as AniList last confirmed it, before the first queued change
+	QueuedAt time.Time  `json:"queued_at"`
+
+	// Remote is set for changes queued because the entry was changed on AniList since it was loaded, rather than
+	// because AniList couldn't be reached
+	Remote bool `json:"remote,omitempty"`
+}
+
+// Reconciliation is a queued change to an entry that has also been changed on AniList since, e.g. from the website or
+// another device, so the user needs to decide which to keep
+type Reconciliation struct {
+	Change QueuedChange
+	Remote EntryState // The entry as AniList has it now
+}
+
+// Resolution is how a Reconciliation is settled
+type Resolution int
+
+const (
+	ResolveLocal  Resolution = iota // Save the local change over AniList's
+	ResolveRemote                   // Keep AniList's entry and discard the local change
+	ResolveMerge                    // Keep the furthest progress from either side, see MergeEntryStates
+)
+
+// OfflineSyncResult describes what happened to the queued changes once AniList could be reached again
+type OfflineSyncResult struct {
+	Synced    int              // Changes saved to AniList
+	Dropped   int              // Changes AniList already had, or to entries no longer in the list
+	Conflicts []Reconciliation // Changes to entries that were changed on AniList too
+	Failed    int              // Changes that still couldn't be saved, and remain queued
+}
+
+// OfflineQueue is a locally persisted set of changes waiting to be saved to AniList.  Only the latest change to each
+// entry is kept, along with the entry as AniList last had it.  A nil queue holds nothing and can't queue changes.
+type OfflineQueue struct {
+	mu      sync.Mutex
+	file    store.File
+	changes map[int]QueuedChange
+}
+
+// NewOfflineQueue creates a queue backed by the given file.  An empty path keeps changes in memory only.
+func NewOfflineQueue(path string) *OfflineQueue {
+	q := &OfflineQueue{
+		file:    store.NewFile(path),
+		changes: make(map[int]QueuedChange),
+	}
+	if err := q.load(); err != nil {
+		log.Warn("Failed to load queued offline changes", "path", path, "error", err)
+	}
+	return q
+}
+
+// newDefaultOfflineQueue creates a queue in the Hisame data dir
+func newDefaultOfflineQueue() *OfflineQueue {
+	return NewOfflineQueue(store.DataPath(offlineQueueFileName, "offline changes"))
+}
+
+// Add queues a change, replacing any earlier change to the same entry but keeping the earlier change's base, and
+// whether it conflicted with AniList
+func (q *OfflineQueue) Add(change QueuedChange) error {
+	if q == nil {
+		return errors.New("offline queue unavailable")
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if existing, ok := q.changes[change.AnimeID]; ok {
+		change.Base = existing.Base
+		change.Remote = change.Remote || existing.Remote
+	}
+	q.changes[change.AnimeID] = change
+	return q.save()
+}
+
+// Remove drops the queued change to an entry
+func (q *OfflineQueue) Remove(animeID int) error {
+	if q == nil {
+		return nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, ok := q.changes[animeID]; !ok {
+		return nil
+	}
+	delete(q.changes, animeID)
+	return q.save()
+}
+
+// Get returns the queued change to an entry, if there is one
+func (q *OfflineQueue) Get(animeID int) (QueuedChange, bool) {
+	if q == nil {
+		return QueuedChange{}, false
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	change, ok := q.changes[animeID]
+	return change, ok
+}
+
+// List returns all queued changes, oldest first
+func (q *OfflineQueue) List() []QueuedChange {
+	if q == nil {
+		return nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	result := make([]QueuedChange, 0, len(q.changes))
+	for _, change := range q.changes {
+		result = append(result, change)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].QueuedAt.Before(result[j].QueuedAt)
+	})
+	return result
+}
+
+func (q *OfflineQueue) load() error {
+	var changes []QueuedChange
+	if err := q.file.Load(&changes); err != nil {
+		return err
+	}
+	for _, change := range changes {
+		q.changes[change.AnimeID] = change
+	}
+	return nil
+}
+
+// save writes the queue to disk, removing the file once the queue is empty.  Must be called with the lock held.
+func (q *OfflineQueue) save() error {
+	if len(q.changes) == 0 {
+		return q.file.Remove()
+	}
+
+	changes := make([]QueuedChange, 0, len(q.changes))
+	for _, change := range q.changes {
+		changes = append(changes, change)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].AnimeID < changes[j].AnimeID
+	})
+	return q.file.Save(changes)
+}
+
+// isOffline reports whether err means AniList couldn't be reached at all, rather than it rejecting the change
+func isOffline(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) ||
+		errors.Is(err, syscall.ENETUNREACH) ||
+		errors.Is(err, syscall.EHOSTUNREACH) ||
+		retry.IsTransient(err)
+}
+
+// MergeEntryStates combines a local and remote entry by keeping the furthest progress, along with the status of the
+// side it came from.  The local status wins a tie.
+func MergeEntryStates(local, remote EntryState) EntryState {
+	if remote.Progress > local.Progress {
+		return remote
+	}
+	return local
+}
+
+// queueOffline keeps a change that couldn't be saved because AniList is unreachable.  base is the entry as AniList
+// last confirmed it.
+func (s *AnimeService) queueOffline(anime *domain.Anime, local, base EntryState) error {
+	log.Info("AniList unreachable, queueing change", "animeID", anime.ID, "title", anime.Title.Preferred,
+		"progress", local.Progress, "status", local.Status)
+	return s.offline.Add(QueuedChange{
+		AnimeID:  anime.ID,
+		Title:    anime.Title.Preferred,
+		Local:    local,
+		Base:     base,
+		QueuedAt: time.Now(),
+	})
+}
+
+// QueuedChanges returns the changes waiting for AniList to be reachable again, oldest first
+func (s *AnimeService) QueuedChanges() []QueuedChange {
+	return s.offline.List()
+}
+
+// SyncOfflineChanges saves the queued changes to the freshly loaded list.  Changes to entries that haven't changed on
+// AniList since are saved, and changes AniList already has are dropped.  Entries that were changed on AniList too are
+// left queued and returned as conflicts, to be settled with ResolveOfflineChange rather than one overwriting the other.
+func (s *AnimeService) SyncOfflineChanges(ctx context.Context) (OfflineSyncResult, error) {
+	s.saving.Add(1)
+	defer s.saving.Add(-1)
+	s.updateLock.Lock()
+	defer s.updateLock.Unlock()
+
+	var result OfflineSyncResult
+	var toSave []QueuedChange
+	for _, change := range s.offline.List() {
+		anime := s.GetAnimeByID(change.AnimeID)
+		switch {
+		case anime == nil || anime.UserData == nil:
+			log.Info("Dropping queued change to an entry no longer in the list", "animeID", change.AnimeID)
+			result.Dropped++
+			if err := s.offline.Remove(change.AnimeID); err != nil {
+				return result, err
+			}
+		case entryState(*anime.UserData) == change.Local:
+			result.Dropped++
+			if err := s.offline.Remove(change.AnimeID); err != nil {
+				return result, err
+			}
+		case entryState(*anime.UserData) == change.Base:
+			toSave = append(toSave, change)
+		default:
+			result.Conflicts = append(result.Conflicts, Reconciliation{Change: change, Remote: entryState(*anime.UserData)})
+		}
+	}
+	if len(toSave) == 0 {
+		return result, nil
+	}
+
+	params := make([]*domain.AnimeUpdateParams, len(toSave))
+	for i, change := range toSave {
+		params[i] = s.updateParamsFor(change.AnimeID, change.Local, change.Base)
+	}
+	results, errs := s.updateBatch(ctx, params)
+	for i, change := range toSave {
+		if errs[i] != nil {
+			log.Warn("Failed to save queued change", "animeID", change.AnimeID, "error", errs[i])
+			result.Failed++
+			continue
+		}
+		s.syncAnimeWithUpdateResult(s.GetAnimeByID(change.AnimeID), results[i])
+		result.Synced++
+		if err := s.offline.Remove(change.AnimeID); err != nil {
+			return result, err
+		}
+	}
+
+	log.Info("Synced offline changes", "synced", result.Synced, "dropped", result.Dropped,
+		"conflicts", len(result.Conflicts), "failed", result.Failed)
+	return result, nil
+}
+
+// ResolveOfflineChange settles a queued change to an entry that was also changed on AniList
+func (s *AnimeService) ResolveOfflineChange(ctx context.Context, animeID int, resolution Resolution) error {
+	s.saving.Add(1)
+	defer s.saving.Add(-1)
+	s.updateLock.Lock()
+	defer s.updateLock.Unlock()
+
+	change, ok := s.offline.Get(animeID)
+	if !ok {
+		return fmt.Errorf("no queued change for anime %d", animeID)
+	}
+	anime := s.GetAnimeByID(animeID)
+	if resolution == ResolveRemote || anime == nil || anime.UserData == nil {
+		log.Info("Discarding queued change", "animeID", animeID, "title", change.Title)
+		return s.offline.Remove(animeID)
+	}
+
+	remote := entryState(*anime.UserData)
+	target := change.Local
+	if resolution == ResolveMerge {
+		target = MergeEntryStates(change.Local, remote)
+	}
+	if target != remote {
+		result, err := s.repo.UpdateAnime(ctx, s.updateParamsFor(animeID, target, remote))
+		if err != nil {
+			return fmt.Errorf("failed to save %s: %w", change.Title, err)
+		}
+		s.syncAnimeWithUpdateResult(anime, result)
+	}
+
+	log.Info("Resolved queued change", "animeID", animeID, "title", change.Title, "progress", target.Progress,
+		"status", target.Status)
+	return s.offline.Remove(animeID)
+}
+
+// updateParamsFor builds the update that takes an entry from current to target.  The status is only sent when it
+// differs, so AniList can still move the entry along by itself, e.g. to completed on the last episode.
+func (s *AnimeService) updateParamsFor(animeID int, target, current EntryState) *domain.AnimeUpdateParams {
+	progress := target.Progress
+	params := &domain.AnimeUpdateParams{
+		MediaID:  animeID,
+		Progress: &progress,
+	}
+	if target.Status != current.Status {
+		params.Status = string(target.Status)
+	}
+	return params
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOfflineQueuePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "offline_queue.json")
+	queue := NewOfflineQueue(path)
+	require.NoError(t, queue.Add(QueuedChange{AnimeID: 1, Local: EntryState{Progress: 4}, Base: EntryState{Progress: 3}}))
+	require.NoError(t, queue.Add(QueuedChange{AnimeID: 1, Local: EntryState{Progress: 5}, Base: EntryState{Progress: 4}}))
+
+	change, ok := NewOfflineQueue(path).Get(1)
+	require.True(t, ok)
+	assert.Equal(t, 5, change.Local.Progress)
+	assert.Equal(t, 3, change.Base.Progress, "the first change's base should be kept")
+
+	require.NoError(t, queue.Remove(1))
+	assert.Empty(t, NewOfflineQueue(path).List())
+}
+
+func TestProgressUpdateQueuedWhenOffline(t *testing.T) {
+	anime := testAnime(1, domain.StatusCurrent, 3)
+	repo := &fakeRepo{updateErr: &net.DNSError{Err: "no such host", Name: "graphql.anilist.co"}}
+	s := &AnimeService{repo: repo, animeList: []*domain.Anime{anime}, offline: NewOfflineQueue("")}
+
+	update, err := s.BeginProgressUpdate(1, 1)
+	require.NoError(t, err)
+	err = s.CommitProgressUpdate(context.Background(), update)
+	assert.ErrorIs(t, err, ErrQueuedOffline)
+	assert.Equal(t, 4, anime.UserData.Progress, "the change should be kept rather than rolled back")
+
+	changes := s.QueuedChanges()
+	require.Len(t, changes, 1)
+	assert.Equal(t, EntryState{Status: domain.StatusCurrent, Progress: 4}, changes[0].Local)
+	assert.Equal(t, EntryState{Status: domain.StatusCurrent, Progress: 3}, changes[0].Base)
+}
+
+func TestSyncOfflineChanges(t *testing.T) {
+	repo := &fakeRepo{}
+	s := &AnimeService{
+		repo: repo,
+		animeList: []*domain.Anime{
+			testAnime(1, domain.StatusCurrent, 3), // Unchanged on AniList
+			testAnime(2, domain.StatusCurrent, 6), // AniList already has the change
+			testAnime(3, domain.StatusDropped, 2), // Changed on AniList too
+		},
+		offline: NewOfflineQueue(""),
+	}
+	current := func(progress int) EntryState {
+		return EntryState{Status: domain.StatusCurrent, Progress: progress}
+	}
+	require.NoError(t, s.offline.Add(QueuedChange{AnimeID: 1, Local: current(5), Base: current(3)}))
+	require.NoError(t, s.offline.Add(QueuedChange{AnimeID: 2, Local: current(6), Base: current(5)}))
+	require.NoError(t, s.offline.Add(QueuedChange{AnimeID: 3, Local: current(4), Base: current(2)}))
+	require.NoError(t, s.offline.Add(QueuedChange{AnimeID: 4, Local: current(1), Base: current(0)}))
+
+	result, err := s.SyncOfflineChanges(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Synced)
+	assert.Equal(t, 2, result.Dropped)
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, 3, result.Conflicts[0].Change.AnimeID)
+	assert.Equal(t, EntryState{Status: domain.StatusDropped, Progress: 2}, result.Conflicts[0].Remote)
+
+	require.Len(t, repo.updates, 1)
+	assert.Equal(t, 5, *repo.updates[0].Progress)
+	assert.Empty(t, repo.updates[0].Status, "status shouldn't be sent when it hasn't changed")
+	assert.Len(t, s.QueuedChanges(), 1, "only the conflict should remain queued")
+}
+
+func TestResolveOfflineChange(t *testing.T) {
+	repo := &fakeRepo{}
+	s := &AnimeService{
+		repo:      repo,
+		animeList: []*domain.Anime{testAnime(1, domain.StatusDropped, 2), testAnime(2, domain.StatusCurrent, 8)},
+		offline:   NewOfflineQueue(""),
+	}
+	require.NoError(t, s.offline.Add(QueuedChange{AnimeID: 1, Local: EntryState{Status: domain.StatusCurrent, Progress: 4}}))
+	require.NoError(t, s.offline.Add(QueuedChange{AnimeID: 2, Local: EntryState{Status: domain.StatusCurrent, Progress: 5}}))
+
+	require.NoError(t, s.ResolveOfflineChange(context.Background(), 1, ResolveMerge))
+	require.Len(t, repo.updates, 1)
+	assert.Equal(t, 4, *repo.updates[0].Progress)
+	assert.Equal(t, string(domain.StatusCurrent), repo.updates[0].Status, "the merged status should be sent as it differs")
+
+	require.NoError(t, s.ResolveOfflineChange(context.Background(), 2, ResolveMerge))
+	assert.Len(t, repo.updates, 1, "nothing to save when AniList is already furthest along")
+	assert.Empty(t, s.QueuedChanges())
+
+	assert.Error(t, s.ResolveOfflineChange(context.Background(), 2, ResolveLocal), "the change was already resolved")
+}
+
+func TestMergeEntryStates(t *testing.T) {
+	local := EntryState{Status: domain.StatusCurrent, Progress: 5}
+	remote := EntryState{Status: domain.StatusCompleted, Progress: 12}
+	assert.Equal(t, remote, MergeEntryStates(local, remote))
+	assert.Equal(t, local, MergeEntryStates(local, EntryState{Status: domain.StatusPaused, Progress: 5}))
+}
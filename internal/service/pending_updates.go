@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// ProgressUpdate is a progress change that has been applied to the local list but not yet saved to AniList
+type ProgressUpdate struct {
+	AnimeID        int
+	Progress       int                // The progress being saved
+	PreviousStatus domain.MediaStatus // The entry's status before the change
+}
+
+// pendingUpdates tracks progress changes that are waiting on AniList, and the last values AniList confirmed for each
+// entry so a failed change can be rolled back
+type pendingUpdates struct {
+	counts    map[int]int                  // AniList ID to number of changes in flight
+	confirmed map[int]domain.UserAnimeData // AniList ID to the entry as it was before the first change in flight
+}
+
+// BeginProgressUpdate changes the anime's progress by delta (1 or -1) in the local list straight away, so the UI can
+// show it before AniList responds.  The change must then be saved with CommitProgressUpdate.
+func (s *AnimeService) BeginProgressUpdate(animeID, delta int) (ProgressUpdate, error) {
+	s.pendingLock.Lock()
+	defer s.pendingLock.Unlock()
+
+	anime := s.GetAnimeByID(animeID)
+	if anime == nil || anime.UserData == nil {
+		return ProgressUpdate{}, fmt.Errorf("anime not found with ID: %d", animeID)
+	}
+
+	newProgress := anime.UserData.Progress + delta
+	if newProgress < 0 {
+		return ProgressUpdate{}, fmt.Errorf("cannot decrement progress: already at 0 episodes")
+	}
+	if anime.Episodes > 0 && newProgress > anime.Episodes {
+		return ProgressUpdate{}, fmt.Errorf("cannot increment progress: already completed all %d episodes", anime.Episodes)
+	}
+
+	if s.pending.counts == nil {
+		s.pending.counts = make(map[int]int)
+		s.pending.confirmed = make(map[int]domain.UserAnimeData)
+	}
+	if s.pending.counts[animeID] == 0 {
+		s.pending.confirmed[animeID] = *anime.UserData
+	}
+	s.pending.counts[animeID]++
+
+	update := ProgressUpdate{
+		AnimeID:        animeID,
+		Progress:       newProgress,
+		PreviousStatus: anime.UserData.Status,
+	}
+	anime.UserData.Progress = newProgress
+	return update, nil
+}
+
+// CommitProgressUpdate saves a change made by BeginProgressUpdate to AniList.  If it fails, the entry is rolled back
+// to the last values AniList confirmed once no other changes to it are in flight.
+func (s *AnimeService) CommitProgressUpdate(ctx context.Context, update ProgressUpdate) error {
+	s.updateLock.Lock()
+	defer s.updateLock.Unlock()
+
+	s.pendingLock.Lock()
+	anime := s.GetAnimeByID(update.AnimeID)
+	known := s.pending.confirmed[update.AnimeID]
+	s.pendingLock.Unlock()
+
+	// Don't overwrite the entry if it was changed on AniList since it was loaded
+	var err error
+	var result *domain.AnimeUpdateResult
+	if anime != nil && anime.UserData != nil {
+		local := EntryState{Status: update.PreviousStatus, Progress: update.Progress}
+		err = s.checkForConflict(ctx, anime, known, local)
+	}
+	if err == nil {
+		progress := update.Progress
+		result, err = s.repo.UpdateAnime(ctx, &domain.AnimeUpdateParams{
+			MediaID:  update.AnimeID,
+			Progress: &progress,
+		})
+	}
+
+	s.pendingLock.Lock()
+	defer s.pendingLock.Unlock()
+
+	s.pending.counts[update.AnimeID]--
+	last := s.pending.counts[update.AnimeID] == 0
+	if last {
+		delete(s.pending.counts, update.AnimeID)
+	}
+
+	if errors.Is(err, ErrRemoteConflict) {
+		// The cached entry is now AniList's, so there is nothing to roll back
+		if last {
+			delete(s.pending.confirmed, update.AnimeID)
+		}
+		return err
+	}
+
+	if err != nil && isOffline(err) && anime != nil && anime.UserData != nil {
+		// Keep the change rather than rolling it back, so it can be saved once AniList is back
+		local := EntryState{Status: anime.UserData.Status, Progress: update.Progress}
+		queueErr := s.queueOffline(anime, local, entryState(s.pending.confirmed[update.AnimeID]))
+		if queueErr == nil {
+			if last {
+				delete(s.pending.confirmed, update.AnimeID)
+			}
+			return fmt.Errorf("%w: %v", ErrQueuedOffline, err)
+		}
+		log.Warn("Failed to queue change, rolling it back", "animeID", update.AnimeID, "error", queueErr)
+	}
+	if err != nil {
+		if last && anime != nil && anime.UserData != nil {
+			*anime.UserData = s.pending.confirmed[update.AnimeID]
+			log.Warn("Rolled back progress change", "animeID", update.AnimeID, "progress", anime.UserData.Progress)
+		}
+		if last {
+			delete(s.pending.confirmed, update.AnimeID)
+		}
+		return fmt.Errorf("failed to update progress: %w", err)
+	}
+
+	if err := s.offline.Remove(update.AnimeID); err != nil {
+		log.Warn("Failed to remove superseded queued change", "animeID", update.AnimeID, "error", err)
+	}
+	if last {
+		delete(s.pending.confirmed, update.AnimeID)
+		s.syncAnimeWithUpdateResult(anime, result)
+	} else if anime != nil && anime.UserData != nil {
+		// Later changes are still in flight, so keep showing their progress but remember this one was confirmed
+		confirmed := *anime.UserData
+		confirmed.Status = result.Status
+		confirmed.Progress = result.Progress
+		confirmed.StartDate = result.StartDate
+		confirmed.EndDate = result.CompletionDate
+		s.pending.confirmed[update.AnimeID] = confirmed
+	}
+
+	log.Info("Saved progress change", "animeID", update.AnimeID, "progress", result.Progress, "status", result.Status)
+	return nil
+}
+
+// PendingChanges returns the number of changes that haven't been saved to AniList yet, including changes waiting for
+// earlier ones to save.  A batch of changes, such as restoring a backup, counts as one.
+func (s *AnimeService) PendingChanges() int {
+	s.pendingLock.Lock()
+	defer s.pendingLock.Unlock()
+
+	count := int(s.saving.Load())
+	for _, n := range s.pending.counts {
+		count += n
+	}
+	return count
+}
+
+// IsUpdatePending reports whether a progress change to the anime is still waiting on AniList
+func (s *AnimeService) IsUpdatePending(animeID int) bool {
+	s.pendingLock.Lock()
+	defer s.pendingLock.Unlock()
+	return s.pending.counts[animeID] > 0
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressUpdateAppliesImmediately(t *testing.T) {
+	anime := testAnime(1, domain.StatusCurrent, 3)
+	anime.Episodes = 12
+	s := &AnimeService{repo: &fakeRepo{}, animeList: []*domain.Anime{anime}}
+
+	update, err := s.BeginProgressUpdate(1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 4, anime.UserData.Progress, "progress should change before AniList responds")
+	assert.True(t, s.IsUpdatePending(1))
+
+	require.NoError(t, s.CommitProgressUpdate(context.Background(), update))
+	assert.Equal(t, 4, anime.UserData.Progress)
+	assert.False(t, s.IsUpdatePending(1))
+
+	anime.UserData.Progress = 12
+	_, err = s.BeginProgressUpdate(1, 1)
+	assert.Error(t, err, "progress can't go past the episode count")
+}
+
+func TestProgressUpdateRollsBack(t *testing.T) {
+	anime := testAnime(1, domain.StatusCurrent, 3)
+	repo := &fakeRepo{}
+	s := &AnimeService{repo: repo, animeList: []*domain.Anime{anime}}
+
+	first, err := s.BeginProgressUpdate(1, 1)
+	require.NoError(t, err)
+	second, err := s.BeginProgressUpdate(1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 5, anime.UserData.Progress)
+
+	require.NoError(t, s.CommitProgressUpdate(context.Background(), first))
+	assert.Equal(t, 5, anime.UserData.Progress, "later changes in flight should still be shown")
+
+	repo.updateErr = errors.New("network error")
+	assert.Error(t, s.CommitProgressUpdate(context.Background(), second))
+	assert.Equal(t, 4, anime.UserData.Progress, "should roll back to the last confirmed progress")
+	assert.False(t, s.IsUpdatePending(1))
+}
+
+func TestPendingChangesCountsQueuedUpdates(t *testing.T) {
+	anime := testAnime(1, domain.StatusCurrent, 3)
+	s := &AnimeService{repo: &fakeRepo{}, animeList: []*domain.Anime{anime}}
+	assert.Equal(t, 0, s.PendingChanges())
+
+	first, err := s.BeginProgressUpdate(1, 1)
+	require.NoError(t, err)
+	_, err = s.BeginProgressUpdate(1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, s.PendingChanges())
+
+	require.NoError(t, s.CommitProgressUpdate(context.Background(), first))
+	assert.Equal(t, 1, s.PendingChanges())
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// SetPriority sets the user's priority for an entry on AniList.  0 clears it.
+func (s *AnimeService) SetPriority(ctx context.Context, animeID int, priority int) error {
+	s.saving.Add(1)
+	defer s.saving.Add(-1)
+	s.updateLock.Lock()
+	defer s.updateLock.Unlock()
+
+	if priority < 0 {
+		return fmt.Errorf("priority can't be negative: %d", priority)
+	}
+	anime := s.GetAnimeByID(animeID)
+	if anime == nil || anime.UserData == nil {
+		return fmt.Errorf("anime %d is not in the list", animeID)
+	}
+
+	result, err := s.repo.UpdateAnime(ctx, &domain.AnimeUpdateParams{
+		MediaID:  animeID,
+		Priority: &priority,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set the priority of %s: %w", anime.Title.Preferred, err)
+	}
+
+	s.syncAnimeWithUpdateResult(anime, result)
+	log.Info("Set anime priority", "animeID", animeID, "title", anime.Title.Preferred, "priority", priority)
+	return nil
+}
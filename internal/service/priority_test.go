@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetPriority(t *testing.T) {
+	repo := &fakeRepo{}
+	anime := testAnime(1, domain.StatusPlanning, 0)
+	s := &AnimeService{repo: repo, animeList: []*domain.Anime{anime}}
+
+	require.NoError(t, s.SetPriority(context.Background(), 1, 3))
+	require.Len(t, repo.updates, 1)
+	assert.Equal(t, 3, *repo.updates[0].Priority)
+	assert.Equal(t, 3, anime.UserData.Priority)
+
+	assert.Error(t, s.SetPriority(context.Background(), 1, -1))
+	assert.Error(t, s.SetPriority(context.Background(), 2, 1), "anime not in the list")
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// RefreshAnime re-fetches a single anime from AniList and merges it into the cached list, so one entry can be brought
+// up to date without reloading the whole list.  The entry is updated in place, so anything holding it sees the new
+// values.  A progress change that is still saving is kept rather than overwritten.
+//
+// If the anime is no longer on the user's list it is removed from the cached list too, and removed is true.
+func (s *AnimeService) RefreshAnime(ctx context.Context, animeID int) (removed bool, err error) {
+	fresh, err := s.repo.GetAnimeByID(ctx, animeID)
+	if err != nil {
+		return false, err
+	}
+
+	s.pendingLock.Lock()
+	defer s.pendingLock.Unlock()
+
+	anime := s.GetAnimeByID(animeID)
+	if anime == nil {
+		return false, fmt.Errorf("anime not found with ID: %d", animeID)
+	}
+
+	if fresh.UserData == nil {
+		log.Info("Anime is no longer on the list, removing it", "id", animeID, "title", anime.Title.Preferred)
+		s.animeList = slices.DeleteFunc(slices.Clone(s.animeList), func(a *domain.Anime) bool {
+			return a.ID == animeID
+		})
+		return true, nil
+	}
+
+	if s.pending.counts[animeID] > 0 {
+		log.Debug("Keeping local progress while a change is saving", "id", animeID)
+		fresh.UserData = anime.UserData
+	}
+
+	*anime = *fresh
+	log.Info("Refreshed anime", "id", animeID, "title", anime.Title.Preferred, "next_airing", anime.NextAiringEp)
+	return false, nil
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshAnimeMergesInPlace(t *testing.T) {
+	anime := testAnime(1, domain.StatusCurrent, 3)
+	fresh := testAnime(1, domain.StatusCurrent, 4)
+	fresh.NextAiringEp = &domain.AiringSchedule{Episode: 6, AiringAt: 1714560000}
+	s := &AnimeService{repo: &fakeRepo{remote: map[int]*domain.Anime{1: fresh}}, animeList: []*domain.Anime{anime}}
+
+	removed, err := s.RefreshAnime(context.Background(), 1)
+	require.NoError(t, err)
+	assert.False(t, removed)
+	assert.Same(t, anime, s.GetAnimeByID(1), "the entry should be updated in place")
+	assert.Equal(t, 6, anime.NextAiringEp.Episode)
+	assert.Equal(t, 4, anime.UserData.Progress)
+}
+
+func TestRefreshAnimeKeepsPendingProgress(t *testing.T) {
+	anime := testAnime(1, domain.StatusCurrent, 3)
+	fresh := testAnime(1, domain.StatusCurrent, 3)
+	s := &AnimeService{repo: &fakeRepo{remote: map[int]*domain.Anime{1: fresh}}, animeList: []*domain.Anime{anime}}
+
+	_, err := s.BeginProgressUpdate(1, 1)
+	require.NoError(t, err)
+	_, err = s.RefreshAnime(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 4, anime.UserData.Progress, "a change still saving shouldn't be overwritten")
+}
+
+func TestRefreshAnimeRemovesEntriesOffTheList(t *testing.T) {
+	fresh := &domain.Anime{ID: 1}
+	s := &AnimeService{
+		repo:      &fakeRepo{remote: map[int]*domain.Anime{1: fresh}},
+		animeList: []*domain.Anime{testAnime(1, domain.StatusCurrent, 3), testAnime(2, domain.StatusCurrent, 1)},
+	}
+
+	removed, err := s.RefreshAnime(context.Background(), 1)
+	require.NoError(t, err)
+	assert.True(t, removed)
+	assert.Nil(t, s.GetAnimeByID(1))
+	assert.Len(t, s.GetAnimeList(), 1)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+)
+
+// EpisodeCountChange is an anime whose total episode count AniList revised between two loads of the list
+type EpisodeCountChange struct {
+	Anime    *domain.Anime
+	Previous int // 0 means the count was previously unknown
+	Current  int // 0 means the count is now unknown
+}
+
+// Note describes how the revised episode count affects the user's entry, or is empty if it makes no difference
+func (c EpisodeCountChange) Note() string {
+	data := c.Anime.UserData
+	if data == nil || c.Current == 0 {
+		return ""
+	}
+
+	switch {
+	case data.Progress > c.Current:
+		return "progress is now beyond the episode count"
+	case data.Status == domain.StatusCompleted && data.Progress < c.Current:
+		return fmt.Sprintf("completed but now has %d unwatched", c.Current-data.Progress)
+	case data.Status != domain.StatusCompleted && data.Progress == c.Current:
+		return "all episodes now watched"
+	}
+	return ""
+}
+
+// String renders the change as e.g. "Frieren 12→28 (completed but now has 16 unwatched)"
+func (c EpisodeCountChange) String() string {
+	s := fmt.Sprintf("%s %s→%s", c.Anime.Title.Preferred, episodeCountText(c.Previous), episodeCountText(c.Current))
+	if note := c.Note(); note != "" {
+		s += " (" + note + ")"
+	}
+	return s
+}
+
+// RefreshSummary describes what changed in the list between two loads
+type RefreshSummary struct {
+	EpisodeCountChanges []EpisodeCountChange
+}
+
+// IsEmpty reports whether nothing of note changed
+func (r RefreshSummary) IsEmpty() bool {
+	return len(r.EpisodeCountChanges) == 0
+}
+
+// String renders a single line summary of the changes, or an empty string if there were none
+func (r RefreshSummary) String() string {
+	if r.IsEmpty() {
+		return ""
+	}
+
+	changes := make([]string, 0, len(r.EpisodeCountChanges))
+	for _, change := range r.EpisodeCountChanges {
+		changes = append(changes, change.String())
+	}
+
+	noun := "count"
+	if len(changes) > 1 {
+		noun = "counts"
+	}
+	return fmt.Sprintf("%d episode %s revised: %s", len(changes), noun, strings.Join(changes, ", "))
+}
+
+// summariseRefresh compares a newly loaded list with the previous one, ignoring any anime the skip func matches
+func summariseRefresh(previous, current []*domain.Anime, skip func(animeID int) bool) RefreshSummary {
+	var summary RefreshSummary
+	if len(previous) == 0 {
+		return summary
+	}
+
+	previousEpisodes := make(map[int]int, len(previous))
+	for _, anime := range previous {
+		previousEpisodes[anime.ID] = anime.Episodes
+	}
+
+	for _, anime := range current {
+		before, ok := previousEpisodes[anime.ID]
+		if !ok || before == anime.Episodes || skip(anime.ID) {
+			continue
+		}
+		summary.EpisodeCountChanges = append(summary.EpisodeCountChanges, EpisodeCountChange{
+			Anime:    anime,
+			Previous: before,
+			Current:  anime.Episodes,
+		})
+	}
+	return summary
+}
+
+func episodeCountText(episodes int) string {
+	if episodes == 0 {
+		return "?"
+	}
+	return fmt.Sprintf("%d", episodes)
+}
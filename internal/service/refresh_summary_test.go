@@ -0,0 +1,46 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummariseRefresh(t *testing.T) {
+	noneHidden := func(int) bool { return false }
+	previous := []*domain.Anime{
+		testAnime(1, domain.StatusCompleted, 12, withEpisodes(12)),
+		testAnime(2, domain.StatusCurrent, 5),
+		testAnime(3, domain.StatusCurrent, 3, withEpisodes(24)),
+	}
+	current := []*domain.Anime{
+		testAnime(1, domain.StatusCompleted, 12, withEpisodes(24)),
+		testAnime(2, domain.StatusCurrent, 5, withEpisodes(13)),
+		testAnime(3, domain.StatusCurrent, 3, withEpisodes(24)),
+		testAnime(4, domain.StatusPlanning, 0, withEpisodes(10)),
+	}
+
+	summary := summariseRefresh(previous, current, noneHidden)
+	require.Len(t, summary.EpisodeCountChanges, 2)
+
+	first := summary.EpisodeCountChanges[0]
+	assert.Equal(t, 12, first.Previous)
+	assert.Equal(t, 24, first.Current)
+	assert.Equal(t, "completed but now has 12 unwatched", first.Note())
+	assert.Equal(t, "Show ?→13", summary.EpisodeCountChanges[1].String())
+}
+
+func TestSummariseRefreshFirstLoad(t *testing.T) {
+	current := []*domain.Anime{testAnime(1, domain.StatusPlanning, 0, withEpisodes(12))}
+	summary := summariseRefresh(nil, current, func(int) bool { return false })
+	assert.True(t, summary.IsEmpty(), "nothing should be reported on first load")
+}
+
+func TestSummariseRefreshSkipsHidden(t *testing.T) {
+	previous := []*domain.Anime{testAnime(1, domain.StatusCurrent, 0, withEpisodes(12))}
+	current := []*domain.Anime{testAnime(1, domain.StatusCurrent, 0, withEpisodes(13))}
+	summary := summariseRefresh(previous, current, func(id int) bool { return id == 1 })
+	assert.True(t, summary.IsEmpty(), "hidden anime should be skipped")
+}
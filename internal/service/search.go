@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// SearchAniList searches all of AniList for anime matching the query, not just the user's list
+func (s *AnimeService) SearchAniList(ctx context.Context, query string) ([]*domain.Anime, error) {
+	return s.repo.SearchAnime(ctx, query)
+}
+
+// AddToList adds an anime found outside the user's list to it with the given status, and to the cached list so its
+// progress can be tracked.  Returns the cached list's entry, which is the existing one if it was already on the list.
+func (s *AnimeService) AddToList(ctx context.Context, anime *domain.Anime, status domain.MediaStatus) (*domain.Anime, error) {
+	s.saving.Add(1)
+	defer s.saving.Add(-1)
+	s.updateLock.Lock()
+	defer s.updateLock.Unlock()
+
+	if existing := s.GetAnimeByID(anime.ID); existing != nil {
+		return existing, nil
+	}
+
+	progress := 0
+	result, err := s.repo.UpdateAnime(ctx, &domain.AnimeUpdateParams{
+		MediaID:  anime.ID,
+		Status:   string(status),
+		Progress: &progress,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add %s to the list: %w", anime.Title.Preferred, err)
+	}
+
+	added := *anime
+	added.UserData = &domain.UserAnimeData{}
+	s.syncAnimeWithUpdateResult(&added, result)
+	s.animeList = append(slices.Clone(s.animeList), &added)
+
+	log.Info("Added anime to the list", "id", added.ID, "title", added.Title.Preferred, "status", added.UserData.Status)
+	return &added, nil
+}
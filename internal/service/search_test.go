@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddToList(t *testing.T) {
+	repo := &fakeRepo{}
+	existing := testAnime(1, domain.StatusCompleted, 12)
+	s := &AnimeService{repo: repo, animeList: []*domain.Anime{existing}}
+
+	found := &domain.Anime{ID: 2, Title: domain.AnimeTitle{Preferred: "Dandadan"}, Episodes: 12}
+	added, err := s.AddToList(context.Background(), found, domain.StatusCurrent)
+	require.NoError(t, err)
+	assert.Same(t, added, s.GetAnimeByID(2))
+	assert.Equal(t, domain.StatusCurrent, added.UserData.Status)
+	assert.Equal(t, 12, added.Episodes)
+	assert.Nil(t, found.UserData, "the search result itself shouldn't change")
+
+	added, err = s.AddToList(context.Background(), &domain.Anime{ID: 1}, domain.StatusCurrent)
+	require.NoError(t, err)
+	assert.Same(t, existing, added, "anime already on the list shouldn't be added again")
+	assert.Len(t, repo.updates, 1)
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// seasonNames are the anime seasons in calendar order, each starting on the first of its quarter
+var seasonNames = []string{"WINTER", "SPRING", "SUMMER", "FALL"}
+
+// Season is an anime season, e.g. Winter 2026, which runs from January to March
+type Season struct {
+	Name string // "WINTER", "SPRING", "SUMMER" or "FALL", as AniList names them
+	Year int
+}
+
+// SeasonOf returns the season the time falls in
+func SeasonOf(t time.Time) Season {
+	return Season{Name: seasonNames[(int(t.Month())-1)/3], Year: t.Year()}
+}
+
+// index is the season's position in the year, 0 for winter
+func (s Season) index() int {
+	for i, name := range seasonNames {
+		if name == s.Name {
+			return i
+		}
+	}
+	return 0
+}
+
+// Previous returns the season before this one
+func (s Season) Previous() Season {
+	if i := s.index(); i > 0 {
+		return Season{Name: seasonNames[i-1], Year: s.Year}
+	}
+	return Season{Name: seasonNames[len(seasonNames)-1], Year: s.Year - 1}
+}
+
+// Next returns the season after this one
+func (s Season) Next() Season {
+	if i := s.index(); i < len(seasonNames)-1 {
+		return Season{Name: seasonNames[i+1], Year: s.Year}
+	}
+	return Season{Name: seasonNames[0], Year: s.Year + 1}
+}
+
+// String returns the season as it is shown, e.g. "Winter 2026"
+func (s Season) String() string {
+	name := s.Name
+	if name != "" {
+		name = name[:1] + strings.ToLower(name[1:])
+	}
+	return fmt.Sprintf("%s %d", name, s.Year)
+}
+
+// ScoreChange is a new score for a list entry
+type ScoreChange struct {
+	AnimeID int
+	Score   float64
+}
+
+// ScoreResult is the outcome of saving several scores
+type ScoreResult struct {
+	Updated int
+	Failed  int
+}
+
+// GetCompletedInSeason returns the anime the user completed during the season, going by their completion dates, in the
+// order they were completed.  Entries whose completion date has no month can't be placed in a season, so are left
+// out.
+func (s *AnimeService) GetCompletedInSeason(season Season) []*domain.Anime {
+	var completed []*domain.Anime
+	for _, anime := range s.animeList {
+		if anime.UserData == nil || anime.UserData.Status != domain.StatusCompleted {
+			continue
+		}
+		date := domain.ParseFuzzyDate(anime.UserData.EndDate)
+		if date.Month == 0 {
+			continue
+		}
+		if SeasonOf(time.Date(date.Year, time.Month(date.Month), 1, 0, 0, 0, 0, time.Local)) == season {
+			completed = append(completed, anime)
+		}
+	}
+
+	sort.SliceStable(completed, func(i, j int) bool {
+		if completed[i].UserData.EndDate != completed[j].UserData.EndDate {
+			return completed[i].UserData.EndDate < completed[j].UserData.EndDate
+		}
+		return completed[i].Title.Preferred < completed[j].Title.Preferred
+	})
+	return completed
+}
+
+// SaveScores saves several scores in as few requests as possible, snapshotting the entries first so the change can be
+// undone from a backup
+func (s *AnimeService) SaveScores(ctx context.Context, changes []ScoreChange) (ScoreResult, error) {
+	s.saving.Add(1)
+	defer s.saving.Add(-1)
+	s.updateLock.Lock()
+	defer s.updateLock.Unlock()
+
+	var result ScoreResult
+	var ids []int
+	var params []*domain.AnimeUpdateParams
+	var entries []*domain.Anime
+	for _, change := range changes {
+		anime := s.GetAnimeByID(change.AnimeID)
+		if anime == nil || anime.UserData == nil {
+			log.Warn("Unable to score anime that is not in the list", "animeID", change.AnimeID)
+			result.Failed++
+			continue
+		}
+		score := change.Score
+		ids = append(ids, change.AnimeID)
+		params = append(params, &domain.AnimeUpdateParams{MediaID: change.AnimeID, Score: &score})
+		entries = append(entries, anime)
+	}
+	s.snapshotBeforeBatch("season scoring", ids)
+
+	updateResults, errs := s.updateBatch(ctx, params)
+	for i, anime := range entries {
+		if errs[i] != nil {
+			log.Warn("Failed to save score", "animeID", anime.ID, "title", anime.Title.Preferred, "error", errs[i])
+			result.Failed++
+			continue
+		}
+
+		s.syncAnimeWithUpdateResult(anime, updateResults[i])
+		result.Updated++
+	}
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	log.Info("Saved scores", "updated", result.Updated, "failed", result.Failed)
+	return result, nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeasonOf(t *testing.T) {
+	assert.Equal(t, Season{Name: "WINTER", Year: 2026}, SeasonOf(time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, Season{Name: "SPRING", Year: 2026}, SeasonOf(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, Season{Name: "FALL", Year: 2026}, SeasonOf(time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestSeasonPreviousAndNext(t *testing.T) {
+	winter := Season{Name: "WINTER", Year: 2026}
+	assert.Equal(t, Season{Name: "FALL", Year: 2025}, winter.Previous())
+	assert.Equal(t, Season{Name: "SPRING", Year: 2026}, winter.Next())
+	assert.Equal(t, winter, winter.Previous().Next())
+	assert.Equal(t, "Winter 2026", winter.String())
+}
+
+func TestGetCompletedInSeason(t *testing.T) {
+	late := testAnime(1, domain.StatusCompleted, 12, withEndDate("2026-03-28"))
+	late.Title.Preferred = "Late"
+	early := testAnime(2, domain.StatusCompleted, 12, withEndDate("2026-01-05"))
+	early.Title.Preferred = "Early"
+	s := &AnimeService{animeList: []*domain.Anime{
+		late,
+		early,
+		testAnime(3, domain.StatusCompleted, 12, withEndDate("2026-04-01")), // Spring
+		testAnime(4, domain.StatusCompleted, 12, withEndDate("2026")),       // No month, so no season
+		testAnime(5, domain.StatusCurrent, 3, withEndDate("2026-02-01")),    // Not completed
+	}}
+
+	completed := s.GetCompletedInSeason(Season{Name: "WINTER", Year: 2026})
+	require.Len(t, completed, 2)
+	assert.Equal(t, "Early", completed[0].Title.Preferred)
+	assert.Equal(t, "Late", completed[1].Title.Preferred)
+}
+
+func TestSaveScores(t *testing.T) {
+	repo := &fakeRepo{}
+	first := testAnime(1, domain.StatusCompleted, 12, withEndDate("2026-02-01"))
+	second := testAnime(2, domain.StatusCompleted, 12, withEndDate("2026-02-02"))
+	s := &AnimeService{repo: repo, animeList: []*domain.Anime{first, second}, backups: NewBackups(t.TempDir())}
+
+	result, err := s.SaveScores(context.Background(), []ScoreChange{
+		{AnimeID: 1, Score: 8.5},
+		{AnimeID: 2, Score: 0},
+		{AnimeID: 9, Score: 7}, // Not in the list
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ScoreResult{Updated: 2, Failed: 1}, result)
+	assert.Equal(t, 1, repo.batches)
+	assert.Equal(t, 8.5, first.UserData.Score)
+
+	backups, err := s.GetBackups()
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.Equal(t, "season scoring", backups[0].Reason)
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/store"
+)
+
+// snoozeFileName is the name of the file agenda snoozes are persisted to within the data dir
+const snoozeFileName = "agenda_snoozes.json"
+
+// SnoozePeriod is how long the airing agenda is snoozed for
+type SnoozePeriod string
+
+const (
+	SnoozeDay    SnoozePeriod = "day"    // For 24 hours
+	SnoozeWeek   SnoozePeriod = "week"   // Until the start of next week
+	SnoozeSeason SnoozePeriod = "season" // Until the next anime season starts
+)
+
+// SnoozeUntil returns when a snooze for the period starting now ends.  Weeks start on Monday, and anime seasons start
+// in January, April, July and October.  Both end at midnight in now's location.
+func SnoozeUntil(period SnoozePeriod, now time.Time) time.Time {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch period {
+	case SnoozeWeek:
+		daysToMonday := (8 - int(now.Weekday())) % 7
+		if daysToMonday == 0 {
+			daysToMonday = 7
+		}
+		return midnight.AddDate(0, 0, daysToMonday)
+	case SnoozeSeason:
+		nextSeason := ((int(now.Month())-1)/3+1)*3 + 1
+		return time.Date(now.Year(), time.Month(nextSeason), 1, 0, 0, 0, 0, now.Location())
+	default:
+		return now.Add(24 * time.Hour)
+	}
+}
+
+// Snooze keeps an anime out of the airing agenda until the given time
+type Snooze struct {
+	AnimeID int       `json:"anime_id"`
+	Title   string    `json:"title"` // Title at the time it was snoozed, so entries no longer in the list can still be shown
+	Until   time.Time `json:"until"`
+}
+
+// snoozeFile is the persisted form of the snoozes
+type snoozeFile struct {
+	All   time.Time `json:"all,omitempty"` // The whole agenda is snoozed until this time
+	Anime []Snooze  `json:"anime"`
+}
+
+// Snoozes is a locally persisted record of what the user has snoozed in the airing agenda, either single anime or the
+// agenda as a whole.  Snoozes that have run out are forgotten the next time they are saved.
+type Snoozes struct {
+	mu      sync.Mutex
+	file    store.File
+	all     time.Time
+	entries map[int]Snooze
+}
+
+// NewSnoozes creates a snooze store backed by the given file.  An empty path keeps snoozes in memory only.
+func NewSnoozes(path string) *Snoozes {
+	s := &Snoozes{
+		file:    store.NewFile(path),
+		entries: make(map[int]Snooze),
+	}
+	if err := s.load(); err != nil {
+		log.Warn("Failed to load agenda snoozes, nothing will be snoozed", "path", path, "error", err)
+	}
+	return s
+}
+
+// newDefaultSnoozes creates a snooze store in the Hisame data dir
+func newDefaultSnoozes() *Snoozes {
+	return NewSnoozes(store.DataPath(snoozeFileName, "agenda snoozes"))
+}
+
+// Snooze snoozes a single anime until the given time and persists it
+func (s *Snoozes) Snooze(animeID int, title string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[animeID] = Snooze{AnimeID: animeID, Title: title, Until: until}
+	return s.save()
+}
+
+// SnoozeAll snoozes the whole agenda until the given time and persists it
+func (s *Snoozes) SnoozeAll(until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.all = until
+	return s.save()
+}
+
+// Unsnooze removes an anime's snooze and persists the change
+func (s *Snoozes) Unsnooze(animeID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.entries[animeID]; !ok {
+		return nil
+	}
+	delete(s.entries, animeID)
+	return s.save()
+}
+
+// UnsnoozeAll removes the snooze on the whole agenda, leaving single anime snoozed, and persists the change
+func (s *Snoozes) UnsnoozeAll() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.all = time.Time{}
+	return s.save()
+}
+
+// IsSnoozed reports whether the anime is snoozed at the given time, on its own or along with the whole agenda
+func (s *Snoozes) IsSnoozed(animeID int, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Before(s.all) {
+		return true
+	}
+	entry, ok := s.entries[animeID]
+	return ok && now.Before(entry.Until)
+}
+
+// AllUntil returns when the snooze on the whole agenda ends, or the zero time if it isn't snoozed at the given time
+func (s *Snoozes) AllUntil(now time.Time) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Before(s.all) {
+		return s.all
+	}
+	return time.Time{}
+}
+
+// List returns the anime snoozed at the given time, soonest to end first
+func (s *Snoozes) List(now time.Time) []Snooze {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]Snooze, 0, len(s.entries))
+	for _, entry := range s.entries {
+		if now.Before(entry.Until) {
+			result = append(result, entry)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Until.Before(result[j].Until)
+	})
+	return result
+}
+
+func (s *Snoozes) load() error {
+	var file snoozeFile
+	if err := s.file.Load(&file); err != nil {
+		return err
+	}
+	s.all = file.All
+	for _, entry := range file.Anime {
+		s.entries[entry.AnimeID] = entry
+	}
+	return nil
+}
+
+// save writes the snoozes that haven't run out to disk.  Must be called with the lock held.
+func (s *Snoozes) save() error {
+	now := time.Now()
+	for id, entry := range s.entries {
+		if !now.Before(entry.Until) {
+			delete(s.entries, id)
+		}
+	}
+	if !now.Before(s.all) {
+		s.all = time.Time{}
+	}
+
+	file := snoozeFile{All: s.all, Anime: make([]Snooze, 0, len(s.entries))}
+	for _, entry := range s.entries {
+		file.Anime = append(file.Anime, entry)
+	}
+	sort.Slice(file.Anime, func(i, j int) bool {
+		return file.Anime[i].AnimeID < file.Anime[j].AnimeID
+	})
+	return s.file.Save(file)
+}
+
+// SnoozeAnime keeps an anime out of the airing agenda for the given period
+func (s *AnimeService) SnoozeAnime(anime *domain.Anime, period SnoozePeriod) (time.Time, error) {
+	until := SnoozeUntil(period, time.Now())
+	log.Info("Snoozing anime in the airing agenda", "anime_id", anime.ID, "title", anime.Title.Preferred,
+		"until", until)
+	return until, s.snoozes.Snooze(anime.ID, anime.Title.Preferred, until)
+}
+
+// SnoozeAgenda stops the airing agenda being shown for the given period
+func (s *AnimeService) SnoozeAgenda(period SnoozePeriod) (time.Time, error) {
+	until := SnoozeUntil(period, time.Now())
+	log.Info("Snoozing the airing agenda", "until", until)
+	return until, s.snoozes.SnoozeAll(until)
+}
+
+// UnsnoozeAnime lets a snoozed anime back into the airing agenda
+func (s *AnimeService) UnsnoozeAnime(animeID int) error {
+	log.Info("Unsnoozing anime in the airing agenda", "anime_id", animeID)
+	return s.snoozes.Unsnooze(animeID)
+}
+
+// UnsnoozeAgenda lets the airing agenda be shown again, though anime snoozed on their own stay snoozed
+func (s *AnimeService) UnsnoozeAgenda() error {
+	log.Info("Unsnoozing the airing agenda")
+	return s.snoozes.UnsnoozeAll()
+}
+
+// AgendaSnoozedUntil returns when the snooze on the whole airing agenda ends, or the zero time if it isn't snoozed
+func (s *AnimeService) AgendaSnoozedUntil() time.Time {
+	return s.snoozes.AllUntil(time.Now())
+}
+
+// GetSnoozes returns the anime snoozed in the airing agenda, soonest to end first
+func (s *AnimeService) GetSnoozes() []Snooze {
+	return s.snoozes.List(time.Now())
+}
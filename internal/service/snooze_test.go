@@ -0,0 +1,46 @@
+package service
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnoozeUntil(t *testing.T) {
+	// A Wednesday in May
+	now := time.Date(2024, 5, 15, 20, 30, 0, 0, time.UTC)
+	assert.Equal(t, now.Add(24*time.Hour), SnoozeUntil(SnoozeDay, now))
+	assert.Equal(t, time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC), SnoozeUntil(SnoozeWeek, now))
+	assert.Equal(t, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), SnoozeUntil(SnoozeSeason, now))
+
+	monday := time.Date(2024, 5, 20, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 5, 27, 0, 0, 0, 0, time.UTC), SnoozeUntil(SnoozeWeek, monday))
+	december := time.Date(2024, 12, 31, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), SnoozeUntil(SnoozeSeason, december))
+}
+
+func TestSnoozesPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), snoozeFileName)
+	now := time.Now()
+
+	s := NewSnoozes(path)
+	require.NoError(t, s.Snooze(1, "Show A", now.Add(time.Hour)))
+	require.NoError(t, s.Snooze(2, "Show B", now.Add(-time.Hour)))
+	require.NoError(t, s.SnoozeAll(now.Add(2*time.Hour)))
+
+	reloaded := NewSnoozes(path)
+	assert.True(t, reloaded.IsSnoozed(3, now), "everything should be snoozed along with the agenda")
+	assert.False(t, reloaded.AllUntil(now).IsZero())
+
+	require.NoError(t, reloaded.UnsnoozeAll())
+	assert.True(t, reloaded.IsSnoozed(1, now))
+	assert.False(t, reloaded.IsSnoozed(2, now), "a snooze that has run out shouldn't apply")
+	assert.False(t, reloaded.IsSnoozed(3, now))
+
+	snoozes := NewSnoozes(path).List(now)
+	require.Len(t, snoozes, 1)
+	assert.Equal(t, "Show A", snoozes[0].Title)
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// SetHiddenFromStatusLists hides an entry from, or returns it to, the user's status lists on AniList.  Unlike
+// HideAnime this changes the entry on AniList, where hidden entries only appear in the user's custom lists.
+func (s *AnimeService) SetHiddenFromStatusLists(ctx context.Context, animeID int, hidden bool) error {
+	s.saving.Add(1)
+	defer s.saving.Add(-1)
+	s.updateLock.Lock()
+	defer s.updateLock.Unlock()
+
+	anime := s.GetAnimeByID(animeID)
+	if anime == nil || anime.UserData == nil {
+		return fmt.Errorf("anime %d is not in the list", animeID)
+	}
+
+	result, err := s.repo.UpdateAnime(ctx, &domain.AnimeUpdateParams{
+		MediaID:               animeID,
+		HiddenFromStatusLists: &hidden,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", anime.Title.Preferred, err)
+	}
+
+	s.syncAnimeWithUpdateResult(anime, result)
+	log.Info("Changed whether anime is hidden from status lists", "animeID", animeID, "title", anime.Title.Preferred,
+		"hidden", hidden)
+	return nil
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetHiddenFromStatusLists(t *testing.T) {
+	repo := &fakeRepo{}
+	anime := testAnime(1, domain.StatusCurrent, 3)
+	s := &AnimeService{repo: repo, animeList: []*domain.Anime{anime}}
+
+	require.NoError(t, s.SetHiddenFromStatusLists(context.Background(), 1, true))
+	require.Len(t, repo.updates, 1)
+	assert.Nil(t, repo.updates[0].Progress, "only the flag should be sent")
+	assert.True(t, *repo.updates[0].HiddenFromStatusLists)
+	assert.True(t, anime.UserData.HiddenFromStatusLists)
+
+	assert.Error(t, s.SetHiddenFromStatusLists(context.Background(), 2, true), "anime not in the list")
+}
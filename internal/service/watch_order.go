@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// maxWatchOrderHops limits how far the prequel/sequel chain is walked, protecting against enormous franchises
+// and any cycles in the relation data
+const maxWatchOrderHops = 25
+
+// WatchOrderEntry is a single step in a franchise's recommended watch order
+type WatchOrderEntry struct {
+	Anime  *domain.Anime
+	OnList bool // Whether the anime is on the user's list
+}
+
+// IsWatched returns true if the user has completed this entry
+func (e WatchOrderEntry) IsWatched() bool {
+	if e.Anime == nil || e.Anime.UserData == nil {
+		return false
+	}
+	if e.Anime.UserData.Status == domain.StatusCompleted {
+		return true
+	}
+	return e.Anime.Episodes > 0 && e.Anime.UserData.Progress >= e.Anime.Episodes
+}
+
+// GetWatchOrder builds a recommended watch order for the franchise the given anime belongs to.  The order is derived
+// from AniList relations:  the prequel chain is walked back to the earliest entry, then the sequel chain is followed
+// forwards from there.  Entries on the user's list are returned with the cached list data so progress can be shown.
+func (s *AnimeService) GetWatchOrder(ctx context.Context, animeID int) ([]WatchOrderEntry, error) {
+	fetched := make(map[int]*domain.Anime)
+	fetch := func(id int) (*domain.Anime, error) {
+		if anime, ok := fetched[id]; ok {
+			return anime, nil
+		}
+		anime, err := s.repo.GetAnimeByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		fetched[id] = anime
+		return anime, nil
+	}
+
+	current, err := fetch(animeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch anime for watch order: %w", err)
+	}
+
+	// Walk back through prequels to find the start of the franchise
+	visited := map[int]bool{current.ID: true}
+	for hops := 0; hops < maxWatchOrderHops; hops++ {
+		prequelID := findRelation(current, domain.RelationPrequel, visited)
+		if prequelID == 0 {
+			break
+		}
+		prequel, err := fetch(prequelID)
+		if err != nil {
+			log.Warn("Failed to fetch prequel, starting watch order from last known entry", "id", prequelID, "error", err)
+			break
+		}
+		visited[prequel.ID] = true
+		current = prequel
+	}
+
+	// Walk forward through sequels, collecting the order as we go
+	var order []*domain.Anime
+	visited = map[int]bool{current.ID: true}
+	order = append(order, current)
+	for hops := 0; hops < maxWatchOrderHops; hops++ {
+		sequelID := findRelation(current, domain.RelationSequel, visited)
+		if sequelID == 0 {
+			break
+		}
+		sequel, err := fetch(sequelID)
+		if err != nil {
+			log.Warn("Failed to fetch sequel, watch order may be incomplete", "id", sequelID, "error", err)
+			break
+		}
+		visited[sequel.ID] = true
+		order = append(order, sequel)
+		current = sequel
+	}
+
+	entries := make([]WatchOrderEntry, 0, len(order))
+	for _, anime := range order {
+		if listAnime := s.GetAnimeByID(anime.ID); listAnime != nil {
+			entries = append(entries, WatchOrderEntry{Anime: listAnime, OnList: true})
+			continue
+		}
+		entries = append(entries, WatchOrderEntry{Anime: anime, OnList: anime.UserData != nil})
+	}
+
+	log.Info("Built watch order", "anime_id", animeID, "entries", len(entries), "api_calls", len(fetched))
+	return entries, nil
+}
+
+// findRelation returns the ID of the first anime related by the given relation type that has not already been visited
+func findRelation(anime *domain.Anime, relationType domain.RelationType, visited map[int]bool) int {
+	for _, relation := range anime.Relations {
+		if relation.Type == relationType && relation.MediaType == "ANIME" && !visited[relation.AnimeID] {
+			return relation.AnimeID
+		}
+	}
+	return 0
+}
@@ -0,0 +1,145 @@
+// Package simkl scrobbles watched episodes to Simkl, for users who keep a Simkl history alongside their AniList list
+package simkl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/diagnostics"
+	"github.com/PizzaHomicide/hisame/internal/proxy"
+	"github.com/PizzaHomicide/hisame/internal/retry"
+)
+
+// simklAPIURL is the base URL of the Simkl API
+const simklAPIURL = "https://api.simkl.com"
+
+// Client adds watched episodes to a Simkl user's history
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	clientID   string
+	token      string
+	retry      retry.Policy
+}
+
+// NewClient creates a Simkl client from the config.  Returns nil if no token is configured, as scrobbling is optional.
+func NewClient(cfg config.SimklConfig, retryConfig config.RetryConfig) *Client {
+	if cfg.Token == "" {
+		return nil
+	}
+	return &Client{
+		httpClient: &http.Client{
+			Timeout:   15 * time.Second,
+			Transport: retry.NewTransport(proxy.Transport()),
+		},
+		baseURL:  simklAPIURL,
+		clientID: cfg.ClientID,
+		token:    cfg.Token,
+		retry:    retry.FromConfig(retryConfig),
+	}
+}
+
+// Episode is a watched episode to add to the history.  Simkl matches the anime by its AniList or MyAnimeList ID, and
+// the episode by its absolute number.
+type Episode struct {
+	AniListID int
+	MalID     int // 0 if the anime has no MyAnimeList entry
+	Title     string
+	Number    int
+	WatchedAt time.Time
+}
+
+// historyRequest is the body of a sync/history request
+type historyRequest struct {
+	Shows []historyShow `json:"shows"`
+}
+
+type historyShow struct {
+	Title    string           `json:"title,omitempty"`
+	IDs      map[string]int   `json:"ids"`
+	Episodes []historyEpisode `json:"episodes"`
+}
+
+type historyEpisode struct {
+	Number    int    `json:"number"`
+	WatchedAt string `json:"watched_at"`
+}
+
+// historyResponse is the part of a sync/history response that says what wasn't matched
+type historyResponse struct {
+	NotFound struct {
+		Shows []json.RawMessage `json:"shows"`
+	} `json:"not_found"`
+}
+
+// ScrobbleEpisode adds the episode to the user's Simkl history
+func (c *Client) ScrobbleEpisode(ctx context.Context, episode Episode) error {
+	ids := map[string]int{"anilist": episode.AniListID}
+	if episode.MalID != 0 {
+		ids["mal"] = episode.MalID
+	}
+	body, err := json.Marshal(historyRequest{Shows: []historyShow{{
+		Title: episode.Title,
+		IDs:   ids,
+		Episodes: []historyEpisode{{
+			Number:    episode.Number,
+			WatchedAt: episode.WatchedAt.UTC().Format(time.RFC3339),
+		}},
+	}}})
+	if err != nil {
+		return fmt.Errorf("failed to encode history: %w", err)
+	}
+
+	var response historyResponse
+	err = c.retry.Do(ctx, "sync/history", func() error {
+		return c.post(ctx, "/sync/history", body, &response)
+	})
+	if err != nil {
+		return err
+	}
+	if len(response.NotFound.Shows) > 0 {
+		return fmt.Errorf("simkl has no anime matching AniList ID %d", episode.AniListID)
+	}
+	return nil
+}
+
+// post sends a JSON request to the Simkl API, decoding the response into result
+func (c *Client) post(ctx context.Context, path string, body []byte, result interface{}) error {
+	start := time.Now()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("simkl-api-key", c.clientID)
+
+	resp, err := c.httpClient.Do(req)
+	callErr := err
+	if err == nil && resp.StatusCode >= http.StatusBadRequest {
+		callErr = fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	diagnostics.TrackAPICall(diagnostics.APISimkl, path, start, callErr)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("simkl returned HTTP %d: %s", resp.StatusCode, bytes.TrimSpace(data))
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
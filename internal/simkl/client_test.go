@@ -0,0 +1,72 @@
+package simkl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClientWithoutToken(t *testing.T) {
+	assert.Nil(t, NewClient(config.SimklConfig{ClientID: "id"}, config.RetryConfig{}))
+}
+
+func TestScrobbleEpisode(t *testing.T) {
+	var received historyRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/sync/history", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "app", r.Header.Get("simkl-api-key"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		_, _ = w.Write([]byte(`{"added":{"episodes":1},"not_found":{"shows":[]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.SimklConfig{ClientID: "app", Token: "secret"}, config.RetryConfig{})
+	client.baseURL = server.URL
+
+	err := client.ScrobbleEpisode(context.Background(), Episode{
+		AniListID: 154587,
+		MalID:     52991,
+		Title:     "Frieren",
+		Number:    3,
+		WatchedAt: time.Date(2026, 10, 1, 20, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.Len(t, received.Shows, 1)
+	assert.Equal(t, map[string]int{"anilist": 154587, "mal": 52991}, received.Shows[0].IDs)
+	assert.Equal(t, []historyEpisode{{Number: 3, WatchedAt: "2026-10-01T20:00:00Z"}}, received.Shows[0].Episodes)
+}
+
+func TestScrobbleEpisodeNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"added":{"episodes":0},"not_found":{"shows":[{"ids":{"anilist":1}}]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.SimklConfig{Token: "secret"}, config.RetryConfig{})
+	client.baseURL = server.URL
+
+	err := client.ScrobbleEpisode(context.Background(), Episode{AniListID: 1, Number: 1, WatchedAt: time.Now()})
+	assert.Error(t, err)
+}
+
+func TestScrobbleEpisodeUnauthorised(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"user_token_failed"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.SimklConfig{Token: "expired"}, config.RetryConfig{})
+	client.baseURL = server.URL
+
+	err := client.ScrobbleEpisode(context.Background(), Episode{AniListID: 1, Number: 1, WatchedAt: time.Now()})
+	assert.ErrorContains(t, err, "HTTP 401")
+}
@@ -0,0 +1,153 @@
+// Package store persists the state Hisame keeps for itself, such as choices made for individual anime and changes
+// waiting to be synced, as JSON files in the data dir.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/log"
+)
+
+// DataPath returns the path of the named file within the Hisame data dir.  If the data dir can't be found, it warns
+// that what the file holds will not be persisted and returns an empty path, which keeps it in memory only.
+func DataPath(name, what string) string {
+	dataDir, err := config.DataDir()
+	if err != nil {
+		log.Warn("Unable to locate data dir, "+what+" will not be persisted", "error", err)
+		return ""
+	}
+	return filepath.Join(dataDir, name)
+}
+
+// File is a JSON file a value is persisted to.  A file with an empty path keeps nothing on disk, so loading it finds
+// nothing and saving it does nothing.
+type File struct {
+	path string
+}
+
+// NewFile returns the JSON file at the given path
+func NewFile(path string) File {
+	return File{path: path}
+}
+
+// Load reads the file into v.  A missing file is not an error, and leaves v as it was.
+func (f File) Load(v any) error {
+	if f.path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse file: %w", err)
+	}
+	return nil
+}
+
+// Save writes v to the file, creating its dir if needed.  It is written to a temporary file first, so an interrupted
+// save never leaves a truncated file behind.
+func (f File) Save(v any) error {
+	if f.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", filepath.Base(f.path), err)
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return fmt.Errorf("failed to create data dir: %w", err)
+	}
+
+	tmpPath := f.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(f.path), err)
+	}
+	return os.Rename(tmpPath, f.path)
+}
+
+// Remove deletes the file.  A missing file is not an error.
+func (f File) Remove() error {
+	if f.path == "" {
+		return nil
+	}
+	if err := os.Remove(f.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Map is a map persisted to a JSON file, which is saved each time the map changes.  It is safe for concurrent use.
+type Map[K comparable, V any] struct {
+	mu     sync.Mutex
+	file   File
+	values map[K]V
+}
+
+// LoadMap loads a map from the JSON file at the given path.  An empty path keeps the map in memory only.  The map is
+// always usable: if the file can't be loaded it starts empty, and the error is returned for the caller to report.
+func LoadMap[K comparable, V any](path string) (*Map[K, V], error) {
+	m := &Map[K, V]{file: NewFile(path)}
+	err := m.file.Load(&m.values)
+	if err != nil {
+		m.values = nil
+	}
+	if m.values == nil {
+		m.values = make(map[K]V)
+	}
+	return m, err
+}
+
+// Get returns the value for the key, reporting whether there is one
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	value, ok := m.values[key]
+	return value, ok
+}
+
+// Set sets the value for the key
+func (m *Map[K, V]) Set(key K, value V) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[key] = value
+	return m.file.Save(m.values)
+}
+
+// Delete removes the key's value, if it has one
+func (m *Map[K, V]) Delete(key K) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.values[key]; !ok {
+		return nil
+	}
+	delete(m.values, key)
+	return m.file.Save(m.values)
+}
+
+// Update replaces the key's value with the one fn returns, given the current value and whether there is one.  If fn
+// returns false the key is deleted instead.  fn is called with the map locked, so must not use the map itself.
+func (m *Map[K, V]) Update(key K, fn func(value V, ok bool) (V, bool)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, ok := m.values[key]
+	value, keep := fn(current, ok)
+	if keep {
+		m.values[key] = value
+	} else {
+		delete(m.values, key)
+	}
+	return m.file.Save(m.values)
+}
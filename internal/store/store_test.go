@@ -0,0 +1,97 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "values.json")
+	file := NewFile(path)
+
+	require.NoError(t, file.Save([]string{"a", "b"}))
+
+	var loaded []string
+	require.NoError(t, file.Load(&loaded))
+	assert.Equal(t, []string{"a", "b"}, loaded)
+
+	_, err := os.Stat(path + ".tmp")
+	assert.True(t, os.IsNotExist(err), "the temporary file should be renamed into place")
+
+	require.NoError(t, file.Remove())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+	assert.NoError(t, file.Remove(), "removing a missing file is not an error")
+}
+
+func TestFileMissingOrEmptyPath(t *testing.T) {
+	loaded := []string{"unchanged"}
+	require.NoError(t, NewFile(filepath.Join(t.TempDir(), "missing.json")).Load(&loaded))
+	assert.Equal(t, []string{"unchanged"}, loaded)
+
+	file := NewFile("")
+	assert.NoError(t, file.Save([]string{"a"}))
+	assert.NoError(t, file.Load(&loaded))
+	assert.NoError(t, file.Remove())
+}
+
+func TestFileLoadInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "values.json")
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0600))
+
+	var loaded []string
+	assert.Error(t, NewFile(path).Load(&loaded))
+}
+
+func TestMapPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "values.json")
+
+	m, err := LoadMap[int, string](path)
+	require.NoError(t, err)
+	require.NoError(t, m.Set(1, "one"))
+	require.NoError(t, m.Set(2, "two"))
+	require.NoError(t, m.Delete(2))
+	require.NoError(t, m.Delete(3))
+
+	reloaded, err := LoadMap[int, string](path)
+	require.NoError(t, err)
+	value, ok := reloaded.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "one", value)
+	_, ok = reloaded.Get(2)
+	assert.False(t, ok)
+}
+
+func TestMapUpdate(t *testing.T) {
+	m, err := LoadMap[string, int]("")
+	require.NoError(t, err)
+
+	increment := func(value int, _ bool) (int, bool) { return value + 1, true }
+	require.NoError(t, m.Update("a", increment))
+	require.NoError(t, m.Update("a", increment))
+	value, _ := m.Get("a")
+	assert.Equal(t, 2, value)
+
+	require.NoError(t, m.Update("a", func(value int, ok bool) (int, bool) {
+		assert.True(t, ok)
+		return value, false
+	}))
+	_, ok := m.Get("a")
+	assert.False(t, ok, "returning false should delete the key")
+}
+
+func TestLoadMapInvalidStartsEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "values.json")
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0600))
+
+	m, err := LoadMap[int, string](path)
+	assert.Error(t, err)
+	require.NotNil(t, m)
+	require.NoError(t, m.Set(1, "one"))
+	value, _ := m.Get(1)
+	assert.Equal(t, "one", value)
+}
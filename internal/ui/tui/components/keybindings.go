@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
-	"github.com/charmbracelet/lipgloss"
 )
 
 // KeyBinding represents a single key and its description for the keybinding bar
@@ -14,11 +13,6 @@ type KeyBinding struct {
 	Desc string
 }
 
-// keyStyle is used to highlight keyboard shortcuts in UI
-var keyStyle = lipgloss.NewStyle().
-	Foreground(lipgloss.Color("#7D56F4")).
-	Bold(true)
-
 // KeyBindingsBar creates a styled footer showing a set of keybindings
 // width: The width of the screen to center the bar
 // bindings: The list of keybindings to display
@@ -26,7 +20,7 @@ func KeyBindingsBar(width int, bindings []KeyBinding) string {
 	var parts []string
 	for _, b := range bindings {
 		parts = append(parts, fmt.Sprintf("%s: %s",
-			keyStyle.Render(b.Key),
+			styles.KeyStyle.Render(b.Key),
 			b.Desc))
 	}
 
@@ -1,6 +1,8 @@
 package keybindings
 
 import (
+	"strings"
+
 	"github.com/PizzaHomicide/hisame/internal/log"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -15,21 +17,34 @@ const (
 	ActionToggleHelp Action = "toggle_help"
 	ActionLogout     Action = "logout"
 	ActionBack       Action = "back" // General purpose "go back" or "cancel"
+	ActionAPIUsage   Action = "api_usage"
+	ActionQuickPlay  Action = "quick_play"
+	ActionSearchAll  Action = "search_all"
+	// Continue watching the anime watched most recently
+	ActionContinueWatching Action = "continue_watching"
 
 	// Navigation actions
-	ActionMoveUp     Action = "move_up"
-	ActionMoveDown   Action = "move_down"
-	ActionPageUp     Action = "page_up"
-	ActionPageDown   Action = "page_down"
-	ActionMoveTop    Action = "move_top"
-	ActionMoveBottom Action = "move_bottom"
+	ActionMoveUp       Action = "move_up"
+	ActionMoveDown     Action = "move_down"
+	ActionPageUp       Action = "page_up"
+	ActionPageDown     Action = "page_down"
+	ActionMoveTop      Action = "move_top"
+	ActionMoveBottom   Action = "move_bottom"
+	ActionHalfPageUp   Action = "half_page_up"
+	ActionHalfPageDown Action = "half_page_down"
 
 	// Auth view actions
-	ActionLogin Action = "login"
+	ActionLogin      Action = "login"
+	ActionPasteToken Action = "paste_token"
+
+	// Episode selection actions
+	ActionChooseSource  Action = "choose_source"
+	ActionCopyStreamURL Action = "copy_stream_url"
 
 	// Anime list actions
 	ActionSelectEpisode               Action = "select_episode"
 	ActionRefreshAnimeList            Action = "refresh_anime_list"
+	ActionRefreshAnime                Action = "refresh_anime"
 	ActionViewAnimeDetails            Action = "view_anime_details"
 	ActionPlayNextEpisode             Action = "play_next_episode"
 	ActionOpenEpisodeSelector         Action = "episode_selector"
@@ -43,9 +58,95 @@ const (
 	ActionToggleFilterStatusRepeating Action = "toggle_filter_status_repeating"
 	ActionToggleFilterNewEpisodes     Action = "toggle_filter_new_episodes"
 	ActionToggleFilterFinishedAiring  Action = "toggle_filter_finished_airing"
+	ActionToggleFilterAdult           Action = "toggle_filter_adult"
+	ActionToggleFilterStatusHidden    Action = "toggle_filter_status_hidden"
+	ActionToggleFilterFormatTV        Action = "toggle_filter_format_tv"
+	ActionToggleFilterFormatMovie     Action = "toggle_filter_format_movie"
+	ActionToggleFilterFormatOVA       Action = "toggle_filter_format_ova"
+	ActionToggleFilterFormatONA       Action = "toggle_filter_format_ona"
+	ActionToggleFilterFormatSpecial   Action = "toggle_filter_format_special"
+	ActionViewWatchOrder              Action = "view_watch_order"
+	ActionBackfillCompletionDates     Action = "backfill_completion_dates"
+	ActionAuditList                   Action = "audit_list"
+	ActionHideAnime                   Action = "hide_anime"
+	ActionManageHidden                Action = "manage_hidden"
+	ActionViewProfile                 Action = "view_profile"
+	ActionViewSession                 Action = "view_session"
+	ActionExportList                  Action = "export_list"
+	ActionManageBackups               Action = "manage_backups"
+	ActionToggleAiringDayGroups       Action = "toggle_airing_day_groups"
+	ActionTogglePrioritySort          Action = "toggle_priority_sort"
+	ActionCycleSort                   Action = "cycle_sort"
+	ActionToggleDetailedRows          Action = "toggle_detailed_rows"
+	ActionFocusMode                   Action = "focus_mode"
+	ActionImportLocalProgress         Action = "import_local_progress"
+	ActionEditNotes                   Action = "edit_notes"
+	ActionCycleSubtitles              Action = "cycle_subtitles"
+	ActionTogglePause                 Action = "toggle_pause"
+	ActionStopPlayback                Action = "stop_playback"
+
+	// API usage view actions
+	ActionRefreshAPIUsage Action = "refresh_api_usage"
+
+	// Session view actions
+	ActionSessionLogout Action = "session_logout"
+	ActionSwitchAccount Action = "switch_account"
+
+	// List audit view actions
+	ActionApplyAuditFix Action = "apply_audit_fix"
+
+	// Hidden entries view actions
+	ActionUnhideAnime Action = "unhide_anime"
+
+	// Notes view actions
+	ActionSaveNotes Action = "save_notes"
+
+	// Agenda and agenda snoozes view actions
+	ActionSnoozeAnime  Action = "snooze_anime"
+	ActionSnoozeAgenda Action = "snooze_agenda"
+	ActionUnsnooze     Action = "unsnooze"
+
+	// Export view actions
+	ActionConfirmExport      Action = "confirm_export"
+	ActionToggleExportFormat Action = "toggle_export_format"
+
+	// Local import view actions
+	ActionConfirmLocalImport     Action = "confirm_local_import"
+	ActionToggleLocalImportEntry Action = "toggle_local_import_entry"
+
+	// MyAnimeList import view actions
+	ActionConfirmMALImport     Action = "confirm_mal_import"
+	ActionToggleMALImportEntry Action = "toggle_mal_import_entry"
+
+	// Season scores view actions
+	ActionEditScore      Action = "edit_score"
+	ActionClearScore     Action = "clear_score"
+	ActionSaveScores     Action = "save_scores"
+	ActionPreviousSeason Action = "previous_season"
+	ActionNextSeason     Action = "next_season"
+
+	// Playback error view actions
+	ActionRetryPlayback   Action = "retry_playback"
+	ActionCopyDiagnostics Action = "copy_diagnostics"
+
+	// Backups view actions
+	ActionRestoreBackup Action = "restore_backup"
+
+	// Reconcile view actions
+	ActionKeepLocal  Action = "keep_local"
+	ActionKeepRemote Action = "keep_remote"
+	ActionMergeEntry Action = "merge_entry"
+
+	// Focus view actions
+	ActionToggleAutoAdvance Action = "toggle_auto_advance"
+
+	// AniList search view actions
+	ActionSearchOrChoose Action = "search_or_choose"
+	ActionAddAndPlay     Action = "add_and_play"
 
 	// Search mode actions
 	ActionEnableSearch   Action = "enable_search"
+	ActionQuickFilter    Action = "quick_filter"
 	ActionSearchComplete Action = "search_complete"
 
 	// Menu actions
@@ -57,25 +158,65 @@ const (
 type ContextName string
 
 const (
-	ContextGlobal           ContextName = "global"
-	ContextAuth             ContextName = "auth"
-	ContextAnimeList        ContextName = "anime_list"
-	ContextEpisodeSelection ContextName = "episode_selection"
-	ContextSearchMode       ContextName = "search_mode"
-	ContextHelp             ContextName = "help"
-	ContextAnimeDetails     ContextName = "anime_details"
-	ContextMenu             ContextName = "menu"
+	ContextGlobal             ContextName = "global"
+	ContextAuth               ContextName = "auth"
+	ContextAnimeList          ContextName = "anime_list"
+	ContextEpisodeSelection   ContextName = "episode_selection"
+	ContextSearchMode         ContextName = "search_mode"
+	ContextHelp               ContextName = "help"
+	ContextAnimeDetails       ContextName = "anime_details"
+	ContextMenu               ContextName = "menu"
+	ContextWatchOrder         ContextName = "watch_order"
+	ContextAPIUsage           ContextName = "api_usage"
+	ContextCompletionBackfill ContextName = "completion_backfill"
+	ContextListAudit          ContextName = "list_audit"
+	ContextAgenda             ContextName = "agenda"
+	ContextHiddenEntries      ContextName = "hidden_entries"
+	ContextQuickPlay          ContextName = "quick_play"
+	ContextExport             ContextName = "export"
+	ContextBackups            ContextName = "backups"
+	ContextAniListSearch      ContextName = "anilist_search"
+	ContextReconcile          ContextName = "reconcile"
+	ContextFocus              ContextName = "focus"
+	ContextLocalImport        ContextName = "local_import"
+	ContextSnoozes            ContextName = "snoozes"
+	ContextNotes              ContextName = "notes"
+	ContextMALImport          ContextName = "mal_import"
+	ContextSeasonScores       ContextName = "season_scores"
+	ContextSession            ContextName = "session"
+	ContextContinueWatching   ContextName = "continue_watching"
+	ContextPlaybackError      ContextName = "playback_error"
 )
 
 var ContextBindings = map[ContextName][]Binding{
-	ContextGlobal:           globalBindings,
-	ContextAuth:             authBindings,
-	ContextAnimeList:        animeListBindings,
-	ContextEpisodeSelection: episodeSelectBindings,
-	ContextSearchMode:       searchModeBindings,
-	ContextHelp:             helpBindings,
-	ContextAnimeDetails:     animeDetailsBindings,
-	ContextMenu:             menuBindings,
+	ContextGlobal:             globalBindings,
+	ContextAuth:               authBindings,
+	ContextAnimeList:          animeListBindings,
+	ContextEpisodeSelection:   episodeSelectBindings,
+	ContextSearchMode:         searchModeBindings,
+	ContextHelp:               helpBindings,
+	ContextAnimeDetails:       animeDetailsBindings,
+	ContextMenu:               menuBindings,
+	ContextWatchOrder:         watchOrderBindings,
+	ContextAPIUsage:           apiUsageBindings,
+	ContextCompletionBackfill: completionBackfillBindings,
+	ContextListAudit:          listAuditBindings,
+	ContextAgenda:             agendaBindings,
+	ContextHiddenEntries:      hiddenEntriesBindings,
+	ContextQuickPlay:          quickPlayBindings,
+	ContextExport:             exportBindings,
+	ContextBackups:            backupsBindings,
+	ContextAniListSearch:      aniListSearchBindings,
+	ContextReconcile:          reconcileBindings,
+	ContextFocus:              focusBindings,
+	ContextLocalImport:        localImportBindings,
+	ContextSnoozes:            snoozesBindings,
+	ContextNotes:              notesBindings,
+	ContextMALImport:          malImportBindings,
+	ContextSeasonScores:       seasonScoresBindings,
+	ContextSession:            sessionBindings,
+	ContextContinueWatching:   continueWatchingBindings,
+	ContextPlaybackError:      playbackErrorBindings,
 }
 
 // KeyMap stores the mappings from actions to key sequences for each context
@@ -123,18 +264,34 @@ var navigationBindings = []Binding{
 			Help:    "Move down one page",
 		},
 	},
+	{
+		Action: ActionHalfPageUp,
+		KeyMap: KeyMap{
+			Primary: "ctrl+u",
+			Help:    "Move up half a page",
+		},
+	},
+	{
+		Action: ActionHalfPageDown,
+		KeyMap: KeyMap{
+			Primary: "ctrl+d",
+			Help:    "Move down half a page",
+		},
+	},
 	{
 		Action: ActionMoveTop,
 		KeyMap: KeyMap{
-			Primary: "home",
-			Help:    "Move top of view",
+			Primary:   "home",
+			Secondary: "gg",
+			Help:      "Move top of view",
 		},
 	},
 	{
 		Action: ActionMoveBottom,
 		KeyMap: KeyMap{
-			Primary: "end",
-			Help:    "Move bottom of view",
+			Primary:   "end",
+			Secondary: "G",
+			Help:      "Move bottom of view",
 		},
 	},
 }
@@ -169,6 +326,34 @@ var globalBindings = []Binding{
 			Help:    "Go back/cancel current action",
 		},
 	},
+	{
+		Action: ActionAPIUsage,
+		KeyMap: KeyMap{
+			Primary: "ctrl+t",
+			Help:    "Show recent API usage",
+		},
+	},
+	{
+		Action: ActionQuickPlay,
+		KeyMap: KeyMap{
+			Primary: "ctrl+g",
+			Help:    "Quick play anything in your list",
+		},
+	},
+	{
+		Action: ActionSearchAll,
+		KeyMap: KeyMap{
+			Primary: "ctrl+s",
+			Help:    "Search all of AniList, including anime not in your list",
+		},
+	},
+	{
+		Action: ActionContinueWatching,
+		KeyMap: KeyMap{
+			Primary: "ctrl+r",
+			Help:    "Continue watching the anime you watched most recently",
+		},
+	},
 }
 
 // authBindings contains key bindings specific to the auth view
@@ -181,6 +366,13 @@ var authBindings = []Binding{
 			Help:      "Start login process",
 		},
 	},
+	{
+		Action: ActionPasteToken,
+		KeyMap: KeyMap{
+			Primary: "t",
+			Help:    "Log in by pasting a token, for machines without a browser such as over SSH",
+		},
+	},
 }
 
 // helpBindings contains key bindings specific to the help view
@@ -202,6 +394,13 @@ var animeListBindings = withNavigation([]Binding{
 			Help:    "Refresh anime list",
 		},
 	},
+	{
+		Action: ActionRefreshAnime,
+		KeyMap: KeyMap{
+			Primary: "R",
+			Help:    "Refresh the selected anime only",
+		},
+	},
 	{
 		Action: ActionPlayNextEpisode,
 		KeyMap: KeyMap{
@@ -224,6 +423,13 @@ var animeListBindings = withNavigation([]Binding{
 			Help:      "Search anime",
 		},
 	},
+	{
+		Action: ActionQuickFilter,
+		KeyMap: KeyMap{
+			Primary: ":",
+			Help:    "Open the quick filter bar (e.g. s:watching score>8 year:2024 frieren)",
+		},
+	},
 	{
 		Action: ActionIncrementProgress,
 		KeyMap: KeyMap{
@@ -295,6 +501,90 @@ var animeListBindings = withNavigation([]Binding{
 			Help:    "Toggle finished airing filter",
 		},
 	},
+	{
+		Action: ActionToggleFilterAdult,
+		KeyMap: KeyMap{
+			Primary: "A",
+			Help:    "Toggle hiding adult anime",
+		},
+	},
+	{
+		Action: ActionToggleFilterStatusHidden,
+		KeyMap: KeyMap{
+			Primary: "H",
+			Help:    "Toggle entries hidden from AniList status lists",
+		},
+	},
+	{
+		Action: ActionToggleAiringDayGroups,
+		KeyMap: KeyMap{
+			Primary: "w",
+			Help:    "Toggle grouping the list by airing weekday",
+		},
+	},
+	{
+		Action: ActionTogglePrioritySort,
+		KeyMap: KeyMap{
+			Primary: "P",
+			Help:    "Toggle sorting the list by priority",
+		},
+	},
+	{
+		Action: ActionCycleSort,
+		KeyMap: KeyMap{
+			Primary: "O",
+			Help:    "Cycle what the list is sorted by",
+		},
+	},
+	{
+		Action: ActionToggleDetailedRows,
+		KeyMap: KeyMap{
+			Primary: "D",
+			Help:    "Toggle a second line of genres, next episode and notes under each anime",
+		},
+	},
+	{
+		Action: ActionFocusMode,
+		KeyMap: KeyMap{
+			Primary: "F",
+			Help:    "Focus on the selected anime for a marathon",
+		},
+	},
+	{
+		Action: ActionToggleFilterFormatTV,
+		KeyMap: KeyMap{
+			Primary: "t",
+			Help:    "Toggle TV format filter",
+		},
+	},
+	{
+		Action: ActionToggleFilterFormatMovie,
+		KeyMap: KeyMap{
+			Primary: "m",
+			Help:    "Toggle movie format filter",
+		},
+	},
+	{
+		Action: ActionToggleFilterFormatOVA,
+		KeyMap: KeyMap{
+			Primary: "v",
+			Help:    "Toggle OVA format filter",
+		},
+	},
+	{
+		Action: ActionToggleFilterFormatONA,
+		KeyMap: KeyMap{
+			Primary: "n",
+			Help:    "Toggle ONA format filter",
+		},
+	},
+	{
+		Action: ActionToggleFilterFormatSpecial,
+		KeyMap: KeyMap{
+			Primary: "s",
+			Help:    "Toggle special format filter",
+		},
+	},
 	{
 		Action: ActionViewAnimeDetails,
 		KeyMap: KeyMap{
@@ -302,6 +592,104 @@ var animeListBindings = withNavigation([]Binding{
 			Help:    "View anime details",
 		},
 	},
+	{
+		Action: ActionViewWatchOrder,
+		KeyMap: KeyMap{
+			Primary: "o",
+			Help:    "View franchise watch order",
+		},
+	},
+	{
+		Action: ActionBackfillCompletionDates,
+		KeyMap: KeyMap{
+			Primary: "b",
+			Help:    "Backfill missing completion dates",
+		},
+	},
+	{
+		Action: ActionAuditList,
+		KeyMap: KeyMap{
+			Primary: "i",
+			Help:    "Audit list for inconsistent entries",
+		},
+	},
+	{
+		Action: ActionHideAnime,
+		KeyMap: KeyMap{
+			Primary: "x",
+			Help:    "Hide the selected anime from Hisame",
+		},
+	},
+	{
+		Action: ActionManageHidden,
+		KeyMap: KeyMap{
+			Primary: "X",
+			Help:    "Manage hidden anime",
+		},
+	},
+	{
+		Action: ActionViewProfile,
+		KeyMap: KeyMap{
+			Primary: "u",
+			Help:    "View the logged in AniList profile",
+		},
+	},
+	{
+		Action: ActionViewSession,
+		KeyMap: KeyMap{
+			Primary: "U",
+			Help:    "View the login session and AniList rate limit",
+		},
+	},
+	{
+		Action: ActionExportList,
+		KeyMap: KeyMap{
+			Primary: "E",
+			Help:    "Export the list to a shareable file",
+		},
+	},
+	{
+		Action: ActionManageBackups,
+		KeyMap: KeyMap{
+			Primary: "B",
+			Help:    "Restore a list backup",
+		},
+	},
+	{
+		Action: ActionImportLocalProgress,
+		KeyMap: KeyMap{
+			Primary: "L",
+			Help:    "Import progress from a folder of downloaded episodes",
+		},
+	},
+	{
+		Action: ActionEditNotes,
+		KeyMap: KeyMap{
+			Primary: "N",
+			Help:    "Edit the notes of the selected anime",
+		},
+	},
+	{
+		Action: ActionCycleSubtitles,
+		KeyMap: KeyMap{
+			Primary: "c",
+			Help:    "Switch to the next subtitle track in the playing episode",
+		},
+	},
+	{
+		Action: ActionTogglePause,
+		KeyMap: KeyMap{
+			Primary: ".",
+			Help:    "Pause or resume the playing episode",
+		},
+	},
+	{
+		Action: ActionStopPlayback,
+		KeyMap: KeyMap{
+			Primary: "S",
+			Help:    "Stop the playing episode",
+		},
+	},
 })
 
 // episodeSelectBindings contains key bindings specific to the episode selection view
@@ -321,6 +709,20 @@ var episodeSelectBindings = withNavigation([]Binding{
 			Help:      "Search episodes",
 		},
 	},
+	{
+		Action: ActionChooseSource,
+		KeyMap: KeyMap{
+			Primary: "s",
+			Help:    "Choose the source to play the episode from",
+		},
+	},
+	{
+		Action: ActionCopyStreamURL,
+		KeyMap: KeyMap{
+			Primary: "y",
+			Help:    "Copy the episode's stream URL to the clipboard",
+		},
+	},
 })
 
 // animDetailsBindings contains key bindings specific to the anime details screen
@@ -355,6 +757,133 @@ var menuBindings = withNavigation([]Binding{
 	},
 })
 
+// watchOrderBindings contains key bindings specific to the watch order view
+var watchOrderBindings = withNavigation([]Binding{
+	{
+		Action: ActionViewAnimeDetails,
+		KeyMap: KeyMap{
+			Primary:   "enter",
+			Secondary: "d",
+			Help:      "View details of the selected entry",
+		},
+	},
+})
+
+// apiUsageBindings contains key bindings specific to the API usage view
+var apiUsageBindings = withNavigation([]Binding{
+	{
+		Action: ActionRefreshAPIUsage,
+		KeyMap: KeyMap{
+			Primary: "r",
+			Help:    "Refresh the list of API calls",
+		},
+	},
+})
+
+// sessionBindings contains key bindings specific to the session view
+var sessionBindings = []Binding{
+	{
+		Action: ActionSessionLogout,
+		KeyMap: KeyMap{
+			Primary: "l",
+			Help:    "Log out of AniList",
+		},
+	},
+	{
+		Action: ActionSwitchAccount,
+		KeyMap: KeyMap{
+			Primary: "s",
+			Help:    "Log out and log in as a different AniList account",
+		},
+	},
+}
+
+// completionBackfillBindings contains key bindings specific to the completion date backfill view
+var completionBackfillBindings = withNavigation([]Binding{
+	{
+		Action: ActionSelectMenuItem,
+		KeyMap: KeyMap{
+			Primary: "enter",
+			Help:    "Apply the selected option",
+		},
+	},
+})
+
+// listAuditBindings contains key bindings specific to the list audit view
+var listAuditBindings = withNavigation([]Binding{
+	{
+		Action: ActionApplyAuditFix,
+		KeyMap: KeyMap{
+			Primary:   "enter",
+			Secondary: "f",
+			Help:      "Apply the suggested fix to the selected entry",
+		},
+	},
+})
+
+// agendaBindings contains key bindings specific to the airing agenda view
+var agendaBindings = withNavigation([]Binding{
+	{
+		Action: ActionPlayNextEpisode,
+		KeyMap: KeyMap{
+			Primary:   "enter",
+			Secondary: "p",
+			Help:      "Play the selected episode once it has aired",
+		},
+	},
+	{
+		Action: ActionSnoozeAnime,
+		KeyMap: KeyMap{
+			Primary: "z",
+			Help:    "Snooze the selected anime in the agenda",
+		},
+	},
+	{
+		Action: ActionSnoozeAgenda,
+		KeyMap: KeyMap{
+			Primary: "Z",
+			Help:    "Snooze the whole agenda",
+		},
+	},
+})
+
+// continueWatchingBindings contains key bindings specific to the continue watching view
+var continueWatchingBindings = withNavigation([]Binding{
+	{
+		Action: ActionPlayNextEpisode,
+		KeyMap: KeyMap{
+			Primary:   "enter",
+			Secondary: "p",
+			Help:      "Play the next episode of the selected anime",
+		},
+	},
+})
+
+// playbackErrorBindings contains key bindings specific to the playback error view
+var playbackErrorBindings = []Binding{
+	{
+		Action: ActionRetryPlayback,
+		KeyMap: KeyMap{
+			Primary: "r",
+			Help:    "Try playing the episode again",
+		},
+	},
+	{
+		Action: ActionChooseSource,
+		KeyMap: KeyMap{
+			Primary: "s",
+			Help:    "Choose another source to play the episode from",
+		},
+	},
+	{
+		Action: ActionCopyDiagnostics,
+		KeyMap: KeyMap{
+			Primary: "c",
+			Help:    "Copy details of the failure to the clipboard, for a bug report",
+		},
+	},
+}
+
 // GetActionKey returns the primary key for an action
 func GetActionKey(action Action, bindings []Binding) string {
 	for _, binding := range bindings {
@@ -365,6 +894,67 @@ func GetActionKey(action Action, bindings []Binding) string {
 	return ""
 }
 
+// DisplayKey returns the primary key bound to the action in the context, as shown in footers and help text, e.g.
+// "Ctrl+h" for ctrl+h and "↑" for up.  Actions the context doesn't bind are looked up in the global context.
+func DisplayKey(action Action, name ContextName) string {
+	key := GetActionKey(action, ContextBindings[name])
+	if key == "" {
+		key = GetActionKey(action, ContextBindings[ContextGlobal])
+	}
+	return displayKeyName(key)
+}
+
+// DisplayKeys returns the primary keys bound to the actions in the context, as DisplayKey does, separated by slashes,
+// e.g. "↑/↓" for moving up and down
+func DisplayKeys(name ContextName, actions ...Action) string {
+	keys := make([]string, 0, len(actions))
+	for _, action := range actions {
+		keys = append(keys, DisplayKey(action, name))
+	}
+	return strings.Join(keys, "/")
+}
+
+// DisplayKeyWithSecondary returns the primary key bound to the action in the context, as DisplayKey does, followed by
+// its secondary key if it has one, e.g. "Enter/p"
+func DisplayKeyWithSecondary(action Action, name ContextName) string {
+	key := DisplayKey(action, name)
+	if secondary := GetActionSecondaryKey(action, ContextBindings[name]); secondary != "" {
+		key += "/" + displayKeyName(secondary)
+	}
+	return key
+}
+
+// keyDisplayNames are how keys bubbletea names in lower case are shown to the user
+var keyDisplayNames = map[string]string{
+	"up":        "↑",
+	"down":      "↓",
+	"left":      "←",
+	"right":     "→",
+	"pgup":      "PgUp",
+	"pgdown":    "PgDn",
+	"home":      "Home",
+	"end":       "End",
+	"esc":       "Esc",
+	"enter":     "Enter",
+	"tab":       "Tab",
+	"delete":    "Del",
+	"backspace": "Backspace",
+	" ":         "Space",
+}
+
+// displayKeyName returns how the key is shown to the user, e.g. "Ctrl+h" for ctrl+h.  Character keys are shown as is.
+func displayKeyName(key string) string {
+	if name, ok := keyDisplayNames[key]; ok {
+		return name
+	}
+	for _, modifier := range []string{"ctrl+", "alt+", "shift+"} {
+		if rest, ok := strings.CutPrefix(key, modifier); ok {
+			return strings.ToUpper(modifier[:1]) + modifier[1:] + displayKeyName(rest)
+		}
+	}
+	return key
+}
+
 // GetActionSecondaryKey returns the secondary key for an action if it exists
 func GetActionSecondaryKey(action Action, bindings []Binding) string {
 	for _, binding := range bindings {
@@ -424,3 +1014,315 @@ func GetHelpText(title string, bindings []Binding) string {
 func withNavigation(bindings []Binding) []Binding {
 	return append(append([]Binding{}, navigationBindings...), bindings...)
 }
+
+// hiddenEntriesBindings contains key bindings specific to the hidden entries view
+var hiddenEntriesBindings = withNavigation([]Binding{
+	{
+		Action: ActionUnhideAnime,
+		KeyMap: KeyMap{
+			Primary:   "enter",
+			Secondary: "u",
+			Help:      "Unhide the selected anime",
+		},
+	},
+})
+
+// notesBindings contains key bindings specific to the notes editor.  Everything else is typed into the notes, so the
+// conflict keys only apply once the notes were found to have changed on AniList.
+var notesBindings = []Binding{
+	{
+		Action: ActionSaveNotes,
+		KeyMap: KeyMap{
+			Primary: "ctrl+o",
+			Help:    "Save the notes to AniList",
+		},
+	},
+	{
+		Action: ActionKeepLocal,
+		KeyMap: KeyMap{
+			Primary: "l",
+			Help:    "On a conflict, save your notes over AniList's",
+		},
+	},
+	{
+		Action: ActionKeepRemote,
+		KeyMap: KeyMap{
+			Primary: "r",
+			Help:    "On a conflict, keep AniList's notes and discard yours",
+		},
+	},
+	{
+		Action: ActionMergeEntry,
+		KeyMap: KeyMap{
+			Primary: "m",
+			Help:    "On a conflict, merge both and review the result before saving",
+		},
+	},
+	{
+		Action: ActionBack,
+		KeyMap: KeyMap{
+			Primary: "esc",
+			Help:    "Discard changes and return",
+		},
+	},
+}
+
+// snoozesBindings contains key bindings specific to the agenda snoozes view
+var snoozesBindings = withNavigation([]Binding{
+	{
+		Action: ActionUnsnooze,
+		KeyMap: KeyMap{
+			Primary:   "enter",
+			Secondary: "u",
+			Help:      "Remove the selected snooze",
+		},
+	},
+	{
+		Action: ActionSnoozeAgenda,
+		KeyMap: KeyMap{
+			Primary: "z",
+			Help:    "Snooze the whole agenda",
+		},
+	},
+})
+
+// backupsBindings contains key bindings specific to the list backups view
+var backupsBindings = withNavigation([]Binding{
+	{
+		Action: ActionRestoreBackup,
+		KeyMap: KeyMap{
+			Primary:   "enter",
+			Secondary: "r",
+			Help:      "Restore the selected backup",
+		},
+	},
+})
+
+// quickPlayBindings contains key bindings specific to the quick play launcher.  Letters are typed into the search, so
+// only the arrow keys navigate.
+var quickPlayBindings = []Binding{
+	{
+		Action: ActionMoveUp,
+		KeyMap: KeyMap{
+			Primary: "up",
+			Help:    "Move cursor up",
+		},
+	},
+	{
+		Action: ActionMoveDown,
+		KeyMap: KeyMap{
+			Primary: "down",
+			Help:    "Move cursor down",
+		},
+	},
+	{
+		Action: ActionPlayNextEpisode,
+		KeyMap: KeyMap{
+			Primary: "enter",
+			Help:    "Play the next episode of the selected anime",
+		},
+	},
+	{
+		Action: ActionBack,
+		KeyMap: KeyMap{
+			Primary: "esc",
+			Help:    "Close quick play",
+		},
+	},
+}
+
+// exportBindings contains key bindings specific to the export view.  Other keys are typed into the path.
+var exportBindings = []Binding{
+	{
+		Action: ActionConfirmExport,
+		KeyMap: KeyMap{
+			Primary: "enter",
+			Help:    "Export the list to the entered path",
+		},
+	},
+	{
+		Action: ActionToggleExportFormat,
+		KeyMap: KeyMap{
+			Primary: "tab",
+			Help:    "Switch between an HTML page, MyAnimeList XML, JSON and CSV",
+		},
+	},
+	{
+		Action: ActionBack,
+		KeyMap: KeyMap{
+			Primary: "esc",
+			Help:    "Close export",
+		},
+	},
+}
+
+// localImportBindings contains key bindings specific to the local import view.  While asking for the folder, keys
+// other than enter and esc are typed into the path.
+var localImportBindings = withNavigation([]Binding{
+	{
+		Action: ActionConfirmLocalImport,
+		KeyMap: KeyMap{
+			Primary: "enter",
+			Help:    "Scan the entered folder, then update the selected entries",
+		},
+	},
+	{
+		Action: ActionToggleLocalImportEntry,
+		KeyMap: KeyMap{
+			Primary: "t",
+			Help:    "Toggle whether the selected entry is updated",
+		},
+	},
+})
+
+// malImportBindings contains key bindings specific to the MyAnimeList import view.  While asking for the export file,
+// keys other than enter and esc are typed into the path.
+var malImportBindings = withNavigation([]Binding{
+	{
+		Action: ActionConfirmMALImport,
+		KeyMap: KeyMap{
+			Primary: "enter",
+			Help:    "Preview importing the entered file, then save the selected changes",
+		},
+	},
+	{
+		Action: ActionToggleMALImportEntry,
+		KeyMap: KeyMap{
+			Primary: "t",
+			Help:    "Toggle whether the selected change is saved",
+		},
+	},
+})
+
+// seasonScoresBindings contains key bindings specific to the season scores view.  Typing a number also starts editing
+// the selected score, and while editing, moving up or down keeps the score and moves to the next row.
+var seasonScoresBindings = withNavigation([]Binding{
+	{
+		Action: ActionEditScore,
+		KeyMap: KeyMap{
+			Primary: "enter",
+			Help:    "Edit the selected score, or keep the score being edited",
+		},
+	},
+	{
+		Action: ActionClearScore,
+		KeyMap: KeyMap{
+			Primary:   "x",
+			Secondary: "delete",
+			Help:      "Clear the selected score",
+		},
+	},
+	{
+		Action: ActionSaveScores,
+		KeyMap: KeyMap{
+			Primary: "ctrl+o",
+			Help:    "Save every changed score to AniList",
+		},
+	},
+	{
+		Action: ActionPreviousSeason,
+		KeyMap: KeyMap{
+			Primary: "[",
+			Help:    "Show the previous season",
+		},
+	},
+	{
+		Action: ActionNextSeason,
+		KeyMap: KeyMap{
+			Primary: "]",
+			Help:    "Show the next season",
+		},
+	},
+})
+
+// aniListSearchBindings contains key bindings specific to the AniList search view.  Other keys are typed into the
+// query.
+var aniListSearchBindings = []Binding{
+	{
+		Action: ActionMoveUp,
+		KeyMap: KeyMap{
+			Primary: "up",
+			Help:    "Move cursor up",
+		},
+	},
+	{
+		Action: ActionMoveDown,
+		KeyMap: KeyMap{
+			Primary: "down",
+			Help:    "Move cursor down",
+		},
+	},
+	{
+		Action: ActionSearchOrChoose,
+		KeyMap: KeyMap{
+			Primary: "enter",
+			Help:    "Search, or choose an episode of the selected anime once the query has been searched",
+		},
+	},
+	{
+		Action: ActionAddAndPlay,
+		KeyMap: KeyMap{
+			Primary: "ctrl+a",
+			Help:    "Add the selected anime to your list as watching, then play its next episode",
+		},
+	},
+	{
+		Action: ActionBack,
+		KeyMap: KeyMap{
+			Primary: "esc",
+			Help:    "Close search",
+		},
+	},
+}
+
+// reconcileBindings contains key bindings specific to the reconcile view
+var reconcileBindings = withNavigation([]Binding{
+	{
+		Action: ActionKeepLocal,
+		KeyMap: KeyMap{
+			Primary: "l",
+			Help:    "Keep the change made in Hisame, overwriting AniList",
+		},
+	},
+	{
+		Action: ActionKeepRemote,
+		KeyMap: KeyMap{
+			Primary: "r",
+			Help:    "Keep AniList's entry, discarding the change made in Hisame",
+		},
+	},
+	{
+		Action: ActionMergeEntry,
+		KeyMap: KeyMap{
+			Primary: "m",
+			Help:    "Merge, keeping the furthest progress from either side",
+		},
+	},
+})
+
+// focusBindings contains key bindings specific to focus mode.  Every other key is ignored, so nothing can be changed
+// by accident during a marathon.
+var focusBindings = []Binding{
+	{
+		Action: ActionPlayNextEpisode,
+		KeyMap: KeyMap{
+			Primary:   "enter",
+			Secondary: "p",
+			Help:      "Play the next episode now",
+		},
+	},
+	{
+		Action: ActionToggleAutoAdvance,
+		KeyMap: KeyMap{
+			Primary: "a",
+			Help:    "Toggle playing the next episode automatically once one is watched",
+		},
+	},
+	{
+		Action: ActionBack,
+		KeyMap: KeyMap{
+			Primary: "esc",
+			Help:    "Cancel a pending auto-advance, or leave focus mode",
+		},
+	},
+}
@@ -3,35 +3,37 @@ package keybindings
 import (
 	"fmt"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNoDuplicateKeyBindings(t *testing.T) {
 	// Check each context individually
 	for contextName, bindings := range ContextBindings {
 		t.Run(fmt.Sprintf("Context_%s", contextName), func(t *testing.T) {
-			keyToAction := make(map[string]Action)
-
-			for _, binding := range bindings {
-				// Check primary key
-				if existingAction, exists := keyToAction[binding.KeyMap.Primary]; exists {
-					t.Errorf("Duplicate key binding '%s' in context '%s': "+
-						"first assigned to action '%s', then to '%s'",
-						binding.KeyMap.Primary, contextName, existingAction, binding.Action)
-				} else {
-					keyToAction[binding.KeyMap.Primary] = binding.Action
-				}
-
-				// Check secondary key if it exists
-				if binding.KeyMap.Secondary != "" {
-					if existingAction, exists := keyToAction[binding.KeyMap.Secondary]; exists {
-						t.Errorf("Duplicate key binding '%s' in context '%s': "+
-							"first assigned to action '%s', then to '%s'",
-							binding.KeyMap.Secondary, contextName, existingAction, binding.Action)
-					} else {
-						keyToAction[binding.KeyMap.Secondary] = binding.Action
-					}
-				}
+			if err := duplicateKeys(bindings); err != nil {
+				t.Errorf("Duplicate key bindings in context '%s': %v", contextName, err)
 			}
 		})
 	}
 }
+
+func TestDisplayKey(t *testing.T) {
+	restoreBindings(t)
+
+	assert.Equal(t, "s", DisplayKey(ActionChooseSource, ContextEpisodeSelection))
+	assert.Equal(t, "Ctrl+h", DisplayKey(ActionToggleHelp, ContextEpisodeSelection),
+		"global actions should be found from any context")
+	assert.Equal(t, "↑/↓", DisplayKeys(ContextEpisodeSelection, ActionMoveUp, ActionMoveDown))
+	assert.Equal(t, "Enter/p", DisplayKeyWithSecondary(ActionPlayNextEpisode, ContextAgenda))
+	assert.Equal(t, "PgUp/PgDn", DisplayKeys(ContextHelp, ActionPageUp, ActionPageDown))
+
+	require.NoError(t, Apply(map[string]map[string]string{
+		"episode_selection": {"choose_source": "ctrl+k"},
+		"global":            {"back": "q"},
+	}))
+	assert.Equal(t, "Ctrl+k", DisplayKey(ActionChooseSource, ContextEpisodeSelection),
+		"remapped keys should be shown")
+	assert.Equal(t, "q", DisplayKey(ActionBack, ContextEpisodeSelection))
+}
@@ -0,0 +1,61 @@
+package keybindings
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ActionPending is returned by Navigation while a count or key sequence is still being typed
+const ActionPending Action = "pending"
+
+// maxCount stops a long run of digits from overflowing the count
+const maxCount = 9999
+
+// Navigation reads vim style key presses: a count typed before a key repeats its action, e.g. 5j moves down five, and
+// a key bound as the same character twice, e.g. gg, is typed as two presses.  It keeps what has been typed between
+// presses, so each view needs its own.
+type Navigation struct {
+	count   int
+	pending string // First key of a sequence, waiting for the second
+}
+
+// Action returns the action bound to the key press in the context, and how many times to do it.  Digits only start a
+// count if they aren't bound to anything else in the context, but carry on a count once one has started.
+func (n *Navigation) Action(keyMsg tea.KeyMsg, name ContextName) (Action, int) {
+	key := keyMsg.String()
+	bindings := ContextBindings[name]
+
+	if n.pending != "" {
+		sequence := n.pending + key
+		n.pending = ""
+		if action, _ := GetBindingByKey(sequence, bindings); action != "" {
+			return action, n.takeCount()
+		}
+	}
+
+	digit := len(key) == 1 && key[0] >= '0' && key[0] <= '9'
+	if digit && n.count > 0 {
+		n.count = min(n.count*10+int(key[0]-'0'), maxCount)
+		return ActionPending, 0
+	}
+	if action, _ := GetBindingByKey(key, bindings); action != "" {
+		return action, n.takeCount()
+	}
+	if digit && key != "0" {
+		n.count = int(key[0] - '0')
+		return ActionPending, 0
+	}
+	if action, _ := GetBindingByKey(key+key, bindings); action != "" {
+		n.pending = key
+		return ActionPending, 0
+	}
+
+	n.count = 0
+	return GetActionByKey(keyMsg, name), 1
+}
+
+// takeCount returns the count typed before the key, 1 if there wasn't one, and starts counting again
+func (n *Navigation) takeCount() int {
+	count := max(n.count, 1)
+	n.count = 0
+	return count
+}
@@ -0,0 +1,57 @@
+package keybindings
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "ctrl+d":
+		return tea.KeyMsg{Type: tea.KeyCtrlD}
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestNavigation(t *testing.T) {
+	type press struct {
+		key    string
+		action Action
+		count  int
+	}
+	tests := map[string]struct {
+		context ContextName
+		presses []press
+	}{
+		"single key": {ContextEpisodeSelection, []press{{"j", ActionMoveDown, 1}, {"ctrl+d", ActionHalfPageDown, 1}}},
+		"count": {ContextEpisodeSelection, []press{
+			{"1", ActionPending, 0}, {"2", ActionPending, 0}, {"j", ActionMoveDown, 12}, {"k", ActionMoveUp, 1},
+		}},
+		"gg":              {ContextHelp, []press{{"g", ActionPending, 0}, {"g", ActionMoveTop, 1}, {"G", ActionMoveBottom, 1}}},
+		"broken sequence": {ContextHelp, []press{{"g", ActionPending, 0}, {"j", ActionMoveDown, 1}}},
+		"count cleared by an unbound key": {ContextHelp, []press{
+			{"3", ActionPending, 0}, {"q", "", 1}, {"j", ActionMoveDown, 1},
+		}},
+		"bound digit": {ContextAnimeList, []press{
+			{"1", ActionToggleFilterStatusCurrent, 1}, {"7", ActionPending, 0}, {"1", ActionPending, 0},
+			{"j", ActionMoveDown, 71},
+		}},
+		"count before another action": {ContextEpisodeSelection, []press{
+			{"5", ActionPending, 0}, {"enter", ActionSelectEpisode, 5}, {"j", ActionMoveDown, 1},
+		}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var nav Navigation
+			for _, p := range tt.presses {
+				action, count := nav.Action(key(p.key), tt.context)
+				assert.Equal(t, p.action, action, "action of %q", p.key)
+				assert.Equal(t, p.count, count, "count of %q", p.key)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package keybindings
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Apply replaces the keys of bindings with those set in the config, as context name to action name to keys, e.g.
+// anime_list: {play_next_episode: "Q"}.  Keys are separated by commas, the first replacing the primary key and the
+// second, if given, the secondary key.  The bindings are left unchanged if any override names a context or action
+// that doesn't exist, or would bind a key twice in a context.
+func Apply(overrides map[string]map[string]string) error {
+	updated := make(map[ContextName][]Binding, len(ContextBindings))
+	for name, bindings := range ContextBindings {
+		updated[name] = append([]Binding{}, bindings...)
+	}
+
+	var errs []error
+	for _, contextName := range sortedKeys(overrides) {
+		name := ContextName(contextName)
+		bindings, ok := updated[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown context %q", contextName))
+			continue
+		}
+		for _, actionName := range sortedKeys(overrides[contextName]) {
+			keyMap, err := parseKeys(overrides[contextName][actionName])
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s.%s: %w", contextName, actionName, err))
+				continue
+			}
+			if !rebind(bindings, Action(actionName), keyMap) {
+				errs = append(errs, fmt.Errorf("%s.%s: unknown action", contextName, actionName))
+			}
+		}
+		if err := duplicateKeys(bindings); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", contextName, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	ContextBindings = updated
+	return nil
+}
+
+// parseKeys reads the comma separated primary and secondary keys of an override
+func parseKeys(value string) (KeyMap, error) {
+	var keys []string
+	for _, key := range strings.Split(value, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	switch len(keys) {
+	case 1:
+		return KeyMap{Primary: keys[0]}, nil
+	case 2:
+		return KeyMap{Primary: keys[0], Secondary: keys[1]}, nil
+	case 0:
+		return KeyMap{}, errors.New("no key given")
+	}
+	return KeyMap{}, fmt.Errorf("%d keys given, at most 2 can be bound", len(keys))
+}
+
+// rebind sets the keys of the action's binding, keeping its help text.  Returns false if the action isn't bound.
+func rebind(bindings []Binding, action Action, keyMap KeyMap) bool {
+	for i := range bindings {
+		if bindings[i].Action == action {
+			keyMap.Help = bindings[i].KeyMap.Help
+			bindings[i].KeyMap = keyMap
+			return true
+		}
+	}
+	return false
+}
+
+// duplicateKeys returns an error naming each key bound to more than one action in the bindings
+func duplicateKeys(bindings []Binding) error {
+	keyToAction := make(map[string]Action)
+	var errs []error
+	for _, binding := range bindings {
+		for _, key := range []string{binding.KeyMap.Primary, binding.KeyMap.Secondary} {
+			if key == "" {
+				continue
+			}
+			if existing, exists := keyToAction[key]; exists {
+				errs = append(errs, fmt.Errorf("key %q is bound to both %s and %s", key, existing, binding.Action))
+				continue
+			}
+			keyToAction[key] = binding.Action
+		}
+	}
+	// A key typed twice as a sequence, e.g. gg, can't be reached if the key is also bound on its own
+	for _, binding := range bindings {
+		for _, key := range []string{binding.KeyMap.Primary, binding.KeyMap.Secondary} {
+			if half := key[:len(key)/2]; len(key) > 1 && half+half == key {
+				if existing, exists := keyToAction[half]; exists {
+					errs = append(errs, fmt.Errorf("key %q is bound to %s, so %q of %s can't be typed", half,
+						existing, key, binding.Action))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,54 @@
+package keybindings
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// restoreBindings puts the default bindings back once the test is done
+func restoreBindings(t *testing.T) {
+	defaults := ContextBindings
+	t.Cleanup(func() { ContextBindings = defaults })
+}
+
+func TestApplyOverrides(t *testing.T) {
+	restoreBindings(t)
+
+	require.NoError(t, Apply(map[string]map[string]string{
+		"anime_list":        {"play_next_episode": "Q"},
+		"episode_selection": {"choose_source": "x, ctrl+k"},
+	}))
+
+	assert.Equal(t, ActionPlayNextEpisode, GetActionByKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Q")}, ContextAnimeList))
+	assert.Equal(t, "Q", GetActionKey(ActionPlayNextEpisode, ContextBindings[ContextAnimeList]))
+	assert.Empty(t, GetActionSecondaryKey(ActionPlayNextEpisode, ContextBindings[ContextAnimeList]),
+		"a single key should replace both keys")
+	assert.Equal(t, "ctrl+k", GetActionSecondaryKey(ActionChooseSource, ContextBindings[ContextEpisodeSelection]))
+
+	_, help := GetBindingByKey("Q", ContextBindings[ContextAnimeList])
+	assert.NotEmpty(t, help, "the help text should be kept for the help screen")
+}
+
+func TestApplyRejectsInvalidOverrides(t *testing.T) {
+	restoreBindings(t)
+	defaults := ContextBindings
+
+	tests := map[string]map[string]map[string]string{
+		"unknown context":  {"nowhere": {"play_next_episode": "o"}},
+		"unknown action":   {"anime_list": {"fly": "o"}},
+		"no key":           {"anime_list": {"play_next_episode": " "}},
+		"too many keys":    {"anime_list": {"play_next_episode": "a,b,c"}},
+		"duplicate key":    {"episode_selection": {"choose_source": "enter"}},
+		"navigation key":   {"menu": {"select_menu_item": "up"}},
+		"hides a sequence": {"anime_list": {"play_next_episode": "g"}},
+	}
+	for name, overrides := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, Apply(overrides))
+			assert.Equal(t, defaults, ContextBindings, "invalid overrides should leave the bindings unchanged")
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/service"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/util"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// agendaWindow is how far ahead the startup agenda looks for airing episodes
+const agendaWindow = 24 * time.Hour
+
+// agendaTickMsg is sent periodically so the agenda's relative times stay current
+type agendaTickMsg struct{}
+
+// AgendaModel shows the episodes from the user's list airing in the next 24 hours.  Episodes that air while the
+// agenda is open become playable from it, and anime or the whole agenda can be snoozed.
+type AgendaModel struct {
+	width, height  int
+	animeService   *service.AnimeService
+	entries        []*domain.Anime
+	cursor         int
+	airingLocation *time.Location
+	status         string
+}
+
+// NewAgendaModel creates a new agenda model for the given airing anime
+func NewAgendaModel(animeService *service.AnimeService, entries []*domain.Anime, airingLocation *time.Location) *AgendaModel {
+	return &AgendaModel{
+		animeService:   animeService,
+		entries:        entries,
+		airingLocation: airingLocation,
+	}
+}
+
+func (m *AgendaModel) ViewType() View {
+	return ViewAgenda
+}
+
+// Init starts the ticker that refreshes relative times
+func (m *AgendaModel) Init() tea.Cmd {
+	return agendaTick()
+}
+
+func agendaTick() tea.Cmd {
+	return tea.Tick(30*time.Second, func(time.Time) tea.Msg {
+		return agendaTickMsg{}
+	})
+}
+
+// Update handles messages
+func (m *AgendaModel) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case agendaTickMsg:
+		// Re-rendering is enough to update the times, just keep ticking
+		return m, agendaTick()
+
+	case SnoozeMsg:
+		m.snooze(msg)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch kb.GetActionByKey(msg, kb.ContextAgenda) {
+		case kb.ActionMoveUp:
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, Handled("cursor_move:up")
+		case kb.ActionMoveDown:
+			if m.cursor < len(m.entries)-1 {
+				m.cursor++
+			}
+			return m, Handled("cursor_move:down")
+		case kb.ActionPlayNextEpisode:
+			if m.cursor >= len(m.entries) {
+				return m, Handled("agenda_play:none_selected")
+			}
+			anime := m.entries[m.cursor]
+			if !anime.HasUnwatchedEpisodes() {
+				return m, Handled("agenda_play:not_aired")
+			}
+			return m, func() tea.Msg {
+				return AgendaPlayMsg{AnimeID: anime.ID}
+			}
+		case kb.ActionSnoozeAnime:
+			if m.cursor >= len(m.entries) {
+				return m, Handled("agenda_snooze:none_selected")
+			}
+			anime := m.entries[m.cursor]
+			return m, snoozeMenu(anime.ID, anime.Title.Preferred)
+		case kb.ActionSnoozeAgenda:
+			return m, snoozeMenu(0, "")
+		}
+	}
+
+	return m, nil
+}
+
+// snooze snoozes the anime picked from the snooze menu, dropping it from the agenda, or the whole agenda
+func (m *AgendaModel) snooze(msg SnoozeMsg) {
+	if msg.AnimeID == 0 {
+		until, err := m.animeService.SnoozeAgenda(msg.Period)
+		if err != nil {
+			log.Error("Failed to snooze the airing agenda", "error", err)
+			m.status = fmt.Sprintf("Failed to snooze the agenda: %v", err)
+			return
+		}
+		m.status = "The agenda won't be shown again until " + until.Local().Format(snoozeTimeFormat)
+		return
+	}
+
+	for i, anime := range m.entries {
+		if anime.ID != msg.AnimeID {
+			continue
+		}
+		until, err := m.animeService.SnoozeAnime(anime, msg.Period)
+		if err != nil {
+			log.Error("Failed to snooze anime", "anime_id", anime.ID, "error", err)
+			m.status = fmt.Sprintf("Failed to snooze %s: %v", anime.Title.Preferred, err)
+			return
+		}
+		m.status = fmt.Sprintf("Snoozed %s until %s", anime.Title.Preferred, until.Local().Format(snoozeTimeFormat))
+		m.entries = append(m.entries[:i], m.entries[i+1:]...)
+		m.cursor = min(m.cursor, max(0, len(m.entries)-1))
+		return
+	}
+}
+
+// View renders the agenda
+func (m *AgendaModel) View() string {
+	header := styles.Header(m.width, "Airing in the Next 24 Hours")
+
+	keyBindings := []components.KeyBinding{
+		{kb.DisplayKeys(kb.ContextAgenda, kb.ActionMoveUp, kb.ActionMoveDown), "Navigate"},
+		{kb.DisplayKeyWithSecondary(kb.ActionPlayNextEpisode, kb.ContextAgenda), "Play when available"},
+		{kb.DisplayKeys(kb.ContextAgenda, kb.ActionSnoozeAnime, kb.ActionSnoozeAgenda), "Snooze anime/agenda"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextAgenda), "Continue to list"},
+	}
+	footer := components.KeyBindingsBar(m.width, keyBindings)
+
+	if m.status != "" {
+		return fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s", header, styles.FilterStatus.Render(m.status), m.renderEntries(),
+			footer)
+	}
+	return fmt.Sprintf("%s\n\n%s\n\n%s", header, m.renderEntries(), footer)
+}
+
+// renderEntries renders each upcoming episode with its relative air time
+func (m *AgendaModel) renderEntries() string {
+	if len(m.entries) == 0 {
+		return styles.CenteredText(m.width, "Nothing from your list airs in the next 24 hours")
+	}
+
+	selectedStyle := styles.Selected.
+		Width(m.width-4).
+		Padding(0, 1)
+
+	normalStyle := lipgloss.NewStyle().
+		Width(m.width-4).
+		Padding(0, 1)
+
+	availableStyle := lipgloss.NewStyle().Foreground(styles.ColorSuccess)
+
+	titleWidth := 50
+	now := time.Now().Unix()
+	var content string
+	for i, anime := range m.entries {
+		title := util.TruncateString(anime.Title.Preferred, titleWidth)
+		title += strings.Repeat(" ", max(0, titleWidth-runewidth.StringWidth(title)))
+
+		airingAt := anime.NextAiringEp.AiringAt
+		when := "in " + strings.TrimSpace(util.FormatTimeUntilAiring(airingAt-now))
+		if airingAt <= now {
+			when = availableStyle.Render("available now")
+		}
+
+		itemText := fmt.Sprintf("%s  Ep %-4d %s  %s", title, anime.NextAiringEp.Episode,
+			util.FormatAiringTime(airingAt, m.airingLocation), when)
+
+		if i == m.cursor {
+			content += selectedStyle.Render(itemText) + "\n"
+		} else {
+			content += normalStyle.Render(itemText) + "\n"
+		}
+	}
+
+	return styles.ContentBox(m.width-2, content, 1)
+}
+
+// Resize updates the dimensions of the model
+func (m *AgendaModel) Resize(width, height int) {
+	m.width = width
+	m.height = height
+}
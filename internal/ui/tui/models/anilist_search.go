@@ -0,0 +1,226 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/service"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/util"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// AniListSearchModel searches all of AniList rather than just the user's list, so a show can be played before it
+// has been added to the list
+type AniListSearchModel struct {
+	width, height int
+	animeService  *service.AnimeService
+	input         textinput.Model
+	searched      string // The query the results are for
+	searching     bool
+	results       []*domain.Anime
+	err           error
+	cursor        int
+}
+
+// NewAniListSearchModel creates a new AniList search view
+func NewAniListSearchModel(animeService *service.AnimeService) *AniListSearchModel {
+	ti := textinput.New()
+	ti.Placeholder = fmt.Sprintf("Type a title and press %s to search AniList...",
+		kb.DisplayKey(kb.ActionSearchOrChoose, kb.ContextAniListSearch))
+	ti.Width = 50
+	ti.Focus()
+
+	return &AniListSearchModel{
+		animeService: animeService,
+		input:        ti,
+	}
+}
+
+func (m *AniListSearchModel) ViewType() View {
+	return ViewAniListSearch
+}
+
+// Init initializes the model
+func (m *AniListSearchModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles messages
+func (m *AniListSearchModel) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case AniListSearchResultsMsg:
+		if msg.Query != m.searched {
+			// The query has changed since, so these results are stale
+			return m, Handled("anilist_search:stale_results")
+		}
+		m.searching = false
+		m.results = msg.Results
+		m.err = msg.Error
+		m.cursor = 0
+		return m, Handled("anilist_search:results")
+
+	case tea.KeyMsg:
+		return m.handleKeyMsg(msg)
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// handleKeyMsg handles key presses.  Keys that aren't bound are typed into the query.
+func (m *AniListSearchModel) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch kb.GetActionByKey(msg, kb.ContextAniListSearch) {
+	case kb.ActionMoveUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, Handled("cursor_move:up")
+	case kb.ActionMoveDown:
+		if m.cursor < len(m.results)-1 {
+			m.cursor++
+		}
+		return m, Handled("cursor_move:down")
+	case kb.ActionSearchOrChoose:
+		if query := strings.TrimSpace(m.input.Value()); query != m.searched {
+			return m, m.search(query)
+		}
+		return m, m.play(false)
+	case kb.ActionAddAndPlay:
+		return m, m.play(true)
+	case kb.ActionBack:
+		// Let the app pop the search view
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if cmd == nil {
+		cmd = Handled("anilist_search:input")
+	}
+	return m, cmd
+}
+
+// search starts searching AniList for the query
+func (m *AniListSearchModel) search(query string) tea.Cmd {
+	m.searched = query
+	m.results = nil
+	m.err = nil
+	m.cursor = 0
+	if query == "" {
+		m.searching = false
+		return Handled("anilist_search:empty_query")
+	}
+
+	m.searching = true
+	log.Info("Searching AniList", "query", query)
+	animeService := m.animeService
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		results, err := animeService.SearchAniList(ctx, query)
+		return AniListSearchResultsMsg{Query: query, Results: results, Error: err}
+	}
+}
+
+// play asks the app to play the selected result
+func (m *AniListSearchModel) play(addToList bool) tea.Cmd {
+	if m.searching || m.cursor >= len(m.results) {
+		return Handled("anilist_search:none_selected")
+	}
+	anime := m.results[m.cursor]
+	return func() tea.Msg {
+		return AniListSearchPlayMsg{Anime: anime, AddToList: addToList}
+	}
+}
+
+// View renders the search view
+func (m *AniListSearchModel) View() string {
+	header := styles.Header(m.width, "Search AniList")
+	prompt := styles.Title.Render("Search: ") + m.input.View()
+
+	keyBindings := []components.KeyBinding{
+		{kb.DisplayKeys(kb.ContextAniListSearch, kb.ActionMoveUp, kb.ActionMoveDown), "Navigate"},
+		{kb.DisplayKey(kb.ActionSearchOrChoose, kb.ContextAniListSearch), "Search / Choose episode"},
+		{kb.DisplayKey(kb.ActionAddAndPlay, kb.ContextAniListSearch), "Add as watching & play"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextAniListSearch), "Return"},
+	}
+	footer := components.KeyBindingsBar(m.width, keyBindings)
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s", header, prompt, m.renderResults(), footer)
+}
+
+// renderResults renders the search results, marking anime already in the list
+func (m *AniListSearchModel) renderResults() string {
+	switch {
+	case m.searching:
+		return styles.CenteredText(m.width, fmt.Sprintf("Searching AniList for %q...", m.searched))
+	case m.err != nil:
+		return styles.CenteredText(m.width, fmt.Sprintf("Search failed: %v", m.err))
+	case m.searched == "":
+		return styles.CenteredText(m.width, fmt.Sprintf("Press %s to search",
+			kb.DisplayKey(kb.ActionSearchOrChoose, kb.ContextAniListSearch)))
+	case len(m.results) == 0:
+		return styles.CenteredText(m.width, fmt.Sprintf("Nothing on AniList matches %q", m.searched))
+	}
+
+	visibleCount := min(len(m.results), max(1, m.height-12))
+	startIdx := 0
+	if m.cursor >= visibleCount {
+		startIdx = m.cursor - visibleCount + 1
+	}
+	endIdx := min(startIdx+visibleCount, len(m.results))
+
+	selectedStyle := styles.Selected.
+		Width(m.width-4).
+		Padding(0, 1)
+
+	normalStyle := lipgloss.NewStyle().
+		Width(m.width-4).
+		Padding(0, 1)
+
+	titleWidth := 50
+	var listContent string
+	for i := startIdx; i < endIdx; i++ {
+		anime := m.results[i]
+		title := util.TruncateString(anime.Title.Preferred, titleWidth)
+		title += strings.Repeat(" ", max(0, titleWidth-runewidth.StringWidth(title)))
+
+		year := anime.SeasonYear
+		if year == "0" {
+			year = ""
+		}
+		episodes := "?"
+		if anime.Episodes > 0 {
+			episodes = fmt.Sprintf("%d", anime.Episodes)
+		}
+		itemText := fmt.Sprintf("%s  %-8s  %-4s  %3s eps", title, anime.Format, year, episodes)
+		if anime.UserData != nil {
+			itemText += fmt.Sprintf("  [In list: %s %d/%s]", anime.UserData.Status, anime.UserData.Progress, episodes)
+		}
+
+		if i == m.cursor {
+			listContent += selectedStyle.Render(itemText) + "\n"
+		} else {
+			listContent += normalStyle.Render(itemText) + "\n"
+		}
+	}
+
+	return styles.ContentBox(m.width-2, listContent, 1)
+}
+
+// Resize updates the dimensions of the model
+func (m *AniListSearchModel) Resize(width, height int) {
+	m.width = width
+	m.height = height
+}
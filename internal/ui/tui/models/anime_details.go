@@ -1,8 +1,12 @@
 package models
 
 import (
+	"context"
 	"fmt"
+	"github.com/PizzaHomicide/hisame/internal/config"
 	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/service"
 	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
 	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
 	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
@@ -11,22 +15,32 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"strings"
+	"time"
 )
 
 // AnimeDetailsModel displays detailed information about a single anime
 type AnimeDetailsModel struct {
-	width, height int
-	anime         *domain.Anime
-	viewport      viewport.Model // For scrolling content
+	width, height  int
+	anime          *domain.Anime
+	animeService   *service.AnimeService
+	airingLocation *time.Location // Timezone used when displaying absolute air times
+	viewport       viewport.Model // For scrolling content
+	navigation     kb.Navigation
+
+	schedule        []domain.AiringSchedule // Episodes still to air, once fetched
+	scheduleLoading bool
+	scheduleErr     error
 }
 
 // NewAnimeDetailsModel creates a new anime details model
-func NewAnimeDetailsModel(anime *domain.Anime) *AnimeDetailsModel {
+func NewAnimeDetailsModel(anime *domain.Anime, cfg *config.Config, animeService *service.AnimeService) *AnimeDetailsModel {
 	vp := viewport.New(80, 20) // Default size, will be updated in Resize()
 
 	return &AnimeDetailsModel{
-		anime:    anime,
-		viewport: vp,
+		anime:          anime,
+		animeService:   animeService,
+		airingLocation: util.ResolveLocation(cfg.UI.Timezone),
+		viewport:       vp,
 	}
 }
 
@@ -36,9 +50,32 @@ func (m *AnimeDetailsModel) ViewType() View {
 
 // Init initializes the model
 func (m *AnimeDetailsModel) Init() tea.Cmd {
+	cmd := m.loadSchedule()
 	content := m.generateContent()
 	m.viewport.SetContent(content)
-	return nil
+	return cmd
+}
+
+// loadSchedule fetches the rest of the broadcast calendar in the background, for anime that are still airing
+func (m *AnimeDetailsModel) loadSchedule() tea.Cmd {
+	if m.anime == nil || m.animeService == nil || !isStillAiring(m.anime) {
+		return nil
+	}
+	m.scheduleLoading = true
+
+	animeID := m.anime.ID
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		schedule, err := m.animeService.GetAiringSchedule(ctx, animeID)
+		return AiringScheduleMsg{AnimeID: animeID, Schedule: schedule, Error: err}
+	}
+}
+
+// isStillAiring reports whether an anime may have episodes still to broadcast
+func isStillAiring(anime *domain.Anime) bool {
+	return anime.NextAiringEp != nil || anime.Status == "RELEASING" || anime.Status == "NOT_YET_RELEASED"
 }
 
 // Update handles messages
@@ -46,16 +83,21 @@ func (m *AnimeDetailsModel) Update(msg tea.Msg) (Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case AiringScheduleMsg:
+		if m.anime == nil || msg.AnimeID != m.anime.ID {
+			return m, nil
+		}
+		m.scheduleLoading = false
+		m.schedule, m.scheduleErr = msg.Schedule, msg.Error
+		if msg.Error != nil {
+			log.Warn("Failed to load airing schedule", "anime_id", msg.AnimeID, "error", msg.Error)
+		}
+		m.viewport.SetContent(m.generateContent())
+		return m, Handled("details:schedule_loaded")
+
 	case tea.KeyMsg:
-		switch kb.GetActionByKey(msg, kb.ContextHelp) {
-		case kb.ActionMoveUp, kb.ActionMoveDown, kb.ActionPageUp, kb.ActionPageDown:
-			m.viewport, cmd = m.viewport.Update(msg)
-			return m, cmd
-		case kb.ActionMoveTop:
-			m.viewport.GotoTop()
-			return m, cmd
-		case kb.ActionMoveBottom:
-			m.viewport.GotoBottom()
+		action, count := m.navigation.Action(msg, kb.ContextHelp)
+		if scrollViewport(&m.viewport, action, count) || action == kb.ActionPending {
 			return m, cmd
 		}
 
@@ -78,10 +120,10 @@ func (m *AnimeDetailsModel) View() string {
 
 	// Define keybindings to be displayed in the footer
 	keyBindings := []components.KeyBinding{
-		{"↑/↓", "Scroll"},
-		{"PgUp/PgDn", "Page scroll"},
-		{"Ctrl+h", "Help"},
-		{"Esc", "Return"},
+		{kb.DisplayKeys(kb.ContextHelp, kb.ActionMoveUp, kb.ActionMoveDown), "Scroll"},
+		{kb.DisplayKeys(kb.ContextHelp, kb.ActionPageUp, kb.ActionPageDown), "Page scroll"},
+		{kb.DisplayKey(kb.ActionToggleHelp, kb.ContextHelp), "Help"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextHelp), "Return"},
 	}
 	footer := components.KeyBindingsBar(m.width, keyBindings)
 
@@ -137,7 +179,7 @@ func (m *AnimeDetailsModel) generateContent() string {
 	var b strings.Builder
 
 	// Styles for different parts of the content
-	sectionTitleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7D56F4"))
+	sectionTitleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorAccent)
 	fieldNameStyle := lipgloss.NewStyle().Bold(true)
 
 	// Basic information section
@@ -193,10 +235,20 @@ func (m *AnimeDetailsModel) generateContent() string {
 	// Next airing episode
 	if anime.NextAiringEp != nil {
 		b.WriteString(fieldNameStyle.Render("Next Episode: "))
-		b.WriteString(fmt.Sprintf("Episode %d airing in %s",
+		b.WriteString(fmt.Sprintf("Episode %d airing in %s (%s %s)",
 			anime.NextAiringEp.Episode,
-			strings.TrimSpace(util.FormatTimeUntilAiring(anime.NextAiringEp.TimeUntilAir))))
+			strings.TrimSpace(util.FormatTimeUntilAiring(anime.NextAiringEp.TimeUntilAir)),
+			util.FormatAiringTime(anime.NextAiringEp.AiringAt, m.airingLocation),
+			m.airingLocation.String()))
+		b.WriteString("\n\n")
+	}
+
+	// Remaining broadcast calendar
+	if schedule := m.renderSchedule(); schedule != "" {
+		b.WriteString(sectionTitleStyle.Render("Upcoming Episodes"))
 		b.WriteString("\n\n")
+		b.WriteString(schedule)
+		b.WriteString("\n")
 	}
 
 	// User's personal information section
@@ -224,6 +276,12 @@ func (m *AnimeDetailsModel) generateContent() string {
 		}
 		b.WriteString("\n")
 
+		if anime.UserData.Priority > 0 {
+			b.WriteString(fieldNameStyle.Render("Priority: "))
+			b.WriteString(fmt.Sprintf("%d", anime.UserData.Priority))
+			b.WriteString("\n")
+		}
+
 		if anime.UserData.StartDate != "" {
 			b.WriteString(fieldNameStyle.Render("Started: "))
 			b.WriteString(anime.UserData.StartDate)
@@ -246,6 +304,37 @@ func (m *AnimeDetailsModel) generateContent() string {
 		b.WriteString("\n")
 	}
 
+	// Genres and tags section
+	if len(anime.Genres) > 0 || len(anime.Tags) > 0 {
+		b.WriteString(sectionTitleStyle.Render("Genres & Tags"))
+		b.WriteString("\n\n")
+
+		if len(anime.Genres) > 0 {
+			b.WriteString(fieldNameStyle.Render("Genres: "))
+			b.WriteString(strings.Join(anime.Genres, ", "))
+			b.WriteString("\n")
+		}
+
+		var tags []string
+		spoilers := 0
+		for _, tag := range anime.Tags {
+			if tag.IsSpoiler {
+				spoilers++
+				continue
+			}
+			tags = append(tags, fmt.Sprintf("%s (%d%%)", tag.Name, tag.Rank))
+		}
+		if len(tags) > 0 {
+			b.WriteString(fieldNameStyle.Render("Tags: "))
+			b.WriteString(strings.Join(tags, ", "))
+			b.WriteString("\n")
+		}
+		if spoilers > 0 {
+			b.WriteString(fmt.Sprintf("%d spoiler tags hidden\n", spoilers))
+		}
+		b.WriteString("\n")
+	}
+
 	// Alternative titles section
 	if len(anime.Synonyms) > 0 {
 		b.WriteString(sectionTitleStyle.Render("Alternative Titles"))
@@ -261,3 +350,25 @@ func (m *AnimeDetailsModel) generateContent() string {
 
 	return b.String()
 }
+
+// renderSchedule lists the episodes still to air with their air times, or says why it can't
+func (m *AnimeDetailsModel) renderSchedule() string {
+	switch {
+	case m.scheduleLoading:
+		return "Loading broadcast schedule...\n"
+	case m.scheduleErr != nil:
+		return "Unable to load the broadcast schedule\n"
+	case len(m.schedule) == 0:
+		return ""
+	}
+
+	var b strings.Builder
+	for _, ep := range m.schedule {
+		b.WriteString(fmt.Sprintf("Episode %-4d %s  (in %s)\n",
+			ep.Episode,
+			util.FormatAiringDate(ep.AiringAt, m.airingLocation),
+			strings.TrimSpace(util.FormatTimeUntilAiring(ep.TimeUntilAir))))
+	}
+	b.WriteString(fmt.Sprintf("Times are in %s\n", m.airingLocation.String()))
+	return b.String()
+}
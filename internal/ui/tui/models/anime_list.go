@@ -11,10 +11,15 @@ import (
 
 	"github.com/PizzaHomicide/hisame/internal/config"
 	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/filler"
+	"github.com/PizzaHomicide/hisame/internal/log"
 	"github.com/PizzaHomicide/hisame/internal/player"
 	"github.com/PizzaHomicide/hisame/internal/service"
+	"github.com/PizzaHomicide/hisame/internal/simkl"
 	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
 	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/util"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -29,6 +34,14 @@ type AnimeFilterSet struct {
 	hasAvailableEpisodes bool                 // Filter to only anime with aired but unwatched episodes
 	isFinishedAiring     bool                 // Filter to anime that have fully completed airing
 	searchQuery          string               // Fuzzy search query to match titles against
+	scoreConditions      []scoreCondition     // Comparisons the user's score must satisfy
+	year                 int                  // Season year to match, 0 means any year
+	formatFilters        []string             // Media formats to show.  Empty slice means any format
+	genreGroups          [][]string           // Each group must match, an anime matches a group if it has any genre in it
+	tagGroups            [][]string           // As genreGroups, but matched against tags
+	quickFilter          string               // The quick filter expression these filters were built from, if any
+	hideAdult            bool                 // Filter out adult anime
+	showStatusListHidden bool                 // Include entries hidden from status lists on AniList
 }
 
 // AnimeListModel handles displaying and interacting with the anime list
@@ -36,6 +49,8 @@ type AnimeListModel struct {
 	config               *config.Config
 	animeService         *service.AnimeService
 	playerService        *player.PlayerService
+	simkl                *simkl.Client  // Scrobbles watched episodes to Simkl.  Nil unless a Simkl token is configured
+	filler               *filler.Client // Looks up filler episodes, unless turned off with player.filler
 	width, height        int
 	loading              bool
 	loadingMsg           string
@@ -43,32 +58,64 @@ type AnimeListModel struct {
 	spinner              spinner.Model
 	filters              AnimeFilterSet
 	cursor               int
+	navigation           kb.Navigation
 	allAnime             []*domain.Anime // All anime from the service
 	filteredAnime        []*domain.Anime // Anime after applying filters
 	searchInput          textinput.Model
 	searchMode           bool // Whether we're in search input mode
+	quickFilterInput     textinput.Model
+	quickFilterMode      bool   // Whether the quick filter bar is open
+	quickFilterErr       string // Parse error for the current quick filter expression
 	playbackCompletionCh chan PlaybackCompletedMsg
+	airingLocation       *time.Location // Timezone used when displaying absolute air times
+	refreshNotice        string         // Summary of what changed on the last refresh, shown above the list
+	reattachChecked      bool           // Whether a player left running from before a restart has been looked for
+	groupByAiringDay     bool           // Whether the list is grouped by the weekday each show airs on
+	sortMode             sortMode       // What the list is ordered by, unless it is grouped by airing day
+	columns              []string       // Names of the columns shown in the list, in order
+	refreshing           bool           // Whether the cached list is being refreshed in the background
+	refreshErr           error          // Why the last background refresh failed, if it did
+	errorToast           string         // Error shown above the list for a few seconds, e.g. a rolled back update
+	toastSeq             int            // Incremented for each toast, so only the latest one's timer clears it
+	autoRefreshSeq       int            // Incremented each time auto refresh is scheduled, so stale timers are ignored
+
+	providerHealth []player.ProviderHealth // Results of the last episode provider check
+	healthCheckSeq int                     // As autoRefreshSeq, for the provider health check timer
 }
 
-// NewAnimeListModel creates a new anime list model
-func NewAnimeListModel(cfg *config.Config, animeService *service.AnimeService) *AnimeListModel {
+// toastDuration is how long error toasts stay above the list
+const toastDuration = 5 * time.Second
+
+// NewAnimeListModel creates a new anime list model.  Adult anime are hidden, and left out of episode searches, unless
+// the user has chosen to see adult content on AniList.
+func NewAnimeListModel(cfg *config.Config, animeService *service.AnimeService, user domain.User) *AnimeListModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4"))
+	s.Style = lipgloss.NewStyle().Foreground(styles.ColorAccent)
 
 	// Default filters - initially show only CURRENT anime
 	defaultFilters := AnimeFilterSet{
 		statusFilters: DEFAULT_STATUS_FILTERS,
+		hideAdult:     !user.DisplayAdultContent,
 	}
 
 	ti := textinput.New()
 	ti.Placeholder = "Search anime..."
 	ti.Width = 30
 
+	qf := textinput.New()
+	qf.Placeholder = "s:watching score>8 year:2024 title..."
+	qf.Width = 50
+
+	playerService := player.NewPlayerService(cfg)
+	playerService.SetAllowAdult(user.DisplayAdultContent)
+
 	return &AnimeListModel{
 		config:               cfg,
 		animeService:         animeService,
-		playerService:        player.NewPlayerService(cfg),
+		playerService:        playerService,
+		simkl:                simkl.NewClient(cfg.Simkl, cfg.Network.Retry),
+		filler:               filler.NewClient(cfg.Network.Retry),
 		loading:              false,
 		spinner:              s,
 		filters:              defaultFilters,
@@ -77,7 +124,10 @@ func NewAnimeListModel(cfg *config.Config, animeService *service.AnimeService) *
 		filteredAnime:        []*domain.Anime{},
 		searchInput:          ti,
 		searchMode:           false,
+		quickFilterInput:     qf,
 		playbackCompletionCh: make(chan PlaybackCompletedMsg),
+		airingLocation:       util.ResolveLocation(cfg.UI.Timezone),
+		columns:              listColumnNames(cfg.UI.Columns),
 	}
 }
 
@@ -91,17 +141,86 @@ func (m *AnimeListModel) Resize(width, height int) {
 	m.height = height
 }
 
-// Init initializes the model
+// Init initializes the model.  If a cached list is available it is shown straight away and refreshed in the
+// background, otherwise the list is fetched behind a loading screen.
 func (m *AnimeListModel) Init() tea.Cmd {
-	return func() tea.Msg {
-		return LoadingMsg{
-			Type:        LoadingStart,
-			Message:     "Loading anime list...",
-			Title:       "Starting Hisame",
-			ContextInfo: "Fetching your anime data from AniList",
-			Operation:   m.fetchAnimeListCmd(),
-		}
+	if m.animeService.LoadCachedAnimeList() {
+		m.refreshing = true
+		cachedList := m.animeService.GetAnimeList()
+		return tea.Batch(
+			tea.Sequence(
+				func() tea.Msg {
+					return AnimeListLoadResultMsg{Success: true, AnimeList: cachedList, Background: true}
+				},
+				m.fetchAnimeListInBackgroundCmd(),
+			),
+			m.scheduleAutoRefresh(),
+			m.checkProviderHealthCmd(),
+			m.scheduleHealthCheck(),
+		)
+	}
+
+	return tea.Batch(
+		func() tea.Msg {
+			return LoadingMsg{
+				Type:        LoadingStart,
+				Message:     "Loading anime list...",
+				Title:       "Starting Hisame",
+				ContextInfo: "Fetching your anime data from AniList",
+				Operation:   m.fetchAnimeListCmd(),
+			}
+		},
+		m.scheduleAutoRefresh(),
+		m.checkProviderHealthCmd(),
+		m.scheduleHealthCheck(),
+	)
+}
+
+// parseInterval parses an interval setting such as network.auto_refresh_interval, returning 0 if the setting is
+// disabled or can't be parsed
+func parseInterval(setting, value string) time.Duration {
+	if value == "" || value == "off" {
+		return 0
+	}
+
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		log.Warn("Invalid interval, the feature is disabled", "setting", setting, "interval", value, "error", err)
+		return 0
 	}
+	return max(interval, 0)
+}
+
+// scheduleAutoRefresh starts the timer for the next background refresh, replacing any timer already running
+func (m *AnimeListModel) scheduleAutoRefresh() tea.Cmd {
+	interval := parseInterval("network.auto_refresh_interval", m.config.Network.AutoRefreshInterval)
+	if interval <= 0 {
+		return nil
+	}
+
+	m.autoRefreshSeq++
+	seq := m.autoRefreshSeq
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return AutoRefreshMsg{Seq: seq}
+	})
+}
+
+// HandleAutoRefresh refreshes the list in the background when its timer fires.  The refresh goes through the same
+// path as pressing r, so airing countdowns, new episodes and the refresh summary are all updated, but without a
+// loading screen.
+func (m *AnimeListModel) HandleAutoRefresh(msg AutoRefreshMsg) (Model, tea.Cmd) {
+	if msg.Seq != m.autoRefreshSeq {
+		return m, nil
+	}
+
+	if m.refreshing {
+		log.Debug("Skipping auto refresh, a refresh is already in progress")
+		return m, m.scheduleAutoRefresh()
+	}
+
+	log.Debug("Auto refreshing anime list")
+	m.refreshing = true
+	return m, tea.Batch(m.fetchAnimeListInBackgroundCmd(), m.scheduleAutoRefresh())
 }
 
 // The fetchAnimeListCmd creates a command to run in the background
@@ -125,17 +244,99 @@ func (m *AnimeListModel) fetchAnimeListCmd() tea.Cmd {
 	}
 }
 
+// fetchAnimeListInBackgroundCmd fetches the list without a loading screen, while the cached list is shown
+func (m *AnimeListModel) fetchAnimeListInBackgroundCmd() tea.Cmd {
+	fetch := m.fetchAnimeListCmd()
+	return func() tea.Msg {
+		msg := fetch().(AnimeListLoadResultMsg)
+		msg.Background = true
+		return msg
+	}
+}
+
 func (m *AnimeListModel) HandleAnimeListLoaded(animeList []*domain.Anime) (Model, tea.Cmd) {
+	if m.animeService.CachedAt().IsZero() {
+		m.refreshing = false
+		m.refreshErr = nil
+	}
 	m.allAnime = animeList
+	m.refreshNotice = m.animeService.LastRefreshSummary().String()
 	m.applyFilters()
-	return m, nil
+
+	var cmds []tea.Cmd
+	// Once the list is there to update, pick back up any episode still playing from before Hisame was restarted
+	if !m.reattachChecked {
+		m.reattachChecked = true
+		cmds = append(cmds, m.reattachPlayback())
+	}
+	if m.animeService.CachedAt().IsZero() {
+		cmds = append(cmds, m.syncOfflineChangesCmd())
+	}
+	return m, tea.Batch(cmds...)
 }
 
 func (m *AnimeListModel) HandleAnimeListError(err error) (Model, tea.Cmd) {
 	// TODO:  UX for error here?
+	if m.refreshing {
+		// The previous list is still shown, so just flag that it couldn't be refreshed
+		log.Warn("Background refresh of the anime list failed", "error", err)
+		m.refreshing = false
+		m.refreshErr = err
+	}
 	return m, nil
 }
 
+// showErrorToast shows an error above the list, clearing it after toastDuration
+func (m *AnimeListModel) showErrorToast(text string) tea.Cmd {
+	m.toastSeq++
+	m.errorToast = text
+	seq := m.toastSeq
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return ToastExpiredMsg{Seq: seq}
+	})
+}
+
+// headerTitle returns the title for the header, flagging when the list shown is from the cache and how the episode
+// providers are doing
+func (m *AnimeListModel) headerTitle() string {
+	title := "Hisame - Anime List"
+
+	if cachedAt := m.animeService.CachedAt(); !cachedAt.IsZero() {
+		title += fmt.Sprintf(" (cached %s ago", formatAge(time.Since(cachedAt)))
+		switch {
+		case m.refreshing:
+			title += ", refreshing...)"
+		case m.refreshErr != nil:
+			title += fmt.Sprintf(", refresh failed - press %s to retry)",
+				kb.DisplayKey(kb.ActionRefreshAnimeList, kb.ContextAnimeList))
+		default:
+			title += ")"
+		}
+	}
+
+	if health := m.providerHealthSummary(); health != "" {
+		title += " | " + health
+	}
+	if m.sortMode != sortDefault && !m.groupByAiringDay {
+		title += " | Sorted by " + m.sortMode.String()
+	}
+	return title
+}
+
+// formatAge formats a duration, such as how long ago something happened, in its largest whole unit
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "<1m"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
 // View renders the anime list model
 func (m *AnimeListModel) View() string {
 	if m.loading {
@@ -147,7 +348,8 @@ func (m *AnimeListModel) View() string {
 	}
 
 	if m.loadError != nil {
-		errorMsg := fmt.Sprintf("Error loading anime list: %v\n\nPress 'r' to retry.", m.loadError)
+		errorMsg := fmt.Sprintf("Error loading anime list: %v\n\nPress %s to retry.", m.loadError,
+			kb.DisplayKey(kb.ActionRefreshAnimeList, kb.ContextAnimeList))
 		return styles.CenteredView(
 			m.width,
 			m.height,
@@ -157,15 +359,15 @@ func (m *AnimeListModel) View() string {
 
 	// Define keybindings to be displayed in footer
 	keyBindings := []components.KeyBinding{
-		{"↑/↓", "Navigate"},
-		{"Enter", "Anime context menu"},
-		{"+/-", "Adjust progress"},
-		{"Ctrl+h", "Help"},
-		{"Ctrl+c", "Quit"},
+		{kb.DisplayKeys(kb.ContextAnimeList, kb.ActionMoveUp, kb.ActionMoveDown), "Navigate"},
+		{kb.DisplayKey(kb.ActionShowMenu, kb.ContextAnimeList), "Anime context menu"},
+		{kb.DisplayKeys(kb.ContextAnimeList, kb.ActionIncrementProgress, kb.ActionDecrementProgress), "Adjust progress"},
+		{kb.DisplayKey(kb.ActionToggleHelp, kb.ContextAnimeList), "Help"},
+		{kb.DisplayKey(kb.ActionQuit, kb.ContextAnimeList), "Quit"},
 	}
 
 	// Build the view
-	header := styles.Header(m.width, "Hisame - Anime List")
+	header := styles.Header(m.width, m.headerTitle())
 	filterStatus := m.renderFilterStatus()
 	content := m.renderAnimeList()
 	keyBar := components.KeyBindingsBar(m.width, keyBindings)
@@ -176,6 +378,24 @@ func (m *AnimeListModel) View() string {
 		content = lipgloss.JoinVertical(lipgloss.Left, searchPrompt, content)
 	}
 
+	if m.quickFilterMode {
+		quickFilterPrompt := styles.Title.Render("Filter: ") + m.quickFilterInput.View()
+		if m.quickFilterErr != "" {
+			quickFilterPrompt += "  " + lipgloss.NewStyle().Foreground(styles.ColorError).Render(m.quickFilterErr)
+		}
+		content = lipgloss.JoinVertical(lipgloss.Left, quickFilterPrompt, content)
+	}
+
+	if m.refreshNotice != "" {
+		notice := util.TruncateString(m.refreshNotice, max(10, m.width-4))
+		filterStatus = lipgloss.JoinVertical(lipgloss.Left, filterStatus, styles.FilterStatus.Render(notice))
+	}
+
+	if m.errorToast != "" {
+		toast := util.TruncateString(m.errorToast, max(10, m.width-4))
+		filterStatus = lipgloss.JoinVertical(lipgloss.Left, filterStatus, styles.Error.Render(toast))
+	}
+
 	// Layout the components
 	return fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s",
 		header,
@@ -0,0 +1,113 @@
+package models
+
+// anime_list_airing_day.go groups the anime list by the weekday each show's next episode airs, starting with today,
+// which is how simulcast watchers plan their week.
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+)
+
+// notAiringGroup is the group offset for anime with no upcoming episode, which are listed after the week
+const notAiringGroup = 7
+
+// airingDayOffset returns how many days after today's weekday the anime's next episode airs on (0 for today's
+// weekday, up to 6), or notAiringGroup if no upcoming episode is known
+func airingDayOffset(anime *domain.Anime, now time.Time, loc *time.Location) int {
+	if anime.NextAiringEp == nil || anime.NextAiringEp.AiringAt <= 0 {
+		return notAiringGroup
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+
+	weekday := time.Unix(anime.NextAiringEp.AiringAt, 0).In(loc).Weekday()
+	today := now.In(loc).Weekday()
+	return (int(weekday) - int(today) + 7) % 7
+}
+
+// sortByAiringDay orders the list into weekday groups starting with today, ordered by air time within each group.
+// Anime with no upcoming episode keep their order at the end.
+func sortByAiringDay(list []*domain.Anime, now time.Time, loc *time.Location) {
+	sort.SliceStable(list, func(i, j int) bool {
+		offsetI, offsetJ := airingDayOffset(list[i], now, loc), airingDayOffset(list[j], now, loc)
+		if offsetI != offsetJ {
+			return offsetI < offsetJ
+		}
+		if offsetI == notAiringGroup {
+			return false
+		}
+		return list[i].NextAiringEp.AiringAt < list[j].NextAiringEp.AiringAt
+	})
+}
+
+// airingDayGroupLabel returns the header shown above a weekday group
+func airingDayGroupLabel(offset int, now time.Time, loc *time.Location, count int) string {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	var label string
+	switch offset {
+	case 0:
+		label = "Today (" + now.In(loc).Weekday().String() + ")"
+	case 1:
+		label = "Tomorrow (" + now.In(loc).AddDate(0, 0, 1).Weekday().String() + ")"
+	case notAiringGroup:
+		label = "Not currently airing"
+	default:
+		label = now.In(loc).AddDate(0, 0, offset).Weekday().String()
+	}
+	return fmt.Sprintf("%s · %d", label, count)
+}
+
+// listRow is a line in the rendered anime list: either an anime, by its index in the filtered list, or a group header
+type listRow struct {
+	index  int // Index into filteredAnime, or -1 for a header
+	header string
+}
+
+// animeListRows returns the rows to render for the filtered list, with a header before each weekday group when the
+// list is grouped by airing day
+func (m *AnimeListModel) animeListRows(now time.Time) []listRow {
+	rows := make([]listRow, 0, len(m.filteredAnime))
+	if !m.groupByAiringDay {
+		for i := range m.filteredAnime {
+			rows = append(rows, listRow{index: i})
+		}
+		return rows
+	}
+
+	counts := make(map[int]int)
+	for _, anime := range m.filteredAnime {
+		counts[airingDayOffset(anime, now, m.airingLocation)]++
+	}
+
+	lastOffset := -1
+	for i, anime := range m.filteredAnime {
+		if offset := airingDayOffset(anime, now, m.airingLocation); offset != lastOffset {
+			rows = append(rows, listRow{index: -1, header: airingDayGroupLabel(offset, now, m.airingLocation, counts[offset])})
+			lastOffset = offset
+		}
+		rows = append(rows, listRow{index: i})
+	}
+	return rows
+}
+
+// animeRange returns the indexes of the first and last anime shown in rows, skipping any group headers at either end
+func animeRange(rows []listRow) (first, last int) {
+	first, last = -1, -1
+	for _, row := range rows {
+		if row.index < 0 {
+			continue
+		}
+		if first < 0 {
+			first = row.index
+		}
+		last = row.index
+	}
+	return first, last
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func airingAnime(id int, airingAt time.Time) *domain.Anime {
+	return &domain.Anime{ID: id, NextAiringEp: &domain.AiringSchedule{AiringAt: airingAt.Unix()}}
+}
+
+func TestSortByAiringDay(t *testing.T) {
+	// A Wednesday
+	now := time.Date(2024, 5, 8, 12, 0, 0, 0, time.UTC)
+
+	list := []*domain.Anime{
+		{ID: 1}, // Not airing
+		airingAnime(2, time.Date(2024, 5, 13, 9, 0, 0, 0, time.UTC)),  // Monday
+		airingAnime(3, time.Date(2024, 5, 8, 22, 0, 0, 0, time.UTC)),  // Today, later
+		airingAnime(4, time.Date(2024, 5, 15, 8, 0, 0, 0, time.UTC)),  // Next Wednesday, already aired today
+		airingAnime(5, time.Date(2024, 5, 9, 18, 0, 0, 0, time.UTC)),  // Tomorrow
+		airingAnime(6, time.Date(2024, 5, 13, 1, 0, 0, 0, time.UTC)),  // Monday, earlier
+		airingAnime(7, time.Date(2024, 5, 14, 23, 0, 0, 0, time.UTC)), // Tuesday, the end of the week
+	}
+	sortByAiringDay(list, now, time.UTC)
+
+	var ids []int
+	for _, anime := range list {
+		ids = append(ids, anime.ID)
+	}
+	assert.Equal(t, []int{3, 4, 5, 6, 2, 7, 1}, ids)
+}
+
+func TestAiringDayOffsetUsesTimezone(t *testing.T) {
+	now := time.Date(2024, 5, 8, 12, 0, 0, 0, time.UTC)
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	// Thursday 20:00 UTC is Friday morning in Tokyo
+	anime := airingAnime(1, time.Date(2024, 5, 9, 20, 0, 0, 0, time.UTC))
+	assert.Equal(t, 1, airingDayOffset(anime, now, time.UTC))
+	assert.Equal(t, 2, airingDayOffset(anime, now, tokyo))
+}
+
+func TestAnimeRangeSkipsHeaders(t *testing.T) {
+	rows := []listRow{{index: -1, header: "Today · 2"}, {index: 0}, {index: 1}, {index: -1, header: "Tomorrow · 1"}}
+	first, last := animeRange(rows)
+	assert.Equal(t, 0, first)
+	assert.Equal(t, 1, last, "a header as the last row shouldn't be counted as an anime")
+}
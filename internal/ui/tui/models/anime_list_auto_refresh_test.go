@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInterval(t *testing.T) {
+	tests := map[string]time.Duration{
+		"15m":     15 * time.Minute,
+		"1h30m":   90 * time.Minute,
+		"off":     0,
+		"":        0,
+		"0":       0,
+		"-5m":     0,
+		"invalid": 0,
+	}
+	for value, want := range tests {
+		assert.Equal(t, want, parseInterval("network.auto_refresh_interval", value), value)
+	}
+}
+
+func TestHandleAutoRefreshIgnoresStaleTimers(t *testing.T) {
+	m := &AnimeListModel{config: &config.Config{Network: config.NetworkConfig{AutoRefreshInterval: "15m"}}}
+	assert.NotNil(t, m.scheduleAutoRefresh())
+	assert.NotNil(t, m.scheduleAutoRefresh())
+
+	_, cmd := m.HandleAutoRefresh(AutoRefreshMsg{Seq: 1})
+	assert.Nil(t, cmd, "a replaced timer should not refresh or reschedule")
+
+	// A refresh already in progress is left alone, but the timer keeps running
+	m.refreshing = true
+	_, cmd = m.HandleAutoRefresh(AutoRefreshMsg{Seq: 2})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, 3, m.autoRefreshSeq)
+}
@@ -0,0 +1,201 @@
+package models
+
+// anime_list_columns.go lays out the columns of the anime list.  Which columns are shown, and in what order, come from
+// ui.columns in the config, and the title is given whatever width the other columns leave.
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/util"
+	"github.com/mattn/go-runewidth"
+)
+
+// minTitleWidth is the narrowest the title gets before columns are dropped from the end of the row to make room
+const minTitleWidth = 20
+
+// listColumn is a column that can be shown in the anime list
+type listColumn struct {
+	header string
+	width  int // 0 for the title, which fills the rest of the row
+	value  func(m *AnimeListModel, anime *domain.Anime) string
+}
+
+// listColumns are the columns that can be shown, by their name in the config
+var listColumns = map[string]listColumn{
+	"title":    {header: "Title", value: (*AnimeListModel).titleCell},
+	"progress": {header: "Progress", width: 8, value: (*AnimeListModel).progressCell},
+	"format":   {header: "Format", width: 8, value: (*AnimeListModel).formatCell},
+	"score":    {header: "Score", width: 5, value: (*AnimeListModel).averageScoreCell},
+	"my_score": {header: "Mine", width: 5, value: (*AnimeListModel).userScoreCell},
+	"status":   {header: "Status", width: 9, value: (*AnimeListModel).statusCell},
+	"next":     {header: "Next #", width: 6, value: (*AnimeListModel).nextEpisodeCell},
+	"airing":   {header: "Airing In", width: 12, value: (*AnimeListModel).airingCell},
+}
+
+// listColumnNames returns the columns named in the config that exist, in order, with the title first if it isn't
+// placed anywhere else
+func listColumnNames(configured []string) []string {
+	var names []string
+	for _, name := range configured {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := listColumns[name]; !ok {
+			log.Warn("Ignoring unknown anime list column", "column", name)
+			continue
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	if !slices.Contains(names, "title") {
+		names = append([]string{"title"}, names...)
+	}
+	return names
+}
+
+// columnLayout returns the columns that fit in a row of the width, and how wide the title is.  The row starts with the
+// marker for episodes being available, and columns are separated by a space.
+func columnLayout(names []string, width int) ([]string, int) {
+	columns := slices.Clone(names)
+	for {
+		used := 2 + len(columns) - 1
+		for _, name := range columns {
+			used += listColumns[name].width
+		}
+		titleWidth := width - used
+		if titleWidth >= minTitleWidth || len(columns) == 1 {
+			return columns, max(titleWidth, 1)
+		}
+
+		// Drop the last column other than the title
+		for i := len(columns) - 1; i >= 0; i-- {
+			if columns[i] != "title" {
+				columns = slices.Delete(columns, i, i+1)
+				break
+			}
+		}
+	}
+}
+
+// formatListRow lays out the marker and cells of a row, truncating and padding each cell to its column's width.  The
+// title is aligned left and other columns right.
+func formatListRow(marker string, columns []string, titleWidth int, cell func(name string) string) string {
+	parts := []string{marker}
+	for _, name := range columns {
+		width, text := listColumns[name].width, cell(name)
+		if name == "title" {
+			width = titleWidth
+		}
+		if runewidth.StringWidth(text) > width {
+			text = runewidth.Truncate(util.TruncateString(text, width), width, "")
+		}
+		padding := strings.Repeat(" ", width-runewidth.StringWidth(text))
+		if name == "title" {
+			parts = append(parts, text+padding)
+		} else {
+			parts = append(parts, padding+text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// listHeader returns the column headers of the list for the row width
+func (m *AnimeListModel) listHeader(width int) string {
+	columns, titleWidth := columnLayout(m.columns, width)
+	return formatListRow(" ", columns, titleWidth, func(name string) string {
+		if name == "airing" && m.showAbsoluteAiringTimes() {
+			return "Airs At"
+		}
+		return listColumns[name].header
+	})
+}
+
+// formatAnimeListItem formats a single anime list item for the row width
+func (m *AnimeListModel) formatAnimeListItem(anime *domain.Anime, width int) string {
+	available := " "
+	if anime.HasUnwatchedEpisodes() {
+		available = "+"
+	}
+	columns, titleWidth := columnLayout(m.columns, width)
+	return formatListRow(available, columns, titleWidth, func(name string) string {
+		return listColumns[name].value(m, anime)
+	})
+}
+
+func (m *AnimeListModel) titleCell(anime *domain.Anime) string {
+	title := anime.Title.Preferred
+	if anime.UserData != nil && anime.UserData.HiddenFromStatusLists {
+		// Only shown when asked for, but flag these as AniList only lists them in custom lists
+		title = "[custom] " + title
+	}
+	if m.sortMode == sortPriority && anime.UserData != nil && anime.UserData.Priority > 0 {
+		title = fmt.Sprintf("(%d) %s", anime.UserData.Priority, title)
+	}
+	return title
+}
+
+func (m *AnimeListModel) progressCell(anime *domain.Anime) string {
+	if anime.UserData == nil {
+		return ""
+	}
+	progress := fmt.Sprintf("%d/?", anime.UserData.Progress)
+	if anime.Episodes > 0 {
+		progress = fmt.Sprintf("%d/%d", anime.UserData.Progress, anime.Episodes)
+	}
+	// Flag progress that is still being saved to AniList
+	if m.animeService.IsUpdatePending(anime.ID) {
+		progress = "*" + progress
+	}
+	return progress
+}
+
+func (m *AnimeListModel) formatCell(anime *domain.Anime) string {
+	if anime.Format == "" {
+		return "?"
+	}
+	return anime.Format
+}
+
+// averageScoreCell is the mean score of the anime on AniList
+func (m *AnimeListModel) averageScoreCell(anime *domain.Anime) string {
+	if anime.AverageScore <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f", anime.AverageScore)
+}
+
+func (m *AnimeListModel) userScoreCell(anime *domain.Anime) string {
+	if anime.UserData == nil || anime.UserData.Score == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%g", anime.UserData.Score)
+}
+
+func (m *AnimeListModel) statusCell(anime *domain.Anime) string {
+	if anime.UserData == nil {
+		return "Unknown"
+	}
+	return statusLabel(anime.UserData.Status)
+}
+
+func (m *AnimeListModel) nextEpisodeCell(anime *domain.Anime) string {
+	if anime.NextAiringEp == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d", anime.NextAiringEp.Episode)
+}
+
+func (m *AnimeListModel) airingCell(anime *domain.Anime) string {
+	switch {
+	case anime.NextAiringEp != nil && m.showAbsoluteAiringTimes():
+		return util.FormatAiringTime(anime.NextAiringEp.AiringAt, m.airingLocation)
+	case anime.NextAiringEp != nil:
+		return util.FormatTimeUntilAiring(anime.NextAiringEp.TimeUntilAir)
+	case anime.Status == "FINISHED":
+		return "Finished"
+	}
+	return ""
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListColumnNames(t *testing.T) {
+	assert.Equal(t, []string{"title", "score", "progress"},
+		listColumnNames([]string{"score", " Progress", "bogus", "score"}),
+		"unknown and repeated columns should be dropped, and the title added first")
+	assert.Equal(t, []string{"status", "title"}, listColumnNames([]string{"status", "title"}))
+}
+
+func TestColumnLayout(t *testing.T) {
+	names := []string{"title", "progress", "format", "airing"}
+
+	columns, titleWidth := columnLayout(names, 100)
+	assert.Equal(t, names, columns)
+	assert.Equal(t, 100-2-3-8-8-12, titleWidth, "the title should take the width the other columns leave")
+
+	columns, titleWidth = columnLayout(names, 45)
+	assert.Equal(t, []string{"title", "progress", "format"}, columns, "columns should be dropped from the end to fit")
+	assert.Equal(t, 45-2-2-8-8, titleWidth)
+
+	columns, _ = columnLayout(names, 10)
+	assert.Equal(t, []string{"title"}, columns)
+}
+
+func TestFormatListRow(t *testing.T) {
+	cells := map[string]string{"title": "Sousou no Frieren", "progress": "12/28", "status": "Watching"}
+	row := formatListRow("+", []string{"title", "progress", "status"}, 10, func(name string) string {
+		return cells[name]
+	})
+	assert.Equal(t, "+ Sousou ...    12/28  Watching", row)
+	assert.Equal(t, 2+10+1+8+1+9, runewidth.StringWidth(row))
+}
@@ -6,7 +6,9 @@ package models
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
 
@@ -17,8 +19,22 @@ import (
 	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
 )
 
+// formatFilterActions maps the format toggle actions to the format they filter on
+var formatFilterActions = map[kb.Action]string{
+	kb.ActionToggleFilterFormatTV:      domain.FormatTV,
+	kb.ActionToggleFilterFormatMovie:   domain.FormatMovie,
+	kb.ActionToggleFilterFormatOVA:     domain.FormatOVA,
+	kb.ActionToggleFilterFormatONA:     domain.FormatONA,
+	kb.ActionToggleFilterFormatSpecial: domain.FormatSpecial,
+}
+
 // toggleFilter toggles a filter based on the action
 func (m *AnimeListModel) toggleFilter(action kb.Action) {
+	if format, ok := formatFilterActions[action]; ok {
+		m.toggleFormatFilter(format)
+		return
+	}
+
 	var status domain.MediaStatus
 
 	switch action {
@@ -40,6 +56,12 @@ func (m *AnimeListModel) toggleFilter(action kb.Action) {
 	case kb.ActionToggleFilterNewEpisodes:
 		m.filters.hasAvailableEpisodes = !m.filters.hasAvailableEpisodes
 		return
+	case kb.ActionToggleFilterAdult:
+		m.filters.hideAdult = !m.filters.hideAdult
+		return
+	case kb.ActionToggleFilterStatusHidden:
+		m.filters.showStatusListHidden = !m.filters.showStatusListHidden
+		return
 	default:
 		return
 	}
@@ -67,6 +89,26 @@ func (m *AnimeListModel) toggleFilter(action kb.Action) {
 	}
 }
 
+// toggleFormatFilter adds the format to the format filters, or removes it if it is already there
+func (m *AnimeListModel) toggleFormatFilter(format string) {
+	if index := slices.Index(m.filters.formatFilters, format); index >= 0 {
+		m.filters.formatFilters = slices.Delete(m.filters.formatFilters, index, index+1)
+	} else {
+		m.filters.formatFilters = append(m.filters.formatFilters, format)
+	}
+}
+
+// matchesFormatFilters reports whether the format is allowed by the filters.  Short TV series count as TV.
+func matchesFormatFilters(filters []string, format string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	if format == domain.FormatTVShort {
+		format = domain.FormatTV
+	}
+	return slices.Contains(filters, format)
+}
+
 // applyFilters applies the current filters to the anime list
 func (m *AnimeListModel) applyFilters() {
 	// Start with all anime that match status filters
@@ -74,7 +116,7 @@ func (m *AnimeListModel) applyFilters() {
 
 	// Apply status filters
 	for _, anime := range m.allAnime {
-		if anime.UserData == nil {
+		if anime.UserData == nil || m.animeService.IsHidden(anime.ID) {
 			continue
 		}
 
@@ -109,6 +151,42 @@ func (m *AnimeListModel) applyFilters() {
 			}
 		}
 
+		// Hide adult anime if enabled
+		if m.filters.hideAdult && anime.IsAdult {
+			includeAnime = false
+		}
+
+		// Leave out entries hidden from status lists, as AniList does, unless they've been asked for
+		if !m.filters.showStatusListHidden && anime.UserData.HiddenFromStatusLists {
+			includeAnime = false
+		}
+
+		// Filter on the user's score
+		if includeAnime {
+			for _, condition := range m.filters.scoreConditions {
+				if !condition.matches(anime.UserData.Score) {
+					includeAnime = false
+					break
+				}
+			}
+		}
+
+		// Filter on season year
+		if m.filters.year != 0 && includeAnime && anime.SeasonYear != strconv.Itoa(m.filters.year) {
+			includeAnime = false
+		}
+
+		// Filter on media format
+		if includeAnime && !matchesFormatFilters(m.filters.formatFilters, anime.Format) {
+			includeAnime = false
+		}
+
+		// Filter on genres and tags
+		if includeAnime {
+			includeAnime = matchesLabelGroups(m.filters.genreGroups, anime.HasGenre) &&
+				matchesLabelGroups(m.filters.tagGroups, anime.HasTag)
+		}
+
 		// Filter on title search query
 		if m.filters.searchQuery != "" && includeAnime {
 			query := strings.ToLower(m.filters.searchQuery)
@@ -125,6 +203,12 @@ func (m *AnimeListModel) applyFilters() {
 		}
 	}
 
+	if m.groupByAiringDay {
+		sortByAiringDay(m.filteredAnime, time.Now(), m.airingLocation)
+	} else {
+		sortAnime(m.filteredAnime, m.sortMode)
+	}
+
 	// Reset cursor if it's out of bounds
 	if len(m.filteredAnime) == 0 {
 		m.cursor = 0
@@ -133,6 +217,16 @@ func (m *AnimeListModel) applyFilters() {
 	}
 }
 
+// matchesLabelGroups reports whether every group has at least one label the anime has
+func matchesLabelGroups(groups [][]string, has func(string) bool) bool {
+	for _, group := range groups {
+		if !slices.ContainsFunc(group, has) {
+			return false
+		}
+	}
+	return true
+}
+
 // getStatusFilterCounts returns a map with the count of anime for each status
 func (m *AnimeListModel) getStatusFilterCounts() map[domain.MediaStatus]int {
 	counts := make(map[domain.MediaStatus]int)
@@ -152,7 +246,7 @@ func (m *AnimeListModel) getStatusFilterCounts() map[domain.MediaStatus]int {
 
 	// Count anime by status
 	for _, anime := range m.allAnime {
-		if anime.UserData != nil {
+		if anime.UserData != nil && !m.animeService.IsHidden(anime.ID) {
 			counts[anime.UserData.Status]++
 		}
 	}
@@ -199,11 +293,50 @@ func (m *AnimeListModel) renderFilterStatus() string {
 		conditionalIndicator(m.filters.hasAvailableEpisodes, "A", "-"),
 		conditionalIndicator(m.filters.isFinishedAiring, "F", "-"))
 
+	formatFilters := []struct {
+		format    string
+		indicator string
+	}{
+		{domain.FormatTV, "T"},
+		{domain.FormatMovie, "M"},
+		{domain.FormatOVA, "V"},
+		{domain.FormatONA, "N"},
+		{domain.FormatSpecial, "S"},
+	}
+	var formatIndicators []string
+	for _, f := range formatFilters {
+		formatIndicators = append(formatIndicators,
+			fmt.Sprintf("[%s]", conditionalIndicator(slices.Contains(m.filters.formatFilters, f.format), f.indicator, "-")))
+	}
+	episodeFilters += " | Format -> " + strings.Join(formatIndicators, " ")
+
 	searchText := "-"
 	if m.filters.searchQuery != "" {
 		searchText = fmt.Sprintf("\"%s\"", m.filters.searchQuery)
 	}
 	searchFilter := fmt.Sprintf(" | Search: %s", searchText)
+	for _, condition := range m.filters.scoreConditions {
+		searchFilter += fmt.Sprintf(" | Score%s%g", condition.op, condition.value)
+	}
+	if m.filters.year != 0 {
+		searchFilter += fmt.Sprintf(" | Year: %d", m.filters.year)
+	}
+	for _, group := range m.filters.genreGroups {
+		searchFilter += " | Genre: " + strings.Join(group, "/")
+	}
+	for _, group := range m.filters.tagGroups {
+		searchFilter += " | Tag: " + strings.Join(group, "/")
+	}
+
+	if m.filters.hideAdult {
+		searchFilter += " | Adult: hidden"
+	}
+	if m.filters.showStatusListHidden {
+		searchFilter += " | Including entries hidden from status lists"
+	}
+	if m.groupByAiringDay {
+		searchFilter += " | Grouped by airing day"
+	}
 
 	// Join all filter sections
 	filterLine := " Status -> " + strings.Join(statusIndicators, " ") + " " + episodeFilters + " " + searchFilter
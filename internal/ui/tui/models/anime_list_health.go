@@ -0,0 +1,66 @@
+package models
+
+// anime_list_health.go keeps track of whether the episode providers are up, so a playback failure caused by a
+// provider outage is obvious from the anime list header.
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/player"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// checkProviderHealthCmd checks the episode providers straight away
+func (m *AnimeListModel) checkProviderHealthCmd() tea.Cmd {
+	if parseInterval("network.health_check_interval", m.config.Network.HealthCheckInterval) <= 0 {
+		return nil
+	}
+
+	playerService := m.playerService
+	return func() tea.Msg {
+		return ProviderHealthMsg{Results: playerService.CheckProviderHealth(context.Background())}
+	}
+}
+
+// scheduleHealthCheck starts the timer for the next provider check, replacing any timer already running
+func (m *AnimeListModel) scheduleHealthCheck() tea.Cmd {
+	interval := parseInterval("network.health_check_interval", m.config.Network.HealthCheckInterval)
+	if interval <= 0 {
+		return nil
+	}
+
+	m.healthCheckSeq++
+	seq := m.healthCheckSeq
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return HealthCheckMsg{Seq: seq}
+	})
+}
+
+// HandleHealthCheck checks the providers when the health check timer fires
+func (m *AnimeListModel) HandleHealthCheck(msg HealthCheckMsg) (Model, tea.Cmd) {
+	if msg.Seq != m.healthCheckSeq {
+		return m, nil
+	}
+	return m, tea.Batch(m.checkProviderHealthCmd(), m.scheduleHealthCheck())
+}
+
+// HandleProviderHealth records the results of a provider check
+func (m *AnimeListModel) HandleProviderHealth(msg ProviderHealthMsg) (Model, tea.Cmd) {
+	m.providerHealth = msg.Results
+	return m, nil
+}
+
+// providerHealthSummary describes the providers' health for the header, e.g. "AllAnime: down".  Providers that
+// haven't been checked are left out, and nothing is returned if no provider has been checked.
+func (m *AnimeListModel) providerHealthSummary() string {
+	var parts []string
+	for _, health := range m.providerHealth {
+		if health.Status == player.HealthUnknown {
+			continue
+		}
+		parts = append(parts, health.Name+": "+health.Status.String())
+	}
+	return strings.Join(parts, ", ")
+}
@@ -10,11 +10,14 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/PizzaHomicide/hisame/internal/config"
 	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/hooks"
 	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
 	"github.com/charmbracelet/bubbles/spinner"
 
 	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/simkl"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -27,6 +30,11 @@ func (m *AnimeListModel) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return m, cmd
 		}
 
+		// Likewise when the quick filter bar is open
+		if cmd := m.handleQuickFilterKeyMsg(msg); cmd != nil {
+			return m, cmd
+		}
+
 		// Normal mode key handling
 		if cmd := m.handleKeyPress(msg); cmd != nil {
 			return m, cmd
@@ -59,42 +67,73 @@ func (m *AnimeListModel) Update(msg tea.Msg) (Model, tea.Cmd) {
 				"message", msg.Message)
 			// Refresh the UI to show updated data
 			m.applyFilters()
+			if msg.Queued {
+				m.refreshNotice = msg.Message
+			} else {
+				m.runHook(m.config.Hooks.OnProgressUpdate, hooks.ProgressUpdate, msg.AnimeID, 0, 0)
+			}
+			if msg.Completed {
+				return m, m.handleCompletionActivity(msg.AnimeID)
+			}
 		} else {
 			log.Error("Anime update failed",
 				"animeID", msg.AnimeID,
 				"error", msg.Error)
+			m.applyFilters()
+			title := fmt.Sprintf("anime %d", msg.AnimeID)
+			if anime := m.findAnimeById(msg.AnimeID); anime != nil {
+				title = anime.Title.Preferred
+			}
+			return m, m.showErrorToast(fmt.Sprintf("Couldn't update %s: %v", title, msg.Error))
 		}
 		return m, nil
 
 	case PlaybackCompletedMsg:
-		if msg.Progress < 75.0 {
+		m.runHook(m.config.Hooks.OnPlaybackComplete, hooks.PlaybackComplete, msg.AnimeID, msg.EpisodeNumber, msg.Progress)
+		if msg.Estimated {
+			return m, m.confirmEstimatedProgress(msg)
+		}
+		threshold := m.watchedThreshold()
+		if msg.Missed && msg.Progress < threshold {
+			// The episode may have carried on well past the journal, so ask rather than assume
+			return m, m.confirmProgress("Played while Hisame was closed", msg)
+		}
+		if msg.Progress < threshold {
+			if m.config.Player.ConfirmUnfinished == "on" {
+				log.Info("Playback ended before the episode was watched.  Asking whether to increment progress",
+					"animeID", msg.AnimeID, "playbackProgress", msg.Progress, "threshold", threshold)
+				return m, m.confirmProgress(fmt.Sprintf("Stopped at %.0f%%", msg.Progress), msg)
+			}
 			log.Info("Playback ended.  Not incrementing progress as not enough of the episode was watched", "animeID", msg.AnimeID, "playbackProgress", msg.Progress)
 			return m, nil
 		}
 
-		return m, func() tea.Msg {
-			log.Info("Playback ended.  Incrementing progress", "animeID", msg.AnimeID, "playbackProgress", msg.Progress, "episode_watched", msg.EpisodeNumber)
-			// Increment anime progress
-			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-			defer cancel()
+		log.Info("Playback ended.  Incrementing progress", "animeID", msg.AnimeID, "playbackProgress", msg.Progress, "episode_watched", msg.EpisodeNumber)
+		return m, tea.Batch(m.incrementProgress(msg.AnimeID, msg.EpisodeNumber), m.scrobble(msg.AnimeID, msg.EpisodeNumber))
 
-			err := m.animeService.IncrementProgress(ctx, msg.AnimeID)
+	case IncrementProgressMsg:
+		log.Info("Incrementing progress after confirmation", "animeID", msg.AnimeID, "episode_watched", msg.EpisodeNumber)
+		return m, tea.Batch(m.incrementProgress(msg.AnimeID, msg.EpisodeNumber), m.scrobble(msg.AnimeID, msg.EpisodeNumber))
 
-			if err != nil {
-				return AnimeUpdatedMsg{
-					Success: false,
-					AnimeID: msg.AnimeID,
-					Error:   err,
-				}
-			}
+	case ScrobbledMsg:
+		if msg.Error != nil {
+			log.Warn("Failed to scrobble episode to Simkl", "animeID", msg.AnimeID, "episode", msg.EpisodeNumber,
+				"error", msg.Error)
+			return m, m.showErrorToast(fmt.Sprintf("Couldn't add episode %d to your Simkl history: %v", msg.EpisodeNumber, msg.Error))
+		}
+		log.Info("Scrobbled episode to Simkl", "animeID", msg.AnimeID, "episode", msg.EpisodeNumber)
+		return m, nil
 
-			return AnimeUpdatedMsg{
-				Success: true,
-				AnimeID: msg.AnimeID,
-				Message: fmt.Sprintf("Automatically updated progress after watching episode %d",
-					msg.EpisodeNumber),
-			}
+	case PostActivityMsg:
+		return m, m.postCompletionActivity(msg.AnimeID)
+
+	case ActivityPostedMsg:
+		if msg.Error != nil {
+			log.Error("Failed to post completion activity", "animeID", msg.AnimeID, "error", msg.Error)
+		} else {
+			log.Info("Posted completion activity", "animeID", msg.AnimeID)
 		}
+		return m, nil
 
 	case PlayNextEpisodeMsg:
 		var selectedAnime = m.findAnimeById(msg.AnimeID)
@@ -113,6 +152,77 @@ func (m *AnimeListModel) Update(msg tea.Msg) (Model, tea.Cmd) {
 		}
 
 		return m, m.handleChooseEpisode(selectedAnime)
+
+	case HideAnimeMsg:
+		return m, m.handleHideAnime(m.findAnimeById(msg.AnimeID))
+
+	case RefreshAnimeMsg:
+		return m, m.refreshAnime(m.findAnimeById(msg.AnimeID))
+
+	case ChoosePriorityMsg:
+		return m, m.showPriorityMenu(m.findAnimeById(msg.AnimeID))
+
+	case SetPriorityMsg:
+		return m, m.setPriority(m.findAnimeById(msg.AnimeID), msg.Priority)
+
+	case PriorityChangedMsg:
+		if msg.Error != nil {
+			return m, m.showErrorToast(fmt.Sprintf("Couldn't set the priority of %s: %v", msg.Title, msg.Error))
+		}
+		m.refreshNotice = fmt.Sprintf("Set the priority of %s to %d", msg.Title, msg.Priority)
+		m.applyFilters()
+		return m, nil
+
+	case SetStatusListsHiddenMsg:
+		return m, m.setStatusListsHidden(m.findAnimeById(msg.AnimeID), msg.Hidden)
+
+	case StatusListsHiddenChangedMsg:
+		if msg.Error != nil {
+			return m, m.showErrorToast(fmt.Sprintf("Couldn't update %s: %v", msg.Title, msg.Error))
+		}
+		if msg.Hidden {
+			m.refreshNotice = fmt.Sprintf("%s is now hidden from your AniList status lists", msg.Title)
+		} else {
+			m.refreshNotice = fmt.Sprintf("%s is back in your AniList status lists", msg.Title)
+		}
+		m.applyFilters()
+		return m, nil
+
+	case AnimeRefreshedMsg:
+		if msg.Error != nil {
+			return m, m.showErrorToast(fmt.Sprintf("Couldn't refresh %s: %v", msg.Title, msg.Error))
+		}
+		if msg.Removed {
+			m.allAnime = m.animeService.GetAnimeList()
+			m.refreshNotice = fmt.Sprintf("%s is no longer on your AniList list", msg.Title)
+		} else {
+			m.refreshNotice = fmt.Sprintf("Refreshed %s", msg.Title)
+		}
+		m.applyFilters()
+		return m, nil
+
+	case AddedToListMsg:
+		if msg.Error != nil {
+			return m, m.showErrorToast(fmt.Sprintf("Couldn't add %s to your list: %v", msg.Title, msg.Error))
+		}
+		m.allAnime = m.animeService.GetAnimeList()
+		m.applyFilters()
+		return m, m.handlePlayNextEpisode(msg.Anime)
+
+	case ToastExpiredMsg:
+		if msg.Seq == m.toastSeq {
+			m.errorToast = ""
+		}
+		return m, nil
+
+	case ShowWatchOrderMsg:
+		var selectedAnime = m.findAnimeById(msg.AnimeID)
+		if selectedAnime == nil {
+			log.Warn("Received message to show watch order, but could not find ID in list", "anime_id", msg.AnimeID)
+			return m, nil
+		}
+
+		return m, m.handleShowWatchOrder(selectedAnime)
 	}
 
 	// Handle other message types in the playback file
@@ -151,29 +261,78 @@ func (m *AnimeListModel) handleSearchModeKeyMsg(msg tea.KeyMsg) tea.Cmd {
 
 // handleKeyPress processes keyboard inputs in normal mode
 func (m *AnimeListModel) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
-	switch action := kb.GetActionByKey(msg, kb.ContextAnimeList); action {
-	case kb.ActionMoveUp:
-		if m.cursor > 0 {
-			m.cursor--
-		}
-		return Handled("cursor_move:up")
-	case kb.ActionMoveDown:
-		if len(m.filteredAnime) > 0 && m.cursor < len(m.filteredAnime)-1 {
-			m.cursor++
-		}
-		return Handled("cursor_move:down")
+	action, count := m.navigation.Action(msg, kb.ContextAnimeList)
+	if cursor, ok := moveCursor(m.cursor, len(m.filteredAnime), m.listPageSize(), action, count); ok {
+		m.cursor = cursor
+		return Handled("cursor_move:" + string(action))
+	}
+
+	switch action {
+	case kb.ActionPending:
+		return Handled("navigation:pending")
 	// All filter toggle actions are handled together
 	case kb.ActionToggleFilterStatusCurrent, kb.ActionToggleFilterStatusPlanning, kb.ActionToggleFilterStatusComplete,
 		kb.ActionToggleFilterStatusDropped, kb.ActionToggleFilterStatusPaused, kb.ActionToggleFilterStatusRepeating,
-		kb.ActionToggleFilterFinishedAiring, kb.ActionToggleFilterNewEpisodes:
+		kb.ActionToggleFilterFinishedAiring, kb.ActionToggleFilterNewEpisodes, kb.ActionToggleFilterAdult,
+		kb.ActionToggleFilterStatusHidden,
+		kb.ActionToggleFilterFormatTV, kb.ActionToggleFilterFormatMovie, kb.ActionToggleFilterFormatOVA,
+		kb.ActionToggleFilterFormatONA, kb.ActionToggleFilterFormatSpecial:
 		m.toggleFilter(action)
 		m.applyFilters()
 		m.cursor = 0
 		return Handled("filter:toggle")
+	case kb.ActionToggleAiringDayGroups:
+		m.groupByAiringDay = !m.groupByAiringDay
+		m.sortMode = sortDefault
+		m.applyFilters()
+		m.cursor = 0
+		return Handled("group_by_airing_day:toggle")
+	case kb.ActionFocusMode:
+		if anime := m.getSelectedAnime(); anime != nil {
+			return func() tea.Msg {
+				return ShowFocusMsg{AnimeID: anime.ID}
+			}
+		}
+		return Handled("focus_mode:none_selected")
+	case kb.ActionTogglePrioritySort:
+		if m.sortMode == sortPriority {
+			m.sortMode = sortDefault
+		} else {
+			m.sortMode = sortPriority
+		}
+		m.groupByAiringDay = false
+		m.applyFilters()
+		m.cursor = 0
+		return Handled("sort_by_priority:toggle")
+	case kb.ActionToggleDetailedRows:
+		rows := "detailed"
+		if m.detailedRows() {
+			rows = "compact"
+		}
+		m.config.UI.ListRows = rows
+		err := config.UpdateConfig(func(conf *config.Config) {
+			conf.UI.ListRows = rows
+		})
+		if err != nil {
+			log.Warn("Failed to save the list rows to the config file", "error", err)
+		}
+		return Handled("list_rows:toggle")
+	case kb.ActionCycleSort:
+		m.sortMode = m.sortMode.next()
+		m.groupByAiringDay = false
+		m.applyFilters()
+		m.cursor = 0
+		return Handled("sort:cycle")
 	case kb.ActionEnableSearch:
 		m.searchMode = true
 		m.searchInput.Focus()
 		return Handled("search:enable")
+	case kb.ActionQuickFilter:
+		m.quickFilterMode = true
+		m.quickFilterInput.SetValue(m.filters.quickFilter)
+		m.quickFilterInput.CursorEnd()
+		m.quickFilterInput.Focus()
+		return Handled("quick_filter:enable")
 	case kb.ActionPlayNextEpisode:
 		return m.handlePlayNextEpisode(m.getSelectedAnime())
 	case kb.ActionOpenEpisodeSelector:
@@ -186,6 +345,8 @@ func (m *AnimeListModel) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 				Operation: m.fetchAnimeListCmd(),
 			}
 		}
+	case kb.ActionRefreshAnime:
+		return m.refreshAnime(m.getSelectedAnime())
 	case kb.ActionIncrementProgress:
 		return m.handleIncrementProgress()
 	case kb.ActionDecrementProgress:
@@ -200,6 +361,69 @@ func (m *AnimeListModel) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 				Anime: anime,
 			}
 		}
+	case kb.ActionViewWatchOrder:
+		anime := m.getSelectedAnime()
+		if anime == nil {
+			return Handled("view_watch_order:none_selected")
+		}
+		return m.handleShowWatchOrder(anime)
+	case kb.ActionBackfillCompletionDates:
+		return m.handleBackfillCompletionDates()
+	case kb.ActionAuditList:
+		return func() tea.Msg {
+			return ShowListAuditMsg{}
+		}
+	case kb.ActionHideAnime:
+		return m.handleHideAnime(m.getSelectedAnime())
+	case kb.ActionManageHidden:
+		return func() tea.Msg {
+			return ShowHiddenEntriesMsg{}
+		}
+	case kb.ActionViewProfile:
+		return func() tea.Msg {
+			return ShowProfileMsg{}
+		}
+	case kb.ActionViewSession:
+		return func() tea.Msg {
+			return ShowSessionMsg{}
+		}
+	case kb.ActionExportList:
+		return func() tea.Msg {
+			return ShowExportMsg{}
+		}
+	case kb.ActionManageBackups:
+		return func() tea.Msg {
+			return ShowBackupsMsg{}
+		}
+	case kb.ActionImportLocalProgress:
+		return func() tea.Msg {
+			return ShowLocalImportMsg{}
+		}
+	case kb.ActionEditNotes:
+		if anime := m.getSelectedAnime(); anime != nil {
+			return func() tea.Msg {
+				return EditNotesMsg{AnimeID: anime.ID}
+			}
+		}
+		return Handled("edit_notes:none_selected")
+	case kb.ActionCycleSubtitles:
+		if err := m.playerService.CycleSubtitles(); err != nil {
+			log.Warn("Failed to cycle subtitles", "error", err)
+			return m.showErrorToast(fmt.Sprintf("Couldn't switch subtitles: %v", err))
+		}
+		return Handled("cycle_subtitles")
+	case kb.ActionTogglePause:
+		if err := m.playerService.TogglePause(); err != nil {
+			log.Warn("Failed to pause playback", "error", err)
+			return m.showErrorToast(fmt.Sprintf("Couldn't pause: %v", err))
+		}
+		return Handled("toggle_pause")
+	case kb.ActionStopPlayback:
+		if !m.playerService.IsPlaying() {
+			return m.showErrorToast("No episode is playing")
+		}
+		m.playerService.StopPlayback()
+		return Handled("stop_playback")
 	case kb.ActionShowMenu:
 		return m.showMenu()
 	}
@@ -207,25 +431,74 @@ func (m *AnimeListModel) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 	return nil
 }
 
+// handleBackfillCompletionDates opens the completion date backfill flow if any completed entries are missing a date
+func (m *AnimeListModel) handleBackfillCompletionDates() tea.Cmd {
+	entries := m.animeService.GetMissingCompletionDates()
+	if len(entries) == 0 {
+		log.Info("No completed entries are missing a completion date")
+		return Handled("backfill_completion_dates:none_missing")
+	}
+	return func() tea.Msg {
+		return ShowCompletionBackfillMsg{Entries: entries}
+	}
+}
+
+// handleHideAnime hides the anime from Hisame locally, leaving the AniList entry untouched
+func (m *AnimeListModel) handleHideAnime(anime *domain.Anime) tea.Cmd {
+	if anime == nil {
+		return Handled("hide_anime:none_selected")
+	}
+	if err := m.animeService.HideAnime(anime); err != nil {
+		log.Error("Failed to hide anime", "anime_id", anime.ID, "error", err)
+		return Handled("hide_anime:error")
+	}
+	m.applyFilters()
+	return Handled("hide_anime:hidden")
+}
+
 // handleIncrementProgress handles incrementing the progress of the selected anime
 func (m *AnimeListModel) handleIncrementProgress() tea.Cmd {
-	anime := m.getSelectedAnime()
+	return m.changeProgress(m.getSelectedAnime(), 1)
+}
+
+// handleDecrementProgress handles decrementing the progress of the selected anime
+func (m *AnimeListModel) handleDecrementProgress() tea.Cmd {
+	return m.changeProgress(m.getSelectedAnime(), -1)
+}
+
+// changeProgress shows the anime's new progress straight away and saves it to AniList in the background.  If saving
+// fails the progress is rolled back and an error is shown, unless AniList couldn't be reached, in which case the
+// change is kept and queued until it is back.
+func (m *AnimeListModel) changeProgress(anime *domain.Anime, delta int) tea.Cmd {
 	if anime == nil {
-		return Handled("increment_progress:none_selected")
+		return Handled("change_progress:none_selected")
 	}
 
-	return func() tea.Msg {
-		log.Info("Incrementing progress",
-			"title", anime.Title.Preferred,
-			"id", anime.ID,
-			"current_progress", anime.UserData.Progress)
+	log.Info("Changing progress",
+		"title", anime.Title.Preferred,
+		"id", anime.ID,
+		"current_progress", anime.UserData.Progress,
+		"delta", delta)
 
+	update, err := m.animeService.BeginProgressUpdate(anime.ID, delta)
+	if err != nil {
+		log.Warn("Can't change progress", "id", anime.ID, "error", err)
+		return m.showErrorToast(err.Error())
+	}
+	m.applyFilters()
+
+	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		err := m.animeService.IncrementProgress(ctx, anime.ID)
-		if err != nil {
-			log.Error("Failed to increment progress", "error", err)
+		if err := m.animeService.CommitProgressUpdate(ctx, update); err != nil {
+			if msg, queued := queuedUpdateMsg(anime.ID, err); queued {
+				return msg
+			}
+			if msg, conflicted := conflictMsg(err); conflicted {
+				return msg
+			}
+			log.Error("Failed to change progress", "error", err)
 			return AnimeUpdatedMsg{
 				Success: false,
 				AnimeID: anime.ID,
@@ -238,46 +511,50 @@ func (m *AnimeListModel) handleIncrementProgress() tea.Cmd {
 			AnimeID: anime.ID,
 			Message: fmt.Sprintf("Updated progress for %s to %d/%d",
 				anime.Title.Preferred,
-				anime.UserData.Progress,
+				update.Progress,
 				anime.Episodes),
+			Completed: m.justCompleted(anime.ID, update.PreviousStatus),
 		}
 	}
 }
 
-// handleDecrementProgress handles decrementing the progress of the selected anime
-func (m *AnimeListModel) handleDecrementProgress() tea.Cmd {
-	anime := m.getSelectedAnime()
+// refreshAnime re-fetches just the given anime from AniList, e.g. to pick up a changed airing schedule without a full
+// list reload
+func (m *AnimeListModel) refreshAnime(anime *domain.Anime) tea.Cmd {
 	if anime == nil {
-		return Handled("decrement_progress:none_selected")
+		return Handled("refresh_anime:none_selected")
 	}
 
+	animeID, title := anime.ID, anime.Title.Preferred
+	log.Info("Refreshing anime", "id", animeID, "title", title)
 	return func() tea.Msg {
-		log.Info("Decrementing progress",
-			"title", anime.Title.Preferred,
-			"id", anime.ID,
-			"current_progress", anime.UserData.Progress)
-
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		err := m.animeService.DecrementProgress(ctx, anime.ID)
+		removed, err := m.animeService.RefreshAnime(ctx, animeID)
 		if err != nil {
-			log.Error("Failed to decrement progress", "error", err)
-			return AnimeUpdatedMsg{
-				Success: false,
-				AnimeID: anime.ID,
-				Error:   err,
-			}
+			log.Error("Failed to refresh anime", "id", animeID, "error", err)
 		}
+		return AnimeRefreshedMsg{AnimeID: animeID, Title: title, Removed: removed, Error: err}
+	}
+}
 
-		return AnimeUpdatedMsg{
-			Success: true,
-			AnimeID: anime.ID,
-			Message: fmt.Sprintf("Updated progress for %s to %d/%d",
-				anime.Title.Preferred,
-				anime.UserData.Progress,
-				anime.Episodes),
+// setStatusListsHidden hides an entry from, or returns it to, the user's status lists on AniList in the background
+func (m *AnimeListModel) setStatusListsHidden(anime *domain.Anime, hidden bool) tea.Cmd {
+	if anime == nil {
+		return Handled("status_lists_hidden:none_selected")
+	}
+
+	animeID, title := anime.ID, anime.Title.Preferred
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		err := m.animeService.SetHiddenFromStatusLists(ctx, animeID, hidden)
+		if err != nil {
+			log.Error("Failed to change whether anime is hidden from status lists", "id", animeID, "error", err)
 		}
+		return StatusListsHiddenChangedMsg{Title: title, Hidden: hidden, Error: err}
 	}
 }
 
@@ -292,22 +569,22 @@ func (m *AnimeListModel) handlePlayNextEpisode(anime *domain.Anime) tea.Cmd {
 			"id", anime.ID, "progress", anime.UserData.Progress, "latest_aired", anime.GetLatestAiredEpisode())
 		return Handled("play_episode:none_available")
 	}
-	nextEpNumber := m.getSelectedAnime().UserData.Progress + 1
+	nextEpNumber := anime.UserData.Progress + 1
 	log.Info("Play next episode",
-		"title", m.getSelectedAnime().Title.Preferred,
-		"id", m.getSelectedAnime().ID,
-		"current_progress", m.getSelectedAnime().UserData.Progress,
+		"title", anime.Title.Preferred,
+		"id", anime.ID,
+		"current_progress", anime.UserData.Progress,
 		"next_ep", nextEpNumber)
 
 	// Set loading state with custom message
 	m.loading = true
 	m.loadingMsg = fmt.Sprintf("Finding episode %d for %s...",
 		nextEpNumber,
-		m.getSelectedAnime().Title.Preferred)
+		anime.Title.Preferred)
 
 	return tea.Batch(
 		m.spinner.Tick,
-		m.loadNextEpisode(nextEpNumber),
+		m.loadNextEpisode(anime, nextEpNumber),
 	)
 }
 
@@ -331,6 +608,53 @@ func (m *AnimeListModel) handleChooseEpisode(anime *domain.Anime) tea.Cmd {
 	)
 }
 
+// handleShowWatchOrder builds the franchise watch order for the anime behind a loading screen
+func (m *AnimeListModel) handleShowWatchOrder(anime *domain.Anime) tea.Cmd {
+	log.Info("Show watch order", "title", anime.Title.Preferred, "id", anime.ID)
+
+	return func() tea.Msg {
+		return LoadingMsg{
+			Type:        LoadingStart,
+			Message:     "Building watch order...",
+			ContextInfo: anime.Title.Preferred,
+			Operation: func() tea.Msg {
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				defer cancel()
+
+				entries, err := m.animeService.GetWatchOrder(ctx, anime.ID)
+				return WatchOrderMsg{
+					Title:   anime.Title.Preferred,
+					Entries: entries,
+					Error:   err,
+				}
+			},
+		}
+	}
+}
+
+// statusListsMenuItem offers to hide the selected entry from the AniList status lists, or to return it if it already is
+func (m *AnimeListModel) statusListsMenuItem() MenuItem {
+	anime := m.getSelectedAnime()
+	hidden := anime.UserData != nil && anime.UserData.HiddenFromStatusLists
+
+	text := "Hide from AniList status lists"
+	if hidden {
+		text = "Return to AniList status lists"
+	}
+	return MenuItem{
+		Text: text,
+		Command: func() tea.Msg {
+			return MenuSelectionMsg{
+				CloseMenu: true,
+				NextMsg: SetStatusListsHiddenMsg{
+					AnimeID: anime.ID,
+					Hidden:  !hidden,
+				},
+			}
+		},
+	}
+}
+
 func (m *AnimeListModel) showMenu() tea.Cmd {
 	menuItems := []MenuItem{
 		{
@@ -359,6 +683,17 @@ func (m *AnimeListModel) showMenu() tea.Cmd {
 				}
 			},
 		},
+		{
+			Text: "Find torrents on Nyaa",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg: FindTorrentsMsg{
+						AnimeID: m.getSelectedAnime().ID,
+					},
+				}
+			},
+		},
 		{
 			Text: "View anime details",
 			Command: func() tea.Msg {
@@ -370,10 +705,229 @@ func (m *AnimeListModel) showMenu() tea.Cmd {
 				}
 			},
 		},
+		{
+			Text: "View watch order",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg: ShowWatchOrderMsg{
+						AnimeID: m.getSelectedAnime().ID,
+					},
+				}
+			},
+		},
+		{
+			Text: "Refresh this entry",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg: RefreshAnimeMsg{
+						AnimeID: m.getSelectedAnime().ID,
+					},
+				}
+			},
+		},
+		{
+			Text: "Focus mode",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg: ShowFocusMsg{
+						AnimeID: m.getSelectedAnime().ID,
+					},
+				}
+			},
+		},
+		{
+			Text: "Set priority",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg: ChoosePriorityMsg{
+						AnimeID: m.getSelectedAnime().ID,
+					},
+				}
+			},
+		},
+		{
+			Text: "Edit notes",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg: EditNotesMsg{
+						AnimeID: m.getSelectedAnime().ID,
+					},
+				}
+			},
+		},
+		{
+			Text: "Player preset",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg: ChoosePresetMsg{
+						AnimeID: m.getSelectedAnime().ID,
+					},
+				}
+			},
+		},
+		{
+			Text: "Sub or dub",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg: ChooseTranslationMsg{
+						AnimeID: m.getSelectedAnime().ID,
+					},
+				}
+			},
+		},
+		{
+			Text: "Match with AllAnime again",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg: ForgetShowMappingMsg{
+						AnimeID: m.getSelectedAnime().ID,
+					},
+				}
+			},
+		},
+		{
+			Text: "Wrong AllAnime match...",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg: ChooseWrongMatchMsg{
+						AnimeID: m.getSelectedAnime().ID,
+					},
+				}
+			},
+		},
+		{
+			Text: "Hide from Hisame",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg: HideAnimeMsg{
+						AnimeID: m.getSelectedAnime().ID,
+					},
+				}
+			},
+		},
+		m.statusListsMenuItem(),
 		{
 			Text:        "System options",
 			IsSeparator: true,
 		},
+		{
+			Text: "View profile",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg:   ShowProfileMsg{},
+				}
+			},
+		},
+		{
+			Text: "View session",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg:   ShowSessionMsg{},
+				}
+			},
+		},
+		{
+			Text: "Export list",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg:   ShowExportMsg{},
+				}
+			},
+		},
+		{
+			Text: "Export list as MyAnimeList XML",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg:   ShowExportMsg{Format: exportFormatMAL},
+				}
+			},
+		},
+		{
+			Text: "Export list as JSON",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg:   ShowExportMsg{Format: exportFormatJSON},
+				}
+			},
+		},
+		{
+			Text: "Export list as CSV",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg:   ShowExportMsg{Format: exportFormatCSV},
+				}
+			},
+		},
+		{
+			Text: "Cast to...",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg:   ChooseCastDeviceMsg{},
+				}
+			},
+		},
+		{
+			Text: "Import progress from local files",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg:   ShowLocalImportMsg{},
+				}
+			},
+		},
+		{
+			Text: "Import a MyAnimeList export",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg:   ShowMALImportMsg{},
+				}
+			},
+		},
+		{
+			Text: "Score this season's completions",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg:   ShowSeasonScoresMsg{},
+				}
+			},
+		},
+		{
+			Text: "Manage agenda snoozes",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg:   ShowSnoozesMsg{},
+				}
+			},
+		},
+		{
+			Text: "Restore a backup",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg:   ShowBackupsMsg{},
+				}
+			},
+		},
 		{
 			Text: "Refresh data",
 			Command: func() tea.Msg {
@@ -412,6 +966,228 @@ func (m *AnimeListModel) showMenu() tea.Cmd {
 	}
 }
 
+// animeStatus returns the list status of the anime with the given ID, or an empty status if it is unknown
+func (m *AnimeListModel) animeStatus(animeID int) domain.MediaStatus {
+	if anime := m.findAnimeById(animeID); anime != nil && anime.UserData != nil {
+		return anime.UserData.Status
+	}
+	return ""
+}
+
+// justCompleted returns true if the anime is now completed but was not before the update
+func (m *AnimeListModel) justCompleted(animeID int, previousStatus domain.MediaStatus) bool {
+	return previousStatus != domain.StatusCompleted && m.animeStatus(animeID) == domain.StatusCompleted
+}
+
+// incrementProgress creates a command that increments the anime's progress after an episode was watched
+func (m *AnimeListModel) incrementProgress(animeID, episodeNumber int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		previousStatus := m.animeStatus(animeID)
+		// Advanced to the episode watched rather than incremented, in case filler before it was skipped
+		err := m.animeService.AdvanceProgress(ctx, animeID, episodeNumber)
+		if msg, queued := queuedUpdateMsg(animeID, err); queued {
+			return msg
+		}
+		if msg, conflicted := conflictMsg(err); conflicted {
+			return msg
+		}
+
+		if err != nil {
+			return AnimeUpdatedMsg{
+				Success: false,
+				AnimeID: animeID,
+				Error:   err,
+			}
+		}
+
+		return AnimeUpdatedMsg{
+			Success:   true,
+			AnimeID:   animeID,
+			Message:   fmt.Sprintf("Automatically updated progress after watching episode %d", episodeNumber),
+			Completed: m.justCompleted(animeID, previousStatus),
+		}
+	}
+}
+
+// runHook runs the hook command for the anime in the background, if one is configured.  episode is the episode played,
+// or 0 for the episode progress is now at.
+func (m *AnimeListModel) runHook(command, hook string, animeID, episode int, playbackPercent float64) {
+	if command == "" {
+		return
+	}
+	anime := m.findAnimeById(animeID)
+	if anime == nil {
+		log.Debug("Not running hook for anime not in the list", "hook", hook, "anime_id", animeID)
+		return
+	}
+
+	event := hooks.Event{
+		Hook:            hook,
+		AnimeID:         anime.ID,
+		Title:           anime.Title.Preferred,
+		Episode:         episode,
+		TotalEpisodes:   anime.Episodes,
+		PlaybackPercent: playbackPercent,
+	}
+	if anime.UserData != nil {
+		event.Progress = anime.UserData.Progress
+	}
+	if event.Episode == 0 {
+		event.Episode = event.Progress
+	}
+	hooks.Run(command, event)
+}
+
+// scrobble adds the watched episode to the user's Simkl history, alongside the AniList progress update.  Does nothing
+// unless Simkl is configured.
+func (m *AnimeListModel) scrobble(animeID, episodeNumber int) tea.Cmd {
+	anime := m.findAnimeById(animeID)
+	if m.simkl == nil || anime == nil {
+		return nil
+	}
+	episode := simkl.Episode{
+		AniListID: anime.ID,
+		MalID:     anime.IDMal,
+		Title:     anime.Title.Preferred,
+		Number:    episodeNumber,
+		WatchedAt: time.Now(),
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		return ScrobbledMsg{
+			AnimeID:       animeID,
+			EpisodeNumber: episodeNumber,
+			Error:         m.simkl.ScrobbleEpisode(ctx, episode),
+		}
+	}
+}
+
+// watchedThreshold is the percentage of an episode that must be played for progress to be updated automatically
+func (m *AnimeListModel) watchedThreshold() float64 {
+	fraction := m.config.Player.WatchedFraction
+	if fraction <= 0 || fraction > 1 {
+		fraction = 0.75
+	}
+	return fraction * 100
+}
+
+// exitWatchedThreshold is the percentage of an episode a player without IPC must run for before Hisame offers to mark
+// the episode watched
+func (m *AnimeListModel) exitWatchedThreshold() float64 {
+	fraction := m.config.Player.ExitWatchedFraction
+	if fraction <= 0 || fraction > 1 {
+		fraction = 0.75
+	}
+	return fraction * 100
+}
+
+// confirmEstimatedProgress asks the user whether to mark the episode watched, when a player without IPC ran for
+// long enough that the episode was probably watched.  Progress is never updated on an estimate alone.
+func (m *AnimeListModel) confirmEstimatedProgress(msg PlaybackCompletedMsg) tea.Cmd {
+	threshold := m.exitWatchedThreshold()
+	if msg.Progress < threshold {
+		log.Info("Player exited.  Not offering to increment progress as it did not run long enough", "animeID", msg.AnimeID,
+			"estimatedProgress", msg.Progress, "threshold", threshold)
+		return nil
+	}
+
+	return m.confirmProgress("Player closed", msg)
+}
+
+// confirmProgress asks the user whether to mark the episode watched, when how much of it was watched isn't known
+func (m *AnimeListModel) confirmProgress(title string, msg PlaybackCompletedMsg) tea.Cmd {
+	anime := m.findAnimeById(msg.AnimeID)
+	if anime == nil {
+		return nil
+	}
+	menuModel := NewMenuModel(title+" - "+anime.Title.Preferred, []MenuItem{
+		{
+			Text:        fmt.Sprintf("Mark episode %d as watched?", msg.EpisodeNumber),
+			IsSeparator: true,
+		},
+		{
+			Text: "Yes, update progress",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg:   IncrementProgressMsg{AnimeID: msg.AnimeID, EpisodeNumber: msg.EpisodeNumber},
+				}
+			},
+		},
+		{
+			Text: "No, leave it",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{CloseMenu: true}
+			},
+		},
+	})
+	return func() tea.Msg {
+		return ShowMenuMsg{Menu: menuModel}
+	}
+}
+
+// handleCompletionActivity posts, or offers to post, a completion activity to AniList depending on config.  There's
+// no account to post it from in local-only mode, so nothing is offered.
+func (m *AnimeListModel) handleCompletionActivity(animeID int) tea.Cmd {
+	if m.config.Auth.LocalOnly {
+		return nil
+	}
+
+	switch m.config.AniList.CompletionActivity {
+	case "always":
+		return m.postCompletionActivity(animeID)
+	case "ask":
+		anime := m.findAnimeById(animeID)
+		if anime == nil {
+			return nil
+		}
+		menuModel := NewMenuModel("Completed - "+anime.Title.Preferred, []MenuItem{
+			{
+				Text:        "Post a completion activity to AniList?",
+				IsSeparator: true,
+			},
+			{
+				Text: "Yes, post it",
+				Command: func() tea.Msg {
+					return MenuSelectionMsg{
+						CloseMenu: true,
+						NextMsg:   PostActivityMsg{AnimeID: animeID},
+					}
+				},
+			},
+			{
+				Text: "No thanks",
+				Command: func() tea.Msg {
+					return MenuSelectionMsg{CloseMenu: true}
+				},
+			},
+		})
+		return func() tea.Msg {
+			return ShowMenuMsg{Menu: menuModel}
+		}
+	default:
+		return nil
+	}
+}
+
+// postCompletionActivity creates a command that posts a completion activity to AniList
+func (m *AnimeListModel) postCompletionActivity(animeID int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		return ActivityPostedMsg{
+			AnimeID: animeID,
+			Error:   m.animeService.PostCompletionActivity(ctx, animeID),
+		}
+	}
+}
+
 // findAnimeById finds an anime in the loaded list and returns it.  Nil if not found
 func (m *AnimeListModel) findAnimeById(id int) *domain.Anime {
 	var selected *domain.Anime
@@ -0,0 +1,76 @@
+package models
+
+// anime_list_offline.go saves changes that were made while AniList couldn't be reached once the list loads from
+// AniList again, handing any that conflict with changes made on AniList over to the reconcile view.  Changes to
+// entries that were changed on AniList since the list loaded are handed over the same way.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/service"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// syncOfflineChangesCmd saves any queued changes to the freshly loaded list
+func (m *AnimeListModel) syncOfflineChangesCmd() tea.Cmd {
+	if len(m.animeService.QueuedChanges()) == 0 {
+		return nil
+	}
+
+	animeService := m.animeService
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		result, err := animeService.SyncOfflineChanges(ctx)
+		return OfflineSyncedMsg{Result: result, Error: err}
+	}
+}
+
+// HandleOfflineSynced reports what happened to the queued changes above the list
+func (m *AnimeListModel) HandleOfflineSynced(msg OfflineSyncedMsg) (Model, tea.Cmd) {
+	m.applyFilters()
+	if msg.Error != nil {
+		return m, m.showErrorToast(fmt.Sprintf("Couldn't save changes made offline: %v", msg.Error))
+	}
+
+	var parts []string
+	if msg.Result.Synced > 0 {
+		parts = append(parts, fmt.Sprintf("saved %d", msg.Result.Synced))
+	}
+	if n := len(msg.Result.Conflicts); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d changed on AniList too", n))
+	}
+	if msg.Result.Failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d still queued", msg.Result.Failed))
+	}
+	if len(parts) > 0 {
+		m.refreshNotice = "Changes made offline: " + strings.Join(parts, ", ")
+	}
+	return m, nil
+}
+
+// conflictMsg reports a change that wasn't saved because the entry was changed on AniList since it was loaded
+func conflictMsg(err error) (RemoteConflictMsg, bool) {
+	var conflict *service.ConflictError
+	if !errors.As(err, &conflict) {
+		return RemoteConflictMsg{}, false
+	}
+	return RemoteConflictMsg{Conflict: conflict.Conflict}, true
+}
+
+// queuedUpdateMsg reports a change that was kept locally because AniList couldn't be reached
+func queuedUpdateMsg(animeID int, err error) (AnimeUpdatedMsg, bool) {
+	if !errors.Is(err, service.ErrQueuedOffline) {
+		return AnimeUpdatedMsg{}, false
+	}
+	return AnimeUpdatedMsg{
+		Success: true,
+		AnimeID: animeID,
+		Message: "AniList can't be reached, the change will be saved once it is back",
+		Queued:  true,
+	}, true
+}
@@ -6,19 +6,180 @@ package models
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/PizzaHomicide/hisame/internal/cast"
+	"github.com/PizzaHomicide/hisame/internal/config"
 	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/filler"
+	"github.com/PizzaHomicide/hisame/internal/hooks"
+	"github.com/PizzaHomicide/hisame/internal/localfiles"
 
 	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/mpris"
 	"github.com/PizzaHomicide/hisame/internal/player"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/terminal"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 // handlePlaybackMessages handles all playback-related messages
 func (m *AnimeListModel) handlePlaybackMessages(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case ChoosePresetMsg:
+		anime := m.findAnimeById(msg.AnimeID)
+		if anime == nil {
+			log.Warn("Received message to choose a player preset, but could not find ID in list", "anime_id", msg.AnimeID)
+			return m, nil
+		}
+		return m, m.showPresetMenu(anime)
+
+	case SetAnimePresetMsg:
+		if err := m.playerService.SetAnimePreset(msg.AnimeID, msg.Preset); err != nil {
+			log.Error("Failed to save player preset", "anime_id", msg.AnimeID, "preset", msg.Preset, "error", err)
+			return m, Handled("set_preset:error")
+		}
+		log.Info("Set player preset", "anime_id", msg.AnimeID, "preset", msg.Preset)
+		return m, Handled("set_preset:saved")
+
+	case ChooseCastDeviceMsg:
+		return m, m.discoverCastDevices()
+
+	case CastDevicesFoundMsg:
+		if msg.Err != nil {
+			log.Error("Failed to look for Chromecasts", "error", msg.Err)
+			return m, m.showErrorToast(fmt.Sprintf("Couldn't look for Chromecasts: %v", msg.Err))
+		}
+		return m, m.showCastDeviceMenu(msg.Devices)
+
+	case SetCastDeviceMsg:
+		m.config.Player.CastDevice = msg.Name
+		err := config.UpdateConfig(func(conf *config.Config) {
+			conf.Player.CastDevice = msg.Name
+		})
+		if err != nil {
+			log.Warn("Failed to save the cast device to the config file", "error", err)
+		}
+		log.Info("Set cast device", "device", msg.Name)
+		if msg.Name == "" {
+			m.refreshNotice = "Episodes will play on this computer"
+		} else {
+			m.refreshNotice = fmt.Sprintf("Episodes will be cast to %s", msg.Name)
+		}
+		return m, Handled("set_cast_device:saved")
+
+	case ChooseTranslationMsg:
+		anime := m.findAnimeById(msg.AnimeID)
+		if anime == nil {
+			log.Warn("Received message to choose a translation type, but could not find ID in list", "anime_id", msg.AnimeID)
+			return m, nil
+		}
+		return m, m.showTranslationMenu(anime)
+
+	case SetAnimeTranslationMsg:
+		if err := m.playerService.SetAnimeTranslationType(msg.AnimeID, msg.TranslationType); err != nil {
+			log.Error("Failed to save translation type", "anime_id", msg.AnimeID, "translation_type", msg.TranslationType,
+				"error", err)
+			return m, m.showErrorToast("Failed to save sub/dub choice")
+		}
+		log.Info("Set translation type", "anime_id", msg.AnimeID, "translation_type", msg.TranslationType)
+		return m, Handled("set_translation:saved")
+
+	case ForgetShowMappingMsg:
+		anime := m.findAnimeById(msg.AnimeID)
+		if anime == nil {
+			log.Warn("Received message to forget a show mapping, but could not find ID in list", "anime_id", msg.AnimeID)
+			return m, nil
+		}
+		if err := m.playerService.ForgetShowMapping(msg.AnimeID); err != nil {
+			log.Error("Failed to forget AllAnime show mapping", "anime_id", msg.AnimeID, "error", err)
+			return m, m.showErrorToast("Failed to forget the AllAnime match")
+		}
+		log.Info("Forgot AllAnime show mapping", "anime_id", msg.AnimeID)
+		m.refreshNotice = fmt.Sprintf("%s will be matched with AllAnime again next time it is played", anime.Title.Preferred)
+		return m, Handled("forget_mapping:done")
+
+	case ChooseWrongMatchMsg:
+		anime := m.findAnimeById(msg.AnimeID)
+		if anime == nil {
+			log.Warn("Received message to choose a wrong match, but could not find ID in list", "anime_id", msg.AnimeID)
+			return m, nil
+		}
+		m.loading = true
+		m.loadingMsg = fmt.Sprintf("Finding the AllAnime shows %s is matched to...", anime.Title.Preferred)
+		return m, tea.Batch(m.spinner.Tick, m.loadMatchedShows(anime))
+
+	case MatchedShowsMsg:
+		m.loading = false
+		if msg.Error != nil {
+			log.Error("Failed to find matched AllAnime shows", "anime_id", msg.Anime.ID, "error", msg.Error)
+			return m, m.showErrorToast("Failed to find AllAnime shows: " + msg.Error.Error())
+		}
+		return m, m.showMatchedShowsMenu(msg.Anime, msg.Shows)
+
+	case BlacklistShowMsg:
+		if err := m.playerService.BlacklistShow(msg.AnimeID, msg.ShowID); err != nil {
+			log.Error("Failed to blacklist AllAnime show", "anime_id", msg.AnimeID, "allanime_id", msg.ShowID, "error", err)
+			return m, m.showErrorToast("Failed to save that the show isn't this anime")
+		}
+		log.Info("Blacklisted AllAnime show", "anime_id", msg.AnimeID, "allanime_id", msg.ShowID, "name", msg.ShowName)
+		m.refreshNotice = fmt.Sprintf("%s won't be matched to this anime again", msg.ShowName)
+		return m, Handled("blacklist_show:saved")
+
+	case FindTorrentsMsg:
+		return m, m.handleFindTorrents(msg)
+
+	case TorrentsFoundMsg:
+		return m, m.handleTorrentsFound(msg)
+
+	case PlayTorrentMsg:
+		return m, m.handlePlayTorrent(msg)
+
+	case PlaySourceMsg:
+		log.Info("Source chosen for episode",
+			"title", msg.Episode.AllAnimeName,
+			"episode", msg.Episode.AllAnimeEpisodeNumber,
+			"source_name", msg.Source.Source.SourceName)
+
+		m.loading = true
+		m.loadingMsg = fmt.Sprintf("Launching media player for %s episode %s%s...",
+			msg.Episode.AllAnimeName, msg.Episode.AllAnimeEpisodeNumber, translationNote(msg.Episode))
+		return m, tea.Batch(
+			m.spinner.Tick,
+			m.playStream(msg.Episode, nil, msg.Source.Stream.URL),
+		)
+
+	case RetryPlaybackMsg:
+		log.Info("Retrying playback", "title", msg.Episode.AllAnimeName, "episode", msg.Episode.AllAnimeEpisodeNumber)
+		m.loading = true
+		m.loadingMsg = fmt.Sprintf("Loading sources for episode %d of %s...",
+			msg.Episode.OverallEpisodeNumber, msg.Episode.PreferredTitle)
+		return m, tea.Batch(m.spinner.Tick, m.playEpisode(msg.Episode, msg.Anime))
+
+	case CopyStreamURLMsg:
+		m.loading = true
+		m.loadingMsg = fmt.Sprintf("Finding a stream for episode %d of %s...",
+			msg.Episode.OverallEpisodeNumber, msg.Episode.PreferredTitle)
+		return m, tea.Batch(m.spinner.Tick, m.copyStreamURL(msg.Episode, msg.Source))
+
+	case StreamURLCopiedMsg:
+		m.loading = false
+		if msg.Error != nil {
+			log.Error("Failed to copy stream URL", "episode", msg.Episode.OverallEpisodeNumber, "error", msg.Error)
+			return m, m.showErrorToast(fmt.Sprintf("Couldn't copy the stream URL of episode %d: %v",
+				msg.Episode.OverallEpisodeNumber, msg.Error))
+		}
+		m.refreshNotice = fmt.Sprintf("Copied the %s stream URL of episode %d of %s",
+			msg.SourceName, msg.Episode.OverallEpisodeNumber, msg.Episode.PreferredTitle)
+		if msg.Referer != "" {
+			m.refreshNotice += fmt.Sprintf(", which only plays with the Referer %s", msg.Referer)
+		}
+		return m, Handled("copy_stream_url:copied")
+
 	case PlaybackMsg:
 		switch msg.Type {
 		case PlaybackEventEpisodeFound:
@@ -46,46 +207,52 @@ func (m *AnimeListModel) handlePlaybackMessages(msg tea.Msg) (Model, tea.Cmd) {
 			log.Info("Episode sources loaded successfully",
 				"title", msg.Episode.AllAnimeName,
 				"episode", msg.Episode.AllAnimeEpisodeNumber,
-				"source_count", len(msg.Sources.Sources))
+				"source_count", len(msg.Details))
 
 			// Log details about each source
-			for i, source := range msg.Sources.Sources {
+			for i, details := range msg.Details {
 				log.Debug("Source option",
 					"index", i,
-					"name", source.SourceName,
-					"priority", source.Priority,
-					"type", source.Type,
-					"has_download", source.Downloads != nil)
+					"name", details.Source.SourceName,
+					"priority", details.Source.Priority,
+					"summary", details.Summary(),
+					"error", details.Error)
 			}
 
-			// At this point, we would normally launch the player
-			// For now, just log that we would play the highest priority source
-			if len(msg.Sources.Sources) > 0 {
-				bestSource := msg.Sources.Sources[0] // Already sorted by priority
-				log.Info("Would play this source (highest priority)",
-					"name", bestSource.SourceName,
-					"priority", bestSource.Priority,
-					"type", bestSource.Type,
-					"url", msg.StreamURL)
-			}
-
-			return m, nil
+			return m, m.showSourceMenu(msg.Episode, msg.Details)
 
 		case PlaybackEventError:
 			m.loading = false
 
+			if errors.Is(msg.Error, hooks.ErrAborted) {
+				// Nothing is wrong with the provider, the user's own hook chose not to play the episode
+				return m, m.showErrorToast(fmt.Sprintf("Didn't play episode %d of %s: %v",
+					msg.Episode.OverallEpisodeNumber, msg.Episode.PreferredTitle, msg.Error))
+			}
+
 			log.Error("Failed to load episode sources",
 				"title", msg.Episode.AllAnimeName,
 				"episode", msg.Episode.AllAnimeEpisodeNumber,
 				"error", msg.Error)
 
-			return m, nil
+			return m, tea.Batch(m.checkProviderHealthCmd(), func() tea.Msg {
+				return ShowPlaybackErrorMsg{Episode: msg.Episode, Anime: msg.Anime, Error: msg.Error}
+			})
 
 		case PlaybackEventStarted:
 			m.loading = false
 			log.Info("Playback started",
 				"title", msg.Episode.AllAnimeName,
-				"episode", msg.Episode.AllAnimeEpisodeNumber)
+				"episode", msg.Episode.AllAnimeEpisodeNumber,
+				"reattached", msg.Reattached)
+			switch {
+			case msg.Missed:
+				m.refreshNotice = fmt.Sprintf("Episode %d of %s stopped playing while Hisame was closed",
+					msg.Episode.OverallEpisodeNumber, msg.Episode.PreferredTitle)
+			case msg.Reattached:
+				m.refreshNotice = fmt.Sprintf("Picked back up episode %d of %s, still playing from before Hisame restarted",
+					msg.Episode.OverallEpisodeNumber, msg.Episode.PreferredTitle)
+			}
 			return m, m.listenForPlaybackCompletion()
 
 		case PlaybackEventEnded:
@@ -125,6 +292,24 @@ func (m *AnimeListModel) handlePlaybackMessages(msg tea.Msg) (Model, tea.Cmd) {
 					m.playEpisode(*msg.Episode, nil),
 				)
 			}
+
+		case EpisodeEventChooseSource:
+			if msg.Episode != nil {
+				m.loading = true
+				m.loadingMsg = fmt.Sprintf("Checking the sources for episode %d of %s...",
+					msg.Episode.OverallEpisodeNumber,
+					msg.Episode.PreferredTitle)
+
+				return m, tea.Batch(
+					m.spinner.Tick,
+					m.loadSourceDetails(*msg.Episode),
+				)
+			}
+
+		case EpisodeEventCopyStreamURL:
+			if msg.Episode != nil {
+				return m.Update(CopyStreamURLMsg{Episode: *msg.Episode})
+			}
 		}
 	}
 
@@ -140,12 +325,7 @@ func (m *AnimeListModel) loadEpisodes(anime *domain.Anime) tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		epResult, err := m.playerService.FindEpisodes(
-			ctx,
-			anime.ID,
-			&anime.Title,
-			anime.Synonyms,
-		)
+		epResult, err := m.playerService.FindEpisodes(ctx, anime)
 
 		if err != nil {
 			log.Error("Failed to get episodes", "error", err)
@@ -155,28 +335,56 @@ func (m *AnimeListModel) loadEpisodes(anime *domain.Anime) tea.Cmd {
 			}
 		}
 
+		// Episode titles are a nice to have, so a failure to fetch them doesn't stop the episodes being shown.  AniList's
+		// titles are used where it has them, and AllAnime's fill in the rest.
+		var episodeTitles map[int]string
+		if !m.config.UI.SpoilerSafe {
+			episodeTitles, err = m.animeService.GetEpisodeTitles(ctx, anime.ID)
+			if err != nil {
+				log.Warn("Failed to get episode titles", "anime_id", anime.ID, "error", err)
+			}
+			for _, episode := range epResult.Episodes {
+				if episode.Title == "" || episodeTitles[episode.OverallEpisodeNumber] != "" {
+					continue
+				}
+				if episodeTitles == nil {
+					episodeTitles = make(map[int]string)
+				}
+				episodeTitles[episode.OverallEpisodeNumber] = episode.Title
+			}
+		}
+
+		// As are the expected air dates of the episodes still to come
+		var schedule []domain.AiringSchedule
+		if isStillAiring(anime) {
+			schedule, err = m.animeService.GetAiringSchedule(ctx, anime.ID)
+			if err != nil {
+				log.Warn("Failed to get airing schedule", "anime_id", anime.ID, "error", err)
+			}
+		}
+
+		title := anime.Title.Preferred
+		if len(epResult.Episodes) > 0 {
+			title += translationNote(epResult.Episodes[0])
+		}
 		return EpisodeMsg{
-			Type:     EpisodeEventLoaded,
-			Episodes: epResult.Episodes,
-			Title:    anime.Title.Preferred,
+			Type:          EpisodeEventLoaded,
+			Episodes:      epResult.Episodes,
+			EpisodeTitles: episodeTitles,
+			Schedule:      schedule,
+			Filler:        m.fillerEpisodes(ctx, anime),
+			Title:         title,
 		}
 	}
 }
 
 // loadNextEpisode loads the specific next episode for an anime
-func (m *AnimeListModel) loadNextEpisode(nextEpNumber int) tea.Cmd {
+func (m *AnimeListModel) loadNextEpisode(anime *domain.Anime, nextEpNumber int) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		anime := m.getSelectedAnime()
-
-		eps, err := m.playerService.FindEpisodes(
-			ctx,
-			anime.ID,
-			&anime.Title,
-			anime.Synonyms,
-		)
+		eps, err := m.playerService.FindEpisodes(ctx, anime)
 
 		if err != nil {
 			log.Error("Failed to get episodes", "error", err)
@@ -186,6 +394,15 @@ func (m *AnimeListModel) loadNextEpisode(nextEpNumber int) tea.Cmd {
 			}
 		}
 
+		// Skip past filler to the next episode that isn't, if asked to
+		if m.config.Player.Filler == "skip" {
+			episodes := m.fillerEpisodes(ctx, anime)
+			for episodes.Kind(nextEpNumber) == filler.Filler && nextEpNumber < anime.GetLatestAiredEpisode() {
+				log.Info("Skipping filler episode", "anime_id", anime.ID, "episode", nextEpNumber)
+				nextEpNumber++
+			}
+		}
+
 		// Find the specific episode we want
 		var selectedEp *player.AllAnimeEpisodeInfo
 		for i, ep := range eps.Episodes {
@@ -218,13 +435,27 @@ func (m *AnimeListModel) loadNextEpisode(nextEpNumber int) tea.Cmd {
 	}
 }
 
+// playbackTimeout is how long finding a working stream and starting playback may take altogether
+func (m *AnimeListModel) playbackTimeout() time.Duration {
+	return player.TimeoutsFromConfig(m.config.Player.Timeouts).Overall
+}
+
 // playEpisode attempts to play the given episode.  Use nil `anime` to skip automatic progress updates
 func (m *AnimeListModel) playEpisode(episode player.AllAnimeEpisodeInfo, anime *domain.Anime) tea.Cmd {
 	return func() tea.Msg {
 		// Create a context with timeout for the entire operation
-		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		ctx, cancel := context.WithTimeout(context.Background(), m.playbackTimeout())
 		defer cancel() // This ensures the main context is always canceled
 
+		// A downloaded copy of the episode is played in place of streaming it
+		if path, ok := m.localEpisodeFile(episode, anime); ok {
+			log.Info("Playing episode from local file", "path", path,
+				"overall_epNum", episode.OverallEpisodeNumber)
+			m.loadingMsg = fmt.Sprintf("Launching media player for %s episode %d from %s...",
+				episode.PreferredTitle, episode.OverallEpisodeNumber, filepath.Base(path))
+			return m.launchPlayback(ctx, episode, anime, path)
+		}
+
 		// Set loading state for source fetching
 		log.Info("Fetching sources for episode",
 			"title", episode.AllAnimeName,
@@ -239,156 +470,442 @@ func (m *AnimeListModel) playEpisode(episode player.AllAnimeEpisodeInfo, anime *
 				Type:    PlaybackEventError,
 				Error:   err,
 				Episode: episode,
+				Anime:   anime,
 			}
 		}
 
-		// Try to get a working stream URL from each source until one works
-		var streamURL string
-		var successSource player.EpisodeSource
-
-		for _, source := range sources.Sources {
-			log.Info("Attempting to get stream URL",
-				"source_name", source.SourceName,
-				"priority", source.Priority)
-
-			url, err := m.playerService.GetStreamURL(ctx, source)
-			if err != nil {
-				log.Warn("Failed to get stream URL from source",
-					"source_name", source.SourceName,
-					"error", err)
-				continue // Try the next source
-			}
-
-			// Success!
-			streamURL = url
-			successSource = source
-			break
-		}
-
-		if streamURL == "" {
+		// Resolve the sources a few at a time, playing whichever works first
+		probed, err := m.playerService.FastestStream(ctx, sources.Sources)
+		if err != nil {
+			log.Error("Failed to get a playable stream", "error", err)
 			return PlaybackMsg{
 				Type:    PlaybackEventError,
-				Error:   fmt.Errorf("failed to get playable URL from any source"),
+				Error:   err,
 				Episode: episode,
+				Anime:   anime,
 			}
 		}
 
-		// Log the URL that would be used to play the episode
 		log.Info("Found playable stream URL",
-			"source_name", successSource.SourceName)
+			"source_name", probed.Source.SourceName)
 
 		// Update loading message to indicate we're starting the player
-		m.loadingMsg = fmt.Sprintf("Launching media player for %s episode %s...",
-			episode.AllAnimeName, episode.AllAnimeEpisodeNumber)
+		m.loadingMsg = fmt.Sprintf("Launching media player for %s episode %s%s...",
+			episode.AllAnimeName, episode.AllAnimeEpisodeNumber, translationNote(episode))
 
-		// Create a new context for the playback monitoring that's independent of this function
-		playbackCtx, playbackCancel := context.WithCancel(context.Background())
+		return m.launchPlayback(ctx, episode, anime, probed.Stream.URL)
+	}
+}
 
-		// Launch the player with the stream URL and get the event channel
-		eventCh, err := m.playerService.LaunchPlayer(playbackCtx, streamURL, episode)
-		if err != nil {
-			playbackCancel() // Clean up the playback context if launch fails
-			log.Error("Failed to launch media player", "error", err)
+// fillerEpisodes looks up the anime's filler and recap episodes.  Returns nil if filler lookups are turned off, the
+// anime has no MyAnimeList entry to look them up by, or they couldn't be fetched, as they are only a nice to have.
+func (m *AnimeListModel) fillerEpisodes(ctx context.Context, anime *domain.Anime) filler.Episodes {
+	if m.config.Player.Filler == "off" || anime.IDMal == 0 {
+		return nil
+	}
+	episodes, err := m.filler.Episodes(ctx, anime.IDMal)
+	if err != nil {
+		log.Warn("Failed to look up filler episodes", "anime_id", anime.ID, "mal_id", anime.IDMal, "error", err)
+		return nil
+	}
+	return episodes
+}
+
+// localEpisodeFile looks for the episode in the configured folders of downloaded episodes, matching files by any of
+// the anime's titles
+func (m *AnimeListModel) localEpisodeFile(episode player.AllAnimeEpisodeInfo, anime *domain.Anime) (string, bool) {
+	if anime == nil || len(m.config.Player.LocalDirs) == 0 {
+		return "", false
+	}
+	dirs := make([]string, 0, len(m.config.Player.LocalDirs))
+	for _, dir := range m.config.Player.LocalDirs {
+		dirs = append(dirs, expandHome(dir))
+	}
+	names := append([]string{anime.Title.Romaji, anime.Title.English, anime.Title.Native}, anime.Synonyms...)
+
+	file, ok := localfiles.FindEpisode(dirs, names, episode.OverallEpisodeNumber)
+	return file.Path, ok
+}
+
+// playStream plays the episode from a stream URL that has already been fetched, e.g. from the source the user chose.
+// Use nil `anime` to skip automatic progress updates
+func (m *AnimeListModel) playStream(episode player.AllAnimeEpisodeInfo, anime *domain.Anime, streamURL string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), m.playbackTimeout())
+		defer cancel()
+
+		return m.launchPlayback(ctx, episode, anime, streamURL)
+	}
+}
+
+// runBeforePlaybackHook runs the before_playback hook, if one is configured, returning an error wrapping
+// hooks.ErrAborted if it stopped playback
+func (m *AnimeListModel) runBeforePlaybackHook(ctx context.Context, episode player.AllAnimeEpisodeInfo,
+	anime *domain.Anime, streamURL string) error {
+	command := m.config.Hooks.BeforePlayback
+	if command == "" {
+		return nil
+	}
+	m.loadingMsg = "Running the before_playback hook..."
+
+	event := hooks.Event{
+		Hook:      hooks.BeforePlayback,
+		AnimeID:   episode.AniListID,
+		Title:     episode.PreferredTitle,
+		Episode:   episode.OverallEpisodeNumber,
+		StreamURL: streamURL,
+	}
+	if anime != nil {
+		event.AnimeID = anime.ID
+		event.Title = anime.Title.Preferred
+		event.TotalEpisodes = anime.Episodes
+		if anime.UserData != nil {
+			event.Progress = anime.UserData.Progress
+		}
+	}
+	return hooks.RunBefore(ctx, command, event)
+}
+
+// launchPlayback launches the player for the stream and waits for playback to start, monitoring it from then on in
+// the background
+func (m *AnimeListModel) launchPlayback(ctx context.Context, episode player.AllAnimeEpisodeInfo, anime *domain.Anime,
+	streamURL string) tea.Msg {
+	if err := m.runBeforePlaybackHook(ctx, episode, anime, streamURL); err != nil {
+		return PlaybackMsg{
+			Type:    PlaybackEventError,
+			Error:   err,
+			Episode: episode,
+			Anime:   anime,
+		}
+	}
+
+	// Create a new context for the playback monitoring that's independent of this function
+	playbackCtx, playbackCancel := context.WithCancel(context.Background())
+
+	// Launch the player with the stream URL and get the event channel
+	eventCh, err := m.playerService.LaunchPlayer(playbackCtx, streamURL, episode, anime != nil)
+	if err != nil {
+		playbackCancel() // Clean up the playback context if launch fails
+		log.Error("Failed to launch media player", "error", err)
+		return PlaybackMsg{
+			Type:    PlaybackEventError,
+			Error:   fmt.Errorf("failed to launch player: %w", err),
+			Episode: episode,
+			Anime:   anime,
+		}
+	}
+
+	// Update loading message to indicate we're waiting for playback to start
+	m.loadingMsg = fmt.Sprintf("Waiting for playback to start for episode %d of %s%s...",
+		episode.OverallEpisodeNumber, episode.PreferredTitle, translationNote(episode))
+
+	// Wait for the first event (should be playback started or an error)
+	select {
+	case <-ctx.Done():
+		playbackCancel() // Clean up the playback context on timeout
+		return PlaybackMsg{
+			Type:    PlaybackEventError,
+			Error:   fmt.Errorf("timeout waiting for playback to start"),
+			Episode: episode,
+			Anime:   anime,
+		}
+	case event, ok := <-eventCh:
+		if !ok {
+			playbackCancel() // Clean up the playback context on channel close
 			return PlaybackMsg{
 				Type:    PlaybackEventError,
-				Error:   fmt.Errorf("failed to launch player: %w", err),
+				Error:   fmt.Errorf("player event channel closed unexpectedly"),
 				Episode: episode,
+				Anime:   anime,
 			}
 		}
 
-		// Update loading message to indicate we're waiting for playback to start
-		m.loadingMsg = fmt.Sprintf("Waiting for playback to start for episode %d of %s...",
-			episode.OverallEpisodeNumber, episode.PreferredTitle)
+		// Handle the event based on its type
+		switch event.Type {
+		case player.PlaybackStarted:
+			log.Info("MPV playback started successfully")
 
-		// Wait for the first event (should be playback started or an error)
-		select {
-		case <-ctx.Done():
-			playbackCancel() // Clean up the playback context on timeout
+			// Start another goroutine to continue monitoring playback progress
+			go m.monitorPlayback(eventCh, playbackCancel, episode, anime)
+
+			// Return a message indicating playback has started
+			return PlaybackMsg{
+				Type:    PlaybackEventStarted,
+				Episode: episode,
+			}
+
+		case player.PlaybackError:
+			playbackCancel() // Clean up the playback context on error
+			log.Error("MPV failed to start playback", "error", event.Error)
 			return PlaybackMsg{
 				Type:    PlaybackEventError,
-				Error:   fmt.Errorf("timeout waiting for playback to start"),
+				Error:   event.Error,
 				Episode: episode,
+				Anime:   anime,
 			}
-		case event, ok := <-eventCh:
-			if !ok {
-				playbackCancel() // Clean up the playback context on channel close
-				return PlaybackMsg{
-					Type:    PlaybackEventError,
-					Error:   fmt.Errorf("player event channel closed unexpectedly"),
-					Episode: episode,
+		default:
+			// TODO:  I don't think I want this.  Let's just report an error playback message, but indicate it _may_ have worked, but monitoring will be unavailable.
+			log.Warn("Unexpected initial event from MPV", "event_type", event.Type)
+			// Treat as started anyway to be safe
+			go func() {
+				defer playbackCancel() // Ensure context is canceled when goroutine exits
+
+				for event := range eventCh {
+					switch event.Type {
+					case player.PlaybackEnded:
+						log.Info("MPV playback ended")
+						return
+					case player.PlaybackError:
+						log.Error("MPV playback error", "error", event.Error)
+						return
+					}
 				}
+			}()
+			return PlaybackMsg{
+				Type:    PlaybackEventStarted,
+				Episode: episode,
 			}
+		}
+	}
+}
 
-			// Handle the event based on its type
-			switch event.Type {
-			case player.PlaybackStarted:
-				log.Info("MPV playback started successfully")
-
-				// Start another goroutine to continue monitoring playback progress
-				go func() {
-					defer playbackCancel() // Ensure context is canceled when goroutine exits
-
-					for event := range eventCh {
-						switch event.Type {
-						case player.PlaybackEnded:
-							log.Info("MPV playback ended", "progress", event.Progress)
-							// Only send this event for "play next episode" scenario.  This is super fragile and I hate it
-							// but requires a full refactor of the playback flow to be better aligned with bubbletea best
-							// practices.  So it will come much later and this is just the pragmatic approach
-							if anime != nil {
-								m.playbackCompletionCh <- PlaybackCompletedMsg{
-									AnimeID:       anime.ID,
-									EpisodeNumber: episode.OverallEpisodeNumber,
-									Progress:      event.Progress,
-								}
-							}
-							return
-						case player.PlaybackError:
-							log.Error("MPV playback error", "error", event.Error)
-							return
-						}
-					}
-					log.Debug("MPV event channel closed, stopping monitoring")
-				}()
+// reattachPlayback picks back up an episode left playing when Hisame was last closed, so its progress is still tracked
+// and finishing it still updates the list.  If the player has exited since, the progress update that was missed is
+// applied from the playback journal instead.
+func (m *AnimeListModel) reattachPlayback() tea.Cmd {
+	return func() tea.Msg {
+		playbackCtx, playbackCancel := context.WithCancel(context.Background())
+		session, eventCh, err := m.playerService.ReattachPlayback(playbackCtx)
+		if err != nil {
+			playbackCancel()
+			return HandledMsg{Message: "playback:nothing_to_reattach"}
+		}
 
-				// Return a message indicating playback has started
-				return PlaybackMsg{
-					Type:    PlaybackEventStarted,
-					Episode: episode,
-				}
+		// Like a freshly launched player, the first event is playback starting
+		event, ok := <-eventCh
+		if !ok || event.Type != player.PlaybackStarted {
+			playbackCancel()
+			return HandledMsg{Message: "playback:reattach_failed"}
+		}
+
+		// An episode played without tracking progress is still followed, but finishing it leaves the list alone
+		episode := session.Episode
+		var anime *domain.Anime
+		if session.Tracked {
+			anime = m.animeService.GetAnimeByID(episode.AniListID)
+		}
+		go m.monitorPlayback(eventCh, playbackCancel, episode, anime)
+		return PlaybackMsg{
+			Type:       PlaybackEventStarted,
+			Episode:    episode,
+			Anime:      anime,
+			Reattached: true,
+			Missed:     event.Missed,
+		}
+	}
+}
 
-			case player.PlaybackError:
-				playbackCancel() // Clean up the playback context on error
-				log.Error("MPV failed to start playback", "error", event.Error)
-				return PlaybackMsg{
-					Type:    PlaybackEventError,
-					Error:   event.Error,
-					Episode: episode,
+// monitorPlayback follows playback after it has started until the player exits, recording where it stopped and
+// reporting the episode's completion.  playbackCancel is called once the end of playback has been handled.
+func (m *AnimeListModel) monitorPlayback(eventCh <-chan player.PlaybackEvent, playbackCancel context.CancelFunc,
+	episode player.AllAnimeEpisodeInfo, anime *domain.Anime) {
+	defer playbackCancel() // Ensure context is canceled when goroutine exits
+
+	defer terminal.ClearProgress()
+
+	mpris.Publish(mprisTrack(episode, anime, m.config.UI.SpoilerSafe), m.playerService)
+	defer mpris.Clear()
+
+	for event := range eventCh {
+		switch event.Type {
+		case player.PlaybackProgress:
+			terminal.SetProgress(int(event.Progress))
+			mpris.SetPosition(event.Position)
+			m.playerService.RecordPlaybackProgress(episode, event.Position, event.Progress)
+		case player.PlaybackPaused, player.PlaybackResumed:
+			mpris.SetPaused(event.Type == player.PlaybackPaused)
+			mpris.SetPosition(event.Position)
+		case player.PlaybackEnded:
+			progress, estimated := event.Progress, false
+			if event.Elapsed > 0 {
+				// The player has no IPC, so estimate how much was watched from how long it ran
+				duration := 0
+				if anime != nil {
+					duration = anime.Duration
 				}
-			default:
-				// TODO:  I don't think I want this.  Let's just report an error playback message, but indicate it _may_ have worked, but monitoring will be unavailable.
-				log.Warn("Unexpected initial event from MPV", "event_type", event.Type)
-				// Treat as started anyway to be safe
-				go func() {
-					defer playbackCancel() // Ensure context is canceled when goroutine exits
-
-					for event := range eventCh {
-						switch event.Type {
-						case player.PlaybackEnded:
-							log.Info("MPV playback ended")
-							return
-						case player.PlaybackError:
-							log.Error("MPV playback error", "error", event.Error)
-							return
-						}
-					}
-				}()
-				return PlaybackMsg{
-					Type:    PlaybackEventStarted,
-					Episode: episode,
+				progress, estimated = player.EstimateProgress(event.Elapsed, duration), true
+			}
+			log.Info("MPV playback ended", "progress", progress, "estimated", estimated)
+			m.playerService.RecordPlaybackStop(episode, event.Position, progress)
+			// Only send this event for "play next episode" scenario.  This is super fragile and I hate it
+			// but requires a full refactor of the playback flow to be better aligned with bubbletea best
+			// practices.  So it will come much later and this is just the pragmatic approach
+			if anime != nil {
+				m.playbackCompletionCh <- PlaybackCompletedMsg{
+					AnimeID:       anime.ID,
+					EpisodeNumber: episode.OverallEpisodeNumber,
+					Progress:      progress,
+					Estimated:     estimated,
+					Missed:        event.Missed,
 				}
 			}
+			return
+		case player.PlaybackError:
+			log.Error("MPV playback error", "error", event.Error)
+			return
+		}
+	}
+	log.Debug("MPV event channel closed, stopping monitoring")
+}
+
+// loadSourceDetails fetches the sources for an episode and checks what each one plays, so the user can choose one
+func (m *AnimeListModel) loadSourceDetails(episode player.AllAnimeEpisodeInfo) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+		defer cancel()
+
+		sources, err := m.playerService.GetEpisodeSources(ctx, episode)
+		if err != nil {
+			log.Error("Failed to get episode sources", "error", err)
+			return PlaybackMsg{
+				Type:    PlaybackEventError,
+				Error:   err,
+				Episode: episode,
+			}
+		}
+
+		return PlaybackMsg{
+			Type:    PlaybackEventSourcesLoaded,
+			Episode: episode,
+			Sources: sources,
+			Details: m.playerService.DescribeSources(ctx, sources),
+		}
+	}
+}
+
+// showSourceMenu lets the user choose the source to play the episode from, showing what each one plays.  Sources that
+// couldn't be fetched are listed but can't be chosen.
+func (m *AnimeListModel) showSourceMenu(episode player.AllAnimeEpisodeInfo, details []player.SourceDetails) tea.Cmd {
+	var menuItems []MenuItem
+	for _, source := range details {
+		text := fmt.Sprintf("%-10s %s", source.Source.SourceName, source.Summary())
+		if source.Error != nil {
+			menuItems = append(menuItems, MenuItem{Text: text, IsSeparator: true})
+			continue
 		}
+		menuItems = append(menuItems, MenuItem{
+			Text: text,
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg:   PlaySourceMsg{Episode: episode, Source: source},
+				}
+			},
+		})
+	}
+	if playable := playableSources(details); len(playable) > 0 {
+		menuItems = append(menuItems, MenuItem{
+			Text: "Copy a stream URL to the clipboard",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg:   ShowMenuMsg{Menu: copySourceMenu(episode, playable)},
+				}
+			},
+		})
+	}
+	if anime := m.getSelectedAnime(); anime != nil {
+		menuItems = append(menuItems, MenuItem{
+			Text: "Search Nyaa for torrents",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg:   FindTorrentsMsg{AnimeID: anime.ID, Episode: episode.OverallEpisodeNumber},
+				}
+			},
+		})
+	}
+	menuItems = append(menuItems, MenuItem{
+		Text: "Back",
+		Command: func() tea.Msg {
+			return MenuSelectionMsg{CloseMenu: true}
+		},
+	})
+
+	title := fmt.Sprintf("Sources - %s episode %s", episode.AllAnimeName, episode.AllAnimeEpisodeNumber)
+	menuModel := NewMenuModel(title, menuItems)
+	return func() tea.Msg {
+		return ShowMenuMsg{Menu: menuModel}
+	}
+}
+
+// playableSources returns the sources whose stream could be fetched
+func playableSources(details []player.SourceDetails) []player.SourceDetails {
+	var playable []player.SourceDetails
+	for _, source := range details {
+		if source.Error == nil {
+			playable = append(playable, source)
+		}
+	}
+	return playable
+}
+
+// copySourceMenu lets the user choose the source whose stream URL is copied to the clipboard
+func copySourceMenu(episode player.AllAnimeEpisodeInfo, sources []player.SourceDetails) *MenuModel {
+	var menuItems []MenuItem
+	for _, source := range sources {
+		menuItems = append(menuItems, MenuItem{
+			Text: fmt.Sprintf("%-10s %s", source.Source.SourceName, source.Summary()),
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg:   CopyStreamURLMsg{Episode: episode, Source: &source},
+				}
+			},
+		})
+	}
+	menuItems = append(menuItems, MenuItem{
+		Text: "Back",
+		Command: func() tea.Msg {
+			return MenuSelectionMsg{CloseMenu: true}
+		},
+	})
+
+	return NewMenuModel(fmt.Sprintf("Copy stream URL - %s episode %s", episode.AllAnimeName,
+		episode.AllAnimeEpisodeNumber), menuItems)
+}
+
+// copyStreamURL copies the stream URL of the episode to the clipboard, from the source given, or the first working
+// source if nil
+func (m *AnimeListModel) copyStreamURL(episode player.AllAnimeEpisodeInfo, source *player.SourceDetails) tea.Cmd {
+	return func() tea.Msg {
+		if source != nil {
+			return copiedStreamURL(episode, source.Source.SourceName, source.Stream)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), m.playbackTimeout())
+		defer cancel()
+
+		sources, err := m.playerService.GetEpisodeSources(ctx, episode)
+		if err != nil {
+			return StreamURLCopiedMsg{Episode: episode, Error: err}
+		}
+		probed, err := m.playerService.FastestStream(ctx, sources.Sources)
+		if err != nil {
+			return StreamURLCopiedMsg{Episode: episode, Error: err}
+		}
+		return copiedStreamURL(episode, probed.Source.SourceName, probed.Stream)
+	}
+}
+
+// copiedStreamURL puts the stream's URL on the clipboard, describing what was copied
+func copiedStreamURL(episode player.AllAnimeEpisodeInfo, sourceName string, stream player.StreamInfo) StreamURLCopiedMsg {
+	log.Info("Copying stream URL", "episode", episode.OverallEpisodeNumber, "source_name", sourceName)
+	return StreamURLCopiedMsg{
+		Episode:    episode,
+		SourceName: sourceName,
+		Referer:    stream.Referer,
+		Error:      terminal.CopyToClipboard(stream.URL),
 	}
 }
 
@@ -398,3 +915,243 @@ func (m *AnimeListModel) listenForPlaybackCompletion() tea.Cmd {
 		return event
 	}
 }
+
+// loadMatchedShows finds the AllAnime shows the anime is matched to
+func (m *AnimeListModel) loadMatchedShows(anime *domain.Anime) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		result, err := m.playerService.FindEpisodes(ctx, anime)
+		if err != nil {
+			return MatchedShowsMsg{Anime: anime, Error: err}
+		}
+		return MatchedShowsMsg{Anime: anime, Shows: result.RawShows}
+	}
+}
+
+// showMatchedShowsMenu lists the AllAnime shows the anime is matched to, so the user can say one of them isn't it
+func (m *AnimeListModel) showMatchedShowsMenu(anime *domain.Anime, shows []player.AllAnimeShow) tea.Cmd {
+	var menuItems []MenuItem
+	for _, show := range shows {
+		year := "?"
+		if show.AiredStart.Year > 0 {
+			year = strconv.Itoa(show.AiredStart.Year)
+		}
+		episodes := len(show.AvailableEpisodesDetail.Sub)
+		if dub := len(show.AvailableEpisodesDetail.Dub); dub > episodes {
+			episodes = dub
+		}
+		menuItems = append(menuItems, MenuItem{
+			Text: fmt.Sprintf("Not this anime: %s (%s, %d episodes)", show.Name, year, episodes),
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg:   BlacklistShowMsg{AnimeID: anime.ID, ShowID: show.ID, ShowName: show.Name},
+				}
+			},
+		})
+	}
+	menuItems = append(menuItems, MenuItem{
+		Text: "Back",
+		Command: func() tea.Msg {
+			return MenuSelectionMsg{CloseMenu: true}
+		},
+	})
+
+	menuModel := NewMenuModel("AllAnime matches - "+anime.Title.Preferred, menuItems)
+	return func() tea.Msg {
+		return ShowMenuMsg{Menu: menuModel}
+	}
+}
+
+// showPresetMenu lets the user pick the player preset used for the anime, or go back to the configured one
+func (m *AnimeListModel) showPresetMenu(anime *domain.Anime) tea.Cmd {
+	current := m.playerService.AnimePreset(anime.ID)
+
+	label := func(text string, selected bool) string {
+		if selected {
+			return "✓ " + text
+		}
+		return "  " + text
+	}
+	choose := func(name string) tea.Cmd {
+		return func() tea.Msg {
+			return MenuSelectionMsg{
+				CloseMenu: true,
+				NextMsg:   SetAnimePresetMsg{AnimeID: anime.ID, Preset: name},
+			}
+		}
+	}
+
+	configured := "none"
+	if m.config.Player.Preset != "" {
+		configured = m.config.Player.Preset
+	}
+	menuItems := []MenuItem{
+		{
+			Text:    label(fmt.Sprintf("Use the configured preset (%s)", configured), current == ""),
+			Command: choose(""),
+		},
+	}
+	for _, preset := range player.Presets(m.config) {
+		menuItems = append(menuItems, MenuItem{
+			Text:    label(fmt.Sprintf("%s - %s", preset.Name, preset.Description), current == preset.Name),
+			Command: choose(preset.Name),
+		})
+	}
+	menuItems = append(menuItems, MenuItem{
+		Text: "  Back",
+		Command: func() tea.Msg {
+			return MenuSelectionMsg{CloseMenu: true}
+		},
+	})
+
+	menuModel := NewMenuModel("Player preset - "+anime.Title.Preferred, menuItems)
+	return func() tea.Msg {
+		return ShowMenuMsg{Menu: menuModel}
+	}
+}
+
+// discoverCastDevices looks for Chromecasts on the local network behind a loading screen
+func (m *AnimeListModel) discoverCastDevices() tea.Cmd {
+	return func() tea.Msg {
+		return LoadingMsg{
+			Type:    LoadingStart,
+			Message: "Looking for Chromecasts...",
+			Operation: func() tea.Msg {
+				devices, err := player.DiscoverCastDevices(context.Background())
+				return CastDevicesFoundMsg{Devices: devices, Err: err}
+			},
+		}
+	}
+}
+
+// showCastDeviceMenu offers the Chromecasts found to cast episodes to, or to play them on this computer
+func (m *AnimeListModel) showCastDeviceMenu(devices []cast.Device) tea.Cmd {
+	current := m.config.Player.CastDevice
+
+	label := func(text string, selected bool) string {
+		if selected {
+			return "✓ " + text
+		}
+		return "  " + text
+	}
+	choose := func(name string) tea.Cmd {
+		return func() tea.Msg {
+			return MenuSelectionMsg{
+				CloseMenu: true,
+				NextMsg:   SetCastDeviceMsg{Name: name},
+			}
+		}
+	}
+
+	menuItems := []MenuItem{
+		{
+			Text:    label("This computer", current == ""),
+			Command: choose(""),
+		},
+	}
+	for _, device := range devices {
+		text := device.Name
+		if device.Model != "" {
+			text += " (" + device.Model + ")"
+		}
+		menuItems = append(menuItems, MenuItem{
+			Text:    label(text, strings.EqualFold(current, device.Name)),
+			Command: choose(device.Name),
+		})
+	}
+	if len(devices) == 0 {
+		menuItems = append(menuItems, MenuItem{Text: "No Chromecasts found", IsSeparator: true})
+	}
+	menuItems = append(menuItems, MenuItem{
+		Text: "  Back",
+		Command: func() tea.Msg {
+			return MenuSelectionMsg{CloseMenu: true}
+		},
+	})
+
+	menuModel := NewMenuModel("Cast to", menuItems)
+	return func() tea.Msg {
+		return ShowMenuMsg{Menu: menuModel}
+	}
+}
+
+// translationNote labels an episode found in the other translation type because there were none in the preferred one,
+// e.g. " (dub, no sub episodes)".  Episodes in the preferred type aren't labelled.
+func translationNote(episode player.AllAnimeEpisodeInfo) string {
+	if !episode.TranslationFallback {
+		return ""
+	}
+	preferred := "sub"
+	if episode.TranslationType == "sub" {
+		preferred = "dub"
+	}
+	return fmt.Sprintf(" (%s, no %s episodes)", episode.TranslationType, preferred)
+}
+
+// showTranslationMenu lets the user pick whether the anime is watched subbed or dubbed, or go back to the configured
+// translation type
+func (m *AnimeListModel) showTranslationMenu(anime *domain.Anime) tea.Cmd {
+	current := m.playerService.AnimeTranslationType(anime.ID)
+
+	label := func(text string, selected bool) string {
+		if selected {
+			return "✓ " + text
+		}
+		return "  " + text
+	}
+	choose := func(translationType string) tea.Cmd {
+		return func() tea.Msg {
+			return MenuSelectionMsg{
+				CloseMenu: true,
+				NextMsg:   SetAnimeTranslationMsg{AnimeID: anime.ID, TranslationType: translationType},
+			}
+		}
+	}
+
+	menuItems := []MenuItem{
+		{
+			Text:    label(fmt.Sprintf("Use the configured type (%s)", m.config.Player.TranslationType), current == ""),
+			Command: choose(""),
+		},
+	}
+	for _, translationType := range player.TranslationTypes {
+		menuItems = append(menuItems, MenuItem{
+			Text:    label(translationType, current == translationType),
+			Command: choose(translationType),
+		})
+	}
+	menuItems = append(menuItems, MenuItem{
+		Text: "  Back",
+		Command: func() tea.Msg {
+			return MenuSelectionMsg{CloseMenu: true}
+		},
+	})
+
+	menuModel := NewMenuModel("Sub or dub - "+anime.Title.Preferred, menuItems)
+	return func() tea.Msg {
+		return ShowMenuMsg{Menu: menuModel}
+	}
+}
+
+// mprisTrack describes the episode being played for desktop media controls.  anime is nil when the episode is played
+// without tracking progress, leaving out what only the list knows, such as the cover image.  The episode's thumbnail
+// is shown in place of the cover unless spoilerSafe is set, as a still from the episode can give away what happens.
+func mprisTrack(episode player.AllAnimeEpisodeInfo, anime *domain.Anime, spoilerSafe bool) mpris.Track {
+	track := mpris.Track{
+		AnimeID:       episode.AniListID,
+		AnimeTitle:    episode.PreferredTitle,
+		EpisodeNumber: episode.OverallEpisodeNumber,
+	}
+	if anime != nil {
+		track.AnimeTitle = anime.Title.Preferred
+		track.ArtURL = anime.CoverImage
+		track.Length = anime.Duration * 60
+	}
+	if episode.Thumbnail != "" && !spoilerSafe {
+		track.ArtURL = episode.Thumbnail
+	}
+	return track
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/player"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMPRISTrackThumbnail(t *testing.T) {
+	episode := player.AllAnimeEpisodeInfo{
+		AniListID:            154587,
+		PreferredTitle:       "Frieren",
+		OverallEpisodeNumber: 4,
+		Thumbnail:            "https://example.com/ep4.jpg",
+	}
+	anime := &domain.Anime{ID: 154587, CoverImage: "https://example.com/cover.jpg", Duration: 24}
+
+	assert.Equal(t, "https://example.com/ep4.jpg", mprisTrack(episode, anime, false).ArtURL)
+	assert.Equal(t, "https://example.com/cover.jpg", mprisTrack(episode, anime, true).ArtURL,
+		"the episode's thumbnail could spoil it")
+	assert.Empty(t, mprisTrack(episode, nil, true).ArtURL)
+}
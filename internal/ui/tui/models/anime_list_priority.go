@@ -0,0 +1,109 @@
+package models
+
+// anime_list_priority.go lets the user set their AniList priority for an entry and sort the list by it, which is
+// mostly useful for ordering the Planning list by what to watch next.
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxMenuPriority is the highest priority offered in the priority menu
+const maxMenuPriority = 5
+
+// sortByPriority orders the list by the user's priority, highest first.  Entries with the same priority keep their
+// order.
+func sortByPriority(list []*domain.Anime) {
+	priority := func(anime *domain.Anime) int {
+		if anime.UserData == nil {
+			return 0
+		}
+		return anime.UserData.Priority
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return priority(list[i]) > priority(list[j])
+	})
+}
+
+// showPriorityMenu lets the user pick a priority for the anime, or clear it
+func (m *AnimeListModel) showPriorityMenu(anime *domain.Anime) tea.Cmd {
+	if anime == nil || anime.UserData == nil {
+		return Handled("choose_priority:none_selected")
+	}
+	current := anime.UserData.Priority
+
+	label := func(text string, selected bool) string {
+		if selected {
+			return "✓ " + text
+		}
+		return "  " + text
+	}
+	choose := func(priority int) tea.Cmd {
+		return func() tea.Msg {
+			return MenuSelectionMsg{
+				CloseMenu: true,
+				NextMsg:   SetPriorityMsg{AnimeID: anime.ID, Priority: priority},
+			}
+		}
+	}
+
+	menuItems := []MenuItem{
+		{
+			Text:    label("No priority", current == 0),
+			Command: choose(0),
+		},
+	}
+	for priority := maxMenuPriority; priority > 0; priority-- {
+		text := fmt.Sprintf("%d", priority)
+		if priority == maxMenuPriority {
+			text += " (watch next)"
+		}
+		menuItems = append(menuItems, MenuItem{
+			Text:    label(text, current == priority),
+			Command: choose(priority),
+		})
+	}
+	if current > maxMenuPriority {
+		// Set higher on the website, so keep it selectable
+		menuItems = append(menuItems, MenuItem{
+			Text:    label(fmt.Sprintf("%d", current), true),
+			Command: choose(current),
+		})
+	}
+	menuItems = append(menuItems, MenuItem{
+		Text: "  Back",
+		Command: func() tea.Msg {
+			return MenuSelectionMsg{CloseMenu: true}
+		},
+	})
+
+	menuModel := NewMenuModel("Priority - "+anime.Title.Preferred, menuItems)
+	return func() tea.Msg {
+		return ShowMenuMsg{Menu: menuModel}
+	}
+}
+
+// setPriority saves the anime's priority to AniList in the background
+func (m *AnimeListModel) setPriority(anime *domain.Anime, priority int) tea.Cmd {
+	if anime == nil {
+		return Handled("set_priority:none_selected")
+	}
+
+	animeID, title := anime.ID, anime.Title.Preferred
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		err := m.animeService.SetPriority(ctx, animeID, priority)
+		if err != nil {
+			log.Error("Failed to set anime priority", "id", animeID, "priority", priority, "error", err)
+		}
+		return PriorityChangedMsg{Title: title, Priority: priority, Error: err}
+	}
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortByPriority(t *testing.T) {
+	var list []*domain.Anime
+	for id, priority := range []int{0, 2, 0, 5} {
+		list = append(list, &domain.Anime{ID: id + 1, UserData: &domain.UserAnimeData{Priority: priority}})
+	}
+
+	sortByPriority(list)
+	var ids []int
+	for _, anime := range list {
+		ids = append(ids, anime.ID)
+	}
+	assert.Equal(t, []int{4, 2, 1, 3}, ids, "entries without a priority should keep their order")
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnfinishedPlaybackAsksWhenConfigured(t *testing.T) {
+	m := &AnimeListModel{
+		config:   &config.Config{Player: config.PlayerConfig{WatchedFraction: 0.9}},
+		allAnime: []*domain.Anime{{ID: 1, UserData: &domain.UserAnimeData{Progress: 2}}},
+	}
+	completed := PlaybackCompletedMsg{AnimeID: 1, EpisodeNumber: 3, Progress: 80}
+
+	_, cmd := m.Update(completed)
+	assert.Nil(t, cmd, "progress below the watched fraction should be left alone")
+
+	m.config.Player.ConfirmUnfinished = "on"
+	_, cmd = m.Update(completed)
+	require.NotNil(t, cmd)
+	menu, ok := cmd().(ShowMenuMsg)
+	require.True(t, ok, "the user should be asked whether to mark the episode watched")
+	assert.Equal(t, "Mark episode 3 as watched?", menu.Menu.Items[0].Text)
+}
+
+func TestWatchedThreshold(t *testing.T) {
+	m := &AnimeListModel{config: &config.Config{Player: config.PlayerConfig{WatchedFraction: 0.9}}}
+	assert.Equal(t, 90.0, m.watchedThreshold())
+
+	m.config.Player.WatchedFraction = 0
+	assert.Equal(t, 75.0, m.watchedThreshold(), "an unset fraction should use the default")
+}
+
+func TestExitWatchedThreshold(t *testing.T) {
+	m := &AnimeListModel{config: &config.Config{Player: config.PlayerConfig{ExitWatchedFraction: 0.5}}}
+	assert.Equal(t, 50.0, m.exitWatchedThreshold())
+
+	m.config.Player.ExitWatchedFraction = 0
+	assert.Equal(t, 75.0, m.exitWatchedThreshold(), "configs from before the setting existed should use the default")
+}
+
+func TestCompletionActivityNotOfferedInLocalOnlyMode(t *testing.T) {
+	m := &AnimeListModel{
+		config:   &config.Config{AniList: config.AniListConfig{CompletionActivity: "always"}},
+		allAnime: []*domain.Anime{{ID: 1, Title: domain.AnimeTitle{Preferred: "Show"}}},
+	}
+	assert.NotNil(t, m.handleCompletionActivity(1))
+
+	m.config.Auth.LocalOnly = true
+	assert.Nil(t, m.handleCompletionActivity(1), "there's no account to post from")
+
+	m.config.AniList.CompletionActivity = "ask"
+	assert.Nil(t, m.handleCompletionActivity(1), "there's no account to post from")
+}
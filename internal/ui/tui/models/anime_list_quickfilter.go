@@ -0,0 +1,227 @@
+package models
+
+// anime_list_quickfilter.go implements the quick filter bar, which lets the user type a single expression such as
+// `s:watching score>8 year:2024 frieren` instead of toggling each filter individually.
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// quickFilterStatuses maps the status names accepted by `s:` to list statuses
+var quickFilterStatuses = map[string]domain.MediaStatus{
+	"watching":  domain.StatusCurrent,
+	"current":   domain.StatusCurrent,
+	"planning":  domain.StatusPlanning,
+	"completed": domain.StatusCompleted,
+	"dropped":   domain.StatusDropped,
+	"paused":    domain.StatusPaused,
+	"onhold":    domain.StatusPaused,
+	"repeating": domain.StatusRepeating,
+}
+
+// quickFilterFormats maps the format names accepted by `format:` to AniList formats
+var quickFilterFormats = map[string]string{
+	"tv":      domain.FormatTV,
+	"movie":   domain.FormatMovie,
+	"ova":     domain.FormatOVA,
+	"ona":     domain.FormatONA,
+	"special": domain.FormatSpecial,
+}
+
+// scoreCondition is a single comparison against the user's score, e.g. score>8
+type scoreCondition struct {
+	op    string
+	value float64
+}
+
+// matches returns true if the score satisfies the condition
+func (c scoreCondition) matches(score float64) bool {
+	switch c.op {
+	case ">":
+		return score > c.value
+	case ">=":
+		return score >= c.value
+	case "<":
+		return score < c.value
+	case "<=":
+		return score <= c.value
+	default:
+		return score == c.value
+	}
+}
+
+// parseQuickFilter parses a quick filter expression into a filter set.  Supported terms are:
+//
+//	s:watching,paused   status filter (status: also accepted)
+//	score>8             score comparison using >, >=, <, <= or :
+//	year:2024           season year
+//	genre:comedy,drama  anime with any of the listed genres (g: also accepted).  Repeat the term to require several
+//	format:tv,movie     anime in any of the formats (tv, movie, ova, ona, special)
+//	tag:isekai          anime with any of the listed tags, repeatable in the same way as genre:
+//	has:new             only anime with aired but unwatched episodes
+//	is:finished         only anime that have finished airing
+//
+// Any other words are joined together and used as the title search.  An empty status list falls back to the defaults.
+func parseQuickFilter(expr string) (AnimeFilterSet, error) {
+	filters := AnimeFilterSet{quickFilter: strings.TrimSpace(expr)}
+	var searchTerms []string
+
+	for _, term := range strings.Fields(expr) {
+		lower := strings.ToLower(term)
+
+		switch {
+		case strings.HasPrefix(lower, "s:") || strings.HasPrefix(lower, "status:"):
+			_, values, _ := strings.Cut(lower, ":")
+			for _, name := range strings.Split(values, ",") {
+				status, ok := quickFilterStatuses[name]
+				if !ok {
+					return AnimeFilterSet{}, fmt.Errorf("unknown status %q", name)
+				}
+				filters.statusFilters = append(filters.statusFilters, status)
+			}
+
+		case isScoreTerm(lower):
+			condition, err := parseScoreCondition(strings.TrimPrefix(lower, "score"))
+			if err != nil {
+				return AnimeFilterSet{}, err
+			}
+			filters.scoreConditions = append(filters.scoreConditions, condition)
+
+		case strings.HasPrefix(lower, "year:"):
+			year, err := strconv.Atoi(strings.TrimPrefix(lower, "year:"))
+			if err != nil {
+				return AnimeFilterSet{}, fmt.Errorf("invalid year in %q", term)
+			}
+			filters.year = year
+
+		case strings.HasPrefix(lower, "g:") || strings.HasPrefix(lower, "genre:"):
+			_, values, _ := strings.Cut(lower, ":")
+			group, err := parseLabelGroup(values, term)
+			if err != nil {
+				return AnimeFilterSet{}, err
+			}
+			filters.genreGroups = append(filters.genreGroups, group)
+
+		case strings.HasPrefix(lower, "format:"):
+			for _, name := range strings.Split(strings.TrimPrefix(lower, "format:"), ",") {
+				format, ok := quickFilterFormats[name]
+				if !ok {
+					return AnimeFilterSet{}, fmt.Errorf("unknown format %q", name)
+				}
+				filters.formatFilters = append(filters.formatFilters, format)
+			}
+
+		case strings.HasPrefix(lower, "tag:"):
+			group, err := parseLabelGroup(strings.TrimPrefix(lower, "tag:"), term)
+			if err != nil {
+				return AnimeFilterSet{}, err
+			}
+			filters.tagGroups = append(filters.tagGroups, group)
+
+		case lower == "has:new":
+			filters.hasAvailableEpisodes = true
+
+		case lower == "is:finished":
+			filters.isFinishedAiring = true
+
+		default:
+			searchTerms = append(searchTerms, term)
+		}
+	}
+
+	if len(filters.statusFilters) == 0 {
+		filters.statusFilters = DEFAULT_STATUS_FILTERS
+	}
+	filters.searchQuery = strings.Join(searchTerms, " ")
+	return filters, nil
+}
+
+// scoreOperators are the comparisons a score filter accepts, with ">=" and "<=" first so they aren't read as ">" or "<"
+var scoreOperators = []string{">=", "<=", ">", "<", ":"}
+
+// isScoreTerm reports whether a term is a score filter, which is "score" directly followed by an operator.  Other terms
+// starting with "score", such as "score" itself or "scorer", are searched for in titles instead.
+func isScoreTerm(term string) bool {
+	rest, ok := strings.CutPrefix(term, "score")
+	if !ok {
+		return false
+	}
+	for _, op := range scoreOperators {
+		if strings.HasPrefix(rest, op) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseLabelGroup splits a comma separated list of genres or tags, rejecting empty names
+func parseLabelGroup(values, term string) ([]string, error) {
+	var group []string
+	for _, name := range strings.Split(values, ",") {
+		if name == "" {
+			return nil, fmt.Errorf("missing name in %q", term)
+		}
+		group = append(group, name)
+	}
+	return group, nil
+}
+
+// parseScoreCondition parses the operator and value following "score", e.g. ">=7.5"
+func parseScoreCondition(s string) (scoreCondition, error) {
+	for _, op := range scoreOperators {
+		if rest, ok := strings.CutPrefix(s, op); ok {
+			value, err := strconv.ParseFloat(rest, 64)
+			if err != nil {
+				return scoreCondition{}, fmt.Errorf("invalid score %q", rest)
+			}
+			return scoreCondition{op: op, value: value}, nil
+		}
+	}
+	return scoreCondition{}, fmt.Errorf("invalid score filter %q, expected e.g. score>8", "score"+s)
+}
+
+// handleQuickFilterKeyMsg handles key presses while the quick filter bar is open
+func (m *AnimeListModel) handleQuickFilterKeyMsg(msg tea.KeyMsg) tea.Cmd {
+	if !m.quickFilterMode {
+		return nil
+	}
+
+	switch kb.GetActionByKey(msg, kb.ContextSearchMode) {
+	case kb.ActionBack:
+		m.quickFilterMode = false
+		m.quickFilterErr = ""
+		m.quickFilterInput.Blur()
+		return Handled("quick_filter:cancel")
+	case kb.ActionSearchComplete:
+		filters, err := parseQuickFilter(m.quickFilterInput.Value())
+		if err != nil {
+			m.quickFilterErr = err.Error()
+			return Handled("quick_filter:invalid")
+		}
+		// The quick filter replaces the filters, but not the choice of which entries are shown at all
+		filters.hideAdult = m.filters.hideAdult
+		filters.showStatusListHidden = m.filters.showStatusListHidden
+		m.filters = filters
+		m.searchInput.SetValue(filters.searchQuery)
+		m.quickFilterMode = false
+		m.quickFilterErr = ""
+		m.quickFilterInput.Blur()
+		m.applyFilters()
+		m.cursor = 0
+		return Handled("quick_filter:apply")
+	}
+
+	var cmd tea.Cmd
+	m.quickFilterInput, cmd = m.quickFilterInput.Update(msg)
+	m.quickFilterErr = ""
+	if cmd == nil {
+		cmd = Handled("quick_filter:input")
+	}
+	return cmd
+}
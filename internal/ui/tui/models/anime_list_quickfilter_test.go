@@ -0,0 +1,90 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuickFilter(t *testing.T) {
+	filters, err := parseQuickFilter("s:watching,paused score>=8 year:2024 has:new sousou no frieren")
+	require.NoError(t, err)
+
+	assert.Equal(t, []domain.MediaStatus{domain.StatusCurrent, domain.StatusPaused}, filters.statusFilters)
+	assert.Equal(t, []scoreCondition{{op: ">=", value: 8}}, filters.scoreConditions)
+	assert.Equal(t, 2024, filters.year)
+	assert.True(t, filters.hasAvailableEpisodes)
+	assert.False(t, filters.isFinishedAiring)
+	assert.Equal(t, "sousou no frieren", filters.searchQuery)
+}
+
+func TestParseQuickFilterDefaults(t *testing.T) {
+	filters, err := parseQuickFilter("frieren")
+	require.NoError(t, err)
+
+	assert.Equal(t, DEFAULT_STATUS_FILTERS, filters.statusFilters)
+	assert.Equal(t, "frieren", filters.searchQuery)
+}
+
+func TestParseQuickFilterMatchesWholeFieldNames(t *testing.T) {
+	filters, err := parseQuickFilter("scorer score>7")
+	require.NoError(t, err)
+
+	assert.Equal(t, []scoreCondition{{op: ">", value: 7}}, filters.scoreConditions)
+	assert.Equal(t, "scorer", filters.searchQuery)
+}
+
+func TestParseQuickFilterScoreWithoutOperatorIsSearched(t *testing.T) {
+	filters, err := parseQuickFilter("kaguya score2 score~5 score")
+	require.NoError(t, err)
+
+	assert.Empty(t, filters.scoreConditions)
+	assert.Equal(t, "kaguya score2 score~5 score", filters.searchQuery)
+}
+
+func TestParseQuickFilterGenresAndTags(t *testing.T) {
+	filters, err := parseQuickFilter("genre:comedy,romance g:slice_of_life tag:isekai")
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{{"comedy", "romance"}, {"slice_of_life"}}, filters.genreGroups)
+	assert.Equal(t, [][]string{{"isekai"}}, filters.tagGroups)
+	assert.Empty(t, filters.searchQuery)
+}
+
+func TestParseQuickFilterFormat(t *testing.T) {
+	filters, err := parseQuickFilter("format:tv,ova")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{domain.FormatTV, domain.FormatOVA}, filters.formatFilters)
+}
+
+func TestMatchesFormatFilters(t *testing.T) {
+	assert.True(t, matchesFormatFilters(nil, domain.FormatMovie))
+	assert.True(t, matchesFormatFilters([]string{domain.FormatTV}, domain.FormatTVShort))
+	assert.False(t, matchesFormatFilters([]string{domain.FormatTV}, domain.FormatMovie))
+}
+
+func TestMatchesLabelGroups(t *testing.T) {
+	anime := &domain.Anime{Genres: []string{"Comedy", "Slice of Life"}, Tags: []domain.AnimeTag{{Name: "Isekai"}}}
+
+	assert.True(t, matchesLabelGroups([][]string{{"drama", "comedy"}, {"slice_of_life"}}, anime.HasGenre))
+	assert.False(t, matchesLabelGroups([][]string{{"comedy"}, {"drama"}}, anime.HasGenre))
+	assert.True(t, matchesLabelGroups([][]string{{"isekai"}}, anime.HasTag))
+	assert.True(t, matchesLabelGroups(nil, anime.HasTag))
+}
+
+func TestParseQuickFilterErrors(t *testing.T) {
+	for _, expr := range []string{"s:bogus", "score>high", "year:soon", "genre:", "tag:a,,b", "format:cd"} {
+		_, err := parseQuickFilter(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestScoreConditionMatches(t *testing.T) {
+	assert.True(t, scoreCondition{op: ">", value: 8}.matches(8.5))
+	assert.False(t, scoreCondition{op: ">", value: 8}.matches(8))
+	assert.True(t, scoreCondition{op: "<=", value: 5}.matches(5))
+	assert.True(t, scoreCondition{op: ":", value: 7}.matches(7))
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mattn/go-runewidth"
 	"strings"
+	"time"
 )
 
 // renderAnimeList renders the anime list for the current filters
@@ -24,35 +25,53 @@ func (m *AnimeListModel) renderAnimeList() string {
 
 	// Calculate available height for the list
 	availableHeight := m.height - 10 // Subtract space for header, tabs, and margins
+	if m.refreshNotice != "" {
+		availableHeight--
+	}
+	if m.errorToast != "" {
+		availableHeight--
+	}
 	if availableHeight < 1 {
 		availableHeight = 1
 	}
 
-	// Determine visible range
-	visibleCount := min(len(animeList), availableHeight-1) // Reserve space for header row
-
-	// Adjust starting index to keep cursor in view
-	startIdx := 0
-	if m.cursor >= visibleCount {
-		startIdx = m.cursor - visibleCount + 1
+	// Group headers take up rows too, so the visible range is worked out over rows rather than anime
+	rows := m.animeListRows(time.Now())
+	cursorRow := 0
+	for i, row := range rows {
+		if row.index == m.cursor {
+			cursorRow = i
+			break
+		}
 	}
 
-	endIdx := startIdx + visibleCount
-	if endIdx > len(animeList) {
-		endIdx = len(animeList)
+	// Determine the visible range, keeping the cursor in view.  Anime take two lines in detailed mode.
+	capacity := max(1, availableHeight-1) // Reserve space for header row
+	rowLines := func(row listRow) int {
+		if row.index >= 0 && m.detailedRows() {
+			return 2
+		}
+		return 1
+	}
+	startIdx, endIdx := cursorRow, cursorRow+1
+	used := rowLines(rows[cursorRow])
+	for startIdx > 0 && used+rowLines(rows[startIdx-1]) <= capacity {
+		startIdx--
+		used += rowLines(rows[startIdx])
+	}
+	for endIdx < len(rows) && used+rowLines(rows[endIdx]) <= capacity {
+		used += rowLines(rows[endIdx])
+		endIdx++
 	}
 
 	// Styles for list items
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#FFFFFF")).
+		Foreground(styles.ColorText).
 		Width(m.width-4).
 		Padding(0, 1)
 
-	selectedStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("#FFFFFF")).
-		Background(lipgloss.Color("#7D56F4")).
+	selectedStyle := styles.Selected.
 		Width(m.width-4).
 		Padding(0, 1)
 
@@ -63,22 +82,40 @@ func (m *AnimeListModel) renderAnimeList() string {
 	// Build the list with header
 	var listContent string
 
-	// Add column headers
-	headerText := fmt.Sprintf("%1s %-100s %8s %8s %5s %9s %5s %12s",
-		" ", "Title", "Progress", "Format", "Score", "Status", "Next #", "Airing In")
-	listContent += headerStyle.Render(headerText) + "\n"
+	// Add column headers, laid out for the width inside the padding
+	rowWidth := m.width - 6
+	listContent += headerStyle.Render(m.listHeader(rowWidth)) + "\n"
 
 	// Add a separator line
 	separatorLine := strings.Repeat("─", m.width-6) // Adjust width to fit inside the box
 	listContent += separatorLine + "\n"
 
+	detailsStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+
+	groupHeaderStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.ColorAccent).
+		Width(m.width-4).
+		Padding(0, 1)
+
 	// Add anime items
 	for i := startIdx; i < endIdx; i++ {
-		anime := animeList[i]
+		row := rows[i]
+		if row.index < 0 {
+			listContent += groupHeaderStyle.Render(row.header) + "\n"
+			continue
+		}
 
-		itemText := m.formatAnimeListItem(anime)
+		itemText := m.formatAnimeListItem(animeList[row.index], rowWidth)
+		if m.detailedRows() {
+			details := m.formatAnimeDetails(animeList[row.index], rowWidth)
+			if row.index != m.cursor {
+				details = detailsStyle.Render(details)
+			}
+			itemText += "\n" + details
+		}
 
-		if i == m.cursor {
+		if row.index == m.cursor {
 			listContent += selectedStyle.Render(itemText) + "\n"
 		} else {
 			listContent += normalStyle.Render(itemText) + "\n"
@@ -86,100 +123,61 @@ func (m *AnimeListModel) renderAnimeList() string {
 	}
 
 	// Add pagination indicator if needed
-	if len(animeList) > visibleCount {
-		pagination := fmt.Sprintf("Showing %d-%d of %d", startIdx+1, endIdx, len(animeList))
+	if startIdx > 0 || endIdx < len(rows) {
+		first, last := animeRange(rows[startIdx:endIdx])
+		pagination := fmt.Sprintf("Showing %d-%d of %d", first+1, last+1, len(animeList))
 		listContent += styles.CenteredText(m.width-4, pagination)
 	}
 
 	return styles.ContentBox(m.width-2, listContent, 1)
 }
 
-// formatAnimeListItem formats a single anime list item for display
-func (m *AnimeListModel) formatAnimeListItem(anime *domain.Anime) string {
-	available := " " // Default: empty/space
-	if anime.HasUnwatchedEpisodes() {
-		available = "+"
-	}
-
-	title := anime.Title.Preferred
-
-	// Truncate title to fit available space
-	titleWidth := 100
-	truncatedTitle := util.TruncateString(title, titleWidth)
+// detailedRows returns true if each anime is shown with a second line of details
+func (m *AnimeListModel) detailedRows() bool {
+	return m.config.UI.ListRows == "detailed"
+}
 
-	// Pad with spaces to ensure consistent width
-	paddedTitle := truncatedTitle
-	titleVisualWidth := 0
-	for _, r := range truncatedTitle {
-		titleVisualWidth += runewidth.RuneWidth(r)
-	}
-	if titleVisualWidth < titleWidth {
-		paddedTitle = truncatedTitle + strings.Repeat(" ", titleWidth-titleVisualWidth)
+// listPageSize returns how many anime fit on a page of the list
+func (m *AnimeListModel) listPageSize() int {
+	if m.detailedRows() {
+		return (m.height - 11) / 2
 	}
+	return m.height - 11
+}
 
-	// Format - TV, Movie, OVA, etc.
-	format := "?"
-	if anime.Format != "" {
-		format = string(anime.Format)
+// formatAnimeDetails formats the second line of an anime in detailed mode: its genres, when the next episode airs and
+// the start of the user's notes
+func (m *AnimeListModel) formatAnimeDetails(anime *domain.Anime, width int) string {
+	var details []string
+	if len(anime.Genres) > 0 {
+		details = append(details, strings.Join(anime.Genres, ", "))
 	}
-
-	// Progress
-	progress := ""
-	if anime.UserData != nil {
-		if anime.Episodes > 0 {
-			progress = fmt.Sprintf("%d/%d", anime.UserData.Progress, anime.Episodes)
+	if anime.NextAiringEp != nil {
+		if m.showAbsoluteAiringTimes() {
+			details = append(details, fmt.Sprintf("Ep %d %s", anime.NextAiringEp.Episode,
+				strings.TrimSpace(util.FormatAiringTime(anime.NextAiringEp.AiringAt, m.airingLocation))))
 		} else {
-			progress = fmt.Sprintf("%d/?", anime.UserData.Progress)
+			details = append(details, fmt.Sprintf("Ep %d in %s", anime.NextAiringEp.Episode,
+				strings.TrimSpace(util.FormatTimeUntilAiring(anime.NextAiringEp.TimeUntilAir))))
 		}
 	}
-
-	// Mean Score from AniList
-	meanScore := "-"
-	if anime.AverageScore > 0 {
-		meanScore = fmt.Sprintf("%.0f", anime.AverageScore)
+	if anime.UserData != nil && anime.UserData.Notes != "" {
+		notes, _, _ := strings.Cut(strings.TrimSpace(anime.UserData.Notes), "\n")
+		details = append(details, "Notes: "+notes)
 	}
-
-	// Next episode number
-	nextEpNum := ""
-	if anime.NextAiringEp != nil {
-		nextEpNum = fmt.Sprintf("%d", anime.NextAiringEp.Episode)
+	if len(details) == 0 {
+		details = append(details, "No genres, airing episode or notes")
 	}
 
-	// Airing countdown
-	airingIn := ""
-	if anime.NextAiringEp != nil {
-		airingIn = util.FormatTimeUntilAiring(anime.NextAiringEp.TimeUntilAir)
-	} else if anime.Status == "FINISHED" {
-		airingIn = "Finished"
-	}
-
-	// Status indicator
-	statusText := "Unknown"
-	if anime.UserData != nil {
-		switch anime.UserData.Status {
-		case domain.StatusCurrent:
-			statusText = "Watching"
-		case domain.StatusPlanning:
-			statusText = "Planning"
-		case domain.StatusCompleted:
-			statusText = "Completed"
-		case domain.StatusDropped:
-			statusText = "Dropped"
-		case domain.StatusPaused:
-			statusText = "Paused"
-		case domain.StatusRepeating:
-			statusText = "Repeating"
-		}
+	// Indented to line up with the title
+	line := "  " + strings.Join(details, " · ")
+	if runewidth.StringWidth(line) > width {
+		line = runewidth.Truncate(line, width, "...")
 	}
+	return line
+}
 
-	// Final formatted string
-	return fmt.Sprintf("%s %-40s %8s %8s %5s %9s %5s %12s",
-		available,
-		paddedTitle,
-		progress,
-		format,
-		meanScore,
-		statusText,
-		nextEpNum,
-		airingIn)
+// showAbsoluteAiringTimes returns true if air times should be shown as a local weekday and time instead of a countdown
+func (m *AnimeListModel) showAbsoluteAiringTimes() bool {
+	return m.config.UI.AiringTimeFormat == "absolute"
 }
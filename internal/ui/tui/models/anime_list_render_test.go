@@ -0,0 +1,28 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/mattn/go-runewidth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatAnimeDetails(t *testing.T) {
+	m := &AnimeListModel{config: &config.Config{}}
+	anime := &domain.Anime{
+		Genres:       []string{"Adventure", "Fantasy"},
+		NextAiringEp: &domain.AiringSchedule{Episode: 12, TimeUntilAir: 2*86400 + 3*3600},
+		UserData:     &domain.UserAnimeData{Notes: "Rewatching with friends\nSecond line"},
+	}
+
+	assert.Equal(t, "  Adventure, Fantasy · Ep 12 in 2d 03h 00m · Notes: Rewatching with friends",
+		m.formatAnimeDetails(anime, 100))
+
+	truncated := m.formatAnimeDetails(anime, 30)
+	assert.Equal(t, 30, runewidth.StringWidth(truncated))
+	assert.Contains(t, truncated, "...")
+
+	assert.Equal(t, "  No genres, airing episode or notes", m.formatAnimeDetails(&domain.Anime{}, 100))
+}
@@ -0,0 +1,113 @@
+package models
+
+// anime_list_sort.go orders the anime list by one of several sort modes, cycled through from the list.  Sorting is done
+// after filtering, so it only changes the order of the anime the filters let through.
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+)
+
+// sortMode is what the anime list is ordered by
+type sortMode int
+
+const (
+	sortDefault sortMode = iota // The order the list was loaded in
+	sortTitle
+	sortScore
+	sortAverageScore
+	sortProgress
+	sortNextAiring
+	sortUpdated
+	sortPriority
+	sortModeCount
+)
+
+// String returns the name of the sort mode shown in the header
+func (s sortMode) String() string {
+	switch s {
+	case sortTitle:
+		return "title"
+	case sortScore:
+		return "score"
+	case sortAverageScore:
+		return "average score"
+	case sortProgress:
+		return "progress"
+	case sortNextAiring:
+		return "next airing"
+	case sortUpdated:
+		return "last updated"
+	case sortPriority:
+		return "priority"
+	default:
+		return "list order"
+	}
+}
+
+// next returns the sort mode cycled to after this one, going back to the default after the last
+func (s sortMode) next() sortMode {
+	return (s + 1) % sortModeCount
+}
+
+// sortAnime orders the list by the sort mode.  Anime without what is sorted by, e.g. with nothing airing when sorting
+// by next airing, keep their order at the end.
+func sortAnime(list []*domain.Anime, mode sortMode) {
+	switch mode {
+	case sortTitle:
+		sort.SliceStable(list, func(i, j int) bool {
+			return strings.ToLower(list[i].Title.Preferred) < strings.ToLower(list[j].Title.Preferred)
+		})
+	case sortScore:
+		sortByKey(list, true, func(anime *domain.Anime) (float64, bool) {
+			if anime.UserData == nil || anime.UserData.Score == 0 {
+				return 0, false
+			}
+			return anime.UserData.Score, true
+		})
+	case sortAverageScore:
+		sortByKey(list, true, func(anime *domain.Anime) (float64, bool) {
+			return anime.AverageScore, anime.AverageScore > 0
+		})
+	case sortProgress:
+		sortByKey(list, true, func(anime *domain.Anime) (float64, bool) {
+			if anime.UserData == nil || anime.Episodes == 0 {
+				return 0, false
+			}
+			return float64(anime.UserData.Progress) / float64(anime.Episodes), true
+		})
+	case sortNextAiring:
+		sortByKey(list, false, func(anime *domain.Anime) (float64, bool) {
+			if anime.NextAiringEp == nil || anime.NextAiringEp.AiringAt == 0 {
+				return 0, false
+			}
+			return float64(anime.NextAiringEp.AiringAt), true
+		})
+	case sortUpdated:
+		sortByKey(list, true, func(anime *domain.Anime) (float64, bool) {
+			if anime.UserData == nil || anime.UserData.UpdatedAt == 0 {
+				return 0, false
+			}
+			return float64(anime.UserData.UpdatedAt), true
+		})
+	case sortPriority:
+		sortByPriority(list)
+	}
+}
+
+// sortByKey orders the list by the key, highest first if descending.  Anime the key returns false for go last.
+func sortByKey(list []*domain.Anime, descending bool, key func(*domain.Anime) (float64, bool)) {
+	sort.SliceStable(list, func(i, j int) bool {
+		keyI, okI := key(list[i])
+		keyJ, okJ := key(list[j])
+		if okI != okJ || !okI {
+			return okI
+		}
+		if descending {
+			return keyI > keyJ
+		}
+		return keyI < keyJ
+	})
+}
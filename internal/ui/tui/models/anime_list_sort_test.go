@@ -0,0 +1,55 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortAnime(t *testing.T) {
+	newList := func() []*domain.Anime {
+		return []*domain.Anime{
+			{ID: 1, Title: domain.AnimeTitle{Preferred: "frieren"}, Episodes: 28, AverageScore: 91,
+				UserData: &domain.UserAnimeData{Score: 9, Progress: 7, UpdatedAt: 300}},
+			{ID: 2, Title: domain.AnimeTitle{Preferred: "Bocchi"}, Episodes: 12,
+				NextAiringEp: &domain.AiringSchedule{AiringAt: 2000},
+				UserData:     &domain.UserAnimeData{Progress: 12, UpdatedAt: 100}},
+			{ID: 3, Title: domain.AnimeTitle{Preferred: "Dandadan"}, AverageScore: 85,
+				NextAiringEp: &domain.AiringSchedule{AiringAt: 1000},
+				UserData:     &domain.UserAnimeData{Score: 8, Progress: 3}},
+		}
+	}
+	ids := func(list []*domain.Anime) []int {
+		var ids []int
+		for _, anime := range list {
+			ids = append(ids, anime.ID)
+		}
+		return ids
+	}
+
+	tests := map[sortMode][]int{
+		sortDefault:      {1, 2, 3},
+		sortTitle:        {2, 3, 1},
+		sortScore:        {1, 3, 2},
+		sortAverageScore: {1, 3, 2},
+		sortProgress:     {2, 1, 3},
+		sortNextAiring:   {3, 2, 1},
+		sortUpdated:      {1, 2, 3},
+	}
+	for mode, want := range tests {
+		t.Run(mode.String(), func(t *testing.T) {
+			list := newList()
+			sortAnime(list, mode)
+			assert.Equal(t, want, ids(list))
+		})
+	}
+}
+
+func TestSortModeCycles(t *testing.T) {
+	mode := sortDefault
+	for range sortModeCount {
+		mode = mode.next()
+	}
+	assert.Equal(t, sortDefault, mode, "cycling should come back to the list's own order")
+}
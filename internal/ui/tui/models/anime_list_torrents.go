@@ -0,0 +1,129 @@
+package models
+
+// anime_list_torrents.go searches Nyaa for torrents of an episode, for episodes AllAnime can't play, and plays the
+// chosen torrent with the configured torrent command or hands it to the system's torrent client.
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/player"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxTorrentMenuItems limits how many torrents are offered, as the best are listed first
+const maxTorrentMenuItems = 15
+
+// handleFindTorrents starts searching Nyaa for torrents of an episode of the anime
+func (m *AnimeListModel) handleFindTorrents(msg FindTorrentsMsg) tea.Cmd {
+	anime := m.findAnimeById(msg.AnimeID)
+	if anime == nil {
+		log.Warn("Received message to find torrents, but could not find ID in list", "anime_id", msg.AnimeID)
+		return nil
+	}
+	episode := msg.Episode
+	if episode == 0 {
+		episode = anime.UserData.Progress + 1
+	}
+
+	m.loading = true
+	m.loadingMsg = fmt.Sprintf("Searching Nyaa for episode %d of %s...", episode, anime.Title.Preferred)
+	return tea.Batch(m.spinner.Tick, m.findTorrents(anime, episode))
+}
+
+// findTorrents searches Nyaa for torrents of the episode
+func (m *AnimeListModel) findTorrents(anime *domain.Anime, episode int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+		defer cancel()
+
+		torrents, err := m.playerService.FindTorrents(ctx, anime, episode)
+		return TorrentsFoundMsg{Anime: anime, Episode: episode, Torrents: torrents, Error: err}
+	}
+}
+
+// handleTorrentsFound lets the user choose from the torrents found
+func (m *AnimeListModel) handleTorrentsFound(msg TorrentsFoundMsg) tea.Cmd {
+	m.loading = false
+	if msg.Error != nil {
+		log.Error("Failed to search Nyaa", "anime_id", msg.Anime.ID, "episode", msg.Episode, "error", msg.Error)
+		return m.showErrorToast("Failed to search Nyaa: " + msg.Error.Error())
+	}
+	if len(msg.Torrents) == 0 {
+		return m.showErrorToast(fmt.Sprintf("No torrents of episode %d found on Nyaa", msg.Episode))
+	}
+	return m.showTorrentMenu(msg.Anime, msg.Episode, msg.Torrents)
+}
+
+// showTorrentMenu lists the torrents of an episode, best first, with their seeders and size
+func (m *AnimeListModel) showTorrentMenu(anime *domain.Anime, episode int, torrents []player.Torrent) tea.Cmd {
+	if len(torrents) > maxTorrentMenuItems {
+		torrents = torrents[:maxTorrentMenuItems]
+	}
+
+	var menuItems []MenuItem
+	for _, torrent := range torrents {
+		trusted := " "
+		if torrent.Trusted {
+			trusted = "✓"
+		}
+		menuItems = append(menuItems, MenuItem{
+			Text: fmt.Sprintf("%s %4d↑ %9s  %s", trusted, torrent.Seeders, torrent.Size, torrent.Title),
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg:   PlayTorrentMsg{Anime: anime, Episode: episode, Torrent: torrent},
+				}
+			},
+		})
+	}
+	menuItems = append(menuItems, MenuItem{
+		Text: "Back",
+		Command: func() tea.Msg {
+			return MenuSelectionMsg{CloseMenu: true}
+		},
+	})
+
+	title := fmt.Sprintf("Nyaa - %s episode %d", anime.Title.Preferred, episode)
+	menuModel := NewMenuModel(title, menuItems)
+	return func() tea.Msg {
+		return ShowMenuMsg{Menu: menuModel}
+	}
+}
+
+// handlePlayTorrent streams the chosen torrent with the torrent command, or hands it to the system's torrent client if
+// none is configured.  Progress is only updated automatically when the torrent is the next episode to watch.
+func (m *AnimeListModel) handlePlayTorrent(msg PlayTorrentMsg) tea.Cmd {
+	log.Info("Torrent chosen for episode", "anime_id", msg.Anime.ID, "episode", msg.Episode,
+		"title", msg.Torrent.Title, "info_hash", msg.Torrent.InfoHash)
+
+	if !m.playerService.CanStreamTorrents() {
+		if err := m.playerService.OpenTorrent(msg.Torrent); err != nil {
+			log.Error("Failed to open torrent", "error", err)
+			return m.showErrorToast("Failed to open the torrent: " + err.Error())
+		}
+		m.refreshNotice = fmt.Sprintf("Opened %s in your torrent client", msg.Torrent.Title)
+		return Handled("play_torrent:opened")
+	}
+
+	episode := player.AllAnimeEpisodeInfo{
+		OverallEpisodeNumber:  msg.Episode,
+		AllAnimeEpisodeNumber: fmt.Sprint(msg.Episode),
+		AllAnimeName:          msg.Anime.Title.Preferred,
+		PreferredTitle:        msg.Anime.Title.Preferred,
+		AniListID:             msg.Anime.ID,
+		Format:                msg.Anime.Format,
+	}
+	var anime *domain.Anime
+	if msg.Episode == msg.Anime.UserData.Progress+1 {
+		anime = msg.Anime
+	}
+
+	m.loading = true
+	m.loadingMsg = fmt.Sprintf("Launching the torrent command for %s episode %d...",
+		msg.Anime.Title.Preferred, msg.Episode)
+	return tea.Batch(m.spinner.Tick, m.playStream(episode, anime, msg.Torrent.Magnet()))
+}
@@ -0,0 +1,170 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/diagnostics"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/terminal"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/util"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// APIUsageModel displays the recent calls made to external APIs, to help diagnose rate limits and slow refreshes.  It
+// also shows the terminal capabilities Hisame detected, to explain why features such as taskbar progress are off.
+type APIUsageModel struct {
+	width, height int
+	calls         []diagnostics.APICall
+	viewport      viewport.Model
+	navigation    kb.Navigation
+}
+
+// NewAPIUsageModel creates a new API usage model populated with the current API call history
+func NewAPIUsageModel() *APIUsageModel {
+	return &APIUsageModel{
+		calls:    diagnostics.RecentAPICalls(),
+		viewport: viewport.New(0, 0),
+	}
+}
+
+func (m *APIUsageModel) ViewType() View {
+	return ViewAPIUsage
+}
+
+// Init initializes the model
+func (m *APIUsageModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m *APIUsageModel) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	case tea.KeyMsg:
+		action, count := m.navigation.Action(msg, kb.ContextAPIUsage)
+		if scrollViewport(&m.viewport, action, count) {
+			return m, Handled("cursor_move:" + string(action))
+		}
+		switch action {
+		case kb.ActionPending:
+			return m, Handled("navigation:pending")
+		case kb.ActionRefreshAPIUsage:
+			m.calls = diagnostics.RecentAPICalls()
+			m.updateContent()
+			return m, Handled("api_usage:refresh")
+		}
+	}
+	return m, cmd
+}
+
+// View renders the API usage screen
+func (m *APIUsageModel) View() string {
+	header := styles.Header(m.width, "API Usage")
+
+	keyBindings := []components.KeyBinding{
+		{kb.DisplayKeys(kb.ContextAPIUsage, kb.ActionMoveUp, kb.ActionMoveDown), "Scroll"},
+		{kb.DisplayKey(kb.ActionRefreshAPIUsage, kb.ContextAPIUsage), "Refresh"},
+		{kb.DisplayKey(kb.ActionToggleHelp, kb.ContextAPIUsage), "Help"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextAPIUsage), "Return"},
+	}
+	footer := components.KeyBindingsBar(m.width, keyBindings)
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		header,
+		"",
+		styles.FilterStatus.Render(m.summary()),
+		styles.FilterStatus.Render(util.TruncateString("Terminal: "+terminal.Detected().Summary(), max(10, m.width-2))),
+		"",
+		styles.ContentBox(m.width-2, m.viewport.View(), 1),
+		"",
+		footer,
+	)
+}
+
+// summary renders overall totals for the recorded calls
+func (m *APIUsageModel) summary() string {
+	var errorCount, cachedCount int
+	var totalDuration time.Duration
+	for _, call := range m.calls {
+		if call.Error != nil {
+			errorCount++
+		}
+		if call.Cached {
+			cachedCount++
+		}
+		totalDuration += call.Duration
+	}
+
+	average := time.Duration(0)
+	if len(m.calls) > 0 {
+		average = totalDuration / time.Duration(len(m.calls))
+	}
+
+	return fmt.Sprintf("%d calls  •  %d errors  •  %d cached  •  avg %s",
+		len(m.calls), errorCount, cachedCount, formatCallDuration(average))
+}
+
+// updateContent renders the call table into the viewport
+func (m *APIUsageModel) updateContent() {
+	if len(m.calls) == 0 {
+		m.viewport.SetContent("No API calls have been made yet")
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(
+		fmt.Sprintf("%-8s  %-8s  %-24s  %8s  %-7s  %s", "Time", "API", "Operation", "Duration", "Source", "Status")))
+	b.WriteString("\n")
+
+	errorStyle := lipgloss.NewStyle().Foreground(styles.ColorError)
+	for _, call := range m.calls {
+		source := "network"
+		if call.Cached {
+			source = "cache"
+		}
+
+		line := fmt.Sprintf("%-8s  %-8s  %-24s  %8s  %-7s  %s",
+			call.StartedAt.Format("15:04:05"),
+			call.API,
+			util.TruncateString(call.Operation, 24),
+			formatCallDuration(call.Duration),
+			source,
+			call.Status())
+
+		if call.Error != nil {
+			line = errorStyle.Render(line + ": " + util.TruncateString(call.Error.Error(), max(10, m.width-80)))
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	m.viewport.SetContent(b.String())
+}
+
+// formatCallDuration formats a call duration compactly, e.g. 340ms or 2.1s
+func formatCallDuration(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
+
+// Resize updates the dimensions of the model
+func (m *APIUsageModel) Resize(width, height int) {
+	m.width = width
+	m.height = height
+
+	m.viewport.Width = max(1, width-4)
+	m.viewport.Height = max(1, height-13)
+	m.updateContent()
+}
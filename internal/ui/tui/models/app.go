@@ -1,15 +1,24 @@
 package models
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/PizzaHomicide/hisame/internal/auth"
 	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/domain"
 	"github.com/PizzaHomicide/hisame/internal/log"
 	"github.com/PizzaHomicide/hisame/internal/repository/anilist"
+	"github.com/PizzaHomicide/hisame/internal/repository/local"
 	"github.com/PizzaHomicide/hisame/internal/service"
 	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/terminal"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/util"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -21,6 +30,16 @@ type AppModel struct {
 
 	// Services used for fetching and updating state
 	animeService *service.AnimeService
+
+	user   domain.User     // The AniList account Hisame is logged in as
+	client *anilist.Client // The client logged in as the user, nil in local-only mode
+
+	rateLimits       <-chan time.Duration // Receives delays caused by AniList's rate limit
+	rateLimitedUntil time.Time            // When requests held back by the rate limit are retried.  Zero if not limited
+
+	agendaShown   bool // Whether the startup airing agenda has already been considered this session
+	continueShown bool // Whether the startup continue watching panel has already been considered this session
+	quitWhenIdle  bool // Whether Hisame quits once changes have saved and playback has ended
 }
 
 func NewAppModel(cfg *config.Config) AppModel {
@@ -64,6 +83,9 @@ func (m *AppModel) PopModel() {
 		return
 	}
 
+	if _, ok := m.CurrentModel().(*LoadingModel); ok {
+		terminal.ClearProgress()
+	}
 	m.modelStack = m.modelStack[:len(m.modelStack)-1]
 	log.Debug("Popped model from stack", "new_top", m.CurrentModel().ViewType(), "stack_size", len(m.modelStack))
 }
@@ -90,6 +112,14 @@ func (m *AppModel) SetStack(models []Model) {
 func (m AppModel) Init() tea.Cmd {
 	log.Info("Initialising Hisame TUI")
 
+	// Local-only mode has no account, so there is no token to validate
+	if m.config.Auth.LocalOnly {
+		return tea.Batch(
+			m.CurrentModel().Init(),
+			func() tea.Msg { return LocalModeMsg{} },
+		)
+	}
+
 	// Start the loading spinner and begin token validation
 	return tea.Batch(
 		m.CurrentModel().Init(), // Initialize the loading model
@@ -172,8 +202,7 @@ func (m *AppModel) handleKeyMsg(msg tea.Msg) tea.Cmd {
 	case tea.KeyMsg:
 		switch kb.GetActionByKey(msg, kb.ContextGlobal) {
 		case kb.ActionQuit:
-			log.Info("Quit command received. Shutting down...")
-			return tea.Quit
+			return m.handleQuit()
 
 		case kb.ActionLogout:
 			return m.handleLogout()
@@ -181,6 +210,18 @@ func (m *AppModel) handleKeyMsg(msg tea.Msg) tea.Cmd {
 		case kb.ActionToggleHelp:
 			return m.handleToggleHelp()
 
+		case kb.ActionAPIUsage:
+			return m.handleShowAPIUsage()
+
+		case kb.ActionQuickPlay:
+			return m.handleShowQuickPlay()
+
+		case kb.ActionContinueWatching:
+			return m.handleShowContinueWatching()
+
+		case kb.ActionSearchAll:
+			return m.handleShowAniListSearch()
+
 		case kb.ActionBack:
 			// First check if the current active model can handle a back action
 			var cmd tea.Cmd
@@ -223,40 +264,61 @@ func (m *AppModel) handleOrchestrationMsg(msg tea.Msg) tea.Cmd {
 				}
 			}
 
-			// Go to auth screen
-			m.SetStack([]Model{NewAuthModel()})
+			// Go to auth screen, saying why if the token was rejected
+			if msg.Error != nil {
+				m.SetStack([]Model{NewAuthModelWithError(fmt.Sprintf("Please log in again: %v", msg.Error))})
+			} else {
+				m.SetStack([]Model{NewAuthModel()})
+			}
 			return m.CurrentModel().Init()
 		}
 
 		// Valid token - set up services and go to anime list
-		animeRepo := anilist.NewAnimeRepository(msg.Client)
+		m.client = msg.Client
+		m.user = msg.Client.GetUser()
+		m.rateLimits = msg.Client.RateLimited()
+		animeRepo := anilist.NewAnimeRepository(msg.Client, m.config)
 		animeService := service.NewAnimeService(animeRepo)
-		animeListModel := NewAnimeListModel(m.config, animeService)
+		animeService.UseListCache(m.user.ID)
 
 		// Save references
 		m.animeService = animeService
 		//m.animeListModel = animeListModel
 
 		// Push anime list model
-		m.SetStack([]Model{NewAnimeListModel(m.config, m.animeService)})
-
-		// Now start loading the anime list data
-		return func() tea.Msg {
-			return LoadingMsg{
-				Type:      LoadingStart,
-				Message:   "Loading your anime list...",
-				Title:     "Fetching Data",
-				Operation: animeListModel.fetchAnimeListCmd(),
-			}
+		m.SetStack([]Model{NewAnimeListModel(m.config, m.animeService, m.user)})
+
+		// Now start loading the anime list data, from the cache if there is one
+		return tea.Batch(m.listenForRateLimits(), m.listenForUnauthorized(), m.watchTokenExpiry(m.config.Auth.Token),
+			m.CurrentModel().Init())
+	case LocalModeMsg:
+		return m.startLocalMode()
+	case TokenExpiredMsg:
+		var listen tea.Cmd
+		if msg.Token == "" {
+			listen = m.listenForUnauthorized()
+		} else if msg.Token != m.config.Auth.Token {
+			return Handled("token_expired:replaced")
 		}
+		if _, ok := m.CurrentModel().(*AuthModel); ok {
+			return tea.Batch(listen, Handled("token_expired:already_shown"))
+		}
+		log.Warn("AniList login expired, asking to log in again")
+		return tea.Batch(listen, m.PushModel(NewReauthModel()))
 	case AuthMsg:
 		if msg.Success {
 			return m.handleSuccessfulAuth(msg.Token)
 		} else {
 			log.Error("Authentication failed", "error", msg.Error)
+			if current, ok := m.CurrentModel().(*AuthModel); ok && current.reauth {
+				// Keep the views underneath, so they can carry on once the login succeeds
+				m.PopModel()
+				retry := NewReauthModel()
+				retry.status = "Login failed: " + msg.Error
+				return m.PushModel(retry)
+			}
 			// Reset auth model in case it's in a bad state
-			//m.authModel = NewAuthModel()
-			m.SetStack([]Model{NewAuthModel()})
+			m.SetStack([]Model{NewAuthModelWithError("Login failed: " + msg.Error)})
 			return m.CurrentModel().Init()
 		}
 
@@ -271,29 +333,34 @@ func (m *AppModel) handleOrchestrationMsg(msg tea.Msg) tea.Cmd {
 
 			log.Info("Episodes loaded", "count", len(msg.Episodes), "title", msg.Title)
 			m.disableLoading()
-			return m.PushModel(NewEpisodeSelectModel(msg.Episodes, msg.Title))
+			return m.PushModel(NewEpisodeSelectModel(msg.Episodes, msg.EpisodeTitles, msg.Schedule, msg.Filler,
+				util.ResolveLocation(m.config.UI.Timezone), msg.Title))
 
-		case EpisodeEventSelected:
+		case EpisodeEventSelected, EpisodeEventChooseSource, EpisodeEventCopyStreamURL:
 			if msg.Episode != nil {
 				log.Info("Episode selected from episode select model",
 					"overall_epNum", msg.Episode.OverallEpisodeNumber,
 					"allanime_epNum", msg.Episode.AllAnimeEpisodeNumber,
 					"title", msg.Episode.AllAnimeName)
 
-				// Pop episode select model
-				m.PopModel()
+				// Pop the episode select or playback error view the episode was chosen from
+				if view := m.CurrentModel().ViewType(); view == ViewEpisodeSelect || view == ViewPlaybackError {
+					m.PopModel()
+				}
 
-				// Delegate to anime list model to handle starting playback
-				// TODO:  This assumption feels fragile.  Might break in the future.  This flow needs to be improved in general to better use messages.
-				// As an idea, maybe we should traverse the stack top to bottom for 'orchestration' type messages looking for a model that can handle it,
-				// so we're not dependent on a specific model stack state.
-				return m.updateCurrentModel(msg)
+				// Delegate to anime list model to handle starting playback, whatever is left on top of it
+				return m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+					return model.Update(msg)
+				})
 			}
 
 		case EpisodeEventError:
 			log.Warn("Could not find episode", "error", msg.Error)
 			m.disableLoading()
-			return nil
+			// Check the providers now, so an outage shows up in the header straight away
+			return m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+				return model, model.checkProviderHealthCmd()
+			})
 		}
 
 	case PlaybackMsg:
@@ -306,7 +373,7 @@ func (m *AppModel) handleOrchestrationMsg(msg tea.Msg) tea.Cmd {
 		}
 
 	case AnimeListLoadResultMsg:
-		if currentModel, ok := m.CurrentModel().(*LoadingModel); ok {
+		if currentModel, ok := m.CurrentModel().(*LoadingModel); ok && !msg.Background {
 			log.Debug("Stopping loading for anime list refresh",
 				"elapsed", currentModel.GetElapsedTime())
 			m.PopModel()
@@ -315,15 +382,36 @@ func (m *AppModel) handleOrchestrationMsg(msg tea.Msg) tea.Cmd {
 		// Then forward the result to the AnimeListModel
 		// TODO:  Bad pattern.  Should just delegate messages.
 		if msg.Success {
-			return m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+			cmd := m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
 				return model.HandleAnimeListLoaded(msg.AnimeList)
 			})
+			// The agenda goes on top, as it is only shown while something airs soon
+			return tea.Batch(cmd, m.showStartupContinueWatching(), m.showStartupAgenda())
 		} else {
 			return m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
 				return model.HandleAnimeListError(msg.Error)
 			})
 		}
 
+	case AutoRefreshMsg:
+		// The timer fires whichever view is open, so send it straight to the list
+		return m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+			return model.HandleAutoRefresh(msg)
+		})
+
+	case HealthCheckMsg:
+		return m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+			return model.HandleHealthCheck(msg)
+		})
+
+	case ProviderHealthMsg:
+		if cmd := m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+			return model.HandleProviderHealth(msg)
+		}); cmd != nil {
+			return cmd
+		}
+		return Handled("provider_health")
+
 	case LoadingMsg:
 		switch msg.Type {
 		case LoadingStart:
@@ -342,6 +430,7 @@ func (m *AppModel) handleOrchestrationMsg(msg tea.Msg) tea.Cmd {
 			}
 
 			log.Debug("Starting loading state", "message", msg.Message)
+			terminal.SetIndeterminate()
 			initCmd := m.PushModel(loadingModel)
 
 			// If there's an operation to run during loading, execute it
@@ -359,13 +448,314 @@ func (m *AppModel) handleOrchestrationMsg(msg tea.Msg) tea.Cmd {
 			return nil
 		}
 
+	case WatchOrderMsg:
+		m.popLoadingModel()
+		if msg.Error != nil {
+			log.Error("Failed to build watch order", "title", msg.Title, "error", msg.Error)
+			return nil
+		}
+		return m.PushModel(NewWatchOrderModel(msg.Title, msg.Entries))
+
+	case CastDevicesFoundMsg:
+		m.popLoadingModel()
+		return m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+			return model.Update(msg)
+		})
+
+	case ShowCompletionBackfillMsg:
+		return m.PushModel(NewCompletionBackfillModel(m.animeService, msg.Entries))
+
+	case CompletionBackfillResultMsg:
+		m.popLoadingModel()
+		if m.CurrentModel().ViewType() == ViewCompletionBackfill {
+			m.PopModel()
+		}
+		if msg.Error != nil {
+			log.Error("Completion date backfill did not finish", "error", msg.Error, "updated", msg.Result.Updated)
+		}
+		return m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+			model.applyFilters()
+			return model, nil
+		})
+
+	case OfflineSyncedMsg:
+		cmd := m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+			return model.HandleOfflineSynced(msg)
+		})
+		if len(msg.Result.Conflicts) == 0 {
+			return tea.Batch(cmd, Handled("offline_synced"))
+		}
+		if _, ok := m.CurrentModel().(*ReconcileModel); ok {
+			m.PopModel()
+		}
+		m.PushModel(NewReconcileModel(m.animeService, msg.Result.Conflicts))
+		return tea.Batch(cmd, Handled("offline_conflicts"))
+
+	case RemoteConflictMsg:
+		// The entry now shows AniList's version, so re-filter the list before letting the user choose
+		m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+			model.applyFilters()
+			return model, nil
+		})
+		if reconcile, ok := m.CurrentModel().(*ReconcileModel); ok {
+			reconcile.addConflict(msg.Conflict)
+			return Handled("remote_conflict")
+		}
+		return m.PushModel(NewReconcileModel(m.animeService, []service.Reconciliation{msg.Conflict}))
+
+	case OfflineChangeResolvedMsg:
+		// Resolving can change progress and status, so re-filter the list before handing the result to the view
+		m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+			model.applyFilters()
+			return model, nil
+		})
+		return m.updateCurrentModel(msg)
+
+	case ShowListAuditMsg:
+		return m.PushModel(NewListAuditModel(m.animeService))
+
+	case AuditFixResultMsg:
+		// Fixes can change status, so make sure the list is re-filtered before handing the result to the audit view
+		m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+			model.applyFilters()
+			return model, nil
+		})
+		return m.updateCurrentModel(msg)
+
+	case RateLimitedMsg:
+		tick := rateLimitTick()
+		if m.rateLimitedUntil.After(time.Now()) {
+			// A countdown is already ticking
+			tick = nil
+		}
+		m.rateLimitedUntil = time.Now().Add(msg.Wait)
+		return tea.Batch(m.listenForRateLimits(), tick, Handled("rate_limited"))
+
+	case RateLimitTickMsg:
+		if time.Now().Before(m.rateLimitedUntil) {
+			return rateLimitTick()
+		}
+		m.rateLimitedUntil = time.Time{}
+		return Handled("rate_limit:cleared")
+
+	case ShowProfileMsg:
+		return m.PushModel(NewProfileModel(m.user))
+	case ShowSessionMsg:
+		return m.PushModel(NewSessionModel(m.sessionInfo()))
+	case LogoutMsg:
+		if msg.SwitchAccount {
+			return m.handleSwitchAccount()
+		}
+		return m.handleLogout()
+
+	case ShowLocalImportMsg:
+		return m.PushModel(NewLocalImportModel(m.animeService))
+
+	case LocalImportResultMsg:
+		if msg.Error != nil {
+			log.Error("Local file import did not finish", "error", msg.Error, "updated", msg.Result.Updated)
+		}
+		cmd := m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+			model.applyFilters()
+			return model, nil
+		})
+		if m.CurrentModel().ViewType() == ViewLocalImport {
+			cmd = tea.Batch(cmd, m.updateCurrentModel(msg))
+		}
+		return tea.Batch(cmd, Handled("local_import:result"))
+
+	case ShowMALImportMsg:
+		return m.PushModel(NewMALImportModel(m.animeService))
+
+	case MALImportResultMsg:
+		if msg.Error != nil {
+			log.Error("MyAnimeList import did not finish", "error", msg.Error, "added", msg.Result.Added,
+				"updated", msg.Result.Updated)
+		}
+		cmd := m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+			model.applyFilters()
+			return model, nil
+		})
+		if m.CurrentModel().ViewType() == ViewMALImport {
+			cmd = tea.Batch(cmd, m.updateCurrentModel(msg))
+		}
+		return tea.Batch(cmd, Handled("mal_import:result"))
+
+	case ShowSeasonScoresMsg:
+		return m.PushModel(NewSeasonScoresModel(m.animeService, m.user.ScoreFormat))
+
+	case SeasonScoresSavedMsg:
+		if msg.Error != nil {
+			log.Error("Saving scores did not finish", "error", msg.Error, "updated", msg.Result.Updated)
+		}
+		cmd := m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+			model.applyFilters()
+			return model, nil
+		})
+		if m.CurrentModel().ViewType() == ViewSeasonScores {
+			cmd = tea.Batch(cmd, m.updateCurrentModel(msg))
+		}
+		return tea.Batch(cmd, Handled("season_scores:saved"))
+
+	case ShowExportMsg:
+		return m.PushModel(NewExportModel(m.animeService, m.user.Name, m.config.Export.Dir, msg.Format))
+
+	case ShowHiddenEntriesMsg:
+		return m.PushModel(NewHiddenEntriesModel(m.animeService))
+
+	case ShowSnoozesMsg:
+		return m.PushModel(NewSnoozesModel(m.animeService))
+
+	case EditNotesMsg:
+		edit, err := m.animeService.BeginNotesEdit(msg.AnimeID)
+		if err != nil {
+			log.Warn("Unable to edit notes", "anime_id", msg.AnimeID, "error", err)
+			return Handled("edit_notes:not_found")
+		}
+		return m.PushModel(NewNotesModel(m.animeService, edit))
+
+	case NotesSavedMsg:
+		if msg.Error != nil || m.CurrentModel().ViewType() != ViewNotes {
+			// Let the editor show the error or conflict
+			return nil
+		}
+		m.PopModel()
+		return Handled("notes:saved")
+
+	case HiddenEntriesChangedMsg:
+		return m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+			model.applyFilters()
+			return model, nil
+		})
+
+	case ShowBackupsMsg:
+		return m.PushModel(NewBackupsModel(m.animeService))
+
+	case RestoreBackupMsg:
+		return func() tea.Msg {
+			return LoadingMsg{
+				Type:    LoadingStart,
+				Message: fmt.Sprintf("Restoring %d entries...", len(msg.Backup.Entries)),
+				Title:   "Restoring backup",
+				Operation: func() tea.Msg {
+					ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+					defer cancel()
+					result, err := m.animeService.RestoreBackup(ctx, msg.Backup)
+					return BackupRestoredMsg{Result: result, Error: err}
+				},
+			}
+		}
+
+	case BackupRestoredMsg:
+		m.popLoadingModel()
+		m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+			model.applyFilters()
+			return model, nil
+		})
+		return tea.Batch(m.updateCurrentModel(msg), Handled("backup_restored"))
+
+	case QuickPlayMsg:
+		if m.CurrentModel().ViewType() == ViewQuickPlay {
+			m.PopModel()
+		}
+		return m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+			return model.Update(PlayNextEpisodeMsg{AnimeID: msg.AnimeID})
+		})
+
+	case AniListSearchPlayMsg:
+		if m.CurrentModel().ViewType() == ViewAniListSearch {
+			m.PopModel()
+		}
+		if !msg.AddToList {
+			return m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+				return model, model.handleChooseEpisode(msg.Anime)
+			})
+		}
+
+		anime := msg.Anime
+		return func() tea.Msg {
+			return LoadingMsg{
+				Type:        LoadingStart,
+				Message:     "Adding to your list...",
+				ContextInfo: anime.Title.Preferred,
+				Operation: func() tea.Msg {
+					ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+					defer cancel()
+					added, err := m.animeService.AddToList(ctx, anime, domain.StatusCurrent)
+					return AddedToListMsg{Anime: added, Title: anime.Title.Preferred, Error: err}
+				},
+			}
+		}
+
+	case AddedToListMsg:
+		m.popLoadingModel()
+		return m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+			return model.Update(msg)
+		})
+
+	case ShowPlaybackErrorMsg:
+		return m.PushModel(NewPlaybackErrorModel(m.config, msg.Episode, msg.Anime, msg.Error))
+
+	case RetryPlaybackMsg:
+		if m.CurrentModel().ViewType() == ViewPlaybackError {
+			m.PopModel()
+		}
+		return m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+			return model.Update(msg)
+		})
+
+	case ContinueWatchingPlayMsg:
+		if m.CurrentModel().ViewType() == ViewContinueWatching {
+			m.PopModel()
+		}
+		return m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+			return model.Update(PlayNextEpisodeMsg{AnimeID: msg.AnimeID})
+		})
+
+	case AgendaPlayMsg:
+		if m.CurrentModel().ViewType() == ViewAgenda {
+			m.PopModel()
+		}
+		return m.withAnimeListModel(func(model *AnimeListModel) (Model, tea.Cmd) {
+			return model.Update(PlayNextEpisodeMsg{AnimeID: msg.AnimeID})
+		})
+
+	case ShowFocusMsg:
+		list, ok := m.getModel(ViewAnimeList).(*AnimeListModel)
+		if !ok {
+			return nil
+		}
+		anime := list.findAnimeById(msg.AnimeID)
+		if anime == nil {
+			log.Warn("Received message to focus on anime, but could not find ID in list", "anime_id", msg.AnimeID)
+			return Handled("focus:not_found")
+		}
+		return tea.Batch(m.PushModel(NewFocusModel(list, anime)), Handled("focus:show"))
+
 	case AnimeDetailsMsg:
-		detailsModel := NewAnimeDetailsModel(msg.Anime)
+		detailsModel := NewAnimeDetailsModel(msg.Anime, m.config, m.animeService)
 		return m.PushModel(detailsModel)
 
 	case ShowMenuMsg:
 		return m.PushModel(msg.Menu)
 
+	case QuitWhenIdleMsg:
+		log.Info("Quitting once unfinished work is done")
+		m.quitWhenIdle = true
+		return quitWaitTick()
+
+	case QuitWaitTickMsg:
+		return m.handleQuitWaitTick()
+
+	case QuitNowMsg:
+		if msg.StopPlayback {
+			if model, ok := m.getModel(ViewAnimeList).(*AnimeListModel); ok {
+				model.playerService.StopPlayback()
+			}
+		}
+		log.Info("Quitting without waiting for unfinished work")
+		return tea.Quit
+
 	case MenuSelectionMsg:
 		if msg.CloseMenu && m.CurrentModel().ViewType() == ViewMenu {
 			m.PopModel()
@@ -396,6 +786,11 @@ func (m *AppModel) popLoadingModel() {
 
 // handleLogout handles the logout action
 func (m *AppModel) handleLogout() tea.Cmd {
+	if m.config.Auth.LocalOnly {
+		log.Info("Ignoring logout in local-only mode, as there is no account to log out of")
+		return nil
+	}
+
 	log.Info("Logging out. Cleaning up token from config file...")
 	m.config.Auth.Token = ""
 	err := config.UpdateConfig(func(conf *config.Config) {
@@ -411,6 +806,35 @@ func (m *AppModel) handleLogout() tea.Cmd {
 	return nil
 }
 
+// handleSwitchAccount logs out, explaining how to log in as a different account.  AniList logs in whichever account
+// the browser is logged in to, so that has to be changed first.
+func (m *AppModel) handleSwitchAccount() tea.Cmd {
+	if m.config.Auth.LocalOnly {
+		return nil
+	}
+	m.handleLogout()
+	m.SetStack([]Model{NewAuthModelWithError("To switch accounts, log out on the AniList website before logging " +
+		"in, or paste a token for the other account")})
+	return m.CurrentModel().Init()
+}
+
+// sessionInfo gathers the details shown on the session screen
+func (m *AppModel) sessionInfo() SessionInfo {
+	info := SessionInfo{
+		User:             m.user,
+		LocalOnly:        m.config.Auth.LocalOnly,
+		TokenSource:      config.TokenSource(m.config.Auth.Token),
+		RateLimit:        anilist.RateLimitStatus{Remaining: -1},
+		RateLimitedUntil: m.rateLimitedUntil,
+	}
+	info.IssuedAt, _ = auth.TokenIssuedAt(m.config.Auth.Token)
+	info.ExpiresAt, _ = auth.TokenExpiry(m.config.Auth.Token)
+	if m.client != nil {
+		info.RateLimit = m.client.RateLimitStatus()
+	}
+	return info
+}
+
 func (m *AppModel) handleToggleHelp() tea.Cmd {
 	// Toggle help screen
 	if _, ok := m.CurrentModel().(*HelpModel); ok {
@@ -427,46 +851,219 @@ func (m *AppModel) handleToggleHelp() tea.Cmd {
 	return nil
 }
 
+func (m *AppModel) handleShowAPIUsage() tea.Cmd {
+	// Don't stack multiple copies of the inspector
+	if _, ok := m.CurrentModel().(*APIUsageModel); ok {
+		return nil
+	}
+	return m.PushModel(NewAPIUsageModel())
+}
+
+// handleShowQuickPlay opens the quick play launcher once the list has been loaded
+func (m *AppModel) handleShowQuickPlay() tea.Cmd {
+	if m.animeService == nil {
+		return nil
+	}
+	switch m.CurrentModel().(type) {
+	case *QuickPlayModel, *LoadingModel:
+		return nil
+	}
+	return m.PushModel(NewQuickPlayModel(m.animeService))
+}
+
+// handleShowContinueWatching opens the continue watching panel once the list has been loaded
+func (m *AppModel) handleShowContinueWatching() tea.Cmd {
+	if m.animeService == nil {
+		return nil
+	}
+	switch m.CurrentModel().(type) {
+	case *ContinueWatchingModel, *LoadingModel:
+		return nil
+	}
+	return m.PushModel(NewContinueWatchingModel(m.animeService))
+}
+
+// handleShowAniListSearch opens the AniList search view once the list has been loaded
+func (m *AppModel) handleShowAniListSearch() tea.Cmd {
+	if m.animeService == nil {
+		return nil
+	}
+	switch m.CurrentModel().(type) {
+	case *AniListSearchModel, *LoadingModel:
+		return nil
+	}
+	return m.PushModel(NewAniListSearchModel(m.animeService))
+}
+
+// showStartupContinueWatching shows the continue watching panel the first time the anime list loads, if enabled and
+// there is anything to continue
+func (m *AppModel) showStartupContinueWatching() tea.Cmd {
+	if m.continueShown || m.config.UI.StartupContinueWatching != "panel" || m.animeService == nil {
+		return nil
+	}
+	m.continueShown = true
+
+	continueModel := NewContinueWatchingModel(m.animeService)
+	if len(continueModel.entries) == 0 {
+		log.Debug("Nothing to continue watching, skipping the startup panel")
+		return nil
+	}
+	return m.PushModel(continueModel)
+}
+
+// showStartupAgenda shows the airing agenda the first time the anime list loads, if anything airs soon
+func (m *AppModel) showStartupAgenda() tea.Cmd {
+	if m.agendaShown || m.config.UI.StartupAgenda == "off" || m.animeService == nil {
+		return nil
+	}
+	m.agendaShown = true
+
+	if until := m.animeService.AgendaSnoozedUntil(); !until.IsZero() {
+		log.Debug("Airing agenda is snoozed, skipping startup agenda", "until", until)
+		return nil
+	}
+	entries := m.animeService.GetAiringAgenda(agendaWindow)
+	if len(entries) == 0 {
+		log.Debug("Nothing airing soon, skipping startup agenda")
+		return nil
+	}
+	return m.PushModel(NewAgendaModel(m.animeService, entries, util.ResolveLocation(m.config.UI.Timezone)))
+}
+
 // handleSuccessfulAuth handles a successful authentication
 func (m *AppModel) handleSuccessfulAuth(token string) tea.Cmd {
 	log.Info("Authentication successful")
 
+	// Initialize AniList client and services.  A pasted token may not be valid, so check it before saving it.
+	client, err := anilist.NewClient(token, m.config.Network.Retry)
+	reauth := false
+	if current, ok := m.CurrentModel().(*AuthModel); ok && current.reauth {
+		reauth = true
+	}
+	if err != nil {
+		log.Error("Failed to create AniList client after authentication", "error", err)
+		if reauth {
+			m.PopModel()
+			retry := NewReauthModel()
+			retry.status = fmt.Sprintf("Login failed: %v", err)
+			return m.PushModel(retry)
+		}
+		m.SetStack([]Model{NewAuthModelWithError(fmt.Sprintf("Login failed: %v", err))})
+		return m.CurrentModel().Init()
+	}
+
 	// Save the token to the config
 	m.config.Auth.Token = token
-	err := config.UpdateConfig(func(conf *config.Config) {
+	err = config.UpdateConfig(func(conf *config.Config) {
 		conf.Auth.Token = token
 	})
 	if err != nil {
 		log.Warn("Error saving auth token to config. Will need to reauthenticate when Hisame opens next", "error", err)
 	}
 
-	// Initialize AniList client and services
-	client, err := anilist.NewClient(token)
-	if err != nil {
-		log.Error("Failed to create AniList client after authentication", "error", err)
-		return tea.Quit
+	// Logging in again as the same user carries on with the existing views and services, swapping in the new token
+	if reauth && m.client != nil && client.GetUser().ID == m.user.ID {
+		log.Info("Logged in again, resuming", "user", m.user.ID)
+		m.client.SetToken(token)
+		m.PopModel()
+		return m.watchTokenExpiry(token)
 	}
 
 	// Set up the anime service and models
-	animeRepo := anilist.NewAnimeRepository(client)
+	m.client = client
+	m.user = client.GetUser()
+	m.rateLimits = client.RateLimited()
+	animeRepo := anilist.NewAnimeRepository(client, m.config)
 	m.animeService = service.NewAnimeService(animeRepo)
+	m.animeService.UseListCache(m.user.ID)
 	//m.animeListModel = NewAnimeListModel(m.config, m.animeService)
 
 	// Replace the entire stack with just the anime list model
-	m.SetStack([]Model{NewAnimeListModel(m.config, m.animeService)})
+	m.SetStack([]Model{NewAnimeListModel(m.config, m.animeService, m.user)})
 
 	// Initialize the anime list model
-	return m.CurrentModel().Init()
+	return tea.Batch(m.listenForRateLimits(), m.listenForUnauthorized(), m.watchTokenExpiry(token),
+		m.CurrentModel().Init())
+}
+
+// startLocalMode sets up the services with the local list, looking anime up on AniList without logging in
+func (m *AppModel) startLocalMode() tea.Cmd {
+	log.Info("Starting in local-only mode")
+
+	client := anilist.NewAnonymousClient(m.config.Network.Retry)
+	// The local list scores out of 100
+	m.user = domain.User{Name: "Local", ScoreFormat: domain.ScoreFormat100}
+	m.rateLimits = client.RateLimited()
+	m.animeService = service.NewAnimeService(local.NewDefaultRepository(anilist.NewAnimeRepository(client, m.config)))
+
+	m.SetStack([]Model{NewAnimeListModel(m.config, m.animeService, m.user)})
+	return tea.Batch(m.listenForRateLimits(), m.CurrentModel().Init())
+}
+
+// listenForUnauthorized waits for AniList to reject the login token part way through the session
+func (m *AppModel) listenForUnauthorized() tea.Cmd {
+	if m.client == nil {
+		return nil
+	}
+	unauthorized := m.client.Unauthorized()
+	return func() tea.Msg {
+		<-unauthorized
+		return TokenExpiredMsg{}
+	}
+}
+
+// watchTokenExpiry asks the user to log in again when the token expires, rather than waiting for requests to fail
+func (m *AppModel) watchTokenExpiry(token string) tea.Cmd {
+	expiry, ok := auth.TokenExpiry(token)
+	if !ok {
+		return nil
+	}
+	log.Info("AniList login expires", "at", expiry)
+	return tea.Tick(time.Until(expiry), func(time.Time) tea.Msg {
+		return TokenExpiredMsg{Token: token}
+	})
+}
+
+// listenForRateLimits waits for the AniList client to report requests being held back by the rate limit
+func (m *AppModel) listenForRateLimits() tea.Cmd {
+	rateLimits := m.rateLimits
+	return func() tea.Msg {
+		return RateLimitedMsg{Wait: <-rateLimits}
+	}
+}
+
+// rateLimitTick schedules the next update of the rate limit countdown
+func rateLimitTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return RateLimitTickMsg{}
+	})
 }
 
 func (m AppModel) View() string {
 	// Render the current model
 	current := m.CurrentModel()
 	if current == nil {
-		return "Error: No active model to display\nThis should not happen.  Please exit Hisame with ctrl+c"
+		return "Error: No active model to display\nThis should not happen.  Please exit Hisame with " +
+			kb.DisplayKey(kb.ActionQuit, kb.ContextGlobal)
 	}
 
-	return current.View()
+	view := current.View()
+	if m.quitWhenIdle {
+		view = replaceLastLine(view, styles.Warning.Render(m.quitWaitNotice()))
+	} else if remaining := time.Until(m.rateLimitedUntil); remaining > 0 {
+		notice := styles.Warning.Render(fmt.Sprintf("AniList rate limited, retrying in %ds",
+			int(remaining.Round(time.Second).Seconds())))
+		view = replaceLastLine(view, notice)
+	}
+	return view
+}
+
+// replaceLastLine shows the notice in place of the view's last line, which is the key bindings bar in most views
+func replaceLastLine(view, notice string) string {
+	if i := strings.LastIndex(view, "\n"); i >= 0 {
+		return view[:i+1] + notice
+	}
+	return view + "\n" + notice
 }
 
 func (m AppModel) validateTokenCmd() tea.Cmd {
@@ -480,8 +1077,16 @@ func (m AppModel) validateTokenCmd() tea.Cmd {
 			}
 		}
 
+		// An expired token would be rejected anyway, so skip straight to logging in again
+		if expiry, ok := auth.TokenExpiry(token); ok && time.Now().After(expiry) {
+			return TokenValidationMsg{
+				Valid: false,
+				Error: fmt.Errorf("the AniList login expired on %s", expiry.Format(time.DateOnly)),
+			}
+		}
+
 		// Validate token by making API call
-		client, err := anilist.NewClient(token)
+		client, err := anilist.NewClient(token, m.config.Network.Retry)
 		if err != nil {
 			// Handle various error types as before
 			var netErr anilist.NetworkError
@@ -0,0 +1,103 @@
+package models
+
+// app_quit.go asks for confirmation before quitting while changes are still saving to AniList or an episode is
+// playing, as quitting part way through would lose them.
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/log"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// quitMenuTitle is the title of the quit confirmation, used to tell when it is already open
+	quitMenuTitle = "Quit Hisame?"
+	// quitWaitInterval is how often Hisame checks whether it can quit, after the user chose to wait
+	quitWaitInterval = 500 * time.Millisecond
+)
+
+// unfinishedWork returns the number of changes still saving to AniList, and whether an episode is playing
+func (m AppModel) unfinishedWork() (changes int, playing bool) {
+	if m.animeService != nil {
+		changes = m.animeService.PendingChanges()
+	}
+	if model, ok := m.getModel(ViewAnimeList).(*AnimeListModel); ok {
+		playing = model.playerService.IsPlaying()
+	}
+	return changes, playing
+}
+
+// handleQuit quits straight away if there is nothing to lose, otherwise asks whether to wait, leave the player
+// running or discard the work.  Quitting again from the confirmation, or while waiting, quits without asking.
+func (m *AppModel) handleQuit() tea.Cmd {
+	changes, playing := m.unfinishedWork()
+	menu, menuOpen := m.CurrentModel().(*MenuModel)
+	if m.quitWhenIdle || (menuOpen && menu.Title == quitMenuTitle) || (changes == 0 && !playing) {
+		log.Info("Quit command received. Shutting down...")
+		return tea.Quit
+	}
+
+	log.Info("Quit command received with unfinished work, asking for confirmation", "changes", changes, "playing", playing)
+	selectMsg := func(msg tea.Msg) tea.Cmd {
+		return func() tea.Msg {
+			return MenuSelectionMsg{CloseMenu: true, NextMsg: msg}
+		}
+	}
+
+	var items []MenuItem
+	if changes > 0 {
+		items = append(items, MenuItem{IsSeparator: true, Text: fmt.Sprintf("Changes still saving to AniList: %d", changes)})
+	}
+	if playing {
+		items = append(items, MenuItem{IsSeparator: true, Text: "An episode is playing and its progress isn't saved yet"})
+	}
+	items = append(items, MenuItem{Text: "Wait for it to finish, then quit", Command: selectMsg(QuitWhenIdleMsg{})})
+	if playing {
+		items = append(items, MenuItem{Text: "Quit now and leave the player running", Command: selectMsg(QuitNowMsg{})})
+	}
+	items = append(items,
+		MenuItem{Text: "Quit now and discard it", Command: selectMsg(QuitNowMsg{StopPlayback: playing})},
+		MenuItem{Text: "Cancel", Command: selectMsg(nil)},
+	)
+
+	return func() tea.Msg {
+		return ShowMenuMsg{Menu: NewMenuModel(quitMenuTitle, items)}
+	}
+}
+
+// handleQuitWaitTick quits once the work the user chose to wait for is done
+func (m *AppModel) handleQuitWaitTick() tea.Cmd {
+	if !m.quitWhenIdle {
+		return Handled("quit_wait:cancelled")
+	}
+
+	if changes, playing := m.unfinishedWork(); changes > 0 || playing {
+		return quitWaitTick()
+	}
+	log.Info("Unfinished work is done. Shutting down...")
+	return tea.Quit
+}
+
+// quitWaitNotice describes what Hisame is waiting on before it quits
+func (m AppModel) quitWaitNotice() string {
+	changes, playing := m.unfinishedWork()
+	quitNow := fmt.Sprintf("%s to quit now", kb.DisplayKey(kb.ActionQuit, kb.ContextGlobal))
+	switch {
+	case changes > 0 && playing:
+		return fmt.Sprintf("Quitting once changes are saved (%d left) and playback ends.  %s", changes, quitNow)
+	case changes > 0:
+		return fmt.Sprintf("Quitting once changes are saved (%d left).  %s", changes, quitNow)
+	default:
+		return "Quitting once playback ends.  " + quitNow
+	}
+}
+
+// quitWaitTick schedules the next check of whether Hisame can quit
+func quitWaitTick() tea.Cmd {
+	return tea.Tick(quitWaitInterval, func(time.Time) tea.Msg {
+		return QuitWaitTickMsg{}
+	})
+}
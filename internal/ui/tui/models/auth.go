@@ -1,11 +1,17 @@
 package models
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
 	"github.com/PizzaHomicide/hisame/internal/auth"
 	"github.com/PizzaHomicide/hisame/internal/log"
 	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
 	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
 	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -28,14 +34,44 @@ type AuthModel struct {
 	width, height  int
 	authInProgress bool
 	authUrl        string
+	cancelAuth     context.CancelFunc // Gives up on the login in progress, shutting down the callback server
+
+	// Manual login, for machines where the browser can't reach the callback server
+	pasting    bool
+	tokenInput textinput.Model
+	status     string // Why the last login failed, if it did
+
+	reauth bool // Shown over the other views because the login expired, so they can carry on once logged in again
 }
 
 func NewAuthModel() *AuthModel {
+	ti := textinput.New()
+	ti.Placeholder = "Paste the address or token"
+	ti.EchoMode = textinput.EchoPassword
+	ti.EchoCharacter = '•'
+	ti.CharLimit = 4096
+
 	return &AuthModel{
-		authUrl: "Authentication URL not available",
+		authUrl:    "Authentication URL not available",
+		tokenInput: ti,
 	}
 }
 
+// NewReauthModel creates an auth model asking the user to log in again because AniList rejected the token, to be
+// pushed over the current view
+func NewReauthModel() *AuthModel {
+	m := NewAuthModel()
+	m.reauth = true
+	return m
+}
+
+// NewAuthModelWithError creates an auth model explaining why the last login failed
+func NewAuthModelWithError(status string) *AuthModel {
+	m := NewAuthModel()
+	m.status = status
+	return m
+}
+
 func (m *AuthModel) ViewType() View {
 	return ViewAuth
 }
@@ -47,25 +83,85 @@ func (m *AuthModel) Init() tea.Cmd {
 func (m *AuthModel) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.pasting {
+			return m, m.handlePasteKeyMsg(msg)
+		}
+		if m.authInProgress {
+			if kb.GetActionByKey(msg, kb.ContextGlobal) != kb.ActionBack {
+				return m, nil
+			}
+			log.Info("Login cancelled")
+			m.Reset()
+			if m.reauth {
+				// Left unhandled, so the app closes this view and carries on with the one underneath
+				return m, nil
+			}
+			return m, Handled("auth:cancel_login")
+		}
+
 		switch kb.GetActionByKey(msg, kb.ContextAuth) {
 		case kb.ActionLogin:
 			log.Info("Start login..")
 			m.authInProgress = true
+			m.status = ""
 			return m, m.startAuth()
+		case kb.ActionPasteToken:
+			log.Info("Start manual login")
+			m.pasting = true
+			m.status = ""
+			m.authUrl = auth.NewAuth().LoginURL.String()
+			m.tokenInput.SetValue("")
+			return m, m.tokenInput.Focus()
 		}
 	}
 
 	return m, nil
 }
 
+// handlePasteKeyMsg handles keys while a token is being pasted.  Keys are typed into the input, apart from the search
+// mode keys to complete, which logs in, and to go back, which cancels.
+func (m *AuthModel) handlePasteKeyMsg(msg tea.KeyMsg) tea.Cmd {
+	switch kb.GetActionByKey(msg, kb.ContextSearchMode) {
+	case kb.ActionBack:
+		m.pasting = false
+		m.tokenInput.Blur()
+		return Handled("auth:cancel_paste")
+	case kb.ActionSearchComplete:
+		token, err := auth.ParseManualToken(m.tokenInput.Value())
+		if err != nil {
+			m.status = err.Error()
+			return Handled("auth:invalid_token")
+		}
+		m.pasting = false
+		m.authInProgress = true
+		m.tokenInput.Blur()
+		return func() tea.Msg {
+			return AuthMsg{Success: true, Token: token}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.tokenInput, cmd = m.tokenInput.Update(msg)
+	if cmd == nil {
+		cmd = Handled("auth:input")
+	}
+	return cmd
+}
+
 // startAuth begins the authentication process
 func (m *AuthModel) startAuth() tea.Cmd {
 	authManager := auth.NewAuth()
 	m.authUrl = authManager.LoginURL.String()
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelAuth = cancel
 	return func() tea.Msg {
-		result := authManager.DoAuth()
+		result := authManager.DoAuth(ctx)
 		m.authInProgress = false
 
+		if errors.Is(result.Error, context.Canceled) {
+			return HandledMsg{Message: "auth:cancelled"}
+		}
+
 		if result.Error != nil {
 			return AuthMsg{
 				Success: false,
@@ -80,8 +176,12 @@ func (m *AuthModel) startAuth() tea.Cmd {
 	}
 }
 
-// Reset resets the auth model so it is ready to do a fresh login if necessary
+// Reset resets the auth model so it is ready to do a fresh login if necessary, giving up on any login in progress
 func (m *AuthModel) Reset() {
+	if m.cancelAuth != nil {
+		m.cancelAuth()
+		m.cancelAuth = nil
+	}
 	m.authInProgress = false
 	m.authUrl = ""
 }
@@ -94,13 +194,15 @@ func (m *AuthModel) View() string {
 
 	// If terminal is extremely small, show a simplified view
 	if m.width < MinWidth || m.height < MinHeight {
-		return "Terminal too small\nResize or press ctrl+c"
+		return "Terminal too small\nResize or press " + kb.DisplayKey(kb.ActionQuit, kb.ContextGlobal)
 	}
 
 	header := styles.Header(contentWidth, "Hisame")
 
 	var content string
-	if m.authInProgress {
+	if m.pasting {
+		content = m.pasteContent(contentWidth)
+	} else if m.authInProgress {
 		content = m.authInProgressContent(contentWidth)
 	} else {
 		content = m.initialContent(contentWidth)
@@ -110,16 +212,27 @@ func (m *AuthModel) View() string {
 	mainContent := styles.ContentBox(contentWidth, content, 1)
 
 	var keyBindings []components.KeyBinding
-	if m.authInProgress {
+	if m.pasting {
+		keyBindings = []components.KeyBinding{
+			{kb.DisplayKey(kb.ActionSearchComplete, kb.ContextSearchMode), "Log in"},
+			{kb.DisplayKey(kb.ActionBack, kb.ContextSearchMode), "Cancel"},
+			{kb.DisplayKey(kb.ActionQuit, kb.ContextGlobal), "Quit"},
+		}
+	} else if m.authInProgress {
 		keyBindings = []components.KeyBinding{
 			{"Browser", "Login with AniList"},
-			{"Ctrl+C", "Quit"},
+			{kb.DisplayKey(kb.ActionBack, kb.ContextGlobal), "Cancel"},
+			{kb.DisplayKey(kb.ActionQuit, kb.ContextGlobal), "Quit"},
 		}
 	} else {
 		keyBindings = []components.KeyBinding{
-			{"l", "Login"},
-			{"Ctrl+h", "Help"},
-			{"Ctrl+c", "Quit"},
+			{kb.DisplayKeyWithSecondary(kb.ActionLogin, kb.ContextAuth), "Login"},
+			{kb.DisplayKey(kb.ActionPasteToken, kb.ContextAuth), "Paste token"},
+			{kb.DisplayKey(kb.ActionToggleHelp, kb.ContextAuth), "Help"},
+			{kb.DisplayKey(kb.ActionQuit, kb.ContextAuth), "Quit"},
+		}
+		if m.reauth {
+			keyBindings = append(keyBindings, components.KeyBinding{kb.DisplayKey(kb.ActionBack, kb.ContextAuth), "Later"})
 		}
 	}
 
@@ -141,15 +254,52 @@ func (m *AuthModel) View() string {
 func (m *AuthModel) initialContent(contentWidth int) string {
 	content := styles.CenteredText(contentWidth-HorizontalPadding,
 		styles.Info.Render("You need to authenticate with AniList to use Hisame."))
+	if m.reauth {
+		content = styles.CenteredText(contentWidth-HorizontalPadding, styles.Warning.Render(
+			"Your AniList login has expired.  Log in again to carry on where you left off."))
+	}
 	content += "\n\n"
 
+	login := kb.DisplayKey(kb.ActionLogin, kb.ContextAuth)
+	pasteToken := kb.DisplayKey(kb.ActionPasteToken, kb.ContextAuth)
 	content += styles.CenteredText(contentWidth-HorizontalPadding,
-		styles.Info.Render("When you press 'l' a browser will open to authenticate with Anilist")) + "\n"
+		styles.Info.Render(fmt.Sprintf("When you press %s a browser will open to authenticate with Anilist", login))) + "\n"
 	content += styles.CenteredText(contentWidth-HorizontalPadding,
 		styles.Info.Render("After seeing the Hisame login success screen in your browser, continue in this application")) + "\n\n"
 
+	if os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_TTY") != "" {
+		content += styles.CenteredText(contentWidth-HorizontalPadding,
+			styles.Warning.Width(contentWidth-HorizontalPadding*2).Render("This looks like an SSH session, where the "+
+				"browser login can't reach Hisame.  Press "+pasteToken+" to paste a token instead.")) + "\n\n"
+	}
+
+	content += styles.CenteredText(contentWidth-HorizontalPadding,
+		styles.Info.Render(fmt.Sprintf("Press %s to login, %s to paste a token, or %s to quit.", login, pasteToken,
+			kb.DisplayKey(kb.ActionQuit, kb.ContextAuth))))
+
+	if m.status != "" {
+		content += "\n\n" + styles.CenteredText(contentWidth-HorizontalPadding, styles.Error.Render(m.status))
+	}
+
+	return content
+}
+
+func (m *AuthModel) pasteContent(contentWidth int) string {
+	content := styles.CenteredText(contentWidth-HorizontalPadding, styles.Info.Render("Log in by pasting a token"))
+	content += "\n\n"
+
+	content += styles.CenteredText(contentWidth-HorizontalPadding, styles.Url.Render(m.authUrl))
+	content += "\n\n"
+
 	content += styles.CenteredText(contentWidth-HorizontalPadding,
-		styles.Info.Render("Press 'l' to login or 'ctrl+c' to quit."))
+		styles.Info.Width(contentWidth-HorizontalPadding*2).Render(auth.ManualLoginInstructions))
+	content += "\n\n"
+
+	content += m.tokenInput.View()
+
+	if m.status != "" {
+		content += "\n\n" + styles.CenteredText(contentWidth-HorizontalPadding, styles.Error.Render(m.status))
+	}
 
 	return content
 }
@@ -171,4 +321,5 @@ func (m *AuthModel) authInProgressContent(contentWidth int) string {
 func (m *AuthModel) Resize(width, height int) {
 	m.width = width
 	m.height = height
+	m.tokenInput.Width = max(10, min(width-HorizontalPadding, MaxContentWidth)-HorizontalPadding*4)
 }
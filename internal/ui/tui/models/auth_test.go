@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthEscCancelsLoginInProgress(t *testing.T) {
+	for _, reauth := range []bool{false, true} {
+		m := NewAuthModel()
+		m.reauth = reauth
+		m.authInProgress = true
+		cancelled := false
+		m.cancelAuth = func() { cancelled = true }
+
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		assert.True(t, cancelled, "the callback server should be shut down")
+		assert.False(t, m.authInProgress)
+		if reauth {
+			assert.Nil(t, cmd, "the app should close the login over the other views")
+		} else {
+			assert.NotNil(t, cmd, "there's nothing under the login to go back to")
+		}
+	}
+}
+
+func TestAuthIgnoresKeysWhileLoginInProgress(t *testing.T) {
+	m := NewAuthModel()
+	m.authInProgress = true
+	m.cancelAuth = func() { t.Fatal("the login shouldn't be cancelled") }
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	assert.Nil(t, cmd)
+	assert.True(t, m.authInProgress)
+}
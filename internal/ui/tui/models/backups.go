@@ -0,0 +1,187 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/service"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// BackupsModel lists the snapshots taken before batch operations and lets the user restore one
+type BackupsModel struct {
+	width, height int
+	animeService  *service.AnimeService
+	backups       []service.Backup
+	cursor        int
+	status        string
+}
+
+// NewBackupsModel creates a new backups model
+func NewBackupsModel(animeService *service.AnimeService) *BackupsModel {
+	m := &BackupsModel{
+		animeService: animeService,
+	}
+	m.loadBackups()
+	return m
+}
+
+func (m *BackupsModel) ViewType() View {
+	return ViewBackups
+}
+
+// Init initializes the model
+func (m *BackupsModel) Init() tea.Cmd {
+	return nil
+}
+
+// loadBackups reads the available backups, keeping the cursor in range
+func (m *BackupsModel) loadBackups() {
+	backups, err := m.animeService.GetBackups()
+	if err != nil {
+		log.Error("Failed to list backups", "error", err)
+		m.status = fmt.Sprintf("Failed to list backups: %v", err)
+	}
+	m.backups = backups
+	if m.cursor >= len(m.backups) {
+		m.cursor = max(0, len(m.backups)-1)
+	}
+}
+
+// Update handles messages
+func (m *BackupsModel) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case BackupRestoredMsg:
+		if msg.Error != nil {
+			m.status = fmt.Sprintf("Restore did not finish: %v (%d restored)", msg.Error, msg.Result.Restored)
+		} else {
+			m.status = fmt.Sprintf("Restored %d entries, %d failed", msg.Result.Restored, msg.Result.Failed)
+		}
+		// Restoring takes a backup of its own, so show it
+		m.loadBackups()
+		return m, nil
+
+	case tea.KeyMsg:
+		switch kb.GetActionByKey(msg, kb.ContextBackups) {
+		case kb.ActionMoveUp:
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, Handled("cursor_move:up")
+		case kb.ActionMoveDown:
+			if m.cursor < len(m.backups)-1 {
+				m.cursor++
+			}
+			return m, Handled("cursor_move:down")
+		case kb.ActionMoveTop:
+			m.cursor = 0
+			return m, Handled("cursor_move:top")
+		case kb.ActionMoveBottom:
+			m.cursor = max(0, len(m.backups)-1)
+			return m, Handled("cursor_move:bottom")
+		case kb.ActionRestoreBackup:
+			if m.cursor >= len(m.backups) {
+				return m, Handled("restore:none_selected")
+			}
+			return m, m.confirmRestore(m.backups[m.cursor])
+		}
+	}
+
+	return m, nil
+}
+
+// confirmRestore asks the user to confirm before overwriting their entries with the backup
+func (m *BackupsModel) confirmRestore(backup service.Backup) tea.Cmd {
+	menuModel := NewMenuModel("Restore backup", []MenuItem{
+		{
+			Text: fmt.Sprintf("Restore %d entries to how they were on %s?", len(backup.Entries),
+				backup.CreatedAt.Local().Format("2006-01-02 15:04")),
+			IsSeparator: true,
+		},
+		{
+			Text: "Yes, restore them",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg:   RestoreBackupMsg{Backup: backup},
+				}
+			},
+		},
+		{
+			Text: "No, go back",
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{CloseMenu: true}
+			},
+		},
+	})
+	return func() tea.Msg {
+		return ShowMenuMsg{Menu: menuModel}
+	}
+}
+
+// View renders the backups
+func (m *BackupsModel) View() string {
+	header := styles.Header(m.width, "List Backups")
+
+	summary := fmt.Sprintf("%d backups taken before batch changes", len(m.backups))
+	if m.status != "" {
+		summary += "  •  " + m.status
+	}
+
+	keyBindings := []components.KeyBinding{
+		{kb.DisplayKeys(kb.ContextBackups, kb.ActionMoveUp, kb.ActionMoveDown), "Navigate"},
+		{kb.DisplayKeyWithSecondary(kb.ActionRestoreBackup, kb.ContextBackups), "Restore"},
+		{kb.DisplayKey(kb.ActionToggleHelp, kb.ContextBackups), "Help"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextBackups), "Return"},
+	}
+	footer := components.KeyBindingsBar(m.width, keyBindings)
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s", header, styles.FilterStatus.Render(summary), m.renderBackups(), footer)
+}
+
+// renderBackups renders the scrollable list of backups
+func (m *BackupsModel) renderBackups() string {
+	if len(m.backups) == 0 {
+		return styles.CenteredText(m.width, "No backups yet.  One is taken automatically before each batch change.")
+	}
+
+	visibleCount := min(len(m.backups), max(1, m.height-12))
+	startIdx := 0
+	if m.cursor >= visibleCount {
+		startIdx = m.cursor - visibleCount + 1
+	}
+	endIdx := min(startIdx+visibleCount, len(m.backups))
+
+	selectedStyle := styles.Selected.
+		Width(m.width-4).
+		Padding(0, 1)
+
+	normalStyle := lipgloss.NewStyle().
+		Width(m.width-4).
+		Padding(0, 1)
+
+	var listContent string
+	for i := startIdx; i < endIdx; i++ {
+		backup := m.backups[i]
+		itemText := fmt.Sprintf("%s  %-30s  %d entries", backup.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+			backup.Reason, len(backup.Entries))
+
+		if i == m.cursor {
+			listContent += selectedStyle.Render(itemText) + "\n"
+		} else {
+			listContent += normalStyle.Render(itemText) + "\n"
+		}
+	}
+
+	return styles.ContentBox(m.width-2, listContent, 1)
+}
+
+// Resize updates the dimensions of the model
+func (m *BackupsModel) Resize(width, height int) {
+	m.width = width
+	m.height = height
+}
@@ -0,0 +1,212 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/service"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/util"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// backfillOption is one of the ways a missing completion date can be filled in
+type backfillOption struct {
+	source      service.CompletionDateSource
+	label       string
+	description string
+}
+
+var backfillOptions = []backfillOption{
+	{service.CompletionDateFromUpdatedAt, "Use last updated date", "The date the list entry was last changed on AniList"},
+	{service.CompletionDateToday, "Use today's date", "Mark every entry as completed today"},
+	{service.CompletionDateManual, "Enter a date", "Use the same date, entered below, for every entry"},
+}
+
+// CompletionBackfillModel guides the user through filling in missing completion dates on completed entries
+type CompletionBackfillModel struct {
+	width, height int
+	animeService  *service.AnimeService
+	entries       []*domain.Anime
+	cursor        int
+	dateInput     textinput.Model
+	enteringDate  bool
+	err           string
+}
+
+// NewCompletionBackfillModel creates a new backfill model for the given completed entries with no completion date
+func NewCompletionBackfillModel(animeService *service.AnimeService, entries []*domain.Anime) *CompletionBackfillModel {
+	ti := textinput.New()
+	ti.Placeholder = "YYYY-MM-DD"
+	ti.CharLimit = 10
+	ti.Width = 12
+
+	return &CompletionBackfillModel{
+		animeService: animeService,
+		entries:      entries,
+		dateInput:    ti,
+	}
+}
+
+func (m *CompletionBackfillModel) ViewType() View {
+	return ViewCompletionBackfill
+}
+
+// Init initializes the model
+func (m *CompletionBackfillModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m *CompletionBackfillModel) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.enteringDate {
+		return m, m.handleDateInput(keyMsg)
+	}
+
+	switch kb.GetActionByKey(keyMsg, kb.ContextCompletionBackfill) {
+	case kb.ActionMoveUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, Handled("cursor_move:up")
+	case kb.ActionMoveDown:
+		if m.cursor < len(backfillOptions)-1 {
+			m.cursor++
+		}
+		return m, Handled("cursor_move:down")
+	case kb.ActionSelectMenuItem:
+		option := backfillOptions[m.cursor]
+		if option.source == service.CompletionDateManual {
+			m.enteringDate = true
+			m.err = ""
+			return m, m.dateInput.Focus()
+		}
+		return m, m.applyBackfill(option.source, time.Time{})
+	}
+
+	return m, nil
+}
+
+// handleDateInput handles key presses while the manual date input is focused
+func (m *CompletionBackfillModel) handleDateInput(msg tea.KeyMsg) tea.Cmd {
+	switch kb.GetActionByKey(msg, kb.ContextSearchMode) {
+	case kb.ActionBack:
+		m.enteringDate = false
+		m.dateInput.Blur()
+		return Handled("completion_backfill:cancel_date")
+	case kb.ActionSearchComplete:
+		date, err := time.ParseInLocation("2006-01-02", m.dateInput.Value(), time.Local)
+		if err != nil {
+			m.err = "Dates must be in the format YYYY-MM-DD"
+			return Handled("completion_backfill:invalid_date")
+		}
+		if date.After(time.Now()) {
+			m.err = "Completion dates cannot be in the future"
+			return Handled("completion_backfill:future_date")
+		}
+		m.enteringDate = false
+		m.dateInput.Blur()
+		return m.applyBackfill(service.CompletionDateManual, date)
+	}
+
+	var cmd tea.Cmd
+	m.dateInput, cmd = m.dateInput.Update(msg)
+	return cmd
+}
+
+// applyBackfill starts updating every entry using the chosen date source
+func (m *CompletionBackfillModel) applyBackfill(source service.CompletionDateSource, manualDate time.Time) tea.Cmd {
+	ids := make([]int, 0, len(m.entries))
+	for _, anime := range m.entries {
+		ids = append(ids, anime.ID)
+	}
+
+	return func() tea.Msg {
+		return LoadingMsg{
+			Type:    LoadingStart,
+			Message: fmt.Sprintf("Backfilling completion dates for %d entries...", len(ids)),
+			Title:   "Updating AniList",
+			Operation: func() tea.Msg {
+				// Each entry is a separate mutation, so allow plenty of time for large imported lists
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+				defer cancel()
+
+				result, err := m.animeService.BackfillCompletionDates(ctx, ids, source, manualDate)
+				return CompletionBackfillResultMsg{Result: result, Error: err}
+			},
+		}
+	}
+}
+
+// View renders the backfill flow
+func (m *CompletionBackfillModel) View() string {
+	header := styles.Header(m.width, "Backfill Completion Dates")
+	summary := styles.FilterStatus.Render(
+		fmt.Sprintf("%d completed entries have no completion date.  Choose how to fill them in:", len(m.entries)))
+
+	selectedStyle := styles.Selected.Padding(0, 1)
+	normalStyle := lipgloss.NewStyle().Padding(0, 1)
+	descStyle := lipgloss.NewStyle().Foreground(styles.ColorFaint)
+
+	var options string
+	for i, option := range backfillOptions {
+		style := normalStyle
+		if i == m.cursor {
+			style = selectedStyle
+		}
+		options += style.Render(option.label) + "  " + descStyle.Render(option.description) + "\n"
+	}
+
+	if m.enteringDate {
+		options += "\nCompletion date: " + m.dateInput.View() + "\n"
+	}
+	if m.err != "" {
+		options += "\n" + lipgloss.NewStyle().Foreground(styles.ColorError).Render(m.err) + "\n"
+	}
+
+	keyBindings := []components.KeyBinding{
+		{kb.DisplayKeys(kb.ContextCompletionBackfill, kb.ActionMoveUp, kb.ActionMoveDown), "Navigate"},
+		{kb.DisplayKey(kb.ActionSelectMenuItem, kb.ContextCompletionBackfill), "Apply"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextCompletionBackfill), "Cancel"},
+	}
+	footer := components.KeyBindingsBar(m.width, keyBindings)
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s\n%s\n\n%s", header, summary, options, m.renderEntries(), footer)
+}
+
+// renderEntries lists the entries that will be updated, as many as fit on screen
+func (m *CompletionBackfillModel) renderEntries() string {
+	available := max(1, m.height-20)
+
+	var content string
+	for i, anime := range m.entries {
+		if i >= available {
+			content += fmt.Sprintf("...and %d more\n", len(m.entries)-i)
+			break
+		}
+		updated := "no update date"
+		if anime.UserData != nil && anime.UserData.UpdatedAt > 0 {
+			updated = "last updated " + time.Unix(anime.UserData.UpdatedAt, 0).Format("2006-01-02")
+		}
+		content += fmt.Sprintf("%s  (%s)\n", util.TruncateString(anime.Title.Preferred, 60), updated)
+	}
+
+	return styles.ContentBox(m.width-2, content, 1)
+}
+
+// Resize updates the dimensions of the model
+func (m *CompletionBackfillModel) Resize(width, height int) {
+	m.width = width
+	m.height = height
+}
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/service"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/util"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// continueWatchingLimit is how many anime the continue watching panel lists
+const continueWatchingLimit = 10
+
+// ContinueWatchingModel lists the anime with aired episodes left to watch, the most recently watched first, so the
+// next episode of whatever was being watched is a key press away
+type ContinueWatchingModel struct {
+	width, height int
+	entries       []*domain.Anime
+	cursor        int
+}
+
+// NewContinueWatchingModel creates a new continue watching model from the user's list
+func NewContinueWatchingModel(animeService *service.AnimeService) *ContinueWatchingModel {
+	return &ContinueWatchingModel{
+		entries: animeService.GetContinueWatching(continueWatchingLimit),
+	}
+}
+
+func (m *ContinueWatchingModel) ViewType() View {
+	return ViewContinueWatching
+}
+
+// Init initializes the model
+func (m *ContinueWatchingModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m *ContinueWatchingModel) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch kb.GetActionByKey(keyMsg, kb.ContextContinueWatching) {
+	case kb.ActionMoveUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, Handled("cursor_move:up")
+	case kb.ActionMoveDown:
+		if m.cursor < len(m.entries)-1 {
+			m.cursor++
+		}
+		return m, Handled("cursor_move:down")
+	case kb.ActionPlayNextEpisode:
+		if m.cursor >= len(m.entries) {
+			return m, Handled("continue_watching:none_selected")
+		}
+		animeID := m.entries[m.cursor].ID
+		return m, func() tea.Msg {
+			return ContinueWatchingPlayMsg{AnimeID: animeID}
+		}
+	}
+
+	return m, nil
+}
+
+// View renders the continue watching panel
+func (m *ContinueWatchingModel) View() string {
+	header := styles.Header(m.width, "Continue Watching")
+
+	keyBindings := []components.KeyBinding{
+		{kb.DisplayKeys(kb.ContextContinueWatching, kb.ActionMoveUp, kb.ActionMoveDown), "Navigate"},
+		{kb.DisplayKeyWithSecondary(kb.ActionPlayNextEpisode, kb.ContextContinueWatching), "Play next episode"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextContinueWatching), "Continue to list"},
+	}
+	footer := components.KeyBindingsBar(m.width, keyBindings)
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s", header, m.renderEntries(), footer)
+}
+
+// renderEntries renders each anime with the episode up next and when it was last watched
+func (m *ContinueWatchingModel) renderEntries() string {
+	if len(m.entries) == 0 {
+		return styles.CenteredText(m.width, "You're caught up on everything you're watching")
+	}
+
+	selectedStyle := styles.Selected.
+		Width(m.width-4).
+		Padding(0, 1)
+
+	normalStyle := lipgloss.NewStyle().
+		Width(m.width-4).
+		Padding(0, 1)
+
+	titleWidth := 50
+	var content string
+	for i, anime := range m.entries {
+		title := util.TruncateString(anime.Title.Preferred, titleWidth)
+		title += strings.Repeat(" ", max(0, titleWidth-runewidth.StringWidth(title)))
+
+		left := anime.GetLatestAiredEpisode() - anime.UserData.Progress
+		itemText := fmt.Sprintf("%s  Ep %-4d %3d left", title, anime.UserData.Progress+1, left)
+		if anime.UserData.UpdatedAt > 0 {
+			itemText += fmt.Sprintf("  watched %s ago", formatAge(time.Since(time.Unix(anime.UserData.UpdatedAt, 0))))
+		}
+
+		if i == m.cursor {
+			content += selectedStyle.Render(itemText) + "\n"
+		} else {
+			content += normalStyle.Render(itemText) + "\n"
+		}
+	}
+
+	return styles.ContentBox(m.width-2, content, 1)
+}
+
+// Resize updates the dimensions of the model
+func (m *ContinueWatchingModel) Resize(width, height int) {
+	m.width = width
+	m.height = height
+}
@@ -2,10 +2,13 @@ package models
 
 import (
 	"fmt"
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/filler"
 	"github.com/PizzaHomicide/hisame/internal/log"
 	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
 	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
 	"strings"
+	"time"
 
 	"github.com/PizzaHomicide/hisame/internal/player"
 	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
@@ -21,6 +24,10 @@ import (
 type EpisodeSelectModel struct {
 	width, height  int
 	episodes       []player.AllAnimeEpisodeInfo
+	episodeTitles  map[int]string          // Episode titles by overall episode number, shown in place of the show's name
+	schedule       []domain.AiringSchedule // Episodes still to air, shown under the list with their air dates
+	filler         filler.Episodes         // Filler and recap episodes, flagged in their rows
+	airingLocation *time.Location          // Timezone used when displaying air dates
 	filtered       []player.AllAnimeEpisodeInfo
 	cursor         int
 	searchInput    textinput.Model
@@ -28,10 +35,14 @@ type EpisodeSelectModel struct {
 	animeTitle     string
 	hasMultiCours  bool // Flag to indicate if we need to show cour episode numbers
 	viewportOffset int  // For scrolling
+	navigation     kb.Navigation
 }
 
-// NewEpisodeSelectModel creates a new episode selection modal
-func NewEpisodeSelectModel(episodes []player.AllAnimeEpisodeInfo, animeTitle string) *EpisodeSelectModel {
+// NewEpisodeSelectModel creates a new episode selection modal.  episodeTitles, schedule and fillerEpisodes may be nil
+// if no titles, future air dates or filler are known.
+func NewEpisodeSelectModel(episodes []player.AllAnimeEpisodeInfo, episodeTitles map[int]string,
+	schedule []domain.AiringSchedule, fillerEpisodes filler.Episodes, airingLocation *time.Location,
+	animeTitle string) *EpisodeSelectModel {
 	input := textinput.New()
 	input.Placeholder = "Filter episodes..."
 	input.Width = 30
@@ -50,6 +61,10 @@ func NewEpisodeSelectModel(episodes []player.AllAnimeEpisodeInfo, animeTitle str
 		searchMode:     false,
 		cursor:         0,
 		episodes:       episodes,
+		episodeTitles:  episodeTitles,
+		schedule:       schedule,
+		filler:         fillerEpisodes,
+		airingLocation: airingLocation,
 		filtered:       episodes,
 		animeTitle:     animeTitle,
 		viewportOffset: 0,
@@ -92,7 +107,16 @@ func (m *EpisodeSelectModel) Update(msg tea.Msg) (Model, tea.Cmd) {
 }
 
 func (m *EpisodeSelectModel) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
-	switch kb.GetActionByKey(msg, kb.ContextEpisodeSelection) {
+	action, count := m.navigation.Action(msg, kb.ContextEpisodeSelection)
+	if cursor, ok := moveCursor(m.cursor, len(m.filtered), m.height-11, action, count); ok {
+		m.cursor = cursor
+		m.ensureCursorVisible()
+		return Handled("cursor_move:" + string(action))
+	}
+
+	switch action {
+	case kb.ActionPending:
+		return Handled("navigation:pending")
 	case kb.ActionSelectEpisode:
 		selectedEp := m.GetSelectedEpisode()
 		if selectedEp != nil {
@@ -105,41 +129,32 @@ func (m *EpisodeSelectModel) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		}
 		log.Warn("Empty episode selected.  This should not be possible")
 		return Handled("err:episode_select:empty_episode_selection")
-	case kb.ActionEnableSearch:
-		m.searchMode = true
-		m.searchInput.Focus()
-		return Handled("search:enable")
-	case kb.ActionMoveDown:
-		if len(m.filtered) > 0 && m.cursor < len(m.filtered)-1 {
-			m.cursor++
-			m.ensureCursorVisible()
-		}
-		return Handled("cursor_move:down")
-	case kb.ActionMoveUp:
-		if m.cursor > 0 {
-			m.cursor--
-			m.ensureCursorVisible()
+	case kb.ActionChooseSource:
+		selectedEp := m.GetSelectedEpisode()
+		if selectedEp == nil {
+			return Handled("episode_select:no_episode")
 		}
-		return Handled("cursor_move:up")
-	case kb.ActionPageDown:
-		pageSize := m.height - 11
-		m.cursor += pageSize
-		if m.cursor >= len(m.filtered) {
-			m.cursor = len(m.filtered) - 1
+		return func() tea.Msg {
+			return EpisodeMsg{
+				Type:    EpisodeEventChooseSource,
+				Episode: selectedEp,
+			}
 		}
-		if m.cursor < 0 {
-			m.cursor = 0
+	case kb.ActionCopyStreamURL:
+		selectedEp := m.GetSelectedEpisode()
+		if selectedEp == nil {
+			return Handled("episode_select:no_episode")
 		}
-		m.ensureCursorVisible()
-		return Handled("cursor_move:pgdown")
-	case kb.ActionPageUp:
-		pageSize := m.height - 11
-		m.cursor -= pageSize
-		if m.cursor < 0 {
-			m.cursor = 0
+		return func() tea.Msg {
+			return EpisodeMsg{
+				Type:    EpisodeEventCopyStreamURL,
+				Episode: selectedEp,
+			}
 		}
-		m.ensureCursorVisible()
-		return Handled("cursor_move:pgup")
+	case kb.ActionEnableSearch:
+		m.searchMode = true
+		m.searchInput.Focus()
+		return Handled("search:enable")
 	}
 
 	return nil
@@ -189,7 +204,8 @@ func (m *EpisodeSelectModel) applyFilter() {
 		if fuzzy.Match(query, epNumStr) ||
 			fuzzy.Match(query, ep.AllAnimeEpisodeNumber) ||
 			fuzzy.Match(query, ep.AllAnimeName) ||
-			fuzzy.Match(query, ep.PreferredTitle) {
+			fuzzy.Match(query, ep.PreferredTitle) ||
+			fuzzy.MatchFold(query, m.episodeTitles[ep.OverallEpisodeNumber]) {
 			filtered = append(filtered, ep)
 		}
 	}
@@ -231,7 +247,7 @@ func (m *EpisodeSelectModel) ensureCursorVisible() {
 
 	// Adjust viewport to show as many entries as possible from the start
 	// while keeping the cursor visible
-	visibleCount := min(len(m.filtered), availableHeight-1)
+	visibleCount := min(len(m.filtered), max(1, availableHeight-1-m.previewLines()-m.upcomingLines()))
 
 	// If total filtered entries fit in viewport, reset offset
 	if len(m.filtered) <= visibleCount {
@@ -272,11 +288,12 @@ func (m *EpisodeSelectModel) View() string {
 
 	// Define keybindings to be displayed in the footer
 	keyBindings := []components.KeyBinding{
-		{"↑/↓", "Scroll"},
-		{"Enter", "Select"},
-		{"/", "Search"},
-		{"Ctrl+h", "Help"},
-		{"Esc", "Return"},
+		{kb.DisplayKeys(kb.ContextEpisodeSelection, kb.ActionMoveUp, kb.ActionMoveDown), "Scroll"},
+		{kb.DisplayKey(kb.ActionSelectEpisode, kb.ContextEpisodeSelection), "Select"},
+		{kb.DisplayKey(kb.ActionChooseSource, kb.ContextEpisodeSelection), "Choose source"},
+		{kb.DisplayKey(kb.ActionEnableSearch, kb.ContextEpisodeSelection), "Search"},
+		{kb.DisplayKey(kb.ActionToggleHelp, kb.ContextEpisodeSelection), "Help"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextEpisodeSelection), "Return"},
 	}
 	footer := components.KeyBindingsBar(m.width, keyBindings)
 
@@ -306,7 +323,7 @@ func (m *EpisodeSelectModel) renderEpisodeList() string {
 	}
 
 	// Determine visible range
-	visibleCount := min(len(m.filtered), availableHeight-1) // Reserve space for header row
+	visibleCount := min(len(m.filtered), max(1, availableHeight-1-m.previewLines()-m.upcomingLines())) // Reserve space for header row and preview
 
 	// Calculate the range of episodes to display
 	startIdx := m.viewportOffset
@@ -319,14 +336,11 @@ func (m *EpisodeSelectModel) renderEpisodeList() string {
 	//TODO:  Use styles package
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#FFFFFF")).
+		Foreground(styles.ColorText).
 		Width(m.width-4).
 		Padding(0, 1)
 
-	selectedStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("#FFFFFF")).
-		Background(lipgloss.Color("#7D56F4")).
+	selectedStyle := styles.Selected.
 		Width(m.width-4).
 		Padding(0, 1)
 
@@ -334,6 +348,17 @@ func (m *EpisodeSelectModel) renderEpisodeList() string {
 		Width(m.width-4).
 		Padding(0, 1)
 
+	previewStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorMuted).
+		Italic(true).
+		Width(m.width-4).
+		Padding(0, 3)
+
+	upcomingStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorMuted).
+		Width(m.width-4).
+		Padding(0, 1)
+
 	// Build the list with header
 	var listContent string
 
@@ -341,10 +366,10 @@ func (m *EpisodeSelectModel) renderEpisodeList() string {
 	var headerText string
 	if m.hasMultiCours {
 		headerText = fmt.Sprintf("%-5s %-6s %-50s %-20s %10s",
-			"Ep #", "Cour #", "AllAnimeName", "Season", "Source")
+			"Ep #", "Cour #", "Title", "Season", "Source")
 	} else {
 		headerText = fmt.Sprintf("%-5s %-70s %-20s %10s",
-			"Ep #", "AllAnimeName", "Season", "Source")
+			"Ep #", "Title", "Season", "Source")
 	}
 	listContent += headerStyle.Render(headerText) + "\n"
 
@@ -359,6 +384,9 @@ func (m *EpisodeSelectModel) renderEpisodeList() string {
 
 		if i == m.cursor {
 			listContent += selectedStyle.Render(itemText) + "\n"
+			if preview := m.preview(episode); preview != "" {
+				listContent += previewStyle.Render("↳ "+util.TruncateString(preview, max(1, m.width-10))) + "\n"
+			}
 		} else {
 			listContent += normalStyle.Render(itemText) + "\n"
 		}
@@ -370,16 +398,70 @@ func (m *EpisodeSelectModel) renderEpisodeList() string {
 		listContent += styles.CenteredText(m.width-4, pagination)
 	}
 
+	if upcoming := m.renderUpcoming(); upcoming != "" {
+		listContent += "\n" + upcomingStyle.Render(upcoming)
+	}
+
 	return styles.ContentBox(m.width-2, listContent, 1)
 }
 
+// preview describes the highlighted episode under its row.  Episodes listed by their own title are previewed with the
+// show they are from and when they were uploaded, as the title takes the show's place in the row.
+func (m *EpisodeSelectModel) preview(episode player.AllAnimeEpisodeInfo) string {
+	if m.episodeTitles[episode.OverallEpisodeNumber] == "" {
+		return ""
+	}
+	preview := episode.AllAnimeName
+	if !episode.UploadDate.IsZero() {
+		preview += " • uploaded " + episode.UploadDate.In(m.airingLocation).Format("2 Jan 2006")
+	}
+	return preview
+}
+
+// previewLines returns how many lines the preview under the highlighted row takes up
+func (m *EpisodeSelectModel) previewLines() int {
+	if len(m.episodeTitles) == 0 {
+		return 0
+	}
+	return 1
+}
+
+// upcomingLines returns how many lines the upcoming episodes under the list take up
+func (m *EpisodeSelectModel) upcomingLines() int {
+	if len(m.schedule) == 0 {
+		return 0
+	}
+	return 2
+}
+
+// renderUpcoming lists the episodes still to air with their expected air dates, on a single line
+func (m *EpisodeSelectModel) renderUpcoming() string {
+	if len(m.schedule) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(m.schedule))
+	for _, ep := range m.schedule {
+		parts = append(parts, fmt.Sprintf("Ep %d %s", ep.Episode, util.FormatAiringDate(ep.AiringAt, m.airingLocation)))
+	}
+	return util.TruncateString("Upcoming: "+strings.Join(parts, " • "), max(1, m.width-8))
+}
+
 // formatEpisodeListItem formats a single episode list item
 func (m *EpisodeSelectModel) formatEpisodeListItem(episode player.AllAnimeEpisodeInfo) string {
 	// Format episode number
 	epNum := fmt.Sprintf("%d", episode.OverallEpisodeNumber)
 
-	// Get title and truncate it
+	// Get title and truncate it.  Episodes are listed by their own title where it's known.
 	title := episode.AllAnimeName
+	if episodeTitle := m.episodeTitles[episode.OverallEpisodeNumber]; episodeTitle != "" {
+		title = episodeTitle
+	}
+	switch m.filler.Kind(episode.OverallEpisodeNumber) {
+	case filler.Filler:
+		title = "[Filler] " + title
+	case filler.Recap:
+		title = "[Recap] " + title
+	}
 
 	// Format season information
 	season := fmt.Sprintf("%s %d", episode.Season, episode.Year)
@@ -0,0 +1,255 @@
+package models
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/export"
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/service"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// exportFormat is a file format the list can be exported in
+type exportFormat string
+
+const (
+	// exportFormatHTML is a static HTML page, for sharing
+	exportFormatHTML exportFormat = "html"
+	// exportFormatMAL is MyAnimeList's XML export format, for backing up or moving to another site
+	exportFormatMAL exportFormat = "mal"
+	// exportFormatJSON is the full list with all of the user's data, for custom tooling
+	exportFormatJSON exportFormat = "json"
+	// exportFormatCSV is the full list with all of the user's data, for spreadsheets
+	exportFormatCSV exportFormat = "csv"
+)
+
+// exportFormats is the order tab cycles through the formats in
+var exportFormats = []exportFormat{exportFormatHTML, exportFormatMAL, exportFormatJSON, exportFormatCSV}
+
+// defaultExportFileNames are the files the export path is pre-filled with for each format, in the export directory
+var defaultExportFileNames = map[exportFormat]string{
+	exportFormatHTML: "hisame-list.html",
+	exportFormatMAL:  "hisame-list-mal.xml",
+	exportFormatJSON: "hisame-list.json",
+	exportFormatCSV:  "hisame-list.csv",
+}
+
+// ExportModel asks where to write the list to and exports it as a static HTML page, MyAnimeList XML, JSON or CSV
+type ExportModel struct {
+	width, height int
+	animeService  *service.AnimeService
+	userName      string
+	exportDir     string
+	format        exportFormat
+	input         textinput.Model
+	status        string
+}
+
+// NewExportModel creates a new export model, starting with the given format or HTML if none is given.  The user name
+// is used to title the exported page.  The path is pre-filled with a file in the export directory, or the home
+// directory if none is configured.
+func NewExportModel(animeService *service.AnimeService, userName, exportDir string, format exportFormat) *ExportModel {
+	if format == "" {
+		format = exportFormatHTML
+	}
+
+	m := &ExportModel{
+		animeService: animeService,
+		userName:     userName,
+		exportDir:    expandHome(exportDir),
+		format:       format,
+	}
+
+	ti := textinput.New()
+	ti.Placeholder = "Path to write the list to..."
+	ti.Width = 60
+	ti.SetValue(m.defaultPath(format))
+	ti.Focus()
+	m.input = ti
+
+	return m
+}
+
+func (m *ExportModel) ViewType() View {
+	return ViewExport
+}
+
+// Init initializes the model
+func (m *ExportModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles messages
+func (m *ExportModel) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ExportResultMsg:
+		if msg.Error != nil {
+			m.status = fmt.Sprintf("Export failed: %v", msg.Error)
+		} else {
+			m.status = fmt.Sprintf("Exported %d anime to %s", msg.Count, msg.Path)
+		}
+		if msg.Skipped > 0 && msg.Error == nil {
+			m.status += fmt.Sprintf(".  %d without a MyAnimeList ID were left out", msg.Skipped)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch kb.GetActionByKey(msg, kb.ContextExport) {
+		case kb.ActionConfirmExport:
+			m.status = "Exporting..."
+			return m, m.exportList(expandHome(strings.TrimSpace(m.input.Value())))
+		case kb.ActionToggleExportFormat:
+			m.toggleFormat()
+			return m, Handled("export:toggle_format")
+		case kb.ActionBack:
+			// Let the app pop the export view
+			return m, nil
+		}
+
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		if cmd == nil {
+			cmd = Handled("export:input")
+		}
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// toggleFormat switches to the next export format, updating the path too if it hasn't been changed from the default
+func (m *ExportModel) toggleFormat() {
+	previous := m.format
+	m.format = exportFormats[0]
+	for i, format := range exportFormats {
+		if format == previous {
+			m.format = exportFormats[(i+1)%len(exportFormats)]
+			break
+		}
+	}
+	if strings.TrimSpace(m.input.Value()) == m.defaultPath(previous) {
+		m.input.SetValue(m.defaultPath(m.format))
+	}
+	m.status = ""
+}
+
+// exportList creates a command that writes the list, minus any hidden entries, to the given path
+func (m *ExportModel) exportList(path string) tea.Cmd {
+	format := m.format
+	return func() tea.Msg {
+		if path == "" {
+			return ExportResultMsg{Error: fmt.Errorf("no path entered")}
+		}
+
+		var list []*domain.Anime
+		for _, anime := range m.animeService.GetAnimeList() {
+			if anime.UserData != nil && !m.animeService.IsHidden(anime.ID) {
+				list = append(list, anime)
+			}
+		}
+
+		f, err := os.Create(path)
+		if err != nil {
+			return ExportResultMsg{Path: path, Error: err}
+		}
+		defer f.Close()
+
+		count, skipped := len(list), 0
+		switch format {
+		case exportFormatMAL:
+			count, err = export.WriteMALXML(f, m.userName, list)
+			skipped = len(list) - count
+		case exportFormatJSON:
+			count, err = export.WriteJSON(f, m.userName, list, time.Now())
+		case exportFormatCSV:
+			count, err = export.WriteCSV(f, list)
+		default:
+			err = export.WriteHTML(f, m.userName, list, time.Now())
+		}
+		if err != nil {
+			log.Error("Failed to export list", "path", path, "format", format, "error", err)
+			return ExportResultMsg{Path: path, Error: err}
+		}
+
+		log.Info("Exported anime list", "path", path, "format", format, "count", count)
+		return ExportResultMsg{Path: path, Count: count, Skipped: skipped}
+	}
+}
+
+// View renders the export prompt
+func (m *ExportModel) View() string {
+	header := styles.Header(m.width, "Export List")
+	description := "Writes your list as a static HTML page with covers, scores and progress, ready to share or " +
+		"put on a personal site.  Hidden anime are left out."
+	formatName := "HTML page"
+	switch m.format {
+	case exportFormatMAL:
+		description = "Writes your list in MyAnimeList's XML export format, to back it up or import it into " +
+			"MyAnimeList or another site.  Hidden anime and anime without a MyAnimeList ID are left out."
+		formatName = "MyAnimeList XML"
+	case exportFormatJSON:
+		description = "Writes your full list as JSON, with everything you've recorded for each entry including " +
+			"scores, dates and notes, for your own tools and scripts.  Hidden anime are left out."
+		formatName = "JSON"
+	case exportFormatCSV:
+		description = "Writes your full list as CSV, one entry per row with everything you've recorded for it, " +
+			"to open in a spreadsheet.  Hidden anime are left out."
+		formatName = "CSV"
+	}
+	prompt := styles.Title.Render("Format: ") + formatName + "\n" + styles.Title.Render("Path: ") + m.input.View()
+
+	status := ""
+	if m.status != "" {
+		status = styles.FilterStatus.Render(m.status)
+	}
+
+	keyBindings := []components.KeyBinding{
+		{kb.DisplayKey(kb.ActionConfirmExport, kb.ContextExport), "Export"},
+		{kb.DisplayKey(kb.ActionToggleExportFormat, kb.ContextExport), "Change format"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextExport), "Return"},
+	}
+	footer := components.KeyBindingsBar(m.width, keyBindings)
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s\n\n%s", header, description, prompt, status, footer)
+}
+
+// Resize updates the dimensions of the model
+func (m *ExportModel) Resize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// defaultPath returns the path an export in the given format is written to unless the user changes it
+func (m *ExportModel) defaultPath(format exportFormat) string {
+	if m.exportDir != "" {
+		return filepath.Join(m.exportDir, defaultExportFileNames[format])
+	}
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return defaultExportFileNames[format]
+	}
+	return filepath.Join(homedir, defaultExportFileNames[format])
+}
+
+// expandHome replaces a leading ~ in the path with the user's home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homedir, strings.TrimPrefix(path, "~"))
+}
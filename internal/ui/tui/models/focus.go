@@ -0,0 +1,226 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/util"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// focusAdvanceDelay is how long focus mode waits after an episode is marked watched before playing the next one, so
+// there is time to cancel
+const focusAdvanceDelay = 10 * time.Second
+
+// FocusModel locks the UI to a single anime for a marathon.  It shows only the next episode and what's left, and
+// ignores every key that isn't for playing it, so nothing else in the list can be changed by accident.  Playback
+// itself is left to the anime list, which every message other than key presses is passed on to.
+type FocusModel struct {
+	width, height int
+	list          *AnimeListModel
+	anime         *domain.Anime
+	autoAdvance   bool
+	advanceSeq    int       // Identifies the pending auto-advance, so a cancelled one is ignored when it fires
+	advanceAt     time.Time // When the pending auto-advance plays the next episode, zero if none is pending
+}
+
+// NewFocusModel creates a focus view for the anime, playing episodes through the given list
+func NewFocusModel(list *AnimeListModel, anime *domain.Anime) *FocusModel {
+	return &FocusModel{
+		list:        list,
+		anime:       anime,
+		autoAdvance: true,
+	}
+}
+
+func (m *FocusModel) ViewType() View {
+	return ViewFocus
+}
+
+// Init initializes the model
+func (m *FocusModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m *FocusModel) Update(msg tea.Msg) (Model, tea.Cmd) {
+	// A refresh replaces the list's entries, so make sure to follow the current one
+	if anime := m.list.findAnimeById(m.anime.ID); anime != nil {
+		m.anime = anime
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m, m.handleKeyMsg(msg)
+
+	case FocusAdvanceMsg:
+		if msg.Seq != m.advanceSeq || m.advanceAt.IsZero() {
+			return m, Handled("focus:advance_cancelled")
+		}
+		if time.Now().Before(m.advanceAt) {
+			// Tick the countdown
+			return m, m.advanceTick()
+		}
+		m.advanceAt = time.Time{}
+		log.Info("Focus mode playing the next episode", "title", m.anime.Title.Preferred)
+		return m, m.list.handlePlayNextEpisode(m.anime)
+
+	case AnimeUpdatedMsg:
+		_, cmd := m.list.Update(msg)
+		if msg.Success && msg.AnimeID == m.anime.ID && !msg.Completed {
+			return m, tea.Batch(cmd, m.scheduleAdvance())
+		}
+		return m, cmd
+	}
+
+	_, cmd := m.list.Update(msg)
+	return m, cmd
+}
+
+// handleKeyMsg handles key presses.  Keys that aren't bound in focus mode are swallowed rather than passed to the list.
+func (m *FocusModel) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
+	switch kb.GetActionByKey(msg, kb.ContextFocus) {
+	case kb.ActionPlayNextEpisode:
+		m.advanceAt = time.Time{}
+		return m.list.handlePlayNextEpisode(m.anime)
+	case kb.ActionToggleAutoAdvance:
+		m.autoAdvance = !m.autoAdvance
+		if !m.autoAdvance {
+			m.advanceAt = time.Time{}
+		}
+		return Handled("focus:toggle_auto_advance")
+	case kb.ActionBack:
+		if !m.advanceAt.IsZero() {
+			m.advanceAt = time.Time{}
+			return Handled("focus:cancel_advance")
+		}
+		// Let the app pop the focus view
+		return nil
+	}
+	return Handled("focus:ignored_key")
+}
+
+// scheduleAdvance starts the countdown to the next episode, if auto-advance is on and one has aired
+func (m *FocusModel) scheduleAdvance() tea.Cmd {
+	if !m.autoAdvance || !m.anime.HasUnwatchedEpisodes() {
+		return nil
+	}
+	m.advanceSeq++
+	m.advanceAt = time.Now().Add(focusAdvanceDelay)
+	return m.advanceTick()
+}
+
+// advanceTick waits a second before checking the auto-advance countdown again
+func (m *FocusModel) advanceTick() tea.Cmd {
+	seq := m.advanceSeq
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return FocusAdvanceMsg{Seq: seq}
+	})
+}
+
+// View renders the focus view
+func (m *FocusModel) View() string {
+	header := styles.Header(m.width, "Focus: "+m.anime.Title.Preferred)
+
+	autoAdvance := "Auto-advance: off"
+	if m.autoAdvance {
+		autoAdvance = "Auto-advance: on"
+	}
+	keyBindings := []components.KeyBinding{
+		{kb.DisplayKey(kb.ActionPlayNextEpisode, kb.ContextFocus), "Play next episode"},
+		{kb.DisplayKey(kb.ActionToggleAutoAdvance, kb.ContextFocus), autoAdvance},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextFocus), "Leave focus mode"},
+	}
+	footer := components.KeyBindingsBar(m.width, keyBindings)
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s", header, m.renderNextEpisode(), m.renderStatus(),
+		styles.CenteredText(m.width, footer))
+}
+
+// renderNextEpisode renders the oversized next episode pane along with what's left to watch
+func (m *FocusModel) renderNextEpisode() string {
+	anime := m.anime
+	progress := 0
+	if anime.UserData != nil {
+		progress = anime.UserData.Progress
+	}
+
+	nextStyle := styles.Selected.
+		Padding(2, 6).
+		Align(lipgloss.Center)
+
+	var next string
+	switch {
+	case anime.Episodes > 0 && progress >= anime.Episodes:
+		next = "All episodes watched"
+	case anime.HasUnwatchedEpisodes():
+		next = fmt.Sprintf("Next: Episode %d", progress+1)
+	case anime.NextAiringEp != nil:
+		next = fmt.Sprintf("Episode %d airs in %s", anime.NextAiringEp.Episode,
+			strings.TrimSpace(util.FormatTimeUntilAiring(anime.NextAiringEp.TimeUntilAir)))
+	default:
+		next = "No more episodes available"
+	}
+
+	total := "?"
+	if anime.Episodes > 0 {
+		total = fmt.Sprintf("%d", anime.Episodes)
+	}
+	lines := []string{fmt.Sprintf("Watched %d of %s", progress, total)}
+
+	if remaining := anime.Episodes - progress; anime.Episodes > 0 && remaining > 0 {
+		line := fmt.Sprintf("%d episodes remaining", remaining)
+		if anime.Duration > 0 {
+			line += fmt.Sprintf(", about %s left", formatFocusDuration(time.Duration(remaining*anime.Duration)*time.Minute))
+		}
+		lines = append(lines, line)
+	}
+	if aired := anime.GetLatestAiredEpisode() - progress; aired > 0 && aired != anime.Episodes-progress {
+		lines = append(lines, fmt.Sprintf("%d aired and ready to watch", aired))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Center,
+		nextStyle.Render(next),
+		"",
+		lipgloss.JoinVertical(lipgloss.Center, lines...),
+	)
+	return lipgloss.Place(m.width, max(0, m.height-10), lipgloss.Center, lipgloss.Center, content)
+}
+
+// renderStatus renders what focus mode is doing: loading an episode, counting down to the next one, or a failure
+func (m *FocusModel) renderStatus() string {
+	switch {
+	case m.list.loading:
+		return styles.CenteredText(m.width, fmt.Sprintf("%s %s", m.list.spinner.View(), m.list.loadingMsg))
+	case !m.advanceAt.IsZero():
+		seconds := int(time.Until(m.advanceAt).Round(time.Second).Seconds())
+		return styles.CenteredText(m.width, fmt.Sprintf("Playing episode %d in %ds.  %s to cancel",
+			m.anime.UserData.Progress+1, max(0, seconds), kb.DisplayKey(kb.ActionBack, kb.ContextFocus)))
+	case m.list.errorToast != "":
+		return styles.CenteredText(m.width, styles.Error.Render(m.list.errorToast))
+	}
+	return ""
+}
+
+// formatFocusDuration formats a duration in hours and minutes, e.g. "4h 20m"
+func formatFocusDuration(d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	if hours == 0 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh %dm", hours, minutes)
+}
+
+// Resize updates the dimensions of the model
+func (m *FocusModel) Resize(width, height int) {
+	m.width = width
+	m.height = height
+}
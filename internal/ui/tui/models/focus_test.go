@@ -0,0 +1,31 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFocusIgnoresUnboundKeys(t *testing.T) {
+	m := NewFocusModel(&AnimeListModel{}, &domain.Anime{ID: 1, UserData: &domain.UserAnimeData{}})
+
+	cmd := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	assert.Equal(t, HandledMsg{Message: "focus:ignored_key"}, cmd())
+}
+
+func TestFocusEscCancelsAdvance(t *testing.T) {
+	m := NewFocusModel(&AnimeListModel{}, &domain.Anime{ID: 1, UserData: &domain.UserAnimeData{}})
+	m.advanceAt = time.Now().Add(focusAdvanceDelay)
+
+	assert.NotNil(t, m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc}), "esc should cancel the countdown")
+	assert.True(t, m.advanceAt.IsZero())
+	assert.Nil(t, m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc}), "esc should then leave focus mode")
+}
+
+func TestFormatFocusDuration(t *testing.T) {
+	assert.Equal(t, "48m", formatFocusDuration(48*time.Minute))
+	assert.Equal(t, "4h 36m", formatFocusDuration(12*23*time.Minute))
+}
@@ -18,6 +18,7 @@ type HelpModel struct {
 	width, height int
 	context       View
 	viewport      viewport.Model
+	navigation    kb.Navigation
 }
 
 // NewHelpModel creates a new help model for the given context
@@ -49,15 +50,8 @@ func (m *HelpModel) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.viewport, cmd = m.viewport.Update(msg)
 		return m, cmd
 	case tea.KeyMsg:
-		switch kb.GetActionByKey(msg, kb.ContextHelp) {
-		case kb.ActionMoveUp, kb.ActionMoveDown, kb.ActionPageUp, kb.ActionPageDown:
-			m.viewport, cmd = m.viewport.Update(msg)
-			return m, cmd
-		case kb.ActionMoveTop:
-			m.viewport.GotoTop()
-			return m, cmd
-		case kb.ActionMoveBottom:
-			m.viewport.GotoBottom()
+		action, count := m.navigation.Action(msg, kb.ContextHelp)
+		if scrollViewport(&m.viewport, action, count) || action == kb.ActionPending {
 			return m, cmd
 		}
 
@@ -109,10 +103,10 @@ func (m *HelpModel) View() string {
 
 	// Define keybindings to be displayed in the footer
 	keyBindings := []components.KeyBinding{
-		{"↑/↓", "Scroll"},
-		{"PgUp/PgDn", "Page scroll"},
-		{"Home/End", "Top/Bottom"},
-		{"Esc", "Return"},
+		{kb.DisplayKeys(kb.ContextHelp, kb.ActionMoveUp, kb.ActionMoveDown), "Scroll"},
+		{kb.DisplayKeys(kb.ContextHelp, kb.ActionPageUp, kb.ActionPageDown), "Page scroll"},
+		{kb.DisplayKeys(kb.ContextHelp, kb.ActionMoveTop, kb.ActionMoveBottom), "Top/Bottom"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextHelp), "Return"},
 	}
 	footer := components.KeyBindingsBar(m.width, keyBindings)
 
@@ -136,6 +130,48 @@ func (m *HelpModel) getContextTitle() string {
 		return "Anime List"
 	case ViewEpisodeSelect:
 		return "Episode Selection"
+	case ViewWatchOrder:
+		return "Watch Order"
+	case ViewAPIUsage:
+		return "API Usage"
+	case ViewCompletionBackfill:
+		return "Backfill Completion Dates"
+	case ViewListAudit:
+		return "List Audit"
+	case ViewAgenda:
+		return "Airing Agenda"
+	case ViewContinueWatching:
+		return "Continue Watching"
+	case ViewHiddenEntries:
+		return "Hidden Anime"
+	case ViewSnoozes:
+		return "Agenda Snoozes"
+	case ViewNotes:
+		return "Edit Notes"
+	case ViewMALImport:
+		return "Import From MyAnimeList"
+	case ViewSeasonScores:
+		return "Season Scores"
+	case ViewProfile:
+		return "Profile"
+	case ViewSession:
+		return "Session"
+	case ViewPlaybackError:
+		return "Playback Failed"
+	case ViewQuickPlay:
+		return "Quick Play"
+	case ViewExport:
+		return "Export List"
+	case ViewLocalImport:
+		return "Import From Local Files"
+	case ViewBackups:
+		return "List Backups"
+	case ViewAniListSearch:
+		return "Search AniList"
+	case ViewReconcile:
+		return "Reconcile Changes"
+	case ViewFocus:
+		return "Focus Mode"
 	default:
 		return "General"
 	}
@@ -196,7 +232,7 @@ func (m *HelpModel) generateHelpContent() string {
 	var b strings.Builder
 
 	// Title style for sections
-	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7D56F4"))
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorAccent)
 
 	// Add context description section
 	b.WriteString(titleStyle.Render(m.getContextTitle()))
@@ -228,6 +264,46 @@ func (m *HelpModel) generateHelpContent() string {
 		contextName = kb.ContextAnimeList
 	case ViewEpisodeSelect:
 		contextName = kb.ContextEpisodeSelection
+	case ViewWatchOrder:
+		contextName = kb.ContextWatchOrder
+	case ViewAPIUsage:
+		contextName = kb.ContextAPIUsage
+	case ViewCompletionBackfill:
+		contextName = kb.ContextCompletionBackfill
+	case ViewListAudit:
+		contextName = kb.ContextListAudit
+	case ViewAgenda:
+		contextName = kb.ContextAgenda
+	case ViewContinueWatching:
+		contextName = kb.ContextContinueWatching
+	case ViewHiddenEntries:
+		contextName = kb.ContextHiddenEntries
+	case ViewSnoozes:
+		contextName = kb.ContextSnoozes
+	case ViewNotes:
+		contextName = kb.ContextNotes
+	case ViewMALImport:
+		contextName = kb.ContextMALImport
+	case ViewSeasonScores:
+		contextName = kb.ContextSeasonScores
+	case ViewSession:
+		contextName = kb.ContextSession
+	case ViewPlaybackError:
+		contextName = kb.ContextPlaybackError
+	case ViewQuickPlay:
+		contextName = kb.ContextQuickPlay
+	case ViewExport:
+		contextName = kb.ContextExport
+	case ViewLocalImport:
+		contextName = kb.ContextLocalImport
+	case ViewBackups:
+		contextName = kb.ContextBackups
+	case ViewAniListSearch:
+		contextName = kb.ContextAniListSearch
+	case ViewReconcile:
+		contextName = kb.ContextReconcile
+	case ViewFocus:
+		contextName = kb.ContextFocus
 	}
 
 	if contextName != "" {
@@ -261,7 +337,7 @@ func (m *HelpModel) generateHelpContent() string {
 func (m *HelpModel) getFilterDetails() string {
 	var b strings.Builder
 
-	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7D56F4"))
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorAccent)
 	b.WriteString(titleStyle.Render("Filters"))
 	b.WriteString("\n\n")
 
@@ -277,8 +353,30 @@ func (m *HelpModel) getFilterDetails() string {
 	b.WriteString("• [A] : Available Episodes - Shows only anime with unwatched aired episodes\n")
 	b.WriteString("• [F] : Finished Airing - Shows only anime that have completed their broadcast run\n\n")
 
+	b.WriteString("Format filters:\n\n")
+	b.WriteString("• [T] : TV - Shows TV series, including short series\n")
+	b.WriteString("• [M] : Movie - Shows movies\n")
+	b.WriteString("• [V] : OVA - Shows original video animations\n")
+	b.WriteString("• [N] : ONA - Shows original net animations\n")
+	b.WriteString("• [S] : Special - Shows specials\n")
+	b.WriteString("If no format filters are active, every format is shown.\n\n")
+
 	b.WriteString("Multiple filters can be active at once. Toggle each filter by pressing its corresponding key.\n")
-	b.WriteString("If no status filters are active, the 'Watching' filter will be applied by default.\n")
+	b.WriteString("If no status filters are active, the 'Watching' filter will be applied by default.\n\n")
+
+	b.WriteString("Quick filter:\n\n")
+	b.WriteString(fmt.Sprintf("Press %s to type a whole filter in one go, ",
+		kb.DisplayKey(kb.ActionQuickFilter, kb.ContextAnimeList)))
+	b.WriteString("e.g. 's:watching,paused score>8 year:2024 frieren'\n")
+	b.WriteString("• s:<status,...> : Statuses to show (watching, planning, completed, dropped, paused, repeating)\n")
+	b.WriteString("• score>N        : Your score compared with >, >=, <, <= or : (equals)\n")
+	b.WriteString("• year:N         : Season year\n")
+	b.WriteString("• genre:<a,...>  : Anime with any of the genres.  Repeat to require several, e.g. genre:comedy genre:romance\n")
+	b.WriteString("• format:<f,...> : Formats to show (tv, movie, ova, ona, special)\n")
+	b.WriteString("• tag:<a,...>    : Anime with any of the tags, e.g. tag:isekai.  Use _ for spaces, e.g. tag:time_skip\n")
+	b.WriteString("• has:new        : Same as the Available Episodes filter\n")
+	b.WriteString("• is:finished    : Same as the Finished Airing filter\n")
+	b.WriteString("Any other words are used as the title search.\n")
 
 	return b.String()
 }
@@ -289,7 +387,12 @@ func (m *HelpModel) getContextDescription() string {
 	case ViewAuth:
 		return "The authentication screen allows you to connect Hisame with your AniList account.\n\n" +
 			"When you press the login key, a browser window will open where you can authorize the application. " +
-			"After completing authorization in your browser, you'll automatically return to Hisame."
+			"After completing authorization in your browser, you'll automatically return to Hisame.\n\n" +
+			"If the browser can't reach Hisame, e.g. over SSH or on a machine without a browser, paste a token " +
+			"instead.  Open the login URL in any browser, and once AniList redirects to a page that won't load, " +
+			"paste that page's address or the access token in it.\n\n" +
+			"AniList logins last a year.  When yours expires, or AniList stops accepting it, this screen is shown " +
+			"over whatever you were doing.  Once you log in again as the same user you carry on where you left off."
 
 	case ViewAnimeList:
 		return "The anime list screen displays your AniList collection with filtering options.\n\n" +
@@ -299,9 +402,172 @@ func (m *HelpModel) getContextDescription() string {
 			"and directly play the next episode of a selected anime."
 
 	case ViewEpisodeSelect:
+		selectEpisode := kb.DisplayKey(kb.ActionSelectEpisode, kb.ContextEpisodeSelection)
+		chooseSource := kb.DisplayKey(kb.ActionChooseSource, kb.ContextEpisodeSelection)
 		return "The episode selection screen allows you to choose a specific episode to watch.\n\n" +
-			"Browse through available episodes, select one, and press Enter to begin playback. " +
-			"You can use the search feature to quickly find specific episodes by number or title."
+			fmt.Sprintf("Browse through available episodes, select one, and press %s to begin playback. ", selectEpisode) +
+			"You can use the search feature to quickly find specific episodes by number or title.\n\n" +
+			fmt.Sprintf("Press %s instead of %s to choose the source yourself.  ", chooseSource, selectEpisode) +
+			"Each source is checked first, showing its resolution and whether its subtitles are burned in (hard) " +
+			"or can be switched in the player (soft)."
+
+	case ViewWatchOrder:
+		return "The watch order screen shows a recommended order for the franchise the selected anime belongs to.\n\n" +
+			"The order is worked out from AniList prequel and sequel relations. Entries you have completed are " +
+			"marked [x], entries in progress are marked [~], and entries you have not started are marked [ ]."
+
+	case ViewAPIUsage:
+		return "The API usage screen lists the most recent calls Hisame has made to AniList and AllAnime.\n\n" +
+			"Each call shows when it was made, the operation, how long it took, whether it succeeded and whether " +
+			"it was served from a cache.  Use this to see what Hisame is doing if you are hitting rate limits or " +
+			"refreshes are slow.\n\n" +
+			"The line above the calls shows what Hisame detected about your terminal, and why any features that " +
+			"depend on it, such as taskbar progress, are turned off."
+
+	case ViewCompletionBackfill:
+		return "The backfill screen fills in completion dates for completed entries that don't have one, which is " +
+			"common after importing a list.\n\n" +
+			"Pick whether to use the date each entry was last updated on AniList, today's date, or a date you " +
+			"enter.  Entries without a last updated date are skipped when using that option."
+
+	case ViewListAudit:
+		return "The list audit screen shows entries in your list that look inconsistent:  progress beyond the " +
+			"episode count, completed entries with episodes left unwatched, and finished shows still in Watching " +
+			"with no activity for over 90 days.\n\n" +
+			"Each finding shows the fix that will be applied.  Select a finding and press the fix key to apply it."
+
+	case ViewAgenda:
+		return "The airing agenda is shown when Hisame starts and lists the episodes from your Watching and " +
+			"Repeating lists that air in the next 24 hours.\n\n" +
+			"Times update while the agenda is open.  Once an episode has aired it is marked as available and can " +
+			"be played straight from the agenda.  Set ui.startup_agenda to off to disable it.\n\n" +
+			fmt.Sprintf("Press %s to snooze the selected anime, or %s to snooze the whole agenda, ",
+				kb.DisplayKey(kb.ActionSnoozeAnime, kb.ContextAgenda), kb.DisplayKey(kb.ActionSnoozeAgenda, kb.ContextAgenda)) +
+			"for a day, until next week or until next season.  Snoozes are managed from the menu under " +
+			"'Manage agenda snoozes'."
+
+	case ViewContinueWatching:
+		return "Continue watching lists the anime from your Watching and Repeating lists that have aired episodes " +
+			"left to watch, with the one you watched most recently first, so " +
+			fmt.Sprintf("%s plays the next episode of whatever you were last watching.\n\n",
+				kb.DisplayKey(kb.ActionPlayNextEpisode, kb.ContextContinueWatching)) +
+			fmt.Sprintf("Open it from anywhere with %s.  ", kb.DisplayKey(kb.ActionContinueWatching, kb.ContextGlobal)) +
+			"Set ui.startup_continue_watching to panel to have it shown when Hisame starts."
+
+	case ViewQuickPlay:
+		return "Quick play finds any anime in your list, whatever its status, and plays its next episode.\n\n" +
+			"Start typing part of any title or synonym.  With nothing typed, the anime you are watching are " +
+			fmt.Sprintf("listed with the most recently updated first, so %s resumes whatever you watched last.",
+				kb.DisplayKey(kb.ActionPlayNextEpisode, kb.ContextQuickPlay))
+
+	case ViewAniListSearch:
+		searchOrChoose := kb.DisplayKey(kb.ActionSearchOrChoose, kb.ContextAniListSearch)
+		return "Search AniList finds any anime on AniList, including ones that aren't in your list yet.\n\n" +
+			fmt.Sprintf("Type a title and press %s to search.  %s on a result then chooses an episode to play ",
+				searchOrChoose, searchOrChoose) +
+			fmt.Sprintf("without touching your list, while %s adds it to your list as watching and plays its next ",
+				kb.DisplayKey(kb.ActionAddAndPlay, kb.ContextAniListSearch)) +
+			"episode, so your progress is tracked from the start.  Anime already in your list are marked."
+
+	case ViewReconcile:
+		return "Progress changed while AniList couldn't be reached is kept and saved once AniList is back.  If " +
+			"an entry was also changed on AniList in the meantime, e.g. from the website or another device, it is " +
+			"listed here instead of one change overwriting the other.  Before saving a progress change, Hisame also " +
+			"checks whether the entry was changed on AniList since the list loaded, and lists it here if so.\n\n" +
+			"Keep Hisame's change, keep AniList's, or merge them to keep whichever has the furthest progress.  " +
+			"Anything left undecided stays queued and is shown again after the next refresh."
+
+	case ViewFocus:
+		return "Focus mode locks Hisame to one anime for a marathon, showing only its next episode and how much " +
+			"is left.  Keys other than those below are ignored, so nothing else in your list can be changed by " +
+			"accident.\n\n" +
+			"With auto-advance on, the next episode plays 10 seconds after one is marked watched.  " +
+			fmt.Sprintf("Press %s during the countdown to cancel it.", kb.DisplayKey(kb.ActionBack, kb.ContextFocus))
+
+	case ViewExport:
+		return "Export writes your list to a static HTML page with covers, scores and progress, grouped by list " +
+			"status.\n\n" +
+			"The page doesn't link to your AniList profile, so it can be shared or embedded on a personal site.  " +
+			"Anime you have hidden from Hisame are left out.\n\n" +
+			fmt.Sprintf("Press %s to export in MyAnimeList's XML format instead, ",
+				kb.DisplayKey(kb.ActionToggleExportFormat, kb.ContextExport)) +
+			"which MyAnimeList and most other list sites " +
+			"can import.  Anime AniList has no MyAnimeList ID for can't be included.  Press it again for JSON or " +
+			"CSV, which include everything you've recorded for each entry, for your own tools or a spreadsheet.\n\n" +
+			"The path starts in the export.dir directory from the config, or your home directory if it isn't set."
+
+	case ViewLocalImport:
+		return "Import from local files scans a folder and its subfolders for downloaded episodes, working out each " +
+			"show and episode number from the file names, e.g. '[Group] Show Name - 05 (1080p).mkv'.\n\n" +
+			"For each show on your list that isn't completed, progress is suggested up to the highest episode found " +
+			"without a gap after your current progress, so a missing episode is never marked as watched.  The " +
+			"entries are backed up before they are updated."
+
+	case ViewProfile:
+		return "The profile screen shows the AniList account Hisame is logged in as, along with the totals AniList " +
+			"keeps for it.\n\n" +
+			fmt.Sprintf("Use %s to log out if this is not the account you expected.",
+				kb.DisplayKey(kb.ActionLogout, kb.ContextGlobal))
+
+	case ViewPlaybackError:
+		return "Shown when an episode couldn't be played, with what went wrong.\n\n" +
+			fmt.Sprintf("Press %s to try the episode again, or %s to pick another source from the list of what "+
+				"each source plays.  ", kb.DisplayKey(kb.ActionRetryPlayback, kb.ContextPlaybackError),
+				kb.DisplayKey(kb.ActionChooseSource, kb.ContextPlaybackError)) +
+			fmt.Sprintf("Press %s to copy the details of the failure, with the AllAnime requests made for it, to "+
+				"include in a bug report.", kb.DisplayKey(kb.ActionCopyDiagnostics, kb.ContextPlaybackError))
+
+	case ViewSession:
+		return "The session screen shows how Hisame is logged in to AniList, to help when AniList rejects " +
+			"requests.\n\n" +
+			"It shows the logged in account, where the token was loaded from, when it was issued and when it " +
+			"expires, as well as how much of AniList's rate limit is left.  A token set with the " +
+			"HISAME_CONFIG_AUTH_TOKEN environment variable overrides the one in the config file, so logging out " +
+			"doesn't remove it.\n\n" +
+			"AniList logs you in as whichever account your browser is logged in to, so to switch accounts, log " +
+			"out on the AniList website first or paste a token for the other account."
+
+	case ViewBackups:
+		return "Before a batch change such as the completion date backfill, Hisame saves the affected entries to a " +
+			"timestamped backup in its data directory.\n\n" +
+			"Restoring a backup puts the status, progress, score, notes and dates of those entries back the way they " +
+			"were.  Their current values are backed up first, so a restore can be undone too."
+	case ViewHiddenEntries:
+		return "The hidden anime screen lists the entries you have hidden from Hisame.\n\n" +
+			"Hidden entries stay on your AniList exactly as they are, but are never shown in the anime list, the " +
+			"airing agenda or the list audit.  Unhide an entry to make it visible again."
+	case ViewSnoozes:
+		return "The agenda snoozes screen lists what you have snoozed in the airing agenda.\n\n" +
+			"A snoozed anime is left out of the agenda until its snooze ends, and while the whole agenda is " +
+			"snoozed it isn't shown on startup at all.  Snoozes end on their own, or can be removed here early.  " +
+			"Snoozes are kept on this computer only."
+	case ViewSeasonScores:
+		return "The season scores screen lists everything you completed in a season, going by your completion " +
+			"dates, so you can score them all at once when the season ends.\n\n" +
+			fmt.Sprintf("Move between rows like a spreadsheet and type a score, or press %s to edit the selected "+
+				"one.  ", kb.DisplayKey(kb.ActionEditScore, kb.ContextSeasonScores)) +
+			fmt.Sprintf("Changed scores are marked with * and nothing is saved until you press %s, which saves "+
+				"them all together.  ", kb.DisplayKey(kb.ActionSaveScores, kb.ContextSeasonScores)) +
+			fmt.Sprintf("Use %s and %s to move between seasons; changes are kept until you save or leave the "+
+				"screen.\n\n", kb.DisplayKey(kb.ActionPreviousSeason, kb.ContextSeasonScores),
+				kb.DisplayKey(kb.ActionNextSeason, kb.ContextSeasonScores)) +
+			"Scores use your AniList scoring format, and the entries are backed up before saving."
+	case ViewMALImport:
+		return "The MyAnimeList import reads a list exported from MyAnimeList, as XML or the .xml.gz file " +
+			"MyAnimeList gives you, and brings your AniList list in line with it.\n\n" +
+			"Nothing is saved until you have reviewed the changes.  Each entry shows what will change, and anime " +
+			"not yet on your AniList are marked as added.  Only fields MyAnimeList has a value for are changed, so " +
+			"an unscored entry won't clear your AniList score.  Entries are matched by MyAnimeList ID, and any " +
+			"AniList has no anime for are listed at the bottom.\n\n" +
+			"Entries already on your list are backed up before the import, so it can be undone from the backups " +
+			"screen."
+	case ViewNotes:
+		return "The notes editor changes the notes of an entry on your AniList.  " +
+			fmt.Sprintf("Press %s to save.\n\n", kb.DisplayKey(kb.ActionSaveNotes, kb.ContextNotes)) +
+			"Before saving, Hisame checks whether the notes were changed on AniList since you started editing, " +
+			"for example from the website or mobile app.  If they were, both versions are shown and nothing is " +
+			"overwritten until you choose to keep yours, keep AniList's, or merge them.  A merge keeps AniList's " +
+			"notes and adds the lines you wrote, and can be reviewed before it is saved."
 
 	default:
 		return "Welcome to Hisame, a terminal UI for managing your AniList and watching anime."
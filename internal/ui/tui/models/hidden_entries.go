@@ -0,0 +1,161 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/service"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/util"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// HiddenEntriesModel lists the anime the user has hidden from Hisame and lets them unhide them
+type HiddenEntriesModel struct {
+	width, height int
+	animeService  *service.AnimeService
+	entries       []service.HiddenEntry
+	cursor        int
+	status        string
+}
+
+// NewHiddenEntriesModel creates a new hidden entries model
+func NewHiddenEntriesModel(animeService *service.AnimeService) *HiddenEntriesModel {
+	return &HiddenEntriesModel{
+		animeService: animeService,
+		entries:      animeService.GetHiddenEntries(),
+	}
+}
+
+func (m *HiddenEntriesModel) ViewType() View {
+	return ViewHiddenEntries
+}
+
+// Init initializes the model
+func (m *HiddenEntriesModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m *HiddenEntriesModel) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch kb.GetActionByKey(keyMsg, kb.ContextHiddenEntries) {
+	case kb.ActionMoveUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, Handled("cursor_move:up")
+	case kb.ActionMoveDown:
+		if m.cursor < len(m.entries)-1 {
+			m.cursor++
+		}
+		return m, Handled("cursor_move:down")
+	case kb.ActionMoveTop:
+		m.cursor = 0
+		return m, Handled("cursor_move:top")
+	case kb.ActionMoveBottom:
+		m.cursor = max(0, len(m.entries)-1)
+		return m, Handled("cursor_move:bottom")
+	case kb.ActionUnhideAnime:
+		if m.cursor >= len(m.entries) {
+			return m, Handled("unhide:none_selected")
+		}
+		return m, m.unhideSelected()
+	}
+
+	return m, nil
+}
+
+// unhideSelected unhides the entry under the cursor and tells the list to re-filter
+func (m *HiddenEntriesModel) unhideSelected() tea.Cmd {
+	entry := m.entries[m.cursor]
+	if err := m.animeService.UnhideAnime(entry.AnimeID); err != nil {
+		log.Error("Failed to unhide anime", "anime_id", entry.AnimeID, "error", err)
+		m.status = fmt.Sprintf("Failed to unhide %s: %v", entry.Title, err)
+		return Handled("unhide:error")
+	}
+
+	m.status = fmt.Sprintf("Unhid %s", entry.Title)
+	m.entries = append(m.entries[:m.cursor], m.entries[m.cursor+1:]...)
+	if m.cursor >= len(m.entries) {
+		m.cursor = max(0, len(m.entries)-1)
+	}
+
+	return func() tea.Msg {
+		return HiddenEntriesChangedMsg{}
+	}
+}
+
+// View renders the hidden entries
+func (m *HiddenEntriesModel) View() string {
+	header := styles.Header(m.width, "Hidden Anime")
+
+	summary := fmt.Sprintf("%d anime hidden from Hisame", len(m.entries))
+	if m.status != "" {
+		summary += "  •  " + m.status
+	}
+
+	keyBindings := []components.KeyBinding{
+		{kb.DisplayKeys(kb.ContextHiddenEntries, kb.ActionMoveUp, kb.ActionMoveDown), "Navigate"},
+		{kb.DisplayKeyWithSecondary(kb.ActionUnhideAnime, kb.ContextHiddenEntries), "Unhide"},
+		{kb.DisplayKey(kb.ActionToggleHelp, kb.ContextHiddenEntries), "Help"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextHiddenEntries), "Return"},
+	}
+	footer := components.KeyBindingsBar(m.width, keyBindings)
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s", header, styles.FilterStatus.Render(summary), m.renderEntries(), footer)
+}
+
+// renderEntries renders the scrollable list of hidden entries
+func (m *HiddenEntriesModel) renderEntries() string {
+	if len(m.entries) == 0 {
+		return styles.CenteredText(m.width, "No anime are hidden")
+	}
+
+	visibleCount := min(len(m.entries), max(1, m.height-12))
+	startIdx := 0
+	if m.cursor >= visibleCount {
+		startIdx = m.cursor - visibleCount + 1
+	}
+	endIdx := min(startIdx+visibleCount, len(m.entries))
+
+	selectedStyle := styles.Selected.
+		Width(m.width-4).
+		Padding(0, 1)
+
+	normalStyle := lipgloss.NewStyle().
+		Width(m.width-4).
+		Padding(0, 1)
+
+	titleWidth := 50
+	var listContent string
+	for i := startIdx; i < endIdx; i++ {
+		entry := m.entries[i]
+		title := util.TruncateString(entry.Title, titleWidth)
+		title += strings.Repeat(" ", max(0, titleWidth-runewidth.StringWidth(title)))
+		itemText := fmt.Sprintf("%s  Hidden %s", title, entry.HiddenAt.Local().Format("2006-01-02"))
+
+		if i == m.cursor {
+			listContent += selectedStyle.Render(itemText) + "\n"
+		} else {
+			listContent += normalStyle.Render(itemText) + "\n"
+		}
+	}
+
+	return styles.ContentBox(m.width-2, listContent, 1)
+}
+
+// Resize updates the dimensions of the model
+func (m *HiddenEntriesModel) Resize(width, height int) {
+	m.width = width
+	m.height = height
+}
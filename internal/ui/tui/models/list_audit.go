@@ -0,0 +1,179 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/service"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/util"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// ListAuditModel shows inconsistent entries found in the user's list and lets them fix each one with a single key
+type ListAuditModel struct {
+	width, height int
+	animeService  *service.AnimeService
+	findings      []service.AuditFinding
+	cursor        int
+	applying      bool
+	status        string
+}
+
+// NewListAuditModel creates a new list audit model, scanning the cached list for findings
+func NewListAuditModel(animeService *service.AnimeService) *ListAuditModel {
+	return &ListAuditModel{
+		animeService: animeService,
+		findings:     animeService.AuditList(),
+	}
+}
+
+func (m *ListAuditModel) ViewType() View {
+	return ViewListAudit
+}
+
+// Init initializes the model
+func (m *ListAuditModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m *ListAuditModel) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case AuditFixResultMsg:
+		m.applying = false
+		if msg.Error != nil {
+			m.status = fmt.Sprintf("Failed to fix %s: %v", msg.Finding.Anime.Title.Preferred, msg.Error)
+			return m, nil
+		}
+		m.status = fmt.Sprintf("Fixed %s", msg.Finding.Anime.Title.Preferred)
+		m.removeFinding(msg.Finding)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch kb.GetActionByKey(msg, kb.ContextListAudit) {
+		case kb.ActionMoveUp:
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, Handled("cursor_move:up")
+		case kb.ActionMoveDown:
+			if m.cursor < len(m.findings)-1 {
+				m.cursor++
+			}
+			return m, Handled("cursor_move:down")
+		case kb.ActionMoveTop:
+			m.cursor = 0
+			return m, Handled("cursor_move:top")
+		case kb.ActionMoveBottom:
+			m.cursor = max(0, len(m.findings)-1)
+			return m, Handled("cursor_move:bottom")
+		case kb.ActionApplyAuditFix:
+			if m.applying || m.cursor >= len(m.findings) {
+				return m, Handled("audit_fix:unavailable")
+			}
+			m.applying = true
+			m.status = "Applying fix..."
+			return m, m.applyFix(m.findings[m.cursor])
+		}
+	}
+
+	return m, nil
+}
+
+// applyFix creates a command that applies the fix for a single finding
+func (m *ListAuditModel) applyFix(finding service.AuditFinding) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		return AuditFixResultMsg{
+			Finding: finding,
+			Error:   m.animeService.ApplyAuditFix(ctx, finding),
+		}
+	}
+}
+
+// removeFinding drops a fixed finding from the list, keeping the cursor in range
+func (m *ListAuditModel) removeFinding(fixed service.AuditFinding) {
+	for i, finding := range m.findings {
+		if finding.Anime.ID == fixed.Anime.ID && finding.Kind == fixed.Kind {
+			m.findings = append(m.findings[:i], m.findings[i+1:]...)
+			break
+		}
+	}
+	if m.cursor >= len(m.findings) {
+		m.cursor = max(0, len(m.findings)-1)
+	}
+}
+
+// View renders the audit findings
+func (m *ListAuditModel) View() string {
+	header := styles.Header(m.width, "List Audit")
+
+	summary := fmt.Sprintf("%d inconsistent entries found", len(m.findings))
+	if m.status != "" {
+		summary += "  •  " + m.status
+	}
+
+	keyBindings := []components.KeyBinding{
+		{kb.DisplayKeys(kb.ContextListAudit, kb.ActionMoveUp, kb.ActionMoveDown), "Navigate"},
+		{kb.DisplayKeyWithSecondary(kb.ActionApplyAuditFix, kb.ContextListAudit), "Fix"},
+		{kb.DisplayKey(kb.ActionToggleHelp, kb.ContextListAudit), "Help"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextListAudit), "Return"},
+	}
+	footer := components.KeyBindingsBar(m.width, keyBindings)
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s", header, styles.FilterStatus.Render(summary), m.renderFindings(), footer)
+}
+
+// renderFindings renders the scrollable list of findings
+func (m *ListAuditModel) renderFindings() string {
+	if len(m.findings) == 0 {
+		return styles.CenteredText(m.width, "No problems found in your list")
+	}
+
+	visibleCount := min(len(m.findings), max(1, m.height-12))
+	startIdx := 0
+	if m.cursor >= visibleCount {
+		startIdx = m.cursor - visibleCount + 1
+	}
+	endIdx := min(startIdx+visibleCount, len(m.findings))
+
+	selectedStyle := styles.Selected.
+		Width(m.width-4).
+		Padding(0, 1)
+
+	normalStyle := lipgloss.NewStyle().
+		Width(m.width-4).
+		Padding(0, 1)
+
+	titleWidth := 40
+	var listContent string
+	for i := startIdx; i < endIdx; i++ {
+		finding := m.findings[i]
+		title := util.TruncateString(finding.Anime.Title.Preferred, titleWidth)
+		title += strings.Repeat(" ", max(0, titleWidth-runewidth.StringWidth(title)))
+		itemText := fmt.Sprintf("%s  %-50s  → %s", title, finding.Description, finding.Fix)
+
+		if i == m.cursor {
+			listContent += selectedStyle.Render(itemText) + "\n"
+		} else {
+			listContent += normalStyle.Render(itemText) + "\n"
+		}
+	}
+
+	return styles.ContentBox(m.width-2, listContent, 1)
+}
+
+// Resize updates the dimensions of the model
+func (m *ListAuditModel) Resize(width, height int) {
+	m.width = width
+	m.height = height
+}
@@ -25,7 +25,7 @@ type LoadingModel struct {
 func NewLoadingModel(message string) *LoadingModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4"))
+	s.Style = lipgloss.NewStyle().Foreground(styles.ColorAccent)
 
 	return &LoadingModel{
 		message:   message,
@@ -84,13 +84,13 @@ func (m *LoadingModel) View() string {
 
 	// Special spinner style with more emphasis
 	spinnerStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#9D86FF")).
+		Foreground(styles.ColorAccentSoft).
 		Bold(true).
 		PaddingRight(1)
 
 	// Message style for the primary message
 	messageStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#FFFFFF")).
+		Foreground(styles.ColorText).
 		Bold(true)
 
 	// Center alignment style for all content
@@ -108,7 +108,7 @@ func (m *LoadingModel) View() string {
 	// Add spacing and context info if present
 	if m.contextInfo != "" {
 		contextStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#AAAAAA")).
+			Foreground(styles.ColorMuted).
 			Italic(true).
 			Width(contentWidth - 6).
 			Align(lipgloss.Center)
@@ -120,7 +120,7 @@ func (m *LoadingModel) View() string {
 	// Add action text if present with distinctive styling
 	if m.actionText != "" {
 		actionStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#43BF6D")).
+			Foreground(styles.ColorSuccess).
 			Bold(true).
 			Width(contentWidth-6).
 			Align(lipgloss.Center).
@@ -136,7 +136,7 @@ func (m *LoadingModel) View() string {
 	// Create a bordered box with enhanced styling
 	boxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#9D86FF")).
+		BorderForeground(styles.ColorAccentSoft).
 		Padding(2, 3).
 		Width(contentWidth)
 
@@ -144,10 +144,7 @@ func (m *LoadingModel) View() string {
 	var finalView string
 	if m.title != "" {
 		// If we have a title, use it in the header with special styling for emphasis
-		titleStyle := lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Background(lipgloss.Color("#7D56F4")).
+		titleStyle := styles.Title.
 			Padding(0, 2).
 			Align(lipgloss.Center).
 			Width(contentWidth)
@@ -0,0 +1,295 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/localfiles"
+	"github.com/PizzaHomicide/hisame/internal/service"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/util"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// defaultLocalImportDir is the folder the import path is pre-filled with, in the user's home directory
+const defaultLocalImportDir = "Videos"
+
+// LocalImportModel scans a folder of downloaded episodes and offers to bring AniList progress up to the episodes
+// found, for users moving over from watching files from a folder
+type LocalImportModel struct {
+	width, height int
+	animeService  *service.AnimeService
+	input         textinput.Model
+	scanned       bool // Whether a folder has been scanned, switching from the path prompt to the suggestions
+	working       bool
+	suggestions   []service.LocalProgress
+	selected      map[int]bool // AniList IDs of the suggestions to apply
+	unmatched     []string
+	cursor        int
+	status        string
+}
+
+// NewLocalImportModel creates a new local import model, starting with the folder prompt
+func NewLocalImportModel(animeService *service.AnimeService) *LocalImportModel {
+	ti := textinput.New()
+	ti.Placeholder = "Folder of downloaded episodes..."
+	ti.Width = 60
+	ti.SetValue(defaultLocalImportPath())
+	ti.Focus()
+
+	return &LocalImportModel{
+		animeService: animeService,
+		input:        ti,
+		selected:     make(map[int]bool),
+	}
+}
+
+func (m *LocalImportModel) ViewType() View {
+	return ViewLocalImport
+}
+
+// Init initializes the model
+func (m *LocalImportModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles messages
+func (m *LocalImportModel) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case LocalScanResultMsg:
+		m.working = false
+		if msg.Error != nil {
+			m.status = fmt.Sprintf("Scan failed: %v", msg.Error)
+			return m, nil
+		}
+		m.scanned = true
+		m.suggestions = msg.Suggestions
+		m.unmatched = msg.Unmatched
+		m.cursor = 0
+		for _, suggestion := range m.suggestions {
+			m.selected[suggestion.Anime.ID] = true
+		}
+		m.status = fmt.Sprintf("Found %d episode files in %s", msg.Files, msg.Dir)
+		return m, nil
+
+	case LocalImportResultMsg:
+		m.working = false
+		if msg.Error != nil {
+			m.status = fmt.Sprintf("Import did not finish: %v", msg.Error)
+		} else {
+			m.status = fmt.Sprintf("Updated progress for %d entries", msg.Result.Updated)
+		}
+		if msg.Result.Failed > 0 {
+			m.status += fmt.Sprintf(", %d failed", msg.Result.Failed)
+		}
+		m.suggestions = nil
+		return m, nil
+
+	case tea.KeyMsg:
+		if !m.scanned {
+			return m, m.handlePromptKeyMsg(msg)
+		}
+		return m, m.handleSuggestionsKeyMsg(msg)
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// handlePromptKeyMsg handles keys while asking for the folder.  Keys other than enter and esc are typed into the path.
+func (m *LocalImportModel) handlePromptKeyMsg(msg tea.KeyMsg) tea.Cmd {
+	switch kb.GetActionByKey(msg, kb.ContextLocalImport) {
+	case kb.ActionConfirmLocalImport:
+		if m.working {
+			return Handled("local_import:busy")
+		}
+		m.working = true
+		m.status = "Scanning..."
+		return m.scan(expandHome(strings.TrimSpace(m.input.Value())))
+	case kb.ActionBack:
+		// Let the app pop the import view
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if cmd == nil {
+		cmd = Handled("local_import:input")
+	}
+	return cmd
+}
+
+// handleSuggestionsKeyMsg handles keys while reviewing the suggested progress
+func (m *LocalImportModel) handleSuggestionsKeyMsg(msg tea.KeyMsg) tea.Cmd {
+	switch kb.GetActionByKey(msg, kb.ContextLocalImport) {
+	case kb.ActionMoveUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return Handled("cursor_move:up")
+	case kb.ActionMoveDown:
+		if m.cursor < len(m.suggestions)-1 {
+			m.cursor++
+		}
+		return Handled("cursor_move:down")
+	case kb.ActionMoveTop:
+		m.cursor = 0
+		return Handled("cursor_move:top")
+	case kb.ActionMoveBottom:
+		m.cursor = max(0, len(m.suggestions)-1)
+		return Handled("cursor_move:bottom")
+	case kb.ActionToggleLocalImportEntry:
+		if m.cursor < len(m.suggestions) {
+			id := m.suggestions[m.cursor].Anime.ID
+			m.selected[id] = !m.selected[id]
+		}
+		return Handled("local_import:toggle")
+	case kb.ActionConfirmLocalImport:
+		return m.apply()
+	}
+	return nil
+}
+
+// scan creates a command that looks for episode files in the folder and matches them to the list
+func (m *LocalImportModel) scan(dir string) tea.Cmd {
+	return func() tea.Msg {
+		if dir == "" {
+			return LocalScanResultMsg{Error: fmt.Errorf("no folder entered")}
+		}
+		files, err := localfiles.Scan(dir)
+		if err != nil {
+			return LocalScanResultMsg{Dir: dir, Error: err}
+		}
+		suggestions, unmatched := m.animeService.SuggestLocalProgress(files)
+		return LocalScanResultMsg{Dir: dir, Files: len(files), Suggestions: suggestions, Unmatched: unmatched}
+	}
+}
+
+// apply creates a command that saves the selected suggestions to AniList
+func (m *LocalImportModel) apply() tea.Cmd {
+	var chosen []service.LocalProgress
+	for _, suggestion := range m.suggestions {
+		if m.selected[suggestion.Anime.ID] {
+			chosen = append(chosen, suggestion)
+		}
+	}
+	if m.working || len(chosen) == 0 {
+		return Handled("local_import:nothing_to_apply")
+	}
+	m.working = true
+	m.status = fmt.Sprintf("Updating %d entries...", len(chosen))
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		result, err := m.animeService.ApplyLocalProgress(ctx, chosen)
+		return LocalImportResultMsg{Result: result, Error: err}
+	}
+}
+
+// View renders the folder prompt or the suggestions found in it
+func (m *LocalImportModel) View() string {
+	header := styles.Header(m.width, "Import Progress From Local Files")
+
+	status := ""
+	if m.status != "" {
+		status = styles.FilterStatus.Render(m.status)
+	}
+
+	if !m.scanned {
+		description := "Scans a folder of downloaded episodes and offers to bring your AniList progress up to the " +
+			"episodes found.  Progress only moves forward, and never past a missing episode."
+		prompt := styles.Title.Render("Folder: ") + m.input.View()
+		footer := components.KeyBindingsBar(m.width, []components.KeyBinding{
+			{kb.DisplayKey(kb.ActionConfirmLocalImport, kb.ContextLocalImport), "Scan"},
+			{kb.DisplayKey(kb.ActionBack, kb.ContextLocalImport), "Return"},
+		})
+		return fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s\n\n%s", header, description, prompt, status, footer)
+	}
+
+	footer := components.KeyBindingsBar(m.width, []components.KeyBinding{
+		{kb.DisplayKeys(kb.ContextLocalImport, kb.ActionMoveUp, kb.ActionMoveDown), "Navigate"},
+		{kb.DisplayKey(kb.ActionToggleLocalImportEntry, kb.ContextLocalImport), "Toggle"},
+		{kb.DisplayKey(kb.ActionConfirmLocalImport, kb.ContextLocalImport), "Update selected"},
+		{kb.DisplayKey(kb.ActionToggleHelp, kb.ContextLocalImport), "Help"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextLocalImport), "Return"},
+	})
+	return fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s", header, status, m.renderSuggestions(), footer)
+}
+
+// renderSuggestions renders each suggested progress change with whether it is selected
+func (m *LocalImportModel) renderSuggestions() string {
+	if len(m.suggestions) == 0 {
+		text := "No entries are behind the episodes found"
+		if len(m.unmatched) > 0 {
+			text += fmt.Sprintf(".  %d shows didn't match anything on your list", len(m.unmatched))
+		}
+		return styles.CenteredText(m.width, text)
+	}
+
+	visibleCount := min(len(m.suggestions), max(1, m.height-13))
+	startIdx := 0
+	if m.cursor >= visibleCount {
+		startIdx = m.cursor - visibleCount + 1
+	}
+	endIdx := min(startIdx+visibleCount, len(m.suggestions))
+
+	selectedStyle := styles.Selected.
+		Width(m.width-4).
+		Padding(0, 1)
+
+	normalStyle := lipgloss.NewStyle().
+		Width(m.width-4).
+		Padding(0, 1)
+
+	titleWidth := 40
+	var listContent string
+	for i := startIdx; i < endIdx; i++ {
+		suggestion := m.suggestions[i]
+		check := "[ ]"
+		if m.selected[suggestion.Anime.ID] {
+			check = "[x]"
+		}
+		title := util.TruncateString(suggestion.Anime.Title.Preferred, titleWidth)
+		title += strings.Repeat(" ", max(0, titleWidth-runewidth.StringWidth(title)))
+		itemText := fmt.Sprintf("%s %s  %d → %d  (%d files)", check, title,
+			suggestion.Anime.UserData.Progress, suggestion.Progress, suggestion.Files)
+
+		if i == m.cursor {
+			listContent += selectedStyle.Render(itemText) + "\n"
+		} else {
+			listContent += normalStyle.Render(itemText) + "\n"
+		}
+	}
+	if len(m.unmatched) > 0 {
+		listContent += "\n" + util.TruncateString("Not on your list: "+strings.Join(m.unmatched, ", "), max(1, m.width-8))
+	}
+
+	return styles.ContentBox(m.width-2, listContent, 1)
+}
+
+// defaultLocalImportPath returns the folder the import prompt starts with
+func defaultLocalImportPath() string {
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homedir, defaultLocalImportDir)
+}
+
+// Resize updates the dimensions of the model
+func (m *LocalImportModel) Resize(width, height int) {
+	m.width = width
+	m.height = height
+}
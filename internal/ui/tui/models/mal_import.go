@@ -0,0 +1,331 @@
+package models
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/export"
+	"github.com/PizzaHomicide/hisame/internal/service"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/util"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// defaultMALImportFileName is the file the import path is pre-filled with, in the user's home directory
+const defaultMALImportFileName = "animelist.xml"
+
+// MALImportModel reads a MyAnimeList export and previews the changes importing it makes to the AniList list, saving
+// only the ones the user keeps selected
+type MALImportModel struct {
+	width, height int
+	animeService  *service.AnimeService
+	input         textinput.Model
+	planned       bool // Whether a file has been read, switching from the path prompt to the preview
+	working       bool
+	plan          service.MALImportPlan
+	selected      map[int]bool // AniList IDs of the changes to save
+	cursor        int
+	status        string
+}
+
+// NewMALImportModel creates a new MyAnimeList import model, starting with the file prompt
+func NewMALImportModel(animeService *service.AnimeService) *MALImportModel {
+	ti := textinput.New()
+	ti.Placeholder = "MyAnimeList export file..."
+	ti.Width = 60
+	ti.SetValue(defaultMALImportPath())
+	ti.Focus()
+
+	return &MALImportModel{
+		animeService: animeService,
+		input:        ti,
+		selected:     make(map[int]bool),
+	}
+}
+
+func (m *MALImportModel) ViewType() View {
+	return ViewMALImport
+}
+
+// Init initializes the model
+func (m *MALImportModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles messages
+func (m *MALImportModel) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case MALImportPlanMsg:
+		m.working = false
+		if msg.Error != nil {
+			m.status = fmt.Sprintf("Failed to read the export: %v", msg.Error)
+			return m, nil
+		}
+		m.planned = true
+		m.plan = msg.Plan
+		m.cursor = 0
+		for _, change := range m.plan.Changes {
+			m.selected[change.Anime.ID] = true
+		}
+		m.status = fmt.Sprintf("%d entries to change, %d already up to date", len(m.plan.Changes), m.plan.Unchanged)
+		return m, nil
+
+	case MALImportResultMsg:
+		m.working = false
+		if msg.Error != nil {
+			m.status = fmt.Sprintf("Import did not finish: %v", msg.Error)
+		} else {
+			m.status = fmt.Sprintf("Added %d and updated %d entries", msg.Result.Added, msg.Result.Updated)
+		}
+		if msg.Result.Failed > 0 {
+			m.status += fmt.Sprintf(", %d failed", msg.Result.Failed)
+		}
+		m.plan.Changes = nil
+		return m, nil
+
+	case tea.KeyMsg:
+		if !m.planned {
+			return m, m.handlePromptKeyMsg(msg)
+		}
+		return m, m.handlePreviewKeyMsg(msg)
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// handlePromptKeyMsg handles keys while asking for the file.  Keys other than enter and esc are typed into the path.
+func (m *MALImportModel) handlePromptKeyMsg(msg tea.KeyMsg) tea.Cmd {
+	switch kb.GetActionByKey(msg, kb.ContextMALImport) {
+	case kb.ActionConfirmMALImport:
+		if m.working {
+			return Handled("mal_import:busy")
+		}
+		m.working = true
+		m.status = "Reading export..."
+		return m.preview(expandHome(strings.TrimSpace(m.input.Value())))
+	case kb.ActionBack:
+		// Let the app pop the import view
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if cmd == nil {
+		cmd = Handled("mal_import:input")
+	}
+	return cmd
+}
+
+// handlePreviewKeyMsg handles keys while reviewing the changes
+func (m *MALImportModel) handlePreviewKeyMsg(msg tea.KeyMsg) tea.Cmd {
+	switch kb.GetActionByKey(msg, kb.ContextMALImport) {
+	case kb.ActionMoveUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return Handled("cursor_move:up")
+	case kb.ActionMoveDown:
+		if m.cursor < len(m.plan.Changes)-1 {
+			m.cursor++
+		}
+		return Handled("cursor_move:down")
+	case kb.ActionMoveTop:
+		m.cursor = 0
+		return Handled("cursor_move:top")
+	case kb.ActionMoveBottom:
+		m.cursor = max(0, len(m.plan.Changes)-1)
+		return Handled("cursor_move:bottom")
+	case kb.ActionToggleMALImportEntry:
+		if m.cursor < len(m.plan.Changes) {
+			id := m.plan.Changes[m.cursor].Anime.ID
+			m.selected[id] = !m.selected[id]
+		}
+		return Handled("mal_import:toggle")
+	case kb.ActionConfirmMALImport:
+		return m.apply()
+	}
+	return nil
+}
+
+// preview creates a command that reads the export and works out what importing it would change
+func (m *MALImportModel) preview(path string) tea.Cmd {
+	return func() tea.Msg {
+		if path == "" {
+			return MALImportPlanMsg{Error: fmt.Errorf("no file entered")}
+		}
+		entries, err := readMALExport(path)
+		if err != nil {
+			return MALImportPlanMsg{Path: path, Error: err}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		plan, err := m.animeService.PlanMALImport(ctx, entries)
+		return MALImportPlanMsg{Path: path, Plan: plan, Error: err}
+	}
+}
+
+// readMALExport reads a MyAnimeList export, decompressing it first if it is the .gz file MyAnimeList gives out
+func readMALExport(path string) ([]export.MALEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(strings.ToLower(path), ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress %s: %w", path, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+	return export.ReadMALXML(r)
+}
+
+// apply creates a command that saves the selected changes to AniList
+func (m *MALImportModel) apply() tea.Cmd {
+	var chosen []service.MALImportChange
+	for _, change := range m.plan.Changes {
+		if m.selected[change.Anime.ID] {
+			chosen = append(chosen, change)
+		}
+	}
+	if m.working || len(chosen) == 0 {
+		return Handled("mal_import:nothing_to_apply")
+	}
+	m.working = true
+	m.status = fmt.Sprintf("Saving %d entries...", len(chosen))
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+		defer cancel()
+
+		result, err := m.animeService.ApplyMALImport(ctx, chosen)
+		return MALImportResultMsg{Result: result, Error: err}
+	}
+}
+
+// View renders the file prompt or the preview of the changes
+func (m *MALImportModel) View() string {
+	header := styles.Header(m.width, "Import From MyAnimeList")
+
+	status := ""
+	if m.status != "" {
+		status = styles.FilterStatus.Render(m.status)
+	}
+
+	if !m.planned {
+		description := "Reads a list exported from MyAnimeList and shows what importing it would change on your " +
+			"AniList.  Nothing is saved until you have reviewed the changes."
+		prompt := styles.Title.Render("File: ") + m.input.View()
+		footer := components.KeyBindingsBar(m.width, []components.KeyBinding{
+			{kb.DisplayKey(kb.ActionConfirmMALImport, kb.ContextMALImport), "Preview"},
+			{kb.DisplayKey(kb.ActionBack, kb.ContextMALImport), "Return"},
+		})
+		return fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s\n\n%s", header, description, prompt, status, footer)
+	}
+
+	footer := components.KeyBindingsBar(m.width, []components.KeyBinding{
+		{kb.DisplayKeys(kb.ContextMALImport, kb.ActionMoveUp, kb.ActionMoveDown), "Navigate"},
+		{kb.DisplayKey(kb.ActionToggleMALImportEntry, kb.ContextMALImport), "Toggle"},
+		{kb.DisplayKey(kb.ActionConfirmMALImport, kb.ContextMALImport), "Save selected"},
+		{kb.DisplayKey(kb.ActionToggleHelp, kb.ContextMALImport), "Help"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextMALImport), "Return"},
+	})
+	return fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s", header, status, m.renderChanges(), footer)
+}
+
+// renderChanges renders each change with whether it is selected
+func (m *MALImportModel) renderChanges() string {
+	changes := m.plan.Changes
+	if len(changes) == 0 {
+		text := "Nothing to change"
+		if len(m.plan.Unmatched) > 0 {
+			text += fmt.Sprintf(".  %d entries have no anime on AniList", len(m.plan.Unmatched))
+		}
+		return styles.CenteredText(m.width, text)
+	}
+
+	visibleCount := min(len(changes), max(1, m.height-13))
+	startIdx := 0
+	if m.cursor >= visibleCount {
+		startIdx = m.cursor - visibleCount + 1
+	}
+	endIdx := min(startIdx+visibleCount, len(changes))
+
+	selectedStyle := styles.Selected.
+		Width(m.width-4).
+		Padding(0, 1)
+
+	normalStyle := lipgloss.NewStyle().
+		Width(m.width-4).
+		Padding(0, 1)
+
+	titleWidth := 40
+	var listContent string
+	for i := startIdx; i < endIdx; i++ {
+		change := changes[i]
+		check := "[ ]"
+		if m.selected[change.Anime.ID] {
+			check = "[x]"
+		}
+		title := util.TruncateString(change.Anime.Title.Preferred, titleWidth)
+		title += strings.Repeat(" ", max(0, titleWidth-runewidth.StringWidth(title)))
+		description := strings.Join(change.Changes, ", ")
+		if change.New {
+			description = "add, " + description
+		}
+		itemText := util.TruncateString(fmt.Sprintf("%s %s  %s", check, title, description), max(1, m.width-6))
+
+		if i == m.cursor {
+			listContent += selectedStyle.Render(itemText) + "\n"
+		} else {
+			listContent += normalStyle.Render(itemText) + "\n"
+		}
+	}
+	if len(m.plan.Unmatched) > 0 {
+		titles := make([]string, 0, len(m.plan.Unmatched))
+		for _, entry := range m.plan.Unmatched {
+			if entry.Title == "" {
+				titles = append(titles, fmt.Sprintf("MyAnimeList #%d", entry.MalID))
+				continue
+			}
+			titles = append(titles, entry.Title)
+		}
+		listContent += "\n" + util.TruncateString("Not on AniList: "+strings.Join(titles, ", "), max(1, m.width-8))
+	}
+
+	return styles.ContentBox(m.width-2, listContent, 1)
+}
+
+// defaultMALImportPath returns the file the import prompt starts with
+func defaultMALImportPath() string {
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return defaultMALImportFileName
+	}
+	return filepath.Join(homedir, defaultMALImportFileName)
+}
+
+// Resize updates the dimensions of the model
+func (m *MALImportModel) Resize(width, height int) {
+	m.width = width
+	m.height = height
+}
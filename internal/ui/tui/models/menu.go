@@ -87,9 +87,9 @@ func (m *MenuModel) View() string {
 	content := styles.ContentBox(m.width-4, menuContent, 1)
 
 	keyBindings := []components.KeyBinding{
-		{"↑/↓", "Navigate"},
-		{"Enter", "Select"},
-		{"Esc", "Cancel"},
+		{kb.DisplayKeys(kb.ContextMenu, kb.ActionMoveUp, kb.ActionMoveDown), "Navigate"},
+		{kb.DisplayKey(kb.ActionSelectMenuItem, kb.ContextMenu), "Select"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextMenu), "Cancel"},
 	}
 	footer := components.KeyBindingsBar(m.width, keyBindings)
 
@@ -125,7 +125,7 @@ func (item MenuItem) renderSeparator(width int) string {
 	}
 	// Calculate the space available for dashes
 	separatorStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#888888"))
+		Foreground(styles.ColorFaint)
 
 	textWidth := lipgloss.Width(item.Text)
 	availableWidth := width - 10                   // Account for margins and padding
@@ -149,10 +149,7 @@ func (item MenuItem) renderSeparator(width int) string {
 
 // renderSelectable renders the item as a selectable menu item
 func (item MenuItem) renderSelectable(width int, isSelected bool) string {
-	selectedStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("#FFFFFF")).
-		Background(lipgloss.Color("#7D56F4")).
+	selectedStyle := styles.Selected.
 		Width(width-8).
 		Padding(0, 1)
 
@@ -1,10 +1,14 @@
 package models
 
 import (
+	"github.com/PizzaHomicide/hisame/internal/cast"
 	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/filler"
 	"github.com/PizzaHomicide/hisame/internal/player"
 	"github.com/PizzaHomicide/hisame/internal/repository/anilist"
+	"github.com/PizzaHomicide/hisame/internal/service"
 	tea "github.com/charmbracelet/bubbletea"
+	"time"
 )
 
 // AuthMsg combines auth success and failure
@@ -38,27 +42,37 @@ type PlaybackMsg struct {
 	Episode   player.AllAnimeEpisodeInfo
 	Anime     *domain.Anime
 	Sources   *player.EpisodeSourceInfo
+	Details   []player.SourceDetails // What each source plays, when the user is choosing one
 	StreamURL string
 	Progress  float64
 	Error     error
+	// Playback was left going when Hisame was last closed, and has been picked back up
+	Reattached bool
+	// The player left going has exited since, so its end is replayed from the playback journal
+	Missed bool
 }
 
 // EpisodeEventType represents different episode-related events
 type EpisodeEventType string
 
 const (
-	EpisodeEventLoaded   EpisodeEventType = "loaded"
-	EpisodeEventSelected EpisodeEventType = "selected"
-	EpisodeEventError    EpisodeEventType = "error"
+	EpisodeEventLoaded        EpisodeEventType = "loaded"
+	EpisodeEventSelected      EpisodeEventType = "selected"
+	EpisodeEventChooseSource  EpisodeEventType = "choose_source"   // Selected, but the user wants to pick the source
+	EpisodeEventCopyStreamURL EpisodeEventType = "copy_stream_url" // Selected, but the user wants the stream URL copied
+	EpisodeEventError         EpisodeEventType = "error"
 )
 
 // EpisodeMsg consolidates episode-related messages
 type EpisodeMsg struct {
-	Type     EpisodeEventType
-	Episodes []player.AllAnimeEpisodeInfo
-	Episode  *player.AllAnimeEpisodeInfo
-	Title    string
-	Error    error
+	Type          EpisodeEventType
+	Episodes      []player.AllAnimeEpisodeInfo
+	EpisodeTitles map[int]string          // Titles of the episodes by overall episode number, where known
+	Schedule      []domain.AiringSchedule // Episodes still to air, where known
+	Filler        filler.Episodes         // Filler and recap episodes, where known
+	Episode       *player.AllAnimeEpisodeInfo
+	Title         string
+	Error         error
 }
 
 // LoadingType represents different loading-related events
@@ -80,9 +94,10 @@ type LoadingMsg struct {
 }
 
 type AnimeListLoadResultMsg struct {
-	Success   bool
-	AnimeList []*domain.Anime
-	Error     error
+	Success    bool
+	AnimeList  []*domain.Anime
+	Error      error
+	Background bool // Loaded without a loading screen, such as the cached list and its refresh on startup
 }
 
 // TokenValidationMsg represents the result of validating an authentication token
@@ -94,11 +109,57 @@ type TokenValidationMsg struct {
 	IsNetwork bool            // Whether the error was a network-related error
 }
 
+// TokenExpiredMsg is sent when the AniList login expires, or AniList rejects the token part way through a session
+type TokenExpiredMsg struct {
+	Token string // The token that expired, if it reached its expiry time rather than being rejected by AniList
+}
+
+// LocalModeMsg starts Hisame with the local list instead of an AniList account
+type LocalModeMsg struct{}
+
 // AnimeUpdatedMsg indicates an anime in the list has been updated
 type AnimeUpdatedMsg struct {
-	Success bool
+	Success   bool
+	AnimeID   int
+	Message   string
+	Error     error
+	Completed bool // Whether this update moved the anime to completed
+	Queued    bool // AniList couldn't be reached, so the change was kept locally to be saved later
+}
+
+// ToastExpiredMsg is sent when a toast has been shown for long enough.  Seq identifies the toast, so a newer toast
+// isn't cleared early.
+type ToastExpiredMsg struct {
+	Seq int
+}
+
+// AutoRefreshMsg is sent when it is time to refresh the list in the background.  Seq identifies the timer that sent
+// it, so only the latest one keeps refreshing.
+type AutoRefreshMsg struct {
+	Seq int
+}
+
+// HealthCheckMsg is sent when it is time to check the episode providers.  Seq identifies the timer that sent it, as
+// with AutoRefreshMsg.
+type HealthCheckMsg struct {
+	Seq int
+}
+
+// ProviderHealthMsg carries the results of checking the episode providers
+type ProviderHealthMsg struct {
+	Results []player.ProviderHealth
+}
+
+// RefreshAnimeMsg is sent when the user wants to re-fetch a single anime rather than the whole list
+type RefreshAnimeMsg struct {
+	AnimeID int
+}
+
+// AnimeRefreshedMsg is sent once a single anime has been re-fetched
+type AnimeRefreshedMsg struct {
 	AnimeID int
-	Message string
+	Title   string
+	Removed bool // The anime is no longer on the user's list, so was removed from it
 	Error   error
 }
 
@@ -107,6 +168,8 @@ type PlaybackCompletedMsg struct {
 	AnimeID       int
 	EpisodeNumber int
 	Progress      float64
+	Estimated     bool // Progress was estimated from how long a player without IPC ran, so needs confirming
+	Missed        bool // The player exited while Hisame was closed, so progress is only as far as was last journaled
 }
 
 // AnimeDetailsMsg is sent when a user wants to view the details for an anime
@@ -114,6 +177,13 @@ type AnimeDetailsMsg struct {
 	Anime *domain.Anime
 }
 
+// AiringScheduleMsg carries the episodes of an anime still to air, fetched for the details view
+type AiringScheduleMsg struct {
+	AnimeID  int
+	Schedule []domain.AiringSchedule
+	Error    error
+}
+
 // HandledMsg is used when a model wants to bubble up the fact that it handled a message
 // and that further processing is likely not required (though the orchestration layer still
 // CAN do further processing if it deems necessary).
@@ -147,7 +217,416 @@ type PlayNextEpisodeMsg struct {
 	AnimeID int
 }
 
+// ChoosePresetMsg is sent when the user wants to pick the player preset used for an anime
+type ChoosePresetMsg struct {
+	AnimeID int
+}
+
+// SetAnimePresetMsg is sent when the user has picked a player preset for an anime.  An empty preset goes back to the
+// configured one.
+type SetAnimePresetMsg struct {
+	AnimeID int
+	Preset  string
+}
+
+// ChooseCastDeviceMsg is sent when the user wants to pick the Chromecast episodes are cast to
+type ChooseCastDeviceMsg struct{}
+
+// CastDevicesFoundMsg is sent once discovery of the Chromecasts on the local network has finished
+type CastDevicesFoundMsg struct {
+	Devices []cast.Device
+	Err     error
+}
+
+// SetCastDeviceMsg is sent when the user has picked the Chromecast to cast episodes to.  An empty name goes back to
+// playing episodes locally.
+type SetCastDeviceMsg struct {
+	Name string
+}
+
+// ChooseTranslationMsg is sent when the user wants to pick whether an anime is watched subbed or dubbed
+type ChooseTranslationMsg struct {
+	AnimeID int
+}
+
+// SetAnimeTranslationMsg is sent when the user has picked the translation type for an anime.  An empty type goes back
+// to the configured one.
+type SetAnimeTranslationMsg struct {
+	AnimeID         int
+	TranslationType string
+}
+
+// ForgetShowMappingMsg is sent when the user wants an anime matched to AllAnime's shows again rather than using the
+// shows it was matched to before
+type ForgetShowMappingMsg struct {
+	AnimeID int
+}
+
+// ChooseWrongMatchMsg is sent when the user wants to pick an AllAnime show an anime was wrongly matched to
+type ChooseWrongMatchMsg struct {
+	AnimeID int
+}
+
+// MatchedShowsMsg carries the AllAnime shows an anime is matched to
+type MatchedShowsMsg struct {
+	Anime *domain.Anime
+	Shows []player.AllAnimeShow
+	Error error
+}
+
+// BlacklistShowMsg is sent when the user has said an AllAnime show is not the anime it was matched to
+type BlacklistShowMsg struct {
+	AnimeID  int
+	ShowID   string
+	ShowName string
+}
+
+// ScrobbledMsg carries the result of adding a watched episode to the user's Simkl history
+type ScrobbledMsg struct {
+	AnimeID       int
+	EpisodeNumber int
+	Error         error
+}
+
+// PlaySourceMsg is sent when the user has chosen the source to play an episode from
+type PlaySourceMsg struct {
+	Episode player.AllAnimeEpisodeInfo
+	Source  player.SourceDetails
+}
+
+// CopyStreamURLMsg is sent when the user wants the stream URL of an episode on the clipboard, to play it somewhere
+// else.  The URL of the chosen source is copied, or of the first working source if Source is nil.
+type CopyStreamURLMsg struct {
+	Episode player.AllAnimeEpisodeInfo
+	Source  *player.SourceDetails
+}
+
+// StreamURLCopiedMsg is sent once the stream URL of an episode has been copied to the clipboard, or couldn't be
+type StreamURLCopiedMsg struct {
+	Episode    player.AllAnimeEpisodeInfo
+	SourceName string
+	Referer    string // Referer the stream's host needs, which has to be given to whatever plays the URL
+	Error      error
+}
+
+// FindTorrentsMsg is sent when the user wants to search Nyaa for torrents of an episode.  Episode 0 searches for the
+// next episode.
+type FindTorrentsMsg struct {
+	AnimeID int
+	Episode int
+}
+
+// TorrentsFoundMsg carries the torrents found on Nyaa for an episode
+type TorrentsFoundMsg struct {
+	Anime    *domain.Anime
+	Episode  int
+	Torrents []player.Torrent
+	Error    error
+}
+
+// PlayTorrentMsg is sent when the user has chosen a torrent to play an episode from
+type PlayTorrentMsg struct {
+	Anime   *domain.Anime
+	Episode int
+	Torrent player.Torrent
+}
+
 // ChooseEpisodeMsg is sent when we want to show the user the episode selection screen
 type ChooseEpisodeMsg struct {
 	AnimeID int
 }
+
+// ShowWatchOrderMsg is sent when the user wants to see the franchise watch order for an anime
+type ShowWatchOrderMsg struct {
+	AnimeID int
+}
+
+// WatchOrderMsg carries the result of building a franchise watch order
+type WatchOrderMsg struct {
+	Title   string
+	Entries []service.WatchOrderEntry
+	Error   error
+}
+
+// IncrementProgressMsg is sent when the user confirms an episode should be marked watched
+type IncrementProgressMsg struct {
+	AnimeID       int
+	EpisodeNumber int
+}
+
+// PostActivityMsg is sent when a completion activity should be posted to AniList for an anime
+type PostActivityMsg struct {
+	AnimeID int
+}
+
+// ActivityPostedMsg carries the result of posting a completion activity
+type ActivityPostedMsg struct {
+	AnimeID int
+	Error   error
+}
+
+// ShowCompletionBackfillMsg is sent when the user wants to backfill missing completion dates
+type ShowCompletionBackfillMsg struct {
+	Entries []*domain.Anime
+}
+
+// CompletionBackfillResultMsg carries the result of backfilling completion dates
+type CompletionBackfillResultMsg struct {
+	Result service.BackfillResult
+	Error  error
+}
+
+// ShowListAuditMsg is sent when the user wants to audit their list for inconsistent entries
+type ShowListAuditMsg struct{}
+
+// AuditFixResultMsg carries the result of applying the fix for an audit finding
+type AuditFixResultMsg struct {
+	Finding service.AuditFinding
+	Error   error
+}
+
+// ShowProfileMsg is sent when the user wants to see which AniList account is logged in
+type ShowProfileMsg struct{}
+
+// ShowSessionMsg is sent when the user wants to see the login session and AniList's rate limit
+type ShowSessionMsg struct{}
+
+// LogoutMsg is sent to log out of AniList, returning to the auth screen
+type LogoutMsg struct {
+	SwitchAccount bool // Whether the user is logging out to log in as a different account
+}
+
+// RateLimitedMsg is sent when AniList's rate limit is holding requests back
+type RateLimitedMsg struct {
+	Wait time.Duration // How long until the requests are retried
+}
+
+// RateLimitTickMsg updates the rate limit countdown
+type RateLimitTickMsg struct{}
+
+// QuitWhenIdleMsg is sent when the user chooses to quit once changes have saved and playback has ended
+type QuitWhenIdleMsg struct{}
+
+// QuitWaitTickMsg checks whether Hisame can quit yet, after the user chose to wait for unfinished work
+type QuitWaitTickMsg struct{}
+
+// QuitNowMsg is sent when the user chooses to quit without waiting for unfinished work
+type QuitNowMsg struct {
+	StopPlayback bool // Stop the player too, rather than leaving it running
+}
+
+// ShowLocalImportMsg is sent when the user wants to import progress from a folder of downloaded episodes
+type ShowLocalImportMsg struct{}
+
+// LocalScanResultMsg carries the progress suggested from the episode files found in a folder
+type LocalScanResultMsg struct {
+	Dir         string
+	Files       int // How many episode files were found
+	Suggestions []service.LocalProgress
+	Unmatched   []string // Titles of files that didn't match anything on the list
+	Error       error
+}
+
+// LocalImportResultMsg carries the result of saving progress found in local files
+type LocalImportResultMsg struct {
+	Result service.LocalImportResult
+	Error  error
+}
+
+// ShowMALImportMsg is sent when the user wants to import a MyAnimeList export
+type ShowMALImportMsg struct{}
+
+// MALImportPlanMsg carries what importing a MyAnimeList export would change
+type MALImportPlanMsg struct {
+	Path  string
+	Plan  service.MALImportPlan
+	Error error
+}
+
+// MALImportResultMsg carries the result of saving the changes from a MyAnimeList export
+type MALImportResultMsg struct {
+	Result service.MALImportResult
+	Error  error
+}
+
+// ShowSeasonScoresMsg is sent when the user wants to score what they completed in a season
+type ShowSeasonScoresMsg struct{}
+
+// SeasonScoresSavedMsg carries the result of saving the scores changed in the season scores view
+type SeasonScoresSavedMsg struct {
+	Result service.ScoreResult
+	Error  error
+}
+
+// ShowExportMsg is sent when the user wants to export their list to a file, in the given format or HTML if none is
+// given
+type ShowExportMsg struct {
+	Format exportFormat
+}
+
+// ExportResultMsg carries the result of exporting the list
+type ExportResultMsg struct {
+	Path    string
+	Count   int // Number of anime exported
+	Skipped int // Number of anime left out of a MyAnimeList export for having no MyAnimeList ID
+	Error   error
+}
+
+// HideAnimeMsg is sent when the user wants to hide an anime from Hisame
+type HideAnimeMsg struct {
+	AnimeID int
+}
+
+// ShowFocusMsg is sent when the user wants to focus on a single anime
+type ShowFocusMsg struct {
+	AnimeID int
+}
+
+// FocusAdvanceMsg ticks the countdown to focus mode playing the next episode.  Seq identifies the countdown, so a
+// cancelled one is ignored.
+type FocusAdvanceMsg struct {
+	Seq int
+}
+
+// EditNotesMsg is sent when the user wants to edit the notes of an anime
+type EditNotesMsg struct {
+	AnimeID int
+}
+
+// NotesSavedMsg is sent once edited notes have been saved, or kept as they are on AniList.  Error is a
+// *service.NotesConflict if the notes were changed on AniList while being edited.
+type NotesSavedMsg struct {
+	AnimeID int
+	Error   error
+}
+
+// ChoosePriorityMsg is sent when the user wants to set their priority for an anime
+type ChoosePriorityMsg struct {
+	AnimeID int
+}
+
+// SetPriorityMsg is sent when the user has picked a priority for an anime.  0 clears it.
+type SetPriorityMsg struct {
+	AnimeID  int
+	Priority int
+}
+
+// PriorityChangedMsg is sent once an anime's priority has been saved to AniList
+type PriorityChangedMsg struct {
+	Title    string
+	Priority int
+	Error    error
+}
+
+// SetStatusListsHiddenMsg is sent when the user wants to hide an entry from, or return it to, their AniList status
+// lists
+type SetStatusListsHiddenMsg struct {
+	AnimeID int
+	Hidden  bool
+}
+
+// StatusListsHiddenChangedMsg is sent once an entry has been hidden from, or returned to, the status lists on AniList
+type StatusListsHiddenChangedMsg struct {
+	Title  string
+	Hidden bool
+	Error  error
+}
+
+// ShowHiddenEntriesMsg is sent when the user wants to manage the anime they have hidden
+type ShowHiddenEntriesMsg struct{}
+
+// HiddenEntriesChangedMsg is sent after an anime has been hidden or unhidden, so the list can be re-filtered
+type HiddenEntriesChangedMsg struct{}
+
+// ShowBackupsMsg is sent when the user wants to see the list backups that can be restored
+type ShowBackupsMsg struct{}
+
+// RestoreBackupMsg is sent when the user has confirmed restoring a backup
+type RestoreBackupMsg struct {
+	Backup service.Backup
+}
+
+// BackupRestoredMsg is sent when restoring a backup has finished
+type BackupRestoredMsg struct {
+	Result service.RestoreResult
+	Error  error
+}
+
+// QuickPlayMsg is sent when the user picks an anime from the quick play launcher
+type QuickPlayMsg struct {
+	AnimeID int
+}
+
+// AgendaPlayMsg is sent when the user wants to play an episode from the airing agenda
+type AgendaPlayMsg struct {
+	AnimeID int
+}
+
+// ContinueWatchingPlayMsg is sent when the user picks an anime to continue watching
+type ContinueWatchingPlayMsg struct {
+	AnimeID int
+}
+
+// ShowSnoozesMsg is sent when the user wants to manage what they have snoozed in the airing agenda
+type ShowSnoozesMsg struct{}
+
+// SnoozeMsg is sent when the user picks how long to snooze an anime in the airing agenda for, or the whole agenda if
+// AnimeID is 0
+type SnoozeMsg struct {
+	AnimeID int
+	Period  service.SnoozePeriod
+}
+
+// AniListSearchResultsMsg carries the results of searching all of AniList
+type AniListSearchResultsMsg struct {
+	Query   string
+	Results []*domain.Anime
+	Error   error
+}
+
+// AniListSearchPlayMsg is sent when the user wants to play an anime found by searching AniList.  With AddToList the
+// anime is added to the list as watching first so its progress is tracked, otherwise episodes are chosen and played
+// without touching the list.
+type AniListSearchPlayMsg struct {
+	Anime     *domain.Anime
+	AddToList bool
+}
+
+// AddedToListMsg is sent once an anime found by searching AniList has been added to the list
+type AddedToListMsg struct {
+	Anime *domain.Anime // The list's entry for the anime
+	Title string
+	Error error
+}
+
+// OfflineSyncedMsg carries the result of saving changes that were queued while AniList couldn't be reached
+type OfflineSyncedMsg struct {
+	Result service.OfflineSyncResult
+	Error  error
+}
+
+// RemoteConflictMsg is sent when a change wasn't saved because the entry was changed on AniList since it was loaded
+type RemoteConflictMsg struct {
+	Conflict service.Reconciliation
+}
+
+// OfflineChangeResolvedMsg is sent once a queued change that conflicted with AniList has been settled
+type OfflineChangeResolvedMsg struct {
+	AnimeID    int
+	Title      string
+	Resolution service.Resolution
+	Error      error
+}
+
+// ShowPlaybackErrorMsg is sent when an episode couldn't be played, to show the user why with ways to try again
+type ShowPlaybackErrorMsg struct {
+	Episode player.AllAnimeEpisodeInfo
+	Anime   *domain.Anime // nil if playback wouldn't have updated progress
+	Error   error
+}
+
+// RetryPlaybackMsg is sent when the user wants to try playing an episode again after it failed
+type RetryPlaybackMsg struct {
+	Episode player.AllAnimeEpisodeInfo
+	Anime   *domain.Anime
+}
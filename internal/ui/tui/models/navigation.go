@@ -0,0 +1,67 @@
+package models
+
+import (
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/charmbracelet/bubbles/viewport"
+)
+
+// moveCursor returns where the cursor of a list of length entries, with pageSize of them visible, moves to for a
+// navigation action done count times.  ok is false if the action isn't navigation.
+func moveCursor(cursor, length, pageSize int, action kb.Action, count int) (moved int, ok bool) {
+	pageSize = max(pageSize, 1)
+	switch action {
+	case kb.ActionMoveUp:
+		cursor -= count
+	case kb.ActionMoveDown:
+		cursor += count
+	case kb.ActionPageUp:
+		cursor -= count * pageSize
+	case kb.ActionPageDown:
+		cursor += count * pageSize
+	case kb.ActionHalfPageUp:
+		cursor -= count * max(pageSize/2, 1)
+	case kb.ActionHalfPageDown:
+		cursor += count * max(pageSize/2, 1)
+	case kb.ActionMoveTop:
+		cursor = 0
+	case kb.ActionMoveBottom:
+		cursor = length - 1
+	default:
+		return cursor, false
+	}
+	return max(min(cursor, length-1), 0), true
+}
+
+// scrollViewport scrolls the viewport for a navigation action done count times.  Returns false if the action isn't
+// navigation.
+func scrollViewport(vp *viewport.Model, action kb.Action, count int) bool {
+	switch action {
+	case kb.ActionMoveUp:
+		vp.LineUp(count)
+	case kb.ActionMoveDown:
+		vp.LineDown(count)
+	case kb.ActionPageUp:
+		for range count {
+			vp.ViewUp()
+		}
+	case kb.ActionPageDown:
+		for range count {
+			vp.ViewDown()
+		}
+	case kb.ActionHalfPageUp:
+		for range count {
+			vp.HalfViewUp()
+		}
+	case kb.ActionHalfPageDown:
+		for range count {
+			vp.HalfViewDown()
+		}
+	case kb.ActionMoveTop:
+		vp.GotoTop()
+	case kb.ActionMoveBottom:
+		vp.GotoBottom()
+	default:
+		return false
+	}
+	return true
+}
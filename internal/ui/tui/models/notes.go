@@ -0,0 +1,196 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/service"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// NotesModel edits the notes of a list entry.  If the notes were changed on AniList while being edited, both versions
+// are shown side by side and the user chooses which to keep, or merges them, rather than overwriting either.
+type NotesModel struct {
+	width, height int
+	animeService  *service.AnimeService
+	edit          service.NotesEdit
+	input         textarea.Model
+	conflict      *service.NotesConflict // Set while the user decides how to settle a conflict
+	saving        bool
+	status        string
+}
+
+// NewNotesModel creates a notes editor for the given edit
+func NewNotesModel(animeService *service.AnimeService, edit service.NotesEdit) *NotesModel {
+	ta := textarea.New()
+	ta.Placeholder = "Notes..."
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
+	ta.SetValue(edit.Base)
+	ta.Focus()
+
+	return &NotesModel{
+		animeService: animeService,
+		edit:         edit,
+		input:        ta,
+	}
+}
+
+func (m *NotesModel) ViewType() View {
+	return ViewNotes
+}
+
+// Init initializes the model
+func (m *NotesModel) Init() tea.Cmd {
+	return textarea.Blink
+}
+
+// Update handles messages
+func (m *NotesModel) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case NotesSavedMsg:
+		m.saving = false
+		var conflict *service.NotesConflict
+		if errors.As(msg.Error, &conflict) {
+			m.conflict = conflict
+			m.input.Blur()
+			m.status = "These notes were changed on AniList while you were editing them"
+			return m, nil
+		}
+		m.status = fmt.Sprintf("Failed to save: %v", msg.Error)
+		return m, nil
+
+	case tea.KeyMsg:
+		action := kb.GetActionByKey(msg, kb.ContextNotes)
+		if action == kb.ActionBack {
+			// Let the app pop the editor
+			return m, nil
+		}
+		if m.conflict != nil {
+			return m, m.handleConflictKey(action)
+		}
+		if action == kb.ActionSaveNotes {
+			return m, m.save(m.input.Value(), false)
+		}
+
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		if cmd == nil {
+			cmd = Handled("notes:input")
+		}
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// handleConflictKey settles a conflict as the user chooses
+func (m *NotesModel) handleConflictKey(action kb.Action) tea.Cmd {
+	conflict := m.conflict
+	switch action {
+	case kb.ActionKeepLocal:
+		return m.save(conflict.Local, true)
+	case kb.ActionKeepRemote:
+		// The cached entry already has AniList's notes, so there is nothing to save
+		return func() tea.Msg {
+			return NotesSavedMsg{AnimeID: m.edit.AnimeID}
+		}
+	case kb.ActionMergeEntry:
+		// Carry on editing from AniList's notes, so only a further change there is a conflict
+		m.edit.Base = conflict.Remote
+		m.edit.BaseUpdatedAt = conflict.RemoteUpdatedAt
+		m.input.SetValue(service.MergeNotes(conflict.Edit.Base, conflict.Local, conflict.Remote))
+		m.input.Focus()
+		m.conflict = nil
+		m.status = fmt.Sprintf("Merged both versions.  Review them and press %s to save",
+			kb.DisplayKey(kb.ActionSaveNotes, kb.ContextNotes))
+		return Handled("notes:merge")
+	}
+	return Handled("notes:conflict_ignored_key")
+}
+
+// save saves the notes in the background, checking for changes on AniList first unless force is set
+func (m *NotesModel) save(notes string, force bool) tea.Cmd {
+	if m.saving {
+		return Handled("notes:already_saving")
+	}
+	m.saving = true
+	m.status = "Saving..."
+
+	edit := m.edit
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		return NotesSavedMsg{
+			AnimeID: edit.AnimeID,
+			Error:   m.animeService.SaveNotes(ctx, edit, notes, force),
+		}
+	}
+}
+
+// View renders the editor, or both versions of the notes if they conflict
+func (m *NotesModel) View() string {
+	header := styles.Header(m.width, "Notes: "+m.edit.Title)
+
+	status := ""
+	if m.status != "" {
+		status = styles.FilterStatus.Render(m.status)
+	}
+
+	if m.conflict != nil {
+		keyBindings := []components.KeyBinding{
+			{kb.DisplayKey(kb.ActionKeepLocal, kb.ContextNotes), "Keep yours"},
+			{kb.DisplayKey(kb.ActionKeepRemote, kb.ContextNotes), "Keep AniList's"},
+			{kb.DisplayKey(kb.ActionMergeEntry, kb.ContextNotes), "Merge"},
+			{kb.DisplayKey(kb.ActionBack, kb.ContextNotes), "Discard yours"},
+		}
+		footer := components.KeyBindingsBar(m.width, keyBindings)
+		return fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s", header, status, m.renderConflict(), footer)
+	}
+
+	keyBindings := []components.KeyBinding{
+		{kb.DisplayKey(kb.ActionSaveNotes, kb.ContextNotes), "Save"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextNotes), "Discard"},
+	}
+	footer := components.KeyBindingsBar(m.width, keyBindings)
+	return fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s", header, m.input.View(), status, footer)
+}
+
+// renderConflict renders the user's notes next to AniList's
+func (m *NotesModel) renderConflict() string {
+	columnWidth := max(20, (m.width-8)/2)
+	column := lipgloss.NewStyle().
+		Width(columnWidth).
+		Padding(0, 1)
+
+	render := func(title, notes string) string {
+		if strings.TrimSpace(notes) == "" {
+			notes = "(empty)"
+		}
+		return column.Render(styles.Title.Render(title) + "\n\n" + notes)
+	}
+
+	content := lipgloss.JoinHorizontal(lipgloss.Top,
+		render("Yours", m.conflict.Local),
+		render("On AniList", m.conflict.Remote))
+	return styles.ContentBox(m.width-2, content, 1)
+}
+
+// Resize updates the dimensions of the model
+func (m *NotesModel) Resize(width, height int) {
+	m.width = width
+	m.height = height
+	m.input.SetWidth(max(20, width-4))
+	m.input.SetHeight(max(3, height-10))
+}
@@ -0,0 +1,168 @@
+package models
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/diagnostics"
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/player"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/terminal"
+	"github.com/PizzaHomicide/hisame/internal/version"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// diagnosticsAPICalls is how many of the most recent AllAnime calls are included in copied diagnostics
+const diagnosticsAPICalls = 10
+
+// PlaybackErrorModel shows why an episode couldn't be played, with ways to try it again, so a failure isn't only
+// written to the log file
+type PlaybackErrorModel struct {
+	width, height int
+	config        *config.Config
+	episode       player.AllAnimeEpisodeInfo
+	anime         *domain.Anime // nil if playback wouldn't have updated progress
+	err           error
+	notice        string // Outcome of copying the diagnostics
+}
+
+// NewPlaybackErrorModel creates a new model showing the error playing the episode
+func NewPlaybackErrorModel(cfg *config.Config, episode player.AllAnimeEpisodeInfo, anime *domain.Anime,
+	err error) *PlaybackErrorModel {
+	return &PlaybackErrorModel{
+		config:  cfg,
+		episode: episode,
+		anime:   anime,
+		err:     err,
+	}
+}
+
+func (m *PlaybackErrorModel) ViewType() View {
+	return ViewPlaybackError
+}
+
+// Init initializes the model
+func (m *PlaybackErrorModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m *PlaybackErrorModel) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch kb.GetActionByKey(keyMsg, kb.ContextPlaybackError) {
+	case kb.ActionRetryPlayback:
+		episode, anime := m.episode, m.anime
+		return m, func() tea.Msg {
+			return RetryPlaybackMsg{Episode: episode, Anime: anime}
+		}
+	case kb.ActionChooseSource:
+		episode := m.episode
+		return m, func() tea.Msg {
+			return EpisodeMsg{Type: EpisodeEventChooseSource, Episode: &episode}
+		}
+	case kb.ActionCopyDiagnostics:
+		if err := terminal.CopyToClipboard(m.diagnostics(time.Now())); err != nil {
+			m.notice = "Couldn't copy the diagnostics: " + err.Error()
+		} else {
+			m.notice = "Copied the diagnostics to the clipboard"
+		}
+		return m, Handled("playback_error:copy_diagnostics")
+	}
+	return m, nil
+}
+
+// diagnostics describes the failure for a bug report: what was played, how, and the AllAnime calls made for it
+func (m *PlaybackErrorModel) diagnostics(now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s on %s/%s\n", version.GetVersionInfo(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "Time: %s\n", now.Format(time.RFC3339))
+	fmt.Fprintf(&b, "Player: %s (%s)\n", m.config.Player.Type, m.config.Player.Command)
+	if m.config.Player.CastDevice != "" {
+		fmt.Fprintf(&b, "Casting to: %s\n", m.config.Player.CastDevice)
+	}
+	if m.config.Syncplay.Enabled {
+		b.WriteString("Playing through Syncplay\n")
+	}
+	fmt.Fprintf(&b, "Anime: %s (AniList %d, AllAnime %s)\n", m.episode.PreferredTitle, m.episode.AniListID,
+		m.episode.AllAnimeID)
+	fmt.Fprintf(&b, "Episode: %d (AllAnime episode %s, %s)\n", m.episode.OverallEpisodeNumber,
+		m.episode.AllAnimeEpisodeNumber, m.episode.TranslationType)
+	fmt.Fprintf(&b, "Error: %v\n", m.err)
+
+	b.WriteString("\nRecent AllAnime calls:\n")
+	count := 0
+	for _, call := range diagnostics.RecentAPICalls() {
+		if call.API != diagnostics.APIAllAnime {
+			continue
+		}
+		fmt.Fprintf(&b, "  %s %s %s %s", call.StartedAt.Format("15:04:05"), call.Operation, call.Status(),
+			call.Duration.Round(time.Millisecond))
+		if call.Error != nil {
+			fmt.Fprintf(&b, " (%v)", call.Error)
+		}
+		b.WriteString("\n")
+		if count++; count == diagnosticsAPICalls {
+			break
+		}
+	}
+	if count == 0 {
+		b.WriteString("  None\n")
+	}
+	return b.String()
+}
+
+// View renders the error and what can be done about it
+func (m *PlaybackErrorModel) View() string {
+	header := styles.Header(m.width, "Playback Failed")
+
+	keyBindings := []components.KeyBinding{
+		{kb.DisplayKey(kb.ActionRetryPlayback, kb.ContextPlaybackError), "Retry"},
+		{kb.DisplayKey(kb.ActionChooseSource, kb.ContextPlaybackError), "Choose another source"},
+		{kb.DisplayKey(kb.ActionCopyDiagnostics, kb.ContextPlaybackError), "Copy diagnostics"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextPlaybackError), "Return"},
+	}
+	footer := components.KeyBindingsBar(m.width, keyBindings)
+
+	titleStyle := lipgloss.NewStyle().Bold(true)
+	errorStyle := lipgloss.NewStyle().Foreground(styles.ColorError).Width(m.width - 8)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.ColorFaint).Width(m.width - 8)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Couldn't play episode %d of %s",
+		m.episode.OverallEpisodeNumber, m.episode.PreferredTitle)))
+	b.WriteString("\n\n")
+	b.WriteString(errorStyle.Render(fmt.Sprint(m.err)))
+	b.WriteString("\n\n")
+	b.WriteString(hintStyle.Render("Sources often fail for a while and come back, so retrying may work.  Choosing " +
+		"another source lists what each one plays and which couldn't be fetched."))
+	if m.notice != "" {
+		b.WriteString("\n\n")
+		b.WriteString(m.notice)
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		header,
+		"",
+		styles.ContentBox(m.width-2, b.String(), 1),
+		"",
+		footer,
+	)
+}
+
+// Resize updates the dimensions of the model
+func (m *PlaybackErrorModel) Resize(width, height int) {
+	m.width = width
+	m.height = height
+}
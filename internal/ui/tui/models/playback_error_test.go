@@ -0,0 +1,59 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/diagnostics"
+	"github.com/PizzaHomicide/hisame/internal/player"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPlaybackErrorModel() *PlaybackErrorModel {
+	cfg := &config.Config{Player: config.PlayerConfig{Type: "mpv", Command: "mpv"}}
+	episode := player.AllAnimeEpisodeInfo{
+		AllAnimeID:            "abc123",
+		OverallEpisodeNumber:  4,
+		AllAnimeEpisodeNumber: "4",
+		PreferredTitle:        "Frieren",
+		AniListID:             154587,
+		TranslationType:       "sub",
+	}
+	return NewPlaybackErrorModel(cfg, episode, nil, errors.New("timeout waiting for playback to start"))
+}
+
+func TestPlaybackErrorDiagnostics(t *testing.T) {
+	m := newTestPlaybackErrorModel()
+	diagnostics.TrackAPICall(diagnostics.APIAllAnime, "episode", time.Now(), errors.New("502 Bad Gateway"))
+	diagnostics.TrackAPICall(diagnostics.APIAniList, "MediaListCollection", time.Now(), nil)
+
+	text := m.diagnostics(time.Now())
+	assert.Contains(t, text, "Player: mpv (mpv)")
+	assert.Contains(t, text, "Anime: Frieren (AniList 154587, AllAnime abc123)")
+	assert.Contains(t, text, "Episode: 4 (AllAnime episode 4, sub)")
+	assert.Contains(t, text, "Error: timeout waiting for playback to start")
+	assert.Contains(t, text, "episode error")
+	assert.Contains(t, text, "502 Bad Gateway")
+	assert.NotContains(t, text, "MediaListCollection", "only AllAnime calls are relevant to playback")
+}
+
+func TestPlaybackErrorActions(t *testing.T) {
+	m := newTestPlaybackErrorModel()
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	require.NotNil(t, cmd)
+	retry, ok := cmd().(RetryPlaybackMsg)
+	require.True(t, ok)
+	assert.Equal(t, 4, retry.Episode.OverallEpisodeNumber)
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	require.NotNil(t, cmd)
+	choose, ok := cmd().(EpisodeMsg)
+	require.True(t, ok)
+	assert.Equal(t, EpisodeEventChooseSource, choose.Type)
+	assert.Equal(t, 4, choose.Episode.OverallEpisodeNumber)
+}
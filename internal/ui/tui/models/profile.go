@@ -0,0 +1,103 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ProfileModel shows the AniList account Hisame is logged in as
+type ProfileModel struct {
+	width, height int
+	user          domain.User
+}
+
+// NewProfileModel creates a new profile model for the given user
+func NewProfileModel(user domain.User) *ProfileModel {
+	return &ProfileModel{
+		user: user,
+	}
+}
+
+func (m *ProfileModel) ViewType() View {
+	return ViewProfile
+}
+
+// Init initializes the model
+func (m *ProfileModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages.  The profile is read only, so there is nothing to handle beyond the global bindings.
+func (m *ProfileModel) Update(msg tea.Msg) (Model, tea.Cmd) {
+	return m, nil
+}
+
+// View renders the profile screen
+func (m *ProfileModel) View() string {
+	header := styles.Header(m.width, "Profile")
+
+	keyBindings := []components.KeyBinding{
+		{kb.DisplayKey(kb.ActionToggleHelp, kb.ContextGlobal), "Help"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextGlobal), "Return"},
+	}
+	footer := components.KeyBindingsBar(m.width, keyBindings)
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		header,
+		"",
+		styles.ContentBox(m.width-2, m.renderProfile(), 1),
+		"",
+		footer,
+	)
+}
+
+// renderProfile renders the account details and list totals
+func (m *ProfileModel) renderProfile() string {
+	sectionTitleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorAccent)
+	fieldNameStyle := lipgloss.NewStyle().Bold(true)
+
+	field := func(b *strings.Builder, name, value string) {
+		b.WriteString(fieldNameStyle.Render(name + ": "))
+		b.WriteString(value)
+		b.WriteString("\n")
+	}
+	orNone := func(s string) string {
+		if s == "" {
+			return "None"
+		}
+		return s
+	}
+
+	var b strings.Builder
+	b.WriteString(sectionTitleStyle.Render("Account"))
+	b.WriteString("\n\n")
+	field(&b, "Name", m.user.Name)
+	field(&b, "User ID", fmt.Sprintf("%d", m.user.ID))
+	field(&b, "Profile", styles.Url.Render(orNone(m.user.SiteURL)))
+	field(&b, "Avatar", orNone(m.user.Avatar))
+
+	stats := m.user.Statistics
+	b.WriteString("\n")
+	b.WriteString(sectionTitleStyle.Render("Totals"))
+	b.WriteString("\n\n")
+	field(&b, "Anime", fmt.Sprintf("%d", stats.AnimeCount))
+	field(&b, "Episodes watched", fmt.Sprintf("%d", stats.EpisodesWatched))
+	field(&b, "Manga", fmt.Sprintf("%d", stats.MangaCount))
+	field(&b, "Chapters read", fmt.Sprintf("%d", stats.ChaptersRead))
+
+	return b.String()
+}
+
+// Resize updates the dimensions of the model
+func (m *ProfileModel) Resize(width, height int) {
+	m.width = width
+	m.height = height
+}
@@ -0,0 +1,256 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/service"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/util"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lithammer/fuzzysearch/fuzzy"
+	"github.com/mattn/go-runewidth"
+)
+
+// quickPlayMatch is a candidate anime along with how well it matched the query.  Lower is better for both fields.
+type quickPlayMatch struct {
+	anime    *domain.Anime
+	tier     int // 0 for a prefix match, 1 for a substring match, 2 for a fuzzy match
+	distance int // Fuzzy match distance of the best matching title
+}
+
+// QuickPlayModel is a launcher that fuzzy matches a title across the whole list and plays the next episode of the
+// selection, so the most common action only needs a few keystrokes from anywhere in the app
+type QuickPlayModel struct {
+	width, height int
+	animeService  *service.AnimeService
+	input         textinput.Model
+	results       []*domain.Anime
+	cursor        int
+}
+
+// NewQuickPlayModel creates a new quick play launcher
+func NewQuickPlayModel(animeService *service.AnimeService) *QuickPlayModel {
+	ti := textinput.New()
+	ti.Placeholder = "Type a title to play..."
+	ti.Width = 50
+	ti.Focus()
+
+	m := &QuickPlayModel{
+		animeService: animeService,
+		input:        ti,
+	}
+	m.updateResults()
+	return m
+}
+
+func (m *QuickPlayModel) ViewType() View {
+	return ViewQuickPlay
+}
+
+// Init initializes the model
+func (m *QuickPlayModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles messages
+func (m *QuickPlayModel) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+
+	switch kb.GetActionByKey(keyMsg, kb.ContextQuickPlay) {
+	case kb.ActionMoveUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, Handled("cursor_move:up")
+	case kb.ActionMoveDown:
+		if m.cursor < len(m.results)-1 {
+			m.cursor++
+		}
+		return m, Handled("cursor_move:down")
+	case kb.ActionPlayNextEpisode:
+		if m.cursor >= len(m.results) {
+			return m, Handled("quick_play:none_selected")
+		}
+		animeID := m.results[m.cursor].ID
+		return m, func() tea.Msg {
+			return QuickPlayMsg{AnimeID: animeID}
+		}
+	case kb.ActionBack:
+		// Let the app pop the launcher
+		return m, nil
+	}
+
+	previous := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(keyMsg)
+	if m.input.Value() != previous {
+		m.updateResults()
+	}
+	if cmd == nil {
+		cmd = Handled("quick_play:input")
+	}
+	return m, cmd
+}
+
+// updateResults re-ranks the list against the current query
+func (m *QuickPlayModel) updateResults() {
+	var candidates []*domain.Anime
+	for _, anime := range m.animeService.GetAnimeList() {
+		if anime.UserData != nil && !m.animeService.IsHidden(anime.ID) {
+			candidates = append(candidates, anime)
+		}
+	}
+	m.results = rankQuickPlay(m.input.Value(), candidates)
+	m.cursor = 0
+}
+
+// rankQuickPlay returns the anime matching the query, best first.  Every title and synonym is considered, and
+// ties are broken by putting anime being watched first and then the most recently updated.  An empty query
+// returns the list in that tie-break order, so the show being watched right now is a single key press away.
+func rankQuickPlay(query string, list []*domain.Anime) []*domain.Anime {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	var matches []quickPlayMatch
+	for _, anime := range list {
+		if query == "" {
+			matches = append(matches, quickPlayMatch{anime: anime})
+			continue
+		}
+		if match, ok := matchQuickPlay(query, anime); ok {
+			matches = append(matches, match)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.tier != b.tier {
+			return a.tier < b.tier
+		}
+		if a.distance != b.distance {
+			return a.distance < b.distance
+		}
+		if aWatching, bWatching := isWatching(a.anime), isWatching(b.anime); aWatching != bWatching {
+			return aWatching
+		}
+		return a.anime.UserData.UpdatedAt > b.anime.UserData.UpdatedAt
+	})
+
+	result := make([]*domain.Anime, 0, len(matches))
+	for _, match := range matches {
+		result = append(result, match.anime)
+	}
+	return result
+}
+
+// matchQuickPlay finds the best match for the query across all of an anime's titles
+func matchQuickPlay(query string, anime *domain.Anime) (quickPlayMatch, bool) {
+	titles := append([]string{anime.Title.Preferred, anime.Title.English, anime.Title.Romaji}, anime.Synonyms...)
+
+	best := quickPlayMatch{anime: anime, tier: 3}
+	for _, title := range titles {
+		lower := strings.ToLower(title)
+		if lower == "" {
+			continue
+		}
+
+		candidate := quickPlayMatch{anime: anime}
+		switch {
+		case strings.HasPrefix(lower, query):
+			candidate.tier = 0
+		case strings.Contains(lower, query):
+			candidate.tier = 1
+		default:
+			candidate.tier = 2
+			candidate.distance = fuzzy.RankMatch(query, lower)
+			if candidate.distance < 0 {
+				continue
+			}
+		}
+
+		if candidate.tier < best.tier || (candidate.tier == best.tier && candidate.distance < best.distance) {
+			best = candidate
+		}
+	}
+	return best, best.tier < 3
+}
+
+func isWatching(anime *domain.Anime) bool {
+	return anime.UserData.Status == domain.StatusCurrent || anime.UserData.Status == domain.StatusRepeating
+}
+
+// View renders the launcher
+func (m *QuickPlayModel) View() string {
+	header := styles.Header(m.width, "Quick Play")
+	prompt := styles.Title.Render("Play: ") + m.input.View()
+
+	keyBindings := []components.KeyBinding{
+		{kb.DisplayKeys(kb.ContextQuickPlay, kb.ActionMoveUp, kb.ActionMoveDown), "Navigate"},
+		{kb.DisplayKey(kb.ActionPlayNextEpisode, kb.ContextQuickPlay), "Play next episode"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextQuickPlay), "Return"},
+	}
+	footer := components.KeyBindingsBar(m.width, keyBindings)
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s", header, prompt, m.renderResults(), footer)
+}
+
+// renderResults renders the ranked matches
+func (m *QuickPlayModel) renderResults() string {
+	if len(m.results) == 0 {
+		return styles.CenteredText(m.width, "No matching anime in your list")
+	}
+
+	visibleCount := min(len(m.results), max(1, m.height-12))
+	startIdx := 0
+	if m.cursor >= visibleCount {
+		startIdx = m.cursor - visibleCount + 1
+	}
+	endIdx := min(startIdx+visibleCount, len(m.results))
+
+	selectedStyle := styles.Selected.
+		Width(m.width-4).
+		Padding(0, 1)
+
+	normalStyle := lipgloss.NewStyle().
+		Width(m.width-4).
+		Padding(0, 1)
+
+	titleWidth := 50
+	var listContent string
+	for i := startIdx; i < endIdx; i++ {
+		anime := m.results[i]
+		title := util.TruncateString(anime.Title.Preferred, titleWidth)
+		title += strings.Repeat(" ", max(0, titleWidth-runewidth.StringWidth(title)))
+
+		episodes := "?"
+		if anime.Episodes > 0 {
+			episodes = fmt.Sprintf("%d", anime.Episodes)
+		}
+		itemText := fmt.Sprintf("%s  %-10s  %d/%s", title, anime.UserData.Status, anime.UserData.Progress, episodes)
+
+		if i == m.cursor {
+			listContent += selectedStyle.Render(itemText) + "\n"
+		} else {
+			listContent += normalStyle.Render(itemText) + "\n"
+		}
+	}
+
+	return styles.ContentBox(m.width-2, listContent, 1)
+}
+
+// Resize updates the dimensions of the model
+func (m *QuickPlayModel) Resize(width, height int) {
+	m.width = width
+	m.height = height
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func quickPlayAnime(id int, title string, status domain.MediaStatus, updatedAt int64, synonyms ...string) *domain.Anime {
+	return &domain.Anime{
+		ID:       id,
+		Title:    domain.AnimeTitle{Preferred: title},
+		Synonyms: synonyms,
+		UserData: &domain.UserAnimeData{Status: status, UpdatedAt: updatedAt},
+	}
+}
+
+func animeIDs(list []*domain.Anime) []int {
+	ids := make([]int, 0, len(list))
+	for _, anime := range list {
+		ids = append(ids, anime.ID)
+	}
+	return ids
+}
+
+func TestRankQuickPlay(t *testing.T) {
+	list := []*domain.Anime{
+		quickPlayAnime(1, "Sousou no Frieren", domain.StatusCompleted, 10, "Frieren: Beyond Journey's End"),
+		quickPlayAnime(2, "Frieren Recap", domain.StatusPlanning, 5),
+		quickPlayAnime(3, "Spy x Family", domain.StatusCurrent, 20),
+	}
+
+	// Prefix matches on any title beat substring matches, and non-matches are dropped
+	assert.Equal(t, []int{1, 2}, animeIDs(rankQuickPlay("frieren", list)))
+
+	// Fuzzy matches are allowed when nothing matches directly
+	assert.Equal(t, []int{3}, animeIDs(rankQuickPlay("spyfam", list)))
+}
+
+func TestRankQuickPlayEmptyQuery(t *testing.T) {
+	list := []*domain.Anime{
+		quickPlayAnime(1, "Old", domain.StatusCompleted, 100),
+		quickPlayAnime(2, "Watching", domain.StatusCurrent, 10),
+		quickPlayAnime(3, "Recent", domain.StatusCurrent, 50),
+	}
+
+	assert.Equal(t, []int{3, 2, 1}, animeIDs(rankQuickPlay("", list)))
+}
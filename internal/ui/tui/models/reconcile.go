@@ -0,0 +1,217 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/service"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/util"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// ReconcileModel shows changes made while AniList couldn't be reached, or to entries changed on AniList since the list
+// loaded, alongside the entry as AniList has it now, and lets the user settle each one rather than one side silently
+// overwriting the other
+type ReconcileModel struct {
+	width, height int
+	animeService  *service.AnimeService
+	conflicts     []service.Reconciliation
+	cursor        int
+	resolving     bool
+	status        string
+}
+
+// NewReconcileModel creates a reconcile view for the given conflicts
+func NewReconcileModel(animeService *service.AnimeService, conflicts []service.Reconciliation) *ReconcileModel {
+	return &ReconcileModel{
+		animeService: animeService,
+		conflicts:    conflicts,
+	}
+}
+
+func (m *ReconcileModel) ViewType() View {
+	return ViewReconcile
+}
+
+// Init initializes the model
+func (m *ReconcileModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m *ReconcileModel) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case OfflineChangeResolvedMsg:
+		m.resolving = false
+		if msg.Error != nil {
+			m.status = fmt.Sprintf("Failed to save %s: %v", msg.Title, msg.Error)
+			return m, nil
+		}
+		m.status = fmt.Sprintf("Reconciled %s", msg.Title)
+		m.removeConflict(msg.AnimeID)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch kb.GetActionByKey(msg, kb.ContextReconcile) {
+		case kb.ActionMoveUp:
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, Handled("cursor_move:up")
+		case kb.ActionMoveDown:
+			if m.cursor < len(m.conflicts)-1 {
+				m.cursor++
+			}
+			return m, Handled("cursor_move:down")
+		case kb.ActionMoveTop:
+			m.cursor = 0
+			return m, Handled("cursor_move:top")
+		case kb.ActionMoveBottom:
+			m.cursor = max(0, len(m.conflicts)-1)
+			return m, Handled("cursor_move:bottom")
+		case kb.ActionKeepLocal:
+			return m, m.resolve(service.ResolveLocal)
+		case kb.ActionKeepRemote:
+			return m, m.resolve(service.ResolveRemote)
+		case kb.ActionMergeEntry:
+			return m, m.resolve(service.ResolveMerge)
+		}
+	}
+
+	return m, nil
+}
+
+// resolve settles the selected conflict in the background
+func (m *ReconcileModel) resolve(resolution service.Resolution) tea.Cmd {
+	if m.resolving || m.cursor >= len(m.conflicts) {
+		return Handled("reconcile:unavailable")
+	}
+	m.resolving = true
+	m.status = "Saving..."
+
+	change := m.conflicts[m.cursor].Change
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		return OfflineChangeResolvedMsg{
+			AnimeID:    change.AnimeID,
+			Title:      change.Title,
+			Resolution: resolution,
+			Error:      m.animeService.ResolveOfflineChange(ctx, change.AnimeID, resolution),
+		}
+	}
+}
+
+// addConflict adds a conflict to the list, replacing any earlier conflict for the same entry
+func (m *ReconcileModel) addConflict(conflict service.Reconciliation) {
+	for i, existing := range m.conflicts {
+		if existing.Change.AnimeID == conflict.Change.AnimeID {
+			m.conflicts[i] = conflict
+			return
+		}
+	}
+	m.conflicts = append(m.conflicts, conflict)
+}
+
+// removeConflict drops a settled conflict, keeping the cursor in range
+func (m *ReconcileModel) removeConflict(animeID int) {
+	for i, conflict := range m.conflicts {
+		if conflict.Change.AnimeID == animeID {
+			m.conflicts = append(m.conflicts[:i], m.conflicts[i+1:]...)
+			break
+		}
+	}
+	if m.cursor >= len(m.conflicts) {
+		m.cursor = max(0, len(m.conflicts)-1)
+	}
+}
+
+// View renders the conflicts
+func (m *ReconcileModel) View() string {
+	header := styles.Header(m.width, "Reconcile Changes")
+
+	summary := fmt.Sprintf("%d entries were changed both in Hisame and on AniList", len(m.conflicts))
+	if m.status != "" {
+		summary += "  •  " + m.status
+	}
+
+	keyBindings := []components.KeyBinding{
+		{kb.DisplayKeys(kb.ContextReconcile, kb.ActionMoveUp, kb.ActionMoveDown), "Navigate"},
+		{kb.DisplayKey(kb.ActionKeepLocal, kb.ContextReconcile), "Keep Hisame's"},
+		{kb.DisplayKey(kb.ActionKeepRemote, kb.ContextReconcile), "Keep AniList's"},
+		{kb.DisplayKey(kb.ActionMergeEntry, kb.ContextReconcile), "Merge"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextReconcile), "Decide later"},
+	}
+	footer := components.KeyBindingsBar(m.width, keyBindings)
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s", header, styles.FilterStatus.Render(summary), m.renderConflicts(), footer)
+}
+
+// renderConflicts renders each conflict with both sides and the merged result next to each other
+func (m *ReconcileModel) renderConflicts() string {
+	if len(m.conflicts) == 0 {
+		return styles.CenteredText(m.width, "Everything has been reconciled")
+	}
+
+	visibleCount := min(len(m.conflicts), max(1, m.height-13))
+	startIdx := 0
+	if m.cursor >= visibleCount {
+		startIdx = m.cursor - visibleCount + 1
+	}
+	endIdx := min(startIdx+visibleCount, len(m.conflicts))
+
+	selectedStyle := styles.Selected.
+		Width(m.width-4).
+		Padding(0, 1)
+
+	normalStyle := lipgloss.NewStyle().
+		Width(m.width-4).
+		Padding(0, 1)
+
+	titleWidth := 40
+	columnFormat := "%s  %-18s  %-18s  %-18s"
+	listContent := normalStyle.Bold(true).Render(fmt.Sprintf(columnFormat,
+		strings.Repeat(" ", titleWidth), "In Hisame", "On AniList", "Merged")) + "\n"
+	for i := startIdx; i < endIdx; i++ {
+		conflict := m.conflicts[i]
+		title := util.TruncateString(conflict.Change.Title, titleWidth)
+		title += strings.Repeat(" ", max(0, titleWidth-runewidth.StringWidth(title)))
+
+		merged := service.MergeEntryStates(conflict.Change.Local, conflict.Remote)
+		itemText := fmt.Sprintf(columnFormat, title,
+			m.formatState(conflict.Change.AnimeID, conflict.Change.Local),
+			m.formatState(conflict.Change.AnimeID, conflict.Remote),
+			m.formatState(conflict.Change.AnimeID, merged))
+
+		if i == m.cursor {
+			listContent += selectedStyle.Render(itemText) + "\n"
+		} else {
+			listContent += normalStyle.Render(itemText) + "\n"
+		}
+	}
+
+	return styles.ContentBox(m.width-2, listContent, 1)
+}
+
+// formatState describes one side of a conflict, e.g. "CURRENT 5/12"
+func (m *ReconcileModel) formatState(animeID int, state service.EntryState) string {
+	episodes := "?"
+	if anime := m.animeService.GetAnimeByID(animeID); anime != nil && anime.Episodes > 0 {
+		episodes = fmt.Sprintf("%d", anime.Episodes)
+	}
+	return fmt.Sprintf("%s %d/%s", state.Status, state.Progress, episodes)
+}
+
+// Resize updates the dimensions of the model
+func (m *ReconcileModel) Resize(width, height int) {
+	m.width = width
+	m.height = height
+}
@@ -0,0 +1,408 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/service"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/util"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// SeasonScoresModel shows everything completed in a season as a table with an editable score column, saving all the
+// changed scores together
+type SeasonScoresModel struct {
+	width, height  int
+	animeService   *service.AnimeService
+	scoreFormat    domain.ScoreFormat // The user's score format, which scores typed in are checked against
+	season         service.Season
+	entries        []*domain.Anime
+	scores         map[int]float64 // Changed scores by anime ID, kept across seasons until saved
+	cursor         int
+	editing        bool
+	input          textinput.Model
+	saving         bool
+	confirmDiscard bool // Whether esc was pressed once with unsaved scores, so the next press discards them
+	status         string
+}
+
+// NewSeasonScoresModel creates a new season scores model.  During the first fortnight of a season it opens on the
+// season just finished, as that is the one still being scored.
+func NewSeasonScoresModel(animeService *service.AnimeService, scoreFormat domain.ScoreFormat) *SeasonScoresModel {
+	ti := textinput.New()
+	ti.CharLimit = 5
+	ti.Width = 5
+	ti.Prompt = ""
+
+	m := &SeasonScoresModel{
+		animeService: animeService,
+		scoreFormat:  scoreFormat,
+		season:       service.SeasonOf(time.Now().AddDate(0, 0, -14)),
+		scores:       make(map[int]float64),
+		input:        ti,
+	}
+	m.reload()
+	return m
+}
+
+func (m *SeasonScoresModel) ViewType() View {
+	return ViewSeasonScores
+}
+
+// Init initializes the model
+func (m *SeasonScoresModel) Init() tea.Cmd {
+	return nil
+}
+
+// reload fetches what was completed in the season, keeping the cursor in range
+func (m *SeasonScoresModel) reload() {
+	m.entries = m.animeService.GetCompletedInSeason(m.season)
+	m.cursor = min(m.cursor, max(0, len(m.entries)-1))
+}
+
+// Update handles messages
+func (m *SeasonScoresModel) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case SeasonScoresSavedMsg:
+		m.saving = false
+		// Keep only the changes that didn't make it to AniList, so they can be saved again
+		for id, score := range m.scores {
+			if anime := m.animeService.GetAnimeByID(id); anime != nil && anime.UserData != nil && anime.UserData.Score == score {
+				delete(m.scores, id)
+			}
+		}
+		switch {
+		case msg.Error != nil:
+			m.status = fmt.Sprintf("Saving did not finish: %v", msg.Error)
+		case msg.Result.Failed > 0:
+			m.status = fmt.Sprintf("Saved %d scores, %d failed", msg.Result.Updated, msg.Result.Failed)
+		default:
+			m.status = fmt.Sprintf("Saved %d scores", msg.Result.Updated)
+		}
+		m.reload()
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.editing {
+			return m, m.handleEditKeyMsg(msg)
+		}
+		return m, m.handleKeyMsg(msg)
+	}
+
+	return m, nil
+}
+
+// handleKeyMsg handles keys while moving around the table
+func (m *SeasonScoresModel) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
+	action := kb.GetActionByKey(msg, kb.ContextSeasonScores)
+	if action != kb.ActionBack {
+		m.confirmDiscard = false
+	}
+
+	switch action {
+	case kb.ActionBack:
+		if len(m.scores) > 0 && !m.confirmDiscard {
+			m.confirmDiscard = true
+			m.status = fmt.Sprintf("%d scores are not saved.  Press %s to save them, or %s again to discard them",
+				len(m.scores), kb.DisplayKey(kb.ActionSaveScores, kb.ContextSeasonScores),
+				kb.DisplayKey(kb.ActionBack, kb.ContextSeasonScores))
+			return Handled("season_scores:confirm_discard")
+		}
+		// Let the app pop the view
+		return nil
+	case kb.ActionMoveUp:
+		m.moveCursor(-1)
+		return Handled("cursor_move:up")
+	case kb.ActionMoveDown:
+		m.moveCursor(1)
+		return Handled("cursor_move:down")
+	case kb.ActionPageUp:
+		m.moveCursor(-m.visibleCount())
+		return Handled("cursor_move:pgup")
+	case kb.ActionPageDown:
+		m.moveCursor(m.visibleCount())
+		return Handled("cursor_move:pgdown")
+	case kb.ActionMoveTop:
+		m.cursor = 0
+		return Handled("cursor_move:top")
+	case kb.ActionMoveBottom:
+		m.cursor = max(0, len(m.entries)-1)
+		return Handled("cursor_move:bottom")
+	case kb.ActionEditScore:
+		if score, ok := m.score(); ok && score > 0 {
+			return m.startEditing(strconv.FormatFloat(score, 'f', -1, 64))
+		}
+		return m.startEditing("")
+	case kb.ActionClearScore:
+		if _, ok := m.score(); ok {
+			m.setScore(0)
+		}
+		return Handled("season_scores:clear")
+	case kb.ActionSaveScores:
+		return m.save()
+	case kb.ActionPreviousSeason:
+		m.season = m.season.Previous()
+		m.cursor = 0
+		m.reload()
+		return Handled("season_scores:previous_season")
+	case kb.ActionNextSeason:
+		m.season = m.season.Next()
+		m.cursor = 0
+		m.reload()
+		return Handled("season_scores:next_season")
+	}
+
+	// Typing a number starts editing the score, as in a spreadsheet
+	if isScoreKey(msg) {
+		return m.startEditing(msg.String())
+	}
+	return nil
+}
+
+// handleEditKeyMsg handles keys while editing a score.  Only numbers are typed into the cell.
+func (m *SeasonScoresModel) handleEditKeyMsg(msg tea.KeyMsg) tea.Cmd {
+	switch kb.GetActionByKey(msg, kb.ContextSeasonScores) {
+	case kb.ActionBack:
+		m.stopEditing()
+		return Handled("season_scores:cancel_edit")
+	case kb.ActionEditScore:
+		m.commitEdit()
+		return Handled("season_scores:commit")
+	case kb.ActionMoveUp:
+		if m.commitEdit() {
+			m.moveCursor(-1)
+		}
+		return Handled("season_scores:commit_up")
+	case kb.ActionMoveDown:
+		if m.commitEdit() {
+			m.moveCursor(1)
+		}
+		return Handled("season_scores:commit_down")
+	}
+
+	if msg.Type == tea.KeyRunes && !isScoreKey(msg) {
+		return Handled("season_scores:ignored_key")
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if cmd == nil {
+		cmd = Handled("season_scores:input")
+	}
+	return cmd
+}
+
+// isScoreKey reports whether the key is one typed into a score
+func isScoreKey(msg tea.KeyMsg) bool {
+	if msg.Type != tea.KeyRunes {
+		return false
+	}
+	for _, r := range msg.Runes {
+		if (r < '0' || r > '9') && r != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// moveCursor moves the cursor by delta rows, staying in range
+func (m *SeasonScoresModel) moveCursor(delta int) {
+	m.cursor = max(0, min(m.cursor+delta, len(m.entries)-1))
+}
+
+// startEditing starts editing the selected score with the given text in the cell
+func (m *SeasonScoresModel) startEditing(value string) tea.Cmd {
+	if m.cursor >= len(m.entries) {
+		return Handled("season_scores:none_selected")
+	}
+	m.editing = true
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	return m.input.Focus()
+}
+
+// stopEditing leaves the cell without changing the score
+func (m *SeasonScoresModel) stopEditing() {
+	m.editing = false
+	m.input.Blur()
+	m.input.SetValue("")
+}
+
+// commitEdit keeps the score typed into the cell.  Returns false, staying in the cell, if it isn't a valid score.
+func (m *SeasonScoresModel) commitEdit() bool {
+	text := strings.TrimSpace(m.input.Value())
+	score := 0.0
+	if text != "" {
+		var err error
+		score, err = strconv.ParseFloat(text, 64)
+		if err != nil || !m.scoreFormat.ValidScore(score) {
+			m.status = fmt.Sprintf("%q is not a score.  Scores are %s", text, scoreRange(m.scoreFormat))
+			return false
+		}
+	}
+	m.setScore(score)
+	m.stopEditing()
+	m.status = ""
+	return true
+}
+
+// scoreRange describes the scores the format accepts, for telling the user why a score was turned away
+func scoreRange(format domain.ScoreFormat) string {
+	if format.Decimal() {
+		return fmt.Sprintf("from 0 to %g, with up to one decimal place", format.MaxScore())
+	}
+	return fmt.Sprintf("whole numbers from 0 to %g", format.MaxScore())
+}
+
+// score returns the selected entry's score, including any unsaved change.  ok is false if nothing is selected.
+func (m *SeasonScoresModel) score() (score float64, ok bool) {
+	if m.cursor >= len(m.entries) {
+		return 0, false
+	}
+	anime := m.entries[m.cursor]
+	if changed, ok := m.scores[anime.ID]; ok {
+		return changed, true
+	}
+	return anime.UserData.Score, true
+}
+
+// setScore changes the selected entry's score, forgetting the change if it puts the score back as it was
+func (m *SeasonScoresModel) setScore(score float64) {
+	anime := m.entries[m.cursor]
+	if score == anime.UserData.Score {
+		delete(m.scores, anime.ID)
+		return
+	}
+	m.scores[anime.ID] = score
+}
+
+// save creates a command that saves every changed score together
+func (m *SeasonScoresModel) save() tea.Cmd {
+	if m.saving || len(m.scores) == 0 {
+		return Handled("season_scores:nothing_to_save")
+	}
+	changes := make([]service.ScoreChange, 0, len(m.scores))
+	for id, score := range m.scores {
+		changes = append(changes, service.ScoreChange{AnimeID: id, Score: score})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].AnimeID < changes[j].AnimeID
+	})
+	m.saving = true
+	m.status = fmt.Sprintf("Saving %d scores...", len(changes))
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		result, err := m.animeService.SaveScores(ctx, changes)
+		return SeasonScoresSavedMsg{Result: result, Error: err}
+	}
+}
+
+// visibleCount is how many rows fit on screen
+func (m *SeasonScoresModel) visibleCount() int {
+	return max(1, m.height-14)
+}
+
+// View renders the table of the season's completions
+func (m *SeasonScoresModel) View() string {
+	header := styles.Header(m.width, "Season Scores - "+m.season.String())
+
+	summary := fmt.Sprintf("%d completed", len(m.entries))
+	if len(m.scores) > 0 {
+		summary += fmt.Sprintf(", %d unsaved scores", len(m.scores))
+	}
+	if m.status != "" {
+		summary += "  •  " + m.status
+	}
+
+	keyBindings := []components.KeyBinding{
+		{kb.DisplayKeys(kb.ContextSeasonScores, kb.ActionMoveUp, kb.ActionMoveDown), "Navigate"},
+		{kb.DisplayKey(kb.ActionEditScore, kb.ContextSeasonScores), "Edit"},
+		{kb.DisplayKey(kb.ActionClearScore, kb.ContextSeasonScores), "Clear"},
+		{kb.DisplayKey(kb.ActionSaveScores, kb.ContextSeasonScores), "Save all"},
+		{kb.DisplayKeys(kb.ContextSeasonScores, kb.ActionPreviousSeason, kb.ActionNextSeason), "Season"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextSeasonScores), "Return"},
+	}
+	footer := components.KeyBindingsBar(m.width, keyBindings)
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s", header, styles.FilterStatus.Render(summary), m.renderTable(), footer)
+}
+
+// renderTable renders the scrollable table of entries with their scores
+func (m *SeasonScoresModel) renderTable() string {
+	if len(m.entries) == 0 {
+		return styles.CenteredText(m.width, "Nothing with a completion date in "+m.season.String())
+	}
+
+	visibleCount := min(len(m.entries), m.visibleCount())
+	startIdx := 0
+	if m.cursor >= visibleCount {
+		startIdx = m.cursor - visibleCount + 1
+	}
+	endIdx := min(startIdx+visibleCount, len(m.entries))
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Width(m.width-4).
+		Padding(0, 1)
+
+	selectedStyle := styles.Selected.
+		Width(m.width-4).
+		Padding(0, 1)
+
+	normalStyle := lipgloss.NewStyle().
+		Width(m.width-4).
+		Padding(0, 1)
+
+	titleWidth := max(10, min(60, m.width-36))
+	listContent := headerStyle.Render(fmt.Sprintf("%-*s  %-10s  %s", titleWidth, "Title", "Completed", "Score")) + "\n"
+	listContent += strings.Repeat("─", max(0, m.width-6)) + "\n"
+	for i := startIdx; i < endIdx; i++ {
+		anime := m.entries[i]
+		title := util.TruncateString(anime.Title.Preferred, titleWidth)
+		title += strings.Repeat(" ", max(0, titleWidth-runewidth.StringWidth(title)))
+
+		cell := "-"
+		score := anime.UserData.Score
+		changed, isChanged := m.scores[anime.ID]
+		if isChanged {
+			score = changed
+		}
+		if score > 0 {
+			cell = strconv.FormatFloat(score, 'f', -1, 64)
+		}
+		if isChanged {
+			cell += " *"
+		}
+		if i == m.cursor && m.editing {
+			cell = "[" + m.input.View() + "]"
+		}
+		itemText := fmt.Sprintf("%s  %-10s  %s", title, anime.UserData.EndDate, cell)
+
+		if i == m.cursor {
+			listContent += selectedStyle.Render(itemText) + "\n"
+		} else {
+			listContent += normalStyle.Render(itemText) + "\n"
+		}
+	}
+
+	return styles.ContentBox(m.width-2, listContent, 1)
+}
+
+// Resize updates the dimensions of the model
+func (m *SeasonScoresModel) Resize(width, height int) {
+	m.width = width
+	m.height = height
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeasonScoresCommitEditChecksScoreFormat(t *testing.T) {
+	tests := []struct {
+		format domain.ScoreFormat
+		text   string
+		valid  bool
+	}{
+		{domain.ScoreFormat100, "85", true},
+		{domain.ScoreFormat100, "85.5", false},
+		{domain.ScoreFormat10Decimal, "8.5", true},
+		{domain.ScoreFormat10Decimal, "8.25", false},
+		{domain.ScoreFormat10Decimal, "85", false},
+		{domain.ScoreFormat10, "8", true},
+		{domain.ScoreFormat10, "8.5", false},
+		{domain.ScoreFormat5, "5", true},
+		{domain.ScoreFormat5, "6", false},
+		{domain.ScoreFormat3, "3", true},
+		{domain.ScoreFormat3, "4", false},
+		{domain.ScoreFormat3, "-1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format)+" "+tt.text, func(t *testing.T) {
+			m := &SeasonScoresModel{
+				scoreFormat: tt.format,
+				entries:     []*domain.Anime{{ID: 1, UserData: &domain.UserAnimeData{}}},
+				scores:      make(map[int]float64),
+				editing:     true,
+			}
+			m.input.SetValue(tt.text)
+
+			assert.Equal(t, tt.valid, m.commitEdit())
+			if tt.valid {
+				assert.Contains(t, m.scores, 1)
+			} else {
+				assert.Contains(t, m.status, "is not a score")
+				assert.True(t, m.editing, "an invalid score should stay in the cell")
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/repository/anilist"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SessionInfo describes the login Hisame is using, as shown on the session screen
+type SessionInfo struct {
+	User             domain.User
+	LocalOnly        bool
+	TokenSource      string                  // Where the token was loaded from, e.g. the config file
+	IssuedAt         time.Time               // When the token was issued.  Zero if it doesn't say
+	ExpiresAt        time.Time               // When the token expires.  Zero if it doesn't say
+	RateLimit        anilist.RateLimitStatus // AniList's rate limit as of the last response
+	RateLimitedUntil time.Time               // When requests held back by the rate limit are retried.  Zero if not limited
+}
+
+// SessionModel shows the login Hisame is using and AniList's rate limit, with options to log out or switch account.
+// It is there to help work out why AniList is rejecting requests.
+type SessionModel struct {
+	width, height int
+	info          SessionInfo
+}
+
+// NewSessionModel creates a new session model showing the given session
+func NewSessionModel(info SessionInfo) *SessionModel {
+	return &SessionModel{
+		info: info,
+	}
+}
+
+func (m *SessionModel) ViewType() View {
+	return ViewSession
+}
+
+// Init initializes the model
+func (m *SessionModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m *SessionModel) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.info.LocalOnly {
+		return m, nil
+	}
+
+	switch kb.GetActionByKey(keyMsg, kb.ContextSession) {
+	case kb.ActionSessionLogout:
+		return m, func() tea.Msg {
+			return LogoutMsg{}
+		}
+	case kb.ActionSwitchAccount:
+		return m, func() tea.Msg {
+			return LogoutMsg{SwitchAccount: true}
+		}
+	}
+	return m, nil
+}
+
+// View renders the session screen
+func (m *SessionModel) View() string {
+	header := styles.Header(m.width, "Session")
+
+	var keyBindings []components.KeyBinding
+	if !m.info.LocalOnly {
+		keyBindings = append(keyBindings,
+			components.KeyBinding{kb.DisplayKey(kb.ActionSessionLogout, kb.ContextSession), "Log out"},
+			components.KeyBinding{kb.DisplayKey(kb.ActionSwitchAccount, kb.ContextSession), "Switch account"},
+		)
+	}
+	keyBindings = append(keyBindings,
+		components.KeyBinding{kb.DisplayKey(kb.ActionToggleHelp, kb.ContextSession), "Help"},
+		components.KeyBinding{kb.DisplayKey(kb.ActionBack, kb.ContextSession), "Return"},
+	)
+	footer := components.KeyBindingsBar(m.width, keyBindings)
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		header,
+		"",
+		styles.ContentBox(m.width-2, m.renderSession(time.Now()), 1),
+		"",
+		footer,
+	)
+}
+
+// renderSession renders the login and rate limit details
+func (m *SessionModel) renderSession(now time.Time) string {
+	sectionTitleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorAccent)
+	fieldNameStyle := lipgloss.NewStyle().Bold(true)
+	warningStyle := lipgloss.NewStyle().Foreground(styles.ColorError)
+
+	field := func(b *strings.Builder, name, value string) {
+		b.WriteString(fieldNameStyle.Render(name + ": "))
+		b.WriteString(value)
+		b.WriteString("\n")
+	}
+
+	var b strings.Builder
+	b.WriteString(sectionTitleStyle.Render("Login"))
+	b.WriteString("\n\n")
+	if m.info.LocalOnly {
+		field(&b, "Mode", "Local-only, without an AniList account")
+	} else {
+		field(&b, "User", fmt.Sprintf("%s (ID %d)", m.info.User.Name, m.info.User.ID))
+		field(&b, "Token source", m.info.TokenSource)
+		if m.info.IssuedAt.IsZero() {
+			field(&b, "Token age", "Unknown")
+		} else {
+			field(&b, "Token age", fmt.Sprintf("%s (issued %s)",
+				formatAge(now.Sub(m.info.IssuedAt)), m.info.IssuedAt.Format("2006-01-02")))
+		}
+		switch {
+		case m.info.ExpiresAt.IsZero():
+			field(&b, "Token expires", "Unknown")
+		case !now.Before(m.info.ExpiresAt):
+			field(&b, "Token expires", warningStyle.Render("Expired "+m.info.ExpiresAt.Format("2006-01-02")))
+		default:
+			field(&b, "Token expires", fmt.Sprintf("%s (in %s)",
+				m.info.ExpiresAt.Format("2006-01-02"), formatAge(m.info.ExpiresAt.Sub(now))))
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(sectionTitleStyle.Render("AniList rate limit"))
+	b.WriteString("\n\n")
+	rateLimit := m.info.RateLimit
+	switch {
+	case !rateLimit.Known():
+		field(&b, "Remaining", "Unknown until the next request")
+	case rateLimit.Limit > 0:
+		field(&b, "Remaining", fmt.Sprintf("%d of %d requests", rateLimit.Remaining, rateLimit.Limit))
+	default:
+		field(&b, "Remaining", fmt.Sprintf("%d requests", rateLimit.Remaining))
+	}
+	if rateLimit.Known() && now.Before(rateLimit.ResetAt) {
+		field(&b, "Window resets", fmt.Sprintf("in %s", formatWait(rateLimit.ResetAt.Sub(now))))
+	}
+	if now.Before(m.info.RateLimitedUntil) {
+		field(&b, "Status", warningStyle.Render(fmt.Sprintf("Requests held back for %s",
+			formatWait(m.info.RateLimitedUntil.Sub(now)))))
+	} else {
+		field(&b, "Status", "OK")
+	}
+
+	return b.String()
+}
+
+// formatWait formats the time left to wait, in seconds when it is under a minute, e.g. "42s".  Part of a second counts
+// as a whole one, so a wait that hasn't ended never shows as "0s".
+func formatWait(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int((d+time.Second-1)/time.Second))
+	}
+	return formatAge(d)
+}
+
+// Resize updates the dimensions of the model
+func (m *SessionModel) Resize(width, height int) {
+	m.width = width
+	m.height = height
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatWait(t *testing.T) {
+	assert.Equal(t, "42s", formatWait(42*time.Second))
+	assert.Equal(t, "1s", formatWait(300*time.Millisecond))
+	assert.Equal(t, "59s", formatWait(58*time.Second+time.Millisecond))
+	assert.Equal(t, "2m", formatWait(2*time.Minute+10*time.Second))
+}
@@ -0,0 +1,244 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/service"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/util"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// snoozeTimeFormat is how the end of a snooze is shown
+const snoozeTimeFormat = "Mon 2 Jan 15:04"
+
+// SnoozesModel lists what the user has snoozed in the airing agenda and lets them remove snoozes early or snooze the
+// whole agenda
+type SnoozesModel struct {
+	width, height int
+	animeService  *service.AnimeService
+	allUntil      time.Time
+	entries       []service.Snooze
+	cursor        int
+	status        string
+}
+
+// NewSnoozesModel creates a new agenda snoozes model
+func NewSnoozesModel(animeService *service.AnimeService) *SnoozesModel {
+	m := &SnoozesModel{animeService: animeService}
+	m.reload()
+	return m
+}
+
+func (m *SnoozesModel) ViewType() View {
+	return ViewSnoozes
+}
+
+// Init initializes the model
+func (m *SnoozesModel) Init() tea.Cmd {
+	return nil
+}
+
+// reload fetches the current snoozes, keeping the cursor in range
+func (m *SnoozesModel) reload() {
+	m.allUntil = m.animeService.AgendaSnoozedUntil()
+	m.entries = m.animeService.GetSnoozes()
+	m.cursor = min(m.cursor, max(0, m.rowCount()-1))
+}
+
+// rowCount is the number of selectable rows, the whole agenda's snooze first if there is one
+func (m *SnoozesModel) rowCount() int {
+	if m.allUntil.IsZero() {
+		return len(m.entries)
+	}
+	return len(m.entries) + 1
+}
+
+// Update handles messages
+func (m *SnoozesModel) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case SnoozeMsg:
+		until, err := m.animeService.SnoozeAgenda(msg.Period)
+		if err != nil {
+			log.Error("Failed to snooze the airing agenda", "error", err)
+			m.status = fmt.Sprintf("Failed to snooze the agenda: %v", err)
+			return m, nil
+		}
+		m.status = "Agenda snoozed until " + until.Local().Format(snoozeTimeFormat)
+		m.reload()
+		return m, nil
+
+	case tea.KeyMsg:
+		switch kb.GetActionByKey(msg, kb.ContextSnoozes) {
+		case kb.ActionMoveUp:
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, Handled("cursor_move:up")
+		case kb.ActionMoveDown:
+			if m.cursor < m.rowCount()-1 {
+				m.cursor++
+			}
+			return m, Handled("cursor_move:down")
+		case kb.ActionMoveTop:
+			m.cursor = 0
+			return m, Handled("cursor_move:top")
+		case kb.ActionMoveBottom:
+			m.cursor = max(0, m.rowCount()-1)
+			return m, Handled("cursor_move:bottom")
+		case kb.ActionUnsnooze:
+			if m.cursor >= m.rowCount() {
+				return m, Handled("unsnooze:none_selected")
+			}
+			m.unsnoozeSelected()
+			return m, Handled("unsnooze")
+		case kb.ActionSnoozeAgenda:
+			return m, snoozeMenu(0, "")
+		}
+	}
+
+	return m, nil
+}
+
+// unsnoozeSelected removes the snooze under the cursor
+func (m *SnoozesModel) unsnoozeSelected() {
+	if !m.allUntil.IsZero() && m.cursor == 0 {
+		if err := m.animeService.UnsnoozeAgenda(); err != nil {
+			log.Error("Failed to unsnooze the airing agenda", "error", err)
+			m.status = fmt.Sprintf("Failed to unsnooze the agenda: %v", err)
+			return
+		}
+		m.status = "The agenda will be shown again"
+		m.reload()
+		return
+	}
+
+	entry := m.entries[m.cursor-(m.rowCount()-len(m.entries))]
+	if err := m.animeService.UnsnoozeAnime(entry.AnimeID); err != nil {
+		log.Error("Failed to unsnooze anime", "anime_id", entry.AnimeID, "error", err)
+		m.status = fmt.Sprintf("Failed to unsnooze %s: %v", entry.Title, err)
+		return
+	}
+	m.status = fmt.Sprintf("Unsnoozed %s", entry.Title)
+	m.reload()
+}
+
+// View renders the snoozes
+func (m *SnoozesModel) View() string {
+	header := styles.Header(m.width, "Agenda Snoozes")
+
+	summary := fmt.Sprintf("%d anime snoozed", len(m.entries))
+	if !m.allUntil.IsZero() {
+		summary += ", and the whole agenda"
+	}
+	if m.status != "" {
+		summary += "  •  " + m.status
+	}
+
+	keyBindings := []components.KeyBinding{
+		{kb.DisplayKeys(kb.ContextSnoozes, kb.ActionMoveUp, kb.ActionMoveDown), "Navigate"},
+		{kb.DisplayKeyWithSecondary(kb.ActionUnsnooze, kb.ContextSnoozes), "Unsnooze"},
+		{kb.DisplayKey(kb.ActionSnoozeAgenda, kb.ContextSnoozes), "Snooze agenda"},
+		{kb.DisplayKey(kb.ActionToggleHelp, kb.ContextSnoozes), "Help"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextSnoozes), "Return"},
+	}
+	footer := components.KeyBindingsBar(m.width, keyBindings)
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s", header, styles.FilterStatus.Render(summary), m.renderEntries(), footer)
+}
+
+// renderEntries renders the scrollable list of snoozes, with the whole agenda's snooze first
+func (m *SnoozesModel) renderEntries() string {
+	rowCount := m.rowCount()
+	if rowCount == 0 {
+		return styles.CenteredText(m.width, "Nothing is snoozed")
+	}
+
+	visibleCount := min(rowCount, max(1, m.height-12))
+	startIdx := 0
+	if m.cursor >= visibleCount {
+		startIdx = m.cursor - visibleCount + 1
+	}
+	endIdx := min(startIdx+visibleCount, rowCount)
+
+	selectedStyle := styles.Selected.
+		Width(m.width-4).
+		Padding(0, 1)
+
+	normalStyle := lipgloss.NewStyle().
+		Width(m.width-4).
+		Padding(0, 1)
+
+	titleWidth := 50
+	var listContent string
+	for i := startIdx; i < endIdx; i++ {
+		title, until := "The whole agenda", m.allUntil
+		if offset := rowCount - len(m.entries); i >= offset {
+			title, until = m.entries[i-offset].Title, m.entries[i-offset].Until
+		}
+		title = util.TruncateString(title, titleWidth)
+		title += strings.Repeat(" ", max(0, titleWidth-runewidth.StringWidth(title)))
+		itemText := fmt.Sprintf("%s  Until %s", title, until.Local().Format(snoozeTimeFormat))
+
+		if i == m.cursor {
+			listContent += selectedStyle.Render(itemText) + "\n"
+		} else {
+			listContent += normalStyle.Render(itemText) + "\n"
+		}
+	}
+
+	return styles.ContentBox(m.width-2, listContent, 1)
+}
+
+// Resize updates the dimensions of the model
+func (m *SnoozesModel) Resize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// snoozeMenu asks how long to snooze an anime in the airing agenda for, or the whole agenda if animeID is 0
+func snoozeMenu(animeID int, title string) tea.Cmd {
+	heading := "Snooze the whole agenda"
+	if animeID != 0 {
+		heading = "Snooze " + title + " in the agenda"
+	}
+
+	items := []MenuItem{{Text: heading, IsSeparator: true}}
+	for _, option := range []struct {
+		text   string
+		period service.SnoozePeriod
+	}{
+		{"For a day", service.SnoozeDay},
+		{"Until next week", service.SnoozeWeek},
+		{"Until next season", service.SnoozeSeason},
+	} {
+		period := option.period
+		items = append(items, MenuItem{
+			Text: option.text,
+			Command: func() tea.Msg {
+				return MenuSelectionMsg{
+					CloseMenu: true,
+					NextMsg:   SnoozeMsg{AnimeID: animeID, Period: period},
+				}
+			},
+		})
+	}
+	items = append(items, MenuItem{
+		Text: "Cancel",
+		Command: func() tea.Msg {
+			return MenuSelectionMsg{CloseMenu: true}
+		},
+	})
+
+	menuModel := NewMenuModel("Snooze", items)
+	return func() tea.Msg {
+		return ShowMenuMsg{Menu: menuModel}
+	}
+}
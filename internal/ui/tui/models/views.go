@@ -7,13 +7,34 @@ type View string
 
 // Available views in the application
 const (
-	ViewAuth          View = "auth"
-	ViewAnimeList     View = "anime-list"
-	ViewHelp          View = "help"
-	ViewEpisodeSelect View = "episode-select"
-	ViewLoading       View = "loading"
-	ViewAnimeDetails  View = "anime-details"
-	ViewMenu          View = "menu"
+	ViewAuth               View = "auth"
+	ViewAnimeList          View = "anime-list"
+	ViewHelp               View = "help"
+	ViewEpisodeSelect      View = "episode-select"
+	ViewLoading            View = "loading"
+	ViewAnimeDetails       View = "anime-details"
+	ViewMenu               View = "menu"
+	ViewWatchOrder         View = "watch-order"
+	ViewAPIUsage           View = "api-usage"
+	ViewCompletionBackfill View = "completion-backfill"
+	ViewListAudit          View = "list-audit"
+	ViewAgenda             View = "agenda"
+	ViewHiddenEntries      View = "hidden-entries"
+	ViewProfile            View = "profile"
+	ViewQuickPlay          View = "quick-play"
+	ViewExport             View = "export"
+	ViewBackups            View = "backups"
+	ViewAniListSearch      View = "anilist-search"
+	ViewReconcile          View = "reconcile"
+	ViewFocus              View = "focus"
+	ViewLocalImport        View = "local-import"
+	ViewSnoozes            View = "snoozes"
+	ViewNotes              View = "notes"
+	ViewMALImport          View = "mal-import"
+	ViewSeasonScores       View = "season-scores"
+	ViewSession            View = "session"
+	ViewContinueWatching   View = "continue-watching"
+	ViewPlaybackError      View = "playback-error"
 )
 
 // Model is the interface that all our models should implement
@@ -0,0 +1,196 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PizzaHomicide/hisame/internal/domain"
+	"github.com/PizzaHomicide/hisame/internal/service"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/components"
+	kb "github.com/PizzaHomicide/hisame/internal/ui/tui/keybindings"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/util"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// WatchOrderModel displays the recommended watch order for a franchise as a checklist against the user's progress
+type WatchOrderModel struct {
+	width, height int
+	title         string
+	entries       []service.WatchOrderEntry
+	cursor        int
+}
+
+// NewWatchOrderModel creates a new watch order model
+func NewWatchOrderModel(title string, entries []service.WatchOrderEntry) *WatchOrderModel {
+	return &WatchOrderModel{
+		title:   title,
+		entries: entries,
+	}
+}
+
+func (m *WatchOrderModel) ViewType() View {
+	return ViewWatchOrder
+}
+
+// Init initializes the model
+func (m *WatchOrderModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m *WatchOrderModel) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch kb.GetActionByKey(msg, kb.ContextWatchOrder) {
+		case kb.ActionMoveUp:
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, Handled("cursor_move:up")
+		case kb.ActionMoveDown:
+			if m.cursor < len(m.entries)-1 {
+				m.cursor++
+			}
+			return m, Handled("cursor_move:down")
+		case kb.ActionMoveTop:
+			m.cursor = 0
+			return m, Handled("cursor_move:top")
+		case kb.ActionMoveBottom:
+			m.cursor = max(0, len(m.entries)-1)
+			return m, Handled("cursor_move:bottom")
+		case kb.ActionViewAnimeDetails:
+			if m.cursor >= len(m.entries) {
+				return m, Handled("view_anime_details:none_selected")
+			}
+			anime := m.entries[m.cursor].Anime
+			return m, func() tea.Msg {
+				return AnimeDetailsMsg{Anime: anime}
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// View renders the watch order checklist
+func (m *WatchOrderModel) View() string {
+	header := styles.Header(m.width, "Watch Order - "+m.title)
+
+	watched := 0
+	for _, entry := range m.entries {
+		if entry.IsWatched() {
+			watched++
+		}
+	}
+	summary := styles.FilterStatus.Render(fmt.Sprintf("Watched %d of %d entries in this franchise", watched, len(m.entries)))
+
+	keyBindings := []components.KeyBinding{
+		{kb.DisplayKeys(kb.ContextWatchOrder, kb.ActionMoveUp, kb.ActionMoveDown), "Navigate"},
+		{kb.DisplayKey(kb.ActionViewAnimeDetails, kb.ContextWatchOrder), "Details"},
+		{kb.DisplayKey(kb.ActionToggleHelp, kb.ContextWatchOrder), "Help"},
+		{kb.DisplayKey(kb.ActionBack, kb.ContextWatchOrder), "Return"},
+	}
+	footer := components.KeyBindingsBar(m.width, keyBindings)
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s", header, summary, m.renderEntries(), footer)
+}
+
+// renderEntries renders the ordered checklist
+func (m *WatchOrderModel) renderEntries() string {
+	if len(m.entries) == 0 {
+		return styles.CenteredText(m.width, "No related entries found")
+	}
+
+	availableHeight := m.height - 12
+	if availableHeight < 1 {
+		availableHeight = 1
+	}
+	visibleCount := min(len(m.entries), availableHeight)
+
+	startIdx := 0
+	if m.cursor >= visibleCount {
+		startIdx = m.cursor - visibleCount + 1
+	}
+	endIdx := min(startIdx+visibleCount, len(m.entries))
+
+	selectedStyle := styles.Selected.
+		Width(m.width-4).
+		Padding(0, 1)
+
+	normalStyle := lipgloss.NewStyle().
+		Width(m.width-4).
+		Padding(0, 1)
+
+	var listContent string
+	for i := startIdx; i < endIdx; i++ {
+		itemText := m.formatEntry(i+1, m.entries[i])
+		if i == m.cursor {
+			listContent += selectedStyle.Render(itemText) + "\n"
+		} else {
+			listContent += normalStyle.Render(itemText) + "\n"
+		}
+	}
+
+	return styles.ContentBox(m.width-2, listContent, 1)
+}
+
+// formatEntry formats a single watch order step
+func (m *WatchOrderModel) formatEntry(position int, entry service.WatchOrderEntry) string {
+	anime := entry.Anime
+
+	check := "[ ]"
+	progress := "Not on list"
+	if entry.IsWatched() {
+		check = "[x]"
+	}
+	if anime.UserData != nil {
+		if anime.UserData.Progress > 0 && !entry.IsWatched() {
+			check = "[~]"
+		}
+		episodes := "?"
+		if anime.Episodes > 0 {
+			episodes = fmt.Sprintf("%d", anime.Episodes)
+		}
+		progress = fmt.Sprintf("%d/%s %s", anime.UserData.Progress, episodes, statusLabel(anime.UserData.Status))
+	}
+
+	season := ""
+	if anime.Season != "" && anime.SeasonYear != "" && anime.SeasonYear != "0" {
+		season = fmt.Sprintf("%s %s", anime.Season, anime.SeasonYear)
+	}
+
+	titleWidth := 60
+	truncatedTitle := util.TruncateString(anime.Title.Preferred, titleWidth)
+	paddedTitle := truncatedTitle + strings.Repeat(" ", max(0, titleWidth-runewidth.StringWidth(truncatedTitle)))
+
+	return fmt.Sprintf("%2d. %s %s %8s %-12s %s", position, check, paddedTitle, anime.Format, season, progress)
+}
+
+// statusLabel returns a user-friendly label for a list status
+func statusLabel(status domain.MediaStatus) string {
+	switch status {
+	case domain.StatusCurrent:
+		return "Watching"
+	case domain.StatusPlanning:
+		return "Planning"
+	case domain.StatusCompleted:
+		return "Completed"
+	case domain.StatusDropped:
+		return "Dropped"
+	case domain.StatusPaused:
+		return "Paused"
+	case domain.StatusRepeating:
+		return "Repeating"
+	default:
+		return "Unknown"
+	}
+}
+
+// Resize updates the dimensions of the model
+func (m *WatchOrderModel) Resize(width, height int) {
+	m.width = width
+	m.height = height
+}
@@ -1,32 +1,113 @@
 package styles
 
 import (
+	"os"
+
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Colors adapt to the terminal's background, using the Light variant on light backgrounds and Dark on dark ones
+var (
+	ColorAccent     lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#5A36D6", Dark: "#7D56F4"}
+	ColorAccentSoft lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#7D56F4", Dark: "#9D86FF"}
+	ColorText       lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#1A1A1A", Dark: "#FFFFFF"}
+	ColorInfo       lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#333333", Dark: "#DEDEDE"}
+	ColorSubtle     lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#4A4A4A", Dark: "#CCCCCC"}
+	ColorMuted      lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#5C5C5C", Dark: "#AAAAAA"}
+	ColorFaint      lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#6E6E6E", Dark: "#888888"}
+	ColorBorder     lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#B0B0B0", Dark: "#555555"}
+	ColorSuccess    lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#1E7B3A", Dark: "#43BF6D"}
+	ColorWarning    lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#B35C00", Dark: "#FFB86C"}
+	ColorError      lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#C4161C", Dark: "#FF5555"}
+
+	// ColorOnAccent is for text on an accent background
+	ColorOnAccent lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#FAFAFA"}
 )
 
+// plain is true when colors are turned off.  Styles that stand out by their background, such as the selected row,
+// use reverse video instead.
+var plain bool
+
+// Text styles
 var (
-	// Text styles
+	Title        lipgloss.Style
+	Selected     lipgloss.Style // Highlights the row under the cursor
+	Info         lipgloss.Style
+	Url          lipgloss.Style
+	FilterStatus lipgloss.Style
+	KeyStyle     lipgloss.Style
+	Warning      lipgloss.Style
+	Error        lipgloss.Style
+)
+
+func init() {
+	buildStyles()
+}
+
+// buildStyles creates the text styles from the colors
+func buildStyles() {
 	Title = lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#FAFAFA")).
-		Background(lipgloss.Color("#7D56F4")).
+		Foreground(ColorOnAccent).
+		Background(ColorAccent).
+		Reverse(plain).
 		Padding(0, 1)
 
+	Selected = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorOnAccent).
+		Background(ColorAccent).
+		Reverse(plain)
+
 	Info = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#DEDEDE"))
+		Foreground(ColorInfo)
 
 	Url = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#43BF6D")).
+		Foreground(ColorSuccess).
 		Underline(true)
 
 	FilterStatus = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#CCCCCC")).
-			Padding(0, 2)
+		Foreground(ColorSubtle).
+		Padding(0, 2)
 
 	KeyStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#7D56F4")).
-			Bold(true)
-)
+		Foreground(ColorAccent).
+		Bold(true)
+
+	Warning = lipgloss.NewStyle().
+		Foreground(ColorWarning).
+		Padding(0, 2)
+
+	Error = lipgloss.NewStyle().
+		Foreground(ColorError).
+		Padding(0, 2)
+}
+
+// Configure sets how colors are rendered from the ui.color setting: "auto" detects whether the terminal has a light
+// or dark background, "light" and "dark" say which it has, and "none" renders plain text, as does setting NO_COLOR.
+// It must be called before anything is rendered.
+func Configure(mode string) {
+	switch mode {
+	case "light":
+		lipgloss.SetHasDarkBackground(false)
+	case "dark":
+		lipgloss.SetHasDarkBackground(true)
+	}
+	if mode != "none" && os.Getenv("NO_COLOR") == "" {
+		return
+	}
+
+	plain = true
+	for _, color := range []*lipgloss.TerminalColor{&ColorAccent, &ColorAccentSoft, &ColorOnAccent, &ColorText,
+		&ColorInfo, &ColorSubtle, &ColorMuted, &ColorFaint, &ColorBorder, &ColorSuccess, &ColorWarning, &ColorError} {
+		*color = lipgloss.NoColor{}
+	}
+	// Lipgloss drops bold and reverse video along with colors when NO_COLOR is set, so the profile is picked without
+	// it.  No colors are left to render.
+	lipgloss.SetColorProfile(termenv.NewOutput(os.Stdout).ColorProfile())
+	buildStyles()
+}
 
 // Layout helpers
 func Header(width int, title string) string {
@@ -41,7 +122,7 @@ func ContentBox(width int, content string, padding int) string {
 		Width(width).
 		Padding(padding).
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#555555")).
+		BorderForeground(ColorBorder).
 		Render(content)
 }
 
@@ -0,0 +1,19 @@
+package styles
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigurePlain(t *testing.T) {
+	assert.False(t, Selected.GetReverse())
+
+	Configure("none")
+
+	assert.Equal(t, lipgloss.NoColor{}, ColorAccent)
+	assert.Equal(t, lipgloss.NoColor{}, Selected.GetBackground())
+	assert.True(t, Selected.GetReverse(), "the selected row should stand out without colors")
+	assert.True(t, Title.GetReverse())
+}
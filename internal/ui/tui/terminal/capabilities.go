@@ -0,0 +1,157 @@
+package terminal
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Capability is a terminal feature Hisame can make use of, and why it was or wasn't detected
+type Capability struct {
+	Name      string
+	Supported bool
+	Reason    string
+}
+
+// Capabilities are the features detected in the terminal Hisame is running in
+type Capabilities struct {
+	TrueColor Capability // 24-bit colour, without which colours are approximated from the 256 colour palette
+	Images    Capability // An inline image protocol (kitty or iTerm2)
+	Mouse     Capability // Mouse reporting
+	Clipboard Capability // Setting the clipboard with OSC 52
+	Progress  Capability // Taskbar progress with OSC 9;4
+}
+
+// List returns the capabilities in the order they are shown
+func (c Capabilities) List() []Capability {
+	return []Capability{c.TrueColor, c.Images, c.Mouse, c.Clipboard, c.Progress}
+}
+
+// Summary describes the capabilities on one line, e.g. "truecolor ✓  images ✗ (inside tmux)"
+func (c Capabilities) Summary() string {
+	parts := make([]string, 0, len(c.List()))
+	for _, capability := range c.List() {
+		if capability.Supported {
+			parts = append(parts, capability.Name+" ✓")
+		} else {
+			parts = append(parts, fmt.Sprintf("%s ✗ (%s)", capability.Name, capability.Reason))
+		}
+	}
+	return strings.Join(parts, "  ")
+}
+
+// detected holds the capabilities found by Detect
+var detected Capabilities
+
+// Detect inspects the environment for the terminal's capabilities, which are then returned by Detected
+func Detect() Capabilities {
+	mu.Lock()
+	defer mu.Unlock()
+
+	detected = DetectCapabilities(os.Getenv)
+	return detected
+}
+
+// Detected returns the capabilities found by the last call to Detect
+func Detected() Capabilities {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return detected
+}
+
+// terminalID names the terminal emulator from the variables it sets, or returns "" if it isn't recognised
+func terminalID(getenv func(string) string) string {
+	switch {
+	case getenv("KITTY_WINDOW_ID") != "" || getenv("TERM") == "xterm-kitty":
+		return "kitty"
+	case getenv("WEZTERM_EXECUTABLE") != "" || getenv("TERM_PROGRAM") == "WezTerm":
+		return "WezTerm"
+	case getenv("TERM_PROGRAM") == "iTerm.app":
+		return "iTerm2"
+	case getenv("WT_SESSION") != "":
+		return "Windows Terminal"
+	case getenv("ConEmuANSI") == "ON":
+		return "ConEmu"
+	case getenv("ALACRITTY_WINDOW_ID") != "" || getenv("TERM") == "alacritty":
+		return "Alacritty"
+	case strings.HasPrefix(getenv("TERM"), "foot"):
+		return "foot"
+	case getenv("TERM_PROGRAM") == "Apple_Terminal":
+		return "Terminal.app"
+	case getenv("VTE_VERSION") != "":
+		return "VTE"
+	}
+	return ""
+}
+
+// DetectCapabilities works out the terminal's capabilities from its environment variables.  Terminals can't be asked
+// about most of these without a round trip that would delay startup, so detection goes by what each known terminal
+// supports, and assumes the feature is missing when the terminal isn't recognised.
+func DetectCapabilities(getenv func(string) string) Capabilities {
+	term := getenv("TERM")
+	id := terminalID(getenv)
+	multiplexed := getenv("TMUX") != "" || strings.HasPrefix(term, "screen")
+
+	unsupported := func(name, reason string) Capability {
+		return Capability{Name: name, Reason: reason}
+	}
+	supported := func(name, reason string) Capability {
+		return Capability{Name: name, Supported: true, Reason: reason}
+	}
+	unknownTerminal := "terminal not known to support it"
+
+	var caps Capabilities
+
+	colorTerm := strings.ToLower(getenv("COLORTERM"))
+	switch {
+	case colorTerm == "truecolor" || colorTerm == "24bit":
+		caps.TrueColor = supported("truecolor", "COLORTERM="+colorTerm)
+	case strings.HasSuffix(term, "-direct"):
+		caps.TrueColor = supported("truecolor", "TERM="+term)
+	case id == "Windows Terminal" || id == "kitty" || id == "WezTerm":
+		caps.TrueColor = supported("truecolor", id)
+	default:
+		caps.TrueColor = unsupported("truecolor", "COLORTERM not set")
+	}
+
+	switch {
+	case multiplexed:
+		caps.Images = unsupported("images", "inside tmux or screen")
+	case id == "kitty" || id == "WezTerm" || id == "iTerm2":
+		caps.Images = supported("images", id)
+	default:
+		caps.Images = unsupported("images", unknownTerminal)
+	}
+
+	switch term {
+	case "":
+		caps.Mouse = unsupported("mouse", "TERM not set")
+	case "dumb":
+		caps.Mouse = unsupported("mouse", "dumb terminal")
+	case "linux":
+		caps.Mouse = unsupported("mouse", "Linux console")
+	default:
+		caps.Mouse = supported("mouse", "TERM="+term)
+	}
+
+	switch {
+	case id == "Terminal.app":
+		caps.Clipboard = unsupported("clipboard", "Terminal.app ignores OSC 52")
+	case multiplexed:
+		caps.Clipboard = unsupported("clipboard", "inside tmux or screen")
+	case id != "" && id != "VTE" && id != "ConEmu":
+		caps.Clipboard = supported("clipboard", id)
+	default:
+		caps.Clipboard = unsupported("clipboard", unknownTerminal)
+	}
+
+	// Other terminals, such as iTerm2, treat OSC 9 as a notification
+	if id == "Windows Terminal" || id == "ConEmu" {
+		caps.Progress = supported("taskbar progress", id)
+	} else {
+		caps.Progress = unsupported("taskbar progress", "only Windows Terminal and ConEmu")
+	}
+
+	return caps
+}
@@ -0,0 +1,63 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// env returns a getenv function that reads from the given variables
+func env(vars map[string]string) func(string) string {
+	return func(name string) string {
+		return vars[name]
+	}
+}
+
+func TestDetectCapabilitiesKitty(t *testing.T) {
+	caps := DetectCapabilities(env(map[string]string{"TERM": "xterm-kitty", "KITTY_WINDOW_ID": "1"}))
+
+	assert.True(t, caps.TrueColor.Supported)
+	assert.True(t, caps.Images.Supported)
+	assert.True(t, caps.Mouse.Supported)
+	assert.True(t, caps.Clipboard.Supported)
+	assert.False(t, caps.Progress.Supported)
+}
+
+func TestDetectCapabilitiesInsideTmux(t *testing.T) {
+	caps := DetectCapabilities(env(map[string]string{
+		"TERM":            "tmux-256color",
+		"TMUX":            "/tmp/tmux-1000/default,1234,0",
+		"COLORTERM":       "truecolor",
+		"KITTY_WINDOW_ID": "1",
+	}))
+
+	assert.True(t, caps.TrueColor.Supported)
+	assert.False(t, caps.Images.Supported)
+	assert.Equal(t, "inside tmux or screen", caps.Images.Reason)
+	assert.False(t, caps.Clipboard.Supported)
+}
+
+func TestDetectCapabilitiesWindowsTerminal(t *testing.T) {
+	caps := DetectCapabilities(env(map[string]string{"WT_SESSION": "abc"}))
+
+	assert.True(t, caps.TrueColor.Supported)
+	assert.True(t, caps.Progress.Supported)
+	assert.False(t, caps.Images.Supported)
+}
+
+func TestDetectCapabilitiesDumbTerminal(t *testing.T) {
+	caps := DetectCapabilities(env(map[string]string{"TERM": "dumb"}))
+
+	for _, capability := range caps.List() {
+		assert.False(t, capability.Supported, capability.Name)
+		assert.NotEmpty(t, capability.Reason, capability.Name)
+	}
+}
+
+func TestCapabilitiesSummary(t *testing.T) {
+	caps := DetectCapabilities(env(map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}))
+
+	assert.Equal(t, "truecolor ✓  images ✗ (terminal not known to support it)  mouse ✓  "+
+		"clipboard ✗ (terminal not known to support it)  taskbar progress ✗ (only Windows Terminal and ConEmu)",
+		caps.Summary())
+}
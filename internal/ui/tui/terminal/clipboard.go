@@ -0,0 +1,62 @@
+package terminal
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// clipboardCommands returns the programs that set the system clipboard from their input, in the order they are tried
+var clipboardCommands = func() [][]string {
+	switch runtime.GOOS {
+	case "darwin":
+		return [][]string{{"pbcopy"}}
+	case "windows":
+		return [][]string{{"clip"}}
+	}
+	var commands [][]string
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		commands = append(commands, []string{"wl-copy"})
+	}
+	if os.Getenv("DISPLAY") != "" {
+		commands = append(commands, []string{"xclip", "-selection", "clipboard"}, []string{"xsel", "--clipboard", "--input"})
+	}
+	return commands
+}
+
+// CopyToClipboard puts the text on the clipboard.  It is sent to the terminal with OSC 52, which also reaches the
+// clipboard of the machine the terminal runs on over SSH, and given to the platform's clipboard program, as not every
+// terminal supports OSC 52.  An error is only returned if neither is known to have worked.
+func CopyToClipboard(text string) error {
+	mu.Lock()
+	_, _ = fmt.Fprint(out, clipboardSequence(text))
+	mu.Unlock()
+
+	var errs []error
+	for _, command := range clipboardCommands() {
+		cmd := exec.Command(command[0], command[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", command[0], err))
+			continue
+		}
+		return nil
+	}
+
+	if Detected().Clipboard.Supported {
+		return nil
+	}
+	if len(errs) == 0 {
+		return errors.New("the terminal doesn't support OSC 52 and no clipboard program is available")
+	}
+	return fmt.Errorf("the terminal doesn't support OSC 52 and no clipboard program worked: %w", errors.Join(errs...))
+}
+
+// clipboardSequence builds the OSC 52 escape sequence that sets the clipboard to the text
+func clipboardSequence(text string) string {
+	return "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\x07"
+}
@@ -0,0 +1,30 @@
+package terminal
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopyToClipboard(t *testing.T) {
+	var buf bytes.Buffer
+	out = &buf
+	defer func(commands func() [][]string) { clipboardCommands = commands }(clipboardCommands)
+
+	clipboardCommands = func() [][]string { return [][]string{{"true"}} }
+	assert.NoError(t, CopyToClipboard("https://example.com/ep.m3u8"))
+	assert.Equal(t, "\x1b]52;c;aHR0cHM6Ly9leGFtcGxlLmNvbS9lcC5tM3U4\x07", buf.String(),
+		"the terminal should be sent the text even when a clipboard program is used")
+
+	detected = Capabilities{}
+	clipboardCommands = func() [][]string { return [][]string{{"false"}} }
+	assert.ErrorContains(t, CopyToClipboard("text"), "no clipboard program worked")
+
+	clipboardCommands = func() [][]string { return nil }
+	assert.ErrorContains(t, CopyToClipboard("text"), "no clipboard program is available")
+
+	detected = Capabilities{Clipboard: Capability{Name: "clipboard", Supported: true}}
+	defer func() { detected = Capabilities{} }()
+	assert.NoError(t, CopyToClipboard("text"), "OSC 52 is enough in a terminal that supports it")
+}
@@ -0,0 +1,78 @@
+// Package terminal contains integrations with the terminal emulator Hisame is running in, beyond what bubbletea
+// provides.
+package terminal
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// progressState is the state parameter of the OSC 9;4 progress sequence
+type progressState int
+
+const (
+	progressClear         progressState = 0
+	progressNormal        progressState = 1
+	progressError         progressState = 2
+	progressIndeterminate progressState = 3
+)
+
+var (
+	mu      sync.Mutex
+	enabled bool
+	out     io.Writer = os.Stdout
+)
+
+// ConfigureProgress enables or disables taskbar progress reporting.  Mode is one of "auto", "on" or "off".  Auto only
+// enables reporting in terminals known to support OSC 9;4 (Windows Terminal and ConEmu), as other terminals such as
+// iTerm2 treat OSC 9 as a notification.
+func ConfigureProgress(mode string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	switch mode {
+	case "on":
+		enabled = true
+	case "off":
+		enabled = false
+	default:
+		enabled = DetectCapabilities(os.Getenv).Progress.Supported
+	}
+}
+
+// SetProgress shows a determinate progress value between 0 and 100
+func SetProgress(percent int) {
+	writeProgress(progressNormal, min(max(percent, 0), 100))
+}
+
+// SetIndeterminate shows a busy indicator for operations with no known progress
+func SetIndeterminate() {
+	writeProgress(progressIndeterminate, 0)
+}
+
+// SetError shows the progress indicator in an error state
+func SetError() {
+	writeProgress(progressError, 100)
+}
+
+// ClearProgress removes any progress indicator
+func ClearProgress() {
+	writeProgress(progressClear, 0)
+}
+
+func writeProgress(state progressState, percent int) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if !enabled {
+		return
+	}
+	_, _ = fmt.Fprint(out, progressSequence(state, percent))
+}
+
+// progressSequence builds the OSC 9;4 escape sequence for the given state
+func progressSequence(state progressState, percent int) string {
+	return fmt.Sprintf("\x1b]9;4;%d;%d\x07", state, percent)
+}
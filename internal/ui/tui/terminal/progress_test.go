@@ -0,0 +1,39 @@
+package terminal
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressSequences(t *testing.T) {
+	var buf bytes.Buffer
+	out = &buf
+	ConfigureProgress("on")
+	defer ConfigureProgress("off")
+
+	SetProgress(42)
+	assert.Equal(t, "\x1b]9;4;1;42\x07", buf.String())
+
+	buf.Reset()
+	SetProgress(250)
+	assert.Equal(t, "\x1b]9;4;1;100\x07", buf.String())
+
+	buf.Reset()
+	SetIndeterminate()
+	assert.Equal(t, "\x1b]9;4;3;0\x07", buf.String())
+
+	buf.Reset()
+	ClearProgress()
+	assert.Equal(t, "\x1b]9;4;0;0\x07", buf.String())
+}
+
+func TestProgressDisabled(t *testing.T) {
+	var buf bytes.Buffer
+	out = &buf
+	ConfigureProgress("off")
+
+	SetProgress(42)
+	assert.Empty(t, buf.String())
+}
@@ -2,11 +2,26 @@ package tui
 
 import (
 	"github.com/PizzaHomicide/hisame/internal/config"
+	"github.com/PizzaHomicide/hisame/internal/log"
+	"github.com/PizzaHomicide/hisame/internal/mpris"
 	"github.com/PizzaHomicide/hisame/internal/ui/tui/models"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/styles"
+	"github.com/PizzaHomicide/hisame/internal/ui/tui/terminal"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func Run(cfg *config.Config) error {
+	caps := terminal.Detect()
+	log.Info("Detected terminal capabilities", "summary", caps.Summary())
+	terminal.ConfigureProgress(cfg.UI.TaskbarProgress)
+	styles.Configure(cfg.UI.Color)
+	defer terminal.ClearProgress()
+
+	if cfg.Player.MPRIS != "off" {
+		mpris.Start()
+		defer mpris.Close()
+	}
+
 	p := tea.NewProgram(models.NewAppModel(cfg), tea.WithAltScreen())
 	_, err := p.Run()
 	return err
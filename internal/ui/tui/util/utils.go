@@ -2,6 +2,7 @@ package util
 
 import (
 	"fmt"
+	"github.com/PizzaHomicide/hisame/internal/log"
 	"github.com/mattn/go-runewidth"
 	"time"
 )
@@ -33,3 +34,34 @@ func FormatTimeUntilAiring(seconds int64) string {
 	// Format with consistent spacing:
 	return fmt.Sprintf("%3dd %02dh %02dm", days, hours, minutes)
 }
+
+// FormatAiringTime formats a unix airing timestamp as an absolute weekday and time in the given location, e.g. "Sat 22:30"
+func FormatAiringTime(airingAt int64, loc *time.Location) string {
+	if loc == nil {
+		loc = time.Local
+	}
+	return time.Unix(airingAt, 0).In(loc).Format("Mon 15:04")
+}
+
+// FormatAiringDate formats a unix airing timestamp as an absolute date and time in the given location, e.g.
+// "Sat 12 Jul 22:30", for air times more than a week away
+func FormatAiringDate(airingAt int64, loc *time.Location) string {
+	if loc == nil {
+		loc = time.Local
+	}
+	return time.Unix(airingAt, 0).In(loc).Format("Mon 2 Jan 15:04")
+}
+
+// ResolveLocation returns the timezone for the given IANA name, falling back to the system timezone if the name is
+// empty or cannot be loaded
+func ResolveLocation(name string) *time.Location {
+	if name == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		log.Warn("Unable to load configured timezone, falling back to system timezone", "timezone", name, "error", err)
+		return time.Local
+	}
+	return loc
+}
@@ -0,0 +1,26 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatAiringTime(t *testing.T) {
+	// 2025-04-05 13:30 UTC is a Saturday
+	airingAt := time.Date(2025, 4, 5, 13, 30, 0, 0, time.UTC).Unix()
+
+	assert.Equal(t, "Sat 13:30", FormatAiringTime(airingAt, time.UTC))
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	assert.Equal(t, "Sat 22:30", FormatAiringTime(airingAt, tokyo))
+}
+
+func TestResolveLocation(t *testing.T) {
+	assert.Equal(t, time.Local, ResolveLocation(""))
+	assert.Equal(t, time.Local, ResolveLocation("Not/A_Zone"))
+}